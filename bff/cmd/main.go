@@ -152,7 +152,7 @@ func main() {
 		}
 
 		rows, err := db.Query(`
-			SELECT p.id, p.name, p.description, p.price, p.stock_quantity, 
+			SELECT p.id, p.name, p.description, p.price_minor, p.currency, p.stock_quantity, 
 			       p.image_url, p.sku, c.name as category_name
 			FROM catalog_service.products p
 			LEFT JOIN catalog_service.categories c ON p.category_id = c.id
@@ -170,10 +170,11 @@ func main() {
 		for rows.Next() {
 			var id, name, sku, categoryName, imageUrl string
 			var description sql.NullString
-			var price float64
+			var currency string
+			var price int64 // centavos (unidades menores de currency), como no catalog-service
 			var stock int
 
-			if err := rows.Scan(&id, &name, &description, &price, &stock, &imageUrl, &sku, &categoryName); err != nil {
+			if err := rows.Scan(&id, &name, &description, &price, &currency, &stock, &imageUrl, &sku, &categoryName); err != nil {
 				log.Printf("Erro ao ler produto: %v", err)
 				continue
 			}
@@ -183,6 +184,7 @@ func main() {
 				"name":        name,
 				"description": description.String,
 				"price":       price,
+				"currency":    currency,
 				"stock":       stock,
 				"image_url":   imageUrl,
 				"sku":         sku,
@@ -3,8 +3,8 @@ import { ApolloClient, InMemoryCache, createHttpLink } from '@apollo/client';
 // Configure HTTP Link para conectar com o BFF GraphQL
 const httpLink = createHttpLink({
   uri: import.meta.env.PROD 
-    ? 'http://localhost:8080/graphql' // Em produção, conecta ao BFF na rede do Docker
-    : 'http://localhost:8080/graphql', // Em desenvolvimento, conecta localmente
+    ? 'http://localhost:8086/graphql' // Em produção, conecta ao BFF na rede do Docker
+    : 'http://localhost:8086/graphql', // Em desenvolvimento, conecta localmente
   credentials: 'same-origin', // Include cookies if needed
 });
 
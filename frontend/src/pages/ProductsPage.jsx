@@ -33,7 +33,7 @@ const ProductsPage = () => {
 
       // Calcular estatísticas
       const total = data.length;
-      const totalValue = data.reduce((sum, p) => sum + (p.price * p.stock), 0);
+      const totalValue = data.reduce((sum, p) => sum + (toMajor(p.price, p.currency) * p.stock), 0);
       const categories = [...new Set(data.map(p => p.category))].length;
       const lowStock = data.filter(p => p.stock < 50).length;
 
@@ -45,13 +45,22 @@ const ProductsPage = () => {
     }
   };
 
-  const formatCurrency = (value) => {
+  const formatCurrency = (value, currency = 'BRL') => {
     return new Intl.NumberFormat('pt-BR', {
       style: 'currency',
-      currency: 'BRL'
+      currency
     }).format(value);
   };
 
+  // Os preços chegam em unidades menores (centavos) da moeda do produto
+  const toMajor = (minor, currency = 'BRL') => {
+    const { maximumFractionDigits } = new Intl.NumberFormat('pt-BR', {
+      style: 'currency',
+      currency
+    }).resolvedOptions();
+    return minor / 10 ** maximumFractionDigits;
+  };
+
   const getStockBadgeVariant = (stock) => {
     if (stock === 0) return 'destructive';
     if (stock < 50) return 'warning';
@@ -206,7 +215,7 @@ const ProductsPage = () => {
                     <div className="flex items-center justify-between">
                       <div>
                         <p className="text-2xl font-bold text-green-600">
-                          {formatCurrency(product.price)}
+                          {formatCurrency(toMajor(product.price, product.currency), product.currency)}
                         </p>
                         <p className="text-xs text-gray-500">SKU: {product.sku}</p>
                       </div>
@@ -61,17 +61,19 @@ CREATE TABLE IF NOT EXISTS products (
     category_id VARCHAR(36) NOT NULL,
     name VARCHAR(255) NOT NULL,
     description TEXT,
-    price DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'BRL',
+    price_minor BIGINT NOT NULL DEFAULT 0,
     stock_quantity INT NOT NULL DEFAULT 0,
     image_url VARCHAR(500),
     sku VARCHAR(100) UNIQUE,
+    tax_class VARCHAR(30) NOT NULL DEFAULT 'standard',
     is_active BOOLEAN DEFAULT TRUE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (category_id) REFERENCES categories(id),
     INDEX idx_category (category_id),
     INDEX idx_name (name),
-    INDEX idx_price (price),
+    INDEX idx_price_minor (price_minor),
     INDEX idx_active (is_active)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
@@ -87,50 +89,101 @@ INSERT INTO categories (id, name, description) VALUES
 ('cat-008', 'Brinquedos', 'Brinquedos e jogos');
 
 -- Insert sample products
-INSERT INTO products (id, category_id, name, description, price, stock_quantity, image_url, sku, is_active) VALUES
+INSERT INTO products (id, category_id, name, description, price_minor, stock_quantity, image_url, sku, is_active) VALUES
 -- Eletrônicos
-('prod-001', 'cat-001', 'Smartphone XYZ Pro', 'Smartphone de última geração com câmera tripla de 108MP', 2999.99, 50, 'https://via.placeholder.com/300', 'SMART-XYZ-001', TRUE),
-('prod-002', 'cat-001', 'Notebook Ultra 15"', 'Notebook com processador i7, 16GB RAM, SSD 512GB', 4599.90, 30, 'https://via.placeholder.com/300', 'NOTE-ULT-002', TRUE),
-('prod-003', 'cat-001', 'Fone Bluetooth Premium', 'Fone de ouvido com cancelamento de ruído ativo', 599.00, 100, 'https://via.placeholder.com/300', 'FONE-BT-003', TRUE),
-('prod-004', 'cat-001', 'Smart Watch Fit', 'Relógio inteligente com monitor cardíaco e GPS', 899.90, 75, 'https://via.placeholder.com/300', 'WATCH-FIT-004', TRUE),
-('prod-005', 'cat-001', 'Tablet 10" WiFi', 'Tablet Android com tela Full HD e 128GB', 1299.00, 40, 'https://via.placeholder.com/300', 'TAB-10-005', TRUE),
+('prod-001', 'cat-001', 'Smartphone XYZ Pro', 'Smartphone de última geração com câmera tripla de 108MP', 299999, 50, 'https://via.placeholder.com/300', 'SMART-XYZ-001', TRUE),
+('prod-002', 'cat-001', 'Notebook Ultra 15"', 'Notebook com processador i7, 16GB RAM, SSD 512GB', 459990, 30, 'https://via.placeholder.com/300', 'NOTE-ULT-002', TRUE),
+('prod-003', 'cat-001', 'Fone Bluetooth Premium', 'Fone de ouvido com cancelamento de ruído ativo', 59900, 100, 'https://via.placeholder.com/300', 'FONE-BT-003', TRUE),
+('prod-004', 'cat-001', 'Smart Watch Fit', 'Relógio inteligente com monitor cardíaco e GPS', 89990, 75, 'https://via.placeholder.com/300', 'WATCH-FIT-004', TRUE),
+('prod-005', 'cat-001', 'Tablet 10" WiFi', 'Tablet Android com tela Full HD e 128GB', 129900, 40, 'https://via.placeholder.com/300', 'TAB-10-005', TRUE),
 
 -- Livros
-('prod-006', 'cat-002', 'Clean Code - Robert Martin', 'Guia essencial para escrever código limpo', 89.90, 200, 'https://via.placeholder.com/300', 'BOOK-CC-006', TRUE),
-('prod-007', 'cat-002', 'Domain-Driven Design', 'Atacando as complexidades no coração do software', 95.00, 150, 'https://via.placeholder.com/300', 'BOOK-DDD-007', TRUE),
-('prod-008', 'cat-002', 'The Pragmatic Programmer', 'Seu caminho para a maestria', 79.90, 180, 'https://via.placeholder.com/300', 'BOOK-PP-008', TRUE),
-('prod-009', 'cat-002', 'Design Patterns - GoF', 'Padrões de projeto reutilizáveis', 110.00, 120, 'https://via.placeholder.com/300', 'BOOK-DP-009', TRUE),
-('prod-010', 'cat-002', 'Microservices Patterns', 'Padrões de microsserviços com exemplos', 115.90, 90, 'https://via.placeholder.com/300', 'BOOK-MS-010', TRUE),
+('prod-006', 'cat-002', 'Clean Code - Robert Martin', 'Guia essencial para escrever código limpo', 8990, 200, 'https://via.placeholder.com/300', 'BOOK-CC-006', TRUE),
+('prod-007', 'cat-002', 'Domain-Driven Design', 'Atacando as complexidades no coração do software', 9500, 150, 'https://via.placeholder.com/300', 'BOOK-DDD-007', TRUE),
+('prod-008', 'cat-002', 'The Pragmatic Programmer', 'Seu caminho para a maestria', 7990, 180, 'https://via.placeholder.com/300', 'BOOK-PP-008', TRUE),
+('prod-009', 'cat-002', 'Design Patterns - GoF', 'Padrões de projeto reutilizáveis', 11000, 120, 'https://via.placeholder.com/300', 'BOOK-DP-009', TRUE),
+('prod-010', 'cat-002', 'Microservices Patterns', 'Padrões de microsserviços com exemplos', 11590, 90, 'https://via.placeholder.com/300', 'BOOK-MS-010', TRUE),
 
 -- Roupas
-('prod-011', 'cat-003', 'Camiseta Básica - Preta', 'Camiseta 100% algodão tamanho M', 49.90, 500, 'https://via.placeholder.com/300', 'CAM-BAS-011', TRUE),
-('prod-012', 'cat-003', 'Calça Jeans Slim', 'Calça jeans masculina corte slim', 159.90, 200, 'https://via.placeholder.com/300', 'CALCA-JS-012', TRUE),
-('prod-013', 'cat-003', 'Jaqueta de Couro', 'Jaqueta de couro legítimo preta', 599.00, 80, 'https://via.placeholder.com/300', 'JAQ-COU-013', TRUE),
-('prod-014', 'cat-003', 'Tênis Esportivo Pro', 'Tênis para corrida com amortecimento', 349.90, 150, 'https://via.placeholder.com/300', 'TEN-ESP-014', TRUE),
+('prod-011', 'cat-003', 'Camiseta Básica - Preta', 'Camiseta 100% algodão tamanho M', 4990, 500, 'https://via.placeholder.com/300', 'CAM-BAS-011', TRUE),
+('prod-012', 'cat-003', 'Calça Jeans Slim', 'Calça jeans masculina corte slim', 15990, 200, 'https://via.placeholder.com/300', 'CALCA-JS-012', TRUE),
+('prod-013', 'cat-003', 'Jaqueta de Couro', 'Jaqueta de couro legítimo preta', 59900, 80, 'https://via.placeholder.com/300', 'JAQ-COU-013', TRUE),
+('prod-014', 'cat-003', 'Tênis Esportivo Pro', 'Tênis para corrida com amortecimento', 34990, 150, 'https://via.placeholder.com/300', 'TEN-ESP-014', TRUE),
 
 -- Casa e Decoração
-('prod-015', 'cat-004', 'Luminária LED Moderna', 'Luminária de mesa com controle de intensidade', 129.90, 60, 'https://via.placeholder.com/300', 'LUM-LED-015', TRUE),
-('prod-016', 'cat-004', 'Quadro Decorativo Abstrato', 'Quadro 60x80cm com moldura', 189.00, 45, 'https://via.placeholder.com/300', 'QUA-ABS-016', TRUE),
-('prod-017', 'cat-004', 'Jogo de Cama Casal', 'Jogo de cama 100% algodão 4 peças', 199.90, 100, 'https://via.placeholder.com/300', 'CAMA-JG-017', TRUE),
+('prod-015', 'cat-004', 'Luminária LED Moderna', 'Luminária de mesa com controle de intensidade', 12990, 60, 'https://via.placeholder.com/300', 'LUM-LED-015', TRUE),
+('prod-016', 'cat-004', 'Quadro Decorativo Abstrato', 'Quadro 60x80cm com moldura', 18900, 45, 'https://via.placeholder.com/300', 'QUA-ABS-016', TRUE),
+('prod-017', 'cat-004', 'Jogo de Cama Casal', 'Jogo de cama 100% algodão 4 peças', 19990, 100, 'https://via.placeholder.com/300', 'CAMA-JG-017', TRUE),
 
 -- Esportes
-('prod-018', 'cat-005', 'Bola de Futebol Oficial', 'Bola oficial tamanho regulamentar', 129.90, 120, 'https://via.placeholder.com/300', 'BOL-FUT-018', TRUE),
-('prod-019', 'cat-005', 'Halteres 5kg (par)', 'Par de halteres revestidos', 89.90, 80, 'https://via.placeholder.com/300', 'HALT-5K-019', TRUE),
-('prod-020', 'cat-005', 'Tapete de Yoga Premium', 'Tapete antiderrapante 180x60cm', 119.00, 95, 'https://via.placeholder.com/300', 'TAP-YOG-020', TRUE),
+('prod-018', 'cat-005', 'Bola de Futebol Oficial', 'Bola oficial tamanho regulamentar', 12990, 120, 'https://via.placeholder.com/300', 'BOL-FUT-018', TRUE),
+('prod-019', 'cat-005', 'Halteres 5kg (par)', 'Par de halteres revestidos', 8990, 80, 'https://via.placeholder.com/300', 'HALT-5K-019', TRUE),
+('prod-020', 'cat-005', 'Tapete de Yoga Premium', 'Tapete antiderrapante 180x60cm', 11900, 95, 'https://via.placeholder.com/300', 'TAP-YOG-020', TRUE),
 
 -- Alimentos
-('prod-021', 'cat-006', 'Café Premium Torrado 500g', 'Café 100% arábica torrado e moído', 29.90, 300, 'https://via.placeholder.com/300', 'CAF-PRM-021', TRUE),
-('prod-022', 'cat-006', 'Chocolate Belga 70% Cacau', 'Barra de chocolate belga 100g', 19.90, 250, 'https://via.placeholder.com/300', 'CHOC-BEL-022', TRUE),
-('prod-023', 'cat-006', 'Azeite Extra Virgem 500ml', 'Azeite de oliva extra virgem português', 39.90, 150, 'https://via.placeholder.com/300', 'AZE-EV-023', TRUE),
+('prod-021', 'cat-006', 'Café Premium Torrado 500g', 'Café 100% arábica torrado e moído', 2990, 300, 'https://via.placeholder.com/300', 'CAF-PRM-021', TRUE),
+('prod-022', 'cat-006', 'Chocolate Belga 70% Cacau', 'Barra de chocolate belga 100g', 1990, 250, 'https://via.placeholder.com/300', 'CHOC-BEL-022', TRUE),
+('prod-023', 'cat-006', 'Azeite Extra Virgem 500ml', 'Azeite de oliva extra virgem português', 3990, 150, 'https://via.placeholder.com/300', 'AZE-EV-023', TRUE),
 
 -- Beleza
-('prod-024', 'cat-007', 'Perfume Masculino 100ml', 'Fragrância amadeirada intensa', 249.90, 70, 'https://via.placeholder.com/300', 'PERF-M-024', TRUE),
-('prod-025', 'cat-007', 'Kit Skin Care Facial', 'Kit completo para cuidados faciais', 179.00, 85, 'https://via.placeholder.com/300', 'SKIN-KIT-025', TRUE),
+('prod-024', 'cat-007', 'Perfume Masculino 100ml', 'Fragrância amadeirada intensa', 24990, 70, 'https://via.placeholder.com/300', 'PERF-M-024', TRUE),
+('prod-025', 'cat-007', 'Kit Skin Care Facial', 'Kit completo para cuidados faciais', 17900, 85, 'https://via.placeholder.com/300', 'SKIN-KIT-025', TRUE),
 
 -- Brinquedos
-('prod-026', 'cat-008', 'Lego Creator 500 peças', 'Set de construção criativo', 199.90, 110, 'https://via.placeholder.com/300', 'LEGO-CR-026', TRUE),
-('prod-027', 'cat-008', 'Boneca Fashion Doll', 'Boneca articulada com acessórios', 89.90, 150, 'https://via.placeholder.com/300', 'BON-FD-027', TRUE),
-('prod-028', 'cat-008', 'Carrinho Controle Remoto', 'Carrinho de corrida 1:16 com controle', 159.90, 90, 'https://via.placeholder.com/300', 'CAR-RC-028', TRUE);
+('prod-026', 'cat-008', 'Lego Creator 500 peças', 'Set de construção criativo', 19990, 110, 'https://via.placeholder.com/300', 'LEGO-CR-026', TRUE),
+('prod-027', 'cat-008', 'Boneca Fashion Doll', 'Boneca articulada com acessórios', 8990, 150, 'https://via.placeholder.com/300', 'BON-FD-027', TRUE),
+('prod-028', 'cat-008', 'Carrinho Controle Remoto', 'Carrinho de corrida 1:16 com controle', 15990, 90, 'https://via.placeholder.com/300', 'CAR-RC-028', TRUE);
+
+-- Variações (SKU próprio com preço, estoque e código de barras por combinação de atributos)
+CREATE TABLE IF NOT EXISTS product_variants (
+    id VARCHAR(36) PRIMARY KEY,
+    product_id VARCHAR(36) NOT NULL,
+    sku VARCHAR(100) NOT NULL UNIQUE,
+    barcode VARCHAR(50),
+    attributes TEXT,
+    attributes_key VARCHAR(255) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'BRL',
+    price_minor BIGINT NOT NULL DEFAULT 0,
+    stock_quantity INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    UNIQUE INDEX idx_variants_product_attributes (product_id, attributes_key),
+    INDEX idx_barcode (barcode)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Insert sample variants
+INSERT INTO product_variants (id, product_id, sku, barcode, attributes, attributes_key, price_minor, stock_quantity, is_active) VALUES
+('var-001', 'prod-011', 'CAM-BAS-011-PT-P', '7890000000116', '{"cor":"Preta","tamanho":"P"}', 'cor=Preta;tamanho=P', 4990, 150, TRUE),
+('var-002', 'prod-011', 'CAM-BAS-011-PT-M', '7890000000123', '{"cor":"Preta","tamanho":"M"}', 'cor=Preta;tamanho=M', 4990, 200, TRUE),
+('var-003', 'prod-011', 'CAM-BAS-011-PT-G', '7890000000130', '{"cor":"Preta","tamanho":"G"}', 'cor=Preta;tamanho=G', 5490, 150, TRUE),
+('var-004', 'prod-014', 'TEN-ESP-014-40', '7890000000147', '{"tamanho":"40"}', 'tamanho=40', 34990, 50, TRUE),
+('var-005', 'prod-014', 'TEN-ESP-014-41', '7890000000154', '{"tamanho":"41"}', 'tamanho=41', 34990, 50, TRUE),
+('var-006', 'prod-014', 'TEN-ESP-014-42', '7890000000161', '{"tamanho":"42"}', 'tamanho=42', 34990, 50, TRUE);
+
+-- Histórico de preços: versões com janela de validade [valid_from, valid_to)
+CREATE TABLE IF NOT EXISTS price_history (
+    id VARCHAR(36) PRIMARY KEY,
+    product_id VARCHAR(36) NOT NULL,
+    variant_id VARCHAR(36) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL DEFAULT 'BRL',
+    price_minor BIGINT NOT NULL DEFAULT 0,
+    valid_from DATETIME(3) NOT NULL,
+    valid_to DATETIME(3) NULL,
+    reason VARCHAR(200),
+    applied_at DATETIME(3) NULL,
+    ended_at DATETIME(3) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_prices_owner_from (product_id, variant_id, valid_from),
+    INDEX idx_price_history_applied_at (applied_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Preço inicial de produtos e variações de exemplo
+INSERT INTO price_history (id, product_id, variant_id, currency, price_minor, valid_from, reason, applied_at)
+SELECT UUID(), id, '', currency, price_minor, created_at, 'preço inicial', created_at FROM products;
+INSERT INTO price_history (id, product_id, variant_id, currency, price_minor, valid_from, reason, applied_at)
+SELECT UUID(), product_id, id, currency, price_minor, created_at, 'preço inicial', created_at FROM product_variants;
 
 -- ============================================================================
 -- ORDER SERVICE
@@ -18,7 +18,7 @@ echo -e "${BLUE}╚════════════════════
 echo ""
 
 # Array de serviços Go
-GO_SERVICES=("order" "payment" "user" "notification" "catalog" "bff-graphql")
+GO_SERVICES=("order" "payment" "user" "notification" "catalog" "bff-graphql" "shared/auth" "shared/money")
 
 # Contador de testes
 TOTAL_TESTS=0
@@ -32,7 +32,7 @@ run_service_tests() {
     echo -e "${BLUE}📦 Testing service: ${GREEN}$service${NC}"
     echo -e "${YELLOW}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${NC}"
     
-    cd "services/$service"
+    pushd "services/$service" > /dev/null
     
     # Instalar dependências
     echo -e "${BLUE}📥 Installing dependencies...${NC}"
@@ -54,7 +54,7 @@ run_service_tests() {
     fi
     
     TOTAL_TESTS=$((TOTAL_TESTS + 1))
-    cd ../..
+    popd > /dev/null
     echo ""
 }
 
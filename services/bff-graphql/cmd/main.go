@@ -18,10 +18,13 @@ import (
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/graph"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/auth"
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/config"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/geo"
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/metrics"
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/telemetry"
+	sharedauth "github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
 )
 
 func main() {
@@ -44,37 +47,50 @@ func main() {
 	}
 
 	log.Printf("⚙️ Configuração carregada - Port: %s", cfg.Port)
+
+	// 🔐 Validador de tokens JWT
+	verifier, err := auth.NewVerifier(cfg.JWTSecret, cfg.JWTIssuer)
+	if err != nil {
+		log.Fatal("❌ Erro ao configurar autenticação:", err)
+	}
 	log.Printf("🔗 Conectando com microserviços:")
 	log.Printf("   📦 Order Service: %s", cfg.OrderServiceURL)
 	log.Printf("   👤 User Service: %s", cfg.UserServiceURL)
 	log.Printf("   💳 Payment Service: %s", cfg.PaymentServiceURL)
 	log.Printf("   📧 Notification Service: %s", cfg.NotificationServiceURL)
 
+	// 🤖 Credenciais da conta de serviço enviadas em todas as chamadas gRPC
+	serviceCreds, err := sharedauth.NewServiceCredentials(cfg.UserServiceURL, cfg.ServiceClientID, cfg.ServiceClientSecret)
+	if err != nil {
+		log.Fatal("❌ Erro ao configurar conta de serviço:", err)
+	}
+	defer serviceCreds.Close()
+
 	// Inicializar clientes gRPC com timeout
 	timeout := 10 * time.Second
 
-	orderClient, err := clients.NewOrderClient(cfg.OrderServiceURL, timeout)
+	orderClient, err := clients.NewOrderClient(cfg.OrderServiceURL, timeout, serviceCreds.DialOption())
 	if err != nil {
 		log.Printf("⚠️ Aviso: Não foi possível conectar ao Order Service: %v", err)
 	} else {
 		log.Println("✅ Order Client conectado")
 	}
 
-	userClient, err := clients.NewUserClient(cfg.UserServiceURL, timeout)
+	userClient, err := clients.NewUserClient(cfg.UserServiceURL, timeout, serviceCreds.DialOption())
 	if err != nil {
 		log.Printf("⚠️ Aviso: Não foi possível conectar ao User Service: %v", err)
 	} else {
 		log.Println("✅ User Client conectado")
 	}
 
-	paymentClient, err := clients.NewPaymentClient(cfg.PaymentServiceURL, timeout)
+	paymentClient, err := clients.NewPaymentClient(cfg.PaymentServiceURL, timeout, serviceCreds.DialOption())
 	if err != nil {
 		log.Printf("⚠️ Aviso: Não foi possível conectar ao Payment Service: %v", err)
 	} else {
 		log.Println("✅ Payment Client conectado")
 	}
 
-	notificationClient, err := clients.NewNotificationClient(cfg.NotificationServiceURL, timeout)
+	notificationClient, err := clients.NewNotificationClient(cfg.NotificationServiceURL, timeout, serviceCreds.DialOption())
 	if err != nil {
 		log.Printf("⚠️ Aviso: Não foi possível conectar ao Notification Service: %v", err)
 	} else {
@@ -90,15 +106,19 @@ func main() {
 	}
 
 	// Configurar servidor GraphQL
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Directives: graph.NewDirectiveRoot(),
+	}))
 
 	// Configurar transporte WebSocket para subscriptions
 	srv.AddTransport(transport.Websocket{
 		Upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
-				return true // Permitir qualquer origem para desenvolvimento
+				return originAllowed(r.Header.Get("Origin"), cfg.AllowedOrigins)
 			},
 		},
+		InitFunc: auth.WebsocketInit(verifier),
 	})
 
 	// Adicionar transporte HTTP
@@ -116,10 +136,10 @@ func main() {
 
 	// Configurar CORS
 	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
+		AllowedOrigins:   cfg.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"*"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"WWW-Authenticate"},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 horas
 	})
@@ -127,8 +147,10 @@ func main() {
 	// Configurar rotas
 	mux := http.NewServeMux()
 
-	// Endpoint GraphQL principal
-	mux.Handle("/", corsHandler.Handler(srv))
+	// Endpoint GraphQL principal (CORS antes da autenticação para responder preflights)
+	authMiddleware := auth.Middleware(verifier)
+	geoMiddleware := geo.Middleware(cfg.GeoCountryHeader)
+	mux.Handle("/", corsHandler.Handler(authMiddleware(geoMiddleware(srv))))
 
 	// Playground para desenvolvimento
 	if os.Getenv("GO_ENV") != "production" {
@@ -193,6 +215,19 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// originAllowed verifica se a origem da requisição está na lista permitida
+func originAllowed(origin string, allowed []string) bool {
+	if origin == "" {
+		return true // Clientes não-navegador não enviam Origin
+	}
+	for _, o := range allowed {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	return false
+}
+
 // Função auxiliar para converter string para int com valor padrão
 func parseIntWithDefault(s string, defaultValue int) int {
 	if val, err := strconv.Atoi(s); err == nil {
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"context"
+	"log"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/graph/model"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/auth"
+)
+
+// NewDirectiveRoot cria as implementações das diretivas @auth e @hasRole do schema
+func NewDirectiveRoot() DirectiveRoot {
+	return DirectiveRoot{
+		Auth:    authDirective,
+		HasRole: hasRoleDirective,
+	}
+}
+
+// authDirective exige que a requisição possua um principal autenticado
+func authDirective(ctx context.Context, obj any, next graphql.Resolver) (any, error) {
+	if _, err := auth.RequirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// hasRoleDirective exige que o principal autenticado possua o papel informado
+func hasRoleDirective(ctx context.Context, obj any, next graphql.Resolver, role model.Role) (any, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !principal.HasRole(role.String()) {
+		field := graphql.GetFieldContext(ctx)
+		if field != nil {
+			log.Printf("🚫 Usuário %s sem papel %s para o campo %s", principal.UserID, role, field.Field.Name)
+		}
+		return nil, auth.ErrForbidden
+	}
+
+	return next(ctx)
+}
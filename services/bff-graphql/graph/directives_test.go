@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/graph/model"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/auth"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
+)
+
+var (
+	admin    = &auth.Principal{UserID: "1", Roles: []string{auth.RoleAdmin}}
+	customer = &auth.Principal{UserID: "7", Roles: []string{auth.RoleUser}}
+	noRoles  = &auth.Principal{UserID: "9"}
+)
+
+// stubUserClient devolve usuários fixos e conta as chamadas ao user-service
+type stubUserClient struct {
+	users []*clients.UserResponse
+	calls int
+}
+
+func (c *stubUserClient) CreateUser(ctx context.Context, req *clients.UserRequest) (*clients.UserResponse, error) {
+	c.calls++
+	return &clients.UserResponse{ID: 2, Name: req.Name, Email: req.Email}, nil
+}
+
+func (c *stubUserClient) ListUsers(ctx context.Context) ([]*clients.UserResponse, error) {
+	c.calls++
+	return c.users, nil
+}
+
+func (c *stubUserClient) Close() error { return nil }
+
+// newTestClient monta o schema com as diretivas reais, como em cmd/main.go, e
+// executa as operações como o principal informado (nil, anônimo)
+func newTestClient(resolver *Resolver, principal *auth.Principal) *client.Client {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers:  resolver,
+		Directives: NewDirectiveRoot(),
+	}))
+	srv.AddTransport(transport.POST{})
+
+	return client.New(srv, func(bd *client.Request) {
+		if principal != nil {
+			bd.HTTP = bd.HTTP.WithContext(auth.WithPrincipal(bd.HTTP.Context(), principal))
+		}
+	})
+}
+
+func TestAuthDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		wantErr   error
+	}{
+		{"anônimo", nil, auth.ErrUnauthenticated},
+		{"autenticado sem papéis", noRoles, nil},
+		{"usuário", customer, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			called := false
+			next := func(ctx context.Context) (any, error) { called = true; return "ok", nil }
+
+			result, err := authDirective(ctx, nil, next)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				assert.False(t, called, "o resolver não pode ser executado")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", result)
+		})
+	}
+}
+
+func TestHasRoleDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		role      model.Role
+		wantErr   error
+	}{
+		{"anônimo", nil, model.RoleAdmin, auth.ErrUnauthenticated},
+		{"sem nenhum papel", noRoles, model.RoleUser, auth.ErrForbidden},
+		{"usuário sem o papel de admin", customer, model.RoleAdmin, auth.ErrForbidden},
+		{"usuário com o papel exigido", customer, model.RoleUser, nil},
+		{"admin", admin, model.RoleAdmin, nil},
+		{"papel em minúsculas no token", &auth.Principal{UserID: "1", Roles: []string{"admin"}}, model.RoleAdmin, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			called := false
+			next := func(ctx context.Context) (any, error) { called = true; return "ok", nil }
+
+			result, err := hasRoleDirective(ctx, nil, next, tt.role)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				assert.False(t, called, "o resolver não pode ser executado")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", result)
+		})
+	}
+}
+
+func TestSchema_HasRoleRejectsMissingRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		wantErr   string
+	}{
+		{"anônimo", nil, auth.ErrUnauthenticated.Error()},
+		{"sem papéis", noRoles, auth.ErrForbidden.Error()},
+		{"usuário comum", customer, auth.ErrForbidden.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &stubUserClient{users: []*clients.UserResponse{{ID: 7, Name: "Maria"}}}
+			c := newTestClient(&Resolver{UserClient: users}, tt.principal)
+
+			var resp struct{ Users []struct{ ID string } }
+			err := c.Post(`query { users { id } }`, &resp)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			err = c.Post(`mutation { createUser(input: {name: "Ana", email: "ana@example.com"}) { id } }`, &resp)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			assert.Zero(t, users.calls, "o user-service não pode ser chamado")
+		})
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		users := &stubUserClient{users: []*clients.UserResponse{{ID: 7, Name: "Maria"}}}
+		c := newTestClient(&Resolver{UserClient: users}, admin)
+
+		var resp struct{ Users []struct{ ID string } }
+		require.NoError(t, c.Post(`query { users { id } }`, &resp))
+		require.Len(t, resp.Users, 1)
+		assert.Equal(t, "7", resp.Users[0].ID)
+	})
+}
@@ -2,11 +2,40 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Boleto struct {
+	OrderID       int     `json:"order_id"`
+	NossoNumero   string  `json:"nosso_numero"`
+	Barcode       string  `json:"barcode"`
+	DigitableLine string  `json:"digitable_line"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+	DueDate       string  `json:"due_date"`
+	Status        string  `json:"status"`
+	ExpiresAt     string  `json:"expires_at"`
+	PaidAt        *string `json:"paid_at,omitempty"`
+	PDF           *string `json:"pdf,omitempty"`
+}
+
 type CreateOrderInput struct {
-	UserID      int     `json:"user_id"`
-	ProductName string  `json:"product_name"`
-	Quantity    int     `json:"quantity"`
-	Price       float64 `json:"price"`
+	UserID           int      `json:"user_id"`
+	ProductName      string   `json:"product_name"`
+	Sku              string   `json:"sku"`
+	Quantity         int      `json:"quantity"`
+	Price            *float64 `json:"price,omitempty"`
+	CouponCodes      []string `json:"coupon_codes,omitempty"`
+	DestinationState *string  `json:"destination_state,omitempty"`
+	PaymentCurrency  *string  `json:"payment_currency,omitempty"`
+	Installments     *int     `json:"installments,omitempty"`
+	PaymentMethod    *string  `json:"payment_method,omitempty"`
+	CardFingerprint  *string  `json:"card_fingerprint,omitempty"`
+	BillingState     *string  `json:"billing_state,omitempty"`
 }
 
 type CreateUserInput struct {
@@ -14,6 +43,21 @@ type CreateUserInput struct {
 	Email string `json:"email"`
 }
 
+type Installment struct {
+	Number  int     `json:"number"`
+	Amount  float64 `json:"amount"`
+	DueDate string  `json:"due_date"`
+}
+
+type InstallmentPlan struct {
+	Installments int            `json:"installments"`
+	InterestFree bool           `json:"interest_free"`
+	MonthlyRate  float64        `json:"monthly_rate"`
+	Interest     float64        `json:"interest"`
+	Total        float64        `json:"total"`
+	Schedule     []*Installment `json:"schedule"`
+}
+
 type Mutation struct {
 }
 
@@ -27,13 +71,41 @@ type Notification struct {
 }
 
 type Order struct {
-	ID          string  `json:"id"`
-	UserID      int     `json:"user_id"`
-	ProductName string  `json:"product_name"`
-	Quantity    int     `json:"quantity"`
-	Price       float64 `json:"price"`
-	Status      string  `json:"status"`
-	CreatedAt   string  `json:"created_at"`
+	ID               string            `json:"id"`
+	UserID           int               `json:"user_id"`
+	ProductName      string            `json:"product_name"`
+	Quantity         int               `json:"quantity"`
+	Price            float64           `json:"price"`
+	Currency         string            `json:"currency"`
+	Sku              *string           `json:"sku,omitempty"`
+	VariantID        *string           `json:"variant_id,omitempty"`
+	Attributes       []*OrderAttribute `json:"attributes"`
+	Subtotal         float64           `json:"subtotal"`
+	DiscountTotal    float64           `json:"discount_total"`
+	TaxTotal         float64           `json:"tax_total"`
+	Total            float64           `json:"total"`
+	Discounts        []*OrderDiscount  `json:"discounts"`
+	DestinationState *string           `json:"destination_state,omitempty"`
+	Taxes            []*OrderTax       `json:"taxes"`
+	PaymentCurrency  string            `json:"payment_currency"`
+	Installments     int               `json:"installments"`
+	PaymentMethod    string            `json:"payment_method"`
+	Status           string            `json:"status"`
+	CreatedAt        string            `json:"created_at"`
+}
+
+type OrderAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type OrderDiscount struct {
+	Line   int     `json:"line"`
+	Sku    *string `json:"sku,omitempty"`
+	Code   string  `json:"code"`
+	Name   *string `json:"name,omitempty"`
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount"`
 }
 
 type OrderSummary struct {
@@ -43,14 +115,45 @@ type OrderSummary struct {
 	Notifications []*Notification `json:"notifications,omitempty"`
 }
 
+type OrderTax struct {
+	Line     int     `json:"line"`
+	Sku      *string `json:"sku,omitempty"`
+	TaxClass string  `json:"tax_class"`
+	Tax      string  `json:"tax"`
+	State    string  `json:"state"`
+	Rate     float64 `json:"rate"`
+	Base     float64 `json:"base"`
+	Amount   float64 `json:"amount"`
+	Included bool    `json:"included"`
+}
+
 type Payment struct {
-	ID            string  `json:"id"`
-	OrderID       int     `json:"order_id"`
-	UserID        int     `json:"user_id"`
-	Amount        float64 `json:"amount"`
-	Status        string  `json:"status"`
-	PaymentMethod string  `json:"payment_method"`
-	CreatedAt     string  `json:"created_at"`
+	ID              string           `json:"id"`
+	OrderID         int              `json:"order_id"`
+	UserID          int              `json:"user_id"`
+	Amount          float64          `json:"amount"`
+	Currency        string           `json:"currency"`
+	OrderAmount     float64          `json:"order_amount"`
+	OrderCurrency   string           `json:"order_currency"`
+	FxRate          string           `json:"fx_rate"`
+	InstallmentPlan *InstallmentPlan `json:"installment_plan"`
+	Status          string           `json:"status"`
+	PaymentMethod   string           `json:"payment_method"`
+	CreatedAt       string           `json:"created_at"`
+}
+
+type PixCharge struct {
+	OrderID    int     `json:"order_id"`
+	Txid       string  `json:"txid"`
+	Kind       string  `json:"kind"`
+	Payload    string  `json:"payload"`
+	QRCodePng  string  `json:"qr_code_png"`
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	Status     string  `json:"status"`
+	ExpiresAt  string  `json:"expires_at"`
+	PaidAt     *string `json:"paid_at,omitempty"`
+	EndToEndID *string `json:"end_to_end_id,omitempty"`
 }
 
 type Query struct {
@@ -62,3 +165,58 @@ type User struct {
 	Email     string `json:"email"`
 	CreatedAt string `json:"created_at"`
 }
+
+type Role string
+
+const (
+	RoleAdmin Role = "ADMIN"
+	RoleUser  Role = "USER"
+)
+
+var AllRole = []Role{
+	RoleAdmin,
+	RoleUser,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleAdmin, RoleUser:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
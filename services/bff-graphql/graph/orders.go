@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"strconv"
+
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/graph/model"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
+)
+
+// productName usa o nome copiado do catálogo na compra; pedidos antigos,
+// sem essa cópia, exibem um nome baseado no ID do produto
+func productName(order *clients.OrderResponse) string {
+	if order.ProductName != "" {
+		return order.ProductName
+	}
+	return "Produto " + strconv.Itoa(int(order.ProductID))
+}
+
+// orderAttributes converte os atributos da variação comprada para o modelo GraphQL
+func orderAttributes(order *clients.OrderResponse) []*model.OrderAttribute {
+	attributes := make([]*model.OrderAttribute, 0, len(order.Attributes))
+	for _, attribute := range order.Attributes {
+		attributes = append(attributes, &model.OrderAttribute{Name: attribute.Name, Value: attribute.Value})
+	}
+	return attributes
+}
+
+// orderDiscounts converte os descontos aplicados ao pedido para o modelo GraphQL
+func orderDiscounts(order *clients.OrderResponse) []*model.OrderDiscount {
+	discounts := make([]*model.OrderDiscount, 0, len(order.Discounts))
+	for _, discount := range order.Discounts {
+		discounts = append(discounts, &model.OrderDiscount{
+			Line:   int(discount.Line),
+			Sku:    optionalString(discount.Sku),
+			Code:   discount.Code,
+			Name:   optionalString(discount.Name),
+			Type:   discount.Type,
+			Amount: discount.Amount.Float(),
+		})
+	}
+	return discounts
+}
+
+// orderTaxes converte os tributos do pedido para o modelo GraphQL
+func orderTaxes(order *clients.OrderResponse) []*model.OrderTax {
+	taxes := make([]*model.OrderTax, 0, len(order.Taxes))
+	for _, item := range order.Taxes {
+		taxes = append(taxes, &model.OrderTax{
+			Line:     int(item.Line),
+			Sku:      optionalString(item.Sku),
+			TaxClass: item.TaxClass,
+			Tax:      item.Tax,
+			State:    item.State,
+			Rate:     item.Rate,
+			Base:     item.Base.Float(),
+			Amount:   item.Amount.Float(),
+			Included: item.Included,
+		})
+	}
+	return taxes
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"strconv"
+
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/graph/model"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/geo"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// toPayment converte o pagamento do payment-service; userID é o cliente do pedido
+func toPayment(payment *clients.PaymentRecord, userID int) *model.Payment {
+	return &model.Payment{
+		ID:              strconv.Itoa(int(payment.ID)),
+		OrderID:         int(payment.OrderID),
+		UserID:          userID,
+		Amount:          payment.Amount.Float(),
+		Currency:        money.NormalizeCurrency(payment.Amount.Currency),
+		OrderAmount:     paymentOrderAmount(payment).Float(),
+		OrderCurrency:   money.NormalizeCurrency(paymentOrderAmount(payment).Currency),
+		FxRate:          paymentFXRate(payment),
+		InstallmentPlan: paymentInstallmentPlan(payment),
+		Status:          payment.Status,
+		PaymentMethod:   paymentMethod(payment.Method),
+		CreatedAt:       payment.CreatedAt,
+	}
+}
+
+// orderPayment busca o pagamento de um pedido; nil quando o pedido ainda não tem
+// pagamento
+func (r *Resolver) orderPayment(ctx context.Context, order *model.Order) (*model.Payment, error) {
+	orderID, err := strconv.Atoi(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := r.PaymentClient.GetPaymentsByOrderIDs(ctx, []uint32{uint32(orderID)})
+	if err != nil || len(payments) == 0 {
+		return nil, err
+	}
+	return toPayment(payments[0], order.UserID), nil
+}
+
+// paymentOrderAmount retorna o total do pedido na moeda do pedido. Pagamentos sem
+// conversão de moeda não trazem o total do pedido, que é o próprio valor cobrado.
+func paymentOrderAmount(payment *clients.PaymentRecord) money.Money {
+	if payment.OrderAmount.IsZero() {
+		return payment.Amount
+	}
+	return payment.OrderAmount
+}
+
+// paymentFXRate retorna a cotação aplicada ao pagamento; sem conversão, 1:1
+func paymentFXRate(payment *clients.PaymentRecord) string {
+	if payment.FxRate == "" {
+		return "1.00000000"
+	}
+	return payment.FxRate
+}
+
+// paymentInstallmentPlan converte o parcelamento do pagamento. Pagamentos sem
+// parcelamento são à vista, sem juros, pelo valor cobrado.
+func paymentInstallmentPlan(payment *clients.PaymentRecord) *model.InstallmentPlan {
+	plan := payment.InstallmentPlan
+	if plan == nil || plan.Installments <= 1 {
+		return &model.InstallmentPlan{
+			Installments: 1,
+			InterestFree: true,
+			Total:        payment.Amount.Float(),
+			Schedule:     []*model.Installment{},
+		}
+	}
+
+	result := &model.InstallmentPlan{
+		Installments: int(plan.Installments),
+		InterestFree: plan.InterestFree,
+		MonthlyRate:  plan.MonthlyRate,
+		Interest:     plan.Interest.Float(),
+		Total:        plan.Total.Float(),
+		Schedule:     make([]*model.Installment, 0, len(plan.Schedule)),
+	}
+	for _, item := range plan.Schedule {
+		result.Schedule = append(result.Schedule, &model.Installment{
+			Number:  int(item.Number),
+			Amount:  item.Amount.Float(),
+			DueDate: item.DueDate,
+		})
+	}
+	return result
+}
+
+// orderInstallments retorna o número de parcelas do pedido; pedidos anteriores ao
+// parcelamento são à vista
+func orderInstallments(installments int32) int {
+	return max(int(installments), 1)
+}
+
+// paymentMethod retorna a forma de pagamento; registros anteriores ao PIX são com cartão
+func paymentMethod(method string) string {
+	if method == "" {
+		return "card"
+	}
+	return method
+}
+
+// pixCharge converte a cobrança PIX; o QR Code em PNG vai em base64
+func pixCharge(charge *clients.PixChargeResponse) *model.PixCharge {
+	return &model.PixCharge{
+		OrderID:    int(charge.OrderID),
+		Txid:       charge.TxID,
+		Kind:       charge.Kind,
+		Payload:    charge.Payload,
+		QRCodePng:  base64.StdEncoding.EncodeToString(charge.QRCodePNG),
+		Amount:     charge.Amount.Float(),
+		Currency:   money.NormalizeCurrency(charge.Amount.Currency),
+		Status:     charge.Status,
+		ExpiresAt:  charge.ExpiresAt,
+		PaidAt:     optionalString(charge.PaidAt),
+		EndToEndID: optionalString(charge.EndToEndID),
+	}
+}
+
+// boleto converte o boleto; o PDF, quando pedido, vai em base64
+func boleto(response *clients.BoletoResponse) *model.Boleto {
+	result := &model.Boleto{
+		OrderID:       int(response.OrderID),
+		NossoNumero:   response.NossoNumero,
+		Barcode:       response.Barcode,
+		DigitableLine: response.DigitableLine,
+		Amount:        response.Amount.Float(),
+		Currency:      money.NormalizeCurrency(response.Amount.Currency),
+		DueDate:       response.DueDate,
+		Status:        response.Status,
+		ExpiresAt:     response.ExpiresAt,
+		PaidAt:        optionalString(response.PaidAt),
+	}
+	if len(response.PDF) > 0 {
+		pdf := base64.StdEncoding.EncodeToString(response.PDF)
+		result.PDF = &pdf
+	}
+	return result
+}
+
+// paymentRisk reúne os sinais da análise antifraude do pagamento: o cartão e a UF de
+// cobrança informados, o país da requisição e a data de cadastro do cliente. A falha
+// ao consultar o cadastro não impede o pedido; o sinal apenas fica de fora.
+func (r *Resolver) paymentRisk(ctx context.Context, input model.CreateOrderInput) *clients.PaymentRisk {
+	risk := &clients.PaymentRisk{IPCountry: geo.CountryFromContext(ctx)}
+	if input.CardFingerprint != nil {
+		risk.CardFingerprint = *input.CardFingerprint
+	}
+	if input.BillingState != nil {
+		risk.BillingState = *input.BillingState
+	}
+
+	if r.UserClient != nil {
+		users, err := r.UserClient.ListUsers(ctx)
+		if err != nil {
+			log.Printf("⚠️ Cadastro do usuário %d indisponível para a análise antifraude: %v", input.UserID, err)
+		}
+		for _, user := range users {
+			if int(user.ID) == input.UserID {
+				risk.CustomerSince = user.CreatedAt
+				break
+			}
+		}
+	}
+
+	if *risk == (clients.PaymentRisk{}) {
+		return nil
+	}
+	return risk
+}
@@ -2,12 +2,18 @@ package graph
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strconv"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/graph/model"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/auth"
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
 	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/config"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 )
 
 // Resolver estrutura principal contendo todos os clientes gRPC
@@ -92,13 +98,40 @@ func (r *Resolver) Close() error {
 func (r *mutationResolver) CreateOrder(ctx context.Context, input model.CreateOrderInput) (*model.Order, error) {
 	log.Printf("📦 GraphQL: Criando pedido para usuário %d", input.UserID)
 
+	// Usuários comuns só podem criar pedidos em nome próprio
+	principal := auth.FromContext(ctx)
+	if !principal.CanAccessCustomer(strconv.Itoa(input.UserID)) {
+		return nil, auth.ErrForbidden
+	}
+
 	// Converter para o formato do cliente gRPC
 	orderReq := &clients.OrderRequest{
-		Customer:  strconv.Itoa(input.UserID), // Converter userID para string como customer
-		ProductID: 1,                          // Assumindo produto padrão
-		Quantity:  int32(input.Quantity),
-		Price:     input.Price,
+		Customer:    strconv.Itoa(input.UserID), // Converter userID para string como customer
+		ProductID:   1,                          // Assumindo produto padrão
+		Sku:         input.Sku,
+		Quantity:    int32(input.Quantity),
+		CouponCodes: input.CouponCodes,
+	}
+	if input.Price != nil {
+		price, err := money.FromFloat(*input.Price, money.DefaultCurrency, money.HalfUp)
+		if err != nil {
+			return nil, fmt.Errorf("preço inválido: %w", err)
+		}
+		orderReq.Price = &price
+	}
+	if input.DestinationState != nil {
+		orderReq.DestinationState = *input.DestinationState
+	}
+	if input.PaymentCurrency != nil {
+		orderReq.PaymentCurrency = *input.PaymentCurrency
+	}
+	if input.Installments != nil {
+		orderReq.Installments = int32(*input.Installments)
+	}
+	if input.PaymentMethod != nil {
+		orderReq.PaymentMethod = *input.PaymentMethod
 	}
+	orderReq.Risk = r.paymentRisk(ctx, input)
 
 	// Chamar o serviço via gRPC
 	orderResp, err := r.OrderClient.CreateOrder(ctx, orderReq)
@@ -109,13 +142,27 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, input model.CreateOr
 
 	// Converter resposta para modelo GraphQL
 	return &model.Order{
-		ID:          strconv.Itoa(int(orderResp.ID)),
-		UserID:      input.UserID,
-		ProductName: input.ProductName,
-		Quantity:    input.Quantity,
-		Price:       orderResp.Price,
-		Status:      "created",
-		CreatedAt:   orderResp.CreatedAt,
+		ID:               strconv.Itoa(int(orderResp.ID)),
+		UserID:           input.UserID,
+		ProductName:      firstNonEmpty(orderResp.ProductName, input.ProductName),
+		Quantity:         input.Quantity,
+		Price:            orderResp.Price.Float(),
+		Currency:         money.NormalizeCurrency(orderResp.Total.Currency),
+		Sku:              &orderResp.Sku,
+		VariantID:        optionalString(orderResp.VariantID),
+		Attributes:       orderAttributes(orderResp),
+		Subtotal:         orderResp.Subtotal.Float(),
+		DiscountTotal:    orderResp.DiscountTotal.Float(),
+		TaxTotal:         orderResp.TaxTotal.Float(),
+		Total:            orderResp.Total.Float(),
+		Discounts:        orderDiscounts(orderResp),
+		DestinationState: optionalString(orderResp.DestinationState),
+		PaymentCurrency:  money.NormalizeCurrency(firstNonEmpty(orderResp.PaymentCurrency, orderResp.Total.Currency)),
+		Installments:     orderInstallments(orderResp.Installments),
+		PaymentMethod:    paymentMethod(orderResp.PaymentMethod),
+		Taxes:            orderTaxes(orderResp),
+		Status:           orderResp.Status,
+		CreatedAt:        orderResp.CreatedAt,
 	}, nil
 }
 
@@ -156,18 +203,35 @@ func (r *queryResolver) Orders(ctx context.Context) ([]*model.Order, error) {
 		return nil, err
 	}
 
+	// Restringir aos pedidos do usuário autenticado (exceto administradores)
+	orders = scopeOrders(ctx, orders)
+
 	// Converter resposta para modelo GraphQL
 	result := make([]*model.Order, len(orders))
 	for i, order := range orders {
 		userID, _ := strconv.Atoi(order.Customer) // Converter customer de volta para userID
 		result[i] = &model.Order{
-			ID:          strconv.Itoa(int(order.ID)),
-			UserID:      userID,
-			ProductName: "Produto " + strconv.Itoa(int(order.ProductID)), // Nome baseado no ID
-			Quantity:    int(order.Quantity),
-			Price:       order.Price,
-			Status:      "completed",
-			CreatedAt:   order.CreatedAt,
+			ID:               strconv.Itoa(int(order.ID)),
+			UserID:           userID,
+			ProductName:      productName(order),
+			Quantity:         int(order.Quantity),
+			Price:            order.Price.Float(),
+			Currency:         money.NormalizeCurrency(order.Total.Currency),
+			Sku:              optionalString(order.Sku),
+			VariantID:        optionalString(order.VariantID),
+			Attributes:       orderAttributes(order),
+			Subtotal:         order.Subtotal.Float(),
+			DiscountTotal:    order.DiscountTotal.Float(),
+			TaxTotal:         order.TaxTotal.Float(),
+			Total:            order.Total.Float(),
+			Discounts:        orderDiscounts(order),
+			DestinationState: optionalString(order.DestinationState),
+			PaymentCurrency:  money.NormalizeCurrency(firstNonEmpty(order.PaymentCurrency, order.Total.Currency)),
+			Installments:     orderInstallments(order.Installments),
+			PaymentMethod:    paymentMethod(order.PaymentMethod),
+			Taxes:            orderTaxes(order),
+			Status:           order.Status,
+			CreatedAt:        order.CreatedAt,
 		}
 	}
 
@@ -203,33 +267,33 @@ func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
 func (r *queryResolver) Payments(ctx context.Context) ([]*model.Payment, error) {
 	log.Printf("💳 GraphQL: Listando pagamentos")
 
-	// Como o PaymentService só tem GetPaymentStatus por orderID,
-	// vamos simular uma lista baseada nos pedidos conhecidos
 	orders, err := r.OrderClient.ListOrders(ctx)
 	if err != nil {
 		log.Printf("❌ Erro ao listar pedidos para pagamentos: %v", err)
 		return nil, err
 	}
 
-	result := make([]*model.Payment, 0)
+	// Uma consulta em lote para os pedidos visíveis ao usuário
+	orders = scopeOrders(ctx, orders)
+	orderIDs := make([]uint32, 0, len(orders))
+	customers := make(map[uint32]int, len(orders))
 	for _, order := range orders {
-		// Tentar obter status do pagamento para cada pedido
-		payment, err := r.PaymentClient.GetPaymentStatus(ctx, order.ID)
-		if err != nil {
-			log.Printf("⚠️ Erro ao obter pagamento do pedido %d: %v", order.ID, err)
-			continue
-		}
+		orderIDs = append(orderIDs, order.ID)
+		customers[order.ID], _ = strconv.Atoi(order.Customer)
+	}
+	if len(orderIDs) == 0 {
+		return []*model.Payment{}, nil
+	}
 
-		userID, _ := strconv.Atoi(order.Customer)
-		result = append(result, &model.Payment{
-			ID:            strconv.Itoa(int(payment.OrderID)), // Usando orderID como ID do payment
-			OrderID:       int(payment.OrderID),
-			UserID:        userID,
-			Amount:        payment.Amount,
-			Status:        payment.Status,
-			PaymentMethod: "card", // Valor padrão
-			CreatedAt:     order.CreatedAt,
-		})
+	payments, err := r.PaymentClient.GetPaymentsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		log.Printf("❌ Erro ao listar pagamentos dos pedidos: %v", err)
+		return nil, err
+	}
+
+	result := make([]*model.Payment, 0, len(payments))
+	for _, payment := range payments {
+		result = append(result, toPayment(payment, customers[payment.OrderID]))
 	}
 
 	return result, nil
@@ -246,17 +310,30 @@ func (r *queryResolver) Notifications(ctx context.Context) ([]*model.Notificatio
 		return nil, err
 	}
 
+	// Usuários comuns veem apenas notificações dos próprios pedidos
+	var allowedOrders map[uint32]bool
+	if !auth.FromContext(ctx).IsAdmin() {
+		allowedOrders, err = r.ownedOrderIDs(ctx)
+		if err != nil {
+			log.Printf("❌ Erro ao listar pedidos para filtrar notificações: %v", err)
+			return nil, err
+		}
+	}
+
 	// Converter resposta para modelo GraphQL
-	result := make([]*model.Notification, len(notifications))
-	for i, notification := range notifications {
-		result[i] = &model.Notification{
+	result := make([]*model.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		if allowedOrders != nil && !allowedOrders[notification.OrderID] {
+			continue
+		}
+		result = append(result, &model.Notification{
 			ID:        strconv.Itoa(int(notification.ID)),
 			PaymentID: int(notification.PaymentID),
 			OrderID:   int(notification.OrderID),
 			Message:   notification.Message,
 			Status:    notification.Status,
 			CreatedAt: notification.CreatedAt,
-		}
+		})
 	}
 
 	return result, nil
@@ -277,17 +354,31 @@ func (r *queryResolver) Order(ctx context.Context, id string) (*model.Order, err
 		return nil, err
 	}
 
-	for _, order := range orders {
+	for _, order := range scopeOrders(ctx, orders) {
 		if order.ID == uint32(orderIDInt) {
 			userID, _ := strconv.Atoi(order.Customer)
 			return &model.Order{
-				ID:          strconv.Itoa(int(order.ID)),
-				UserID:      userID,
-				ProductName: "Produto " + strconv.Itoa(int(order.ProductID)),
-				Quantity:    int(order.Quantity),
-				Price:       order.Price,
-				Status:      "completed",
-				CreatedAt:   order.CreatedAt,
+				ID:               strconv.Itoa(int(order.ID)),
+				UserID:           userID,
+				ProductName:      productName(order),
+				Quantity:         int(order.Quantity),
+				Price:            order.Price.Float(),
+				Currency:         money.NormalizeCurrency(order.Total.Currency),
+				Sku:              optionalString(order.Sku),
+				VariantID:        optionalString(order.VariantID),
+				Attributes:       orderAttributes(order),
+				Subtotal:         order.Subtotal.Float(),
+				DiscountTotal:    order.DiscountTotal.Float(),
+				TaxTotal:         order.TaxTotal.Float(),
+				Total:            order.Total.Float(),
+				Discounts:        orderDiscounts(order),
+				DestinationState: optionalString(order.DestinationState),
+				PaymentCurrency:  money.NormalizeCurrency(firstNonEmpty(order.PaymentCurrency, order.Total.Currency)),
+				Installments:     orderInstallments(order.Installments),
+				PaymentMethod:    paymentMethod(order.PaymentMethod),
+				Taxes:            orderTaxes(order),
+				Status:           order.Status,
+				CreatedAt:        order.CreatedAt,
 			}, nil
 		}
 	}
@@ -299,6 +390,11 @@ func (r *queryResolver) Order(ctx context.Context, id string) (*model.Order, err
 func (r *queryResolver) User(ctx context.Context, id string) (*model.User, error) {
 	log.Printf("👤 GraphQL: Buscando usuário ID %s", id)
 
+	// Usuários comuns só podem consultar o próprio cadastro
+	if !auth.FromContext(ctx).CanAccessCustomer(id) {
+		return nil, auth.ErrForbidden
+	}
+
 	// Para simplicidade, listar todos e filtrar
 	users, err := r.UserClient.ListUsers(ctx)
 	if err != nil {
@@ -328,25 +424,74 @@ func (r *queryResolver) User(ctx context.Context, id string) (*model.User, error
 func (r *queryResolver) Payment(ctx context.Context, id string) (*model.Payment, error) {
 	log.Printf("💳 GraphQL: Buscando pagamento ID %s", id)
 
-	orderID, err := strconv.Atoi(id)
+	paymentID, err := strconv.Atoi(id)
 	if err != nil {
 		return nil, err
 	}
 
-	payment, err := r.PaymentClient.GetPaymentStatus(ctx, uint32(orderID))
+	payment, err := r.PaymentClient.GetPayment(ctx, uint32(paymentID))
+	if status.Code(err) == codes.NotFound {
+		return nil, nil // Pagamento não encontrado
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.Payment{
-		ID:            strconv.Itoa(int(payment.OrderID)),
-		OrderID:       int(payment.OrderID),
-		UserID:        0, // Seria necessário consultar o pedido para obter o userID
-		Amount:        payment.Amount,
-		Status:        payment.Status,
-		PaymentMethod: "card",
-		CreatedAt:     "",
-	}, nil
+	// Garantir que o pedido do pagamento pertence ao usuário autenticado
+	order, err := r.Order(ctx, strconv.Itoa(int(payment.OrderID)))
+	if err != nil || order == nil {
+		return nil, err
+	}
+
+	return toPayment(payment, order.UserID), nil
+}
+
+// PixCharge is the resolver for the pixCharge field.
+func (r *queryResolver) PixCharge(ctx context.Context, orderID string, qrCodeSize *int) (*model.PixCharge, error) {
+	log.Printf("🔳 GraphQL: Buscando cobrança PIX do pedido %s", orderID)
+
+	id, err := strconv.Atoi(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Garantir que o pedido pertence ao usuário autenticado
+	order, err := r.Order(ctx, orderID)
+	if err != nil || order == nil {
+		return nil, err
+	}
+
+	size := int32(0)
+	if qrCodeSize != nil {
+		size = int32(*qrCodeSize)
+	}
+	charge, err := r.PaymentClient.GetPixCharge(ctx, uint32(id), size)
+	if err != nil {
+		return nil, err
+	}
+	return pixCharge(charge), nil
+}
+
+// Boleto is the resolver for the boleto field.
+func (r *queryResolver) Boleto(ctx context.Context, orderID string, includePDF *bool) (*model.Boleto, error) {
+	log.Printf("🧾 GraphQL: Buscando boleto do pedido %s", orderID)
+
+	id, err := strconv.Atoi(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Garantir que o pedido pertence ao usuário autenticado
+	order, err := r.Order(ctx, orderID)
+	if err != nil || order == nil {
+		return nil, err
+	}
+
+	response, err := r.PaymentClient.GetBoleto(ctx, uint32(id), includePDF != nil && *includePDF)
+	if err != nil {
+		return nil, err
+	}
+	return boleto(response), nil
 }
 
 // OrderSummary is the resolver for the orderSummary field.
@@ -366,7 +511,7 @@ func (r *queryResolver) OrderSummary(ctx context.Context, orderID string) (*mode
 	}
 
 	// Buscar pagamento
-	payment, err := r.Payment(ctx, orderID)
+	payment, err := r.orderPayment(ctx, order)
 	if err != nil {
 		payment = nil // Continuar mesmo se não encontrar pagamento
 	}
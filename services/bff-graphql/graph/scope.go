@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/auth"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
+)
+
+// scopeOrders mantém apenas os pedidos que o principal pode ver.
+// Administradores veem todos; demais usuários apenas os pedidos em que são o cliente.
+func scopeOrders(ctx context.Context, orders []*clients.OrderResponse) []*clients.OrderResponse {
+	principal := auth.FromContext(ctx)
+	if principal.IsAdmin() {
+		return orders
+	}
+
+	scoped := make([]*clients.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		if principal.CanAccessCustomer(order.Customer) {
+			scoped = append(scoped, order)
+		}
+	}
+	return scoped
+}
+
+// ownedOrderIDs retorna o conjunto de IDs de pedidos visíveis ao principal
+func (r *Resolver) ownedOrderIDs(ctx context.Context) (map[uint32]bool, error) {
+	orders, err := r.OrderClient.ListOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[uint32]bool)
+	for _, order := range scopeOrders(ctx, orders) {
+		ids[order.ID] = true
+	}
+	return ids, nil
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/auth"
+	"github.com/seu-usuario/go-microservices-architecture/bff-graphql/internal/clients"
+)
+
+// stubOrderClient devolve pedidos fixos, como o order-service, sem filtro por cliente
+type stubOrderClient struct {
+	orders []*clients.OrderResponse
+	err    error
+}
+
+func (c *stubOrderClient) CreateOrder(ctx context.Context, req *clients.OrderRequest) (*clients.OrderResponse, error) {
+	return &clients.OrderResponse{ID: 99, Customer: req.Customer}, nil
+}
+
+func (c *stubOrderClient) ListOrders(ctx context.Context) ([]*clients.OrderResponse, error) {
+	return c.orders, c.err
+}
+
+func (c *stubOrderClient) Close() error { return nil }
+
+// sampleOrders tem pedidos do cliente 7 e do cliente 8
+func sampleOrders() []*clients.OrderResponse {
+	return []*clients.OrderResponse{
+		{ID: 10, Customer: "7"},
+		{ID: 11, Customer: "8"},
+		{ID: 12, Customer: "7"},
+	}
+}
+
+func orderIDs(orders []*clients.OrderResponse) []uint32 {
+	ids := make([]uint32, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	return ids
+}
+
+func TestScopeOrders(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      []uint32
+	}{
+		{"admin vê todos", admin, []uint32{10, 11, 12}},
+		{"usuário vê apenas os próprios", customer, []uint32{10, 12}},
+		{"usuário sem pedidos", &auth.Principal{UserID: "9", Roles: []string{auth.RoleUser}}, []uint32{}},
+		{"cliente 8 não vê os do cliente 7", &auth.Principal{UserID: "8", Roles: []string{auth.RoleUser}}, []uint32{11}},
+		{"anônimo não vê nenhum", nil, []uint32{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			assert.Equal(t, tt.want, orderIDs(scopeOrders(ctx, sampleOrders())))
+		})
+	}
+}
+
+func TestOwnedOrderIDs(t *testing.T) {
+	resolver := &Resolver{OrderClient: &stubOrderClient{orders: sampleOrders()}}
+
+	ids, err := resolver.ownedOrderIDs(auth.WithPrincipal(context.Background(), customer))
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]bool{10: true, 12: true}, ids)
+
+	failing := &Resolver{OrderClient: &stubOrderClient{err: errors.New("order-service indisponível")}}
+	_, err = failing.ownedOrderIDs(auth.WithPrincipal(context.Background(), customer))
+	assert.Error(t, err)
+}
+
+func TestSchema_UserSeesOnlyOwnOrders(t *testing.T) {
+	resolver := &Resolver{OrderClient: &stubOrderClient{orders: sampleOrders()}}
+
+	type orderResult struct {
+		ID     string
+		UserID int `json:"user_id"`
+	}
+
+	t.Run("lista", func(t *testing.T) {
+		var resp struct{ Orders []orderResult }
+		require.NoError(t, newTestClient(resolver, customer).Post(`query { orders { id user_id } }`, &resp))
+		assert.Equal(t, []orderResult{{ID: "10", UserID: 7}, {ID: "12", UserID: 7}}, resp.Orders)
+
+		require.NoError(t, newTestClient(resolver, admin).Post(`query { orders { id user_id } }`, &resp))
+		assert.Len(t, resp.Orders, 3)
+	})
+
+	t.Run("pedido de outro cliente não é encontrado", func(t *testing.T) {
+		var resp struct{ Order *orderResult }
+		require.NoError(t, newTestClient(resolver, customer).Post(`query { order(id: "11") { id user_id } }`, &resp))
+		assert.Nil(t, resp.Order)
+
+		require.NoError(t, newTestClient(resolver, customer).Post(`query { order(id: "12") { id user_id } }`, &resp))
+		require.NotNil(t, resp.Order)
+		assert.Equal(t, "12", resp.Order.ID)
+	})
+
+	t.Run("anônimo é recusado", func(t *testing.T) {
+		var resp struct{ Orders []orderResult }
+		err := newTestClient(resolver, nil).Post(`query { orders { id } }`, &resp)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), auth.ErrUnauthenticated.Error())
+	})
+
+	t.Run("pedido em nome de outro cliente é recusado", func(t *testing.T) {
+		var resp struct{ CreateOrder *orderResult }
+		err := newTestClient(resolver, customer).Post(`mutation { createOrder(input: {user_id: 8, product_name: "Camiseta", sku: "CAM-001", quantity: 1}) { id } }`, &resp)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), auth.ErrForbidden.Error())
+	})
+}
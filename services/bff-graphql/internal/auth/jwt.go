@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims representa as claims esperadas nos tokens de acesso
+type Claims struct {
+	Email string   `json:"email,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+	Kind  string   `json:"kind,omitempty"`
+	AMR   []string `json:"amr,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Verifier valida tokens JWT assinados com HMAC-SHA256
+type Verifier struct {
+	secret []byte
+	issuer string
+	leeway time.Duration
+}
+
+// NewVerifier cria um novo validador de tokens
+func NewVerifier(secret, issuer string) (*Verifier, error) {
+	if secret == "" {
+		return nil, errors.New("JWT_SECRET não configurado")
+	}
+	return &Verifier{
+		secret: []byte(secret),
+		issuer: issuer,
+		leeway: 30 * time.Second,
+	}, nil
+}
+
+// Verify valida assinatura, expiração e emissor do token e retorna o principal
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.leeway),
+	}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return v.secret, nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("token inválido: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("token inválido: claim 'sub' ausente")
+	}
+
+	// Tokens de contas de serviço e desafios de MFA não são tokens de acesso de usuário
+	if claims.Kind != "" && claims.Kind != "user" {
+		return nil, fmt.Errorf("token inválido: tipo %q não aceito", claims.Kind)
+	}
+
+	return &Principal{
+		UserID: claims.Subject,
+		Email:  claims.Email,
+		Roles:  claims.Roles,
+		MFA:    slices.Contains(claims.AMR, "otp"),
+		Token:  token,
+	}, nil
+}
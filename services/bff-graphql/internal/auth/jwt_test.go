@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret = "segredo-de-teste"
+	testIssuer = "user-service"
+)
+
+// validClaims retorna as claims de um token de acesso válido do usuário 7
+func validClaims() Claims {
+	return Claims{
+		Email: "maria@example.com",
+		Roles: []string{RoleUser},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "7",
+			Issuer:    testIssuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+// signHS assina as claims com o algoritmo HMAC e o segredo informados
+func signHS(t *testing.T, method jwt.SigningMethod, secret string, claims Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+// signed assina as claims como o user-service: HS256 com o segredo compartilhado
+func signed(t *testing.T, edit func(c *Claims)) string {
+	t.Helper()
+	claims := validClaims()
+	if edit != nil {
+		edit(&claims)
+	}
+	return signHS(t, jwt.SigningMethodHS256, testSecret, claims)
+}
+
+func newTestVerifier(t *testing.T) *Verifier {
+	t.Helper()
+	verifier, err := NewVerifier(testSecret, testIssuer)
+	require.NoError(t, err)
+	return verifier
+}
+
+func TestNewVerifier_RequiresSecret(t *testing.T) {
+	_, err := NewVerifier("", testIssuer)
+	assert.Error(t, err)
+}
+
+func TestVerify_ValidToken(t *testing.T) {
+	token := signed(t, func(c *Claims) { c.AMR = []string{"pwd", "otp"} })
+
+	principal, err := newTestVerifier(t).Verify(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "7", principal.UserID)
+	assert.Equal(t, "maria@example.com", principal.Email)
+	assert.Equal(t, []string{RoleUser}, principal.Roles)
+	assert.True(t, principal.MFA)
+	assert.Equal(t, token, principal.Token)
+}
+
+func TestVerify_RejectsInvalidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rs256, err := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims()).SignedString(rsaKey)
+	require.NoError(t, err)
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	// Cabeçalho e assinatura de um token válido com as claims de outro token (papel ADMIN)
+	valid := strings.Split(signed(t, nil), ".")
+	other := strings.Split(signed(t, func(c *Claims) { c.Roles = []string{RoleAdmin} }), ".")
+	tampered := strings.Join([]string{valid[0], other[1], valid[2]}, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expirado", signed(t, func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) })},
+		{"sem expiração", signed(t, func(c *Claims) { c.ExpiresAt = nil })},
+		{"ainda não válido", signed(t, func(c *Claims) { c.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour)) })},
+		{"algoritmo HS512", signHS(t, jwt.SigningMethodHS512, testSecret, validClaims())},
+		{"algoritmo RS256", rs256},
+		{"algoritmo none", none},
+		{"assinado com outro segredo", signHS(t, jwt.SigningMethodHS256, "outro-segredo", validClaims())},
+		{"claims adulteradas", tampered},
+		{"emissor diferente", signed(t, func(c *Claims) { c.Issuer = "outro-servico" })},
+		{"sem sub", signed(t, func(c *Claims) { c.Subject = "" })},
+		{"conta de serviço", signed(t, func(c *Claims) { c.Kind = "service" })},
+		{"desafio de MFA", signed(t, func(c *Claims) { c.Kind = "mfa_challenge" })},
+		{"texto qualquer", "nao-e-um-jwt"},
+	}
+
+	verifier := newTestVerifier(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token)
+			assert.Error(t, err)
+			assert.Nil(t, principal)
+		})
+	}
+}
+
+func TestVerify_Leeway(t *testing.T) {
+	verifier := newTestVerifier(t)
+
+	// Dentro da tolerância de relógio de 30s o token ainda é aceito
+	_, err := verifier.Verify(signed(t, func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Second)) }))
+	assert.NoError(t, err)
+
+	_, err = verifier.Verify(signed(t, func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) }))
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+}
+
+func TestVerify_WithoutIssuerAcceptsAnyIssuer(t *testing.T) {
+	verifier, err := NewVerifier(testSecret, "")
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(signed(t, func(c *Claims) { c.Issuer = "outro-servico" }))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// Middleware valida o header "Authorization: Bearer <token>" e coloca o principal no contexto.
+// Requisições sem token seguem como anônimas; as diretivas do schema decidem o acesso.
+// Um token presente porém inválido é rejeitado com 401.
+func Middleware(verifier *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r.Header.Get("Authorization"))
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := verifier.Verify(token)
+			if err != nil {
+				log.Printf("🔒 Token rejeitado: %v", err)
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"errors":[{"message":"token inválido ou expirado"}]}`))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// WebsocketInit autentica conexões WebSocket a partir do payload de connection_init
+// ({"Authorization": "Bearer <token>"}), já que navegadores não enviam headers customizados.
+func WebsocketInit(verifier *Verifier) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		token, ok := bearerToken(payload.Authorization())
+		if !ok {
+			return ctx, &payload, nil
+		}
+
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			return ctx, nil, err
+		}
+
+		return WithPrincipal(ctx, principal), &payload, nil
+	}
+}
+
+// bearerToken extrai o token de um header Authorization no formato Bearer
+func bearerToken(header string) (string, bool) {
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(prefix):])
+	return token, token != ""
+}
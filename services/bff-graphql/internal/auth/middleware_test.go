@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve executa o middleware e retorna a resposta e o principal visto pelo próximo handler
+func serve(t *testing.T, authorization string) (*httptest.ResponseRecorder, *Principal, bool) {
+	t.Helper()
+	var (
+		called    bool
+		principal *Principal
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		principal = FromContext(r.Context())
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/query", nil)
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	Middleware(newTestVerifier(t))(next).ServeHTTP(recorder, request)
+	return recorder, principal, called
+}
+
+func TestMiddleware(t *testing.T) {
+	valid := signed(t, nil)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantNext      bool
+		wantUser      string
+	}{
+		{"sem token segue anônimo", "", http.StatusOK, true, ""},
+		{"outro esquema segue anônimo", "Basic dXNlcjpzZW5oYQ==", http.StatusOK, true, ""},
+		{"bearer vazio segue anônimo", "Bearer   ", http.StatusOK, true, ""},
+		{"token válido", "Bearer " + valid, http.StatusOK, true, "7"},
+		{"esquema em minúsculas", "bearer " + valid, http.StatusOK, true, "7"},
+		{"token expirado", "Bearer " + signed(t, func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour)) }), http.StatusUnauthorized, false, ""},
+		{"assinatura inválida", "Bearer " + valid + "x", http.StatusUnauthorized, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, principal, called := serve(t, tt.authorization)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantNext, called)
+			if tt.wantUser == "" {
+				assert.Nil(t, principal)
+			} else {
+				require.NotNil(t, principal)
+				assert.Equal(t, tt.wantUser, principal.UserID)
+			}
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer error="invalid_token"`, recorder.Header().Get("WWW-Authenticate"))
+				assert.JSONEq(t, `{"errors":[{"message":"token inválido ou expirado"}]}`, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebsocketInit(t *testing.T) {
+	initFunc := WebsocketInit(newTestVerifier(t))
+
+	t.Run("sem token segue anônimo", func(t *testing.T) {
+		ctx, payload, err := initFunc(context.Background(), transport.InitPayload{})
+		require.NoError(t, err)
+		assert.NotNil(t, payload)
+		assert.Nil(t, FromContext(ctx))
+	})
+
+	t.Run("token válido", func(t *testing.T) {
+		ctx, _, err := initFunc(context.Background(), transport.InitPayload{"Authorization": "Bearer " + signed(t, nil)})
+		require.NoError(t, err)
+		require.NotNil(t, FromContext(ctx))
+		assert.Equal(t, "7", FromContext(ctx).UserID)
+	})
+
+	t.Run("token inválido recusa a conexão", func(t *testing.T) {
+		token := signHS(t, jwt.SigningMethodHS512, testSecret, validClaims())
+		_, payload, err := initFunc(context.Background(), transport.InitPayload{"Authorization": "Bearer " + token})
+		assert.Error(t, err)
+		assert.Nil(t, payload)
+	})
+}
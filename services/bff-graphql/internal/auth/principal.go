@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Papéis reconhecidos pelo BFF
+const (
+	RoleAdmin = "ADMIN"
+	RoleUser  = "USER"
+)
+
+var (
+	// ErrUnauthenticated indica que a requisição não possui um token válido
+	ErrUnauthenticated = errors.New("autenticação obrigatória")
+	// ErrForbidden indica que o usuário autenticado não tem permissão para o recurso
+	ErrForbidden = errors.New("acesso negado")
+)
+
+// Principal representa o usuário autenticado extraído do JWT
+type Principal struct {
+	UserID string
+	Email  string
+	Roles  []string
+	Token  string
+	MFA    bool // login concluído com segundo fator (claim amr contém "otp")
+}
+
+// HasRole verifica se o principal possui o papel informado (case-insensitive)
+func (p *Principal) HasRole(role string) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin indica se o principal possui o papel de administrador
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+// CanAccessCustomer indica se o principal pode ver dados do cliente informado
+func (p *Principal) CanAccessCustomer(customer string) bool {
+	if p == nil {
+		return false
+	}
+	return p.IsAdmin() || p.UserID == customer
+}
+
+type principalKey struct{}
+
+// WithPrincipal retorna um novo contexto contendo o principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext retorna o principal do contexto, ou nil se a requisição for anônima
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// RequirePrincipal retorna o principal do contexto ou ErrUnauthenticated
+func RequirePrincipal(ctx context.Context) (*Principal, error) {
+	p := FromContext(ctx)
+	if p == nil {
+		return nil, ErrUnauthenticated
+	}
+	return p, nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_Roles(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		role      string
+		want      bool
+	}{
+		{"papel presente", &Principal{Roles: []string{RoleUser}}, RoleUser, true},
+		{"sem diferenciar maiúsculas", &Principal{Roles: []string{"admin"}}, RoleAdmin, true},
+		{"papel ausente", &Principal{Roles: []string{RoleUser}}, RoleAdmin, false},
+		{"sem papéis", &Principal{}, RoleUser, false},
+		{"anônimo", nil, RoleUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.principal.HasRole(tt.role))
+			assert.Equal(t, tt.want && tt.role == RoleAdmin, tt.principal.IsAdmin())
+		})
+	}
+}
+
+func TestPrincipal_CanAccessCustomer(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		customer  string
+		want      bool
+	}{
+		{"próprio cliente", &Principal{UserID: "7", Roles: []string{RoleUser}}, "7", true},
+		{"outro cliente", &Principal{UserID: "7", Roles: []string{RoleUser}}, "8", false},
+		{"administrador", &Principal{UserID: "1", Roles: []string{RoleAdmin}}, "8", true},
+		{"anônimo", nil, "7", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.principal.CanAccessCustomer(tt.customer))
+		})
+	}
+}
+
+func TestRequirePrincipal(t *testing.T) {
+	_, err := RequirePrincipal(context.Background())
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	principal := &Principal{UserID: "7"}
+	got, err := RequirePrincipal(WithPrincipal(context.Background(), principal))
+	assert.NoError(t, err)
+	assert.Same(t, principal, got)
+}
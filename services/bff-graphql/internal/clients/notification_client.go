@@ -64,15 +64,18 @@ type grpcNotificationClient struct {
 }
 
 // NewNotificationClient cria um novo cliente para notification-service
-func NewNotificationClient(grpcAddr string, timeout time.Duration) (NotificationClient, error) {
+func NewNotificationClient(grpcAddr string, timeout time.Duration, opts ...grpc.DialOption) (NotificationClient, error) {
 	log.Printf("🔌 Conectando ao Notification Service em %s...", grpcAddr)
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, grpcAddr,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock())
+		grpc.WithBlock(),
+	}, opts...)
+
+	conn, err := grpc.DialContext(ctx, grpcAddr, dialOpts...)
 	if err != nil {
 		log.Printf("❌ Erro ao conectar com Notification Service: %v", err)
 		return nil, err
@@ -7,24 +7,92 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 )
 
-// OrderRequest representa uma requisição de criação de pedido
+// OrderRequest representa uma requisição de criação de pedido. Os valores monetários
+// trafegam em centavos com a moeda (money.Money).
 type OrderRequest struct {
-	Customer  string  `json:"customer"`
-	ProductID uint32  `json:"product_id"`
-	Quantity  int32   `json:"quantity"`
-	Price     float64 `json:"price"`
+	Customer    string       `json:"customer"`
+	ProductID   uint32       `json:"product_id"`
+	Quantity    int32        `json:"quantity"`
+	Price       *money.Money `json:"price,omitempty"`
+	Sku         string       `json:"sku"`
+	CouponCodes []string     `json:"coupon_codes"`
+	// DestinationState é a UF de entrega, usada no cálculo dos tributos
+	DestinationState string `json:"destination_state"`
+	// PaymentCurrency é a moeda em que o cliente paga; vazia, a moeda do pedido
+	PaymentCurrency string `json:"payment_currency"`
+	// Installments é o número de parcelas; 0, à vista
+	Installments int32 `json:"installments"`
+	// PaymentMethod é a forma de pagamento (card, pix ou boleto); vazia, card
+	PaymentMethod string `json:"payment_method"`
+	// Risk são os sinais repassados à análise antifraude do pagamento
+	Risk *PaymentRisk `json:"risk,omitempty"`
+}
+
+// PaymentRisk são os sinais de risco do pagamento do pedido
+type PaymentRisk struct {
+	CardFingerprint string `json:"card_fingerprint,omitempty"`
+	BillingState    string `json:"billing_state,omitempty"`
+	IPCountry       string `json:"ip_country,omitempty"`
+	// CustomerSince é a data de cadastro do cliente (RFC 3339)
+	CustomerSince string `json:"customer_since,omitempty"`
+}
+
+// OrderAttribute representa um atributo da variação comprada
+type OrderAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// OrderDiscount representa o desconto de uma promoção em uma linha do pedido
+type OrderDiscount struct {
+	Line   int32       `json:"line"`
+	Sku    string      `json:"sku"`
+	Code   string      `json:"code"`
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Amount money.Money `json:"amount"`
+}
+
+// OrderTax representa um tributo calculado para uma linha do pedido
+type OrderTax struct {
+	Line     int32       `json:"line"`
+	Sku      string      `json:"sku"`
+	TaxClass string      `json:"tax_class"`
+	Tax      string      `json:"tax"`
+	State    string      `json:"state"`
+	Rate     float64     `json:"rate"`
+	Base     money.Money `json:"base"`
+	Amount   money.Money `json:"amount"`
+	Included bool        `json:"included"`
 }
 
 // OrderResponse representa a resposta de um pedido
 type OrderResponse struct {
-	ID        uint32  `json:"id"`
-	Customer  string  `json:"customer"`
-	ProductID uint32  `json:"product_id"`
-	Quantity  int32   `json:"quantity"`
-	Price     float64 `json:"price"`
-	CreatedAt string  `json:"created_at"`
+	ID               uint32            `json:"id"`
+	Customer         string            `json:"customer"`
+	ProductID        uint32            `json:"product_id"`
+	Quantity         int32             `json:"quantity"`
+	Price            money.Money       `json:"price"`
+	CreatedAt        string            `json:"created_at"`
+	Sku              string            `json:"sku"`
+	Status           string            `json:"status"`
+	VariantID        string            `json:"variant_id"`
+	ProductName      string            `json:"product_name"`
+	Attributes       []*OrderAttribute `json:"attributes"`
+	Subtotal         money.Money       `json:"subtotal"`
+	DiscountTotal    money.Money       `json:"discount_total"`
+	Total            money.Money       `json:"total"`
+	Discounts        []*OrderDiscount  `json:"discounts"`
+	DestinationState string            `json:"destination_state"`
+	TaxTotal         money.Money       `json:"tax_total"`
+	Taxes            []*OrderTax       `json:"taxes"`
+	PaymentCurrency  string            `json:"payment_currency"`
+	Installments     int32             `json:"installments"`
+	PaymentMethod    string            `json:"payment_method"`
 }
 
 // ListOrdersRequest representa uma requisição para listar pedidos
@@ -83,15 +151,18 @@ func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersReque
 }
 
 // NewOrderClient cria um novo cliente para order-service
-func NewOrderClient(grpcAddr string, timeout time.Duration) (OrderClient, error) {
+func NewOrderClient(grpcAddr string, timeout time.Duration, opts ...grpc.DialOption) (OrderClient, error) {
 	log.Printf("🔌 Conectando ao Order Service em %s...", grpcAddr)
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, grpcAddr,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock())
+		grpc.WithBlock(),
+	}, opts...)
+
+	conn, err := grpc.DialContext(ctx, grpcAddr, dialOpts...)
 	if err != nil {
 		log.Printf("❌ Erro ao conectar com Order Service: %v", err)
 		return nil, err
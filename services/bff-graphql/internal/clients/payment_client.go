@@ -7,6 +7,8 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 )
 
 // PaymentStatusRequest representa uma requisição de status de pagamento
@@ -16,20 +18,145 @@ type PaymentStatusRequest struct {
 
 // PaymentStatusResponse representa a resposta de status de pagamento
 type PaymentStatusResponse struct {
-	OrderID uint32  `json:"order_id"`
-	Status  string  `json:"status"`
-	Amount  float64 `json:"amount"`
+	OrderID     uint32      `json:"order_id"`
+	Status      string      `json:"status"`
+	Amount      money.Money `json:"amount"`
+	OrderAmount money.Money `json:"order_amount"`
+	FxRate      string      `json:"fx_rate"`
+	// InstallmentPlan é o parcelamento; ausente nos pagamentos anteriores a ele
+	InstallmentPlan *InstallmentPlan `json:"installment_plan"`
+	// Method é a forma de pagamento: card, pix ou boleto
+	Method string `json:"method"`
+}
+
+// PaymentRequest representa uma requisição de um pagamento pelo ID
+type PaymentRequest struct {
+	PaymentID uint32 `json:"payment_id"`
+}
+
+// PaymentsByOrdersRequest representa uma requisição dos pagamentos de vários pedidos
+type PaymentsByOrdersRequest struct {
+	OrderIDs []uint32 `json:"order_ids"`
+}
+
+// ListPaymentsRequest representa uma requisição da listagem paginada de pagamentos;
+// campos vazios não filtram e created_from/created_to vão em RFC 3339
+type ListPaymentsRequest struct {
+	Status      string       `json:"status"`
+	CreatedFrom string       `json:"created_from"`
+	CreatedTo   string       `json:"created_to"`
+	MinAmount   *money.Money `json:"min_amount"`
+	MaxAmount   *money.Money `json:"max_amount"`
+	OrderIDs    []uint32     `json:"order_ids"`
+	PageSize    int32        `json:"page_size"`
+	PageToken   string       `json:"page_token"`
+}
+
+// PaymentRecord representa um pagamento completo, com ID e data de criação
+type PaymentRecord struct {
+	ID              uint32           `json:"id"`
+	OrderID         uint32           `json:"order_id"`
+	Status          string           `json:"status"`
+	CreatedAt       string           `json:"created_at"`
+	Amount          money.Money      `json:"amount"`
+	OrderAmount     money.Money      `json:"order_amount"`
+	FxRate          string           `json:"fx_rate"`
+	InstallmentPlan *InstallmentPlan `json:"installment_plan"`
+	Method          string           `json:"method"`
+}
+
+// PaymentsResponse representa uma lista de pagamentos
+type PaymentsResponse struct {
+	Payments []*PaymentRecord `json:"payments"`
+}
+
+// ListPaymentsResponse representa uma página de pagamentos; NextPageToken vazio
+// indica a última página
+type ListPaymentsResponse struct {
+	Payments      []*PaymentRecord `json:"payments"`
+	NextPageToken string           `json:"next_page_token"`
+}
+
+// MaxPaymentsByOrders é o máximo de pedidos por chamada de GetPaymentsByOrderIDs
+const MaxPaymentsByOrders = 500
+
+// PixChargeRequest representa uma requisição da cobrança PIX de um pedido
+type PixChargeRequest struct {
+	OrderID    uint32 `json:"order_id"`
+	QRCodeSize int32  `json:"qr_code_size"`
+}
+
+// PixChargeResponse representa a cobrança PIX: BR Code (copia e cola) e QR Code em PNG
+type PixChargeResponse struct {
+	OrderID    uint32      `json:"order_id"`
+	TxID       string      `json:"txid"`
+	Kind       string      `json:"kind"`
+	Payload    string      `json:"payload"`
+	QRCodePNG  []byte      `json:"qr_code_png"`
+	Amount     money.Money `json:"amount"`
+	Status     string      `json:"status"`
+	ExpiresAt  string      `json:"expires_at"`
+	PaidAt     string      `json:"paid_at"`
+	EndToEndID string      `json:"end_to_end_id"`
+}
+
+// BoletoRequest representa uma requisição do boleto de um pedido
+type BoletoRequest struct {
+	OrderID    uint32 `json:"order_id"`
+	IncludePDF bool   `json:"include_pdf"`
+}
+
+// BoletoResponse representa o boleto: linha digitável, código de barras e PDF
+type BoletoResponse struct {
+	OrderID       uint32      `json:"order_id"`
+	NossoNumero   string      `json:"nosso_numero"`
+	Barcode       string      `json:"barcode"`
+	DigitableLine string      `json:"digitable_line"`
+	Amount        money.Money `json:"amount"`
+	DueDate       string      `json:"due_date"`
+	Status        string      `json:"status"`
+	ExpiresAt     string      `json:"expires_at"`
+	PaidAt        string      `json:"paid_at"`
+	PaidAmount    money.Money `json:"paid_amount"`
+	PDF           []byte      `json:"pdf"`
+}
+
+// InstallmentPlan representa o parcelamento de um pagamento
+type InstallmentPlan struct {
+	Installments int32          `json:"installments"`
+	InterestFree bool           `json:"interest_free"`
+	MonthlyRate  float64        `json:"monthly_rate"`
+	Interest     money.Money    `json:"interest"`
+	Total        money.Money    `json:"total"`
+	Schedule     []*Installment `json:"schedule"`
+}
+
+// Installment representa uma parcela do cronograma
+type Installment struct {
+	Number  int32       `json:"number"`
+	Amount  money.Money `json:"amount"`
+	DueDate string      `json:"due_date"`
 }
 
 // PaymentClient interface para comunicação com payment-service
 type PaymentClient interface {
 	GetPaymentStatus(ctx context.Context, orderID uint32) (*PaymentStatusResponse, error)
+	GetPayment(ctx context.Context, paymentID uint32) (*PaymentRecord, error)
+	ListPayments(ctx context.Context, req *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	GetPaymentsByOrderIDs(ctx context.Context, orderIDs []uint32) ([]*PaymentRecord, error)
+	GetPixCharge(ctx context.Context, orderID uint32, qrCodeSize int32) (*PixChargeResponse, error)
+	GetBoleto(ctx context.Context, orderID uint32, includePDF bool) (*BoletoResponse, error)
 	Close() error
 }
 
 // PaymentServiceClient interface gRPC (simplificada)
 type PaymentServiceClient interface {
 	GetPaymentStatus(ctx context.Context, in *PaymentStatusRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error)
+	GetPayment(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*PaymentRecord, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	GetPaymentsByOrderIDs(ctx context.Context, in *PaymentsByOrdersRequest, opts ...grpc.CallOption) (*PaymentsResponse, error)
+	GetPixCharge(ctx context.Context, in *PixChargeRequest, opts ...grpc.CallOption) (*PixChargeResponse, error)
+	GetBoleto(ctx context.Context, in *BoletoRequest, opts ...grpc.CallOption) (*BoletoResponse, error)
 }
 
 // paymentServiceClient implementa PaymentServiceClient
@@ -51,6 +178,51 @@ func (c *paymentServiceClient) GetPaymentStatus(ctx context.Context, in *Payment
 	return out, nil
 }
 
+func (c *paymentServiceClient) GetPayment(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*PaymentRecord, error) {
+	out := new(PaymentRecord)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ListPayments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetPaymentsByOrderIDs(ctx context.Context, in *PaymentsByOrdersRequest, opts ...grpc.CallOption) (*PaymentsResponse, error) {
+	out := new(PaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPaymentsByOrderIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetPixCharge(ctx context.Context, in *PixChargeRequest, opts ...grpc.CallOption) (*PixChargeResponse, error) {
+	out := new(PixChargeResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPixCharge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetBoleto(ctx context.Context, in *BoletoRequest, opts ...grpc.CallOption) (*BoletoResponse, error) {
+	out := new(BoletoResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetBoleto", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // grpcPaymentClient implementa PaymentClient usando gRPC
 type grpcPaymentClient struct {
 	conn   *grpc.ClientConn
@@ -58,15 +230,18 @@ type grpcPaymentClient struct {
 }
 
 // NewPaymentClient cria um novo cliente para payment-service
-func NewPaymentClient(grpcAddr string, timeout time.Duration) (PaymentClient, error) {
+func NewPaymentClient(grpcAddr string, timeout time.Duration, opts ...grpc.DialOption) (PaymentClient, error) {
 	log.Printf("🔌 Conectando ao Payment Service em %s...", grpcAddr)
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, grpcAddr,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock())
+		grpc.WithBlock(),
+	}, opts...)
+
+	conn, err := grpc.DialContext(ctx, grpcAddr, dialOpts...)
 	if err != nil {
 		log.Printf("❌ Erro ao conectar com Payment Service: %v", err)
 		return nil, err
@@ -96,6 +271,74 @@ func (c *grpcPaymentClient) GetPaymentStatus(ctx context.Context, orderID uint32
 	return response, nil
 }
 
+// GetPayment obtém um pagamento pelo ID
+func (c *grpcPaymentClient) GetPayment(ctx context.Context, paymentID uint32) (*PaymentRecord, error) {
+	log.Printf("💳 Consultando pagamento ID: %d", paymentID)
+
+	response, err := c.client.GetPayment(ctx, &PaymentRequest{PaymentID: paymentID})
+	if err != nil {
+		log.Printf("❌ Erro ao consultar pagamento: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListPayments obtém uma página de pagamentos com os filtros informados
+func (c *grpcPaymentClient) ListPayments(ctx context.Context, req *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	log.Printf("💳 Listando pagamentos")
+
+	response, err := c.client.ListPayments(ctx, req)
+	if err != nil {
+		log.Printf("❌ Erro ao listar pagamentos: %v", err)
+		return nil, err
+	}
+
+	log.Printf("✅ %d pagamentos listados", len(response.Payments))
+	return response, nil
+}
+
+// GetPaymentsByOrderIDs obtém os pagamentos dos pedidos informados, em lotes de até
+// MaxPaymentsByOrders pedidos por chamada
+func (c *grpcPaymentClient) GetPaymentsByOrderIDs(ctx context.Context, orderIDs []uint32) ([]*PaymentRecord, error) {
+	log.Printf("💳 Consultando pagamentos de %d pedidos", len(orderIDs))
+
+	var payments []*PaymentRecord
+	for start := 0; start < len(orderIDs); start += MaxPaymentsByOrders {
+		end := min(start+MaxPaymentsByOrders, len(orderIDs))
+		response, err := c.client.GetPaymentsByOrderIDs(ctx, &PaymentsByOrdersRequest{OrderIDs: orderIDs[start:end]})
+		if err != nil {
+			log.Printf("❌ Erro ao consultar pagamentos dos pedidos: %v", err)
+			return nil, err
+		}
+		payments = append(payments, response.Payments...)
+	}
+	return payments, nil
+}
+
+// GetPixCharge obtém a cobrança PIX de um pedido, com o QR Code no tamanho pedido
+func (c *grpcPaymentClient) GetPixCharge(ctx context.Context, orderID uint32, qrCodeSize int32) (*PixChargeResponse, error) {
+	log.Printf("🔳 Consultando cobrança PIX do order ID: %d", orderID)
+
+	response, err := c.client.GetPixCharge(ctx, &PixChargeRequest{OrderID: orderID, QRCodeSize: qrCodeSize})
+	if err != nil {
+		log.Printf("❌ Erro ao consultar cobrança PIX: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetBoleto obtém o boleto de um pedido, com o PDF se pedido
+func (c *grpcPaymentClient) GetBoleto(ctx context.Context, orderID uint32, includePDF bool) (*BoletoResponse, error) {
+	log.Printf("🧾 Consultando boleto do order ID: %d", orderID)
+
+	response, err := c.client.GetBoleto(ctx, &BoletoRequest{OrderID: orderID, IncludePDF: includePDF})
+	if err != nil {
+		log.Printf("❌ Erro ao consultar boleto: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
 // Close fecha a conexão
 func (c *grpcPaymentClient) Close() error {
 	log.Printf("🔌 Fechando conexão com Payment Service...")
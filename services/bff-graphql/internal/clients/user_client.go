@@ -79,15 +79,18 @@ type grpcUserClient struct {
 }
 
 // NewUserClient cria um novo cliente para user-service
-func NewUserClient(grpcAddr string, timeout time.Duration) (UserClient, error) {
+func NewUserClient(grpcAddr string, timeout time.Duration, opts ...grpc.DialOption) (UserClient, error) {
 	log.Printf("🔌 Conectando ao User Service em %s...", grpcAddr)
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, grpcAddr,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock())
+		grpc.WithBlock(),
+	}, opts...)
+
+	conn, err := grpc.DialContext(ctx, grpcAddr, dialOpts...)
 	if err != nil {
 		log.Printf("❌ Erro ao conectar com User Service: %v", err)
 		return nil, err
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -26,6 +27,19 @@ type Config struct {
 	PaymentServiceURL      string
 	NotificationServiceURL string
 
+	// Autenticação
+	JWTSecret      string
+	JWTIssuer      string
+	AllowedOrigins []string
+
+	// GeoCountryHeader é o header com o país do IP do cliente, preenchido pelo proxy ou
+	// CDN; usado como sinal da análise antifraude dos pagamentos
+	GeoCountryHeader string
+
+	// Conta de serviço usada nas chamadas para os microserviços
+	ServiceClientID     string
+	ServiceClientSecret string
+
 	// Configurações gerais
 	Environment string
 	GRPCTimeout time.Duration
@@ -49,6 +63,12 @@ func LoadConfig() *Config {
 		PaymentGRPCAddr:      getEnv("PAYMENT_SERVICE_URL", "localhost:50053"),
 		NotificationGRPCAddr: getEnv("NOTIFICATION_SERVICE_URL", "localhost:50055"),
 		Environment:          getEnv("GO_ENV", "development"),
+		JWTSecret:            getEnv("JWT_SECRET", ""),
+		JWTIssuer:            getEnv("JWT_ISSUER", "user-service"),
+		AllowedOrigins:       getEnvAsList("ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173"}),
+		GeoCountryHeader:     getEnv("GEO_COUNTRY_HEADER", "CF-IPCountry"),
+		ServiceClientID:      getEnv("SERVICE_CLIENT_ID", "bff-graphql"),
+		ServiceClientSecret:  getEnv("SERVICE_CLIENT_SECRET", ""),
 		GRPCTimeout:          getEnvAsDuration("GRPC_TIMEOUT", 10*time.Second),
 		LogLevel:             getEnv("LOG_LEVEL", "info"),
 	}
@@ -90,3 +110,19 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getEnvAsList obtém variável de ambiente como lista separada por vírgulas
+func getEnvAsList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
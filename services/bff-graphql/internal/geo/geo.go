@@ -0,0 +1,35 @@
+// Package geo identifica o país de origem das requisições a partir do header
+// preenchido pelo proxy ou CDN à frente do BFF (ex.: CF-IPCountry, da Cloudflare).
+package geo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type countryKey struct{}
+
+// Middleware coloca no contexto o país (ISO 3166-1 alfa-2) lido do header informado.
+// Sem header configurado, ou com um valor que não tenha duas letras, o país fica vazio.
+func Middleware(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if header == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			country := strings.ToUpper(strings.TrimSpace(r.Header.Get(header)))
+			if len(country) != 2 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), countryKey{}, country)))
+		})
+	}
+}
+
+// CountryFromContext retorna o país da requisição, ou vazio se desconhecido
+func CountryFromContext(ctx context.Context) string {
+	country, _ := ctx.Value(countryKey{}).(string)
+	return country
+}
@@ -0,0 +1,273 @@
+// Command catalogctl importa e exporta produtos do catálogo em CSV ou JSON Lines
+// usando os RPCs ImportProducts e ExportProducts do CatalogService.
+//
+// Uso:
+//
+//	catalogctl import [-dry-run] [-batch 100] [-report erros.csv] produtos.csv
+//	catalogctl export [-format jsonl] [-category ID] [-include-inactive] [-o produtos.jsonl]
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/bulk"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// Tamanho das partes enviadas na importação
+const chunkSize = 32 * 1024
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "-h", "--help", "help":
+		usage()
+		return
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "uso: catalogctl <import|export> [opções]")
+	fmt.Fprintln(os.Stderr, "  catalogctl import [-dry-run] [-batch N] [-report erros.csv] <arquivo.csv|arquivo.jsonl|->")
+	fmt.Fprintln(os.Stderr, "  catalogctl export [-format csv|jsonl] [-category ID] [-include-inactive] [-o arquivo]")
+}
+
+// connection contém as opções de conexão comuns aos subcomandos
+type connection struct {
+	addr  string
+	token string
+}
+
+func (c *connection) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.addr, "addr", getEnv("CATALOG_SERVICE_URL", "localhost:50054"), "endereço gRPC do catalog-service")
+	fs.StringVar(&c.token, "token", os.Getenv("CATALOG_TOKEN"), "token de acesso (Bearer) com catalog:write ou catalog:read")
+}
+
+func (c *connection) dial() (proto.CatalogServiceClient, *grpc.ClientConn, context.Context, error) {
+	conn, err := grpc.Dial(c.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("erro ao conectar em %s: %w", c.addr, err)
+	}
+	ctx := context.Background()
+	if c.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	}
+	return proto.NewCatalogServiceClient(conn), conn, ctx, nil
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	format := fs.String("format", "", "formato do arquivo: csv ou jsonl (padrão: extensão do arquivo)")
+	batch := fs.Int("batch", 0, "linhas gravadas por transação (padrão do servidor: 100)")
+	dryRun := fs.Bool("dry-run", false, "valida e simula a gravação sem alterar o catálogo")
+	reportPath := fs.String("report", "", "grava as linhas rejeitadas neste arquivo CSV")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("informe o arquivo a importar (ou - para a entrada padrão)")
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = filepath.Ext(path)
+	}
+	if _, err := bulk.NormalizeFormat(*format); err != nil {
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	stream, err := client.ImportProducts(ctx)
+	if err != nil {
+		return err
+	}
+
+	first := &proto.ImportProductsRequest{Format: *format, DryRun: *dryRun, BatchSize: int32(*batch)}
+	buffer := make([]byte, chunkSize)
+	for {
+		n, readErr := input.Read(buffer)
+		if n > 0 {
+			req := &proto.ImportProductsRequest{}
+			if first != nil {
+				req, first = first, nil
+			}
+			req.Chunk = append([]byte(nil), buffer[:n]...)
+			if err := stream.Send(req); err != nil {
+				// o servidor encerrou o stream; o motivo vem em CloseAndRecv
+				break
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+	if first != nil {
+		if err := stream.Send(first); err != nil {
+			return err
+		}
+	}
+
+	report, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	mode := ""
+	if report.GetDryRun() {
+		mode = " (simulação, nada foi gravado)"
+	}
+	fmt.Printf("📦 Importação concluída%s: %d linhas, %d criadas, %d atualizadas, %d rejeitadas\n",
+		mode, report.GetTotalRows(), report.GetCreated(), report.GetUpdated(), report.GetFailed())
+	for _, rowErr := range report.GetErrors() {
+		fmt.Fprintf(os.Stderr, "  linha %d (SKU %s): %s\n", rowErr.GetLine(), rowErr.GetSku(), rowErr.GetMessage())
+	}
+	if report.GetErrorsTruncated() {
+		fmt.Fprintf(os.Stderr, "  ... lista de erros truncada em %d linhas\n", len(report.GetErrors()))
+	}
+
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, report.GetErrors()); err != nil {
+			return err
+		}
+	}
+	if report.GetFailed() > 0 {
+		return fmt.Errorf("%d linhas rejeitadas", report.GetFailed())
+	}
+	return nil
+}
+
+// writeReport grava as linhas rejeitadas em CSV (line, sku, message)
+func writeReport(path string, rowErrors []*proto.ImportRowError) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"line", "sku", "message"})
+	for _, rowErr := range rowErrors {
+		writer.Write([]string{strconv.Itoa(int(rowErr.GetLine())), rowErr.GetSku(), rowErr.GetMessage()})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	format := fs.String("format", "", "formato de saída: csv ou jsonl (padrão: extensão de -o, ou csv)")
+	category := fs.String("category", "", "exporta apenas os produtos desta categoria")
+	includeInactive := fs.Bool("include-inactive", false, "inclui produtos e variações inativos")
+	outPath := fs.String("o", "-", "arquivo de saída (- para a saída padrão)")
+	fs.Parse(args)
+
+	if *format == "" {
+		*format = bulk.FormatCSV
+		if *outPath != "-" && filepath.Ext(*outPath) != "" {
+			*format = filepath.Ext(*outPath)
+		}
+	}
+	if _, err := bulk.NormalizeFormat(*format); err != nil {
+		return err
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	stream, err := client.ExportProducts(ctx, &proto.ExportProductsRequest{
+		Format:          *format,
+		CategoryId:      *category,
+		IncludeInactive: *includeInactive,
+	})
+	if err != nil {
+		return err
+	}
+
+	output := io.Writer(os.Stdout)
+	if *outPath != "-" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := output.Write(chunk.GetChunk()); err != nil {
+			return err
+		}
+	}
+
+	if file, ok := output.(*os.File); ok && file != os.Stdout {
+		return file.Close()
+	}
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/config"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/telemetry"
+	grpcServer "github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/transport/grpc"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+)
+
+func main() {
+	// 🔧 Carregar configuração
+	cfg := config.Load()
+
+	// 📊 Inicializar métricas Prometheus
+	log.Println("📊 Inicializando métricas Prometheus...")
+	metrics.Init()
+
+	// 🔍 Inicializar OpenTelemetry Tracing
+	log.Println("🔍 Inicializando OpenTelemetry Tracing...")
+	ctx := context.Background()
+	shutdown := telemetry.InitTracer("catalog-service")
+	defer shutdown(ctx)
+
+	// 🔐 Validador de tokens para autorização das chamadas gRPC
+	tokens, err := auth.NewTokenManager(cfg.JWTSecret, cfg.JWTIssuer)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar autenticação: %v", err)
+	}
+
+	// 🧩 Conectar ao banco de dados
+	log.Println("🔗 Conectando ao banco de dados...")
+	db := config.ConnectDatabase(cfg)
+
+	// ⚙️ Executar migração automática
+	if err := config.AutoMigrate(db); err != nil {
+		log.Fatalf("❌ Erro ao executar migração: %v", err)
+	}
+
+	// 🏗️ Inicializar dependências
+	log.Println("🏗️  Inicializando dependências...")
+	categoryRepo := repository.NewCategoryRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	variantRepo := repository.NewVariantRepository(db)
+	reservationRepo := repository.NewReservationRepository(db)
+	bulkRepo := repository.NewBulkRepository(db)
+	priceRepo := repository.NewPriceRepository(db)
+	searchIndex := search.NewIndex()
+	catalogService := service.NewCatalogService(categoryRepo, productRepo, variantRepo, searchIndex)
+	inventoryService := service.NewInventoryService(productRepo, variantRepo, reservationRepo, cfg.ReservationTTL)
+	bulkService := service.NewBulkService(categoryRepo, productRepo, bulkRepo, searchIndex)
+	priceService := service.NewPriceService(productRepo, variantRepo, priceRepo, searchIndex)
+	catalogGRPCServer := grpcServer.NewCatalogGRPCServer(catalogService, inventoryService, bulkService, priceService)
+
+	// 🔎 Montar o índice de busca; alterações posteriores o atualizam incrementalmente
+	if _, err := catalogService.ReindexProducts(); err != nil {
+		log.Fatalf("❌ Erro ao montar índice de busca: %v", err)
+	}
+
+	// ⏰ Devolver ao estoque as reservas não confirmadas dentro do prazo
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	defer stopWorker()
+	go inventoryService.RunExpiryWorker(workerCtx, cfg.ReservationSweepInterval)
+
+	// 🏷️ Aplicar preços agendados (promoções) quando a vigência começa ou termina
+	go priceService.RunScheduler(workerCtx, cfg.PriceSchedulerInterval)
+
+	// 🚀 Configurar servidor gRPC
+	lis, err := net.Listen("tcp", ":"+cfg.ServicePort)
+	if err != nil {
+		log.Fatalf("❌ Erro ao iniciar listener: %v", err)
+	}
+
+	// 📡 Criar e configurar servidor gRPC com autorização por método
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens, grpcServer.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens, grpcServer.MethodPermissions)),
+	)
+	proto.RegisterCatalogServiceServer(s, catalogGRPCServer)
+	metrics.SetServiceHealth(true)
+
+	// 🎯 Iniciar servidor em goroutine
+	go func() {
+		log.Printf("🚀 CatalogService rodando em gRPC :%s", cfg.ServicePort)
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("❌ Erro ao servir gRPC: %v", err)
+		}
+	}()
+
+	// 🛡️ Graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("🛑 Desligando CatalogService...")
+	metrics.SetServiceHealth(false)
+	stopWorker()
+	s.GracefulStop()
+
+	log.Println("✅ CatalogService desligado com sucesso")
+}
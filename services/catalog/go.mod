@@ -3,9 +3,14 @@ module github.com/seu-usuario/go-microservices-architecture/services/catalog
 go 1.25.0
 
 require (
+	github.com/golang-jwt/jwt/v5 v5.3.0 // indirect
+	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/prometheus/client_golang v1.17.0
 	github.com/rabbitmq/amqp091-go v1.10.0
+	github.com/seu-usuario/go-microservices-architecture/services/shared/auth v0.0.0-00010101000000-000000000000
+	github.com/seu-usuario/go-microservices-architecture/services/shared/money v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.9.0
 	go.opentelemetry.io/otel v1.21.0
 	go.opentelemetry.io/otel/exporters/jaeger v1.17.0
 	go.opentelemetry.io/otel/sdk v1.21.0
@@ -14,3 +19,7 @@ require (
 	gorm.io/driver/mysql v1.6.0
 	gorm.io/gorm v1.31.0
 )
+
+replace github.com/seu-usuario/go-microservices-architecture/services/shared/auth => ../shared/auth
+
+replace github.com/seu-usuario/go-microservices-architecture/services/shared/money => ../shared/money
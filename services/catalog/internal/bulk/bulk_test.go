@@ -0,0 +1,159 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAll lê todas as linhas, separando as válidas dos erros de linha
+func readAll(t *testing.T, reader Reader) ([]*Row, []*RowError) {
+	var (
+		rows   []*Row
+		errors []*RowError
+	)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows, errors
+		}
+		if rowErr, ok := err.(*RowError); ok {
+			errors = append(errors, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVReader_SemicolonAndCommaDecimal(t *testing.T) {
+	input := "\ufeffSKU;name;category_id;price;stock_quantity;is_active\n" +
+		"CAM-001;Camiseta;cat-002;49,90;10;sim\n" +
+		"CAM-002;Camiseta Gola V;cat-002;59.90;;não\n"
+
+	reader, err := NewReader("csv", strings.NewReader(input))
+	require.NoError(t, err)
+	rows, rowErrors := readAll(t, reader)
+
+	require.Empty(t, rowErrors)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "CAM-001", rows[0].SKU)
+	assert.Equal(t, json.Number("49.90"), rows[0].Price)
+	assert.Empty(t, rows[0].Currency)
+	assert.Equal(t, 10, rows[0].StockQuantity)
+	assert.True(t, rows[0].Active())
+	assert.Equal(t, 3, rows[1].Line)
+	assert.False(t, rows[1].Active())
+}
+
+func TestCSVReader_RowErrorsDoNotStopReading(t *testing.T) {
+	input := "sku,parent_sku,price,attributes\n" +
+		"CAM-001-P,CAM-001,abc,cor=azul\n" +
+		"CAM-001-M,CAM-001,49.90,tamanho\n" +
+		"CAM-001-G,CAM-001,49.90,cor=azul|tamanho=G\n"
+
+	reader, err := NewReader(".csv", strings.NewReader(input))
+	require.NoError(t, err)
+	rows, rowErrors := readAll(t, reader)
+
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, 2, rowErrors[0].Line)
+	assert.Equal(t, "CAM-001-P", rowErrors[0].SKU)
+	assert.Contains(t, rowErrors[0].Message, "preço inválido")
+	assert.Equal(t, 3, rowErrors[1].Line)
+
+	require.Len(t, rows, 1)
+	assert.True(t, rows[0].IsVariant())
+	assert.Equal(t, map[string]string{"cor": "azul", "tamanho": "G"}, rows[0].Attributes)
+}
+
+func TestCSVReader_InvalidHeader(t *testing.T) {
+	for _, input := range []string{"", "name,price\n", "sku,color\n", "sku,sku\n"} {
+		reader, err := NewReader("csv", strings.NewReader(input))
+		require.NoError(t, err)
+
+		_, err = reader.Read()
+		assert.Error(t, err, "entrada %q", input)
+		assert.NotErrorIs(t, err, io.EOF)
+	}
+}
+
+func TestJSONLReader(t *testing.T) {
+	input := `{"sku":"CAM-001","name":"Camiseta","category_id":"cat-002","price":49.9}` + "\n" +
+		"\n" +
+		`{"sku":"CAM-001-P","parent_sku":"CAM-001","price":49.9,"attributes":{"tamanho":"P"},"is_active":false}` + "\n" +
+		`{"sku":"CAM-002","preco":10}` + "\n" +
+		`{"sku":`
+
+	reader, err := NewReader("ndjson", strings.NewReader(input))
+	require.NoError(t, err)
+	rows, rowErrors := readAll(t, reader)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].Line)
+	assert.True(t, rows[0].Active())
+	assert.Equal(t, 3, rows[1].Line)
+	assert.False(t, rows[1].Active())
+	assert.Equal(t, "P", rows[1].Attributes["tamanho"])
+
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, 4, rowErrors[0].Line)
+	assert.Equal(t, 5, rowErrors[1].Line)
+}
+
+func TestRoundTrip(t *testing.T) {
+	inactive := false
+	rows := []*Row{
+		{SKU: "CAM-001", Name: "Camiseta, gola \"V\"", Description: "Algodão\nfio 30", CategoryID: "cat-002", Price: "49.90", Currency: "USD", StockQuantity: 3},
+		{SKU: "CAM-001-P", ParentSKU: "CAM-001", Price: "49.90", Currency: "USD", StockQuantity: 1, Barcode: "7891234567895",
+			Attributes: map[string]string{"tamanho": "P", "cor": "azul"}, IsActive: &inactive},
+	}
+
+	for _, format := range []string{FormatCSV, FormatJSONL} {
+		t.Run(format, func(t *testing.T) {
+			var buffer bytes.Buffer
+			writer, err := NewWriter(format, &buffer)
+			require.NoError(t, err)
+			for _, row := range rows {
+				require.NoError(t, writer.Write(row))
+			}
+			require.NoError(t, writer.Flush())
+
+			reader, err := NewReader(format, &buffer)
+			require.NoError(t, err)
+			got, rowErrors := readAll(t, reader)
+
+			require.Empty(t, rowErrors)
+			require.Len(t, got, len(rows))
+			for i, row := range rows {
+				assert.Equal(t, row.SKU, got[i].SKU)
+				assert.Equal(t, row.ParentSKU, got[i].ParentSKU)
+				assert.Equal(t, row.Name, got[i].Name)
+				assert.Equal(t, row.Description, got[i].Description)
+				assert.Equal(t, row.Price, got[i].Price)
+				assert.Equal(t, row.Currency, got[i].Currency)
+				assert.Equal(t, row.StockQuantity, got[i].StockQuantity)
+				assert.Equal(t, row.Barcode, got[i].Barcode)
+				assert.Equal(t, row.Active(), got[i].Active())
+				if len(row.Attributes) > 0 {
+					assert.Equal(t, row.Attributes, got[i].Attributes)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeFormat(t *testing.T) {
+	format, err := NormalizeFormat(".JSONL")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSONL, format)
+
+	_, err = NormalizeFormat("xlsx")
+	assert.Error(t, err)
+}
@@ -0,0 +1,262 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Colunas do CSV, na ordem usada pela exportação. Na importação a ordem é livre
+// e apenas "sku" é obrigatória.
+var csvColumns = []string{
+	"sku", "parent_sku", "name", "description", "category_id", "price", "currency",
+	"stock_quantity", "image_url", "barcode", "attributes", "is_active", "tax_class",
+}
+
+// Separadores do campo attributes: "cor=Azul|tamanho=M"
+const (
+	attributeSeparator = "|"
+	attributeAssign    = "="
+)
+
+// csvReader lê linhas de um CSV com cabeçalho. Aceita vírgula ou ponto e vírgula
+// (padrão das planilhas em português) como separador.
+type csvReader struct {
+	source  *bufio.Reader
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	return &csvReader{source: bufio.NewReader(r)}
+}
+
+// Read retorna a próxima linha do CSV
+func (c *csvReader) Read() (*Row, error) {
+	if c.reader == nil {
+		if err := c.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := c.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()}
+		}
+		return nil, err
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	return c.parse(line, record)
+}
+
+func (c *csvReader) readHeader() error {
+	first, err := c.source.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return err
+	}
+	firstLine, _, _ := strings.Cut(string(first), "\n")
+
+	c.reader = csv.NewReader(c.source)
+	c.reader.FieldsPerRecord = -1
+	c.reader.TrimLeadingSpace = true
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		c.reader.Comma = ';'
+	}
+
+	header, err := c.reader.Read()
+	if err == io.EOF {
+		return errors.New("arquivo CSV vazio")
+	}
+	if err != nil {
+		return fmt.Errorf("cabeçalho do CSV inválido: %w", err)
+	}
+
+	known := make(map[string]bool, len(csvColumns))
+	for _, column := range csvColumns {
+		known[column] = true
+	}
+	c.columns = make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !known[name] {
+			return fmt.Errorf("coluna desconhecida no CSV: %q", name)
+		}
+		if _, ok := c.columns[name]; ok {
+			return fmt.Errorf("coluna repetida no CSV: %q", name)
+		}
+		c.columns[name] = i
+	}
+	if _, ok := c.columns["sku"]; !ok {
+		return errors.New("o CSV precisa da coluna sku")
+	}
+	return nil
+}
+
+func (c *csvReader) parse(line int, record []string) (*Row, error) {
+	field := func(name string) string {
+		if i, ok := c.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := &Row{
+		Line:        line,
+		SKU:         field("sku"),
+		ParentSKU:   field("parent_sku"),
+		Name:        field("name"),
+		Description: field("description"),
+		CategoryID:  field("category_id"),
+		Currency:    field("currency"),
+		ImageURL:    field("image_url"),
+		Barcode:     field("barcode"),
+		TaxClass:    field("tax_class"),
+	}
+	fail := func(format string, args ...interface{}) (*Row, error) {
+		return nil, &RowError{Line: line, SKU: row.SKU, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if len(record) > len(c.columns) {
+		return fail("a linha tem %d colunas, mas o cabeçalho tem %d", len(record), len(c.columns))
+	}
+
+	if value := field("price"); value != "" {
+		price, err := parseDecimal(value)
+		if err != nil {
+			return fail("preço inválido: %q", value)
+		}
+		row.Price = price
+	}
+	if value := field("stock_quantity"); value != "" {
+		stock, err := strconv.Atoi(value)
+		if err != nil {
+			return fail("estoque inválido: %q", value)
+		}
+		row.StockQuantity = stock
+	}
+	if value := field("is_active"); value != "" {
+		active, err := parseBool(value)
+		if err != nil {
+			return fail("is_active inválido: %q", value)
+		}
+		row.IsActive = &active
+	}
+	if value := field("attributes"); value != "" {
+		attributes, err := parseAttributes(value)
+		if err != nil {
+			return fail("%s", err.Error())
+		}
+		row.Attributes = attributes
+	}
+	return row, nil
+}
+
+// csvWriter grava linhas em CSV separado por vírgula, com cabeçalho
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+// Write grava uma linha, precedida do cabeçalho na primeira chamada
+func (c *csvWriter) Write(row *Row) error {
+	if !c.headerWritten {
+		if err := c.writer.Write(csvColumns); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+
+	return c.writer.Write([]string{
+		row.SKU,
+		row.ParentSKU,
+		row.Name,
+		row.Description,
+		row.CategoryID,
+		row.Price.String(),
+		row.Currency,
+		strconv.Itoa(row.StockQuantity),
+		row.ImageURL,
+		row.Barcode,
+		formatAttributes(row.Attributes),
+		strconv.FormatBool(row.Active()),
+		row.TaxClass,
+	})
+}
+
+// Flush grava o cabeçalho (mesmo sem linhas) e descarrega o buffer
+func (c *csvWriter) Flush() error {
+	if !c.headerWritten {
+		if err := c.writer.Write(csvColumns); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// parseDecimal aceita ponto ou vírgula como separador decimal ("49.90" ou "49,90").
+// O texto é mantido como está: a conversão para unidades menores depende da moeda
+// e acontece na validação da linha
+func parseDecimal(value string) (json.Number, error) {
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return "", err
+	}
+	return json.Number(value), nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "1", "sim", "s", "yes", "y":
+		return true, nil
+	case "false", "0", "nao", "não", "n", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("valor booleano inválido: %q", value)
+	}
+}
+
+func parseAttributes(value string) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, pair := range strings.Split(value, attributeSeparator) {
+		name, val, ok := strings.Cut(pair, attributeAssign)
+		if !ok {
+			return nil, fmt.Errorf("atributo inválido: %q (use nome=valor separados por |)", pair)
+		}
+		attributes[strings.TrimSpace(name)] = strings.TrimSpace(val)
+	}
+	return attributes, nil
+}
+
+func formatAttributes(attributes map[string]string) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + attributeAssign + attributes[name]
+	}
+	return strings.Join(pairs, attributeSeparator)
+}
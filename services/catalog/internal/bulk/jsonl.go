@@ -0,0 +1,73 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Tamanho máximo de uma linha JSON
+const maxJSONLineSize = 1024 * 1024
+
+// jsonlReader lê um objeto JSON por linha; linhas em branco são ignoradas
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxJSONLineSize)
+	return &jsonlReader{scanner: scanner}
+}
+
+// Read retorna a próxima linha do arquivo
+func (j *jsonlReader) Read() (*Row, error) {
+	for j.scanner.Scan() {
+		j.line++
+		data := bytes.TrimSpace(j.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		var row Row
+		if err := decoder.Decode(&row); err != nil {
+			return nil, &RowError{Line: j.line, Message: "JSON inválido: " + err.Error()}
+		}
+		row.Line = j.line
+		return &row, nil
+	}
+	if err := j.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// jsonlWriter grava um objeto JSON por linha
+type jsonlWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &jsonlWriter{writer: writer, encoder: encoder}
+}
+
+// Write grava uma linha; is_active é sempre explícito para a exportação ser reimportável
+func (j *jsonlWriter) Write(row *Row) error {
+	active := row.Active()
+	out := *row
+	out.IsActive = &active
+	return j.encoder.Encode(&out)
+}
+
+// Flush descarrega o buffer
+func (j *jsonlWriter) Flush() error {
+	return j.writer.Flush()
+}
@@ -0,0 +1,110 @@
+// Package bulk lê e grava linhas de produtos e variações em CSV ou JSON Lines,
+// usadas na importação e exportação em massa do catálogo.
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Formatos aceitos na importação e exportação
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Row é uma linha do arquivo: um produto ou, quando ParentSKU está preenchido,
+// uma variação do produto com aquele SKU. O produto deve aparecer antes das variações.
+// Price é o decimal escrito no arquivo (ex.: 49.90), em unidades de Currency; a
+// conversão para centavos é feita na validação da linha, que conhece a moeda.
+type Row struct {
+	Line          int               `json:"-"`
+	SKU           string            `json:"sku"`
+	ParentSKU     string            `json:"parent_sku,omitempty"`
+	Name          string            `json:"name,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	CategoryID    string            `json:"category_id,omitempty"`
+	Price         json.Number       `json:"price"`
+	Currency      string            `json:"currency,omitempty"`
+	StockQuantity int               `json:"stock_quantity"`
+	ImageURL      string            `json:"image_url,omitempty"`
+	Barcode       string            `json:"barcode,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	IsActive      *bool             `json:"is_active,omitempty"`
+	// TaxClass vale apenas para produtos; vazio mantém a classe atual
+	TaxClass string `json:"tax_class,omitempty"`
+}
+
+// IsVariant indica se a linha descreve uma variação
+func (r *Row) IsVariant() bool {
+	return r.ParentSKU != ""
+}
+
+// Active retorna o estado informado na linha; ausente significa ativo
+func (r *Row) Active() bool {
+	return r.IsActive == nil || *r.IsActive
+}
+
+// RowError descreve uma linha rejeitada. Leitores retornam *RowError para linhas
+// malformadas e continuam a leitura na linha seguinte.
+type RowError struct {
+	Line    int
+	SKU     string
+	Message string
+}
+
+func (e *RowError) Error() string {
+	if e.SKU != "" {
+		return fmt.Sprintf("linha %d (SKU %s): %s", e.Line, e.SKU, e.Message)
+	}
+	return fmt.Sprintf("linha %d: %s", e.Line, e.Message)
+}
+
+// Reader lê linhas uma a uma. Retorna io.EOF ao final e *RowError para linhas inválidas.
+type Reader interface {
+	Read() (*Row, error)
+}
+
+// Writer grava linhas no formato escolhido; Flush deve ser chamado ao final
+type Writer interface {
+	Write(row *Row) error
+	Flush() error
+}
+
+// NormalizeFormat valida o formato informado, aceitando também "ndjson" e ".csv"/".jsonl"
+func NormalizeFormat(format string) (string, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(format)), ".") {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSONL, "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("formato não suportado: %q (use csv ou jsonl)", format)
+	}
+}
+
+// NewReader cria o leitor do formato informado
+func NewReader(format string, r io.Reader) (Reader, error) {
+	format, err := NormalizeFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatCSV {
+		return newCSVReader(r), nil
+	}
+	return newJSONLReader(r), nil
+}
+
+// NewWriter cria o gravador do formato informado
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	format, err := NormalizeFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatCSV {
+		return newCSVWriter(w), nil
+	}
+	return newJSONLWriter(w), nil
+}
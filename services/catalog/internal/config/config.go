@@ -0,0 +1,71 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	ServicePort string
+	DBHost      string
+	DBPort      string
+	DBUser      string
+	DBPass      string
+	DBName      string
+	JWTSecret   string
+	JWTIssuer   string
+
+	// ReservationTTL é o prazo padrão das reservas de estoque não confirmadas
+	ReservationTTL time.Duration
+	// ReservationSweepInterval é o intervalo do expirador de reservas vencidas
+	ReservationSweepInterval time.Duration
+	// PriceSchedulerInterval é o intervalo do agendador que aplica preços programados
+	PriceSchedulerInterval time.Duration
+}
+
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg := &Config{
+		ServicePort: getEnv("SERVICE_PORT", "50054"),
+		DBHost:      getEnv("DB_HOST", "mysql"),
+		DBPort:      getEnv("DB_PORT", "3306"),
+		DBUser:      getEnv("DB_USER", "root"),
+		DBPass:      getEnv("DB_PASSWORD", "secret"),
+		DBName:      getEnv("DB_NAME", "catalog_service"),
+		JWTSecret:   getEnv("JWT_SECRET", ""),
+		JWTIssuer:   getEnv("JWT_ISSUER", "user-service"),
+
+		ReservationTTL:           getEnvAsDuration("RESERVATION_TTL", 15*time.Minute),
+		ReservationSweepInterval: getEnvAsDuration("RESERVATION_SWEEP_INTERVAL", time.Minute),
+		PriceSchedulerInterval:   getEnvAsDuration("PRICE_SCHEDULER_INTERVAL", time.Minute),
+	}
+
+	log.Printf("Config carregada: porta=%s banco=%s@%s:%s/%s", cfg.ServicePort, cfg.DBUser, cfg.DBHost, cfg.DBPort, cfg.DBName)
+	return cfg
+}
+
+func getEnv(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return fallback
+}
+
+func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+		log.Printf("⚠️ Valor inválido para %s: %q, usando %s", key, value, fallback)
+	}
+	return fallback
+}
+
+// GetEnv retorna uma variável de ambiente ou um valor padrão (exportada)
+func GetEnv(key, fallback string) string {
+	return getEnv(key, fallback)
+}
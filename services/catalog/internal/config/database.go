@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func ConnectDatabase(cfg *Config) *gorm.DB {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		cfg.DBUser, cfg.DBPass, cfg.DBHost, cfg.DBPort, cfg.DBName)
+
+	log.Printf("🔗 Tentando conectar ao banco de dados: %s@%s:%s/%s", cfg.DBUser, cfg.DBHost, cfg.DBPort, cfg.DBName)
+
+	var db *gorm.DB
+	var err error
+	maxRetries := 10
+
+	for i := 0; i < maxRetries; i++ {
+		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		if err == nil {
+			log.Println("✅ Conectado ao banco de dados MySQL com sucesso")
+			return db
+		}
+
+		log.Printf("⏳ Tentativa %d/%d falhou: %v. Aguardando 3 segundos...", i+1, maxRetries, err)
+		time.Sleep(3 * time.Second)
+	}
+
+	log.Fatalf("❌ Erro ao conectar ao banco após %d tentativas: %v", maxRetries, err)
+	return nil
+}
+
+// AutoMigrate executa a migração automática do banco de dados.
+// As tabelas categories e products já são criadas por infra/mysql/init/init.sql;
+// a migração apenas acrescenta colunas e índices que faltarem e converte os preços
+// decimais para centavos.
+func AutoMigrate(db *gorm.DB) error {
+	log.Println("🔄 Executando migração do banco de dados...")
+
+	err := db.AutoMigrate(&domain.Category{}, &domain.Product{}, &domain.Variant{}, &domain.Reservation{}, &domain.PriceRecord{})
+	if err != nil {
+		log.Printf("❌ Erro ao executar migração: %v", err)
+		return err
+	}
+
+	if err := MigrateMoneyColumns(db); err != nil {
+		log.Printf("❌ Erro ao migrar valores monetários: %v", err)
+		return err
+	}
+
+	log.Println("✅ Migração concluída com sucesso")
+	return nil
+}
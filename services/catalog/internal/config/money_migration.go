@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// legacyMoneyColumn é uma coluna decimal de preços em reais substituída por uma
+// coluna inteira em centavos
+type legacyMoneyColumn struct {
+	table  string
+	legacy string
+	column string
+}
+
+var legacyMoneyColumns = []legacyMoneyColumn{
+	{table: "products", legacy: "price", column: "price_minor"},
+	{table: "product_variants", legacy: "price", column: "price_minor"},
+	{table: "price_history", legacy: "price", column: "price_minor"},
+	{table: "stock_reservations", legacy: "price", column: "price_minor"},
+}
+
+// MigrateMoneyColumns copia os preços das antigas colunas decimais para as colunas em
+// centavos e remove as antigas. Os preços antigos estão em reais e a coluna currency
+// nasce com BRL. O arredondamento é feito pelo banco em DECIMAL (meio centavo para
+// cima), nunca em ponto flutuante. Deve rodar depois do AutoMigrate, que cria as novas
+// colunas; colunas já migradas são ignoradas. O init.sql já cria o esquema novo, e o BFF
+// legado lê price_minor e currency.
+func MigrateMoneyColumns(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, c := range legacyMoneyColumns {
+		if !migrator.HasTable(c.table) || !migrator.HasColumn(c.table, c.legacy) {
+			continue
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET %s = ROUND(CAST(%s AS DECIMAL(18,4)) * 100) WHERE %s = 0",
+			c.table, c.column, c.legacy, c.column)
+		result := db.Exec(query)
+		if result.Error != nil {
+			return fmt.Errorf("falha ao converter %s.%s: %w", c.table, c.legacy, result.Error)
+		}
+		if err := migrator.DropColumn(c.table, c.legacy); err != nil {
+			return fmt.Errorf("falha ao remover %s.%s: %w", c.table, c.legacy, err)
+		}
+		log.Printf("💱 Coluna %s.%s convertida para %s (%d registros)", c.table, c.legacy, c.column, result.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// PriceRecord é uma versão do preço de um produto ou variação, válida na janela
+// [ValidFrom, ValidTo). ValidTo nulo indica um preço sem prazo de término.
+//
+// O preço vigente em um instante é o da versão mais recente (maior ValidFrom) cuja
+// janela contém o instante. Assim uma promoção com prazo se sobrepõe ao preço base e,
+// quando termina, o preço base volta a valer sem precisar de outra versão. Price está
+// em unidades menores de Currency, a moeda do produto.
+type PriceRecord struct {
+	ID        string     `gorm:"primaryKey;size:36" json:"id"`
+	ProductID string     `gorm:"size:36;not null;index:idx_prices_owner_from" json:"product_id"`
+	VariantID string     `gorm:"size:36;not null;default:'';index:idx_prices_owner_from" json:"variant_id"`
+	Currency  string     `gorm:"size:3;not null;default:BRL" json:"currency"`
+	Price     int64      `gorm:"column:price_minor;not null;default:0" json:"price"`
+	ValidFrom time.Time  `gorm:"not null;index:idx_prices_owner_from" json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+	Reason    string     `gorm:"size:200" json:"reason"`
+	// AppliedAt e EndedAt registram quando o agendador aplicou o início e o fim da
+	// janela no preço do cadastro; versões ainda não processadas ficam nulas
+	AppliedAt *time.Time `gorm:"index" json:"applied_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (PriceRecord) TableName() string {
+	return "price_history"
+}
+
+// BeforeCreate gera o ID quando não informado
+func (p *PriceRecord) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// PriceMoney retorna o preço da versão na moeda do produto
+func (p *PriceRecord) PriceMoney() money.Money {
+	return money.New(p.Price, p.Currency)
+}
+
+// ActiveAt indica se a janela da versão contém o instante informado
+func (p *PriceRecord) ActiveAt(at time.Time) bool {
+	return !at.Before(p.ValidFrom) && (p.ValidTo == nil || at.Before(*p.ValidTo))
+}
+
+// IsScheduled indica se a versão ainda não começou a valer
+func (p *PriceRecord) IsScheduled(now time.Time) bool {
+	return now.Before(p.ValidFrom)
+}
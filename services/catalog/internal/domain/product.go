@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// DefaultTaxClass é a classe fiscal dos produtos sem classificação específica
+const DefaultTaxClass = "standard"
+
+// Product representa um produto à venda no catálogo.
+// Quando possui variações, o SKU do produto identifica apenas o modelo; preço,
+// estoque e reservas passam a ser controlados por variação. TaxClass define as
+// alíquotas aplicadas no pedido e vale também para as variações. Price está em
+// unidades menores (centavos) de Currency, a moeda do produto, das variações e do
+// histórico de preços.
+type Product struct {
+	ID            string    `gorm:"primaryKey;size:36" json:"id"`
+	CategoryID    string    `gorm:"size:36;index" json:"category_id"`
+	Name          string    `gorm:"size:200;not null" json:"name"`
+	Description   string    `gorm:"type:text" json:"description"`
+	Currency      string    `gorm:"size:3;not null;default:BRL" json:"currency"`
+	Price         int64     `gorm:"column:price_minor;not null;default:0;index:idx_price_minor" json:"price"`
+	StockQuantity int       `gorm:"default:0" json:"stock_quantity"`
+	ImageURL      string    `gorm:"size:500" json:"image_url"`
+	SKU           string    `gorm:"column:sku;size:100;uniqueIndex" json:"sku"`
+	TaxClass      string    `gorm:"size:30;not null;default:standard" json:"tax_class"`
+	IsActive      bool      `gorm:"not null" json:"is_active"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Variants      []Variant `json:"variants,omitempty" gorm:"foreignKey:ProductID"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Product) TableName() string {
+	return "products"
+}
+
+// BeforeCreate gera o ID quando não informado
+func (p *Product) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// PriceMoney retorna o preço do cadastro na moeda do produto
+func (p *Product) PriceMoney() money.Money {
+	return money.New(p.Price, p.Currency)
+}
+
+// HasVariants indica se o produto é vendido por variação
+func (p *Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
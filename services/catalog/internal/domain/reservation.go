@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// Estados de uma reserva de estoque
+const (
+	ReservationPending   = "pending"   // estoque separado aguardando confirmação
+	ReservationCommitted = "committed" // venda confirmada, baixa definitiva
+	ReservationReleased  = "released"  // devolvida ao estoque (pagamento recusado ou pedido cancelado)
+	ReservationExpired   = "expired"   // devolvida ao estoque por falta de confirmação no prazo
+)
+
+// Reservation separa uma quantidade de um produto (ou de uma de suas variações) para um pedido.
+// Enquanto pendente, a quantidade já foi descontada do stock_quantity do produto ou da
+// variação; liberar ou expirar a reserva devolve a quantidade ao estoque.
+type Reservation struct {
+	ID        string `gorm:"primaryKey;size:36" json:"id"`
+	ProductID string `gorm:"size:36;not null;index" json:"product_id"`
+	VariantID string `gorm:"size:36;index" json:"variant_id"`
+	SKU       string `gorm:"column:sku;size:100" json:"sku"`
+	Quantity  int    `gorm:"not null" json:"quantity"`
+	// Preço unitário vigente na reserva, em unidades menores da moeda do produto
+	Currency  string    `gorm:"size:3;not null;default:BRL" json:"currency"`
+	Price     int64     `gorm:"column:price_minor;not null;default:0" json:"price"`
+	Reference string    `gorm:"size:100;index" json:"reference"`
+	Status    string    `gorm:"size:20;not null;index:idx_reservations_status_expires" json:"status"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_reservations_status_expires" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Produto e variação reservados, preenchidos pelo serviço ao criar a reserva
+	Product *Product `gorm:"-" json:"-"`
+	Variant *Variant `gorm:"-" json:"-"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Reservation) TableName() string {
+	return "stock_reservations"
+}
+
+// BeforeCreate gera o ID quando não informado
+func (r *Reservation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// UnitPrice retorna o preço unitário vigente no momento da reserva. Reservas antigas,
+// gravadas sem preço, usam o preço atual da variação ou do produto.
+func (r *Reservation) UnitPrice() money.Money {
+	if r.Price > 0 {
+		return money.New(r.Price, r.Currency)
+	}
+	if r.Variant != nil {
+		return r.Variant.PriceMoney()
+	}
+	if r.Product != nil {
+		return r.Product.PriceMoney()
+	}
+	return money.Zero(r.Currency)
+}
+
+// IsPending indica se a reserva ainda segura estoque
+func (r *Reservation) IsPending() bool {
+	return r.Status == ReservationPending
+}
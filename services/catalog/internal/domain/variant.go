@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// Attributes são as características que distinguem uma variação (ex.: cor=azul, tamanho=M).
+// É gravado como JSON em uma coluna de texto.
+type Attributes map[string]string
+
+// Value serializa os atributos para o banco de dados
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan lê os atributos gravados no banco de dados
+func (a *Attributes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = Attributes{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tipo inválido para atributos: %T", value)
+	}
+	return json.Unmarshal(data, a)
+}
+
+// Key retorna uma representação canônica dos atributos (nomes ordenados),
+// usada para impedir duas variações iguais no mesmo produto
+func (a Attributes) Key() string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + a[name]
+	}
+	return strings.Join(pairs, ";")
+}
+
+// Variant é uma combinação de atributos de um produto vendida com SKU próprio.
+// Produtos com variações têm preço, estoque e código de barras controlados por variação.
+// O preço fica em unidades menores da moeda do produto, copiada em Currency.
+type Variant struct {
+	ID            string     `gorm:"primaryKey;size:36" json:"id"`
+	ProductID     string     `gorm:"size:36;not null;uniqueIndex:idx_variants_product_attributes" json:"product_id"`
+	SKU           string     `gorm:"column:sku;size:100;not null;uniqueIndex" json:"sku"`
+	Barcode       string     `gorm:"size:50;index" json:"barcode"`
+	Attributes    Attributes `gorm:"type:text" json:"attributes"`
+	AttributesKey string     `gorm:"size:255;not null;uniqueIndex:idx_variants_product_attributes" json:"-"`
+	Currency      string     `gorm:"size:3;not null;default:BRL" json:"currency"`
+	Price         int64      `gorm:"column:price_minor;not null;default:0" json:"price"`
+	StockQuantity int        `gorm:"default:0" json:"stock_quantity"`
+	IsActive      bool       `gorm:"not null" json:"is_active"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Variant) TableName() string {
+	return "product_variants"
+}
+
+// PriceMoney retorna o preço do cadastro na moeda da variação
+func (v *Variant) PriceMoney() money.Money {
+	return money.New(v.Price, v.Currency)
+}
+
+// BeforeSave gera o ID quando não informado e mantém a chave dos atributos sincronizada
+func (v *Variant) BeforeSave(tx *gorm.DB) error {
+	if v.ID == "" {
+		v.ID = uuid.NewString()
+	}
+	v.AttributesKey = v.Attributes.Key()
+	return nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Conflitos detectados linha a linha durante a gravação em lote. A linha é
+// rejeitada e as demais linhas do lote continuam sendo gravadas.
+var (
+	ErrSKUInUse            = errors.New("o SKU já pertence a outro produto ou variação")
+	ErrParentNotFound      = errors.New("produto pai não encontrado")
+	ErrDuplicateAttributes = errors.New("o produto já possui uma variação com estes atributos")
+	ErrCurrencyMismatch    = errors.New("a moeda difere da moeda do produto cadastrado, que não pode ser alterada")
+)
+
+// errDryRun desfaz a transação de uma simulação
+var errDryRun = errors.New("simulação")
+
+// Motivo registrado no histórico de preços para alterações vindas da importação
+const importReason = "importação"
+
+// BulkItem é uma linha já validada da importação: um produto ou uma variação
+// do produto com SKU ParentSKU
+type BulkItem struct {
+	Line      int
+	Product   *domain.Product
+	Variant   *domain.Variant
+	ParentSKU string
+}
+
+// BulkResult é o resultado da gravação de um item. Err contém um dos conflitos
+// de linha acima; ProductID é o produto criado, alterado ou dono da variação.
+type BulkResult struct {
+	Line      int
+	ProductID string
+	Created   bool
+	Err       error
+}
+
+// BulkRepository grava produtos e variações em lote, identificando-os pelo SKU
+type BulkRepository interface {
+	UpsertBatch(items []BulkItem, dryRun bool) ([]BulkResult, error)
+}
+
+// bulkRepository implementa BulkRepository
+type bulkRepository struct {
+	db *gorm.DB
+}
+
+// NewBulkRepository cria uma nova instância do repositório de importação em lote
+func NewBulkRepository(db *gorm.DB) BulkRepository {
+	return &bulkRepository{
+		db: db,
+	}
+}
+
+// UpsertBatch cria ou atualiza os itens pelo SKU dentro de uma única transação.
+// Conflitos de linha são devolvidos no resultado; qualquer outro erro desfaz o lote inteiro.
+// Com dryRun a transação é sempre desfeita, mas os resultados refletem o que seria gravado.
+func (r *bulkRepository) UpsertBatch(items []BulkItem, dryRun bool) ([]BulkResult, error) {
+	results := make([]BulkResult, 0, len(items))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			var (
+				result BulkResult
+				err    error
+			)
+			if item.Variant != nil {
+				result, err = upsertVariant(tx, item)
+			} else {
+				result, err = upsertProduct(tx, item)
+			}
+			if err != nil {
+				return err
+			}
+			result.Line = item.Line
+			results = append(results, result)
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return results, nil
+}
+
+func upsertProduct(tx *gorm.DB, item BulkItem) (BulkResult, error) {
+	product := item.Product
+
+	taken, err := exists(tx, &domain.Variant{}, "sku = ?", product.SKU)
+	if err != nil || taken {
+		return BulkResult{Err: ErrSKUInUse}, err
+	}
+
+	var existing domain.Product
+	err = tx.First(&existing, "sku = ?", product.SKU).Error
+	switch {
+	case err == nil:
+		if existing.Currency != product.Currency {
+			return BulkResult{ProductID: existing.ID, Err: ErrCurrencyMismatch}, nil
+		}
+		product.ID = existing.ID
+		product.CreatedAt = existing.CreatedAt
+		if product.TaxClass == "" {
+			product.TaxClass = existing.TaxClass
+		}
+		if err := tx.Omit(clause.Associations).Save(product).Error; err != nil {
+			return BulkResult{}, err
+		}
+		err = recordPriceChange(tx, PriceOwner{ProductID: product.ID}, product.Price, product.Currency, importReason)
+		return BulkResult{ProductID: product.ID}, err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if product.TaxClass == "" {
+			product.TaxClass = domain.DefaultTaxClass
+		}
+		if err := tx.Omit(clause.Associations).Create(product).Error; err != nil {
+			return BulkResult{}, err
+		}
+		err = recordPriceChange(tx, PriceOwner{ProductID: product.ID}, product.Price, product.Currency, importReason)
+		return BulkResult{ProductID: product.ID, Created: true}, err
+	default:
+		return BulkResult{}, err
+	}
+}
+
+func upsertVariant(tx *gorm.DB, item BulkItem) (BulkResult, error) {
+	variant := item.Variant
+
+	var parent domain.Product
+	if err := tx.First(&parent, "sku = ?", item.ParentSKU).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return BulkResult{Err: ErrParentNotFound}, nil
+		}
+		return BulkResult{}, err
+	}
+	variant.ProductID = parent.ID
+	result := BulkResult{ProductID: parent.ID}
+	if variant.Currency != parent.Currency {
+		result.Err = ErrCurrencyMismatch
+		return result, nil
+	}
+
+	taken, err := exists(tx, &domain.Product{}, "sku = ?", variant.SKU)
+	if err != nil || taken {
+		result.Err = ErrSKUInUse
+		return result, err
+	}
+
+	var existing domain.Variant
+	err = tx.First(&existing, "sku = ?", variant.SKU).Error
+	switch {
+	case err == nil:
+		if existing.ProductID != parent.ID {
+			result.Err = ErrSKUInUse
+			return result, nil
+		}
+		variant.ID = existing.ID
+		variant.CreatedAt = existing.CreatedAt
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return BulkResult{}, err
+	}
+
+	taken, err = exists(tx, &domain.Variant{}, "product_id = ? AND attributes_key = ? AND sku <> ?",
+		parent.ID, variant.Attributes.Key(), variant.SKU)
+	if err != nil || taken {
+		result.Err = ErrDuplicateAttributes
+		return result, err
+	}
+
+	if variant.ID != "" {
+		err = tx.Save(variant).Error
+	} else {
+		err = tx.Create(variant).Error
+		result.Created = true
+	}
+	if err != nil {
+		return BulkResult{}, err
+	}
+	if err := recordPriceChange(tx, variantPriceOwner(variant), variant.Price, variant.Currency, importReason); err != nil {
+		return BulkResult{}, err
+	}
+	return result, nil
+}
+
+func exists(tx *gorm.DB, model interface{}, query string, args ...interface{}) (bool, error) {
+	var count int64
+	if err := tx.Model(model).Where(query, args...).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
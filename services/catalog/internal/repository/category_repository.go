@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CategoryRepository define a interface para operações de persistência de categorias
+type CategoryRepository interface {
+	Create(category *domain.Category) error
+	Update(category *domain.Category) error
+	Delete(id string) error
+	GetByID(id string) (*domain.Category, error)
+	List() ([]domain.Category, error)
+}
+
+// categoryRepository implementa CategoryRepository
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository cria uma nova instância do repositório de categorias
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{
+		db: db,
+	}
+}
+
+// Create cria uma nova categoria no banco de dados
+func (r *categoryRepository) Create(category *domain.Category) error {
+	return r.db.Create(category).Error
+}
+
+// Update grava todas as colunas da categoria
+func (r *categoryRepository) Update(category *domain.Category) error {
+	return r.db.Save(category).Error
+}
+
+// Delete remove a categoria pelo ID
+func (r *categoryRepository) Delete(id string) error {
+	result := r.db.Delete(&domain.Category{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetByID retorna uma categoria específica pelo ID
+func (r *categoryRepository) GetByID(id string) (*domain.Category, error) {
+	var category domain.Category
+	if err := r.db.First(&category, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// List retorna todas as categorias ordenadas por nome
+func (r *categoryRepository) List() ([]domain.Category, error) {
+	var categories []domain.Category
+	if err := r.db.Order("name").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
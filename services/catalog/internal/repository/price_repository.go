@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPriceStarted indica uma versão de preço que já começou a valer e não pode ser cancelada
+var ErrPriceStarted = errors.New("a versão de preço já está em vigor")
+
+// PriceOwner identifica o produto ou a variação dona de um histórico de preços
+type PriceOwner struct {
+	ProductID string
+	VariantID string
+}
+
+// PriceRepository define a interface para o histórico de preços de produtos e variações
+type PriceRepository interface {
+	Create(record *domain.PriceRecord) error
+	CancelScheduled(id string, now time.Time) error
+	GetByID(id string) (*domain.PriceRecord, error)
+	ListByOwner(owner PriceOwner) ([]domain.PriceRecord, error)
+	GetEffective(owner PriceOwner, at time.Time) (*domain.PriceRecord, error)
+	ListDueOwners(now time.Time, limit int) ([]PriceOwner, error)
+	ApplyDue(owner PriceOwner, now time.Time) (*domain.PriceRecord, error)
+}
+
+// priceRepository implementa PriceRepository
+type priceRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceRepository cria uma nova instância do repositório de histórico de preços
+func NewPriceRepository(db *gorm.DB) PriceRepository {
+	return &priceRepository{
+		db: db,
+	}
+}
+
+// Create grava uma nova versão de preço
+func (r *priceRepository) Create(record *domain.PriceRecord) error {
+	return r.db.Create(record).Error
+}
+
+// CancelScheduled remove uma versão que ainda não começou a valer
+func (r *priceRepository) CancelScheduled(id string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var record domain.PriceRecord
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&record, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if !record.IsScheduled(now) || record.AppliedAt != nil {
+			return ErrPriceStarted
+		}
+		return tx.Delete(&record).Error
+	})
+}
+
+// GetByID retorna uma versão de preço pelo ID
+func (r *priceRepository) GetByID(id string) (*domain.PriceRecord, error) {
+	var record domain.PriceRecord
+	if err := r.db.First(&record, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ListByOwner retorna o histórico do produto ou variação, das versões mais novas para as mais antigas
+func (r *priceRepository) ListByOwner(owner PriceOwner) ([]domain.PriceRecord, error) {
+	var records []domain.PriceRecord
+	err := r.db.Where("product_id = ? AND variant_id = ?", owner.ProductID, owner.VariantID).
+		Order("valid_from DESC").Order("created_at DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetEffective retorna a versão vigente no instante informado
+func (r *priceRepository) GetEffective(owner PriceOwner, at time.Time) (*domain.PriceRecord, error) {
+	return effectivePrice(r.db, owner, at)
+}
+
+// ListDueOwners retorna os donos de versões cujo início ou fim já passou e ainda não
+// foi aplicado ao preço do cadastro
+func (r *priceRepository) ListDueOwners(now time.Time, limit int) ([]PriceOwner, error) {
+	var owners []PriceOwner
+	err := r.db.Model(&domain.PriceRecord{}).
+		Distinct("product_id", "variant_id").
+		Where("(applied_at IS NULL AND valid_from <= ?) OR (ended_at IS NULL AND valid_to <= ?)", now, now).
+		Limit(limit).Scan(&owners).Error
+	if err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// ApplyDue grava no cadastro o preço vigente agora e marca como processados os inícios
+// e fins de janela vencidos, na mesma transação. Retorna a versão aplicada, ou nil
+// quando nenhuma versão está vigente (o preço do cadastro é mantido).
+func (r *priceRepository) ApplyDue(owner PriceOwner, now time.Time) (*domain.PriceRecord, error) {
+	var effective *domain.PriceRecord
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		record, err := effectivePrice(tx, owner, now)
+		switch {
+		case err == nil:
+			effective = record
+			if err := priceOwner(tx, owner).UpdateColumn("price_minor", record.Price).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		records := tx.Model(&domain.PriceRecord{}).
+			Where("product_id = ? AND variant_id = ?", owner.ProductID, owner.VariantID).
+			Session(&gorm.Session{})
+		if err := records.Where("applied_at IS NULL AND valid_from <= ?", now).
+			UpdateColumn("applied_at", now).Error; err != nil {
+			return err
+		}
+		return records.Where("ended_at IS NULL AND valid_to <= ?", now).
+			UpdateColumn("ended_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return effective, nil
+}
+
+// effectivePrice busca a versão mais recente cuja janela contém o instante
+func effectivePrice(db *gorm.DB, owner PriceOwner, at time.Time) (*domain.PriceRecord, error) {
+	var record domain.PriceRecord
+	err := db.Where("product_id = ? AND variant_id = ?", owner.ProductID, owner.VariantID).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", at, at).
+		Order("valid_from DESC").Order("created_at DESC").
+		First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// recordPriceChange acrescenta ao histórico uma versão sem prazo a partir de agora quando
+// o preço gravado no cadastro difere do preço vigente. Chamado dentro da transação que
+// altera o produto ou a variação; price está em unidades menores de currency.
+func recordPriceChange(tx *gorm.DB, owner PriceOwner, price int64, currency, reason string) error {
+	now := time.Now()
+	current, err := effectivePrice(tx, owner, now)
+	switch {
+	case err == nil && current.Price == price:
+		return nil
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+
+	return tx.Create(&domain.PriceRecord{
+		ProductID: owner.ProductID,
+		VariantID: owner.VariantID,
+		Currency:  currency,
+		Price:     price,
+		ValidFrom: now,
+		Reason:    reason,
+		AppliedAt: &now,
+	}).Error
+}
+
+// currentPrice retorna o preço vigente agora, ou o preço do cadastro quando o produto
+// ainda não tem histórico
+func currentPrice(tx *gorm.DB, owner PriceOwner) (int64, error) {
+	record, err := effectivePrice(tx, owner, time.Now())
+	if err == nil {
+		return record.Price, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	var price int64
+	err = priceOwner(tx, owner).Select("price_minor").Scan(&price).Error
+	return price, err
+}
+
+// priceOwner seleciona a linha cujo preço o histórico controla
+func priceOwner(tx *gorm.DB, owner PriceOwner) *gorm.DB {
+	if owner.VariantID != "" {
+		return tx.Model(&domain.Variant{}).Where("id = ?", owner.VariantID)
+	}
+	return tx.Model(&domain.Product{}).Where("id = ?", owner.ProductID)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductFilter restringe a listagem de produtos
+type ProductFilter struct {
+	CategoryID string
+	ActiveOnly bool
+	Offset     int
+	Limit      int
+}
+
+// ProductRepository define a interface para operações de persistência de produtos.
+// Os produtos retornados trazem suas variações carregadas.
+type ProductRepository interface {
+	Create(product *domain.Product) error
+	Update(product *domain.Product) error
+	Delete(id string) error
+	GetByID(id string) (*domain.Product, error)
+	GetBySKU(sku string) (*domain.Product, error)
+	GetByIDs(ids []string) ([]domain.Product, error)
+	ListAll() ([]domain.Product, error)
+	List(filter ProductFilter) ([]domain.Product, int64, error)
+	CountByCategory(categoryID string) (int64, error)
+}
+
+// productRepository implementa ProductRepository
+type productRepository struct {
+	db *gorm.DB
+}
+
+// NewProductRepository cria uma nova instância do repositório de produtos
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &productRepository{
+		db: db,
+	}
+}
+
+// Create cria um novo produto no banco de dados e abre seu histórico de preços
+func (r *productRepository) Create(product *domain.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		return recordPriceChange(tx, PriceOwner{ProductID: product.ID}, product.Price, product.Currency, "cadastro")
+	})
+}
+
+// Update grava todas as colunas do produto e registra no histórico uma mudança de preço;
+// as variações são gravadas pelo VariantRepository
+func (r *productRepository) Update(product *domain.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(product).Error; err != nil {
+			return err
+		}
+		return recordPriceChange(tx, PriceOwner{ProductID: product.ID}, product.Price, product.Currency, "alteração manual")
+	})
+}
+
+// Delete remove o produto e suas variações pelo ID
+func (r *productRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.Variant{}, "product_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Product{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// GetByID retorna um produto específico pelo ID
+func (r *productRepository) GetByID(id string) (*domain.Product, error) {
+	var product domain.Product
+	if err := r.withVariants().First(&product, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetBySKU retorna um produto pelo código SKU
+func (r *productRepository) GetBySKU(sku string) (*domain.Product, error) {
+	var product domain.Product
+	if err := r.withVariants().First(&product, "sku = ?", sku).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetByIDs retorna os produtos com os IDs informados, sem ordem definida
+func (r *productRepository) GetByIDs(ids []string) ([]domain.Product, error) {
+	var products []domain.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	if err := r.withVariants().Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListAll retorna todos os produtos, ativos ou não (usado para montar o índice de busca)
+func (r *productRepository) ListAll() ([]domain.Product, error) {
+	var products []domain.Product
+	if err := r.withVariants().Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// List retorna uma página de produtos e o total de registros que atendem ao filtro
+func (r *productRepository) List(filter ProductFilter) ([]domain.Product, int64, error) {
+	query := r.db.Model(&domain.Product{})
+	if filter.CategoryID != "" {
+		query = query.Where("category_id = ?", filter.CategoryID)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var products []domain.Product
+	if err := query.Preload("Variants", orderVariants).Order("name").Order("id").Offset(filter.Offset).Limit(filter.Limit).Find(&products).Error; err != nil {
+		return nil, 0, err
+	}
+	return products, total, nil
+}
+
+// CountByCategory retorna quantos produtos pertencem à categoria
+func (r *productRepository) CountByCategory(categoryID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Product{}).Where("category_id = ?", categoryID).Count(&count).Error
+	return count, err
+}
+
+// withVariants carrega as variações junto com os produtos
+func (r *productRepository) withVariants() *gorm.DB {
+	return r.db.Preload("Variants", orderVariants)
+}
+
+func orderVariants(db *gorm.DB) *gorm.DB {
+	return db.Order("sku")
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInsufficientStock indica que o produto não tem estoque disponível para a reserva
+	ErrInsufficientStock = errors.New("estoque insuficiente")
+	// ErrReservationExpired indica uma reserva pendente cujo prazo já venceu
+	ErrReservationExpired = errors.New("reserva expirada")
+	// ErrReservationClosed indica uma reserva já liberada ou expirada
+	ErrReservationClosed = errors.New("reserva já foi liberada")
+)
+
+// ReservationRepository define a interface para operações de persistência de reservas de estoque
+type ReservationRepository interface {
+	Reserve(reservation *domain.Reservation) error
+	Commit(id string, now time.Time) (*domain.Reservation, error)
+	Release(id string, status string) (*domain.Reservation, error)
+	GetByID(id string) (*domain.Reservation, error)
+	ListExpired(now time.Time, limit int) ([]domain.Reservation, error)
+}
+
+// reservationRepository implementa ReservationRepository
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationRepository cria uma nova instância do repositório de reservas
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &reservationRepository{
+		db: db,
+	}
+}
+
+// Reserve desconta a quantidade do estoque do produto (ou da variação) e grava a reserva,
+// com o preço vigente no histórico, na mesma transação. O desconto é condicional (stock_quantity >= quantidade), evitando
+// venda acima do estoque mesmo com pedidos concorrentes.
+func (r *reservationRepository) Reserve(reservation *domain.Reservation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := stockOwner(tx, reservation).
+			Where("is_active = ? AND stock_quantity >= ?", true, reservation.Quantity).
+			UpdateColumn("stock_quantity", gorm.Expr("stock_quantity - ?", reservation.Quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsufficientStock
+		}
+
+		price, err := currentPrice(tx, PriceOwner{ProductID: reservation.ProductID, VariantID: reservation.VariantID})
+		if err != nil {
+			return err
+		}
+		reservation.Price = price
+		reservation.Status = domain.ReservationPending
+		return tx.Create(reservation).Error
+	})
+}
+
+// Commit confirma uma reserva pendente. Confirmar novamente uma reserva já confirmada
+// não tem efeito; reservas vencidas retornam ErrReservationExpired.
+func (r *reservationRepository) Commit(id string, now time.Time) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockReservation(tx, id, &reservation); err != nil {
+			return err
+		}
+
+		switch reservation.Status {
+		case domain.ReservationCommitted:
+			return nil
+		case domain.ReservationPending:
+			if !now.Before(reservation.ExpiresAt) {
+				return ErrReservationExpired
+			}
+			reservation.Status = domain.ReservationCommitted
+			return tx.Model(&reservation).Update("status", reservation.Status).Error
+		default:
+			return ErrReservationClosed
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// Release devolve ao estoque a quantidade de uma reserva pendente e grava o novo estado
+// (released ou expired). Reservas que não estão pendentes são retornadas sem alteração.
+func (r *reservationRepository) Release(id string, status string) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockReservation(tx, id, &reservation); err != nil {
+			return err
+		}
+		if !reservation.IsPending() {
+			return nil
+		}
+
+		err := stockOwner(tx, &reservation).
+			UpdateColumn("stock_quantity", gorm.Expr("stock_quantity + ?", reservation.Quantity)).Error
+		if err != nil {
+			return err
+		}
+
+		reservation.Status = status
+		return tx.Model(&reservation).Update("status", status).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// GetByID retorna uma reserva específica pelo ID
+func (r *reservationRepository) GetByID(id string) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	if err := r.db.First(&reservation, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// ListExpired retorna reservas pendentes cujo prazo venceu, das mais antigas para as mais novas
+func (r *reservationRepository) ListExpired(now time.Time, limit int) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	err := r.db.Where("status = ? AND expires_at <= ?", domain.ReservationPending, now).
+		Order("expires_at").Limit(limit).Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// stockOwner seleciona a linha cujo estoque a reserva controla: a variação, quando
+// informada, ou o próprio produto
+func stockOwner(tx *gorm.DB, reservation *domain.Reservation) *gorm.DB {
+	if reservation.VariantID != "" {
+		return tx.Model(&domain.Variant{}).Where("id = ?", reservation.VariantID)
+	}
+	return tx.Model(&domain.Product{}).Where("id = ?", reservation.ProductID)
+}
+
+// lockReservation carrega a reserva com SELECT ... FOR UPDATE dentro da transação
+func lockReservation(tx *gorm.DB, id string, reservation *domain.Reservation) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(reservation, "id = ?", id).Error
+}
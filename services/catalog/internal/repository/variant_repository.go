@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"gorm.io/gorm"
+)
+
+// VariantRepository define a interface para operações de persistência de variações de produto
+type VariantRepository interface {
+	Create(variant *domain.Variant) error
+	Update(variant *domain.Variant) error
+	Delete(id string) error
+	GetByID(id string) (*domain.Variant, error)
+	GetBySKU(sku string) (*domain.Variant, error)
+	GetByAttributes(productID string, attributes domain.Attributes) (*domain.Variant, error)
+	ListByProduct(productID string) ([]domain.Variant, error)
+}
+
+// variantRepository implementa VariantRepository
+type variantRepository struct {
+	db *gorm.DB
+}
+
+// NewVariantRepository cria uma nova instância do repositório de variações
+func NewVariantRepository(db *gorm.DB) VariantRepository {
+	return &variantRepository{
+		db: db,
+	}
+}
+
+// Create cria uma nova variação no banco de dados e abre seu histórico de preços
+func (r *variantRepository) Create(variant *domain.Variant) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(variant).Error; err != nil {
+			return err
+		}
+		return recordPriceChange(tx, variantPriceOwner(variant), variant.Price, variant.Currency, "cadastro")
+	})
+}
+
+// Update grava todas as colunas da variação e registra no histórico uma mudança de preço
+func (r *variantRepository) Update(variant *domain.Variant) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(variant).Error; err != nil {
+			return err
+		}
+		return recordPriceChange(tx, variantPriceOwner(variant), variant.Price, variant.Currency, "alteração manual")
+	})
+}
+
+// Delete remove a variação pelo ID
+func (r *variantRepository) Delete(id string) error {
+	result := r.db.Delete(&domain.Variant{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetByID retorna uma variação específica pelo ID
+func (r *variantRepository) GetByID(id string) (*domain.Variant, error) {
+	var variant domain.Variant
+	if err := r.db.First(&variant, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// GetBySKU retorna uma variação pelo código SKU
+func (r *variantRepository) GetBySKU(sku string) (*domain.Variant, error) {
+	var variant domain.Variant
+	if err := r.db.First(&variant, "sku = ?", sku).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// GetByAttributes retorna a variação do produto com exatamente os atributos informados
+func (r *variantRepository) GetByAttributes(productID string, attributes domain.Attributes) (*domain.Variant, error) {
+	var variant domain.Variant
+	err := r.db.First(&variant, "product_id = ? AND attributes_key = ?", productID, attributes.Key()).Error
+	if err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// ListByProduct retorna as variações de um produto ordenadas por SKU
+func (r *variantRepository) ListByProduct(productID string) ([]domain.Variant, error) {
+	var variants []domain.Variant
+	if err := r.db.Where("product_id = ?", productID).Order("sku").Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+func variantPriceOwner(variant *domain.Variant) PriceOwner {
+	return PriceOwner{ProductID: variant.ProductID, VariantID: variant.ID}
+}
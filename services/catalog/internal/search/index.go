@@ -0,0 +1,409 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Ordenações aceitas pela busca
+const (
+	SortRelevance = "relevance"
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortName      = "name"
+)
+
+// Peso de cada campo do produto na relevância
+const (
+	nameWeight        = 3.0
+	skuWeight         = 2.0
+	descriptionWeight = 1.0
+)
+
+// Fatores aplicados quando o termo do índice não é idêntico ao termo buscado
+const (
+	prefixFactor = 0.7
+	typoFactor   = 0.5
+)
+
+// PriceBucket é uma faixa de preço [Min, Max) em unidades inteiras da moeda (reais,
+// não centavos); Max = 0 indica faixa aberta
+type PriceBucket struct {
+	Label string
+	Min   int64
+	Max   int64
+}
+
+// PriceBuckets são as faixas usadas na contagem de facetas por preço
+var PriceBuckets = []PriceBucket{
+	{Label: "0-50", Min: 0, Max: 50},
+	{Label: "50-100", Min: 50, Max: 100},
+	{Label: "100-250", Min: 100, Max: 250},
+	{Label: "250-500", Min: 250, Max: 500},
+	{Label: "500-1000", Min: 500, Max: 1000},
+	{Label: "1000-2500", Min: 1000, Max: 2500},
+	{Label: "2500+", Min: 2500},
+}
+
+// Bounds retorna os limites da faixa em unidades menores da moeda
+func (b PriceBucket) Bounds(currency string) (money.Money, money.Money) {
+	scale := int64(math.Pow10(money.Exponent(currency)))
+	return money.New(b.Min*scale, currency), money.New(b.Max*scale, currency)
+}
+
+// Contains indica se o preço, em unidades menores da moeda, pertence à faixa
+func (b PriceBucket) Contains(price int64, currency string) bool {
+	lower, upper := b.Bounds(currency)
+	return price >= lower.Amount && (upper.IsZero() || price < upper.Amount)
+}
+
+// Query descreve uma busca. MinPrice/MaxPrice estão em unidades menores de Currency;
+// iguais a zero não filtram. Filtro e facetas de preço consideram apenas os produtos
+// na moeda Currency (vazia, a moeda padrão).
+type Query struct {
+	Text            string
+	CategoryID      string
+	Currency        string
+	MinPrice        int64
+	MaxPrice        int64
+	IncludeInactive bool
+	Sort            string
+	Offset          int
+	Limit           int
+}
+
+// Hit é um produto encontrado e sua pontuação de relevância
+type Hit struct {
+	ProductID string
+	Score     float64
+}
+
+// FacetCount é a quantidade de resultados para um valor de faceta
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// PriceFacet é a quantidade de resultados em uma faixa de preço
+type PriceFacet struct {
+	Bucket PriceBucket
+	Count  int
+}
+
+// Result é uma página de resultados com as facetas do conjunto completo
+type Result struct {
+	Hits           []Hit
+	Total          int
+	CategoryFacets []FacetCount
+	PriceFacets    []PriceFacet
+	// Currency é a moeda do filtro e das facetas de preço
+	Currency string
+}
+
+// document guarda os campos filtráveis do produto e os termos indexados
+type document struct {
+	id         string
+	name       string
+	categoryID string
+	currency   string
+	price      int64
+	active     bool
+	terms      map[string]float64
+}
+
+// Index é um índice invertido em memória dos produtos do catálogo.
+// É seguro para uso concorrente e atualizado incrementalmente via Upsert/Remove.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]*document
+	postings map[string]map[string]float64 // termo -> produto -> peso
+}
+
+// NewIndex cria um índice vazio
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]float64),
+	}
+}
+
+// Rebuild substitui todo o conteúdo do índice pelos produtos informados
+func (idx *Index) Rebuild(products []domain.Product) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.docs = make(map[string]*document, len(products))
+	idx.postings = make(map[string]map[string]float64)
+	for i := range products {
+		idx.add(&products[i])
+	}
+}
+
+// Upsert indexa o produto, substituindo a versão anterior se existir
+func (idx *Index) Upsert(product *domain.Product) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(product.ID)
+	idx.add(product)
+}
+
+// Remove retira o produto do índice
+func (idx *Index) Remove(productID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(productID)
+}
+
+// Len retorna a quantidade de produtos indexados
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Search executa a busca. Todos os termos da consulta precisam ser encontrados
+// (exatos, por prefixo ou com erro de digitação); sem termos, todos os produtos
+// que passam pelos filtros são retornados.
+func (idx *Index) Search(query Query) Result {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	scores := idx.match(Tokenize(query.Text))
+
+	currency := money.NormalizeCurrency(query.Currency)
+	filterPrice := query.MinPrice > 0 || query.MaxPrice > 0
+	inPrice := func(doc *document) bool {
+		if !filterPrice {
+			return true
+		}
+		return doc.currency == currency &&
+			(query.MinPrice <= 0 || doc.price >= query.MinPrice) &&
+			(query.MaxPrice <= 0 || doc.price <= query.MaxPrice)
+	}
+	inCategory := func(doc *document) bool {
+		return query.CategoryID == "" || doc.categoryID == query.CategoryID
+	}
+
+	// Cada faceta ignora o próprio filtro, para que o usuário veja as alternativas
+	categoryCounts := make(map[string]int)
+	priceCounts := make([]int, len(PriceBuckets))
+	var matched []*document
+
+	for id := range scores {
+		doc := idx.docs[id]
+		if !doc.active && !query.IncludeInactive {
+			continue
+		}
+		if inPrice(doc) {
+			categoryCounts[doc.categoryID]++
+		}
+		if inCategory(doc) && doc.currency == currency {
+			for i, bucket := range PriceBuckets {
+				if bucket.Contains(doc.price, currency) {
+					priceCounts[i]++
+				}
+			}
+		}
+		if inPrice(doc) && inCategory(doc) {
+			matched = append(matched, doc)
+		}
+	}
+
+	sortDocuments(matched, scores, query.Sort)
+
+	result := Result{Total: len(matched), Currency: currency}
+	for value, count := range categoryCounts {
+		result.CategoryFacets = append(result.CategoryFacets, FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(result.CategoryFacets, func(i, j int) bool {
+		a, b := result.CategoryFacets[i], result.CategoryFacets[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Value < b.Value
+	})
+	for i, bucket := range PriceBuckets {
+		result.PriceFacets = append(result.PriceFacets, PriceFacet{Bucket: bucket, Count: priceCounts[i]})
+	}
+
+	start := min(max(query.Offset, 0), len(matched))
+	end := len(matched)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(matched))
+	}
+	for _, doc := range matched[start:end] {
+		result.Hits = append(result.Hits, Hit{ProductID: doc.id, Score: scores[doc.id]})
+	}
+	return result
+}
+
+// match retorna a pontuação dos produtos que contêm todos os termos da consulta
+func (idx *Index) match(queryTerms []string) map[string]float64 {
+	scores := make(map[string]float64)
+	if len(queryTerms) == 0 {
+		for id := range idx.docs {
+			scores[id] = 0
+		}
+		return scores
+	}
+
+	for i, term := range queryTerms {
+		// O último termo pode estar incompleto (busca enquanto digita)
+		termScores := idx.termScores(term, i == len(queryTerms)-1)
+		if i == 0 {
+			scores = termScores
+			continue
+		}
+		for id, score := range scores {
+			if s, ok := termScores[id]; ok {
+				scores[id] = score + s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+// termScores pontua os produtos para um termo da consulta. Termos idênticos e
+// prefixos têm prioridade; erros de digitação só são considerados sem eles.
+func (idx *Index) termScores(term string, allowPrefix bool) map[string]float64 {
+	candidates := make(map[string]float64)
+	if _, ok := idx.postings[term]; ok {
+		candidates[term] = 1
+	}
+
+	minPrefix := 3
+	if allowPrefix {
+		minPrefix = 2
+	}
+	if len([]rune(term)) >= minPrefix {
+		for indexed := range idx.postings {
+			if indexed != term && strings.HasPrefix(indexed, term) {
+				candidates[indexed] = prefixFactor
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		if typos := maxTypos(term); typos > 0 {
+			for indexed := range idx.postings {
+				if d := editDistance(term, indexed, typos); d <= typos {
+					candidates[indexed] = typoFactor / float64(d)
+				}
+			}
+		}
+	}
+
+	scores := make(map[string]float64)
+	total := float64(len(idx.docs))
+	for indexed, factor := range candidates {
+		postings := idx.postings[indexed]
+		idf := math.Log(1 + total/float64(len(postings)))
+		for id, weight := range postings {
+			if s := factor * weight * idf; s > scores[id] {
+				scores[id] = s
+			}
+		}
+	}
+	return scores
+}
+
+func (idx *Index) add(product *domain.Product) {
+	doc := &document{
+		id:         product.ID,
+		name:       product.Name,
+		categoryID: product.CategoryID,
+		currency:   money.NormalizeCurrency(product.Currency),
+		price:      product.Price,
+		active:     product.IsActive,
+		terms:      make(map[string]float64),
+	}
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{product.Name, nameWeight},
+		{product.SKU, skuWeight},
+		{product.Description, descriptionWeight},
+	} {
+		for _, term := range Tokenize(field.text) {
+			doc.terms[term] += field.weight
+		}
+	}
+	// Variações tornam o produto encontrável pelo SKU e pelos atributos (ex.: "azul", "M")
+	for _, variant := range product.Variants {
+		for _, term := range Tokenize(variant.SKU) {
+			doc.terms[term] = max(doc.terms[term], skuWeight)
+		}
+		for _, value := range variant.Attributes {
+			for _, term := range Tokenize(value) {
+				doc.terms[term] = max(doc.terms[term], descriptionWeight)
+			}
+		}
+	}
+
+	idx.docs[doc.id] = doc
+	for term, weight := range doc.terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[string]float64)
+		}
+		idx.postings[term][doc.id] = weight
+	}
+}
+
+func (idx *Index) remove(productID string) {
+	doc, ok := idx.docs[productID]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(idx.postings[term], productID)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.docs, productID)
+}
+
+func sortDocuments(docs []*document, scores map[string]float64, order string) {
+	byName := func(a, b *document) bool {
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		return a.id < b.id
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		// Preços em moedas diferentes não são comparáveis: agrupa por moeda
+		if order == SortPriceAsc || order == SortPriceDesc {
+			if a.currency != b.currency {
+				return a.currency < b.currency
+			}
+		}
+		switch order {
+		case SortPriceAsc:
+			if a.price != b.price {
+				return a.price < b.price
+			}
+		case SortPriceDesc:
+			if a.price != b.price {
+				return a.price > b.price
+			}
+		case SortName:
+		default:
+			if scores[a.id] != scores[b.id] {
+				return scores[a.id] > scores[b.id]
+			}
+		}
+		return byName(a, b)
+	})
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+)
+
+func sampleIndex() *Index {
+	idx := NewIndex()
+	idx.Rebuild([]domain.Product{
+		{ID: "prod-001", CategoryID: "cat-001", Name: "Smartphone Galaxy", Description: "Celular com câmera tripla", SKU: "SMART-GAL-001", Price: 249990, IsActive: true},
+		{ID: "prod-002", CategoryID: "cat-001", Name: "Notebook Gamer", Description: "Notebook para jogos", SKU: "NOTE-GAM-001", Price: 599900, IsActive: true},
+		{ID: "prod-003", CategoryID: "cat-002", Name: "Camiseta Básica", Description: "Algodão, várias cores", SKU: "CAM-BAS-001", Price: 3990, IsActive: true},
+		{ID: "prod-004", CategoryID: "cat-001", Name: "Fone Bluetooth", Description: "Fone sem fio com cancelamento de ruído", SKU: "FONE-BT-001", Price: 29900, IsActive: true},
+		{ID: "prod-005", CategoryID: "cat-001", Name: "Smartphone Antigo", Description: "Fora de linha", SKU: "SMART-OLD-001", Price: 19900, IsActive: false},
+	})
+	return idx
+}
+
+func hitIDs(result Result) []string {
+	ids := make([]string, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.ProductID
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"camera", "eletronica", "4k"}, Tokenize("Câmera de Eletrônica 4K!"))
+	assert.Equal(t, []string{"smart", "xyz", "001"}, Tokenize("SMART-XYZ-001"))
+	assert.Empty(t, Tokenize(" de  para "))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("fone", "fone", 2))
+	assert.Equal(t, 1, editDistance("fnoe", "fone", 2)) // transposição
+	assert.Equal(t, 1, editDistance("notebok", "notebook", 2))
+	assert.Equal(t, 3, editDistance("abc", "xyzw", 2)) // acima do limite
+}
+
+func TestSearch_ExactAndAccentInsensitive(t *testing.T) {
+	result := sampleIndex().Search(Query{Text: "camera"})
+	assert.Equal(t, []string{"prod-001"}, hitIDs(result))
+}
+
+func TestSearch_TypoTolerance(t *testing.T) {
+	result := sampleIndex().Search(Query{Text: "notbook"})
+	assert.Equal(t, []string{"prod-002"}, hitIDs(result))
+
+	result = sampleIndex().Search(Query{Text: "smartphnoe galaxy"})
+	assert.Equal(t, []string{"prod-001"}, hitIDs(result))
+}
+
+func TestSearch_PrefixOnLastTerm(t *testing.T) {
+	result := sampleIndex().Search(Query{Text: "fone blue"})
+	assert.Equal(t, []string{"prod-004"}, hitIDs(result))
+}
+
+func TestSearch_AllTermsRequired(t *testing.T) {
+	result := sampleIndex().Search(Query{Text: "smartphone notebook"})
+	assert.Empty(t, result.Hits)
+}
+
+func TestSearch_RelevancePrefersName(t *testing.T) {
+	idx := sampleIndex()
+	idx.Upsert(&domain.Product{ID: "prod-006", CategoryID: "cat-003", Name: "Capa protetora", Description: "Compatível com notebook", SKU: "CAPA-001", Price: 8990, IsActive: true})
+
+	result := idx.Search(Query{Text: "notebook"})
+	require.Len(t, result.Hits, 2)
+	assert.Equal(t, "prod-002", result.Hits[0].ProductID)
+	assert.Greater(t, result.Hits[0].Score, result.Hits[1].Score)
+}
+
+func TestSearch_InactiveHiddenByDefault(t *testing.T) {
+	idx := sampleIndex()
+	assert.Equal(t, []string{"prod-001"}, hitIDs(idx.Search(Query{Text: "smartphone"})))
+	assert.Len(t, idx.Search(Query{Text: "smartphone", IncludeInactive: true}).Hits, 2)
+}
+
+func TestSearch_SortByPriceAndPagination(t *testing.T) {
+	idx := sampleIndex()
+
+	asc := idx.Search(Query{Sort: SortPriceAsc})
+	assert.Equal(t, []string{"prod-003", "prod-004", "prod-001", "prod-002"}, hitIDs(asc))
+
+	page := idx.Search(Query{Sort: SortPriceDesc, Offset: 1, Limit: 2})
+	assert.Equal(t, 4, page.Total)
+	assert.Equal(t, []string{"prod-001", "prod-004"}, hitIDs(page))
+}
+
+func TestSearch_Facets(t *testing.T) {
+	result := sampleIndex().Search(Query{CategoryID: "cat-001", MaxPrice: 100000})
+
+	assert.Equal(t, []string{"prod-004"}, hitIDs(result))
+	// A faceta de categoria ignora o filtro de categoria, mas respeita o de preço
+	assert.Equal(t, []FacetCount{{Value: "cat-001", Count: 1}, {Value: "cat-002", Count: 1}}, result.CategoryFacets)
+
+	// A faceta de preço ignora o filtro de preço, mas respeita o de categoria
+	counts := map[string]int{}
+	for _, facet := range result.PriceFacets {
+		counts[facet.Bucket.Label] = facet.Count
+	}
+	assert.Equal(t, 1, counts["250-500"])
+	assert.Equal(t, 1, counts["1000-2500"])
+	assert.Equal(t, 1, counts["2500+"])
+	assert.Equal(t, 0, counts["0-50"])
+}
+
+func TestIndex_IncrementalUpdates(t *testing.T) {
+	idx := sampleIndex()
+
+	idx.Upsert(&domain.Product{ID: "prod-003", CategoryID: "cat-002", Name: "Camiseta Estampada", SKU: "CAM-EST-001", Price: 5990, IsActive: true})
+	assert.Empty(t, idx.Search(Query{Text: "basica"}).Hits)
+	assert.Equal(t, []string{"prod-003"}, hitIDs(idx.Search(Query{Text: "estampada"})))
+
+	idx.Remove("prod-003")
+	assert.Empty(t, idx.Search(Query{Text: "camiseta"}).Hits)
+	assert.Equal(t, 4, idx.Len())
+}
+
+func TestSearch_PriceFilterUsesQueryCurrency(t *testing.T) {
+	idx := sampleIndex()
+	idx.Upsert(&domain.Product{ID: "prod-007", CategoryID: "cat-001", Name: "Fone importado", SKU: "FONE-US-001", Price: 4990, Currency: "USD", IsActive: true})
+
+	brl := idx.Search(Query{Text: "fone", MaxPrice: 100000})
+	assert.Equal(t, []string{"prod-004"}, hitIDs(brl))
+
+	usd := idx.Search(Query{Text: "fone", Currency: "usd", MaxPrice: 10000})
+	assert.Equal(t, []string{"prod-007"}, hitIDs(usd))
+	assert.Equal(t, "USD", usd.Currency)
+}
+
+func TestSearch_MatchesVariantAttributesAndSKU(t *testing.T) {
+	idx := sampleIndex()
+	idx.Upsert(&domain.Product{
+		ID: "prod-003", CategoryID: "cat-002", Name: "Camiseta Básica", SKU: "CAM-BAS-001", Price: 3990, IsActive: true,
+		Variants: []domain.Variant{
+			{ID: "var-001", SKU: "CAM-BAS-AZ-M", Attributes: domain.Attributes{"cor": "Azul", "tamanho": "M"}},
+			{ID: "var-002", SKU: "CAM-BAS-VM-G", Attributes: domain.Attributes{"cor": "Vermelha", "tamanho": "G"}},
+		},
+	})
+
+	assert.Equal(t, []string{"prod-003"}, hitIDs(idx.Search(Query{Text: "camiseta vermelha"})))
+	assert.Equal(t, []string{"prod-003"}, hitIDs(idx.Search(Query{Text: "vm"})))
+}
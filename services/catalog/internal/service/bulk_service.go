@@ -0,0 +1,411 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/bulk"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// Limites da importação em massa
+const (
+	DefaultImportBatchSize = 100
+	MaxImportBatchSize     = 1000
+	// MaxReportedErrors limita os erros devolvidos no relatório; a contagem continua completa
+	MaxReportedErrors = 1000
+)
+
+// ImportOptions controla a importação em massa
+type ImportOptions struct {
+	BatchSize int
+	// DryRun valida e grava cada lote em uma transação que é sempre desfeita
+	DryRun bool
+}
+
+// ImportReport resume a importação, com os erros de cada linha rejeitada
+type ImportReport struct {
+	Total           int
+	Created         int
+	Updated         int
+	Failed          int
+	DryRun          bool
+	Errors          []bulk.RowError
+	ErrorsTruncated bool
+}
+
+// ExportQuery filtra os produtos exportados
+type ExportQuery struct {
+	CategoryID      string
+	IncludeInactive bool
+}
+
+// BulkService define a interface para importação e exportação em massa do catálogo
+type BulkService interface {
+	ImportProducts(reader bulk.Reader, opts ImportOptions) (*ImportReport, error)
+	ExportProducts(query ExportQuery, write func(row *bulk.Row) error) (int, error)
+}
+
+// bulkService implementa BulkService
+type bulkService struct {
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+	bulkRepo     repository.BulkRepository
+	index        *search.Index
+}
+
+// NewBulkService cria uma nova instância do serviço de importação e exportação.
+// Os produtos gravados são atualizados no índice de busca ao final de cada lote.
+func NewBulkService(categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository, bulkRepo repository.BulkRepository, index *search.Index) BulkService {
+	return &bulkService{
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+		bulkRepo:     bulkRepo,
+		index:        index,
+	}
+}
+
+// importRun guarda o estado de uma importação em andamento
+type importRun struct {
+	opts       ImportOptions
+	report     *ImportReport
+	categories map[string]bool
+	seen       map[string]int
+	batch      []repository.BulkItem
+	skus       map[int]string
+}
+
+// ImportProducts lê todas as linhas, valida cada uma e grava produtos e variações
+// por SKU em lotes transacionais. Linhas inválidas entram no relatório sem interromper
+// a importação; falhas de banco interrompem, mantendo os lotes já gravados.
+func (s *bulkService) ImportProducts(reader bulk.Reader, opts ImportOptions) (*ImportReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultImportBatchSize
+	}
+	if opts.BatchSize > MaxImportBatchSize {
+		return nil, invalid("o lote aceita no máximo 1000 linhas")
+	}
+
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		log.Printf("Erro ao listar categorias: %v", err)
+		return nil, errCatalogUnavailable
+	}
+
+	run := &importRun{
+		opts:       opts,
+		report:     &ImportReport{DryRun: opts.DryRun},
+		categories: make(map[string]bool, len(categories)),
+		seen:       make(map[string]int),
+		skus:       make(map[int]string),
+	}
+	for _, category := range categories {
+		run.categories[category.ID] = true
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var rowErr *bulk.RowError
+			if !errors.As(err, &rowErr) {
+				return nil, invalid(err.Error())
+			}
+			run.report.Total++
+			run.fail(*rowErr)
+			continue
+		}
+
+		run.report.Total++
+		item, rowErr := run.validate(row)
+		if rowErr != nil {
+			run.fail(*rowErr)
+			continue
+		}
+		run.batch = append(run.batch, item)
+		run.skus[item.Line] = row.SKU
+
+		if len(run.batch) >= opts.BatchSize {
+			if err := s.flush(run); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.flush(run); err != nil {
+		return nil, err
+	}
+
+	report := run.report
+	log.Printf("📦 Importação concluída: Linhas=%d, Criados=%d, Atualizados=%d, Falhas=%d, Simulação=%t",
+		report.Total, report.Created, report.Updated, report.Failed, report.DryRun)
+	return report, nil
+}
+
+// flush grava o lote pendente e reindexa os produtos afetados
+func (s *bulkService) flush(run *importRun) error {
+	if len(run.batch) == 0 {
+		return nil
+	}
+	batch := run.batch
+	run.batch = nil
+
+	results, err := s.bulkRepo.UpsertBatch(batch, run.opts.DryRun)
+	if err != nil {
+		log.Printf("Erro ao gravar lote da importação (linhas %d a %d): %v",
+			batch[0].Line, batch[len(batch)-1].Line, err)
+		metrics.RecordCatalogUpdate("import", "error")
+		return errCatalogUnavailable
+	}
+	metrics.RecordCatalogUpdate("import", "success")
+
+	touched := make(map[string]bool)
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			run.fail(bulk.RowError{Line: result.Line, SKU: run.skus[result.Line], Message: result.Err.Error()})
+		case result.Created:
+			run.report.Created++
+			touched[result.ProductID] = true
+		default:
+			run.report.Updated++
+			touched[result.ProductID] = true
+		}
+		delete(run.skus, result.Line)
+	}
+
+	if run.opts.DryRun || len(touched) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(touched))
+	for id := range touched {
+		ids = append(ids, id)
+	}
+	products, err := s.productRepo.GetByIDs(ids)
+	if err != nil {
+		log.Printf("⚠️ Erro ao reindexar produtos importados: %v", err)
+		return nil
+	}
+	for i := range products {
+		s.index.Upsert(&products[i])
+	}
+	return nil
+}
+
+// validate normaliza a linha e a converte no item gravado pelo repositório
+func (run *importRun) validate(row *bulk.Row) (repository.BulkItem, *bulk.RowError) {
+	row.SKU = strings.TrimSpace(row.SKU)
+	row.ParentSKU = strings.TrimSpace(row.ParentSKU)
+	fail := func(msg string) (repository.BulkItem, *bulk.RowError) {
+		return repository.BulkItem{}, &bulk.RowError{Line: row.Line, SKU: row.SKU, Message: msg}
+	}
+
+	if row.SKU == "" {
+		return fail("SKU é obrigatório")
+	}
+	if line, ok := run.seen[row.SKU]; ok {
+		return fail("SKU repetido no arquivo (já informado na linha " + strconv.Itoa(line) + ")")
+	}
+	run.seen[row.SKU] = row.Line
+
+	price, err := rowPrice(row)
+	if err != nil {
+		return fail(err.Error())
+	}
+	if row.StockQuantity < 0 {
+		return fail("estoque não pode ser negativo")
+	}
+
+	if row.IsVariant() {
+		if row.ParentSKU == row.SKU {
+			return fail("a variação não pode ser filha de si mesma")
+		}
+		barcode := strings.TrimSpace(row.Barcode)
+		if barcode != "" && !isDigits(barcode) {
+			return fail("código de barras deve conter apenas dígitos")
+		}
+		if row.TaxClass != "" {
+			return fail("classe fiscal só é aceita em produtos; as variações usam a do produto")
+		}
+		attributes, err := normalizeAttributes(row.Attributes)
+		if err != nil {
+			return fail(strings.TrimPrefix(err.Error(), ErrInvalidInput.Error()+": "))
+		}
+		return repository.BulkItem{
+			Line:      row.Line,
+			ParentSKU: row.ParentSKU,
+			Variant: &domain.Variant{
+				SKU:           row.SKU,
+				Barcode:       barcode,
+				Attributes:    attributes,
+				Currency:      price.Currency,
+				Price:         price.Amount,
+				StockQuantity: row.StockQuantity,
+				IsActive:      row.Active(),
+			},
+		}, nil
+	}
+
+	name := strings.TrimSpace(row.Name)
+	categoryID := strings.TrimSpace(row.CategoryID)
+	if name == "" {
+		return fail("nome do produto é obrigatório")
+	}
+	if categoryID == "" {
+		return fail("categoria é obrigatória")
+	}
+	if !run.categories[categoryID] {
+		return fail(ErrCategoryNotFound.Error() + ": " + categoryID)
+	}
+	if row.Barcode != "" || len(row.Attributes) > 0 {
+		return fail("código de barras e atributos só são aceitos em variações (informe parent_sku)")
+	}
+	taxClass := ""
+	if strings.TrimSpace(row.TaxClass) != "" {
+		normalized, err := normalizeTaxClass(row.TaxClass)
+		if err != nil {
+			return fail(strings.TrimPrefix(err.Error(), ErrInvalidInput.Error()+": "))
+		}
+		taxClass = normalized
+	}
+	return repository.BulkItem{
+		Line: row.Line,
+		Product: &domain.Product{
+			CategoryID:    categoryID,
+			Name:          name,
+			Description:   row.Description,
+			Currency:      price.Currency,
+			Price:         price.Amount,
+			StockQuantity: row.StockQuantity,
+			ImageURL:      strings.TrimSpace(row.ImageURL),
+			SKU:           row.SKU,
+			TaxClass:      taxClass,
+			IsActive:      row.Active(),
+		},
+	}, nil
+}
+
+// rowPrice converte o preço decimal da linha para unidades menores da moeda
+// informada (vazia, a moeda padrão)
+func rowPrice(row *bulk.Row) (money.Money, error) {
+	value := row.Price.String()
+	if value == "" {
+		value = "0"
+	}
+	price, err := money.Parse(value, row.Currency)
+	switch {
+	case errors.Is(err, money.ErrUnknownCurrency):
+		return money.Money{}, fmt.Errorf("moeda não suportada: %q", row.Currency)
+	case err != nil:
+		return money.Money{}, fmt.Errorf("preço inválido: %q", value)
+	case !price.IsPositive():
+		return money.Money{}, errors.New("preço deve ser maior que zero")
+	}
+	return price, nil
+}
+
+// fail registra uma linha rejeitada, respeitando o limite de erros do relatório
+func (run *importRun) fail(rowErr bulk.RowError) {
+	run.report.Failed++
+	if len(run.report.Errors) >= MaxReportedErrors {
+		run.report.ErrorsTruncated = true
+		return
+	}
+	run.report.Errors = append(run.report.Errors, rowErr)
+}
+
+// ExportProducts percorre os produtos em páginas e grava cada produto seguido de suas
+// variações, no mesmo formato aceito pela importação. Retorna a quantidade de linhas gravadas.
+func (s *bulkService) ExportProducts(query ExportQuery, write func(row *bulk.Row) error) (int, error) {
+	if query.CategoryID != "" {
+		if _, err := s.categoryRepo.GetByID(query.CategoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrCategoryNotFound
+			}
+			log.Printf("Erro ao buscar categoria %s: %v", query.CategoryID, err)
+			return 0, errCatalogUnavailable
+		}
+	}
+
+	written := 0
+	filter := repository.ProductFilter{
+		CategoryID: query.CategoryID,
+		ActiveOnly: !query.IncludeInactive,
+		Limit:      MaxPageSize,
+	}
+	for {
+		products, _, err := s.productRepo.List(filter)
+		if err != nil {
+			log.Printf("Erro ao listar produtos para exportação: %v", err)
+			return written, errCatalogUnavailable
+		}
+
+		for i := range products {
+			product := &products[i]
+			if err := write(productRow(product)); err != nil {
+				return written, err
+			}
+			written++
+
+			for j := range product.Variants {
+				variant := &product.Variants[j]
+				if !variant.IsActive && !query.IncludeInactive {
+					continue
+				}
+				if err := write(variantRow(product, variant)); err != nil {
+					return written, err
+				}
+				written++
+			}
+		}
+
+		if len(products) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+
+	log.Printf("📤 Exportação concluída: Linhas=%d", written)
+	return written, nil
+}
+
+func productRow(product *domain.Product) *bulk.Row {
+	active := product.IsActive
+	return &bulk.Row{
+		SKU:           product.SKU,
+		Name:          product.Name,
+		Description:   product.Description,
+		CategoryID:    product.CategoryID,
+		Price:         json.Number(product.PriceMoney().Decimal()),
+		Currency:      product.PriceMoney().Currency,
+		StockQuantity: product.StockQuantity,
+		ImageURL:      product.ImageURL,
+		IsActive:      &active,
+		TaxClass:      product.TaxClass,
+	}
+}
+
+func variantRow(product *domain.Product, variant *domain.Variant) *bulk.Row {
+	active := variant.IsActive
+	return &bulk.Row{
+		SKU:           variant.SKU,
+		ParentSKU:     product.SKU,
+		Price:         json.Number(variant.PriceMoney().Decimal()),
+		Currency:      variant.PriceMoney().Currency,
+		StockQuantity: variant.StockQuantity,
+		Barcode:       variant.Barcode,
+		Attributes:    variant.Attributes,
+		IsActive:      &active,
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/bulk"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+)
+
+// MockBulkRepository is a mock implementation of BulkRepository
+type MockBulkRepository struct {
+	mock.Mock
+}
+
+func (m *MockBulkRepository) UpsertBatch(items []repository.BulkItem, dryRun bool) ([]repository.BulkResult, error) {
+	args := m.Called(items, dryRun)
+	results, _ := args.Get(0).([]repository.BulkResult)
+	return results, args.Error(1)
+}
+
+func csvReader(t *testing.T, input string) bulk.Reader {
+	reader, err := bulk.NewReader(bulk.FormatCSV, strings.NewReader(input))
+	require.NoError(t, err)
+	return reader
+}
+
+func bulkCategories() *MockCategoryRepository {
+	categories := new(MockCategoryRepository)
+	categories.On("List").Return([]domain.Category{{ID: "cat-002"}}, nil)
+	return categories
+}
+
+func TestImportProducts_ValidatesRowsAndWritesInBatches(t *testing.T) {
+	input := "sku,parent_sku,name,category_id,price,stock_quantity,attributes\n" +
+		"CAM-001,,Camiseta,cat-002,49.90,0,\n" +
+		"CAM-001-P,CAM-001,,,49.90,5,tamanho=P\n" +
+		"CAM-001-P,CAM-001,,,49.90,5,tamanho=M\n" +
+		"CAM-002,,Boné,cat-999,29.90,1,\n" +
+		"CAM-003,,,cat-002,29.90,1,\n" +
+		"CAM-004,,Meia,cat-002,0,1,\n" +
+		"CAM-001-M,CAM-001,,,49.90,2,tamanho=M\n"
+
+	bulkRepo := new(MockBulkRepository)
+	bulkRepo.On("UpsertBatch", mock.MatchedBy(func(items []repository.BulkItem) bool { return len(items) == 2 }), false).
+		Return([]repository.BulkResult{
+			{Line: 2, ProductID: "prod-100", Created: true},
+			{Line: 3, ProductID: "prod-100", Created: true},
+		}, nil).Once()
+	bulkRepo.On("UpsertBatch", mock.MatchedBy(func(items []repository.BulkItem) bool {
+		return len(items) == 1 && items[0].Variant != nil && items[0].ParentSKU == "CAM-001"
+	}), false).Return([]repository.BulkResult{
+		{Line: 8, ProductID: "prod-100", Err: repository.ErrDuplicateAttributes},
+	}, nil).Once()
+
+	products := new(MockProductRepository)
+	products.On("GetByIDs", []string{"prod-100"}).Return([]domain.Product{
+		{ID: "prod-100", Name: "Camiseta", SKU: "CAM-001", CategoryID: "cat-002", Price: 4990, IsActive: true},
+	}, nil)
+	index := search.NewIndex()
+
+	report, err := NewBulkService(bulkCategories(), products, bulkRepo, index).
+		ImportProducts(csvReader(t, input), ImportOptions{BatchSize: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Total)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 0, report.Updated)
+	assert.Equal(t, 5, report.Failed)
+
+	lines := make([]int, len(report.Errors))
+	for i, rowErr := range report.Errors {
+		lines[i] = rowErr.Line
+	}
+	assert.Equal(t, []int{4, 5, 6, 7, 8}, lines)
+	assert.Contains(t, report.Errors[0].Message, "SKU repetido")
+	assert.Equal(t, "CAM-001-M", report.Errors[4].SKU)
+	assert.Equal(t, 1, index.Len())
+	bulkRepo.AssertExpectations(t)
+}
+
+func TestImportProducts_DryRunSkipsReindex(t *testing.T) {
+	input := "sku,name,category_id,price\nCAM-001,Camiseta,cat-002,49.90\n"
+
+	bulkRepo := new(MockBulkRepository)
+	bulkRepo.On("UpsertBatch", mock.Anything, true).Return([]repository.BulkResult{{Line: 2, ProductID: "prod-001"}}, nil)
+	products := new(MockProductRepository)
+
+	report, err := NewBulkService(bulkCategories(), products, bulkRepo, search.NewIndex()).
+		ImportProducts(csvReader(t, input), ImportOptions{DryRun: true})
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Updated)
+	products.AssertNotCalled(t, "GetByIDs", mock.Anything)
+}
+
+func TestImportProducts_PriceInRowCurrency(t *testing.T) {
+	input := "sku,name,category_id,price,currency\n" +
+		"CAM-001,Camiseta,cat-002,\"49,90\",\n" +
+		"CAM-002,T-shirt,cat-002,12.5,usd\n" +
+		"CAM-003,Boné,cat-002,10,XYZ\n" +
+		"CAM-004,Meia,cat-002,0.001,BRL\n"
+
+	bulkRepo := new(MockBulkRepository)
+	bulkRepo.On("UpsertBatch", mock.MatchedBy(func(items []repository.BulkItem) bool {
+		return len(items) == 2 &&
+			items[0].Product.Price == 4990 && items[0].Product.Currency == "BRL" &&
+			items[1].Product.Price == 1250 && items[1].Product.Currency == "USD"
+	}), true).Return([]repository.BulkResult{{Line: 2, ProductID: "prod-001"}, {Line: 3, ProductID: "prod-002"}}, nil).Once()
+
+	report, err := NewBulkService(bulkCategories(), new(MockProductRepository), bulkRepo, search.NewIndex()).
+		ImportProducts(csvReader(t, input), ImportOptions{DryRun: true})
+
+	require.NoError(t, err)
+	require.Len(t, report.Errors, 2)
+	assert.Contains(t, report.Errors[0].Message, "moeda não suportada")
+	assert.Contains(t, report.Errors[1].Message, "preço inválido")
+	bulkRepo.AssertExpectations(t)
+}
+
+func TestImportProducts_BatchFailureAborts(t *testing.T) {
+	input := "sku,name,category_id,price\nCAM-001,Camiseta,cat-002,49.90\n"
+
+	bulkRepo := new(MockBulkRepository)
+	bulkRepo.On("UpsertBatch", mock.Anything, false).Return(nil, errors.New("deadlock"))
+
+	_, err := NewBulkService(bulkCategories(), new(MockProductRepository), bulkRepo, search.NewIndex()).
+		ImportProducts(csvReader(t, input), ImportOptions{})
+
+	assert.ErrorIs(t, err, errCatalogUnavailable)
+}
+
+func TestImportProducts_InvalidFile(t *testing.T) {
+	_, err := NewBulkService(bulkCategories(), new(MockProductRepository), new(MockBulkRepository), search.NewIndex()).
+		ImportProducts(csvReader(t, "nome,preco\n"), ImportOptions{})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestExportProducts_WritesVariantsAfterProduct(t *testing.T) {
+	products := new(MockProductRepository)
+	products.On("List", repository.ProductFilter{ActiveOnly: true, Limit: MaxPageSize}).Return([]domain.Product{
+		{ID: "prod-001", SKU: "CAM-001", Name: "Camiseta", CategoryID: "cat-002", Price: 4990, IsActive: true, Variants: []domain.Variant{
+			{SKU: "CAM-001-M", Attributes: domain.Attributes{"tamanho": "M"}, Price: 4990, IsActive: true},
+			{SKU: "CAM-001-XG", Attributes: domain.Attributes{"tamanho": "XG"}, Price: 5490, IsActive: false},
+		}},
+		{ID: "prod-002", SKU: "BON-001", Name: "Boné", CategoryID: "cat-002", Price: 2990, IsActive: true},
+	}, int64(2), nil)
+
+	var rows []*bulk.Row
+	written, err := NewBulkService(new(MockCategoryRepository), products, new(MockBulkRepository), search.NewIndex()).
+		ExportProducts(ExportQuery{}, func(row *bulk.Row) error {
+			rows = append(rows, row)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, written)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "CAM-001", rows[0].SKU)
+	assert.Equal(t, "CAM-001-M", rows[1].SKU)
+	assert.Equal(t, "CAM-001", rows[1].ParentSKU)
+	assert.Equal(t, "BON-001", rows[2].SKU)
+	products.AssertNumberOfCalls(t, "List", 1)
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// Limites de paginação da listagem de produtos
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	ErrInvalidInput       = errors.New("dados inválidos")
+	ErrCategoryNotFound   = errors.New("categoria não encontrada")
+	ErrProductNotFound    = errors.New("produto não encontrado")
+	ErrCategoryNotEmpty   = errors.New("a categoria possui produtos e não pode ser removida")
+	ErrDuplicateSKU       = errors.New("já existe um produto ou variação com este SKU")
+	errCatalogUnavailable = errors.New("falha ao acessar o catálogo")
+)
+
+// CategoryInput contém os campos editáveis de uma categoria
+type CategoryInput struct {
+	Name        string
+	Description string
+}
+
+// ProductInput contém os campos editáveis de um produto. A moeda do preço passa a
+// ser a do produto; vazia, vale a moeda padrão.
+type ProductInput struct {
+	CategoryID    string
+	Name          string
+	Description   string
+	Price         money.Money
+	StockQuantity int
+	ImageURL      string
+	SKU           string
+	TaxClass      string
+	IsActive      bool
+}
+
+// ProductQuery define filtros e paginação da listagem de produtos.
+// Page começa em 1; valores fora do intervalo são normalizados.
+type ProductQuery struct {
+	CategoryID      string
+	IncludeInactive bool
+	Page            int
+	PageSize        int
+}
+
+// ProductPage é uma página da listagem de produtos
+type ProductPage struct {
+	Products   []domain.Product
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// CatalogService define a interface para regras de negócio do catálogo
+type CatalogService interface {
+	CreateCategory(input CategoryInput) (*domain.Category, error)
+	UpdateCategory(id string, input CategoryInput) (*domain.Category, error)
+	DeleteCategory(id string) error
+	GetCategory(id string) (*domain.Category, error)
+	ListCategories() ([]domain.Category, error)
+
+	CreateProduct(input ProductInput) (*domain.Product, error)
+	UpdateProduct(id string, input ProductInput) (*domain.Product, error)
+	DeleteProduct(id string) error
+	GetProduct(id string) (*domain.Product, error)
+	GetProductBySKU(sku string) (*domain.Product, error)
+	ListProducts(query ProductQuery) (*ProductPage, error)
+	SetProductActive(id string, active bool) (*domain.Product, error)
+	UpdateProductPrice(id string, price money.Money) (*domain.Product, error)
+
+	CreateVariant(input VariantInput) (*domain.Variant, error)
+	UpdateVariant(id string, input VariantInput) (*domain.Variant, error)
+	DeleteVariant(id string) error
+	GetVariant(id string) (*domain.Variant, error)
+	GetVariantBySKU(sku string) (*domain.Variant, error)
+	ListVariants(productID string, includeInactive bool) ([]domain.Variant, error)
+
+	SearchProducts(query SearchQuery) (*SearchPage, error)
+	ReindexProducts() (int, error)
+}
+
+// catalogService implementa CatalogService
+type catalogService struct {
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+	variantRepo  repository.VariantRepository
+	index        *search.Index
+}
+
+// NewCatalogService cria uma nova instância do serviço de catálogo.
+// O índice de busca é mantido atualizado a cada alteração de produto.
+func NewCatalogService(categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository, variantRepo repository.VariantRepository, index *search.Index) CatalogService {
+	return &catalogService{
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+		variantRepo:  variantRepo,
+		index:        index,
+	}
+}
+
+// CreateCategory cria uma nova categoria
+func (s *catalogService) CreateCategory(input CategoryInput) (*domain.Category, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, invalid("nome da categoria é obrigatório")
+	}
+
+	category := &domain.Category{Name: input.Name, Description: input.Description}
+	if err := s.categoryRepo.Create(category); err != nil {
+		log.Printf("Erro ao criar categoria: %v", err)
+		metrics.RecordCatalogUpdate("create_category", "error")
+		return nil, errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("create_category", "success")
+	log.Printf("✅ Categoria criada: ID=%s, Nome=%s", category.ID, category.Name)
+	return category, nil
+}
+
+// UpdateCategory altera nome e descrição de uma categoria
+func (s *catalogService) UpdateCategory(id string, input CategoryInput) (*domain.Category, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, invalid("nome da categoria é obrigatório")
+	}
+
+	category, err := s.GetCategory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	category.Name = input.Name
+	category.Description = input.Description
+	if err := s.categoryRepo.Update(category); err != nil {
+		log.Printf("Erro ao atualizar categoria %s: %v", id, err)
+		metrics.RecordCatalogUpdate("update_category", "error")
+		return nil, errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("update_category", "success")
+	return category, nil
+}
+
+// DeleteCategory remove uma categoria sem produtos vinculados
+func (s *catalogService) DeleteCategory(id string) error {
+	if _, err := s.GetCategory(id); err != nil {
+		return err
+	}
+
+	count, err := s.productRepo.CountByCategory(id)
+	if err != nil {
+		log.Printf("Erro ao contar produtos da categoria %s: %v", id, err)
+		return errCatalogUnavailable
+	}
+	if count > 0 {
+		return ErrCategoryNotEmpty
+	}
+
+	if err := s.categoryRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCategoryNotFound
+		}
+		log.Printf("Erro ao remover categoria %s: %v", id, err)
+		metrics.RecordCatalogUpdate("delete_category", "error")
+		return errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("delete_category", "success")
+	log.Printf("🗑️ Categoria removida: ID=%s", id)
+	return nil
+}
+
+// GetCategory retorna uma categoria pelo ID
+func (s *catalogService) GetCategory(id string) (*domain.Category, error) {
+	if id == "" {
+		return nil, invalid("ID da categoria é obrigatório")
+	}
+
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		log.Printf("Erro ao buscar categoria %s: %v", id, err)
+		return nil, errCatalogUnavailable
+	}
+	return category, nil
+}
+
+// ListCategories retorna todas as categorias
+func (s *catalogService) ListCategories() ([]domain.Category, error) {
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		log.Printf("Erro ao listar categorias: %v", err)
+		return nil, errCatalogUnavailable
+	}
+	return categories, nil
+}
+
+// CreateProduct cria um novo produto após validar categoria e unicidade do SKU
+func (s *catalogService) CreateProduct(input ProductInput) (*domain.Product, error) {
+	if err := s.validateProduct("", &input); err != nil {
+		return nil, err
+	}
+
+	product := &domain.Product{}
+	applyProductInput(product, input)
+	if err := s.productRepo.Create(product); err != nil {
+		log.Printf("Erro ao criar produto: %v", err)
+		metrics.RecordCatalogUpdate("create_product", "error")
+		return nil, errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("create_product", "success")
+	s.index.Upsert(product)
+	log.Printf("✅ Produto criado: ID=%s, SKU=%s", product.ID, product.SKU)
+	return product, nil
+}
+
+// UpdateProduct substitui os campos editáveis de um produto. A moeda não muda, pois
+// variações e histórico de preços estão nela.
+func (s *catalogService) UpdateProduct(id string, input ProductInput) (*domain.Product, error) {
+	product, err := s.GetProduct(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := inProductCurrency(&input.Price, product.Currency); err != nil {
+		return nil, err
+	}
+	if err := s.validateProduct(id, &input); err != nil {
+		return nil, err
+	}
+
+	applyProductInput(product, input)
+	return s.saveProduct(product, "update_product")
+}
+
+// DeleteProduct remove um produto do catálogo
+func (s *catalogService) DeleteProduct(id string) error {
+	if id == "" {
+		return invalid("ID do produto é obrigatório")
+	}
+
+	if err := s.productRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		log.Printf("Erro ao remover produto %s: %v", id, err)
+		metrics.RecordCatalogUpdate("delete_product", "error")
+		return errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("delete_product", "success")
+	s.index.Remove(id)
+	log.Printf("🗑️ Produto removido: ID=%s", id)
+	return nil
+}
+
+// GetProduct retorna um produto pelo ID
+func (s *catalogService) GetProduct(id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, invalid("ID do produto é obrigatório")
+	}
+
+	metrics.RecordProductQuery("get")
+	product, err := s.productRepo.GetByID(id)
+	return productResult(product, err)
+}
+
+// GetProductBySKU retorna um produto pelo código SKU
+func (s *catalogService) GetProductBySKU(sku string) (*domain.Product, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, invalid("SKU é obrigatório")
+	}
+
+	metrics.RecordProductQuery("get_by_sku")
+	product, err := s.productRepo.GetBySKU(sku)
+	return productResult(product, err)
+}
+
+// ListProducts retorna uma página de produtos, opcionalmente filtrada por categoria.
+// Produtos inativos só aparecem quando IncludeInactive é verdadeiro.
+func (s *catalogService) ListProducts(query ProductQuery) (*ProductPage, error) {
+	page, pageSize := normalizePage(query.Page, query.PageSize)
+
+	if query.CategoryID != "" {
+		if _, err := s.GetCategory(query.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	metrics.RecordProductQuery("list")
+	products, total, err := s.productRepo.List(repository.ProductFilter{
+		CategoryID: query.CategoryID,
+		ActiveOnly: !query.IncludeInactive,
+		Offset:     (page - 1) * pageSize,
+		Limit:      pageSize,
+	})
+	if err != nil {
+		log.Printf("Erro ao listar produtos: %v", err)
+		return nil, errCatalogUnavailable
+	}
+
+	return &ProductPage{
+		Products:   products,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// SetProductActive ativa ou desativa a venda de um produto
+func (s *catalogService) SetProductActive(id string, active bool) (*domain.Product, error) {
+	product, err := s.GetProduct(id)
+	if err != nil {
+		return nil, err
+	}
+
+	product.IsActive = active
+	return s.saveProduct(product, "set_active")
+}
+
+// UpdateProductPrice altera o preço de um produto, na moeda do produto
+func (s *catalogService) UpdateProductPrice(id string, price money.Money) (*domain.Product, error) {
+	if !price.IsPositive() {
+		return nil, invalid("preço deve ser maior que zero")
+	}
+
+	product, err := s.GetProduct(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := inProductCurrency(&price, product.Currency); err != nil {
+		return nil, err
+	}
+
+	product.Price = price.Amount
+	return s.saveProduct(product, "update_price")
+}
+
+// validateProduct normaliza e valida os campos do produto.
+// productID é o produto em edição, ignorado na checagem de SKU duplicado.
+func (s *catalogService) validateProduct(productID string, input *ProductInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.SKU = strings.TrimSpace(input.SKU)
+	taxClass, err := normalizeTaxClass(input.TaxClass)
+	if err != nil {
+		return err
+	}
+	input.TaxClass = taxClass
+
+	if input.Name == "" {
+		return invalid("nome do produto é obrigatório")
+	}
+	if input.SKU == "" {
+		return invalid("SKU é obrigatório")
+	}
+	if err := validatePrice(&input.Price); err != nil {
+		return err
+	}
+	if input.StockQuantity < 0 {
+		return invalid("estoque não pode ser negativo")
+	}
+	if input.CategoryID == "" {
+		return invalid("categoria é obrigatória")
+	}
+
+	if _, err := s.GetCategory(input.CategoryID); err != nil {
+		return err
+	}
+
+	return s.checkSKU(input.SKU, productID)
+}
+
+func (s *catalogService) saveProduct(product *domain.Product, operation string) (*domain.Product, error) {
+	if err := s.productRepo.Update(product); err != nil {
+		log.Printf("Erro ao atualizar produto %s: %v", product.ID, err)
+		metrics.RecordCatalogUpdate(operation, "error")
+		return nil, errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate(operation, "success")
+	s.index.Upsert(product)
+	return product, nil
+}
+
+func applyProductInput(product *domain.Product, input ProductInput) {
+	product.CategoryID = input.CategoryID
+	product.Name = input.Name
+	product.Description = input.Description
+	product.Currency = input.Price.Currency
+	product.Price = input.Price.Amount
+	product.StockQuantity = input.StockQuantity
+	product.ImageURL = input.ImageURL
+	product.SKU = input.SKU
+	product.TaxClass = input.TaxClass
+	product.IsActive = input.IsActive
+}
+
+// validatePrice padroniza a moeda do preço (vazia vira a moeda padrão) e exige um
+// valor positivo em moeda suportada
+func validatePrice(price *money.Money) error {
+	price.Currency = money.NormalizeCurrency(price.Currency)
+	if !money.ValidCurrency(price.Currency) {
+		return invalid("moeda não suportada: " + price.Currency)
+	}
+	if !price.IsPositive() {
+		return invalid("preço deve ser maior que zero")
+	}
+	return nil
+}
+
+// inProductCurrency valida um preço que precisa estar na moeda do produto (variações,
+// alterações de preço e versões agendadas); moeda vazia assume a do produto
+func inProductCurrency(price *money.Money, currency string) error {
+	if strings.TrimSpace(price.Currency) == "" {
+		price.Currency = currency
+	}
+	if money.NormalizeCurrency(price.Currency) != money.NormalizeCurrency(currency) {
+		return invalid(fmt.Sprintf("o preço deve estar na moeda do produto (%s)", money.NormalizeCurrency(currency)))
+	}
+	return validatePrice(price)
+}
+
+// taxClassPattern define o formato aceito para classes fiscais (ex.: standard, food, service)
+var taxClassPattern = regexp.MustCompile(`^[a-z0-9_-]{1,30}$`)
+
+// normalizeTaxClass padroniza a classe fiscal; vazia significa a classe padrão
+func normalizeTaxClass(taxClass string) (string, error) {
+	taxClass = strings.ToLower(strings.TrimSpace(taxClass))
+	if taxClass == "" {
+		return domain.DefaultTaxClass, nil
+	}
+	if !taxClassPattern.MatchString(taxClass) {
+		return "", invalid("classe fiscal deve ter até 30 letras minúsculas, dígitos, - ou _")
+	}
+	return taxClass, nil
+}
+
+func productResult(product *domain.Product, err error) (*domain.Product, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		log.Printf("Erro ao buscar produto: %v", err)
+		return nil, errCatalogUnavailable
+	}
+	return product, nil
+}
+
+// normalizePage aplica os valores padrão e o limite máximo de paginação
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return page, pageSize
+}
+
+func invalid(msg string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidInput, msg)
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// MockCategoryRepository is a mock implementation of CategoryRepository
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) Create(category *domain.Category) error {
+	return m.Called(category).Error(0)
+}
+
+func (m *MockCategoryRepository) Update(category *domain.Category) error {
+	return m.Called(category).Error(0)
+}
+
+func (m *MockCategoryRepository) Delete(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockCategoryRepository) GetByID(id string) (*domain.Category, error) {
+	args := m.Called(id)
+	category, _ := args.Get(0).(*domain.Category)
+	return category, args.Error(1)
+}
+
+func (m *MockCategoryRepository) List() ([]domain.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+// MockProductRepository is a mock implementation of ProductRepository
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(product *domain.Product) error {
+	return m.Called(product).Error(0)
+}
+
+func (m *MockProductRepository) Update(product *domain.Product) error {
+	return m.Called(product).Error(0)
+}
+
+func (m *MockProductRepository) Delete(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockProductRepository) GetByID(id string) (*domain.Product, error) {
+	args := m.Called(id)
+	product, _ := args.Get(0).(*domain.Product)
+	return product, args.Error(1)
+}
+
+func (m *MockProductRepository) GetBySKU(sku string) (*domain.Product, error) {
+	args := m.Called(sku)
+	product, _ := args.Get(0).(*domain.Product)
+	return product, args.Error(1)
+}
+
+func (m *MockProductRepository) GetByIDs(ids []string) ([]domain.Product, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) ListAll() ([]domain.Product, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(filter repository.ProductFilter) ([]domain.Product, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.Product), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductRepository) CountByCategory(categoryID string) (int64, error) {
+	args := m.Called(categoryID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockVariantRepository is a mock implementation of VariantRepository
+type MockVariantRepository struct {
+	mock.Mock
+}
+
+func (m *MockVariantRepository) Create(variant *domain.Variant) error {
+	return m.Called(variant).Error(0)
+}
+
+func (m *MockVariantRepository) Update(variant *domain.Variant) error {
+	return m.Called(variant).Error(0)
+}
+
+func (m *MockVariantRepository) Delete(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockVariantRepository) GetByID(id string) (*domain.Variant, error) {
+	args := m.Called(id)
+	variant, _ := args.Get(0).(*domain.Variant)
+	return variant, args.Error(1)
+}
+
+func (m *MockVariantRepository) GetBySKU(sku string) (*domain.Variant, error) {
+	args := m.Called(sku)
+	variant, _ := args.Get(0).(*domain.Variant)
+	return variant, args.Error(1)
+}
+
+func (m *MockVariantRepository) GetByAttributes(productID string, attributes domain.Attributes) (*domain.Variant, error) {
+	args := m.Called(productID, attributes)
+	variant, _ := args.Get(0).(*domain.Variant)
+	return variant, args.Error(1)
+}
+
+func (m *MockVariantRepository) ListByProduct(productID string) ([]domain.Variant, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]domain.Variant), args.Error(1)
+}
+
+// noVariants returns a variant repository where no SKU belongs to a variant
+func noVariants() *MockVariantRepository {
+	variants := new(MockVariantRepository)
+	variants.On("GetBySKU", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	return variants
+}
+
+func validProductInput() ProductInput {
+	return ProductInput{
+		CategoryID:    "cat-001",
+		Name:          " Smartphone XYZ ",
+		Price:         money.New(199990, "BRL"),
+		StockQuantity: 10,
+		SKU:           "SMART-XYZ-001",
+		IsActive:      true,
+	}
+}
+
+func TestCreateProduct_Success(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	products := new(MockProductRepository)
+	categories.On("GetByID", "cat-001").Return(&domain.Category{ID: "cat-001"}, nil)
+	products.On("GetBySKU", "SMART-XYZ-001").Return(nil, gorm.ErrRecordNotFound)
+	products.On("Create", mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	product, err := NewCatalogService(categories, products, noVariants(), search.NewIndex()).CreateProduct(validProductInput())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Smartphone XYZ", product.Name)
+	assert.Equal(t, int64(199990), product.Price)
+	assert.Equal(t, "BRL", product.Currency)
+	assert.True(t, product.IsActive)
+	products.AssertExpectations(t)
+}
+
+func TestCreateProduct_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		apply func(*ProductInput)
+	}{
+		{"missing name", func(in *ProductInput) { in.Name = "  " }},
+		{"missing sku", func(in *ProductInput) { in.SKU = "" }},
+		{"zero price", func(in *ProductInput) { in.Price = money.Zero("BRL") }},
+		{"unknown currency", func(in *ProductInput) { in.Price = money.New(199990, "XYZ") }},
+		{"negative stock", func(in *ProductInput) { in.StockQuantity = -1 }},
+		{"missing category", func(in *ProductInput) { in.CategoryID = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := validProductInput()
+			tt.apply(&input)
+
+			_, err := NewCatalogService(new(MockCategoryRepository), new(MockProductRepository), noVariants(), search.NewIndex()).CreateProduct(input)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestCreateProduct_DuplicateSKU(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	products := new(MockProductRepository)
+	categories.On("GetByID", "cat-001").Return(&domain.Category{ID: "cat-001"}, nil)
+	products.On("GetBySKU", "SMART-XYZ-001").Return(&domain.Product{ID: "prod-001"}, nil)
+
+	_, err := NewCatalogService(categories, products, noVariants(), search.NewIndex()).CreateProduct(validProductInput())
+
+	assert.ErrorIs(t, err, ErrDuplicateSKU)
+	products.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateProduct_KeepsOwnSKU(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	products := new(MockProductRepository)
+	existing := &domain.Product{ID: "prod-001", SKU: "SMART-XYZ-001"}
+	categories.On("GetByID", "cat-001").Return(&domain.Category{ID: "cat-001"}, nil)
+	products.On("GetByID", "prod-001").Return(existing, nil)
+	products.On("GetBySKU", "SMART-XYZ-001").Return(existing, nil)
+	products.On("Update", existing).Return(nil)
+
+	input := validProductInput()
+	input.Price = money.New(179990, "")
+	product, err := NewCatalogService(categories, products, noVariants(), search.NewIndex()).UpdateProduct("prod-001", input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(179990), product.Price)
+}
+
+func TestCreateProduct_UnknownCategory(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	categories.On("GetByID", "cat-001").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := NewCatalogService(categories, new(MockProductRepository), noVariants(), search.NewIndex()).CreateProduct(validProductInput())
+
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+}
+
+func TestDeleteCategory_WithProducts(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	products := new(MockProductRepository)
+	categories.On("GetByID", "cat-001").Return(&domain.Category{ID: "cat-001"}, nil)
+	products.On("CountByCategory", "cat-001").Return(int64(3), nil)
+
+	err := NewCatalogService(categories, products, noVariants(), search.NewIndex()).DeleteCategory("cat-001")
+
+	assert.ErrorIs(t, err, ErrCategoryNotEmpty)
+	categories.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestListProducts_Pagination(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	products := new(MockProductRepository)
+	categories.On("GetByID", "cat-001").Return(&domain.Category{ID: "cat-001"}, nil)
+	products.On("List", repository.ProductFilter{
+		CategoryID: "cat-001",
+		ActiveOnly: true,
+		Offset:     20,
+		Limit:      10,
+	}).Return([]domain.Product{{ID: "prod-021"}}, int64(21), nil)
+
+	page, err := NewCatalogService(categories, products, noVariants(), search.NewIndex()).ListProducts(ProductQuery{
+		CategoryID: "cat-001",
+		Page:       3,
+		PageSize:   10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), page.Total)
+	assert.Equal(t, 3, page.TotalPages)
+	assert.Len(t, page.Products, 1)
+}
+
+func TestNormalizePage(t *testing.T) {
+	page, size := normalizePage(0, 0)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, DefaultPageSize, size)
+
+	_, size = normalizePage(2, 1000)
+	assert.Equal(t, MaxPageSize, size)
+}
+
+func TestUpdateProductPrice_RejectsNonPositive(t *testing.T) {
+	_, err := NewCatalogService(new(MockCategoryRepository), new(MockProductRepository), noVariants(), search.NewIndex()).UpdateProductPrice("prod-001", money.New(-500, ""))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestSearchProducts_LoadsHitsInIndexOrder(t *testing.T) {
+	products := new(MockProductRepository)
+	index := search.NewIndex()
+	index.Rebuild([]domain.Product{
+		{ID: "prod-001", CategoryID: "cat-001", Name: "Fone Bluetooth", SKU: "FONE-BT-001", Price: 29900, IsActive: true},
+		{ID: "prod-002", CategoryID: "cat-001", Name: "Fone com fio", SKU: "FONE-FIO-001", Price: 4990, IsActive: true},
+	})
+	products.On("GetByIDs", []string{"prod-002", "prod-001"}).
+		Return([]domain.Product{{ID: "prod-001"}, {ID: "prod-002"}}, nil)
+
+	page, err := NewCatalogService(new(MockCategoryRepository), products, noVariants(), index).SearchProducts(SearchQuery{Text: "fone", Sort: "PRICE_ASC"})
+
+	require.NoError(t, err)
+	require.Len(t, page.Hits, 2)
+	assert.Equal(t, "prod-002", page.Hits[0].Product.ID)
+	assert.Equal(t, 2, page.Total)
+	assert.Equal(t, 1, page.TotalPages)
+}
+
+func TestSearchProducts_InvalidSort(t *testing.T) {
+	_, err := NewCatalogService(new(MockCategoryRepository), new(MockProductRepository), noVariants(), search.NewIndex()).SearchProducts(SearchQuery{Sort: "popular"})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Prazo máximo aceito para uma reserva de estoque. Cobre pedidos pagos com boleto, que
+// ficam reservados até o vencimento, a tolerância após ele e o retorno do banco.
+const MaxReservationTTL = 15 * 24 * time.Hour
+
+// Quantidade de reservas vencidas liberadas por rodada do expirador
+const expiryBatchSize = 100
+
+var (
+	ErrInsufficientStock   = errors.New("estoque insuficiente para o produto")
+	ErrProductInactive     = errors.New("produto indisponível para venda")
+	ErrVariantRequired     = errors.New("o produto possui variações; informe o SKU da variação")
+	ErrReservationNotFound = errors.New("reserva não encontrada")
+	ErrReservationExpired  = errors.New("reserva expirada; o estoque foi devolvido")
+	ErrReservationClosed   = errors.New("reserva já foi liberada e não pode ser confirmada")
+)
+
+// ReserveInput identifica o produto (por ID ou SKU) e a quantidade a reservar.
+// Produtos com variações só podem ser reservados pelo SKU da variação.
+type ReserveInput struct {
+	ProductID string
+	SKU       string
+	Quantity  int
+	Reference string
+	TTL       time.Duration
+}
+
+// InventoryService define a interface para reservas de estoque
+type InventoryService interface {
+	Reserve(input ReserveInput) (*domain.Reservation, error)
+	Commit(id string) (*domain.Reservation, error)
+	Release(id string) (*domain.Reservation, error)
+	ExpireReservations(now time.Time) (int, error)
+	RunExpiryWorker(ctx context.Context, interval time.Duration)
+}
+
+// inventoryService implementa InventoryService
+type inventoryService struct {
+	productRepo     repository.ProductRepository
+	variantRepo     repository.VariantRepository
+	reservationRepo repository.ReservationRepository
+	defaultTTL      time.Duration
+	now             func() time.Time
+}
+
+// NewInventoryService cria uma nova instância do serviço de estoque.
+// defaultTTL é usado quando a reserva não informa prazo.
+func NewInventoryService(productRepo repository.ProductRepository, variantRepo repository.VariantRepository, reservationRepo repository.ReservationRepository, defaultTTL time.Duration) InventoryService {
+	return &inventoryService{
+		productRepo:     productRepo,
+		variantRepo:     variantRepo,
+		reservationRepo: reservationRepo,
+		defaultTTL:      defaultTTL,
+		now:             time.Now,
+	}
+}
+
+// Reserve separa estoque de um produto (ou variação) ativo até a confirmação ou o
+// vencimento da reserva. A reserva retornada traz o produto e a variação, para que o
+// pedido registre nome, preço e atributos vigentes no momento da compra.
+func (s *inventoryService) Reserve(input ReserveInput) (*domain.Reservation, error) {
+	if input.Quantity <= 0 {
+		return nil, invalid("quantidade deve ser maior que zero")
+	}
+	if input.TTL < 0 || input.TTL > MaxReservationTTL {
+		return nil, invalid(fmt.Sprintf("prazo da reserva deve estar entre 0 e %d dias", MaxReservationTTL/(24*time.Hour)))
+	}
+	if input.TTL == 0 {
+		input.TTL = s.defaultTTL
+	}
+
+	product, variant, err := s.findStock(input.ProductID, input.SKU)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsActive || (variant != nil && !variant.IsActive) {
+		return nil, ErrProductInactive
+	}
+
+	reservation := &domain.Reservation{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		Currency:  product.PriceMoney().Currency,
+		Quantity:  input.Quantity,
+		Reference: strings.TrimSpace(input.Reference),
+		ExpiresAt: s.now().Add(input.TTL),
+	}
+	if variant != nil {
+		reservation.VariantID = variant.ID
+		reservation.SKU = variant.SKU
+	}
+	if err := s.reservationRepo.Reserve(reservation); err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			metrics.RecordCatalogUpdate("reserve_stock", "insufficient")
+			return nil, ErrInsufficientStock
+		}
+		log.Printf("Erro ao reservar estoque do produto %s: %v", product.ID, err)
+		metrics.RecordCatalogUpdate("reserve_stock", "error")
+		return nil, errCatalogUnavailable
+	}
+
+	reservation.Product = product
+	reservation.Variant = variant
+	metrics.RecordCatalogUpdate("reserve_stock", "success")
+	log.Printf("📦 Estoque reservado: Reserva=%s, SKU=%s, Quantidade=%d, Referência=%s",
+		reservation.ID, reservation.SKU, reservation.Quantity, reservation.Reference)
+	return reservation, nil
+}
+
+// Commit confirma a reserva, tornando definitiva a baixa de estoque.
+// Uma reserva vencida é liberada e retorna ErrReservationExpired.
+func (s *inventoryService) Commit(id string) (*domain.Reservation, error) {
+	if id == "" {
+		return nil, invalid("ID da reserva é obrigatório")
+	}
+
+	reservation, err := s.reservationRepo.Commit(id, s.now())
+	switch {
+	case err == nil:
+		metrics.RecordCatalogUpdate("commit_reservation", "success")
+		log.Printf("✅ Reserva confirmada: %s", id)
+		return reservation, nil
+	case errors.Is(err, repository.ErrReservationExpired):
+		if _, releaseErr := s.reservationRepo.Release(id, domain.ReservationExpired); releaseErr != nil {
+			log.Printf("⚠️ Erro ao expirar reserva %s: %v", id, releaseErr)
+		}
+		return nil, ErrReservationExpired
+	case errors.Is(err, repository.ErrReservationClosed):
+		return nil, ErrReservationClosed
+	default:
+		return nil, reservationError(id, err)
+	}
+}
+
+// Release devolve ao estoque uma reserva pendente. Liberar uma reserva que já não
+// está pendente não tem efeito, o que torna a operação segura para reprocessamento.
+func (s *inventoryService) Release(id string) (*domain.Reservation, error) {
+	if id == "" {
+		return nil, invalid("ID da reserva é obrigatório")
+	}
+
+	reservation, err := s.reservationRepo.Release(id, domain.ReservationReleased)
+	if err != nil {
+		return nil, reservationError(id, err)
+	}
+
+	metrics.RecordCatalogUpdate("release_reservation", "success")
+	log.Printf("↩️ Reserva %s: estado=%s", id, reservation.Status)
+	return reservation, nil
+}
+
+// ExpireReservations libera as reservas pendentes vencidas e retorna quantas foram expiradas
+func (s *inventoryService) ExpireReservations(now time.Time) (int, error) {
+	expired := 0
+	for {
+		reservations, err := s.reservationRepo.ListExpired(now, expiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, reservation := range reservations {
+			if _, err := s.reservationRepo.Release(reservation.ID, domain.ReservationExpired); err != nil {
+				return expired, err
+			}
+			expired++
+		}
+
+		if len(reservations) < expiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// RunExpiryWorker expira reservas vencidas periodicamente até o contexto ser cancelado
+func (s *inventoryService) RunExpiryWorker(ctx context.Context, interval time.Duration) {
+	log.Printf("⏰ Expirador de reservas iniciado (intervalo %s)", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Expirador de reservas finalizado")
+			return
+		case <-ticker.C:
+			count, err := s.ExpireReservations(s.now())
+			if err != nil {
+				log.Printf("⚠️ Erro ao expirar reservas: %v", err)
+			}
+			if count > 0 {
+				metrics.RecordCatalogUpdate("expire_reservations", "success")
+				log.Printf("⌛ %d reservas expiradas e devolvidas ao estoque", count)
+			}
+		}
+	}
+}
+
+// findStock localiza o item a reservar. Um SKU pode ser de variação ou de produto;
+// produtos com variações exigem o SKU da variação.
+func (s *inventoryService) findStock(productID, sku string) (*domain.Product, *domain.Variant, error) {
+	sku = strings.TrimSpace(sku)
+	var product *domain.Product
+	var err error
+	switch {
+	case productID != "":
+		product, err = productResult(s.productRepo.GetByID(productID))
+	case sku != "":
+		variant, variantErr := s.variantRepo.GetBySKU(sku)
+		if variantErr == nil {
+			product, err = productResult(s.productRepo.GetByID(variant.ProductID))
+			if err != nil {
+				return nil, nil, err
+			}
+			return product, variant, nil
+		}
+		if !errors.Is(variantErr, gorm.ErrRecordNotFound) {
+			log.Printf("Erro ao buscar variação %s: %v", sku, variantErr)
+			return nil, nil, errCatalogUnavailable
+		}
+		product, err = productResult(s.productRepo.GetBySKU(sku))
+	default:
+		return nil, nil, invalid("ID do produto ou SKU é obrigatório")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if product.HasVariants() {
+		return nil, nil, ErrVariantRequired
+	}
+	return product, nil, nil
+}
+
+func reservationError(id string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrReservationNotFound
+	}
+	log.Printf("Erro ao atualizar reserva %s: %v", id, err)
+	return errCatalogUnavailable
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// MockReservationRepository is a mock implementation of ReservationRepository
+type MockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationRepository) Reserve(reservation *domain.Reservation) error {
+	return m.Called(reservation).Error(0)
+}
+
+func (m *MockReservationRepository) Commit(id string, now time.Time) (*domain.Reservation, error) {
+	args := m.Called(id, now)
+	reservation, _ := args.Get(0).(*domain.Reservation)
+	return reservation, args.Error(1)
+}
+
+func (m *MockReservationRepository) Release(id string, status string) (*domain.Reservation, error) {
+	args := m.Called(id, status)
+	reservation, _ := args.Get(0).(*domain.Reservation)
+	return reservation, args.Error(1)
+}
+
+func (m *MockReservationRepository) GetByID(id string) (*domain.Reservation, error) {
+	args := m.Called(id)
+	reservation, _ := args.Get(0).(*domain.Reservation)
+	return reservation, args.Error(1)
+}
+
+func (m *MockReservationRepository) ListExpired(now time.Time, limit int) ([]domain.Reservation, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]domain.Reservation), args.Error(1)
+}
+
+func newTestInventoryService(products *MockProductRepository, reservations *MockReservationRepository, now time.Time) *inventoryService {
+	svc := NewInventoryService(products, noVariants(), reservations, 15*time.Minute).(*inventoryService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestReserve_BySKUUsesDefaultTTL(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	products := new(MockProductRepository)
+	reservations := new(MockReservationRepository)
+	products.On("GetBySKU", "SMART-XYZ-001").Return(&domain.Product{ID: "prod-001", SKU: "SMART-XYZ-001", IsActive: true}, nil)
+	reservations.On("Reserve", mock.AnythingOfType("*domain.Reservation")).Return(nil)
+
+	reservation, err := newTestInventoryService(products, reservations, now).Reserve(ReserveInput{
+		SKU:       "SMART-XYZ-001",
+		Quantity:  2,
+		Reference: "order-42",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "prod-001", reservation.ProductID)
+	assert.Equal(t, now.Add(15*time.Minute), reservation.ExpiresAt)
+}
+
+func TestReserve_AcceptsBoletoTTL(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	products := new(MockProductRepository)
+	reservations := new(MockReservationRepository)
+	products.On("GetBySKU", "SMART-XYZ-001").Return(&domain.Product{ID: "prod-001", SKU: "SMART-XYZ-001", IsActive: true}, nil)
+	reservations.On("Reserve", mock.AnythingOfType("*domain.Reservation")).Return(nil)
+
+	reservation, err := newTestInventoryService(products, reservations, now).Reserve(ReserveInput{
+		SKU:      "SMART-XYZ-001",
+		Quantity: 1,
+		TTL:      12 * 24 * time.Hour,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(12*24*time.Hour), reservation.ExpiresAt)
+}
+
+func TestReserve_InsufficientStock(t *testing.T) {
+	products := new(MockProductRepository)
+	reservations := new(MockReservationRepository)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001", IsActive: true}, nil)
+	reservations.On("Reserve", mock.Anything).Return(repository.ErrInsufficientStock)
+
+	_, err := newTestInventoryService(products, reservations, time.Now()).Reserve(ReserveInput{ProductID: "prod-001", Quantity: 99})
+
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+}
+
+func TestReserve_InactiveProduct(t *testing.T) {
+	products := new(MockProductRepository)
+	reservations := new(MockReservationRepository)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001", IsActive: false}, nil)
+
+	_, err := newTestInventoryService(products, reservations, time.Now()).Reserve(ReserveInput{ProductID: "prod-001", Quantity: 1})
+
+	assert.ErrorIs(t, err, ErrProductInactive)
+	reservations.AssertNotCalled(t, "Reserve", mock.Anything)
+}
+
+func TestReserve_Validation(t *testing.T) {
+	svc := newTestInventoryService(new(MockProductRepository), new(MockReservationRepository), time.Now())
+
+	_, err := svc.Reserve(ReserveInput{ProductID: "prod-001", Quantity: 0})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = svc.Reserve(ReserveInput{ProductID: "prod-001", Quantity: 1, TTL: MaxReservationTTL + time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = svc.Reserve(ReserveInput{Quantity: 1})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestCommit_ExpiredReservationIsReleased(t *testing.T) {
+	now := time.Now()
+	reservations := new(MockReservationRepository)
+	reservations.On("Commit", "res-1", now).Return(nil, repository.ErrReservationExpired)
+	reservations.On("Release", "res-1", domain.ReservationExpired).Return(&domain.Reservation{ID: "res-1", Status: domain.ReservationExpired}, nil)
+
+	_, err := newTestInventoryService(new(MockProductRepository), reservations, now).Commit("res-1")
+
+	assert.ErrorIs(t, err, ErrReservationExpired)
+	reservations.AssertExpectations(t)
+}
+
+func TestExpireReservations(t *testing.T) {
+	now := time.Now()
+	reservations := new(MockReservationRepository)
+	reservations.On("ListExpired", now, expiryBatchSize).Return([]domain.Reservation{{ID: "res-1"}, {ID: "res-2"}}, nil)
+	reservations.On("Release", mock.Anything, domain.ReservationExpired).Return(&domain.Reservation{}, nil)
+
+	count, err := newTestInventoryService(new(MockProductRepository), reservations, now).ExpireReservations(now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	reservations.AssertNumberOfCalls(t, "Release", 2)
+}
+
+func TestReserve_ByVariantSKU(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	products := new(MockProductRepository)
+	variants := new(MockVariantRepository)
+	reservations := new(MockReservationRepository)
+	variant := &domain.Variant{ID: "var-001", ProductID: "prod-001", SKU: "CAM-AZ-M", Price: 4990, IsActive: true,
+		Attributes: domain.Attributes{"cor": "azul", "tamanho": "M"}}
+	variants.On("GetBySKU", "CAM-AZ-M").Return(variant, nil)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001", Name: "Camiseta", SKU: "CAM", Price: 3990, IsActive: true,
+		Variants: []domain.Variant{*variant}}, nil)
+	reservations.On("Reserve", mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.VariantID == "var-001" && r.SKU == "CAM-AZ-M"
+	})).Return(nil)
+
+	svc := NewInventoryService(products, variants, reservations, 15*time.Minute).(*inventoryService)
+	svc.now = func() time.Time { return now }
+	reservation, err := svc.Reserve(ReserveInput{SKU: "CAM-AZ-M", Quantity: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "prod-001", reservation.ProductID)
+	assert.Equal(t, money.New(4990, "BRL"), reservation.UnitPrice())
+	assert.Equal(t, "Camiseta", reservation.Product.Name)
+}
+
+func TestReserve_ProductWithVariantsRequiresVariantSKU(t *testing.T) {
+	products := new(MockProductRepository)
+	reservations := new(MockReservationRepository)
+	products.On("GetBySKU", "CAM").Return(&domain.Product{ID: "prod-001", SKU: "CAM", IsActive: true,
+		Variants: []domain.Variant{{ID: "var-001", SKU: "CAM-AZ-M"}}}, nil)
+
+	_, err := newTestInventoryService(products, reservations, time.Now()).Reserve(ReserveInput{SKU: "CAM", Quantity: 1})
+
+	assert.ErrorIs(t, err, ErrVariantRequired)
+	reservations.AssertNotCalled(t, "Reserve", mock.Anything)
+}
+
+func TestReserve_InactiveVariant(t *testing.T) {
+	products := new(MockProductRepository)
+	variants := new(MockVariantRepository)
+	reservations := new(MockReservationRepository)
+	variants.On("GetBySKU", "CAM-AZ-M").Return(&domain.Variant{ID: "var-001", ProductID: "prod-001", IsActive: false}, nil)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001", IsActive: true}, nil)
+
+	_, err := NewInventoryService(products, variants, reservations, time.Minute).Reserve(ReserveInput{SKU: "CAM-AZ-M", Quantity: 1})
+
+	assert.ErrorIs(t, err, ErrProductInactive)
+	reservations.AssertNotCalled(t, "Reserve", mock.Anything)
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// Quantidade de produtos e variações processados por rodada do agendador de preços
+const priceSchedulerBatchSize = 100
+
+// Tolerância para inícios de vigência no passado (relógios dessincronizados, latência)
+const priceClockSkew = time.Minute
+
+// Tamanho máximo do motivo de uma versão de preço
+const maxPriceReasonLength = 200
+
+var (
+	ErrPriceNotFound       = errors.New("nenhum preço vigente para o SKU na data informada")
+	ErrPriceRecordNotFound = errors.New("versão de preço não encontrada")
+	ErrPriceAlreadyActive  = errors.New("a versão de preço já está em vigor e não pode ser cancelada")
+)
+
+// Situação de uma versão de preço em relação ao momento da consulta
+const (
+	PriceScheduled = "scheduled" // ainda não começou a valer
+	PriceStarted   = "started"   // janela em andamento (vigente, se não houver versão mais nova)
+	PriceEnded     = "ended"     // janela encerrada
+)
+
+// ScheduleInput define uma nova versão de preço para um SKU. ValidFrom zero significa
+// agora; ValidTo nulo mantém o preço até a próxima versão. O preço fica na moeda do
+// produto; moeda vazia assume a do produto.
+type ScheduleInput struct {
+	SKU       string
+	Price     money.Money
+	ValidFrom time.Time
+	ValidTo   *time.Time
+	Reason    string
+}
+
+// PriceAt é o preço de um SKU em um instante. Record é nil para produtos ainda
+// sem histórico, cujo preço vem do cadastro.
+type PriceAt struct {
+	SKU       string
+	ProductID string
+	VariantID string
+	Price     money.Money
+	At        time.Time
+	Record    *domain.PriceRecord
+}
+
+// PriceHistory é o histórico de preços de um SKU, das versões mais novas para as mais antigas
+type PriceHistory struct {
+	SKU             string
+	ProductID       string
+	VariantID       string
+	CurrentPrice    money.Money
+	CurrentRecordID string
+	Records         []domain.PriceRecord
+}
+
+// PriceService define a interface para histórico e agendamento de preços
+type PriceService interface {
+	SchedulePrice(input ScheduleInput) (*domain.PriceRecord, error)
+	CancelScheduledPrice(id string) error
+	ListPriceHistory(sku string) (*PriceHistory, error)
+	GetPriceAt(sku string, at time.Time) (*PriceAt, error)
+	ApplyScheduledPrices(now time.Time) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+// priceService implementa PriceService
+type priceService struct {
+	productRepo repository.ProductRepository
+	variantRepo repository.VariantRepository
+	priceRepo   repository.PriceRepository
+	index       *search.Index
+	now         func() time.Time
+}
+
+// NewPriceService cria uma nova instância do serviço de preços.
+// Os produtos com preço alterado pelo agendador são atualizados no índice de busca.
+func NewPriceService(productRepo repository.ProductRepository, variantRepo repository.VariantRepository, priceRepo repository.PriceRepository, index *search.Index) PriceService {
+	return &priceService{
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		priceRepo:   priceRepo,
+		index:       index,
+		now:         time.Now,
+	}
+}
+
+// SchedulePrice grava uma nova versão de preço. Versões que começam agora são aplicadas
+// ao cadastro imediatamente; as futuras ficam para o agendador.
+func (s *priceService) SchedulePrice(input ScheduleInput) (*domain.PriceRecord, error) {
+	now := s.now()
+	input.Reason = strings.TrimSpace(input.Reason)
+
+	if !input.Price.IsPositive() {
+		return nil, invalid("preço deve ser maior que zero")
+	}
+	if input.ValidFrom.IsZero() {
+		input.ValidFrom = now
+	}
+	if input.ValidFrom.Before(now.Add(-priceClockSkew)) {
+		return nil, invalid("o início da vigência não pode estar no passado")
+	}
+	if input.ValidTo != nil && !input.ValidTo.After(input.ValidFrom) {
+		return nil, invalid("o fim da vigência deve ser posterior ao início")
+	}
+	if len(input.Reason) > maxPriceReasonLength {
+		return nil, invalid("o motivo aceita no máximo 200 caracteres")
+	}
+
+	owner, price, err := s.resolveSKU(input.SKU)
+	if err != nil {
+		return nil, err
+	}
+	if err := inProductCurrency(&input.Price, price.Currency); err != nil {
+		return nil, err
+	}
+
+	// Uma promoção com prazo precisa de um preço base para onde voltar ao terminar
+	if input.ValidTo != nil {
+		if err := s.ensureBasePrice(owner, price, now); err != nil {
+			return nil, err
+		}
+	}
+
+	record := &domain.PriceRecord{
+		ProductID: owner.ProductID,
+		VariantID: owner.VariantID,
+		Currency:  input.Price.Currency,
+		Price:     input.Price.Amount,
+		ValidFrom: input.ValidFrom,
+		ValidTo:   input.ValidTo,
+		Reason:    input.Reason,
+	}
+	if err := s.priceRepo.Create(record); err != nil {
+		log.Printf("Erro ao agendar preço do SKU %s: %v", input.SKU, err)
+		metrics.RecordCatalogUpdate("schedule_price", "error")
+		return nil, errCatalogUnavailable
+	}
+	metrics.RecordCatalogUpdate("schedule_price", "success")
+	log.Printf("🏷️ Preço agendado: SKU=%s, Preço=%s, Início=%s", strings.TrimSpace(input.SKU), record.PriceMoney(), record.ValidFrom.Format(time.RFC3339))
+
+	if !record.IsScheduled(now) {
+		if err := s.apply(owner, now); err != nil {
+			log.Printf("⚠️ Erro ao aplicar preço do SKU %s (o agendador tentará novamente): %v", input.SKU, err)
+		}
+	}
+	return record, nil
+}
+
+// CancelScheduledPrice remove uma versão de preço que ainda não começou a valer
+func (s *priceService) CancelScheduledPrice(id string) error {
+	if id == "" {
+		return invalid("ID da versão de preço é obrigatório")
+	}
+
+	err := s.priceRepo.CancelScheduled(id, s.now())
+	switch {
+	case err == nil:
+		metrics.RecordCatalogUpdate("cancel_price", "success")
+		log.Printf("🗑️ Preço agendado cancelado: %s", id)
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrPriceRecordNotFound
+	case errors.Is(err, repository.ErrPriceStarted):
+		return ErrPriceAlreadyActive
+	default:
+		log.Printf("Erro ao cancelar preço %s: %v", id, err)
+		return errCatalogUnavailable
+	}
+}
+
+// ListPriceHistory retorna todas as versões de preço do SKU e o preço vigente agora
+func (s *priceService) ListPriceHistory(sku string) (*PriceHistory, error) {
+	owner, price, err := s.resolveSKU(sku)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.priceRepo.ListByOwner(owner)
+	if err != nil {
+		log.Printf("Erro ao listar histórico de preços do SKU %s: %v", sku, err)
+		return nil, errCatalogUnavailable
+	}
+
+	history := &PriceHistory{
+		SKU:          strings.TrimSpace(sku),
+		ProductID:    owner.ProductID,
+		VariantID:    owner.VariantID,
+		CurrentPrice: price,
+		Records:      records,
+	}
+	if current := effectiveRecord(records, s.now()); current != nil {
+		history.CurrentPrice = current.PriceMoney()
+		history.CurrentRecordID = current.ID
+	}
+	return history, nil
+}
+
+// GetPriceAt retorna o preço do SKU vigente no instante informado (zero significa agora).
+// Produtos sem histórico retornam o preço do cadastro.
+func (s *priceService) GetPriceAt(sku string, at time.Time) (*PriceAt, error) {
+	if at.IsZero() {
+		at = s.now()
+	}
+
+	owner, price, err := s.resolveSKU(sku)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.RecordProductQuery("price_at")
+	result := &PriceAt{SKU: strings.TrimSpace(sku), ProductID: owner.ProductID, VariantID: owner.VariantID, At: at}
+	record, err := s.priceRepo.GetEffective(owner, at)
+	switch {
+	case err == nil:
+		result.Price = record.PriceMoney()
+		result.Record = record
+		return result, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("Erro ao buscar preço do SKU %s: %v", sku, err)
+		return nil, errCatalogUnavailable
+	}
+
+	records, err := s.priceRepo.ListByOwner(owner)
+	if err != nil {
+		log.Printf("Erro ao listar histórico de preços do SKU %s: %v", sku, err)
+		return nil, errCatalogUnavailable
+	}
+	if len(records) > 0 {
+		return nil, ErrPriceNotFound
+	}
+	result.Price = price
+	return result, nil
+}
+
+// ApplyScheduledPrices grava no cadastro os preços cujas janelas começaram ou terminaram
+// até now e retorna quantos produtos ou variações foram atualizados
+func (s *priceService) ApplyScheduledPrices(now time.Time) (int, error) {
+	applied := 0
+	for {
+		owners, err := s.priceRepo.ListDueOwners(now, priceSchedulerBatchSize)
+		if err != nil {
+			return applied, err
+		}
+
+		for _, owner := range owners {
+			if err := s.apply(owner, now); err != nil {
+				return applied, err
+			}
+			applied++
+		}
+
+		if len(owners) < priceSchedulerBatchSize {
+			return applied, nil
+		}
+	}
+}
+
+// RunScheduler aplica os preços agendados periodicamente até o contexto ser cancelado
+func (s *priceService) RunScheduler(ctx context.Context, interval time.Duration) {
+	log.Printf("⏰ Agendador de preços iniciado (intervalo %s)", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Agendador de preços finalizado")
+			return
+		case <-ticker.C:
+			count, err := s.ApplyScheduledPrices(s.now())
+			if err != nil {
+				log.Printf("⚠️ Erro ao aplicar preços agendados: %v", err)
+			}
+			if count > 0 {
+				log.Printf("🏷️ %d preços agendados aplicados", count)
+			}
+		}
+	}
+}
+
+// apply grava o preço vigente no cadastro e atualiza o produto no índice de busca
+func (s *priceService) apply(owner repository.PriceOwner, now time.Time) error {
+	record, err := s.priceRepo.ApplyDue(owner, now)
+	if err != nil {
+		metrics.RecordCatalogUpdate("apply_price", "error")
+		return err
+	}
+	metrics.RecordCatalogUpdate("apply_price", "success")
+	if record != nil {
+		log.Printf("🏷️ Preço aplicado: Produto=%s, Variação=%s, Preço=%s", owner.ProductID, owner.VariantID, record.PriceMoney())
+	}
+
+	product, err := s.productRepo.GetByID(owner.ProductID)
+	if err != nil {
+		log.Printf("⚠️ Erro ao reindexar produto %s: %v", owner.ProductID, err)
+		return nil
+	}
+	s.index.Upsert(product)
+	return nil
+}
+
+// ensureBasePrice grava o preço do cadastro como versão sem prazo quando nenhuma versão
+// está vigente (produtos anteriores ao histórico de preços)
+func (s *priceService) ensureBasePrice(owner repository.PriceOwner, price money.Money, now time.Time) error {
+	_, err := s.priceRepo.GetEffective(owner, now)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Erro ao buscar preço vigente: %v", err)
+		return errCatalogUnavailable
+	}
+
+	base := &domain.PriceRecord{
+		ProductID: owner.ProductID,
+		VariantID: owner.VariantID,
+		Currency:  price.Currency,
+		Price:     price.Amount,
+		ValidFrom: now,
+		Reason:    "preço base",
+		AppliedAt: &now,
+	}
+	if err := s.priceRepo.Create(base); err != nil {
+		log.Printf("Erro ao gravar preço base: %v", err)
+		return errCatalogUnavailable
+	}
+	return nil
+}
+
+// resolveSKU encontra a variação ou o produto do SKU e retorna seu preço de cadastro
+func (s *priceService) resolveSKU(sku string) (repository.PriceOwner, money.Money, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return repository.PriceOwner{}, money.Money{}, invalid("SKU é obrigatório")
+	}
+
+	variant, err := s.variantRepo.GetBySKU(sku)
+	switch {
+	case err == nil:
+		return repository.PriceOwner{ProductID: variant.ProductID, VariantID: variant.ID}, variant.PriceMoney(), nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("Erro ao buscar variação %s: %v", sku, err)
+		return repository.PriceOwner{}, money.Money{}, errCatalogUnavailable
+	}
+
+	product, err := s.productRepo.GetBySKU(sku)
+	if _, err := productResult(product, err); err != nil {
+		return repository.PriceOwner{}, money.Money{}, err
+	}
+	return repository.PriceOwner{ProductID: product.ID}, product.PriceMoney(), nil
+}
+
+// effectiveRecord escolhe, entre versões ordenadas da mais nova para a mais antiga,
+// a vigente no instante informado
+func effectiveRecord(records []domain.PriceRecord, at time.Time) *domain.PriceRecord {
+	for i := range records {
+		if records[i].ActiveAt(at) {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+// PriceStatus classifica a versão em relação ao instante informado
+func PriceStatus(record *domain.PriceRecord, now time.Time) string {
+	switch {
+	case record.IsScheduled(now):
+		return PriceScheduled
+	case record.ValidTo != nil && !now.Before(*record.ValidTo):
+		return PriceEnded
+	default:
+		return PriceStarted
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// MockPriceRepository is a mock implementation of PriceRepository
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceRepository) Create(record *domain.PriceRecord) error {
+	return m.Called(record).Error(0)
+}
+
+func (m *MockPriceRepository) CancelScheduled(id string, now time.Time) error {
+	return m.Called(id, now).Error(0)
+}
+
+func (m *MockPriceRepository) GetByID(id string) (*domain.PriceRecord, error) {
+	args := m.Called(id)
+	record, _ := args.Get(0).(*domain.PriceRecord)
+	return record, args.Error(1)
+}
+
+func (m *MockPriceRepository) ListByOwner(owner repository.PriceOwner) ([]domain.PriceRecord, error) {
+	args := m.Called(owner)
+	return args.Get(0).([]domain.PriceRecord), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetEffective(owner repository.PriceOwner, at time.Time) (*domain.PriceRecord, error) {
+	args := m.Called(owner, at)
+	record, _ := args.Get(0).(*domain.PriceRecord)
+	return record, args.Error(1)
+}
+
+func (m *MockPriceRepository) ListDueOwners(now time.Time, limit int) ([]repository.PriceOwner, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]repository.PriceOwner), args.Error(1)
+}
+
+func (m *MockPriceRepository) ApplyDue(owner repository.PriceOwner, now time.Time) (*domain.PriceRecord, error) {
+	args := m.Called(owner, now)
+	record, _ := args.Get(0).(*domain.PriceRecord)
+	return record, args.Error(1)
+}
+
+var priceNow = time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+
+// newPriceService returns a price service for product SMART-XYZ-001 (prod-001, R$ 1999,90) at priceNow
+func newPriceService(prices *MockPriceRepository) (*priceService, *MockProductRepository) {
+	products := new(MockProductRepository)
+	products.On("GetBySKU", "SMART-XYZ-001").Return(&domain.Product{ID: "prod-001", SKU: "SMART-XYZ-001", Price: 199990}, nil)
+	svc := NewPriceService(products, noVariants(), prices, search.NewIndex()).(*priceService)
+	svc.now = func() time.Time { return priceNow }
+	return svc, products
+}
+
+func TestSchedulePrice_PromotionKeepsBasePrice(t *testing.T) {
+	prices := new(MockPriceRepository)
+	owner := repository.PriceOwner{ProductID: "prod-001"}
+	prices.On("GetEffective", owner, priceNow).Return(nil, gorm.ErrRecordNotFound)
+	prices.On("Create", mock.MatchedBy(func(r *domain.PriceRecord) bool { return r.Price == 199990 && r.ValidTo == nil })).Return(nil).Once()
+	prices.On("Create", mock.MatchedBy(func(r *domain.PriceRecord) bool { return r.Price == 179990 })).Return(nil).Once()
+	svc, _ := newPriceService(prices)
+
+	start := priceNow.Add(24 * time.Hour)
+	end := start.Add(72 * time.Hour)
+	record, err := svc.SchedulePrice(ScheduleInput{SKU: "SMART-XYZ-001", Price: money.New(179990, ""), ValidFrom: start, ValidTo: &end, Reason: " Black Friday "})
+
+	require.NoError(t, err)
+	assert.Equal(t, "prod-001", record.ProductID)
+	assert.Equal(t, "Black Friday", record.Reason)
+	assert.Equal(t, PriceScheduled, PriceStatus(record, priceNow))
+	prices.AssertExpectations(t)
+	prices.AssertNotCalled(t, "ApplyDue", mock.Anything, mock.Anything)
+}
+
+func TestSchedulePrice_ImmediateChangeIsApplied(t *testing.T) {
+	prices := new(MockPriceRepository)
+	owner := repository.PriceOwner{ProductID: "prod-001"}
+	prices.On("Create", mock.AnythingOfType("*domain.PriceRecord")).Return(nil)
+	prices.On("ApplyDue", owner, priceNow).Return(&domain.PriceRecord{Price: 189990}, nil)
+	svc, products := newPriceService(prices)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001", Name: "Smartphone XYZ", Price: 189990, IsActive: true}, nil)
+
+	record, err := svc.SchedulePrice(ScheduleInput{SKU: "SMART-XYZ-001", Price: money.New(189990, "")})
+
+	require.NoError(t, err)
+	assert.Equal(t, priceNow, record.ValidFrom)
+	assert.Equal(t, 1, svc.index.Len())
+	prices.AssertExpectations(t)
+}
+
+func TestSchedulePrice_Validation(t *testing.T) {
+	past := priceNow.Add(-time.Hour)
+	tests := []struct {
+		name  string
+		input ScheduleInput
+	}{
+		{"zero price", ScheduleInput{SKU: "SMART-XYZ-001"}},
+		{"start in the past", ScheduleInput{SKU: "SMART-XYZ-001", Price: money.New(1000, ""), ValidFrom: past}},
+		{"end before start", ScheduleInput{SKU: "SMART-XYZ-001", Price: money.New(1000, ""), ValidTo: &past}},
+		{"missing sku", ScheduleInput{Price: money.New(1000, "")}},
+		{"currency differs from product", ScheduleInput{SKU: "SMART-XYZ-001", Price: money.New(1000, "USD")}},
+		{"unknown currency", ScheduleInput{SKU: "SMART-XYZ-001", Price: money.New(1000, "XYZ")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newPriceService(new(MockPriceRepository))
+
+			_, err := svc.SchedulePrice(tt.input)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestGetPriceAt_UsesHistory(t *testing.T) {
+	prices := new(MockPriceRepository)
+	at := priceNow.Add(48 * time.Hour)
+	owner := repository.PriceOwner{ProductID: "prod-001"}
+	prices.On("GetEffective", owner, at).Return(&domain.PriceRecord{ID: "price-2", Price: 179990, ValidFrom: priceNow}, nil)
+	svc, _ := newPriceService(prices)
+
+	price, err := svc.GetPriceAt(" SMART-XYZ-001 ", at)
+
+	require.NoError(t, err)
+	assert.Equal(t, "SMART-XYZ-001", price.SKU)
+	assert.Equal(t, money.New(179990, "BRL"), price.Price)
+	assert.Equal(t, "price-2", price.Record.ID)
+}
+
+func TestGetPriceAt_WithoutHistoryUsesProductPrice(t *testing.T) {
+	prices := new(MockPriceRepository)
+	owner := repository.PriceOwner{ProductID: "prod-001"}
+	prices.On("GetEffective", owner, priceNow).Return(nil, gorm.ErrRecordNotFound)
+	prices.On("ListByOwner", owner).Return([]domain.PriceRecord{}, nil)
+	svc, _ := newPriceService(prices)
+
+	price, err := svc.GetPriceAt("SMART-XYZ-001", time.Time{})
+
+	require.NoError(t, err)
+	assert.Equal(t, money.New(199990, "BRL"), price.Price)
+	assert.Nil(t, price.Record)
+}
+
+func TestGetPriceAt_BeforeHistory(t *testing.T) {
+	prices := new(MockPriceRepository)
+	at := priceNow.AddDate(-1, 0, 0)
+	owner := repository.PriceOwner{ProductID: "prod-001"}
+	prices.On("GetEffective", owner, at).Return(nil, gorm.ErrRecordNotFound)
+	prices.On("ListByOwner", owner).Return([]domain.PriceRecord{{ID: "price-1", Price: 199990, ValidFrom: priceNow}}, nil)
+	svc, _ := newPriceService(prices)
+
+	_, err := svc.GetPriceAt("SMART-XYZ-001", at)
+
+	assert.ErrorIs(t, err, ErrPriceNotFound)
+}
+
+func TestListPriceHistory_CurrentRecord(t *testing.T) {
+	prices := new(MockPriceRepository)
+	end := priceNow.Add(time.Hour)
+	owner := repository.PriceOwner{ProductID: "prod-001"}
+	prices.On("ListByOwner", owner).Return([]domain.PriceRecord{
+		{ID: "price-3", Price: 169990, ValidFrom: priceNow.Add(24 * time.Hour)},
+		{ID: "price-2", Price: 179990, ValidFrom: priceNow.Add(-time.Hour), ValidTo: &end},
+		{ID: "price-1", Price: 199990, ValidFrom: priceNow.AddDate(0, -1, 0)},
+	}, nil)
+	svc, _ := newPriceService(prices)
+
+	history, err := svc.ListPriceHistory("SMART-XYZ-001")
+
+	require.NoError(t, err)
+	assert.Equal(t, "price-2", history.CurrentRecordID)
+	assert.Equal(t, money.New(179990, "BRL"), history.CurrentPrice)
+	assert.Len(t, history.Records, 3)
+}
+
+func TestApplyScheduledPrices(t *testing.T) {
+	prices := new(MockPriceRepository)
+	owners := []repository.PriceOwner{{ProductID: "prod-001"}, {ProductID: "prod-011", VariantID: "var-002"}}
+	prices.On("ListDueOwners", priceNow, priceSchedulerBatchSize).Return(owners, nil)
+	prices.On("ApplyDue", owners[0], priceNow).Return(&domain.PriceRecord{Price: 179990}, nil)
+	prices.On("ApplyDue", owners[1], priceNow).Return(&domain.PriceRecord{Price: 4490}, nil)
+	svc, products := newPriceService(prices)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001", Name: "Smartphone XYZ", IsActive: true}, nil)
+	products.On("GetByID", "prod-011").Return(&domain.Product{ID: "prod-011", Name: "Camiseta Básica", IsActive: true}, nil)
+
+	applied, err := svc.ApplyScheduledPrices(priceNow)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.Equal(t, 2, svc.index.Len())
+	prices.AssertExpectations(t)
+}
+
+func TestCancelScheduledPrice_AlreadyActive(t *testing.T) {
+	prices := new(MockPriceRepository)
+	prices.On("CancelScheduled", "price-1", priceNow).Return(repository.ErrPriceStarted)
+	svc, _ := newPriceService(prices)
+
+	err := svc.CancelScheduledPrice("price-1")
+
+	assert.ErrorIs(t, err, ErrPriceAlreadyActive)
+}
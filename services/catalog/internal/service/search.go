@@ -0,0 +1,150 @@
+package service
+
+import (
+	"log"
+	"strings"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// SearchQuery define texto, filtros, ordenação e paginação da busca de produtos.
+// Preços iguais a zero não filtram; a moeda dos preços informados define a moeda do
+// filtro e das facetas de preço (sem preços, a moeda padrão).
+type SearchQuery struct {
+	Text            string
+	CategoryID      string
+	MinPrice        money.Money
+	MaxPrice        money.Money
+	Sort            string
+	IncludeInactive bool
+	Page            int
+	PageSize        int
+}
+
+// SearchHit é um produto encontrado e sua pontuação de relevância
+type SearchHit struct {
+	Product domain.Product
+	Score   float64
+}
+
+// SearchPage é uma página de resultados da busca com as facetas do resultado completo
+type SearchPage struct {
+	Hits           []SearchHit
+	Total          int
+	Page           int
+	PageSize       int
+	TotalPages     int
+	CategoryFacets []search.FacetCount
+	PriceFacets    []search.PriceFacet
+	// PriceCurrency é a moeda das facetas de preço
+	PriceCurrency string
+}
+
+// SearchProducts busca produtos no índice invertido e carrega a página do banco,
+// para que estoque e demais campos estejam sempre atualizados
+func (s *catalogService) SearchProducts(query SearchQuery) (*SearchPage, error) {
+	query.Sort = strings.ToLower(strings.TrimSpace(query.Sort))
+	switch query.Sort {
+	case "":
+		query.Sort = search.SortRelevance
+	case search.SortRelevance, search.SortPriceAsc, search.SortPriceDesc, search.SortName:
+	default:
+		return nil, invalid("ordenação inválida: use relevance, price_asc, price_desc ou name")
+	}
+	currency, err := searchCurrency(query.MinPrice, query.MaxPrice)
+	if err != nil {
+		return nil, err
+	}
+	if query.MinPrice.Amount < 0 || query.MaxPrice.Amount < 0 {
+		return nil, invalid("faixa de preço não pode ser negativa")
+	}
+	if query.MaxPrice.IsPositive() && query.MinPrice.Amount > query.MaxPrice.Amount {
+		return nil, invalid("preço mínimo maior que o máximo")
+	}
+
+	page, pageSize := normalizePage(query.Page, query.PageSize)
+
+	metrics.RecordProductQuery("search")
+	result := s.index.Search(search.Query{
+		Text:            query.Text,
+		CategoryID:      query.CategoryID,
+		Currency:        currency,
+		MinPrice:        query.MinPrice.Amount,
+		MaxPrice:        query.MaxPrice.Amount,
+		IncludeInactive: query.IncludeInactive,
+		Sort:            query.Sort,
+		Offset:          (page - 1) * pageSize,
+		Limit:           pageSize,
+	})
+
+	ids := make([]string, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.ProductID
+	}
+	products, err := s.productRepo.GetByIDs(ids)
+	if err != nil {
+		log.Printf("Erro ao carregar produtos da busca: %v", err)
+		return nil, errCatalogUnavailable
+	}
+	byID := make(map[string]domain.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	response := &SearchPage{
+		Total:          result.Total,
+		Page:           page,
+		PageSize:       pageSize,
+		TotalPages:     (result.Total + pageSize - 1) / pageSize,
+		CategoryFacets: result.CategoryFacets,
+		PriceFacets:    result.PriceFacets,
+		PriceCurrency:  result.Currency,
+	}
+	for _, hit := range result.Hits {
+		// Produtos removidos entre a busca e a leitura são ignorados
+		if product, ok := byID[hit.ProductID]; ok {
+			response.Hits = append(response.Hits, SearchHit{Product: product, Score: hit.Score})
+		}
+	}
+	return response, nil
+}
+
+// ReindexProducts reconstrói o índice de busca a partir do banco e retorna
+// a quantidade de produtos indexados
+func (s *catalogService) ReindexProducts() (int, error) {
+	products, err := s.productRepo.ListAll()
+	if err != nil {
+		log.Printf("Erro ao carregar produtos para indexação: %v", err)
+		return 0, errCatalogUnavailable
+	}
+
+	s.index.Rebuild(products)
+	log.Printf("🔎 Índice de busca reconstruído com %d produtos", len(products))
+	return len(products), nil
+}
+
+// searchCurrency retorna a moeda da faixa de preço; os dois limites informados
+// precisam estar na mesma moeda
+func searchCurrency(minPrice, maxPrice money.Money) (string, error) {
+	currency := ""
+	for _, price := range []money.Money{minPrice, maxPrice} {
+		if price.IsZero() && strings.TrimSpace(price.Currency) == "" {
+			continue
+		}
+		normalized := money.NormalizeCurrency(price.Currency)
+		if !money.ValidCurrency(normalized) {
+			return "", invalid("moeda não suportada: " + normalized)
+		}
+		if currency != "" && normalized != currency {
+			return "", invalid("preço mínimo e máximo devem estar na mesma moeda")
+		}
+		currency = normalized
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	return currency, nil
+}
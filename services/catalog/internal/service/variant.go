@@ -0,0 +1,288 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"gorm.io/gorm"
+)
+
+// Limites dos atributos de uma variação
+const (
+	maxVariantAttributes = 5
+	maxAttributeLength   = 50
+)
+
+var (
+	ErrVariantNotFound  = errors.New("variação não encontrada")
+	ErrDuplicateVariant = errors.New("o produto já possui uma variação com estes atributos")
+)
+
+// VariantInput contém os campos editáveis de uma variação. O preço fica na moeda do
+// produto; moeda vazia assume a do produto.
+type VariantInput struct {
+	ProductID     string
+	SKU           string
+	Barcode       string
+	Attributes    map[string]string
+	Price         money.Money
+	StockQuantity int
+	IsActive      bool
+}
+
+// CreateVariant cria uma variação para o produto; a partir dela o produto passa a ser
+// vendido e reservado por variação
+func (s *catalogService) CreateVariant(input VariantInput) (*domain.Variant, error) {
+	product, err := s.GetProduct(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateVariant("", product.Currency, &input); err != nil {
+		return nil, err
+	}
+
+	variant := &domain.Variant{}
+	applyVariantInput(variant, input)
+	if err := s.variantRepo.Create(variant); err != nil {
+		log.Printf("Erro ao criar variação: %v", err)
+		metrics.RecordCatalogUpdate("create_variant", "error")
+		return nil, errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("create_variant", "success")
+	s.reindexProduct(variant.ProductID)
+	log.Printf("✅ Variação criada: ID=%s, Produto=%s, SKU=%s, Atributos=%s",
+		variant.ID, variant.ProductID, variant.SKU, variant.Attributes.Key())
+	return variant, nil
+}
+
+// UpdateVariant substitui os campos editáveis de uma variação. O produto da variação não muda.
+func (s *catalogService) UpdateVariant(id string, input VariantInput) (*domain.Variant, error) {
+	variant, err := s.GetVariant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	input.ProductID = variant.ProductID
+	if err := s.validateVariant(id, variant.Currency, &input); err != nil {
+		return nil, err
+	}
+
+	applyVariantInput(variant, input)
+	if err := s.variantRepo.Update(variant); err != nil {
+		log.Printf("Erro ao atualizar variação %s: %v", id, err)
+		metrics.RecordCatalogUpdate("update_variant", "error")
+		return nil, errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("update_variant", "success")
+	s.reindexProduct(variant.ProductID)
+	return variant, nil
+}
+
+// DeleteVariant remove uma variação do produto
+func (s *catalogService) DeleteVariant(id string) error {
+	variant, err := s.GetVariant(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.variantRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVariantNotFound
+		}
+		log.Printf("Erro ao remover variação %s: %v", id, err)
+		metrics.RecordCatalogUpdate("delete_variant", "error")
+		return errCatalogUnavailable
+	}
+
+	metrics.RecordCatalogUpdate("delete_variant", "success")
+	s.reindexProduct(variant.ProductID)
+	log.Printf("🗑️ Variação removida: ID=%s, SKU=%s", id, variant.SKU)
+	return nil
+}
+
+// GetVariant retorna uma variação pelo ID
+func (s *catalogService) GetVariant(id string) (*domain.Variant, error) {
+	if id == "" {
+		return nil, invalid("ID da variação é obrigatório")
+	}
+
+	variant, err := s.variantRepo.GetByID(id)
+	return variantResult(variant, err)
+}
+
+// GetVariantBySKU retorna uma variação pelo código SKU
+func (s *catalogService) GetVariantBySKU(sku string) (*domain.Variant, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, invalid("SKU é obrigatório")
+	}
+
+	variant, err := s.variantRepo.GetBySKU(sku)
+	return variantResult(variant, err)
+}
+
+// ListVariants retorna as variações de um produto. Variações inativas só aparecem
+// quando includeInactive é verdadeiro.
+func (s *catalogService) ListVariants(productID string, includeInactive bool) ([]domain.Variant, error) {
+	if _, err := s.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	variants, err := s.variantRepo.ListByProduct(productID)
+	if err != nil {
+		log.Printf("Erro ao listar variações do produto %s: %v", productID, err)
+		return nil, errCatalogUnavailable
+	}
+	if includeInactive {
+		return variants, nil
+	}
+
+	active := variants[:0]
+	for _, variant := range variants {
+		if variant.IsActive {
+			active = append(active, variant)
+		}
+	}
+	return active, nil
+}
+
+// validateVariant normaliza e valida os campos da variação.
+// variantID é a variação em edição, ignorada nas checagens de duplicidade; currency é
+// a moeda do produto.
+func (s *catalogService) validateVariant(variantID, currency string, input *VariantInput) error {
+	input.SKU = strings.TrimSpace(input.SKU)
+	input.Barcode = strings.TrimSpace(input.Barcode)
+
+	if input.SKU == "" {
+		return invalid("SKU é obrigatório")
+	}
+	if err := inProductCurrency(&input.Price, currency); err != nil {
+		return err
+	}
+	if input.StockQuantity < 0 {
+		return invalid("estoque não pode ser negativo")
+	}
+	if input.Barcode != "" && !isDigits(input.Barcode) {
+		return invalid("código de barras deve conter apenas dígitos")
+	}
+
+	attributes, err := normalizeAttributes(input.Attributes)
+	if err != nil {
+		return err
+	}
+	input.Attributes = attributes
+
+	if err := s.checkSKU(input.SKU, variantID); err != nil {
+		return err
+	}
+
+	existing, err := s.variantRepo.GetByAttributes(input.ProductID, attributes)
+	switch {
+	case err == nil && existing.ID != variantID:
+		return ErrDuplicateVariant
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("Erro ao verificar atributos da variação: %v", err)
+		return errCatalogUnavailable
+	}
+	return nil
+}
+
+// checkSKU garante que o SKU não está em uso por outro produto ou variação;
+// ownerID é o produto ou a variação em edição
+func (s *catalogService) checkSKU(sku, ownerID string) error {
+	product, err := s.productRepo.GetBySKU(sku)
+	switch {
+	case err == nil && product.ID != ownerID:
+		return ErrDuplicateSKU
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("Erro ao verificar SKU %s: %v", sku, err)
+		return errCatalogUnavailable
+	}
+
+	variant, err := s.variantRepo.GetBySKU(sku)
+	switch {
+	case err == nil && variant.ID != ownerID:
+		return ErrDuplicateSKU
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("Erro ao verificar SKU %s: %v", sku, err)
+		return errCatalogUnavailable
+	}
+	return nil
+}
+
+// reindexProduct recarrega o produto com suas variações e atualiza o índice de busca
+func (s *catalogService) reindexProduct(productID string) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		log.Printf("⚠️ Erro ao reindexar produto %s: %v", productID, err)
+		return
+	}
+	s.index.Upsert(product)
+}
+
+// normalizeAttributes padroniza nomes (minúsculas) e valores (sem espaços nas pontas)
+func normalizeAttributes(attributes map[string]string) (domain.Attributes, error) {
+	if len(attributes) == 0 {
+		return nil, invalid("a variação precisa de ao menos um atributo")
+	}
+	if len(attributes) > maxVariantAttributes {
+		return nil, invalid("a variação aceita no máximo 5 atributos")
+	}
+
+	normalized := make(domain.Attributes, len(attributes))
+	for name, value := range attributes {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if name == "" || value == "" {
+			return nil, invalid("nome e valor do atributo são obrigatórios")
+		}
+		if len(name) > maxAttributeLength || len(value) > maxAttributeLength {
+			return nil, invalid("nome e valor do atributo aceitam no máximo 50 caracteres")
+		}
+		if strings.ContainsAny(name+value, "=;") {
+			return nil, invalid("atributos não podem conter '=' ou ';'")
+		}
+		if _, ok := normalized[name]; ok {
+			return nil, invalid("atributo repetido: " + name)
+		}
+		normalized[name] = value
+	}
+	return normalized, nil
+}
+
+func applyVariantInput(variant *domain.Variant, input VariantInput) {
+	variant.ProductID = input.ProductID
+	variant.SKU = input.SKU
+	variant.Barcode = input.Barcode
+	variant.Attributes = input.Attributes
+	variant.Currency = input.Price.Currency
+	variant.Price = input.Price.Amount
+	variant.StockQuantity = input.StockQuantity
+	variant.IsActive = input.IsActive
+}
+
+func variantResult(variant *domain.Variant, err error) (*domain.Variant, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVariantNotFound
+		}
+		log.Printf("Erro ao buscar variação: %v", err)
+		return nil, errCatalogUnavailable
+	}
+	return variant, nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/search"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+func validVariantInput() VariantInput {
+	return VariantInput{
+		ProductID:     "prod-001",
+		SKU:           " CAM-AZ-M ",
+		Barcode:       "7891234567895",
+		Attributes:    map[string]string{" Cor ": "Azul", "TAMANHO": " M "},
+		Price:         money.New(4990, "BRL"),
+		StockQuantity: 5,
+		IsActive:      true,
+	}
+}
+
+func TestCreateVariant_NormalizesAttributesAndReindexes(t *testing.T) {
+	products := new(MockProductRepository)
+	variants := new(MockVariantRepository)
+	index := search.NewIndex()
+	product := &domain.Product{ID: "prod-001", Name: "Camiseta", SKU: "CAM", Price: 3990, IsActive: true}
+	products.On("GetByID", "prod-001").Return(product, nil)
+	products.On("GetBySKU", "CAM-AZ-M").Return(nil, gorm.ErrRecordNotFound)
+	variants.On("GetBySKU", "CAM-AZ-M").Return(nil, gorm.ErrRecordNotFound)
+	variants.On("GetByAttributes", "prod-001", domain.Attributes{"cor": "Azul", "tamanho": "M"}).Return(nil, gorm.ErrRecordNotFound)
+	variants.On("Create", mock.AnythingOfType("*domain.Variant")).Run(func(args mock.Arguments) {
+		product.Variants = append(product.Variants, *args.Get(0).(*domain.Variant))
+	}).Return(nil)
+
+	variant, err := NewCatalogService(new(MockCategoryRepository), products, variants, index).CreateVariant(validVariantInput())
+
+	require.NoError(t, err)
+	assert.Equal(t, "CAM-AZ-M", variant.SKU)
+	assert.Equal(t, "cor=Azul;tamanho=M", variant.Attributes.Key())
+	assert.Len(t, index.Search(search.Query{Text: "camiseta azul"}).Hits, 1)
+}
+
+func TestCreateVariant_DuplicateAttributes(t *testing.T) {
+	products := new(MockProductRepository)
+	variants := new(MockVariantRepository)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001"}, nil)
+	products.On("GetBySKU", "CAM-AZ-M").Return(nil, gorm.ErrRecordNotFound)
+	variants.On("GetBySKU", "CAM-AZ-M").Return(nil, gorm.ErrRecordNotFound)
+	variants.On("GetByAttributes", "prod-001", mock.Anything).Return(&domain.Variant{ID: "var-009"}, nil)
+
+	_, err := NewCatalogService(new(MockCategoryRepository), products, variants, search.NewIndex()).CreateVariant(validVariantInput())
+
+	assert.ErrorIs(t, err, ErrDuplicateVariant)
+	variants.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateVariant_SKUUsedByProduct(t *testing.T) {
+	products := new(MockProductRepository)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001"}, nil)
+	products.On("GetBySKU", "CAM-AZ-M").Return(&domain.Product{ID: "prod-002"}, nil)
+
+	_, err := NewCatalogService(new(MockCategoryRepository), products, new(MockVariantRepository), search.NewIndex()).CreateVariant(validVariantInput())
+
+	assert.ErrorIs(t, err, ErrDuplicateSKU)
+}
+
+func TestCreateVariant_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		apply func(*VariantInput)
+	}{
+		{"missing sku", func(in *VariantInput) { in.SKU = "" }},
+		{"zero price", func(in *VariantInput) { in.Price = money.Zero("BRL") }},
+		{"price in another currency", func(in *VariantInput) { in.Price = money.New(4990, "USD") }},
+		{"negative stock", func(in *VariantInput) { in.StockQuantity = -1 }},
+		{"barcode with letters", func(in *VariantInput) { in.Barcode = "78912A" }},
+		{"no attributes", func(in *VariantInput) { in.Attributes = nil }},
+		{"empty attribute value", func(in *VariantInput) { in.Attributes = map[string]string{"cor": " "} }},
+		{"repeated attribute", func(in *VariantInput) { in.Attributes = map[string]string{"cor": "azul", "COR": "verde"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := new(MockProductRepository)
+			products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001"}, nil)
+			input := validVariantInput()
+			tt.apply(&input)
+
+			_, err := NewCatalogService(new(MockCategoryRepository), products, new(MockVariantRepository), search.NewIndex()).CreateVariant(input)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestUpdateVariant_KeepsProduct(t *testing.T) {
+	products := new(MockProductRepository)
+	variants := new(MockVariantRepository)
+	existing := &domain.Variant{ID: "var-001", ProductID: "prod-001", SKU: "CAM-AZ-M"}
+	variants.On("GetByID", "var-001").Return(existing, nil)
+	products.On("GetBySKU", "CAM-AZ-M").Return(nil, gorm.ErrRecordNotFound)
+	variants.On("GetBySKU", "CAM-AZ-M").Return(existing, nil)
+	variants.On("GetByAttributes", "prod-001", mock.Anything).Return(existing, nil)
+	variants.On("Update", existing).Return(nil)
+	products.On("GetByID", "prod-001").Return(&domain.Product{ID: "prod-001"}, nil)
+
+	input := validVariantInput()
+	input.ProductID = "prod-999"
+	input.Price = money.New(5990, "")
+	variant, err := NewCatalogService(new(MockCategoryRepository), products, variants, search.NewIndex()).UpdateVariant("var-001", input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "prod-001", variant.ProductID)
+	assert.Equal(t, int64(5990), variant.Price)
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"io"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/bulk"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// Tamanho das partes enviadas na exportação
+const exportChunkSize = 32 * 1024
+
+// ImportProducts recebe o arquivo em partes e devolve o relatório da importação via gRPC
+func (s *CatalogGRPCServer) ImportProducts(stream proto.CatalogService_ImportProductsServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "nenhum dado recebido para importação")
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("📥 Recebida requisição ImportProducts: Format=%s, DryRun=%t, Batch=%d",
+		first.GetFormat(), first.GetDryRun(), first.GetBatchSize())
+
+	source := &importStream{stream: stream, pending: first.GetChunk()}
+	reader, err := bulk.NewReader(first.GetFormat(), source)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	report, err := s.bulkService.ImportProducts(reader, service.ImportOptions{
+		BatchSize: int(first.GetBatchSize()),
+		DryRun:    first.GetDryRun(),
+	})
+	if source.err != nil {
+		return source.err
+	}
+	if err != nil {
+		return catalogError(err)
+	}
+	return stream.SendAndClose(toImportResponse(report))
+}
+
+// ExportProducts envia os produtos e variações em partes no formato pedido via gRPC
+func (s *CatalogGRPCServer) ExportProducts(req *proto.ExportProductsRequest, stream proto.CatalogService_ExportProductsServer) error {
+	log.Printf("📤 Recebida requisição ExportProducts: Format=%s, Category=%s", req.GetFormat(), req.GetCategoryId())
+
+	chunks := &exportStream{stream: stream}
+	writer, err := bulk.NewWriter(req.GetFormat(), chunks)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = s.bulkService.ExportProducts(service.ExportQuery{
+		CategoryID:      req.GetCategoryId(),
+		IncludeInactive: req.GetIncludeInactive(),
+	}, writer.Write)
+	if chunks.err != nil {
+		return chunks.err
+	}
+	if err != nil {
+		return catalogError(err)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return chunks.flush()
+}
+
+// importStream expõe as partes recebidas como um io.Reader
+type importStream struct {
+	stream  proto.CatalogService_ImportProductsServer
+	pending []byte
+	err     error
+}
+
+func (r *importStream) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		req, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.pending = req.GetChunk()
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// exportStream acumula os bytes gravados e os envia em partes de exportChunkSize
+type exportStream struct {
+	stream proto.CatalogService_ExportProductsServer
+	buffer []byte
+	err    error
+}
+
+func (w *exportStream) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buffer = append(w.buffer, p...)
+	for len(w.buffer) >= exportChunkSize {
+		if err := w.send(w.buffer[:exportChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buffer = w.buffer[exportChunkSize:]
+	}
+	return len(p), nil
+}
+
+func (w *exportStream) flush() error {
+	if len(w.buffer) == 0 {
+		return w.err
+	}
+	err := w.send(w.buffer)
+	w.buffer = nil
+	return err
+}
+
+func (w *exportStream) send(data []byte) error {
+	chunk := make([]byte, len(data))
+	copy(chunk, data)
+	if err := w.stream.Send(&proto.ExportProductsChunk{Chunk: chunk}); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+func toImportResponse(report *service.ImportReport) *proto.ImportProductsResponse {
+	response := &proto.ImportProductsResponse{
+		TotalRows:       int32(report.Total),
+		Created:         int32(report.Created),
+		Updated:         int32(report.Updated),
+		Failed:          int32(report.Failed),
+		DryRun:          report.DryRun,
+		ErrorsTruncated: report.ErrorsTruncated,
+	}
+	for _, rowErr := range report.Errors {
+		response.Errors = append(response.Errors, &proto.ImportRowError{
+			Line:    int32(rowErr.Line),
+			Sku:     rowErr.SKU,
+			Message: rowErr.Message,
+		})
+	}
+	return response
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// ReserveStock separa estoque para um pedido via gRPC
+func (s *CatalogGRPCServer) ReserveStock(ctx context.Context, req *proto.ReserveStockRequest) (*proto.ReservationResponse, error) {
+	log.Printf("📦 Recebida requisição ReserveStock: Product=%s, SKU=%s, Quantity=%d, Reference=%s",
+		req.GetProductId(), req.GetSku(), req.GetQuantity(), req.GetReference())
+
+	reservation, err := s.inventoryService.Reserve(service.ReserveInput{
+		ProductID: req.GetProductId(),
+		SKU:       req.GetSku(),
+		Quantity:  int(req.GetQuantity()),
+		Reference: req.GetReference(),
+		TTL:       time.Duration(req.GetTtlSeconds()) * time.Second,
+	})
+	if err != nil {
+		log.Printf("❌ Erro ao reservar estoque: %v", err)
+		return nil, inventoryError(err)
+	}
+	return toReservationResponse(reservation), nil
+}
+
+// CommitReservation confirma uma reserva via gRPC
+func (s *CatalogGRPCServer) CommitReservation(ctx context.Context, req *proto.ReservationRequest) (*proto.ReservationResponse, error) {
+	reservation, err := s.inventoryService.Commit(req.GetId())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return toReservationResponse(reservation), nil
+}
+
+// ReleaseReservation devolve ao estoque uma reserva via gRPC
+func (s *CatalogGRPCServer) ReleaseReservation(ctx context.Context, req *proto.ReservationRequest) (*proto.ReservationResponse, error) {
+	reservation, err := s.inventoryService.Release(req.GetId())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return toReservationResponse(reservation), nil
+}
+
+func toReservationResponse(reservation *domain.Reservation) *proto.ReservationResponse {
+	response := &proto.ReservationResponse{
+		Id:        reservation.ID,
+		ProductId: reservation.ProductID,
+		Sku:       reservation.SKU,
+		Quantity:  int32(reservation.Quantity),
+		Reference: reservation.Reference,
+		Status:    reservation.Status,
+		ExpiresAt: reservation.ExpiresAt.Format(time.RFC3339),
+		VariantId: reservation.VariantID,
+		UnitPrice: toProtoMoney(reservation.UnitPrice()),
+	}
+	if reservation.Product != nil {
+		response.ProductName = reservation.Product.Name
+		response.TaxClass = reservation.Product.TaxClass
+	}
+	if reservation.Variant != nil {
+		response.Attributes = toVariantAttributes(reservation.Variant.Attributes)
+	}
+	return response
+}
+
+// inventoryError traduz erros de reserva de estoque para códigos gRPC
+func inventoryError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInsufficientStock), errors.Is(err, service.ErrProductInactive),
+		errors.Is(err, service.ErrVariantRequired),
+		errors.Is(err, service.ErrReservationExpired), errors.Is(err, service.ErrReservationClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return catalogError(err)
+	}
+}
@@ -0,0 +1,18 @@
+package grpc
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// fromProtoMoney converte um preço recebido via gRPC. A moeda vazia é mantida: o
+// serviço a trata como a moeda padrão em produtos novos e como a moeda do produto
+// nos demais preços.
+func fromProtoMoney(value *proto.Money) money.Money {
+	return money.Money{Amount: value.GetAmount(), Currency: value.GetCurrency()}
+}
+
+// toProtoMoney converte um valor para a resposta gRPC
+func toProtoMoney(value money.Money) *proto.Money {
+	return &proto.Money{Amount: value.Amount, Currency: value.Currency}
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import "github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+
+// MethodPermissions declara a permissão exigida por cada RPC do CatalogService
+var MethodPermissions = auth.Policy{
+	"/catalog.CatalogService/GetCategory":      {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/ListCategories":   {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/GetProduct":       {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/ListProducts":     {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/SearchProducts":   {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/GetVariant":       {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/ListVariants":     {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/ExportProducts":   {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/ListPriceHistory": {Permission: auth.PermCatalogRead},
+	"/catalog.CatalogService/GetPriceAt":       {Permission: auth.PermCatalogRead},
+
+	"/catalog.CatalogService/CreateCategory":       {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/UpdateCategory":       {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/DeleteCategory":       {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/CreateProduct":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/UpdateProduct":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/DeleteProduct":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/SetProductActive":     {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/UpdateProductPrice":   {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/CreateVariant":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/UpdateVariant":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/DeleteVariant":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/ImportProducts":       {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/SchedulePrice":        {Permission: auth.PermCatalogWrite},
+	"/catalog.CatalogService/CancelScheduledPrice": {Permission: auth.PermCatalogWrite},
+
+	"/catalog.CatalogService/ReserveStock":       {Permission: auth.PermInventoryWrite},
+	"/catalog.CatalogService/CommitReservation":  {Permission: auth.PermInventoryWrite},
+	"/catalog.CatalogService/ReleaseReservation": {Permission: auth.PermInventoryWrite},
+}
@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// SchedulePrice agenda uma versão de preço para um SKU via gRPC
+func (s *CatalogGRPCServer) SchedulePrice(ctx context.Context, req *proto.SchedulePriceRequest) (*proto.PriceRecordResponse, error) {
+	log.Printf("🏷️ Recebida requisição SchedulePrice: SKU=%s, Preço=%d %s, Início=%s, Fim=%s",
+		req.GetSku(), req.GetPrice().GetAmount(), req.GetPrice().GetCurrency(), req.GetValidFrom(), req.GetValidTo())
+
+	validFrom, err := parseTime("valid_from", req.GetValidFrom())
+	if err != nil {
+		return nil, err
+	}
+	validTo, err := parseTime("valid_to", req.GetValidTo())
+	if err != nil {
+		return nil, err
+	}
+
+	input := service.ScheduleInput{
+		SKU:       req.GetSku(),
+		Price:     fromProtoMoney(req.GetPrice()),
+		ValidFrom: validFrom,
+		Reason:    req.GetReason(),
+	}
+	if !validTo.IsZero() {
+		input.ValidTo = &validTo
+	}
+
+	record, err := s.priceService.SchedulePrice(input)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toPriceRecordResponse(record, time.Now()), nil
+}
+
+// CancelScheduledPrice remove uma versão de preço que ainda não começou via gRPC
+func (s *CatalogGRPCServer) CancelScheduledPrice(ctx context.Context, req *proto.CancelPriceRequest) (*proto.DeleteResponse, error) {
+	if err := s.priceService.CancelScheduledPrice(req.GetId()); err != nil {
+		return nil, catalogError(err)
+	}
+	return &proto.DeleteResponse{Success: true}, nil
+}
+
+// ListPriceHistory lista o histórico de preços de um SKU via gRPC
+func (s *CatalogGRPCServer) ListPriceHistory(ctx context.Context, req *proto.ListPriceHistoryRequest) (*proto.PriceHistoryResponse, error) {
+	history, err := s.priceService.ListPriceHistory(req.GetSku())
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	now := time.Now()
+	response := &proto.PriceHistoryResponse{
+		Sku:             history.SKU,
+		ProductId:       history.ProductID,
+		VariantId:       history.VariantID,
+		CurrentPrice:    toProtoMoney(history.CurrentPrice),
+		CurrentRecordId: history.CurrentRecordID,
+	}
+	for i := range history.Records {
+		response.Records = append(response.Records, toPriceRecordResponse(&history.Records[i], now))
+	}
+	return response, nil
+}
+
+// GetPriceAt retorna o preço de um SKU vigente em um instante via gRPC
+func (s *CatalogGRPCServer) GetPriceAt(ctx context.Context, req *proto.GetPriceAtRequest) (*proto.PriceAtResponse, error) {
+	at, err := parseTime("at", req.GetAt())
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := s.priceService.GetPriceAt(req.GetSku(), at)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	response := &proto.PriceAtResponse{
+		Sku:       price.SKU,
+		ProductId: price.ProductID,
+		VariantId: price.VariantID,
+		Price:     toProtoMoney(price.Price),
+		At:        price.At.Format(time.RFC3339),
+	}
+	if price.Record != nil {
+		response.Record = toPriceRecordResponse(price.Record, time.Now())
+	}
+	return response, nil
+}
+
+func toPriceRecordResponse(record *domain.PriceRecord, now time.Time) *proto.PriceRecordResponse {
+	response := &proto.PriceRecordResponse{
+		Id:        record.ID,
+		ProductId: record.ProductID,
+		VariantId: record.VariantID,
+		Price:     toProtoMoney(record.PriceMoney()),
+		ValidFrom: record.ValidFrom.Format(time.RFC3339),
+		Reason:    record.Reason,
+		Status:    service.PriceStatus(record, now),
+		CreatedAt: record.CreatedAt.Format(time.RFC3339),
+	}
+	if record.ValidTo != nil {
+		response.ValidTo = record.ValidTo.Format(time.RFC3339)
+	}
+	return response
+}
+
+// parseTime lê uma data RFC 3339 opcional; vazio retorna o instante zero
+func parseTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s deve estar no formato RFC 3339 (ex.: 2025-11-28T00:00:00-03:00)", field)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"log"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// SearchProducts executa a busca textual de produtos com facetas via gRPC
+func (s *CatalogGRPCServer) SearchProducts(ctx context.Context, req *proto.SearchProductsRequest) (*proto.SearchProductsResponse, error) {
+	log.Printf("🔎 Recebida requisição SearchProducts: Query=%q, Category=%s, Sort=%s", req.GetQuery(), req.GetCategoryId(), req.GetSort())
+
+	page, err := s.catalogService.SearchProducts(service.SearchQuery{
+		Text:            req.GetQuery(),
+		CategoryID:      req.GetCategoryId(),
+		MinPrice:        fromProtoMoney(req.GetMinPrice()),
+		MaxPrice:        fromProtoMoney(req.GetMaxPrice()),
+		Sort:            req.GetSort(),
+		IncludeInactive: req.GetIncludeInactive(),
+		Page:            int(req.GetPage()),
+		PageSize:        int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	response := &proto.SearchProductsResponse{
+		Total:      int64(page.Total),
+		Page:       int32(page.Page),
+		PageSize:   int32(page.PageSize),
+		TotalPages: int32(page.TotalPages),
+	}
+	for i := range page.Hits {
+		response.Hits = append(response.Hits, &proto.SearchHit{
+			Product: toProductResponse(&page.Hits[i].Product),
+			Score:   page.Hits[i].Score,
+		})
+	}
+	for _, facet := range page.CategoryFacets {
+		response.CategoryFacets = append(response.CategoryFacets, &proto.FacetCount{
+			Value: facet.Value,
+			Count: int32(facet.Count),
+		})
+	}
+	for _, facet := range page.PriceFacets {
+		lower, upper := facet.Bucket.Bounds(page.PriceCurrency)
+		response.PriceFacets = append(response.PriceFacets, &proto.PriceBucketFacet{
+			Label: facet.Bucket.Label,
+			Min:   toProtoMoney(lower),
+			Max:   toProtoMoney(upper),
+			Count: int32(facet.Count),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,248 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// CatalogGRPCServer implementa o servidor gRPC do catálogo
+type CatalogGRPCServer struct {
+	proto.UnimplementedCatalogServiceServer
+	catalogService   service.CatalogService
+	inventoryService service.InventoryService
+	bulkService      service.BulkService
+	priceService     service.PriceService
+}
+
+// NewCatalogGRPCServer cria uma nova instância do servidor gRPC
+func NewCatalogGRPCServer(catalogService service.CatalogService, inventoryService service.InventoryService, bulkService service.BulkService, priceService service.PriceService) *CatalogGRPCServer {
+	return &CatalogGRPCServer{
+		catalogService:   catalogService,
+		inventoryService: inventoryService,
+		bulkService:      bulkService,
+		priceService:     priceService,
+	}
+}
+
+// CreateCategory cria uma nova categoria via gRPC
+func (s *CatalogGRPCServer) CreateCategory(ctx context.Context, req *proto.CategoryRequest) (*proto.CategoryResponse, error) {
+	log.Printf("📝 Recebida requisição CreateCategory: Name=%s", req.GetName())
+
+	category, err := s.catalogService.CreateCategory(service.CategoryInput{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toCategoryResponse(category), nil
+}
+
+// UpdateCategory altera uma categoria via gRPC
+func (s *CatalogGRPCServer) UpdateCategory(ctx context.Context, req *proto.UpdateCategoryRequest) (*proto.CategoryResponse, error) {
+	category, err := s.catalogService.UpdateCategory(req.GetId(), service.CategoryInput{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toCategoryResponse(category), nil
+}
+
+// DeleteCategory remove uma categoria sem produtos via gRPC
+func (s *CatalogGRPCServer) DeleteCategory(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	if err := s.catalogService.DeleteCategory(req.GetId()); err != nil {
+		return nil, catalogError(err)
+	}
+	return &proto.DeleteResponse{Success: true}, nil
+}
+
+// GetCategory busca uma categoria pelo ID via gRPC
+func (s *CatalogGRPCServer) GetCategory(ctx context.Context, req *proto.GetCategoryRequest) (*proto.CategoryResponse, error) {
+	category, err := s.catalogService.GetCategory(req.GetId())
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toCategoryResponse(category), nil
+}
+
+// ListCategories lista todas as categorias via gRPC
+func (s *CatalogGRPCServer) ListCategories(ctx context.Context, req *proto.ListCategoriesRequest) (*proto.ListCategoriesResponse, error) {
+	categories, err := s.catalogService.ListCategories()
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	response := &proto.ListCategoriesResponse{}
+	for i := range categories {
+		response.Categories = append(response.Categories, toCategoryResponse(&categories[i]))
+	}
+	return response, nil
+}
+
+// CreateProduct cria um novo produto via gRPC
+func (s *CatalogGRPCServer) CreateProduct(ctx context.Context, req *proto.ProductRequest) (*proto.ProductResponse, error) {
+	log.Printf("📝 Recebida requisição CreateProduct: SKU=%s, Name=%s, Price=%d %s",
+		req.GetSku(), req.GetName(), req.GetPrice().GetAmount(), req.GetPrice().GetCurrency())
+
+	product, err := s.catalogService.CreateProduct(toProductInput(req))
+	if err != nil {
+		log.Printf("❌ Erro ao criar produto: %v", err)
+		return nil, catalogError(err)
+	}
+	return toProductResponse(product), nil
+}
+
+// UpdateProduct substitui os dados de um produto via gRPC
+func (s *CatalogGRPCServer) UpdateProduct(ctx context.Context, req *proto.UpdateProductRequest) (*proto.ProductResponse, error) {
+	if req.GetProduct() == nil {
+		return nil, status.Error(codes.InvalidArgument, "dados do produto são obrigatórios")
+	}
+
+	product, err := s.catalogService.UpdateProduct(req.GetId(), toProductInput(req.GetProduct()))
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toProductResponse(product), nil
+}
+
+// DeleteProduct remove um produto via gRPC
+func (s *CatalogGRPCServer) DeleteProduct(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	if err := s.catalogService.DeleteProduct(req.GetId()); err != nil {
+		return nil, catalogError(err)
+	}
+	return &proto.DeleteResponse{Success: true}, nil
+}
+
+// GetProduct busca um produto pelo ID ou SKU via gRPC
+func (s *CatalogGRPCServer) GetProduct(ctx context.Context, req *proto.GetProductRequest) (*proto.ProductResponse, error) {
+	var product *domain.Product
+	var err error
+	if req.GetId() == "" && req.GetSku() != "" {
+		product, err = s.catalogService.GetProductBySKU(req.GetSku())
+	} else {
+		product, err = s.catalogService.GetProduct(req.GetId())
+	}
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toProductResponse(product), nil
+}
+
+// ListProducts lista produtos paginados, opcionalmente por categoria, via gRPC
+func (s *CatalogGRPCServer) ListProducts(ctx context.Context, req *proto.ListProductsRequest) (*proto.ListProductsResponse, error) {
+	log.Printf("📋 Recebida requisição ListProducts: Category=%s, Page=%d, PageSize=%d",
+		req.GetCategoryId(), req.GetPage(), req.GetPageSize())
+
+	page, err := s.catalogService.ListProducts(service.ProductQuery{
+		CategoryID:      req.GetCategoryId(),
+		IncludeInactive: req.GetIncludeInactive(),
+		Page:            int(req.GetPage()),
+		PageSize:        int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	response := &proto.ListProductsResponse{
+		Total:      page.Total,
+		Page:       int32(page.Page),
+		PageSize:   int32(page.PageSize),
+		TotalPages: int32(page.TotalPages),
+	}
+	for i := range page.Products {
+		response.Products = append(response.Products, toProductResponse(&page.Products[i]))
+	}
+	return response, nil
+}
+
+// SetProductActive ativa ou desativa um produto via gRPC
+func (s *CatalogGRPCServer) SetProductActive(ctx context.Context, req *proto.SetProductActiveRequest) (*proto.ProductResponse, error) {
+	product, err := s.catalogService.SetProductActive(req.GetId(), req.GetActive())
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toProductResponse(product), nil
+}
+
+// UpdateProductPrice altera o preço de um produto via gRPC
+func (s *CatalogGRPCServer) UpdateProductPrice(ctx context.Context, req *proto.UpdateProductPriceRequest) (*proto.ProductResponse, error) {
+	product, err := s.catalogService.UpdateProductPrice(req.GetId(), fromProtoMoney(req.GetPrice()))
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toProductResponse(product), nil
+}
+
+func toCategoryResponse(category *domain.Category) *proto.CategoryResponse {
+	return &proto.CategoryResponse{
+		Id:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   category.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toProductInput(req *proto.ProductRequest) service.ProductInput {
+	return service.ProductInput{
+		CategoryID:    req.GetCategoryId(),
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		Price:         fromProtoMoney(req.GetPrice()),
+		StockQuantity: int(req.GetStockQuantity()),
+		ImageURL:      req.GetImageUrl(),
+		SKU:           req.GetSku(),
+		TaxClass:      req.GetTaxClass(),
+		IsActive:      req.GetIsActive(),
+	}
+}
+
+func toProductResponse(product *domain.Product) *proto.ProductResponse {
+	response := &proto.ProductResponse{
+		Id:            product.ID,
+		CategoryId:    product.CategoryID,
+		Name:          product.Name,
+		Description:   product.Description,
+		Price:         toProtoMoney(product.PriceMoney()),
+		StockQuantity: int32(product.StockQuantity),
+		ImageUrl:      product.ImageURL,
+		Sku:           product.SKU,
+		TaxClass:      product.TaxClass,
+		IsActive:      product.IsActive,
+		CreatedAt:     product.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     product.UpdatedAt.Format(time.RFC3339),
+	}
+	for i := range product.Variants {
+		response.Variants = append(response.Variants, toVariantResponse(&product.Variants[i]))
+	}
+	return response
+}
+
+// catalogError traduz erros do serviço de catálogo para códigos gRPC
+func catalogError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrProductNotFound),
+		errors.Is(err, service.ErrVariantNotFound), errors.Is(err, service.ErrPriceNotFound),
+		errors.Is(err, service.ErrPriceRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrDuplicateSKU), errors.Is(err, service.ErrDuplicateVariant):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrCategoryNotEmpty), errors.Is(err, service.ErrPriceAlreadyActive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/catalog/proto"
+)
+
+// CreateVariant cria uma variação de produto via gRPC
+func (s *CatalogGRPCServer) CreateVariant(ctx context.Context, req *proto.VariantRequest) (*proto.VariantResponse, error) {
+	log.Printf("📝 Recebida requisição CreateVariant: Product=%s, SKU=%s", req.GetProductId(), req.GetSku())
+
+	variant, err := s.catalogService.CreateVariant(toVariantInput(req))
+	if err != nil {
+		log.Printf("❌ Erro ao criar variação: %v", err)
+		return nil, catalogError(err)
+	}
+	return toVariantResponse(variant), nil
+}
+
+// UpdateVariant substitui os campos editáveis de uma variação via gRPC
+func (s *CatalogGRPCServer) UpdateVariant(ctx context.Context, req *proto.UpdateVariantRequest) (*proto.VariantResponse, error) {
+	variant, err := s.catalogService.UpdateVariant(req.GetId(), toVariantInput(req.GetVariant()))
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toVariantResponse(variant), nil
+}
+
+// DeleteVariant remove uma variação via gRPC
+func (s *CatalogGRPCServer) DeleteVariant(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	if err := s.catalogService.DeleteVariant(req.GetId()); err != nil {
+		return nil, catalogError(err)
+	}
+	return &proto.DeleteResponse{Success: true}, nil
+}
+
+// GetVariant busca uma variação pelo ID ou SKU via gRPC
+func (s *CatalogGRPCServer) GetVariant(ctx context.Context, req *proto.GetVariantRequest) (*proto.VariantResponse, error) {
+	var variant *domain.Variant
+	var err error
+	if req.GetId() == "" && req.GetSku() != "" {
+		variant, err = s.catalogService.GetVariantBySKU(req.GetSku())
+	} else {
+		variant, err = s.catalogService.GetVariant(req.GetId())
+	}
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toVariantResponse(variant), nil
+}
+
+// ListVariants lista as variações de um produto via gRPC
+func (s *CatalogGRPCServer) ListVariants(ctx context.Context, req *proto.ListVariantsRequest) (*proto.ListVariantsResponse, error) {
+	variants, err := s.catalogService.ListVariants(req.GetProductId(), req.GetIncludeInactive())
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	response := &proto.ListVariantsResponse{}
+	for i := range variants {
+		response.Variants = append(response.Variants, toVariantResponse(&variants[i]))
+	}
+	return response, nil
+}
+
+func toVariantInput(req *proto.VariantRequest) service.VariantInput {
+	attributes := make(map[string]string, len(req.GetAttributes()))
+	for _, attribute := range req.GetAttributes() {
+		attributes[attribute.GetName()] = attribute.GetValue()
+	}
+	return service.VariantInput{
+		ProductID:     req.GetProductId(),
+		SKU:           req.GetSku(),
+		Barcode:       req.GetBarcode(),
+		Attributes:    attributes,
+		Price:         fromProtoMoney(req.GetPrice()),
+		StockQuantity: int(req.GetStockQuantity()),
+		IsActive:      req.GetIsActive(),
+	}
+}
+
+func toVariantResponse(variant *domain.Variant) *proto.VariantResponse {
+	return &proto.VariantResponse{
+		Id:            variant.ID,
+		ProductId:     variant.ProductID,
+		Sku:           variant.SKU,
+		Barcode:       variant.Barcode,
+		Attributes:    toVariantAttributes(variant.Attributes),
+		Price:         toProtoMoney(variant.PriceMoney()),
+		StockQuantity: int32(variant.StockQuantity),
+		IsActive:      variant.IsActive,
+		CreatedAt:     variant.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     variant.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toVariantAttributes converte os atributos em lista ordenada por nome
+func toVariantAttributes(attributes domain.Attributes) []*proto.VariantAttribute {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]*proto.VariantAttribute, len(names))
+	for i, name := range names {
+		result[i] = &proto.VariantAttribute{Name: name, Value: attributes[name]}
+	}
+	return result
+}
@@ -5,15 +5,24 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata" // fusos horários das preferências mesmo sem zoneinfo na imagem
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"notification-service/internal/channel"
+	"notification-service/internal/clients"
+	"notification-service/internal/config"
 	"notification-service/internal/database"
 	"notification-service/internal/messaging"
 	"notification-service/internal/repository"
 	"notification-service/internal/service"
+	"notification-service/internal/templates"
 	"notification-service/internal/transport"
+	"notification-service/internal/unsubscribe"
 	pb "notification-service/proto"
 
 	"google.golang.org/grpc"
@@ -22,6 +31,14 @@ import (
 func main() {
 	log.Println("🚀 Iniciando Notification Service...")
 
+	cfg := config.LoadConfig()
+
+	// 🔐 Validador de tokens para autorização das chamadas gRPC
+	tokens, err := auth.NewTokenManager(cfg.JWTSecret, cfg.JWTIssuer)
+	if err != nil {
+		log.Fatalf("❌ Falha ao configurar autenticação: %v", err)
+	}
+
 	// Inicializar conexão com o banco de dados
 	db, err := database.Connect()
 	if err != nil {
@@ -35,14 +52,80 @@ func main() {
 	}
 
 	// Inicializar dependências
-	notificationRepo := repository.NewNotificationRepository(db)
-	notificationService := service.NewNotificationService(notificationRepo)
+	channels, err := channel.New(cfg.ChannelConfig())
+	if err != nil {
+		log.Fatalf("❌ Falha ao configurar canais de notificação: %v", err)
+	}
+	for _, ch := range channels {
+		log.Printf("📣 Canal de notificação habilitado: %s", ch.Name())
+	}
+
+	// 📝 Modelos das mensagens: embutidos, da pasta configurada e versões do banco
+	baseTemplates, err := templates.Defaults()
+	if err != nil {
+		log.Fatalf("❌ Falha ao carregar modelos de notificação: %v", err)
+	}
+	if cfg.TemplatesDir != "" {
+		dirTemplates, err := templates.LoadDir(cfg.TemplatesDir)
+		if err != nil {
+			log.Fatalf("❌ Falha ao carregar modelos de %s: %v", cfg.TemplatesDir, err)
+		}
+		baseTemplates = append(baseTemplates, dirTemplates...)
+		log.Printf("📝 %d modelos de notificação carregados de %s", len(dirTemplates), cfg.TemplatesDir)
+	}
+	templateService, err := service.NewTemplateService(repository.NewTemplateRepository(db), baseTemplates, cfg.Locale, cfg.TemplatesRefresh)
+	if err != nil {
+		log.Fatalf("❌ Falha ao inicializar modelos de notificação: %v", err)
+	}
+
+	// 🤖 Conta de serviço usada para buscar os destinatários no user-service
+	serviceCreds, err := auth.NewServiceCredentials(cfg.UserServiceURL, cfg.ServiceClientID, cfg.ServiceClientSecret)
+	if err != nil {
+		log.Fatalf("❌ Falha ao configurar conta de serviço: %v", err)
+	}
+	defer serviceCreds.Close()
+	userClient, err := clients.NewUserClient(cfg.UserServiceURL, serviceCreds.DialOption())
+	if err != nil {
+		log.Fatalf("❌ Falha ao conectar ao User Service: %v", err)
+	}
+	defer userClient.Close()
+
+	// 🔕 Preferências dos usuários e cancelamento de inscrição em um clique
+	var signer *unsubscribe.Signer
+	if cfg.UnsubscribeSecret != "" {
+		if signer, err = unsubscribe.NewSigner(cfg.UnsubscribeSecret); err != nil {
+			log.Fatalf("❌ Falha ao configurar cancelamento de inscrição: %v", err)
+		}
+	} else {
+		log.Println("⚠️ NOTIFICATION_UNSUBSCRIBE_SECRET não definido: e-mails sem link de cancelamento de inscrição")
+	}
+	preferenceRepo := repository.NewPreferenceRepository(db)
+	preferenceService := service.NewPreferenceService(preferenceRepo, userClient, signer)
 
-	// Inicializar servidor gRPC
-	grpcServer := grpc.NewServer()
-	notificationGRPCServer := transport.NewNotificationGRPCServer(notificationService)
+	notificationRepo := repository.NewNotificationRepository(db)
+	deliveryRepo := repository.NewDeliveryRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, deliveryRepo, service.Delivery{
+		Channels: channels,
+		Attempts: cfg.DeliveryAttempts,
+		Backoff:  cfg.DeliveryBackoff,
+		Timeout:  cfg.DeliveryTimeout,
+	}, service.Recipients{
+		Users:          userClient,
+		Preferences:    preferenceRepo,
+		Signer:         signer,
+		UnsubscribeURL: cfg.UnsubscribeURL,
+	}, templateService, cfg.Locale)
+
+	// Inicializar servidor gRPC com autorização por método
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens, transport.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens, transport.MethodPermissions)),
+	)
+	notificationGRPCServer := transport.NewNotificationGRPCServer(notificationService, templateService, preferenceService)
 	pb.RegisterNotificationServiceServer(grpcServer, notificationGRPCServer)
 
+	unsubscribeServer := newUnsubscribeServer(cfg, signer, preferenceService)
+
 	// Inicializar consumer RabbitMQ
 	consumer, err := messaging.NewPaymentConsumer(notificationService)
 	if err != nil {
@@ -63,6 +146,9 @@ func main() {
 		}
 	}()
 
+	// Entregar as notificações adiadas pelo horário de silêncio
+	go notificationService.RunDeferredDispatcher(ctx, cfg.DeferredInterval)
+
 	// Iniciar servidor gRPC
 	lis, err := net.Listen("tcp", ":50055")
 	if err != nil {
@@ -85,6 +171,9 @@ func main() {
 	log.Println("✅ Notification Service iniciado com sucesso!")
 	log.Println("📋 Serviços disponíveis:")
 	log.Println("   📡 gRPC Server: :50055")
+	if unsubscribeServer != nil {
+		log.Printf("   🔕 Cancelamento de inscrição: :%s%s", cfg.HTTPPort, transport.UnsubscribePath)
+	}
 	log.Println("   🐰 RabbitMQ Consumer: payments queue")
 	log.Println("   🗄️  MySQL Database: notification_service")
 
@@ -94,7 +183,36 @@ func main() {
 
 	// Graceful shutdown
 	cancel() // Cancelar contexto do consumer
+	if unsubscribeServer != nil {
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelShutdown()
+		unsubscribeServer.Shutdown(shutdownCtx)
+	}
 	grpcServer.GracefulStop()
 
 	fmt.Println("✅ Notification service desligado com sucesso.")
 }
+
+// newUnsubscribeServer inicia o servidor HTTP do link de cancelamento de inscrição. Sem
+// NOTIFICATION_UNSUBSCRIBE_SECRET os e-mails não trazem o link e o servidor não é iniciado.
+func newUnsubscribeServer(cfg *config.Config, signer *unsubscribe.Signer, preferenceService service.PreferenceService) *http.Server {
+	if signer == nil {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(transport.UnsubscribePath, transport.NewUnsubscribeHandler(preferenceService))
+	server := &http.Server{
+		Addr:              ":" + cfg.HTTPPort,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Printf("🔕 Cancelamento de inscrição ouvindo em :%s%s", cfg.HTTPPort, transport.UnsubscribePath)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Erro no servidor de cancelamento de inscrição: %v", err)
+		}
+	}()
+	return server
+}
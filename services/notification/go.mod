@@ -6,6 +6,9 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/prometheus/client_golang v1.17.0
 	github.com/rabbitmq/amqp091-go v1.10.0
+	github.com/seu-usuario/go-microservices-architecture/services/shared/auth v0.0.0-00010101000000-000000000000
+	github.com/seu-usuario/go-microservices-architecture/services/shared/money v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.9.0
 	go.opentelemetry.io/otel v1.21.0
 	go.opentelemetry.io/otel/exporters/jaeger v1.17.0
 	go.opentelemetry.io/otel/sdk v1.21.0
@@ -18,6 +21,7 @@ require (
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/go-sql-driver/mysql v1.8.1 // indirect
+	github.com/golang-jwt/jwt/v5 v5.3.0 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	golang.org/x/net v0.42.0 // indirect
@@ -25,3 +29,7 @@ require (
 	golang.org/x/text v0.27.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
 )
+
+replace github.com/seu-usuario/go-microservices-architecture/services/shared/auth => ../shared/auth
+
+replace github.com/seu-usuario/go-microservices-architecture/services/shared/money => ../shared/money
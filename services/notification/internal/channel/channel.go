@@ -0,0 +1,158 @@
+// Package channel entrega as notificações pelos canais configurados: e-mail por SMTP,
+// SMS por um provedor HTTP, webhooks genéricos (JSON assinado com HMAC-SHA256) e
+// webhooks de entrada compatíveis com o Slack. O canal log apenas registra a mensagem no
+// log do serviço, como no ambiente de desenvolvimento.
+package channel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Nomes dos canais aceitos em NOTIFICATION_CHANNELS
+const (
+	Log     = "log"
+	SMTP    = "smtp"
+	SMS     = "sms"
+	Webhook = "webhook"
+	Slack   = "slack"
+)
+
+// ErrNotConfigured indica um canal pedido na configuração sem os dados obrigatórios
+var ErrNotConfigured = errors.New("canal de notificação não configurado")
+
+// maxResponse é o máximo guardado da resposta do provedor
+const maxResponse = 500
+
+// Message é uma notificação a entregar
+type Message struct {
+	NotificationID uint
+	OrderID        uint
+	PaymentID      uint
+	// Event é o evento de origem, como payment.approved ou payment.disputed
+	Event   string
+	Subject string
+	Text    string
+	// HTML é a versão formatada da mensagem, usada no e-mail quando presente
+	HTML      string
+	Locale    string
+	CreatedAt time.Time
+	// Recipient é o usuário dono do pedido, resolvido no user-service; nil em eventos
+	// sem o cliente, que vão só aos destinatários configurados
+	Recipient *Recipient
+	// UnsubscribeURL é o link de cancelamento de inscrição em um clique do destinatário
+	UnsubscribeURL string
+}
+
+// Recipient é o destinatário de uma notificação
+type Recipient struct {
+	UserID uint
+	Name   string
+	Email  string
+}
+
+// Channel entrega notificações por um meio. Send devolve a resposta do provedor, também
+// nas falhas quando houver, para o registro da tentativa.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, message Message) (string, error)
+}
+
+// Config reúne os canais habilitados, na ordem de entrega, e os dados de cada um
+type Config struct {
+	Channels []string
+	SMTP     SMTPConfig
+	SMS      SMSConfig
+	Webhook  WebhookConfig
+	Slack    SlackConfig
+}
+
+// New cria os canais habilitados na configuração
+func New(cfg Config) ([]Channel, error) {
+	var channels []Channel
+	for _, name := range cfg.Channels {
+		var (
+			ch  Channel
+			err error
+		)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case Log:
+			ch = LogChannel{}
+		case SMTP:
+			ch, err = NewSMTPChannel(cfg.SMTP)
+		case SMS:
+			ch, err = NewSMSChannel(cfg.SMS)
+		case Webhook:
+			ch, err = NewWebhookChannel(cfg.Webhook)
+		case Slack:
+			ch, err = NewSlackChannel(cfg.Slack)
+		default:
+			return nil, fmt.Errorf("canal de notificação %q desconhecido (esperado log, smtp, sms, webhook ou slack)", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, ch)
+	}
+	return channels, nil
+}
+
+// LogChannel registra a notificação no log do serviço
+type LogChannel struct{}
+
+// Name retorna o nome do canal
+func (LogChannel) Name() string { return Log }
+
+// Send registra a notificação no log
+func (LogChannel) Send(ctx context.Context, message Message) (string, error) {
+	log.Printf("📋 [%s] %s: %s", message.Event, message.Subject, message.Text)
+	return "registrada no log", nil
+}
+
+// postJSON envia o corpo em JSON e devolve o status e o início da resposta do provedor;
+// respostas fora de 2xx são erro. O prazo vem do contexto.
+func postJSON(ctx context.Context, url string, body []byte, header http.Header) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponse))
+	response := truncate(strings.TrimSpace(fmt.Sprintf("%s %s", resp.Status, content)), maxResponse)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return response, fmt.Errorf("provedor respondeu %s", resp.Status)
+	}
+	return response, nil
+}
+
+// truncate corta o texto em até size bytes, sem quebrar caracteres
+func truncate(text string, size int) string {
+	if len(text) <= size {
+		return text
+	}
+	cut := size
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
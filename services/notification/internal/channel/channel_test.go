@@ -0,0 +1,153 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessage = Message{
+	NotificationID: 7,
+	OrderID:        42,
+	PaymentID:      3,
+	Event:          "payment.approved",
+	Subject:        "Pagamento do pedido 42",
+	Text:           "💬 Pagamento aprovado para o pedido 42 no valor de R$ 199,90",
+	CreatedAt:      time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+}
+
+// recorder é um provedor HTTP de teste que guarda as requisições e responde status
+type recorder struct {
+	status   int
+	response string
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newRecorder(t *testing.T, status int, response string) (*recorder, *httptest.Server) {
+	rec := &recorder{status: status, response: response}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rec.requests = append(rec.requests, r)
+		rec.bodies = append(rec.bodies, body)
+		w.WriteHeader(rec.status)
+		io.WriteString(w, rec.response)
+	}))
+	t.Cleanup(server.Close)
+	return rec, server
+}
+
+func TestSMSChannel(t *testing.T) {
+	rec, server := newRecorder(t, http.StatusAccepted, `{"id":"sms_1"}`)
+	ch, err := NewSMSChannel(SMSConfig{URL: server.URL, Token: "token", From: "LOJA", To: []string{"+5511999990000", "+5511988880000"}})
+	require.NoError(t, err)
+
+	response, err := ch.Send(context.Background(), testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, `202 Accepted {"id":"sms_1"}`, response)
+
+	require.Len(t, rec.requests, 2)
+	assert.Equal(t, "Bearer token", rec.requests[0].Header.Get("Authorization"))
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.bodies[1], &body))
+	assert.Equal(t, map[string]string{"to": "+5511988880000", "from": "LOJA", "message": testMessage.Text}, body)
+}
+
+func TestSMSChannel_ProviderError(t *testing.T) {
+	_, server := newRecorder(t, http.StatusUnprocessableEntity, `{"error":"número inválido"}`)
+	ch, err := NewSMSChannel(SMSConfig{URL: server.URL, To: []string{"123"}})
+	require.NoError(t, err)
+
+	response, err := ch.Send(context.Background(), testMessage)
+	require.Error(t, err)
+	assert.Equal(t, `422 Unprocessable Entity {"error":"número inválido"}`, response, "a resposta do provedor fica registrada também na falha")
+}
+
+func TestWebhookChannel(t *testing.T) {
+	rec, server := newRecorder(t, http.StatusOK, "ok")
+	ch, err := NewWebhookChannel(WebhookConfig{URL: server.URL, Secret: "segredo"})
+	require.NoError(t, err)
+	ch.now = func() time.Time { return time.Unix(1741608000, 0) }
+
+	_, err = ch.Send(context.Background(), testMessage)
+	require.NoError(t, err)
+
+	require.Len(t, rec.requests, 1)
+	assert.Equal(t, "application/json", rec.requests[0].Header.Get("Content-Type"))
+	assert.Equal(t, Sign([]byte("segredo"), rec.bodies[0], time.Unix(1741608000, 0)), rec.requests[0].Header.Get(SignatureHeader))
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(rec.bodies[0], &payload))
+	assert.Equal(t, WebhookPayload{
+		Event:          "payment.approved",
+		NotificationID: 7,
+		OrderID:        42,
+		PaymentID:      3,
+		Subject:        "Pagamento do pedido 42",
+		Message:        testMessage.Text,
+		CreatedAt:      "2025-03-10T12:00:00Z",
+	}, payload)
+}
+
+func TestSign(t *testing.T) {
+	header := Sign([]byte("segredo"), []byte(`{"event":"payment.approved"}`), time.Unix(1741608000, 0))
+	assert.Equal(t, "t=1741608000,v1=", header[:16])
+	assert.Len(t, header, 16+64)
+	assert.NotEqual(t, header, Sign([]byte("outro"), []byte(`{"event":"payment.approved"}`), time.Unix(1741608000, 0)))
+}
+
+func TestSlackChannel(t *testing.T) {
+	rec, server := newRecorder(t, http.StatusOK, "ok")
+	ch, err := NewSlackChannel(SlackConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	response, err := ch.Send(context.Background(), testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, "200 OK ok", response)
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.bodies[0], &body))
+	assert.Equal(t, "*Pagamento do pedido 42*\n"+testMessage.Text, body["text"])
+}
+
+func TestHTTPChannel_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+	ch, err := NewSlackChannel(SlackConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = ch.Send(ctx, testMessage)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNew(t *testing.T) {
+	channels, err := New(Config{
+		Channels: []string{"log", " Slack ", "webhook"},
+		Slack:    SlackConfig{URL: "http://localhost/slack"},
+		Webhook:  WebhookConfig{URL: "http://localhost/webhook"},
+	})
+	require.NoError(t, err)
+	require.Len(t, channels, 3)
+	assert.Equal(t, []string{Log, Slack, Webhook}, []string{channels[0].Name(), channels[1].Name(), channels[2].Name()})
+
+	_, err = New(Config{Channels: []string{"smtp"}})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+	_, err = New(Config{Channels: []string{"sms"}, SMS: SMSConfig{URL: "http://localhost/sms"}})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+	_, err = New(Config{Channels: []string{"pombo"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SlackConfig é o incoming webhook do Slack (ou compatível, como Mattermost e Rocket.Chat)
+type SlackConfig struct {
+	URL string
+}
+
+// SlackChannel entrega as notificações em um canal do Slack
+type SlackChannel struct {
+	cfg SlackConfig
+}
+
+// NewSlackChannel cria o canal do Slack
+func NewSlackChannel(cfg SlackConfig) (*SlackChannel, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("%w: slack exige SLACK_WEBHOOK_URL", ErrNotConfigured)
+	}
+	return &SlackChannel{cfg: cfg}, nil
+}
+
+// Name retorna o nome do canal
+func (c *SlackChannel) Name() string { return Slack }
+
+// Send publica a notificação com o assunto em negrito
+func (c *SlackChannel) Send(ctx context.Context, message Message) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", message.Subject, message.Text),
+	})
+	if err != nil {
+		return "", err
+	}
+	return postJSON(ctx, c.cfg.URL, body, nil)
+}
@@ -0,0 +1,60 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// maxSMS é o máximo de caracteres do texto enviado por SMS
+const maxSMS = 480
+
+// SMSConfig é o provedor HTTP de SMS: recebe POST em JSON com to, from e message,
+// autenticado com o token no cabeçalho Authorization (Bearer)
+type SMSConfig struct {
+	URL   string
+	Token string
+	From  string
+	To    []string
+}
+
+// SMSChannel entrega as notificações por SMS
+type SMSChannel struct {
+	cfg SMSConfig
+}
+
+// NewSMSChannel cria o canal de SMS
+func NewSMSChannel(cfg SMSConfig) (*SMSChannel, error) {
+	if cfg.URL == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("%w: sms exige SMS_PROVIDER_URL e SMS_TO", ErrNotConfigured)
+	}
+	return &SMSChannel{cfg: cfg}, nil
+}
+
+// Name retorna o nome do canal
+func (c *SMSChannel) Name() string { return SMS }
+
+// Send envia o texto da notificação a cada número configurado
+func (c *SMSChannel) Send(ctx context.Context, message Message) (string, error) {
+	header := http.Header{}
+	if c.cfg.Token != "" {
+		header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	var response string
+	for _, to := range c.cfg.To {
+		body, err := json.Marshal(map[string]string{
+			"to":      to,
+			"from":    c.cfg.From,
+			"message": truncate(message.Text, maxSMS),
+		})
+		if err != nil {
+			return "", err
+		}
+		if response, err = postJSON(ctx, c.cfg.URL, body, header); err != nil {
+			return response, fmt.Errorf("SMS para %s: %w", to, err)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,185 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// SMTPConfig é o servidor de e-mail e os destinatários das notificações sem usuário
+// identificado; as demais vão ao e-mail do destinatário. Sem usuário SMTP, o envio é feito
+// sem autenticação; STARTTLS é usado quando o servidor oferece.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTPChannel entrega as notificações por e-mail
+type SMTPChannel struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPChannel cria o canal de e-mail
+func NewSMTPChannel(cfg SMTPConfig) (*SMTPChannel, error) {
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, fmt.Errorf("%w: smtp exige SMTP_HOST e SMTP_FROM", ErrNotConfigured)
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	return &SMTPChannel{cfg: cfg}, nil
+}
+
+// Name retorna o nome do canal
+func (c *SMTPChannel) Name() string { return SMTP }
+
+// Send envia a notificação por e-mail ao destinatário ou, sem ele, aos destinatários
+// configurados
+func (c *SMTPChannel) Send(ctx context.Context, message Message) (string, error) {
+	to := c.cfg.To
+	if message.Recipient != nil && message.Recipient.Email != "" {
+		to = []string{message.Recipient.Email}
+	}
+	if len(to) == 0 {
+		return "", errors.New("notificação sem destinatário de e-mail (defina SMTP_TO)")
+	}
+
+	addr := net.JoinHostPort(c.cfg.Host, c.cfg.Port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return "", err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.cfg.Host}); err != nil {
+			return "", err
+		}
+	}
+	if c.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)); err != nil {
+			return "", err
+		}
+	}
+	if err := client.Mail(c.cfg.From); err != nil {
+		return "", err
+	}
+	for _, address := range to {
+		if err := client.Rcpt(address); err != nil {
+			return "", err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(c.compose(message, to)); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	client.Quit()
+
+	return fmt.Sprintf("aceita por %s para %s", addr, strings.Join(to, ", ")), nil
+}
+
+// compose monta o e-mail em UTF-8, com o assunto codificado para os cabeçalhos. Com
+// HTML, a mensagem vai em multipart/alternative com o texto simples antes do HTML. Com
+// link de cancelamento, o rodapé o traz e os cabeçalhos List-Unsubscribe e
+// List-Unsubscribe-Post permitem o cancelamento em um clique (RFC 8058).
+func (c *SMTPChannel) compose(message Message, to []string) []byte {
+	sent := message.CreatedAt
+	if sent.IsZero() {
+		sent = time.Now()
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", c.cfg.From)
+	if message.Recipient != nil && message.Recipient.Email != "" {
+		address := mail.Address{Name: message.Recipient.Name, Address: message.Recipient.Email}
+		fmt.Fprintf(&buf, "To: %s\r\n", address.String())
+	} else {
+		fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", sent.Format(time.RFC1123Z))
+	text, htmlBody := message.Text, message.HTML
+	if message.UnsubscribeURL != "" {
+		fmt.Fprintf(&buf, "List-Unsubscribe: <%s>\r\n", message.UnsubscribeURL)
+		buf.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+		footer := unsubscribeFooters[money.NormalizeLocale(message.Locale)]
+		text += "\n\n" + footer + ": " + message.UnsubscribeURL
+		if htmlBody != "" {
+			htmlBody += fmt.Sprintf("\n<p><a href=\"%s\">%s</a></p>", html.EscapeString(message.UnsubscribeURL), html.EscapeString(footer))
+		}
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if htmlBody == "" {
+		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		writeQuotedPrintable(&buf, text)
+		return buf.Bytes()
+	}
+
+	parts := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", htmlBody},
+	} {
+		writer, _ := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		writeQuotedPrintable(writer, part.body)
+	}
+	parts.Close()
+	return buf.Bytes()
+}
+
+// unsubscribeFooters é o texto do link de cancelamento por localidade
+var unsubscribeFooters = map[string]string{
+	money.LocalePtBR: "Para não receber mais estas notificações, cancele a inscrição",
+	money.LocaleEnUS: "To stop receiving these notifications, unsubscribe",
+	money.LocaleES:   "Para dejar de recibir estas notificaciones, cancele la suscripción",
+}
+
+// writeQuotedPrintable escreve o corpo em quoted-printable
+func writeQuotedPrintable(w io.Writer, text string) {
+	body := quotedprintable.NewWriter(w)
+	body.Write([]byte(text))
+	body.Close()
+	io.WriteString(w, "\r\n")
+}
@@ -0,0 +1,240 @@
+package channel
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpServer é um servidor SMTP mínimo em processo: aceita AUTH PLAIN e guarda a
+// última mensagem recebida. rejectRcpt recusa os destinatários.
+type smtpServer struct {
+	listener   net.Listener
+	rejectRcpt bool
+	received   chan smtpMail
+}
+
+// smtpMail é uma mensagem recebida pelo servidor de teste
+type smtpMail struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPServer(t *testing.T, rejectRcpt bool) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &smtpServer{listener: listener, rejectRcpt: rejectRcpt, received: make(chan smtpMail, 1)}
+	t.Cleanup(func() { listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *smtpServer) config() SMTPConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: "notificacoes",
+		Password: "segredo",
+		From:     "loja@example.com",
+		To:       []string{"financeiro@example.com", "suporte@example.com"},
+	}
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	var current smtpMail
+	reply("220 localhost ESMTP teste")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			current.auth = line
+			reply("235 autenticado")
+		case "MAIL":
+			current.from = line
+			reply("250 ok")
+		case "RCPT":
+			if s.rejectRcpt {
+				reply("550 destinatário desconhecido")
+				continue
+			}
+			current.to = append(current.to, line)
+			reply("250 ok")
+		case "DATA":
+			reply("354 envie a mensagem")
+			var data strings.Builder
+			for {
+				body, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if body == ".\r\n" {
+					break
+				}
+				data.WriteString(body)
+			}
+			current.data = data.String()
+			reply("250 mensagem aceita")
+			s.received <- current
+		case "QUIT":
+			reply("221 até logo")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPChannel(t *testing.T) {
+	server := newSMTPServer(t, false)
+	ch, err := NewSMTPChannel(server.config())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := ch.Send(ctx, Message{
+		Event:     "payment.approved",
+		Subject:   "Pagamento do pedido 42",
+		Text:      "💬 Pagamento aprovado para o pedido 42 no valor de R$ 199,90",
+		CreatedAt: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Contains(t, response, "financeiro@example.com, suporte@example.com")
+
+	received := <-server.received
+	assert.Equal(t, "AUTH PLAIN AG5vdGlmaWNhY29lcwBzZWdyZWRv", received.auth)
+	assert.Equal(t, "MAIL FROM:<loja@example.com>", received.from)
+	assert.Len(t, received.to, 2)
+
+	message, err := mail.ReadMessage(strings.NewReader(received.data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Pagamento do pedido 42", subject)
+	assert.Equal(t, "text/plain; charset=UTF-8", message.Header.Get("Content-Type"))
+	body, err := io.ReadAll(quotedprintable.NewReader(message.Body))
+	require.NoError(t, err)
+	assert.Equal(t, "💬 Pagamento aprovado para o pedido 42 no valor de R$ 199,90", strings.TrimSpace(string(body)))
+}
+
+func TestSMTPChannel_HTML(t *testing.T) {
+	server := newSMTPServer(t, false)
+	ch, err := NewSMTPChannel(server.config())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = ch.Send(ctx, Message{
+		Subject: "Pagamento do pedido 42",
+		Text:    "Pagamento aprovado para o pedido 42",
+		HTML:    "<p>Pagamento <strong>aprovado</strong> para o pedido 42.</p>",
+	})
+	require.NoError(t, err)
+
+	received := <-server.received
+	message, err := mail.ReadMessage(strings.NewReader(received.data))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	var types, bodies []string
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		types = append(types, part.Header.Get("Content-Type"))
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+	}
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, types)
+	assert.Equal(t, []string{"Pagamento aprovado para o pedido 42", "<p>Pagamento <strong>aprovado</strong> para o pedido 42.</p>"}, bodies)
+}
+
+func TestSMTPChannel_Recipient(t *testing.T) {
+	server := newSMTPServer(t, false)
+	ch, err := NewSMTPChannel(server.config())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := ch.Send(ctx, Message{
+		Subject:        "Payment for order 42",
+		Text:           "Payment approved",
+		HTML:           "<p>Payment approved</p>",
+		Locale:         "en-US",
+		Recipient:      &Recipient{UserID: 7, Name: "Ana Souza", Email: "ana@example.com"},
+		UnsubscribeURL: "https://loja.example.com/unsubscribe?token=v1.7.abc",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, response, "para ana@example.com")
+
+	received := <-server.received
+	assert.Equal(t, []string{"RCPT TO:<ana@example.com>"}, received.to, "vai só ao destinatário, não a SMTP_TO")
+
+	message, err := mail.ReadMessage(strings.NewReader(received.data))
+	require.NoError(t, err)
+	to, err := message.Header.AddressList("To")
+	require.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Ana Souza", Address: "ana@example.com"}}, to)
+	assert.Equal(t, "<https://loja.example.com/unsubscribe?token=v1.7.abc>", message.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", message.Header.Get("List-Unsubscribe-Post"))
+
+	body, err := io.ReadAll(message.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(string(body))))
+	require.NoError(t, err)
+	assert.Contains(t, string(decoded), "To stop receiving these notifications, unsubscribe: https://loja.example.com/unsubscribe?token=v1.7.abc")
+	assert.Contains(t, string(decoded), `<a href="https://loja.example.com/unsubscribe?token=v1.7.abc">`)
+}
+
+func TestSMTPChannel_Rejected(t *testing.T) {
+	server := newSMTPServer(t, true)
+	ch, err := NewSMTPChannel(server.config())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = ch.Send(ctx, Message{Subject: "Pagamento do pedido 42", Text: "aprovado"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "550")
+}
@@ -0,0 +1,92 @@
+package channel
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader é o cabeçalho com a assinatura dos webhooks de notificação
+const SignatureHeader = "X-Notification-Signature"
+
+// WebhookConfig é o endereço que recebe as notificações em JSON e o segredo da
+// assinatura; sem segredo os webhooks vão sem assinatura
+type WebhookConfig struct {
+	URL    string
+	Secret string
+}
+
+// WebhookPayload é o corpo dos webhooks de notificação
+type WebhookPayload struct {
+	Event          string `json:"event"`
+	NotificationID uint   `json:"notification_id"`
+	OrderID        uint   `json:"order_id"`
+	PaymentID      uint   `json:"payment_id"`
+	UserID         uint   `json:"user_id,omitempty"`
+	Subject        string `json:"subject"`
+	Message        string `json:"message"`
+	HTML           string `json:"html,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// WebhookChannel entrega as notificações por webhook
+type WebhookChannel struct {
+	cfg WebhookConfig
+	now func() time.Time
+}
+
+// NewWebhookChannel cria o canal de webhooks genéricos
+func NewWebhookChannel(cfg WebhookConfig) (*WebhookChannel, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("%w: webhook exige NOTIFICATION_WEBHOOK_URL", ErrNotConfigured)
+	}
+	return &WebhookChannel{cfg: cfg, now: time.Now}, nil
+}
+
+// Name retorna o nome do canal
+func (c *WebhookChannel) Name() string { return Webhook }
+
+// Send envia a notificação em JSON, assinada com HMAC-SHA256 sobre "<unix>.<corpo>"
+// no cabeçalho SignatureHeader ("t=<unix>,v1=<hex>")
+func (c *WebhookChannel) Send(ctx context.Context, message Message) (string, error) {
+	payload := WebhookPayload{
+		Event:          message.Event,
+		NotificationID: message.NotificationID,
+		OrderID:        message.OrderID,
+		PaymentID:      message.PaymentID,
+		Subject:        message.Subject,
+		Message:        message.Text,
+		HTML:           message.HTML,
+	}
+	if message.Recipient != nil {
+		payload.UserID = message.Recipient.UserID
+	}
+	if !message.CreatedAt.IsZero() {
+		payload.CreatedAt = message.CreatedAt.Format(time.RFC3339)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	header := http.Header{}
+	if c.cfg.Secret != "" {
+		header.Set(SignatureHeader, Sign([]byte(c.cfg.Secret), body, c.now()))
+	}
+	return postJSON(ctx, c.cfg.URL, body, header)
+}
+
+// Sign assina o corpo do webhook e devolve o valor do cabeçalho SignatureHeader
+func Sign(secret, body []byte, timestamp time.Time) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unix + "."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GetUserRequest identifica o usuário buscado no user-service
+type GetUserRequest struct {
+	ID uint32 `json:"id"`
+}
+
+// User representa um usuário retornado pelo user-service
+type User struct {
+	ID        uint32   `json:"id"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email"`
+	CreatedAt string   `json:"created_at"`
+	Roles     []string `json:"roles"`
+}
+
+// UserClient resolve os destinatários das notificações no user-service
+type UserClient interface {
+	GetUser(ctx context.Context, userID uint) (*User, error)
+	Close() error
+}
+
+// grpcUserClient implementa UserClient usando gRPC
+type grpcUserClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewUserClient conecta ao user-service; opts normalmente inclui as credenciais da conta
+// de serviço (permissão users:read)
+func NewUserClient(addr string, opts ...grpc.DialOption) (UserClient, error) {
+	log.Printf("🔌 Configurando conexão com User Service em %s...", addr)
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(addr, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcUserClient{conn: conn}, nil
+}
+
+// GetUser busca o usuário pelo ID
+func (c *grpcUserClient) GetUser(ctx context.Context, userID uint) (*User, error) {
+	out := new(User)
+	if err := c.conn.Invoke(ctx, "/user.UserService/GetUser", &GetUserRequest{ID: uint32(userID)}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Close fecha a conexão com o user-service
+func (c *grpcUserClient) Close() error {
+	return c.conn.Close()
+}
@@ -2,6 +2,11 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"notification-service/internal/channel"
 )
 
 // Config armazena as configurações da aplicação
@@ -22,6 +27,60 @@ type Config struct {
 
 	// Server
 	GRPCPort string
+	// HTTPPort atende o link de cancelamento de inscrição dos e-mails
+	HTTPPort string
+
+	// Autenticação
+	JWTSecret string
+	JWTIssuer string
+
+	// Destinatários: user-service consultado com a conta de serviço do notification-service
+	UserServiceURL      string
+	ServiceClientID     string
+	ServiceClientSecret string
+
+	// Cancelamento de inscrição em um clique: segredo dos tokens e endereço público do
+	// link (ex.: https://loja.exemplo/notifications/unsubscribe); sem o segredo, os
+	// e-mails vão sem o link
+	UnsubscribeSecret string
+	UnsubscribeURL    string
+
+	// Locale define o idioma e a formatação de valores nas mensagens (pt-BR, en-US ou es)
+	Locale string
+
+	// Modelos das mensagens: pasta opcional com <localidade>.tmpl que substitui os
+	// modelos embutidos e intervalo de recarga das versões gravadas no banco
+	TemplatesDir     string
+	TemplatesRefresh time.Duration
+
+	// Entrega das notificações: canais (log, smtp, sms, webhook, slack), tentativas por
+	// canal, intervalo entre tentativas e prazo de cada envio
+	Channels         []string
+	DeliveryAttempts int
+	DeliveryBackoff  time.Duration
+	DeliveryTimeout  time.Duration
+
+	// Intervalo da entrega das notificações adiadas pelo horário de silêncio
+	DeferredInterval time.Duration
+
+	// E-mail
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       []string
+
+	// SMS por provedor HTTP
+	SMSProviderURL   string
+	SMSProviderToken string
+	SMSFrom          string
+	SMSTo            []string
+
+	// Webhooks genéricos e Slack
+	WebhookURL      string
+	WebhookSecret   string
+	SlackWebhookURL string
 }
 
 // LoadConfig carrega as configurações das variáveis de ambiente
@@ -43,6 +102,69 @@ func LoadConfig() *Config {
 
 		// Server
 		GRPCPort: getEnv("GRPC_PORT", "50055"),
+		HTTPPort: getEnv("NOTIFICATION_HTTP_PORT", "8085"),
+
+		// Autenticação
+		JWTSecret: getEnv("JWT_SECRET", ""),
+		JWTIssuer: getEnv("JWT_ISSUER", "user-service"),
+
+		UserServiceURL:      getEnv("USER_SERVICE_URL", "user:50051"),
+		ServiceClientID:     getEnv("SERVICE_CLIENT_ID", "notification-service"),
+		ServiceClientSecret: getEnv("SERVICE_CLIENT_SECRET", ""),
+
+		UnsubscribeSecret: getEnv("NOTIFICATION_UNSUBSCRIBE_SECRET", ""),
+		UnsubscribeURL:    getEnv("NOTIFICATION_UNSUBSCRIBE_URL", "http://localhost:8085/unsubscribe"),
+
+		Locale: getEnv("NOTIFICATION_LOCALE", "pt-BR"),
+
+		TemplatesDir:     getEnv("NOTIFICATION_TEMPLATES_DIR", ""),
+		TemplatesRefresh: getEnvAsDuration("NOTIFICATION_TEMPLATES_REFRESH", time.Minute),
+
+		Channels:         getEnvAsList("NOTIFICATION_CHANNELS", "log"),
+		DeliveryAttempts: getEnvAsInt("NOTIFICATION_DELIVERY_ATTEMPTS", 3),
+		DeliveryBackoff:  getEnvAsDuration("NOTIFICATION_DELIVERY_BACKOFF", 2*time.Second),
+		DeliveryTimeout:  getEnvAsDuration("NOTIFICATION_DELIVERY_TIMEOUT", 10*time.Second),
+
+		DeferredInterval: getEnvAsDuration("NOTIFICATION_DEFERRED_INTERVAL", time.Minute),
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", ""),
+		SMTPTo:       getEnvAsList("SMTP_TO", ""),
+
+		SMSProviderURL:   getEnv("SMS_PROVIDER_URL", ""),
+		SMSProviderToken: getEnv("SMS_PROVIDER_TOKEN", ""),
+		SMSFrom:          getEnv("SMS_FROM", ""),
+		SMSTo:            getEnvAsList("SMS_TO", ""),
+
+		WebhookURL:      getEnv("NOTIFICATION_WEBHOOK_URL", ""),
+		WebhookSecret:   getEnv("NOTIFICATION_WEBHOOK_SECRET", ""),
+		SlackWebhookURL: getEnv("SLACK_WEBHOOK_URL", ""),
+	}
+}
+
+// ChannelConfig retorna a configuração dos canais de entrega
+func (c *Config) ChannelConfig() channel.Config {
+	return channel.Config{
+		Channels: c.Channels,
+		SMTP: channel.SMTPConfig{
+			Host:     c.SMTPHost,
+			Port:     c.SMTPPort,
+			Username: c.SMTPUsername,
+			Password: c.SMTPPassword,
+			From:     c.SMTPFrom,
+			To:       c.SMTPTo,
+		},
+		SMS: channel.SMSConfig{
+			URL:   c.SMSProviderURL,
+			Token: c.SMSProviderToken,
+			From:  c.SMSFrom,
+			To:    c.SMSTo,
+		},
+		Webhook: channel.WebhookConfig{URL: c.WebhookURL, Secret: c.WebhookSecret},
+		Slack:   channel.SlackConfig{URL: c.SlackWebhookURL},
 	}
 }
 
@@ -53,3 +175,30 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvAsInt obtém uma variável de ambiente inteira ou retorna um valor padrão
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsDuration obtém uma duração (ex.: 10s) ou retorna um valor padrão
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsList obtém uma lista separada por vírgulas, sem itens vazios
+func getEnvAsList(key, defaultValue string) []string {
+	var items []string
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
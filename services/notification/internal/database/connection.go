@@ -55,6 +55,9 @@ func Connect() (*gorm.DB, error) {
 func Migrate(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&domain.Notification{},
+		&domain.DeliveryAttempt{},
+		&domain.NotificationTemplate{},
+		&domain.NotificationPreference{},
 	)
 
 	if err != nil {
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// DeliveryAttempt registra uma tentativa de entrega de notificação por um canal, com a
+// resposta do provedor. Entregas suprimidas ou adiadas pelas preferências do destinatário
+// ficam com Attempt zero e o motivo em Reason.
+type DeliveryAttempt struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	NotificationID uint      `gorm:"index;not null" json:"notification_id"`
+	Channel        string    `gorm:"type:varchar(20);not null" json:"channel"`
+	Attempt        int       `gorm:"not null" json:"attempt"`
+	Status         string    `gorm:"type:varchar(20);not null" json:"status"`
+	Response       string    `gorm:"type:varchar(500)" json:"response"`
+	Error          string    `gorm:"type:varchar(500)" json:"error"`
+	Reason         string    `gorm:"type:varchar(50)" json:"reason"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Status das tentativas de entrega
+const (
+	DeliveryStatusSent   = "sent"
+	DeliveryStatusFailed = "failed"
+	// DeliveryStatusSuppressed é a entrega não feita por causa das preferências
+	DeliveryStatusSuppressed = "suppressed"
+	// DeliveryStatusDeferred é a entrega adiada pelo horário de silêncio
+	DeliveryStatusDeferred = "deferred"
+)
+
+// TableName define o nome da tabela no banco de dados
+func (DeliveryAttempt) TableName() string {
+	return "notification_deliveries"
+}
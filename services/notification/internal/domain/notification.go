@@ -6,16 +6,26 @@ import (
 	"gorm.io/gorm"
 )
 
-// Notification representa uma notificação no sistema
+// Notification representa uma notificação no sistema. UserID é o destinatário, dono do
+// pedido; zero em eventos sem o cliente. Event é o evento enviado aos canais e Topic o
+// usado nas preferências. Subject, HTML e Locale guardam a mensagem montada apenas
+// enquanto a entrega está adiada (DEFERRED) até DeliverAfter.
 type Notification struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	PaymentID uint           `gorm:"index" json:"payment_id"`
-	OrderID   uint           `gorm:"index" json:"order_id"`
-	Message   string         `gorm:"type:varchar(500);not null" json:"message"`
-	Status    string         `gorm:"type:varchar(50);not null;default:'SENT'" json:"status"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	PaymentID    uint           `gorm:"index" json:"payment_id"`
+	OrderID      uint           `gorm:"index" json:"order_id"`
+	UserID       uint           `gorm:"index" json:"user_id"`
+	Event        string         `gorm:"type:varchar(50)" json:"event"`
+	Topic        string         `gorm:"type:varchar(50)" json:"topic"`
+	Message      string         `gorm:"type:varchar(500);not null" json:"message"`
+	Subject      string         `gorm:"type:varchar(200)" json:"-"`
+	HTML         string         `gorm:"column:html;type:text" json:"-"`
+	Locale       string         `gorm:"type:varchar(10)" json:"-"`
+	Status       string         `gorm:"type:varchar(50);not null;default:'SENT';index:idx_notifications_deferred,priority:1" json:"status"`
+	DeliverAfter *time.Time     `gorm:"index:idx_notifications_deferred,priority:2" json:"deliver_after,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Status possíveis para notificações
@@ -23,8 +33,17 @@ const (
 	NotificationStatusSent    = "SENT"
 	NotificationStatusPending = "PENDING"
 	NotificationStatusFailed  = "FAILED"
+	// NotificationStatusSuppressed indica que as preferências do destinatário impediram
+	// a entrega em todos os canais
+	NotificationStatusSuppressed = "SUPPRESSED"
+	// NotificationStatusDeferred indica a entrega adiada pelo horário de silêncio do
+	// destinatário, feita a partir de DeliverAfter
+	NotificationStatusDeferred = "DEFERRED"
 )
 
+// RedactedMessage substitui o conteúdo de notificações anonimizadas (LGPD)
+const RedactedMessage = "[conteúdo removido a pedido do titular]"
+
 // TableName define o nome da tabela no banco de dados
 func (Notification) TableName() string {
 	return "notifications"
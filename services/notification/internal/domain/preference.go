@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Motivos de uma entrega suprimida pelas preferências do destinatário
+const (
+	SuppressedUnsubscribed    = "unsubscribed"     // inscrição cancelada em todas as notificações
+	SuppressedChannelDisabled = "channel_disabled" // canal desligado para o evento
+)
+
+// DeferredQuietHours é o motivo de uma entrega adiada: o horário de silêncio não descarta
+// a notificação, apenas a segura até o fim do período
+const DeferredQuietHours = "quiet_hours"
+
+// AllEvents é a chave das preferências que vale para qualquer evento
+const AllEvents = "*"
+
+// EventChannels são os canais aceitos por evento (ex.: payment.approved: [smtp]),
+// gravados como JSON em uma coluna de texto. A chave pode ser um evento, a família
+// ("payment.*", "dispute.*") ou AllEvents; uma lista vazia desliga o evento.
+type EventChannels map[string][]string
+
+// For retorna os canais aceitos no evento: os do evento, os da família ou os de
+// AllEvents. ok é falso quando nada foi definido, e então valem todos os canais.
+func (e EventChannels) For(event string) (channels []string, ok bool) {
+	keys := []string{event}
+	if family, _, found := strings.Cut(event, "."); found {
+		keys = append(keys, family+".*")
+	}
+	for _, key := range append(keys, AllEvents) {
+		if channels, ok := e[key]; ok {
+			return channels, true
+		}
+	}
+	return nil, false
+}
+
+// Value serializa os canais para o banco de dados
+func (e EventChannels) Value() (driver.Value, error) {
+	if e == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan lê os canais gravados no banco de dados
+func (e *EventChannels) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = EventChannels{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tipo inválido para canais por evento: %T", value)
+	}
+	return json.Unmarshal(data, e)
+}
+
+// NotificationPreference são as preferências de notificação de um usuário. Sem
+// preferências gravadas o usuário recebe tudo. O horário de silêncio vai de QuietStart a
+// QuietEnd ("HH:MM", podendo passar da meia-noite) no fuso Timezone (IANA, ex.:
+// America/Sao_Paulo); Locale escolhe o idioma dos modelos.
+type NotificationPreference struct {
+	UserID         uint          `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Locale         string        `gorm:"type:varchar(10)" json:"locale"`
+	Timezone       string        `gorm:"type:varchar(64)" json:"timezone"`
+	QuietStart     string        `gorm:"type:varchar(5)" json:"quiet_start"`
+	QuietEnd       string        `gorm:"type:varchar(5)" json:"quiet_end"`
+	Channels       EventChannels `gorm:"type:text" json:"channels"`
+	Unsubscribed   bool          `gorm:"not null;default:false" json:"unsubscribed"`
+	UnsubscribedAt *time.Time    `json:"unsubscribed_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// TableName define o nome da tabela no banco de dados
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// Suppression retorna o motivo para não entregar o evento pelo canal, ou vazio quando
+// a entrega é permitida. O horário de silêncio não suprime a entrega: ver QuietUntil.
+func (p *NotificationPreference) Suppression(event, channel string) string {
+	if p == nil {
+		return ""
+	}
+	if p.Unsubscribed {
+		return SuppressedUnsubscribed
+	}
+	if channels, ok := p.Channels.For(event); ok && !slices.Contains(channels, channel) {
+		return SuppressedChannelDisabled
+	}
+	return ""
+}
+
+// Location retorna o fuso do usuário (vazio é UTC). O fuso é validado ao gravar as
+// preferências; o erro só aparece em registros gravados antes dessa validação.
+func (p *NotificationPreference) Location() (*time.Location, error) {
+	location, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("fuso horário %q desconhecido: %w", p.Timezone, err)
+	}
+	return location, nil
+}
+
+// QuietUntil indica se o instante está no horário de silêncio, no fuso location, e
+// retorna o fim do período: a próxima ocorrência de QuietEnd naquele fuso.
+func (p *NotificationPreference) QuietUntil(now time.Time, location *time.Location) (time.Time, bool) {
+	if p == nil {
+		return time.Time{}, false
+	}
+	start, okStart := clockMinutes(p.QuietStart)
+	end, okEnd := clockMinutes(p.QuietEnd)
+	if !okStart || !okEnd || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	quiet := minute >= start && minute < end
+	if start > end {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, location)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, location)
+	}
+	return until, true
+}
+
+// clockMinutes converte "HH:MM" em minutos desde a meia-noite
+func clockMinutes(value string) (int, bool) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return clock.Hour()*60 + clock.Minute(), true
+}
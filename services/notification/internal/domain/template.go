@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// NotificationTemplate é uma versão de modelo de notificação gravada no banco. Só uma
+// versão por evento e localidade fica ativa; as anteriores ficam no histórico e podem
+// ser reativadas.
+type NotificationTemplate struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Event     string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_notification_templates_version" json:"event"`
+	Locale    string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_notification_templates_version" json:"locale"`
+	Version   int       `gorm:"not null;uniqueIndex:idx_notification_templates_version" json:"version"`
+	Subject   string    `gorm:"type:text;not null" json:"subject"`
+	Text      string    `gorm:"type:text;not null" json:"text"`
+	HTML      string    `gorm:"column:html;type:text" json:"html"`
+	Active    bool      `gorm:"not null;default:false;index" json:"active"`
+	CreatedBy string    `gorm:"type:varchar(255)" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName define o nome da tabela no banco de dados
+func (NotificationTemplate) TableName() string {
+	return "notification_templates"
+}
@@ -5,23 +5,56 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 	"notification-service/internal/config"
 	"notification-service/internal/service"
 
 	"github.com/rabbitmq/amqp091-go"
 )
 
-// PaymentEvent representa o evento de pagamento recebido do RabbitMQ
+// PaymentEvent representa o evento de pagamento recebido do RabbitMQ. Amount é o valor
+// cobrado e OrderAmount o total na moeda do pedido; eventos antigos não trazem OrderAmount.
+// Eventos de contestação têm EventType payment.disputed e trazem a contestação em Dispute.
+// Customer é o cliente do pedido: o ID do usuário ou o e-mail; eventos antigos não o trazem.
 type PaymentEvent struct {
-	PaymentID uint    `json:"payment_id"`
-	OrderID   uint    `json:"order_id"`
-	Status    string  `json:"status"`
-	Amount    float64 `json:"amount"`
-	CreatedAt string  `json:"created_at"`
+	PaymentID   uint          `json:"payment_id"`
+	OrderID     uint          `json:"order_id"`
+	Status      string        `json:"status"`
+	Amount      money.Money   `json:"amount"`
+	OrderAmount money.Money   `json:"order_amount"`
+	FXRate      string        `json:"fx_rate"`
+	CreatedAt   string        `json:"created_at"`
+	Customer    string        `json:"customer,omitempty"`
+	EventType   string        `json:"event_type,omitempty"`
+	Dispute     *DisputeEvent `json:"dispute,omitempty"`
 }
 
+// UserID retorna o usuário dono do pedido, ou zero quando o cliente não é um ID de
+// usuário (e-mail ou eventos antigos) e a notificação segue sem destinatário resolvido
+func (e PaymentEvent) UserID() uint {
+	userID, err := strconv.ParseUint(e.Customer, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(userID)
+}
+
+// DisputeEvent é a contestação de um evento payment.disputed
+type DisputeEvent struct {
+	ID            uint        `json:"id"`
+	Reference     string      `json:"reference"`
+	Reason        string      `json:"reason"`
+	Status        string      `json:"status"`
+	Amount        money.Money `json:"amount"`
+	EvidenceDueAt string      `json:"evidence_due_at"`
+}
+
+// EventPaymentDisputed é o tipo dos eventos de contestação de pagamento
+const EventPaymentDisputed = "payment.disputed"
+
 // PaymentConsumer gerencia o consumo de mensagens da fila payments
 type PaymentConsumer struct {
 	conn                *amqp091.Connection
@@ -158,16 +191,35 @@ func (c *PaymentConsumer) processMessage(delivery amqp091.Delivery) {
 	log.Printf("   🆔 Payment ID: %d", paymentEvent.PaymentID)
 	log.Printf("   📝 Order ID: %d", paymentEvent.OrderID)
 	log.Printf("   📊 Status: %s", paymentEvent.Status)
-	log.Printf("   💰 Valor: R$ %.2f", paymentEvent.Amount)
+	log.Printf("   💰 Valor: %s (pedido: %s, cotação: %s)", paymentEvent.Amount, paymentEvent.OrderAmount, paymentEvent.FXRate)
 	log.Printf("   ⏰ Criado em: %s", paymentEvent.CreatedAt)
+	userID := paymentEvent.UserID()
 
 	// Processar notificação
-	err = c.notificationService.ProcessPaymentNotification(
-		paymentEvent.PaymentID,
-		paymentEvent.OrderID,
-		paymentEvent.Status,
-		paymentEvent.Amount,
-	)
+	if paymentEvent.EventType == EventPaymentDisputed && paymentEvent.Dispute != nil {
+		log.Printf("   ⚖️ Contestação: %s (%s)", paymentEvent.Dispute.Reference, paymentEvent.Dispute.Status)
+		err = c.notificationService.ProcessDisputeNotification(
+			paymentEvent.PaymentID,
+			paymentEvent.OrderID,
+			userID,
+			service.DisputeNotice{
+				Reference:     paymentEvent.Dispute.Reference,
+				Reason:        paymentEvent.Dispute.Reason,
+				Status:        paymentEvent.Dispute.Status,
+				Amount:        paymentEvent.Dispute.Amount,
+				EvidenceDueAt: paymentEvent.Dispute.EvidenceDueAt,
+			},
+		)
+	} else {
+		err = c.notificationService.ProcessPaymentNotification(
+			paymentEvent.PaymentID,
+			paymentEvent.OrderID,
+			userID,
+			paymentEvent.Status,
+			paymentEvent.Amount,
+			paymentEvent.OrderAmount,
+		)
+	}
 
 	if err != nil {
 		log.Printf("❌ Erro ao processar notificação de pagamento: %v", err)
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"notification-service/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// DeliveryRepository define os métodos de acesso às tentativas de entrega
+type DeliveryRepository interface {
+	Create(attempt *domain.DeliveryAttempt) error
+	ListByNotificationID(notificationID uint) ([]*domain.DeliveryAttempt, error)
+}
+
+// DeliveryRepositoryImpl implementa DeliveryRepository usando GORM
+type DeliveryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewDeliveryRepository cria uma nova instância do repositório de entregas
+func NewDeliveryRepository(db *gorm.DB) DeliveryRepository {
+	return &DeliveryRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create registra uma tentativa de entrega
+func (r *DeliveryRepositoryImpl) Create(attempt *domain.DeliveryAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+// ListByNotificationID busca as tentativas de entrega de uma notificação, na ordem
+func (r *DeliveryRepositoryImpl) ListByNotificationID(notificationID uint) ([]*domain.DeliveryAttempt, error) {
+	var attempts []*domain.DeliveryAttempt
+	err := r.db.Where("notification_id = ?", notificationID).Order("id").Find(&attempts).Error
+	return attempts, err
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"notification-service/internal/domain"
 
 	"gorm.io/gorm"
@@ -15,6 +17,10 @@ type NotificationRepository interface {
 	GetAll() ([]*domain.Notification, error)
 	Update(notification *domain.Notification) error
 	Delete(id uint) error
+	ListByOrderIDs(orderIDs []uint) ([]*domain.Notification, error)
+	RedactByOrderIDs(orderIDs []uint, message string) (int64, error)
+	ListDeferred(now time.Time, limit int) ([]*domain.Notification, error)
+	ClaimDeferred(id uint) (bool, error)
 }
 
 // NotificationRepositoryImpl implementa NotificationRepository usando GORM
@@ -74,3 +80,51 @@ func (r *NotificationRepositoryImpl) Update(notification *domain.Notification) e
 func (r *NotificationRepositoryImpl) Delete(id uint) error {
 	return r.db.Delete(&domain.Notification{}, id).Error
 }
+
+// ListByOrderIDs busca as notificações dos pedidos, incluindo as removidas logicamente
+func (r *NotificationRepositoryImpl) ListByOrderIDs(orderIDs []uint) ([]*domain.Notification, error) {
+	var notifications []*domain.Notification
+	err := r.db.Unscoped().Where("order_id IN ?", orderIDs).Order("created_at").Find(&notifications).Error
+	return notifications, err
+}
+
+// RedactByOrderIDs substitui o conteúdo das notificações dos pedidos, incluindo as removidas
+// logicamente. As entregas ainda adiadas são canceladas, para não enviar o conteúdo removido.
+func (r *NotificationRepositoryImpl) RedactByOrderIDs(orderIDs []uint, message string) (int64, error) {
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&domain.Notification{}).
+			Where("order_id IN ? AND status = ?", orderIDs, domain.NotificationStatusDeferred).
+			Updates(map[string]interface{}{"status": domain.NotificationStatusSuppressed, "deliver_after": nil}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&domain.Notification{}).
+			Where("order_id IN ?", orderIDs).
+			Updates(map[string]interface{}{"message": message, "subject": "", "html": ""})
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
+}
+
+// ListDeferred busca as notificações adiadas cuja entrega já pode ser feita, das mais antigas
+func (r *NotificationRepositoryImpl) ListDeferred(now time.Time, limit int) ([]*domain.Notification, error) {
+	var notifications []*domain.Notification
+	err := r.db.
+		Where("status = ? AND deliver_after <= ?", domain.NotificationStatusDeferred, now).
+		Order("deliver_after, id").
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// ClaimDeferred marca a notificação adiada como em entrega (PENDING). Retorna falso se
+// ela já não estava adiada, por exemplo porque outra instância do serviço a pegou.
+func (r *NotificationRepositoryImpl) ClaimDeferred(id uint) (bool, error) {
+	result := r.db.Model(&domain.Notification{}).
+		Where("id = ? AND status = ?", id, domain.NotificationStatusDeferred).
+		Update("status", domain.NotificationStatusPending)
+	return result.RowsAffected == 1, result.Error
+}
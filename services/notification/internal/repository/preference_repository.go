@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"notification-service/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// PreferenceRepository define os métodos de acesso às preferências de notificação
+type PreferenceRepository interface {
+	GetByUserID(userID uint) (*domain.NotificationPreference, error)
+	Save(preference *domain.NotificationPreference) error
+}
+
+// PreferenceRepositoryImpl implementa PreferenceRepository usando GORM
+type PreferenceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPreferenceRepository cria uma nova instância do repositório de preferências
+func NewPreferenceRepository(db *gorm.DB) PreferenceRepository {
+	return &PreferenceRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetByUserID busca as preferências do usuário; gorm.ErrRecordNotFound quando ele nunca
+// as definiu
+func (r *PreferenceRepositoryImpl) GetByUserID(userID uint) (*domain.NotificationPreference, error) {
+	var preference domain.NotificationPreference
+	if err := r.db.First(&preference, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// Save grava as preferências, criando o registro na primeira vez
+func (r *PreferenceRepositoryImpl) Save(preference *domain.NotificationPreference) error {
+	return r.db.Save(preference).Error
+}
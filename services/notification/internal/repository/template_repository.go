@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"notification-service/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TemplateRepository define os métodos de acesso às versões dos modelos de notificação
+type TemplateRepository interface {
+	CreateVersion(template *domain.NotificationTemplate) error
+	Activate(event, locale string, version int) (*domain.NotificationTemplate, error)
+	GetVersion(event, locale string, version int) (*domain.NotificationTemplate, error)
+	List(event, locale string) ([]*domain.NotificationTemplate, error)
+	ListActive() ([]*domain.NotificationTemplate, error)
+}
+
+// TemplateRepositoryImpl implementa TemplateRepository usando GORM
+type TemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository cria uma nova instância do repositório de modelos
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &TemplateRepositoryImpl{
+		db: db,
+	}
+}
+
+// CreateVersion grava o modelo como a próxima versão do evento e localidade e a deixa
+// ativa no lugar da anterior
+func (r *TemplateRepositoryImpl) CreateVersion(template *domain.NotificationTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest domain.NotificationTemplate
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event = ? AND locale = ?", template.Event, template.Locale).
+			Order("version DESC").Limit(1).Find(&latest).Error
+		if err != nil {
+			return err
+		}
+
+		if err := deactivate(tx, template.Event, template.Locale); err != nil {
+			return err
+		}
+		template.Version = latest.Version + 1
+		template.Active = true
+		return tx.Create(template).Error
+	})
+}
+
+// Activate deixa ativa uma versão já gravada
+func (r *TemplateRepositoryImpl) Activate(event, locale string, version int) (*domain.NotificationTemplate, error) {
+	var template domain.NotificationTemplate
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&template, "event = ? AND locale = ? AND version = ?", event, locale, version).Error
+		if err != nil {
+			return err
+		}
+		if err := deactivate(tx, event, locale); err != nil {
+			return err
+		}
+		template.Active = true
+		return tx.Model(&template).Update("active", true).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// deactivate desativa as versões do evento e localidade
+func deactivate(tx *gorm.DB, event, locale string) error {
+	return tx.Model(&domain.NotificationTemplate{}).
+		Where("event = ? AND locale = ? AND active = ?", event, locale, true).
+		Update("active", false).Error
+}
+
+// GetVersion busca uma versão do modelo do evento e localidade
+func (r *TemplateRepositoryImpl) GetVersion(event, locale string, version int) (*domain.NotificationTemplate, error) {
+	var template domain.NotificationTemplate
+	err := r.db.First(&template, "event = ? AND locale = ? AND version = ?", event, locale, version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// List busca as versões gravadas, das mais novas para as mais antigas; event e locale
+// vazios não filtram
+func (r *TemplateRepositoryImpl) List(event, locale string) ([]*domain.NotificationTemplate, error) {
+	query := r.db.Model(&domain.NotificationTemplate{})
+	if event != "" {
+		query = query.Where("event = ?", event)
+	}
+	if locale != "" {
+		query = query.Where("locale = ?", locale)
+	}
+
+	var templates []*domain.NotificationTemplate
+	err := query.Order("event").Order("locale").Order("version DESC").Find(&templates).Error
+	return templates, err
+}
+
+// ListActive busca as versões ativas de todos os eventos e localidades
+func (r *TemplateRepositoryImpl) ListActive() ([]*domain.NotificationTemplate, error) {
+	var templates []*domain.NotificationTemplate
+	err := r.db.Where("active = ?", true).Find(&templates).Error
+	return templates, err
+}
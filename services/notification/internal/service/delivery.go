@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"notification-service/internal/channel"
+	"notification-service/internal/domain"
+	"notification-service/internal/templates"
+)
+
+// Delivery é a entrega das notificações: os canais, o máximo de tentativas em cada
+// canal, o intervalo entre elas (multiplicado pela tentativa) e o prazo de cada envio
+type Delivery struct {
+	Channels []channel.Channel
+	Attempts int
+	Backoff  time.Duration
+	Timeout  time.Duration
+}
+
+// deliver envia a notificação por todos os canais, registra cada tentativa e grava o
+// status final: SENT quando algum canal entregou, SUPPRESSED quando as preferências do
+// destinatário barraram todos os canais e FAILED quando nenhum entregou. Os canais
+// barrados ficam registrados como entregas suprimidas, com o motivo. Dentro do horário
+// de silêncio do destinatário a entrega é adiada (DEFERRED) até o fim do período.
+func (s *NotificationServiceImpl) deliver(notification *domain.Notification, out outgoing) {
+	if len(s.delivery.Channels) == 0 {
+		return
+	}
+
+	// Motivo de cada canal não entregar, na ordem dos canais; vazio quando permitido
+	reasons := make([]string, len(s.delivery.Channels))
+	allowed := 0
+	for i, ch := range s.delivery.Channels {
+		reasons[i] = out.preference.Suppression(out.topic, ch.Name())
+		if reasons[i] == "" {
+			allowed++
+		}
+	}
+	if allowed == 0 {
+		for i, ch := range s.delivery.Channels {
+			s.recordSuppressed(notification.ID, ch.Name(), domain.DeliveryStatusSuppressed, reasons[i])
+		}
+		s.finish(notification, domain.NotificationStatusSuppressed)
+		return
+	}
+
+	if until, quiet := s.quietUntil(out.preference); quiet {
+		s.deferDelivery(notification, out, reasons, until)
+		return
+	}
+
+	message := channel.Message{
+		NotificationID: notification.ID,
+		OrderID:        notification.OrderID,
+		PaymentID:      notification.PaymentID,
+		Event:          out.event,
+		Subject:        out.rendered.Subject,
+		Text:           notification.Message,
+		HTML:           out.rendered.HTML,
+		Locale:         out.rendered.Locale,
+		CreatedAt:      notification.CreatedAt,
+		Recipient:      out.recipient,
+	}
+	if out.recipient != nil && s.recipients.Signer != nil && s.recipients.UnsubscribeURL != "" {
+		message.UnsubscribeURL = s.recipients.Signer.URL(s.recipients.UnsubscribeURL, out.recipient.UserID)
+	}
+
+	delivered := false
+	for i, ch := range s.delivery.Channels {
+		if reasons[i] != "" {
+			s.recordSuppressed(notification.ID, ch.Name(), domain.DeliveryStatusSuppressed, reasons[i])
+			continue
+		}
+		if s.sendWithRetry(ch, message) {
+			delivered = true
+		}
+	}
+
+	if delivered {
+		s.finish(notification, domain.NotificationStatusSent)
+	} else {
+		s.finish(notification, domain.NotificationStatusFailed)
+	}
+}
+
+// quietUntil indica se a entrega cai no horário de silêncio do destinatário e até quando.
+// Um fuso desconhecido, só possível em preferências gravadas antes da validação, é
+// tratado como UTC e registrado no log.
+func (s *NotificationServiceImpl) quietUntil(preference *domain.NotificationPreference) (time.Time, bool) {
+	if preference == nil {
+		return time.Time{}, false
+	}
+	location, err := preference.Location()
+	if err != nil {
+		log.Printf("⚠️ Preferências do usuário %d com %v; horário de silêncio avaliado em UTC", preference.UserID, err)
+		location = time.UTC
+	}
+	return preference.QuietUntil(s.now(), location)
+}
+
+// deferDelivery guarda a mensagem montada e adia a entrega até o fim do horário de
+// silêncio; os canais que receberão a notificação ficam registrados como adiados
+func (s *NotificationServiceImpl) deferDelivery(notification *domain.Notification, out outgoing, reasons []string, until time.Time) {
+	log.Printf("🌙 Notificação %d adiada até %s: horário de silêncio do destinatário", notification.ID, until.Format(time.RFC3339))
+	for i, ch := range s.delivery.Channels {
+		if reasons[i] != "" {
+			s.recordSuppressed(notification.ID, ch.Name(), domain.DeliveryStatusSuppressed, reasons[i])
+		} else {
+			s.recordSuppressed(notification.ID, ch.Name(), domain.DeliveryStatusDeferred, domain.DeferredQuietHours)
+		}
+	}
+
+	until = until.UTC()
+	notification.DeliverAfter = &until
+	notification.Subject = out.rendered.Subject
+	notification.HTML = out.rendered.HTML
+	notification.Locale = out.rendered.Locale
+	s.finish(notification, domain.NotificationStatusDeferred)
+}
+
+// finish grava o status final da entrega. Fora do estado adiado a cópia da mensagem
+// montada não é mais necessária e é descartada.
+func (s *NotificationServiceImpl) finish(notification *domain.Notification, status string) {
+	notification.Status = status
+	if status != domain.NotificationStatusDeferred {
+		notification.DeliverAfter = nil
+		notification.Subject, notification.HTML, notification.Locale = "", "", ""
+	}
+	notification.UpdatedAt = time.Now()
+	if err := s.repo.Update(notification); err != nil {
+		log.Printf("❌ Erro ao atualizar status da notificação %d: %v", notification.ID, err)
+	}
+}
+
+// deferredBatch é o máximo de notificações adiadas entregues a cada rodada
+const deferredBatch = 100
+
+// DispatchDeferred entrega as notificações adiadas cujo horário de silêncio terminou.
+// Destinatário e preferências são lidos de novo: um cancelamento de inscrição ou canal
+// desligado nesse meio tempo vale para a entrega, e um novo horário de silêncio a adia
+// outra vez. Uma notificação que falha volta a aguardar a próxima rodada sem segurar as
+// demais; os erros são devolvidos juntos. Retorna quantas notificações foram processadas.
+func (s *NotificationServiceImpl) DispatchDeferred(now time.Time) (int, error) {
+	notifications, err := s.repo.ListDeferred(now, deferredBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	var errs []error
+	for _, notification := range notifications {
+		claimed, err := s.repo.ClaimDeferred(notification.ID)
+		if err != nil {
+			log.Printf("❌ Erro ao reservar a notificação adiada %d: %v", notification.ID, err)
+			errs = append(errs, fmt.Errorf("notificação %d: %w", notification.ID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		recipient, preference, err := s.resolveRecipient(notification.UserID)
+		if err != nil {
+			// Sem as preferências a notificação volta a aguardar a próxima rodada
+			log.Printf("❌ Notificação adiada %d volta a aguardar: %v", notification.ID, err)
+			s.finish(notification, domain.NotificationStatusDeferred)
+			errs = append(errs, fmt.Errorf("notificação %d: %w", notification.ID, err))
+			continue
+		}
+
+		notification.Status = domain.NotificationStatusPending
+		s.deliver(notification, outgoing{
+			event: notification.Event,
+			topic: notification.Topic,
+			rendered: templates.Rendered{
+				Event:   notification.Topic,
+				Locale:  notification.Locale,
+				Subject: notification.Subject,
+				Text:    notification.Message,
+				HTML:    notification.HTML,
+			},
+			recipient:  recipient,
+			preference: preference,
+		})
+		processed++
+	}
+	return processed, errors.Join(errs...)
+}
+
+// RunDeferredDispatcher entrega as notificações adiadas periodicamente até o contexto
+// ser cancelado
+func (s *NotificationServiceImpl) RunDeferredDispatcher(ctx context.Context, interval time.Duration) {
+	log.Printf("🌙 Entrega de notificações adiadas iniciada (intervalo %s)", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Entrega de notificações adiadas finalizada")
+			return
+		case <-ticker.C:
+			count, err := s.DispatchDeferred(s.now())
+			if err != nil {
+				log.Printf("⚠️ Erro ao entregar notificações adiadas: %v", err)
+			}
+			if count > 0 {
+				log.Printf("📬 %d notificações adiadas processadas", count)
+			}
+		}
+	}
+}
+
+// recordSuppressed registra a entrega não feita agora por causa das preferências:
+// suprimida ou adiada, conforme status
+func (s *NotificationServiceImpl) recordSuppressed(notificationID uint, name, status, reason string) {
+	if status == domain.DeliveryStatusSuppressed {
+		log.Printf("🔕 Notificação %d não enviada por %s: %s", notificationID, name, reason)
+	}
+	record := &domain.DeliveryAttempt{
+		NotificationID: notificationID,
+		Channel:        name,
+		Status:         status,
+		Reason:         reason,
+	}
+	if err := s.deliveries.Create(record); err != nil {
+		log.Printf("❌ Erro ao registrar entrega %s da notificação %d: %v", status, notificationID, err)
+	}
+}
+
+// sendWithRetry tenta entregar a mensagem pelo canal até o máximo de tentativas
+func (s *NotificationServiceImpl) sendWithRetry(ch channel.Channel, message channel.Message) bool {
+	attempts := max(s.delivery.Attempts, 1)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			s.sleep(time.Duration(attempt-1) * s.delivery.Backoff)
+		}
+
+		ctx := context.Background()
+		cancel := func() {}
+		if s.delivery.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, s.delivery.Timeout)
+		}
+		started := time.Now()
+		response, err := ch.Send(ctx, message)
+		cancel()
+
+		record := &domain.DeliveryAttempt{
+			NotificationID: message.NotificationID,
+			Channel:        ch.Name(),
+			Attempt:        attempt,
+			Status:         domain.DeliveryStatusSent,
+			Response:       truncate(response, 500),
+			DurationMs:     time.Since(started).Milliseconds(),
+		}
+		if err != nil {
+			record.Status = domain.DeliveryStatusFailed
+			record.Error = truncate(err.Error(), 500)
+		}
+		if err := s.deliveries.Create(record); err != nil {
+			log.Printf("❌ Erro ao registrar tentativa de entrega da notificação %d: %v", message.NotificationID, err)
+		}
+
+		if err == nil {
+			log.Printf("📨 Notificação %d entregue por %s (tentativa %d)", message.NotificationID, ch.Name(), attempt)
+			return true
+		}
+		log.Printf("⚠️ Falha ao entregar notificação %d por %s (tentativa %d/%d): %v", message.NotificationID, ch.Name(), attempt, attempts, err)
+	}
+	return false
+}
+
+// GetDeliveries retorna as tentativas de entrega de uma notificação
+func (s *NotificationServiceImpl) GetDeliveries(notificationID uint) ([]*domain.DeliveryAttempt, error) {
+	return s.deliveries.ListByNotificationID(notificationID)
+}
+
+// truncate corta o texto em até size bytes, sem quebrar caracteres
+func truncate(text string, size int) string {
+	if len(text) <= size {
+		return text
+	}
+	return strings.ToValidUTF8(text[:size], "")
+}
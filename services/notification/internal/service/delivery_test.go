@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"notification-service/internal/channel"
+	"notification-service/internal/clients"
+	"notification-service/internal/domain"
+	"notification-service/internal/repository"
+	"notification-service/internal/unsubscribe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryNotificationRepository guarda as notificações em memória; só os métodos usados
+// na entrega são implementados
+type memoryNotificationRepository struct {
+	repository.NotificationRepository
+	notifications []*domain.Notification
+	claimErrs     map[uint]error
+}
+
+func (r *memoryNotificationRepository) Create(notification *domain.Notification) error {
+	notification.ID = uint(len(r.notifications) + 1)
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+func (r *memoryNotificationRepository) Update(notification *domain.Notification) error {
+	return nil
+}
+
+func (r *memoryNotificationRepository) ListDeferred(now time.Time, limit int) ([]*domain.Notification, error) {
+	var due []*domain.Notification
+	for _, notification := range r.notifications {
+		if notification.Status == domain.NotificationStatusDeferred && !notification.DeliverAfter.After(now) && len(due) < limit {
+			due = append(due, notification)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryNotificationRepository) ClaimDeferred(id uint) (bool, error) {
+	if err := r.claimErrs[id]; err != nil {
+		return false, err
+	}
+	notification := r.notifications[id-1]
+	if notification.Status != domain.NotificationStatusDeferred {
+		return false, nil
+	}
+	notification.Status = domain.NotificationStatusPending
+	return true, nil
+}
+
+// memoryDeliveryRepository guarda as tentativas de entrega em memória
+type memoryDeliveryRepository struct {
+	attempts []*domain.DeliveryAttempt
+}
+
+func (r *memoryDeliveryRepository) Create(attempt *domain.DeliveryAttempt) error {
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *memoryDeliveryRepository) ListByNotificationID(notificationID uint) ([]*domain.DeliveryAttempt, error) {
+	return r.attempts, nil
+}
+
+// flakyChannel falha nas primeiras failures tentativas e guarda as mensagens recebidas
+type flakyChannel struct {
+	name     string
+	failures int
+	messages []channel.Message
+}
+
+func (c *flakyChannel) Name() string { return c.name }
+
+func (c *flakyChannel) Send(ctx context.Context, message channel.Message) (string, error) {
+	c.messages = append(c.messages, message)
+	if len(c.messages) <= c.failures {
+		return "503 Service Unavailable", errors.New("provedor respondeu 503 Service Unavailable")
+	}
+	return "200 OK", nil
+}
+
+func newDeliveryFixture(t *testing.T, channels ...channel.Channel) (*NotificationServiceImpl, *memoryNotificationRepository, *memoryDeliveryRepository) {
+	notifications := &memoryNotificationRepository{}
+	deliveries := &memoryDeliveryRepository{}
+	svc := NewNotificationService(notifications, deliveries, Delivery{Channels: channels, Attempts: 3, Backoff: time.Second}, Recipients{}, newTemplateFixture(t).svc, "pt-BR").(*NotificationServiceImpl)
+	svc.sleep = func(time.Duration) {}
+	return svc, notifications, deliveries
+}
+
+func TestDeliver_Retry(t *testing.T) {
+	slack := &flakyChannel{name: channel.Slack, failures: 1}
+	sms := &flakyChannel{name: channel.SMS, failures: 5}
+	svc, notifications, deliveries := newDeliveryFixture(t, slack, sms)
+
+	require.NoError(t, svc.ProcessPaymentNotification(3, 42, 0, "APPROVED", money.New(19990, "BRL"), money.Money{}))
+
+	require.Len(t, notifications.notifications, 1)
+	notification := notifications.notifications[0]
+	assert.Equal(t, domain.NotificationStatusSent, notification.Status, "um canal entregou")
+
+	require.Len(t, slack.messages, 2)
+	assert.Equal(t, "payment.approved", slack.messages[1].Event)
+	assert.Equal(t, "Pagamento do pedido 42", slack.messages[1].Subject)
+	assert.Equal(t, notification.Message, slack.messages[1].Text)
+	assert.Equal(t, "💬 Notificação: Pagamento aprovado para o pedido 42 no valor de R$ 199,90", notification.Message)
+	assert.Contains(t, slack.messages[1].HTML, "<strong>aprovado</strong>")
+	assert.Len(t, sms.messages, 3, "no máximo três tentativas por canal")
+
+	require.Len(t, deliveries.attempts, 5)
+	assert.Equal(t, domain.DeliveryStatusFailed, deliveries.attempts[0].Status)
+	assert.Equal(t, "503 Service Unavailable", deliveries.attempts[0].Response)
+	assert.Equal(t, domain.DeliveryStatusSent, deliveries.attempts[1].Status)
+	assert.Equal(t, 2, deliveries.attempts[1].Attempt)
+	assert.Equal(t, channel.SMS, deliveries.attempts[4].Channel)
+	assert.Equal(t, 3, deliveries.attempts[4].Attempt)
+	assert.NotEmpty(t, deliveries.attempts[4].Error)
+}
+
+func TestDeliver_AllFailed(t *testing.T) {
+	webhook := &flakyChannel{name: channel.Webhook, failures: 5}
+	svc, notifications, _ := newDeliveryFixture(t, webhook)
+
+	require.NoError(t, svc.ProcessDisputeNotification(3, 42, 0, DisputeNotice{Reference: "CB-1", Status: "lost", Amount: money.New(19990, "BRL")}))
+
+	assert.Equal(t, domain.NotificationStatusFailed, notifications.notifications[0].Status)
+	assert.Equal(t, EventPaymentDisputed, webhook.messages[0].Event)
+	assert.Equal(t, "Contestação do pedido 42", webhook.messages[0].Subject)
+	assert.Equal(t, "💸 Notificação: Contestação CB-1 do pedido 42 perdida - R$ 199,90 estornado ao cliente", webhook.messages[0].Text)
+}
+
+func TestDeliver_Preferences(t *testing.T) {
+	smtp := &flakyChannel{name: channel.SMTP}
+	sms := &flakyChannel{name: channel.SMS}
+	svc, notifications, deliveries := newDeliveryFixture(t, smtp, sms)
+	preferences := newMemoryPreferenceRepository(&domain.NotificationPreference{
+		UserID:   7,
+		Locale:   money.LocaleEnUS,
+		Channels: domain.EventChannels{"payment.*": {channel.SMTP}},
+	})
+	signer, err := unsubscribe.NewSigner("segredo")
+	require.NoError(t, err)
+	svc.recipients = Recipients{
+		Users:          &stubUserClient{users: map[uint]*clients.User{7: {ID: 7, Name: "Maria", Email: "maria@example.com"}}},
+		Preferences:    preferences,
+		Signer:         signer,
+		UnsubscribeURL: "https://loja.example/unsubscribe",
+	}
+
+	require.NoError(t, svc.ProcessPaymentNotification(3, 42, 7, "APPROVED", money.New(19990, "BRL"), money.Money{}))
+
+	notification := notifications.notifications[0]
+	assert.Equal(t, uint(7), notification.UserID)
+	assert.Equal(t, domain.NotificationStatusSent, notification.Status)
+	require.Len(t, smtp.messages, 1)
+	assert.Equal(t, "Payment for order 42", smtp.messages[0].Subject, "no idioma do usuário")
+	assert.Equal(t, "maria@example.com", smtp.messages[0].Recipient.Email)
+	assert.Equal(t, signer.URL("https://loja.example/unsubscribe", 7), smtp.messages[0].UnsubscribeURL)
+	assert.Empty(t, sms.messages)
+
+	require.Len(t, deliveries.attempts, 2)
+	assert.Equal(t, channel.SMS, deliveries.attempts[1].Channel)
+	assert.Equal(t, domain.DeliveryStatusSuppressed, deliveries.attempts[1].Status)
+	assert.Equal(t, domain.SuppressedChannelDisabled, deliveries.attempts[1].Reason)
+}
+
+func TestDeliver_Unsubscribed(t *testing.T) {
+	webhook := &flakyChannel{name: channel.Webhook}
+	svc, notifications, deliveries := newDeliveryFixture(t, webhook)
+	svc.recipients = Recipients{
+		Users:       &stubUserClient{},
+		Preferences: newMemoryPreferenceRepository(&domain.NotificationPreference{UserID: 7, Unsubscribed: true}),
+	}
+
+	require.NoError(t, svc.ProcessDisputeNotification(3, 42, 7, DisputeNotice{Reference: "CB-1", Status: "won", Amount: money.New(19990, "BRL")}))
+
+	assert.Equal(t, domain.NotificationStatusSuppressed, notifications.notifications[0].Status)
+	assert.Empty(t, webhook.messages)
+	require.Len(t, deliveries.attempts, 1)
+	assert.Equal(t, domain.SuppressedUnsubscribed, deliveries.attempts[0].Reason)
+}
+
+// quietHoursFixture prepara um destinatário com silêncio das 22:00 às 07:00 em São Paulo
+// e o relógio às 02:30 de lá
+func quietHoursFixture(t *testing.T) (*NotificationServiceImpl, *memoryNotificationRepository, *memoryDeliveryRepository, *flakyChannel, *memoryPreferenceRepository) {
+	t.Helper()
+	webhook := &flakyChannel{name: channel.Webhook}
+	svc, notifications, deliveries := newDeliveryFixture(t, webhook)
+	preferences := newMemoryPreferenceRepository(&domain.NotificationPreference{
+		UserID:     7,
+		Timezone:   "America/Sao_Paulo",
+		QuietStart: "22:00",
+		QuietEnd:   "07:00",
+	})
+	svc.recipients = Recipients{Preferences: preferences}
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 5, 30, 0, 0, time.UTC) }
+	return svc, notifications, deliveries, webhook, preferences
+}
+
+func TestDeliver_QuietHours(t *testing.T) {
+	svc, notifications, deliveries, webhook, _ := quietHoursFixture(t)
+
+	require.NoError(t, svc.ProcessPaymentNotification(3, 42, 7, "APPROVED", money.New(19990, "BRL"), money.Money{}))
+
+	deferred := notifications.notifications[0]
+	assert.Equal(t, domain.NotificationStatusDeferred, deferred.Status)
+	require.NotNil(t, deferred.DeliverAfter)
+	assert.Equal(t, time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC), *deferred.DeliverAfter, "07:00 em São Paulo")
+	assert.Empty(t, webhook.messages)
+	require.Len(t, deliveries.attempts, 1)
+	assert.Equal(t, domain.DeliveryStatusDeferred, deliveries.attempts[0].Status)
+	assert.Equal(t, domain.DeferredQuietHours, deliveries.attempts[0].Reason)
+
+	// Antes do fim do silêncio nada é entregue
+	count, err := svc.DispatchDeferred(time.Date(2025, 3, 10, 9, 59, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, webhook.messages)
+
+	// 07:00 em São Paulo: a mensagem guardada sai com o mesmo conteúdo
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC) }
+	count, err = svc.DispatchDeferred(svc.now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, domain.NotificationStatusSent, deferred.Status)
+	assert.Nil(t, deferred.DeliverAfter)
+	assert.Empty(t, deferred.HTML)
+	require.Len(t, webhook.messages, 1)
+	assert.Equal(t, deferred.Message, webhook.messages[0].Text)
+	assert.Equal(t, "payment.approved", webhook.messages[0].Event)
+	require.NotNil(t, webhook.messages[0].Recipient)
+	assert.Equal(t, uint(7), webhook.messages[0].Recipient.UserID)
+	assert.Empty(t, webhook.messages[0].Recipient.Email, "sem user-service, vai sem o e-mail")
+
+	// Já entregue, não sai de novo
+	count, err = svc.DispatchDeferred(svc.now())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Len(t, webhook.messages, 1)
+}
+
+func TestDeliver_OutsideQuietHours(t *testing.T) {
+	svc, notifications, _, webhook, _ := quietHoursFixture(t)
+	// 12:00 em São Paulo
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, svc.ProcessPaymentNotification(3, 42, 7, "APPROVED", money.New(19990, "BRL"), money.Money{}))
+
+	assert.Equal(t, domain.NotificationStatusSent, notifications.notifications[0].Status)
+	assert.Nil(t, notifications.notifications[0].DeliverAfter)
+	assert.Len(t, webhook.messages, 1)
+}
+
+func TestDispatchDeferred_UsesCurrentPreferences(t *testing.T) {
+	svc, notifications, deliveries, webhook, preferences := quietHoursFixture(t)
+	require.NoError(t, svc.ProcessPaymentNotification(3, 42, 7, "APPROVED", money.New(19990, "BRL"), money.Money{}))
+	require.Equal(t, domain.NotificationStatusDeferred, notifications.notifications[0].Status)
+
+	// O usuário cancela a inscrição enquanto a notificação aguarda
+	preferences.preferences[7].Unsubscribed = true
+
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC) }
+	count, err := svc.DispatchDeferred(svc.now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.Equal(t, domain.NotificationStatusSuppressed, notifications.notifications[0].Status)
+	assert.Empty(t, webhook.messages)
+	require.Len(t, deliveries.attempts, 2)
+	assert.Equal(t, domain.SuppressedUnsubscribed, deliveries.attempts[1].Reason)
+}
+
+// failingPreferenceRepository falha ao ler as preferências dos usuários em failing
+type failingPreferenceRepository struct {
+	*memoryPreferenceRepository
+	failing map[uint]bool
+}
+
+func (r *failingPreferenceRepository) GetByUserID(userID uint) (*domain.NotificationPreference, error) {
+	if r.failing[userID] {
+		return nil, errors.New("banco indisponível")
+	}
+	return r.memoryPreferenceRepository.GetByUserID(userID)
+}
+
+func TestDispatchDeferred_FailuresDoNotHoldTheBatch(t *testing.T) {
+	svc, notifications, _, webhook, preferences := quietHoursFixture(t)
+	for _, userID := range []uint{8, 9} {
+		copied := *preferences.preferences[7]
+		copied.UserID = userID
+		preferences.preferences[userID] = &copied
+	}
+	for _, userID := range []uint{7, 8, 9} {
+		require.NoError(t, svc.ProcessPaymentNotification(3, 42, userID, "APPROVED", money.New(19990, "BRL"), money.Money{}))
+	}
+
+	// A notificação 1 não pode ser reservada e as preferências do usuário 8 estão fora do ar
+	notifications.claimErrs = map[uint]error{1: errors.New("deadlock")}
+	svc.recipients.Preferences = &failingPreferenceRepository{memoryPreferenceRepository: preferences, failing: map[uint]bool{8: true}}
+
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC) }
+	count, err := svc.DispatchDeferred(svc.now())
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "deadlock")
+	assert.ErrorContains(t, err, "banco indisponível")
+	assert.Equal(t, 1, count)
+	require.Len(t, webhook.messages, 1)
+	assert.Equal(t, uint(9), webhook.messages[0].Recipient.UserID)
+	assert.Equal(t, domain.NotificationStatusDeferred, notifications.notifications[0].Status)
+	assert.Equal(t, domain.NotificationStatusDeferred, notifications.notifications[1].Status)
+	assert.NotNil(t, notifications.notifications[1].DeliverAfter, "segue aguardando a próxima rodada")
+	assert.Equal(t, domain.NotificationStatusSent, notifications.notifications[2].Status)
+}
@@ -1,77 +1,214 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"notification-service/internal/channel"
 	"notification-service/internal/domain"
 	"notification-service/internal/repository"
+	"notification-service/internal/templates"
+
+	"gorm.io/gorm"
 )
 
 // NotificationService define os métodos de negócio para notificações
 type NotificationService interface {
-	ProcessPaymentNotification(paymentID uint, orderID uint, status string, amount float64) error
+	ProcessPaymentNotification(paymentID uint, orderID uint, userID uint, status string, amount, orderAmount money.Money) error
+	ProcessDisputeNotification(paymentID uint, orderID uint, userID uint, dispute DisputeNotice) error
 	GetAllNotifications() ([]*domain.Notification, error)
 	GetNotificationsByOrderID(orderID uint) ([]*domain.Notification, error)
 	GetNotificationsByPaymentID(paymentID uint) ([]*domain.Notification, error)
+	GetNotificationsByOrderIDs(orderIDs []uint) ([]*domain.Notification, error)
+	GetDeliveries(notificationID uint) ([]*domain.DeliveryAttempt, error)
+	RedactNotifications(orderIDs []uint) (int64, error)
+	DispatchDeferred(now time.Time) (int, error)
+	RunDeferredDispatcher(ctx context.Context, interval time.Duration)
 }
 
 // NotificationServiceImpl implementa NotificationService
 type NotificationServiceImpl struct {
-	repo repository.NotificationRepository
+	repo       repository.NotificationRepository
+	deliveries repository.DeliveryRepository
+	delivery   Delivery
+	recipients Recipients
+	templates  TemplateService
+	locale     string
+	sleep      func(time.Duration)
+	now        func() time.Time
 }
 
-// NewNotificationService cria uma nova instância do service
-func NewNotificationService(repo repository.NotificationRepository) NotificationService {
+// NewNotificationService cria uma nova instância do service. delivery define os canais
+// de entrega, recipients como resolver o destinatário e as preferências dele, templates
+// os modelos das mensagens e locale a localidade padrão delas (pt-BR, en-US ou es).
+func NewNotificationService(repo repository.NotificationRepository, deliveries repository.DeliveryRepository, delivery Delivery, recipients Recipients, templateService TemplateService, locale string) NotificationService {
 	return &NotificationServiceImpl{
-		repo: repo,
+		repo:       repo,
+		deliveries: deliveries,
+		delivery:   delivery,
+		recipients: recipients,
+		templates:  templateService,
+		locale:     money.NormalizeLocale(locale),
+		sleep:      time.Sleep,
+		now:        time.Now,
+	}
+}
+
+// outgoing é uma notificação montada, pronta para gravar e entregar. event é o evento
+// enviado aos canais e topic o usado nas preferências (payment.approved, dispute.won...).
+type outgoing struct {
+	event      string
+	topic      string
+	rendered   templates.Rendered
+	recipient  *channel.Recipient
+	preference *domain.NotificationPreference
+}
+
+// ProcessPaymentNotification processa uma notificação de pagamento. userID é o dono do
+// pedido (zero se desconhecido); amount é o valor cobrado e orderAmount o total na
+// moeda do pedido, usada na mensagem.
+func (s *NotificationServiceImpl) ProcessPaymentNotification(paymentID uint, orderID uint, userID uint, status string, amount, orderAmount money.Money) error {
+	recipient, preference, err := s.resolveRecipient(userID)
+	if err != nil {
+		return err
+	}
+	if orderAmount.IsZero() {
+		orderAmount = amount
+	}
+
+	event := "payment." + strings.ToLower(status)
+	rendered, err := s.templates.Render(event, s.localeFor(preference), templates.Data{
+		OrderID:     orderID,
+		PaymentID:   paymentID,
+		Status:      status,
+		Amount:      amount,
+		OrderAmount: orderAmount,
+	})
+	if err != nil {
+		log.Printf("❌ Erro ao montar notificação %s do pedido %d: %v", event, orderID, err)
+		return fmt.Errorf("falha ao montar notificação: %w", err)
+	}
+	log.Printf("%s", rendered.Text)
+
+	return s.save(paymentID, orderID, outgoing{event: event, topic: event, rendered: rendered, recipient: recipient, preference: preference})
+}
+
+// EventPaymentDisputed é o evento das notificações de contestação
+const EventPaymentDisputed = "payment.disputed"
+
+// DisputeNotice é a contestação (chargeback) de um evento payment.disputed. Status é
+// opened, evidence_submitted, won ou lost; EvidenceDueAt em RFC 3339
+type DisputeNotice struct {
+	Reference     string
+	Reason        string
+	Status        string
+	Amount        money.Money
+	EvidenceDueAt string
+}
+
+// ProcessDisputeNotification processa a notificação de uma mudança na contestação de um
+// pagamento. O modelo e as preferências são os do status (dispute.opened, dispute.won...).
+func (s *NotificationServiceImpl) ProcessDisputeNotification(paymentID uint, orderID uint, userID uint, dispute DisputeNotice) error {
+	recipient, preference, err := s.resolveRecipient(userID)
+	if err != nil {
+		return err
+	}
+
+	data := templates.Data{
+		OrderID:     orderID,
+		PaymentID:   paymentID,
+		Amount:      dispute.Amount,
+		OrderAmount: dispute.Amount,
+		Dispute: &templates.Dispute{
+			Reference: dispute.Reference,
+			Reason:    dispute.Reason,
+			Status:    dispute.Status,
+			Amount:    dispute.Amount,
+		},
+	}
+	if dueAt, err := time.Parse(time.RFC3339, dispute.EvidenceDueAt); err == nil {
+		data.Dispute.EvidenceDueAt = dueAt
+	}
+
+	topic := "dispute." + dispute.Status
+	rendered, err := s.templates.Render(topic, s.localeFor(preference), data)
+	if err != nil {
+		log.Printf("❌ Erro ao montar notificação da contestação %s: %v", dispute.Reference, err)
+		return fmt.Errorf("falha ao montar notificação: %w", err)
 	}
+	log.Printf("%s", rendered.Text)
+
+	return s.save(paymentID, orderID, outgoing{event: EventPaymentDisputed, topic: topic, rendered: rendered, recipient: recipient, preference: preference})
+}
+
+// resolveRecipient busca o usuário no user-service e as preferências dele. Sem o
+// user-service a notificação segue sem o e-mail do usuário; já as preferências são
+// obrigatórias, e a falha ao lê-las devolve a mensagem à fila.
+func (s *NotificationServiceImpl) resolveRecipient(userID uint) (*channel.Recipient, *domain.NotificationPreference, error) {
+	if userID == 0 {
+		return nil, nil, nil
+	}
+
+	var preference *domain.NotificationPreference
+	if s.recipients.Preferences != nil {
+		stored, err := s.recipients.Preferences.GetByUserID(userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("❌ Erro ao buscar preferências do usuário %d: %v", userID, err)
+			return nil, nil, fmt.Errorf("falha ao buscar preferências: %w", err)
+		}
+		preference = stored
+	}
+
+	recipient := &channel.Recipient{UserID: userID}
+	if s.recipients.Users != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), userLookupTimeout)
+		defer cancel()
+		user, err := s.recipients.Users.GetUser(ctx, userID)
+		if err != nil {
+			log.Printf("⚠️ Usuário %d não resolvido no user-service: %v", userID, err)
+		} else {
+			recipient.Name = user.Name
+			recipient.Email = user.Email
+		}
+	}
+	return recipient, preference, nil
+}
+
+// localeFor retorna o idioma preferido do destinatário ou o padrão do serviço
+func (s *NotificationServiceImpl) localeFor(preference *domain.NotificationPreference) string {
+	if preference != nil && preference.Locale != "" {
+		return preference.Locale
+	}
+	return s.locale
 }
 
-// ProcessPaymentNotification processa uma notificação de pagamento
-func (s *NotificationServiceImpl) ProcessPaymentNotification(paymentID uint, orderID uint, status string, amount float64) error {
-	// Gerar mensagem baseada no status do pagamento
-	var message string
-	var notificationStatus string
-
-	switch status {
-	case "APPROVED":
-		message = fmt.Sprintf("💬 Notificação: Pagamento aprovado para o pedido %d no valor de R$ %.2f - Enviada com sucesso!", orderID, amount)
-		notificationStatus = domain.NotificationStatusSent
-		log.Printf("✅ %s", message)
-	case "REJECTED":
-		message = fmt.Sprintf("❌ Notificação: Pagamento rejeitado para o pedido %d no valor de R$ %.2f", orderID, amount)
-		notificationStatus = domain.NotificationStatusSent
-		log.Printf("❌ %s", message)
-	default:
-		message = fmt.Sprintf("📝 Notificação: Status de pagamento '%s' para o pedido %d no valor de R$ %.2f", status, orderID, amount)
-		notificationStatus = domain.NotificationStatusSent
-		log.Printf("📝 %s", message)
-	}
-
-	// Criar notificação
+// save grava a notificação montada e a entrega pelos canais
+func (s *NotificationServiceImpl) save(paymentID, orderID uint, out outgoing) error {
 	notification := &domain.Notification{
 		PaymentID: paymentID,
 		OrderID:   orderID,
-		Message:   message,
-		Status:    notificationStatus,
+		Event:     out.event,
+		Topic:     out.topic,
+		Message:   out.rendered.Text,
+		Status:    domain.NotificationStatusPending,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-
-	// Salvar no banco de dados
-	err := s.repo.Create(notification)
-	if err != nil {
+	if out.recipient != nil {
+		notification.UserID = out.recipient.UserID
+	}
+	if err := s.repo.Create(notification); err != nil {
 		log.Printf("❌ Erro ao salvar notificação no banco: %v", err)
 		return fmt.Errorf("falha ao salvar notificação: %w", err)
 	}
 
 	log.Printf("✅ Notificação salva no banco de dados - ID: %d", notification.ID)
-
-	// Simular envio da notificação (em um cenário real, aqui seria o envio por email, SMS, etc.)
-	s.simulateNotificationSending(notification)
-
+	s.deliver(notification, out)
 	return nil
 }
 
@@ -90,11 +227,26 @@ func (s *NotificationServiceImpl) GetNotificationsByPaymentID(paymentID uint) ([
 	return s.repo.GetByPaymentID(paymentID)
 }
 
-// simulateNotificationSending simula o envio da notificação
-func (s *NotificationServiceImpl) simulateNotificationSending(notification *domain.Notification) {
-	log.Printf("📧 Simulando envio de notificação...")
-	log.Printf("📱 Canal: Email/SMS")
-	log.Printf("📋 Conteúdo: %s", notification.Message)
-	log.Printf("⏰ Enviado em: %s", notification.CreatedAt.Format("2006-01-02 15:04:05"))
-	log.Printf("📨 Notificação enviada com sucesso!")
+// GetNotificationsByOrderIDs busca as notificações de um conjunto de pedidos (exportação LGPD)
+func (s *NotificationServiceImpl) GetNotificationsByOrderIDs(orderIDs []uint) ([]*domain.Notification, error) {
+	if len(orderIDs) == 0 {
+		return []*domain.Notification{}, nil
+	}
+	return s.repo.ListByOrderIDs(orderIDs)
+}
+
+// RedactNotifications remove o conteúdo das mensagens dos pedidos, que pode conter dados pessoais
+func (s *NotificationServiceImpl) RedactNotifications(orderIDs []uint) (int64, error) {
+	if len(orderIDs) == 0 {
+		return 0, nil
+	}
+
+	affected, err := s.repo.RedactByOrderIDs(orderIDs, domain.RedactedMessage)
+	if err != nil {
+		log.Printf("❌ Erro ao anonimizar notificações: %v", err)
+		return 0, err
+	}
+
+	log.Printf("🕶️ %d notificações anonimizadas", affected)
+	return affected, nil
 }
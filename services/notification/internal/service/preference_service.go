@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"notification-service/internal/channel"
+	"notification-service/internal/clients"
+	"notification-service/internal/domain"
+	"notification-service/internal/repository"
+	"notification-service/internal/templates"
+	"notification-service/internal/unsubscribe"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Erros das preferências de notificação
+var (
+	ErrInvalidPreferences  = errors.New("preferências de notificação inválidas")
+	ErrUserNotFound        = errors.New("usuário não encontrado")
+	ErrUnsubscribeDisabled = errors.New("cancelamento de inscrição em um clique desabilitado")
+)
+
+// userLookupTimeout é o prazo da consulta do destinatário no user-service
+const userLookupTimeout = 5 * time.Second
+
+// Recipients resolve os destinatários: o usuário no user-service, as preferências dele e
+// o link de cancelamento de inscrição (UnsubscribeURL com o token do Signer). Sem
+// Signer ou UnsubscribeURL os e-mails vão sem o link.
+type Recipients struct {
+	Users          clients.UserClient
+	Preferences    repository.PreferenceRepository
+	Signer         *unsubscribe.Signer
+	UnsubscribeURL string
+}
+
+// PreferenceService define os métodos das preferências de notificação dos usuários
+type PreferenceService interface {
+	GetPreferences(userID uint) (*domain.NotificationPreference, error)
+	UpdatePreferences(userID uint, input PreferencesInput) (*domain.NotificationPreference, error)
+	Unsubscribe(token string) (*domain.NotificationPreference, error)
+}
+
+// PreferencesInput são as preferências completas de um usuário, que substituem as
+// anteriores. Channels lista os canais aceitos por evento (payment.approved), família
+// (payment.*, dispute.*) ou "*"; QuietStart e QuietEnd são "HH:MM" no fuso Timezone.
+type PreferencesInput struct {
+	Locale       string
+	Timezone     string
+	QuietStart   string
+	QuietEnd     string
+	Channels     domain.EventChannels
+	Unsubscribed bool
+}
+
+// PreferenceServiceImpl implementa PreferenceService
+type PreferenceServiceImpl struct {
+	repo   repository.PreferenceRepository
+	users  clients.UserClient
+	signer *unsubscribe.Signer
+	now    func() time.Time
+}
+
+// NewPreferenceService cria o serviço de preferências. users confirma que o usuário
+// existe; signer valida os tokens de cancelamento (nil desabilita o cancelamento em um
+// clique).
+func NewPreferenceService(repo repository.PreferenceRepository, users clients.UserClient, signer *unsubscribe.Signer) PreferenceService {
+	return &PreferenceServiceImpl{
+		repo:   repo,
+		users:  users,
+		signer: signer,
+		now:    time.Now,
+	}
+}
+
+// GetPreferences retorna as preferências do usuário; quem nunca as definiu recebe tudo
+func (s *PreferenceServiceImpl) GetPreferences(userID uint) (*domain.NotificationPreference, error) {
+	return s.load(userID)
+}
+
+// UpdatePreferences valida e grava as preferências do usuário
+func (s *PreferenceServiceImpl) UpdatePreferences(userID uint, input PreferencesInput) (*domain.NotificationPreference, error) {
+	if err := validatePreferences(&input); err != nil {
+		return nil, err
+	}
+	if err := s.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	preference, err := s.load(userID)
+	if err != nil {
+		return nil, err
+	}
+	preference.Locale = input.Locale
+	preference.Timezone = input.Timezone
+	preference.QuietStart = input.QuietStart
+	preference.QuietEnd = input.QuietEnd
+	preference.Channels = input.Channels
+	if input.Unsubscribed != preference.Unsubscribed {
+		preference.Unsubscribed = input.Unsubscribed
+		preference.UnsubscribedAt = nil
+		if input.Unsubscribed {
+			now := s.now()
+			preference.UnsubscribedAt = &now
+		}
+	}
+
+	if err := s.repo.Save(preference); err != nil {
+		log.Printf("❌ Erro ao salvar preferências do usuário %d: %v", userID, err)
+		return nil, fmt.Errorf("falha ao salvar preferências: %w", err)
+	}
+	log.Printf("⚙️ Preferências de notificação do usuário %d atualizadas", userID)
+	return preference, nil
+}
+
+// Unsubscribe cancela a inscrição do usuário do token em todas as notificações. Repetir
+// o cancelamento não altera a data original.
+func (s *PreferenceServiceImpl) Unsubscribe(token string) (*domain.NotificationPreference, error) {
+	if s.signer == nil {
+		return nil, ErrUnsubscribeDisabled
+	}
+	userID, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := s.load(userID)
+	if err != nil {
+		return nil, err
+	}
+	if preference.Unsubscribed {
+		return preference, nil
+	}
+
+	now := s.now()
+	preference.Unsubscribed = true
+	preference.UnsubscribedAt = &now
+	if err := s.repo.Save(preference); err != nil {
+		log.Printf("❌ Erro ao cancelar inscrição do usuário %d: %v", userID, err)
+		return nil, fmt.Errorf("falha ao cancelar inscrição: %w", err)
+	}
+	log.Printf("🔕 Usuário %d cancelou a inscrição nas notificações", userID)
+	return preference, nil
+}
+
+// load busca as preferências gravadas ou as padrão do usuário
+func (s *PreferenceServiceImpl) load(userID uint) (*domain.NotificationPreference, error) {
+	preference, err := s.repo.GetByUserID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.NotificationPreference{UserID: userID, Channels: domain.EventChannels{}}, nil
+	}
+	return preference, err
+}
+
+// checkUser confirma no user-service que o usuário existe
+func (s *PreferenceServiceImpl) checkUser(userID uint) error {
+	if userID == 0 {
+		return fmt.Errorf("%w: user_id é obrigatório", ErrInvalidPreferences)
+	}
+	if s.users == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), userLookupTimeout)
+	defer cancel()
+	if _, err := s.users.GetUser(ctx, userID); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+		}
+		return fmt.Errorf("falha ao consultar usuário %d: %w", userID, err)
+	}
+	return nil
+}
+
+// preferenceChannels são os canais que podem ser escolhidos nas preferências
+var preferenceChannels = []string{channel.Log, channel.SMTP, channel.SMS, channel.Webhook, channel.Slack}
+
+// validatePreferences valida e padroniza as preferências informadas
+func validatePreferences(input *PreferencesInput) error {
+	if input.Locale != "" {
+		locale, known := templates.ResolveLocale(input.Locale)
+		if !known {
+			return fmt.Errorf("%w: localidade %q (esperado %s)", ErrInvalidPreferences, input.Locale, strings.Join(templates.Locales, ", "))
+		}
+		input.Locale = locale
+	}
+
+	if input.Timezone != "" {
+		if _, err := time.LoadLocation(input.Timezone); err != nil {
+			return fmt.Errorf("%w: fuso horário %q desconhecido", ErrInvalidPreferences, input.Timezone)
+		}
+	}
+
+	if input.QuietStart != "" || input.QuietEnd != "" {
+		start, errStart := time.Parse("15:04", input.QuietStart)
+		end, errEnd := time.Parse("15:04", input.QuietEnd)
+		if errStart != nil || errEnd != nil {
+			return fmt.Errorf("%w: horário de silêncio deve ter início e fim em HH:MM", ErrInvalidPreferences)
+		}
+		if start.Equal(end) {
+			return fmt.Errorf("%w: horário de silêncio com início igual ao fim", ErrInvalidPreferences)
+		}
+	}
+
+	channels := domain.EventChannels{}
+	for event, names := range input.Channels {
+		if !validPreferenceEvent(event) {
+			return fmt.Errorf("%w: evento %q (ex.: payment.approved, payment.*, dispute.* ou *)", ErrInvalidPreferences, event)
+		}
+		normalized := []string{}
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !slices.Contains(preferenceChannels, name) {
+				return fmt.Errorf("%w: canal %q (esperado %s)", ErrInvalidPreferences, name, strings.Join(preferenceChannels, ", "))
+			}
+			if !slices.Contains(normalized, name) {
+				normalized = append(normalized, name)
+			}
+		}
+		channels[event] = normalized
+	}
+	input.Channels = channels
+	return nil
+}
+
+// validPreferenceEvent aceita "*", as famílias e os eventos de pagamento e contestação
+func validPreferenceEvent(event string) bool {
+	if event == domain.AllEvents {
+		return true
+	}
+	family, name, found := strings.Cut(event, ".")
+	if !found || (family != "payment" && family != "dispute") || name == "" {
+		return false
+	}
+	return name == "*" || strings.Trim(name, "abcdefghijklmnopqrstuvwxyz_") == ""
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"notification-service/internal/channel"
+	"notification-service/internal/clients"
+	"notification-service/internal/domain"
+	"notification-service/internal/unsubscribe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// memoryPreferenceRepository guarda as preferências em memória
+type memoryPreferenceRepository struct {
+	preferences map[uint]*domain.NotificationPreference
+}
+
+func newMemoryPreferenceRepository(preferences ...*domain.NotificationPreference) *memoryPreferenceRepository {
+	r := &memoryPreferenceRepository{preferences: map[uint]*domain.NotificationPreference{}}
+	for _, preference := range preferences {
+		r.preferences[preference.UserID] = preference
+	}
+	return r
+}
+
+func (r *memoryPreferenceRepository) GetByUserID(userID uint) (*domain.NotificationPreference, error) {
+	preference, ok := r.preferences[userID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *preference
+	return &copied, nil
+}
+
+func (r *memoryPreferenceRepository) Save(preference *domain.NotificationPreference) error {
+	copied := *preference
+	r.preferences[preference.UserID] = &copied
+	return nil
+}
+
+// stubUserClient responde com os usuários cadastrados e NotFound para os demais
+type stubUserClient struct {
+	users map[uint]*clients.User
+}
+
+func (c *stubUserClient) GetUser(ctx context.Context, userID uint) (*clients.User, error) {
+	user, ok := c.users[userID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "usuário não encontrado")
+	}
+	return user, nil
+}
+
+func (c *stubUserClient) Close() error { return nil }
+
+func newPreferenceFixture(t *testing.T) (*PreferenceServiceImpl, *memoryPreferenceRepository, *unsubscribe.Signer) {
+	repo := newMemoryPreferenceRepository()
+	signer, err := unsubscribe.NewSigner("segredo")
+	require.NoError(t, err)
+	users := &stubUserClient{users: map[uint]*clients.User{7: {ID: 7, Name: "Maria", Email: "maria@example.com"}}}
+	svc := NewPreferenceService(repo, users, signer).(*PreferenceServiceImpl)
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC) }
+	return svc, repo, signer
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	svc, repo, _ := newPreferenceFixture(t)
+
+	preference, err := svc.GetPreferences(7)
+	require.NoError(t, err)
+	assert.Empty(t, preference.Channels, "sem preferências gravadas, todos os canais")
+
+	preference, err = svc.UpdatePreferences(7, PreferencesInput{
+		Locale:     "en",
+		Timezone:   "America/Sao_Paulo",
+		QuietStart: "22:00",
+		QuietEnd:   "07:00",
+		Channels:   domain.EventChannels{"payment.*": {" SMTP", "smtp", "sms"}, "dispute.won": {}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, money.LocaleEnUS, preference.Locale)
+	assert.Equal(t, []string{channel.SMTP, channel.SMS}, preference.Channels["payment.*"])
+
+	stored, err := repo.GetByUserID(7)
+	require.NoError(t, err)
+	assert.Equal(t, "America/Sao_Paulo", stored.Timezone)
+	assert.Equal(t, domain.SuppressedChannelDisabled, stored.Suppression("dispute.won", channel.SMTP))
+	assert.Empty(t, stored.Suppression("payment.approved", channel.SMTP))
+}
+
+func TestUpdatePreferences_Invalid(t *testing.T) {
+	svc, _, _ := newPreferenceFixture(t)
+
+	tests := []struct {
+		name  string
+		input PreferencesInput
+	}{
+		{"localidade", PreferencesInput{Locale: "fr-FR"}},
+		{"fuso horário", PreferencesInput{Timezone: "Marte/Olympus"}},
+		{"horário de silêncio incompleto", PreferencesInput{QuietStart: "22:00"}},
+		{"horário de silêncio vazio", PreferencesInput{QuietStart: "08:00", QuietEnd: "08:00"}},
+		{"evento", PreferencesInput{Channels: domain.EventChannels{"order.created": {channel.SMTP}}}},
+		{"canal", PreferencesInput{Channels: domain.EventChannels{"*": {"pombo"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdatePreferences(7, tt.input)
+			assert.ErrorIs(t, err, ErrInvalidPreferences)
+		})
+	}
+
+	_, err := svc.UpdatePreferences(99, PreferencesInput{})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	svc, repo, signer := newPreferenceFixture(t)
+
+	_, err := svc.Unsubscribe("v1.7.assinatura-falsa")
+	assert.ErrorIs(t, err, unsubscribe.ErrInvalidToken)
+
+	preference, err := svc.Unsubscribe(signer.Token(7))
+	require.NoError(t, err)
+	assert.True(t, preference.Unsubscribed)
+	unsubscribedAt := *preference.UnsubscribedAt
+
+	// Repetir o clique não altera a data do cancelamento
+	svc.now = func() time.Time { return unsubscribedAt.Add(time.Hour) }
+	preference, err = svc.Unsubscribe(signer.Token(7))
+	require.NoError(t, err)
+	assert.Equal(t, unsubscribedAt, *preference.UnsubscribedAt)
+
+	stored, err := repo.GetByUserID(7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SuppressedUnsubscribed, stored.Suppression("payment.approved", channel.Log))
+
+	// O próprio usuário pode voltar a receber pelas preferências
+	preference, err = svc.UpdatePreferences(7, PreferencesInput{})
+	require.NoError(t, err)
+	assert.False(t, preference.Unsubscribed)
+	assert.Nil(t, preference.UnsubscribedAt)
+
+	_, err = NewPreferenceService(repo, nil, nil).Unsubscribe(signer.Token(7))
+	assert.ErrorIs(t, err, ErrUnsubscribeDisabled)
+}
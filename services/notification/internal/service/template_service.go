@@ -0,0 +1,284 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/repository"
+	"notification-service/internal/templates"
+
+	"gorm.io/gorm"
+)
+
+// TemplateService define os métodos dos modelos das notificações
+type TemplateService interface {
+	Render(event, locale string, data templates.Data) (templates.Rendered, error)
+	Preview(input PreviewInput) (templates.Rendered, error)
+	ListTemplates(event, locale string) ([]*domain.NotificationTemplate, error)
+	SaveTemplate(input SaveTemplateInput) (*domain.NotificationTemplate, error)
+	ActivateTemplate(event, locale string, version int) (*domain.NotificationTemplate, error)
+	Reload() error
+}
+
+// PreviewInput é o pedido de pré-visualização. Com assunto e texto, monta o rascunho
+// informado; com Version, a versão gravada; sem nenhum dos dois, o modelo em uso para o
+// evento e a localidade, com as regras de fallback.
+type PreviewInput struct {
+	Event   string
+	Locale  string
+	Version int
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// SaveTemplateInput é uma nova versão de modelo
+type SaveTemplateInput struct {
+	Event     string
+	Locale    string
+	Subject   string
+	Text      string
+	HTML      string
+	CreatedBy string
+}
+
+// TemplateServiceImpl implementa TemplateService. Os modelos embutidos e os da pasta
+// configurada formam a base; as versões ativas no banco os substituem e são
+// recarregadas a cada refresh, para valer também nas outras réplicas.
+type TemplateServiceImpl struct {
+	repo          repository.TemplateRepository
+	base          []*templates.Compiled
+	defaultLocale string
+	refresh       time.Duration
+
+	mu       sync.RWMutex
+	registry *templates.Registry
+	loadedAt time.Time
+	now      func() time.Time
+}
+
+// NewTemplateService cria o serviço de modelos e carrega as versões ativas do banco
+func NewTemplateService(repo repository.TemplateRepository, base []*templates.Compiled, defaultLocale string, refresh time.Duration) (TemplateService, error) {
+	s := &TemplateServiceImpl{
+		repo:          repo,
+		base:          base,
+		defaultLocale: defaultLocale,
+		refresh:       refresh,
+		now:           time.Now,
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload recarrega as versões ativas do banco. Versões que deixaram de compilar são
+// ignoradas, valendo o modelo da base.
+func (s *TemplateServiceImpl) Reload() error {
+	active, err := s.repo.ListActive()
+	if err != nil {
+		return fmt.Errorf("falha ao carregar modelos de notificação: %w", err)
+	}
+
+	stored := make([]*templates.Compiled, 0, len(active))
+	for _, record := range active {
+		compiled, err := templates.Compile(toTemplate(record))
+		if err != nil {
+			log.Printf("⚠️ Modelo %s %s v%d ignorado: %v", record.Event, record.Locale, record.Version, err)
+			continue
+		}
+		stored = append(stored, compiled)
+	}
+
+	registry := templates.NewRegistry(s.defaultLocale, s.base, stored)
+	s.mu.Lock()
+	s.registry = registry
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+	return nil
+}
+
+// current retorna o registro em uso, recarregando do banco quando passou o refresh
+func (s *TemplateServiceImpl) current() *templates.Registry {
+	s.mu.RLock()
+	registry, loadedAt := s.registry, s.loadedAt
+	s.mu.RUnlock()
+
+	if s.refresh > 0 && s.now().Sub(loadedAt) >= s.refresh {
+		if err := s.Reload(); err != nil {
+			log.Printf("⚠️ %v; mantendo os modelos carregados", err)
+			return registry
+		}
+		s.mu.RLock()
+		registry = s.registry
+		s.mu.RUnlock()
+	}
+	return registry
+}
+
+// Render monta a notificação do evento na localidade
+func (s *TemplateServiceImpl) Render(event, locale string, data templates.Data) (templates.Rendered, error) {
+	return s.current().Render(event, locale, data)
+}
+
+// Preview monta a notificação com os dados de exemplo do evento
+func (s *TemplateServiceImpl) Preview(input PreviewInput) (templates.Rendered, error) {
+	data := templates.SampleData(input.Event)
+
+	if input.Subject != "" || input.Text != "" || input.HTML != "" {
+		locale, err := supportedLocale(input.Locale)
+		if err != nil {
+			return templates.Rendered{}, err
+		}
+		compiled, err := templates.Compile(templates.Template{Event: input.Event, Locale: locale, Subject: input.Subject, Text: input.Text, HTML: input.HTML})
+		if err != nil {
+			return templates.Rendered{}, err
+		}
+		return compiled.Render(data)
+	}
+
+	if input.Version > 0 {
+		locale, err := supportedLocale(input.Locale)
+		if err != nil {
+			return templates.Rendered{}, err
+		}
+		record, err := s.repo.GetVersion(input.Event, locale, input.Version)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return templates.Rendered{}, fmt.Errorf("%w: %s %s v%d", templates.ErrTemplateNotFound, input.Event, locale, input.Version)
+		}
+		if err != nil {
+			return templates.Rendered{}, err
+		}
+		compiled, err := templates.Compile(toTemplate(record))
+		if err != nil {
+			return templates.Rendered{}, err
+		}
+		return compiled.Render(data)
+	}
+
+	return s.Render(input.Event, input.Locale, data)
+}
+
+// ListTemplates lista os modelos da base (versão 0) e as versões gravadas no banco;
+// event e locale vazios não filtram. Active indica a versão em uso.
+func (s *TemplateServiceImpl) ListTemplates(event, locale string) ([]*domain.NotificationTemplate, error) {
+	if locale != "" {
+		var err error
+		if locale, err = supportedLocale(locale); err != nil {
+			return nil, err
+		}
+	}
+
+	stored, err := s.repo.List(event, locale)
+	if err != nil {
+		return nil, err
+	}
+	overridden := map[string]bool{}
+	for _, record := range stored {
+		if record.Active {
+			overridden[record.Event+"|"+record.Locale] = true
+		}
+	}
+
+	var list []*domain.NotificationTemplate
+	for _, compiled := range templates.NewRegistry(s.defaultLocale, s.base).Templates() {
+		if (event != "" && compiled.Event != event) || (locale != "" && compiled.Locale != locale) {
+			continue
+		}
+		list = append(list, &domain.NotificationTemplate{
+			Event:   compiled.Event,
+			Locale:  compiled.Locale,
+			Subject: compiled.Subject,
+			Text:    compiled.Text,
+			HTML:    compiled.HTML,
+			Active:  !overridden[compiled.Event+"|"+compiled.Locale],
+		})
+	}
+	return append(list, stored...), nil
+}
+
+// SaveTemplate valida e grava uma nova versão do modelo, que passa a valer no lugar da
+// anterior
+func (s *TemplateServiceImpl) SaveTemplate(input SaveTemplateInput) (*domain.NotificationTemplate, error) {
+	locale, err := supportedLocale(input.Locale)
+	if err != nil {
+		return nil, err
+	}
+	record := &domain.NotificationTemplate{
+		Event:     input.Event,
+		Locale:    locale,
+		Subject:   input.Subject,
+		Text:      input.Text,
+		HTML:      input.HTML,
+		CreatedBy: input.CreatedBy,
+		CreatedAt: time.Now(),
+	}
+	if _, err := templates.Compile(toTemplate(record)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVersion(record); err != nil {
+		log.Printf("❌ Erro ao salvar modelo %s %s: %v", record.Event, record.Locale, err)
+		return nil, fmt.Errorf("falha ao salvar modelo: %w", err)
+	}
+	log.Printf("📝 Modelo %s %s v%d salvo por %s", record.Event, record.Locale, record.Version, record.CreatedBy)
+
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ActivateTemplate volta a usar uma versão já gravada do modelo
+func (s *TemplateServiceImpl) ActivateTemplate(event, locale string, version int) (*domain.NotificationTemplate, error) {
+	locale, err := supportedLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.repo.GetVersion(event, locale, version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s %s v%d", templates.ErrTemplateNotFound, event, locale, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := templates.Compile(toTemplate(record)); err != nil {
+		return nil, err
+	}
+
+	if record, err = s.repo.Activate(event, locale, version); err != nil {
+		return nil, fmt.Errorf("falha ao ativar modelo: %w", err)
+	}
+	log.Printf("🔁 Modelo %s %s v%d ativado", record.Event, record.Locale, record.Version)
+
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// supportedLocale exige uma das localidades com modelos
+func supportedLocale(locale string) (string, error) {
+	resolved, known := templates.ResolveLocale(locale)
+	if !known {
+		return "", fmt.Errorf("%w: localidade %q (esperado %s)", templates.ErrInvalidTemplate, locale, strings.Join(templates.Locales, ", "))
+	}
+	return resolved, nil
+}
+
+// toTemplate converte a versão gravada no modelo a compilar
+func toTemplate(record *domain.NotificationTemplate) templates.Template {
+	return templates.Template{
+		Event:   record.Event,
+		Locale:  record.Locale,
+		Version: record.Version,
+		Subject: record.Subject,
+		Text:    record.Text,
+		HTML:    record.HTML,
+	}
+}
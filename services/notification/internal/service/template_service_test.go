@@ -0,0 +1,185 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"notification-service/internal/domain"
+	"notification-service/internal/templates"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryTemplateRepository guarda as versões dos modelos em memória
+type memoryTemplateRepository struct {
+	templates []*domain.NotificationTemplate
+}
+
+func (r *memoryTemplateRepository) CreateVersion(template *domain.NotificationTemplate) error {
+	template.Version = 1
+	for _, stored := range r.templates {
+		if stored.Event == template.Event && stored.Locale == template.Locale {
+			stored.Active = false
+			template.Version = max(template.Version, stored.Version+1)
+		}
+	}
+	template.ID = uint(len(r.templates) + 1)
+	template.Active = true
+	r.templates = append(r.templates, template)
+	return nil
+}
+
+func (r *memoryTemplateRepository) Activate(event, locale string, version int) (*domain.NotificationTemplate, error) {
+	found, err := r.GetVersion(event, locale, version)
+	if err != nil {
+		return nil, err
+	}
+	for _, stored := range r.templates {
+		if stored.Event == event && stored.Locale == locale {
+			stored.Active = stored == found
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryTemplateRepository) GetVersion(event, locale string, version int) (*domain.NotificationTemplate, error) {
+	for _, stored := range r.templates {
+		if stored.Event == event && stored.Locale == locale && stored.Version == version {
+			return stored, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryTemplateRepository) List(event, locale string) ([]*domain.NotificationTemplate, error) {
+	var list []*domain.NotificationTemplate
+	for _, stored := range r.templates {
+		if (event == "" || stored.Event == event) && (locale == "" || stored.Locale == locale) {
+			list = append(list, stored)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryTemplateRepository) ListActive() ([]*domain.NotificationTemplate, error) {
+	var list []*domain.NotificationTemplate
+	for _, stored := range r.templates {
+		if stored.Active {
+			list = append(list, stored)
+		}
+	}
+	return list, nil
+}
+
+type templateFixture struct {
+	svc  *TemplateServiceImpl
+	repo *memoryTemplateRepository
+}
+
+func newTemplateFixture(t *testing.T) templateFixture {
+	t.Helper()
+	base, err := templates.Defaults()
+	require.NoError(t, err)
+	repo := &memoryTemplateRepository{}
+	svc, err := NewTemplateService(repo, base, money.LocalePtBR, time.Minute)
+	require.NoError(t, err)
+	return templateFixture{svc: svc.(*TemplateServiceImpl), repo: repo}
+}
+
+func TestSaveTemplate_Versions(t *testing.T) {
+	f := newTemplateFixture(t)
+	data := templates.SampleData(templates.EventPaymentApproved)
+
+	first, err := f.svc.SaveTemplate(SaveTemplateInput{Event: templates.EventPaymentApproved, Locale: "en", Subject: "Order {{.OrderID}}", Text: "Approved: {{money .Amount}}", CreatedBy: "admin@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, money.LocaleEnUS, first.Locale)
+
+	rendered, err := f.svc.Render(templates.EventPaymentApproved, "en-US", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Approved: R$199.90", rendered.Text)
+	assert.Equal(t, 1, rendered.Version)
+	assert.Empty(t, rendered.HTML)
+
+	second, err := f.svc.SaveTemplate(SaveTemplateInput{Event: templates.EventPaymentApproved, Locale: "en-US", Subject: "Order {{.OrderID}}", Text: "Paid {{money .Amount}}"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Version)
+	rendered, _ = f.svc.Render(templates.EventPaymentApproved, "en-US", data)
+	assert.Equal(t, "Paid R$199.90", rendered.Text)
+
+	_, err = f.svc.ActivateTemplate(templates.EventPaymentApproved, "en-US", 1)
+	require.NoError(t, err)
+	rendered, _ = f.svc.Render(templates.EventPaymentApproved, "en-US", data)
+	assert.Equal(t, "Approved: R$199.90", rendered.Text)
+
+	list, err := f.svc.ListTemplates(templates.EventPaymentApproved, "en-US")
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, 0, list[0].Version)
+	assert.False(t, list[0].Active, "o modelo embutido foi substituído")
+	assert.True(t, list[1].Active)
+	assert.False(t, list[2].Active)
+
+	_, err = f.svc.ActivateTemplate(templates.EventPaymentApproved, "en-US", 9)
+	assert.ErrorIs(t, err, templates.ErrTemplateNotFound)
+}
+
+func TestSaveTemplate_Invalid(t *testing.T) {
+	f := newTemplateFixture(t)
+
+	_, err := f.svc.SaveTemplate(SaveTemplateInput{Event: templates.EventPaymentApproved, Locale: "fr-FR", Subject: "s", Text: "t"})
+	assert.ErrorIs(t, err, templates.ErrInvalidTemplate)
+	_, err = f.svc.SaveTemplate(SaveTemplateInput{Event: templates.EventPaymentApproved, Locale: "es", Subject: "s", Text: "{{.Customer.Name}}"})
+	assert.ErrorIs(t, err, templates.ErrInvalidTemplate)
+	assert.Empty(t, f.repo.templates, "modelos inválidos não são gravados")
+}
+
+func TestPreview(t *testing.T) {
+	f := newTemplateFixture(t)
+
+	// Rascunho
+	rendered, err := f.svc.Preview(PreviewInput{Event: templates.EventDisputeOpened, Locale: "es", Subject: "{{.Dispute.Reference}}", Text: "Hasta {{datetime .Dispute.EvidenceDueAt}}", HTML: "<b>{{.Dispute.Reason}}</b>"})
+	require.NoError(t, err)
+	assert.Equal(t, templates.Rendered{Event: templates.EventDisputeOpened, Locale: money.LocaleES, Subject: "CB-2025-0042", Text: "Hasta 17/03/2025 18:00", HTML: "<b>fraud</b>"}, rendered)
+	assert.Empty(t, f.repo.templates, "a pré-visualização não grava")
+
+	// Modelo em uso, com fallback de localidade
+	rendered, err = f.svc.Preview(PreviewInput{Event: templates.EventPaymentRejected, Locale: "fr-FR"})
+	require.NoError(t, err)
+	assert.True(t, rendered.Fallback)
+	assert.Equal(t, money.LocalePtBR, rendered.Locale)
+	assert.Equal(t, "❌ Notificação: Pagamento rejeitado para o pedido 1042 no valor de R$ 199,90", rendered.Text)
+
+	// Versão gravada, mesmo que não esteja ativa
+	_, err = f.svc.SaveTemplate(SaveTemplateInput{Event: templates.EventDisputeWon, Locale: "pt-BR", Subject: "Ganha", Text: "v1 {{.Dispute.Reference}}"})
+	require.NoError(t, err)
+	_, err = f.svc.SaveTemplate(SaveTemplateInput{Event: templates.EventDisputeWon, Locale: "pt-BR", Subject: "Ganha", Text: "v2 {{.Dispute.Reference}}"})
+	require.NoError(t, err)
+	rendered, err = f.svc.Preview(PreviewInput{Event: templates.EventDisputeWon, Locale: "pt-BR", Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "v1 CB-2025-0042", rendered.Text)
+
+	_, err = f.svc.Preview(PreviewInput{Event: templates.EventDisputeWon, Locale: "pt-BR", Version: 5})
+	assert.ErrorIs(t, err, templates.ErrTemplateNotFound)
+}
+
+func TestTemplateService_Refresh(t *testing.T) {
+	f := newTemplateFixture(t)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	f.svc.now = func() time.Time { return now }
+	require.NoError(t, f.svc.Reload())
+
+	// Versão gravada por outra réplica
+	require.NoError(t, f.repo.CreateVersion(&domain.NotificationTemplate{Event: templates.EventPaymentStatus, Locale: money.LocalePtBR, Subject: "Pedido {{.OrderID}}", Text: "Status {{.Status}}"}))
+
+	rendered, _ := f.svc.Render("payment.pending", "pt-BR", templates.SampleData(templates.EventPaymentStatus))
+	assert.Contains(t, rendered.Text, "Status de pagamento", "ainda dentro do refresh")
+
+	now = now.Add(time.Minute)
+	rendered, _ = f.svc.Render("payment.pending", "pt-BR", templates.SampleData(templates.EventPaymentStatus))
+	assert.Equal(t, "Status PENDING", rendered.Text)
+	assert.True(t, rendered.Fallback)
+}
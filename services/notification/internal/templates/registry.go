@@ -0,0 +1,203 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+//go:embed defaults/*.tmpl
+var defaults embed.FS
+
+// key identifica o modelo de um evento em uma localidade
+type key struct {
+	event  string
+	locale string
+}
+
+// Registry guarda os modelos em uso e escolhe o de cada notificação, com as regras de
+// fallback de localidade e de evento
+type Registry struct {
+	defaultLocale string
+	templates     map[key]*Compiled
+}
+
+// NewRegistry cria o registro com os conjuntos de modelos informados; os conjuntos
+// seguintes substituem os anteriores no mesmo evento e localidade
+func NewRegistry(defaultLocale string, sets ...[]*Compiled) *Registry {
+	registry := &Registry{
+		defaultLocale: money.NormalizeLocale(defaultLocale),
+		templates:     map[key]*Compiled{},
+	}
+	for _, set := range sets {
+		for _, compiled := range set {
+			registry.templates[key{compiled.Event, compiled.Locale}] = compiled
+		}
+	}
+	return registry
+}
+
+// Lookup retorna o modelo do evento na localidade. Sem modelo para a localidade valem a
+// localidade padrão e pt-BR; em cada uma, o evento e depois o genérico da família.
+// fallback indica que o modelo não é exatamente o pedido.
+func (r *Registry) Lookup(event, locale string) (compiled *Compiled, fallback bool, err error) {
+	requested, known := r.resolveLocale(locale)
+	var locales []string
+	if known {
+		locales = append(locales, requested)
+	}
+	for _, candidate := range []string{r.defaultLocale, money.LocalePtBR} {
+		if !slices.Contains(locales, candidate) {
+			locales = append(locales, candidate)
+		}
+	}
+	events := []string{event}
+	if generic := family(event); generic != event {
+		events = append(events, generic)
+	}
+
+	for _, loc := range locales {
+		for _, ev := range events {
+			if compiled, ok := r.templates[key{ev, loc}]; ok {
+				return compiled, !known || loc != requested || ev != event, nil
+			}
+		}
+	}
+	return nil, false, fmt.Errorf("%w: %s (%s)", ErrTemplateNotFound, event, locale)
+}
+
+// resolveLocale padroniza a localidade pedida; vazia vale a padrão
+func (r *Registry) resolveLocale(locale string) (string, bool) {
+	if strings.TrimSpace(locale) == "" {
+		return r.defaultLocale, true
+	}
+	return ResolveLocale(locale)
+}
+
+// ResolveLocale padroniza a localidade (pt_BR, pt-br e pt viram pt-BR). known é falso
+// para idiomas sem modelos (ex.: fr-FR), que seguem direto para o fallback.
+func ResolveLocale(locale string) (resolved string, known bool) {
+	locale = strings.TrimSpace(locale)
+	language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	switch strings.ToLower(language) {
+	case "pt", "en", "es":
+		return money.NormalizeLocale(locale), true
+	default:
+		return locale, false
+	}
+}
+
+// Render monta a notificação do evento na localidade
+func (r *Registry) Render(event, locale string, data Data) (Rendered, error) {
+	compiled, fallback, err := r.Lookup(event, locale)
+	if err != nil {
+		return Rendered{}, err
+	}
+	rendered, err := compiled.Render(data)
+	if err != nil {
+		return Rendered{}, err
+	}
+	rendered.Fallback = fallback
+	return rendered, nil
+}
+
+// Templates retorna os modelos em uso, ordenados por evento e localidade
+func (r *Registry) Templates() []*Compiled {
+	list := make([]*Compiled, 0, len(r.templates))
+	for _, compiled := range r.templates {
+		list = append(list, compiled)
+	}
+	slices.SortFunc(list, func(a, b *Compiled) int {
+		if c := strings.Compare(a.Event, b.Event); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Locale, b.Locale)
+	})
+	return list
+}
+
+// Defaults carrega os modelos embutidos no serviço
+func Defaults() ([]*Compiled, error) {
+	sub, err := fs.Sub(defaults, "defaults")
+	if err != nil {
+		return nil, err
+	}
+	return LoadFS(sub)
+}
+
+// LoadDir carrega os modelos de uma pasta (NOTIFICATION_TEMPLATES_DIR)
+func LoadDir(dir string) ([]*Compiled, error) {
+	return LoadFS(os.DirFS(dir))
+}
+
+// LoadFS carrega os arquivos <localidade>.tmpl. Cada arquivo traz blocos define
+// "<evento>.subject", "<evento>.text" e, opcionalmente, "<evento>.html".
+func LoadFS(fsys fs.FS) ([]*Compiled, error) {
+	files, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	var compiled []*Compiled
+	for _, file := range files {
+		locale := strings.TrimSuffix(path.Base(file), ".tmpl")
+		if money.NormalizeLocale(locale) != locale {
+			return nil, fmt.Errorf("%w: localidade %q desconhecida em %s", ErrInvalidTemplate, locale, file)
+		}
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		set, err := texttemplate.New(file).Funcs(funcMap(locale)).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+
+		// Agrupa as partes de cada evento; o fonte de cada bloco é recuperado da árvore
+		parts := map[string]*Template{}
+		for _, tmpl := range set.Templates() {
+			if tmpl.Name() == file || tmpl.Tree == nil {
+				continue
+			}
+			idx := strings.LastIndex(tmpl.Name(), ".")
+			if idx < 0 {
+				return nil, fmt.Errorf("%w: bloco %q em %s", ErrInvalidTemplate, tmpl.Name(), file)
+			}
+			event, part := tmpl.Name()[:idx], tmpl.Name()[idx+1:]
+			t, ok := parts[event]
+			if !ok {
+				t = &Template{Event: event, Locale: locale}
+				parts[event] = t
+			}
+			source := tmpl.Tree.Root.String()
+			switch part {
+			case "subject":
+				t.Subject = source
+			case "text":
+				t.Text = source
+			case "html":
+				t.HTML = source
+			default:
+				return nil, fmt.Errorf("%w: bloco %q em %s", ErrInvalidTemplate, tmpl.Name(), file)
+			}
+		}
+
+		for _, event := range slices.Sorted(maps.Keys(parts)) {
+			c, err := Compile(*parts[event])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", file, event, err)
+			}
+			compiled = append(compiled, c)
+		}
+	}
+	return compiled, nil
+}
@@ -0,0 +1,235 @@
+// Package templates monta as notificações a partir de modelos por evento e localidade,
+// com assunto e texto em text/template e a versão HTML em html/template.
+//
+// Os modelos embutidos ficam em defaults/<localidade>.tmpl, um bloco define por evento e
+// parte ("payment.approved.subject", "payment.approved.text", "payment.approved.html"). Uma
+// pasta no mesmo formato e as versões gravadas no banco substituem os embutidos. Quando
+// não há modelo para a localidade pedida valem, na ordem, a localidade padrão e pt-BR;
+// eventos sem modelo próprio usam o genérico da família (payment.status ou
+// dispute.status).
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Erros dos modelos de notificação
+var (
+	ErrTemplateNotFound = errors.New("modelo de notificação não encontrado")
+	ErrInvalidTemplate  = errors.New("modelo de notificação inválido")
+)
+
+// Eventos com modelo
+const (
+	EventPaymentApproved          = "payment.approved"
+	EventPaymentRejected          = "payment.rejected"
+	EventPaymentStatus            = "payment.status"
+	EventDisputeOpened            = "dispute.opened"
+	EventDisputeEvidenceSubmitted = "dispute.evidence_submitted"
+	EventDisputeWon               = "dispute.won"
+	EventDisputeLost              = "dispute.lost"
+	EventDisputeStatus            = "dispute.status"
+)
+
+// Events são os eventos com modelo embutido
+var Events = []string{
+	EventPaymentApproved,
+	EventPaymentRejected,
+	EventPaymentStatus,
+	EventDisputeOpened,
+	EventDisputeEvidenceSubmitted,
+	EventDisputeWon,
+	EventDisputeLost,
+	EventDisputeStatus,
+}
+
+// Locales são as localidades com modelos embutidos
+var Locales = []string{money.LocalePtBR, money.LocaleEnUS, money.LocaleES}
+
+// Data são os dados disponíveis nos modelos. Amount é o valor cobrado e OrderAmount o
+// total na moeda do pedido; Dispute só existe nos eventos de contestação.
+type Data struct {
+	OrderID     uint
+	PaymentID   uint
+	Status      string
+	Amount      money.Money
+	OrderAmount money.Money
+	Dispute     *Dispute
+}
+
+// Converted indica a cobrança em moeda diferente da do pedido
+func (d Data) Converted() bool {
+	return money.NormalizeCurrency(d.Amount.Currency) != money.NormalizeCurrency(d.OrderAmount.Currency)
+}
+
+// Dispute é a contestação (chargeback) nos eventos dispute.*
+type Dispute struct {
+	Reference     string
+	Reason        string
+	Status        string
+	Amount        money.Money
+	EvidenceDueAt time.Time
+}
+
+// Template é o modelo de um evento em uma localidade. Version zero é o modelo embutido
+// ou da pasta; as versões gravadas no banco começam em 1. HTML é opcional.
+type Template struct {
+	Event   string
+	Locale  string
+	Version int
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Rendered é a notificação montada. Fallback indica que o modelo usado é de outra
+// localidade ou do evento genérico.
+type Rendered struct {
+	Event    string
+	Locale   string
+	Version  int
+	Subject  string
+	Text     string
+	HTML     string
+	Fallback bool
+}
+
+// Compiled é um modelo validado, pronto para montar notificações
+type Compiled struct {
+	Template
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// Compile valida o modelo e prepara as três partes. Os valores e datas são formatados
+// na localidade do modelo, pelas funções money e datetime.
+func Compile(t Template) (*Compiled, error) {
+	if !validEvent(t.Event) {
+		return nil, fmt.Errorf("%w: evento %q", ErrInvalidTemplate, t.Event)
+	}
+	t.Locale = money.NormalizeLocale(t.Locale)
+	if strings.TrimSpace(t.Subject) == "" || strings.TrimSpace(t.Text) == "" {
+		return nil, fmt.Errorf("%w: assunto e texto são obrigatórios", ErrInvalidTemplate)
+	}
+
+	funcs := funcMap(t.Locale)
+	compiled := &Compiled{Template: t}
+	var err error
+	if compiled.subject, err = texttemplate.New("subject").Funcs(funcs).Parse(t.Subject); err != nil {
+		return nil, fmt.Errorf("%w: assunto: %v", ErrInvalidTemplate, err)
+	}
+	if compiled.text, err = texttemplate.New("text").Funcs(funcs).Parse(t.Text); err != nil {
+		return nil, fmt.Errorf("%w: texto: %v", ErrInvalidTemplate, err)
+	}
+	if strings.TrimSpace(t.HTML) != "" {
+		if compiled.html, err = htmltemplate.New("html").Funcs(htmltemplate.FuncMap(funcs)).Parse(t.HTML); err != nil {
+			return nil, fmt.Errorf("%w: html: %v", ErrInvalidTemplate, err)
+		}
+	}
+
+	// Modelos que não montam com os dados de exemplo não são aceitos
+	if _, err := compiled.Render(SampleData(t.Event)); err != nil {
+		return nil, err
+	}
+	return compiled, nil
+}
+
+// Render monta a notificação com os dados informados
+func (c *Compiled) Render(data Data) (Rendered, error) {
+	rendered := Rendered{Event: c.Event, Locale: c.Locale, Version: c.Version}
+
+	var buf bytes.Buffer
+	if err := c.subject.Execute(&buf, data); err != nil {
+		return Rendered{}, fmt.Errorf("%w: assunto: %v", ErrInvalidTemplate, err)
+	}
+	rendered.Subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := c.text.Execute(&buf, data); err != nil {
+		return Rendered{}, fmt.Errorf("%w: texto: %v", ErrInvalidTemplate, err)
+	}
+	rendered.Text = strings.TrimSpace(buf.String())
+
+	if c.html != nil {
+		buf.Reset()
+		if err := c.html.Execute(&buf, data); err != nil {
+			return Rendered{}, fmt.Errorf("%w: html: %v", ErrInvalidTemplate, err)
+		}
+		rendered.HTML = strings.TrimSpace(buf.String())
+	}
+	return rendered, nil
+}
+
+// dateLayouts são os formatos de data e hora por localidade
+var dateLayouts = map[string]string{
+	money.LocalePtBR: "02/01/2006 15:04",
+	money.LocaleEnUS: "01/02/2006 3:04 PM",
+	money.LocaleES:   "02/01/2006 15:04",
+}
+
+// funcMap são as funções disponíveis nos modelos da localidade
+func funcMap(locale string) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"money": func(value money.Money) string {
+			return money.Format(value, locale)
+		},
+		"datetime": func(value time.Time) string {
+			return value.Format(dateLayouts[locale])
+		},
+	}
+}
+
+// validEvent aceita os eventos conhecidos
+func validEvent(event string) bool {
+	return slices.Contains(Events, event)
+}
+
+// family retorna o evento genérico da família do evento
+func family(event string) string {
+	if strings.HasPrefix(event, "dispute.") {
+		return EventDisputeStatus
+	}
+	return EventPaymentStatus
+}
+
+// SampleData retorna os dados de exemplo do evento, usados na pré-visualização e na
+// validação dos modelos
+func SampleData(event string) Data {
+	data := Data{
+		OrderID:     1042,
+		PaymentID:   7,
+		Status:      "APPROVED",
+		Amount:      money.New(19990, "BRL"),
+		OrderAmount: money.New(19990, "BRL"),
+	}
+	switch event {
+	case EventPaymentRejected:
+		data.Status = "REJECTED"
+	case EventPaymentStatus:
+		data.Status = "PENDING"
+	}
+	if strings.HasPrefix(event, "dispute.") {
+		status := strings.TrimPrefix(event, "dispute.")
+		if event == EventDisputeStatus {
+			status = "under_review"
+		}
+		data.Dispute = &Dispute{
+			Reference:     "CB-2025-0042",
+			Reason:        "fraud",
+			Status:        status,
+			Amount:        money.New(19990, "BRL"),
+			EvidenceDueAt: time.Date(2025, 3, 17, 18, 0, 0, 0, time.UTC),
+		}
+	}
+	return data
+}
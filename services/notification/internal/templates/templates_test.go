@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDefaultRegistry(t *testing.T, defaultLocale string, sets ...[]*Compiled) *Registry {
+	t.Helper()
+	compiled, err := Defaults()
+	require.NoError(t, err)
+	return NewRegistry(defaultLocale, append([][]*Compiled{compiled}, sets...)...)
+}
+
+func TestDefaults(t *testing.T) {
+	registry := newDefaultRegistry(t, money.LocalePtBR)
+
+	require.Len(t, registry.Templates(), len(Events)*len(Locales), "todo evento tem modelo embutido em todas as localidades")
+	for _, compiled := range registry.Templates() {
+		assert.NotEmpty(t, compiled.HTML, "%s %s", compiled.Event, compiled.Locale)
+		assert.Zero(t, compiled.Version)
+	}
+}
+
+func TestRender_Payment(t *testing.T) {
+	registry := newDefaultRegistry(t, money.LocalePtBR)
+	data := Data{OrderID: 42, PaymentID: 3, Status: "APPROVED", Amount: money.New(3333, "USD"), OrderAmount: money.New(18000, "BRL")}
+
+	rendered, err := registry.Render(EventPaymentApproved, "pt-BR", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Pagamento do pedido 42", rendered.Subject)
+	assert.Equal(t, "💬 Notificação: Pagamento aprovado para o pedido 42 no valor de R$ 180,00 (cobrado US$ 33,33)", rendered.Text)
+	assert.False(t, rendered.Fallback)
+
+	rendered, err = registry.Render(EventPaymentApproved, "en_US", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Payment for order 42", rendered.Subject)
+	assert.Equal(t, "💬 Notification: Payment approved for order 42 in the amount of R$180.00 (charged $33.33)", rendered.Text)
+	assert.Equal(t, money.LocaleEnUS, rendered.Locale)
+}
+
+func TestRender_EscapesHTML(t *testing.T) {
+	registry := newDefaultRegistry(t, money.LocalePtBR)
+	data := SampleData(EventDisputeOpened)
+	data.Dispute.Reference = "<script>alert(1)</script>"
+
+	rendered, err := registry.Render(EventDisputeOpened, "es", data)
+	require.NoError(t, err)
+	assert.Contains(t, rendered.Text, "<script>", "o texto simples não é escapado")
+	assert.NotContains(t, rendered.HTML, "<script>")
+	assert.Contains(t, rendered.HTML, "&lt;script&gt;")
+	assert.Contains(t, rendered.HTML, "17/03/2025 18:00")
+}
+
+func TestLookup_Fallback(t *testing.T) {
+	custom, err := Compile(Template{Event: EventPaymentStatus, Locale: "es", Version: 2, Subject: "Pedido {{.OrderID}}", Text: "Estado {{.Status}}"})
+	require.NoError(t, err)
+	registry := NewRegistry(money.LocaleEnUS, []*Compiled{custom})
+
+	// Sem payment.approved em es, vale o genérico da família na mesma localidade
+	compiled, fallback, err := registry.Lookup(EventPaymentApproved, "es")
+	require.NoError(t, err)
+	assert.Equal(t, custom, compiled)
+	assert.True(t, fallback)
+
+	// Idioma sem modelos vai para a localidade padrão e depois para pt-BR
+	_, _, err = registry.Lookup(EventPaymentApproved, "fr-FR")
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+
+	registry = newDefaultRegistry(t, money.LocaleEnUS, []*Compiled{custom})
+	compiled, fallback, err = registry.Lookup(EventPaymentApproved, "fr-FR")
+	require.NoError(t, err)
+	assert.Equal(t, money.LocaleEnUS, compiled.Locale)
+	assert.Equal(t, EventPaymentApproved, compiled.Event)
+	assert.True(t, fallback)
+
+	compiled, fallback, err = registry.Lookup(EventPaymentStatus, "es")
+	require.NoError(t, err)
+	assert.Equal(t, 2, compiled.Version, "os modelos do banco substituem os embutidos")
+	assert.False(t, fallback)
+
+	compiled, fallback, err = registry.Lookup("payment.pending", "")
+	require.NoError(t, err)
+	assert.Equal(t, EventPaymentStatus, compiled.Event)
+	assert.Equal(t, money.LocaleEnUS, compiled.Locale)
+	assert.True(t, fallback)
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template Template
+	}{
+		{"evento desconhecido", Template{Event: "order.created", Subject: "s", Text: "t"}},
+		{"sem assunto", Template{Event: EventPaymentApproved, Text: "t"}},
+		{"sintaxe", Template{Event: EventPaymentApproved, Subject: "s", Text: "{{.OrderID"}},
+		{"campo inexistente", Template{Event: EventPaymentApproved, Subject: "s", Text: "{{.Customer}}"}},
+		{"função inexistente", Template{Event: EventPaymentApproved, Subject: "s", Text: "t", HTML: "{{upper .Status}}"}},
+		{"contestação nos eventos de pagamento", Template{Event: EventPaymentApproved, Subject: "s", Text: "{{.Dispute.Reference}}"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.template)
+			assert.ErrorIs(t, err, ErrInvalidTemplate)
+		})
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"es.tmpl": {Data: []byte(`{{define "dispute.won.subject"}}Ganado {{.OrderID}}{{end}}
+{{define "dispute.won.text"}}Ganado el {{datetime .Dispute.EvidenceDueAt}} por {{money .Dispute.Amount}}{{end}}`)},
+	}
+	compiled, err := LoadFS(fsys)
+	require.NoError(t, err)
+	require.Len(t, compiled, 1)
+	assert.Equal(t, "Ganado el {{datetime .Dispute.EvidenceDueAt}} por {{money .Dispute.Amount}}", compiled[0].Text)
+	assert.Empty(t, compiled[0].HTML)
+
+	rendered, err := compiled[0].Render(Data{OrderID: 9, Dispute: &Dispute{Amount: money.New(123456, "EUR"), EvidenceDueAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)}})
+	require.NoError(t, err)
+	assert.Equal(t, "Ganado el 01/03/2025 09:30 por 1.234,56 €", rendered.Text)
+
+	_, err = LoadFS(fstest.MapFS{"fr-FR.tmpl": {Data: []byte(`{{define "dispute.won.text"}}x{{end}}`)}})
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+	_, err = LoadFS(fstest.MapFS{"pt-BR.tmpl": {Data: []byte(`{{define "dispute.won.body"}}x{{end}}`)}})
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+}
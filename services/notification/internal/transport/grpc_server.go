@@ -2,7 +2,9 @@ package transport
 
 import (
 	"context"
+	"time"
 
+	"notification-service/internal/domain"
 	"notification-service/internal/service"
 	pb "notification-service/proto"
 )
@@ -11,12 +13,16 @@ import (
 type NotificationGRPCServer struct {
 	pb.UnimplementedNotificationServiceServer
 	notificationService service.NotificationService
+	templateService     service.TemplateService
+	preferenceService   service.PreferenceService
 }
 
 // NewNotificationGRPCServer cria uma nova instância do servidor gRPC
-func NewNotificationGRPCServer(notificationService service.NotificationService) *NotificationGRPCServer {
+func NewNotificationGRPCServer(notificationService service.NotificationService, templateService service.TemplateService, preferenceService service.PreferenceService) *NotificationGRPCServer {
 	return &NotificationGRPCServer{
 		notificationService: notificationService,
+		templateService:     templateService,
+		preferenceService:   preferenceService,
 	}
 }
 
@@ -27,6 +33,53 @@ func (s *NotificationGRPCServer) ListNotifications(ctx context.Context, req *pb.
 		return nil, err
 	}
 
+	return toListNotificationsResponse(notifications), nil
+}
+
+// ExportNotifications retorna as notificações dos pedidos de um titular (LGPD)
+func (s *NotificationGRPCServer) ExportNotifications(ctx context.Context, req *pb.NotificationsByOrdersRequest) (*pb.ListNotificationsResponse, error) {
+	notifications, err := s.notificationService.GetNotificationsByOrderIDs(toUintIDs(req.GetOrderIds()))
+	if err != nil {
+		return nil, err
+	}
+	return toListNotificationsResponse(notifications), nil
+}
+
+// RedactNotifications anonimiza as mensagens dos pedidos de um titular (LGPD)
+func (s *NotificationGRPCServer) RedactNotifications(ctx context.Context, req *pb.NotificationsByOrdersRequest) (*pb.RedactNotificationsResponse, error) {
+	affected, err := s.notificationService.RedactNotifications(toUintIDs(req.GetOrderIds()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RedactNotificationsResponse{Affected: uint32(affected)}, nil
+}
+
+// ListDeliveries retorna as tentativas de entrega de uma notificação
+func (s *NotificationGRPCServer) ListDeliveries(ctx context.Context, req *pb.DeliveriesRequest) (*pb.DeliveriesResponse, error) {
+	attempts, err := s.notificationService.GetDeliveries(uint(req.GetNotificationId()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.DeliveriesResponse{}
+	for _, attempt := range attempts {
+		response.Deliveries = append(response.Deliveries, &pb.DeliveryAttempt{
+			Id:         uint32(attempt.ID),
+			Channel:    attempt.Channel,
+			Attempt:    int32(attempt.Attempt),
+			Status:     attempt.Status,
+			Response:   attempt.Response,
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+			CreatedAt:  attempt.CreatedAt.Format(time.RFC3339),
+			Reason:     attempt.Reason,
+		})
+	}
+	return response, nil
+}
+
+// toListNotificationsResponse converte as notificações para a resposta gRPC
+func toListNotificationsResponse(notifications []*domain.Notification) *pb.ListNotificationsResponse {
 	var pbNotifications []*pb.NotificationResponse
 	for _, notification := range notifications {
 		pbNotification := &pb.NotificationResponse{
@@ -36,11 +89,20 @@ func (s *NotificationGRPCServer) ListNotifications(ctx context.Context, req *pb.
 			Message:   notification.Message,
 			Status:    notification.Status,
 			CreatedAt: notification.CreatedAt.Format("2006-01-02 15:04:05"),
+			UserId:    uint32(notification.UserID),
 		}
 		pbNotifications = append(pbNotifications, pbNotification)
 	}
 
 	return &pb.ListNotificationsResponse{
 		Notifications: pbNotifications,
-	}, nil
+	}
+}
+
+func toUintIDs(ids []uint32) []uint {
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, uint(id))
+	}
+	return result
 }
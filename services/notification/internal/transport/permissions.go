@@ -0,0 +1,19 @@
+package transport
+
+import "github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+
+// MethodPermissions declara a permissão exigida por cada RPC do NotificationService. A
+// anonimização chamada por usuários exige login com segundo fator.
+var MethodPermissions = auth.Policy{
+	"/notification.NotificationService/ListNotifications":   {Permission: auth.PermNotificationsRead},
+	"/notification.NotificationService/ExportNotifications": {Permission: auth.PermPrivacy},
+	"/notification.NotificationService/RedactNotifications": {Permission: auth.PermPrivacy, RequireMFA: true},
+	"/notification.NotificationService/ListDeliveries":      {Permission: auth.PermNotificationsRead},
+	"/notification.NotificationService/ListTemplates":       {Permission: auth.PermNotificationsManage},
+	"/notification.NotificationService/SaveTemplate":        {Permission: auth.PermNotificationsManage},
+	"/notification.NotificationService/ActivateTemplate":    {Permission: auth.PermNotificationsManage},
+	"/notification.NotificationService/PreviewTemplate":     {Permission: auth.PermNotificationsManage},
+	"/notification.NotificationService/GetPreferences":      {Permission: auth.Authenticated},
+	"/notification.NotificationService/UpdatePreferences":   {Permission: auth.Authenticated},
+	"/notification.NotificationService/Unsubscribe":         {Permission: auth.Public},
+}
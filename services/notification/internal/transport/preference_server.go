@@ -0,0 +1,124 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"notification-service/internal/domain"
+	"notification-service/internal/service"
+	"notification-service/internal/unsubscribe"
+	pb "notification-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPreferences retorna as preferências de notificação do próprio usuário ou, para quem
+// gerencia as notificações, de qualquer usuário
+func (s *NotificationGRPCServer) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.NotificationPreferences, error) {
+	userID := uint(req.GetUserId())
+	if err := authorizePreferences(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	preference, err := s.preferenceService.GetPreferences(userID)
+	if err != nil {
+		return nil, preferenceError(err)
+	}
+	return toPreferencesResponse(preference), nil
+}
+
+// UpdatePreferences substitui as preferências de notificação do usuário
+func (s *NotificationGRPCServer) UpdatePreferences(ctx context.Context, req *pb.NotificationPreferences) (*pb.NotificationPreferences, error) {
+	userID := uint(req.GetUserId())
+	if err := authorizePreferences(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	channels := domain.EventChannels{}
+	for _, entry := range req.GetChannels() {
+		channels[entry.GetEvent()] = append([]string{}, entry.GetChannels()...)
+	}
+	preference, err := s.preferenceService.UpdatePreferences(userID, service.PreferencesInput{
+		Locale:       req.GetLocale(),
+		Timezone:     req.GetTimezone(),
+		QuietStart:   req.GetQuietStart(),
+		QuietEnd:     req.GetQuietEnd(),
+		Channels:     channels,
+		Unsubscribed: req.GetUnsubscribed(),
+	})
+	if err != nil {
+		return nil, preferenceError(err)
+	}
+	return toPreferencesResponse(preference), nil
+}
+
+// Unsubscribe cancela a inscrição com o token do link dos e-mails; não exige login
+func (s *NotificationGRPCServer) Unsubscribe(ctx context.Context, req *pb.UnsubscribeRequest) (*pb.NotificationPreferences, error) {
+	preference, err := s.preferenceService.Unsubscribe(req.GetToken())
+	if err != nil {
+		return nil, preferenceError(err)
+	}
+	return toPreferencesResponse(preference), nil
+}
+
+// authorizePreferences permite o acesso ao próprio usuário e a quem gerencia as notificações
+func authorizePreferences(ctx context.Context, userID uint) error {
+	claims := auth.CallerFromContext(ctx)
+	if claims == nil {
+		return status.Error(codes.Unauthenticated, "token de acesso ausente")
+	}
+	if claims.HasPermission(auth.PermNotificationsManage) {
+		return nil
+	}
+	if claims.Kind == auth.KindUser && claims.Subject == strconv.FormatUint(uint64(userID), 10) {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "apenas o próprio usuário ou quem gerencia as notificações pode acessar as preferências")
+}
+
+// toPreferencesResponse converte as preferências para a resposta gRPC, com os eventos em
+// ordem alfabética
+func toPreferencesResponse(preference *domain.NotificationPreference) *pb.NotificationPreferences {
+	response := &pb.NotificationPreferences{
+		UserId:       uint32(preference.UserID),
+		Locale:       preference.Locale,
+		Timezone:     preference.Timezone,
+		QuietStart:   preference.QuietStart,
+		QuietEnd:     preference.QuietEnd,
+		Unsubscribed: preference.Unsubscribed,
+	}
+	events := make([]string, 0, len(preference.Channels))
+	for event := range preference.Channels {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		response.Channels = append(response.Channels, &pb.EventChannels{Event: event, Channels: preference.Channels[event]})
+	}
+	if preference.UnsubscribedAt != nil {
+		response.UnsubscribedAt = preference.UnsubscribedAt.Format(time.RFC3339)
+	}
+	if !preference.UpdatedAt.IsZero() {
+		response.UpdatedAt = preference.UpdatedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// preferenceError converte os erros das preferências em status gRPC
+func preferenceError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidPreferences), errors.Is(err, unsubscribe.ErrInvalidToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrUnsubscribeDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,122 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"notification-service/internal/domain"
+	"notification-service/internal/service"
+	"notification-service/internal/templates"
+	pb "notification-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTemplates lista os modelos das mensagens e as versões gravadas
+func (s *NotificationGRPCServer) ListTemplates(ctx context.Context, req *pb.ListTemplatesRequest) (*pb.ListTemplatesResponse, error) {
+	list, err := s.templateService.ListTemplates(req.GetEvent(), req.GetLocale())
+	if err != nil {
+		return nil, templateError(err)
+	}
+
+	response := &pb.ListTemplatesResponse{}
+	for _, template := range list {
+		response.Templates = append(response.Templates, toTemplateResponse(template))
+	}
+	return response, nil
+}
+
+// SaveTemplate grava uma nova versão do modelo em nome de quem chamou
+func (s *NotificationGRPCServer) SaveTemplate(ctx context.Context, req *pb.SaveTemplateRequest) (*pb.NotificationTemplate, error) {
+	template, err := s.templateService.SaveTemplate(service.SaveTemplateInput{
+		Event:     req.GetEvent(),
+		Locale:    req.GetLocale(),
+		Subject:   req.GetSubject(),
+		Text:      req.GetText(),
+		HTML:      req.GetHtml(),
+		CreatedBy: caller(ctx),
+	})
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return toTemplateResponse(template), nil
+}
+
+// ActivateTemplate volta a usar uma versão gravada do modelo
+func (s *NotificationGRPCServer) ActivateTemplate(ctx context.Context, req *pb.ActivateTemplateRequest) (*pb.NotificationTemplate, error) {
+	template, err := s.templateService.ActivateTemplate(req.GetEvent(), req.GetLocale(), int(req.GetVersion()))
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return toTemplateResponse(template), nil
+}
+
+// PreviewTemplate monta um modelo com os dados de exemplo do evento
+func (s *NotificationGRPCServer) PreviewTemplate(ctx context.Context, req *pb.PreviewTemplateRequest) (*pb.RenderedTemplate, error) {
+	rendered, err := s.templateService.Preview(service.PreviewInput{
+		Event:   req.GetEvent(),
+		Locale:  req.GetLocale(),
+		Version: int(req.GetVersion()),
+		Subject: req.GetSubject(),
+		Text:    req.GetText(),
+		HTML:    req.GetHtml(),
+	})
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return &pb.RenderedTemplate{
+		Event:    rendered.Event,
+		Locale:   rendered.Locale,
+		Version:  int32(rendered.Version),
+		Subject:  rendered.Subject,
+		Text:     rendered.Text,
+		Html:     rendered.HTML,
+		Fallback: rendered.Fallback,
+	}, nil
+}
+
+// toTemplateResponse converte o modelo para a resposta gRPC; os modelos da base não
+// têm autor nem data
+func toTemplateResponse(template *domain.NotificationTemplate) *pb.NotificationTemplate {
+	response := &pb.NotificationTemplate{
+		Event:     template.Event,
+		Locale:    template.Locale,
+		Version:   int32(template.Version),
+		Subject:   template.Subject,
+		Text:      template.Text,
+		Html:      template.HTML,
+		Active:    template.Active,
+		CreatedBy: template.CreatedBy,
+	}
+	if !template.CreatedAt.IsZero() {
+		response.CreatedAt = template.CreatedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// caller identifica quem chamou, pelo e-mail ou pelo sujeito do token
+func caller(ctx context.Context) string {
+	claims := auth.CallerFromContext(ctx)
+	if claims == nil {
+		return "desconhecido"
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.Subject
+}
+
+// templateError converte os erros dos modelos em status gRPC
+func templateError(err error) error {
+	switch {
+	case errors.Is(err, templates.ErrTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, templates.ErrInvalidTemplate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
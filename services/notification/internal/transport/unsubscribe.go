@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+
+	"notification-service/internal/service"
+	"notification-service/internal/unsubscribe"
+)
+
+// UnsubscribePath é o caminho do link de cancelamento de inscrição dos e-mails
+const UnsubscribePath = "/unsubscribe"
+
+// maxUnsubscribeBody limita o formulário aceito no cancelamento
+const maxUnsubscribeBody = 1 << 10
+
+// unsubscribePage é a página de confirmação e de resultado do cancelamento
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head><meta charset="utf-8"><title>Cancelar inscrição</title></head>
+<body>
+<p>{{.Message}}</p>
+{{if .Token}}<form method="post" action="?token={{.Token}}"><button type="submit">Cancelar inscrição</button></form>{{end}}
+</body>
+</html>
+`))
+
+// unsubscribeHandler atende o link de cancelamento de inscrição em um clique
+type unsubscribeHandler struct {
+	preferenceService service.PreferenceService
+}
+
+// NewUnsubscribeHandler cria o handler do link de cancelamento. GET mostra a
+// confirmação, para que pré-visualizações e antivírus que abrem os links não cancelem a
+// inscrição; POST cancela, como feito pelos clientes de e-mail com List-Unsubscribe-Post
+// (RFC 8058). O token vem na query string.
+func NewUnsubscribeHandler(preferenceService service.PreferenceService) http.Handler {
+	return &unsubscribeHandler{preferenceService: preferenceService}
+}
+
+// ServeHTTP valida o token e cancela a inscrição do usuário
+func (h *unsubscribeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+
+	switch r.Method {
+	case http.MethodGet:
+		if token == "" {
+			h.render(w, http.StatusBadRequest, "Link de cancelamento inválido.", "")
+			return
+		}
+		h.render(w, http.StatusOK, "Deseja deixar de receber as notificações dos seus pedidos por todos os canais?", token)
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, maxUnsubscribeBody)
+		if err := r.ParseForm(); err != nil {
+			h.render(w, http.StatusBadRequest, "Requisição inválida.", "")
+			return
+		}
+		if token == "" {
+			token = r.PostForm.Get("token")
+		}
+
+		_, err := h.preferenceService.Unsubscribe(token)
+		switch {
+		case err == nil:
+			h.render(w, http.StatusOK, "Inscrição cancelada. Você não receberá mais as notificações dos seus pedidos.", "")
+		case errors.Is(err, unsubscribe.ErrInvalidToken):
+			log.Printf("🚫 Cancelamento com token inválido de %s", r.RemoteAddr)
+			h.render(w, http.StatusBadRequest, "Link de cancelamento inválido.", "")
+		default:
+			log.Printf("❌ Erro ao cancelar inscrição: %v", err)
+			h.render(w, http.StatusInternalServerError, "Não foi possível cancelar a inscrição. Tente novamente mais tarde.", "")
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "método não permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// render escreve a página com a mensagem e, com token, o botão de confirmação
+func (h *unsubscribeHandler) render(w http.ResponseWriter, code int, message, token string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	unsubscribePage.Execute(w, struct{ Message, Token string }{message, token})
+}
@@ -0,0 +1,71 @@
+// Package unsubscribe emite e valida os tokens de cancelamento de inscrição em um clique
+// enviados nos e-mails. O token identifica o usuário e é assinado com HMAC-SHA256 sobre
+// "unsubscribe:<usuário>"; não expira, e trocar o segredo invalida os links já enviados.
+package unsubscribe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidToken indica um token malformado ou com assinatura inválida
+var ErrInvalidToken = errors.New("token de cancelamento de inscrição inválido")
+
+// version é o prefixo do formato atual dos tokens
+const version = "v1"
+
+// Signer emite e valida os tokens
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner cria o assinador com o segredo NOTIFICATION_UNSUBSCRIBE_SECRET
+func NewSigner(secret string) (*Signer, error) {
+	if secret == "" {
+		return nil, errors.New("NOTIFICATION_UNSUBSCRIBE_SECRET é obrigatório")
+	}
+	return &Signer{secret: []byte(secret)}, nil
+}
+
+// Token emite o token do usuário, no formato "v1.<usuário>.<assinatura>"
+func (s *Signer) Token(userID uint) string {
+	id := strconv.FormatUint(uint64(userID), 10)
+	return version + "." + id + "." + s.sign(id)
+}
+
+// Verify valida o token e retorna o usuário
+func (s *Signer) Verify(token string) (uint, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != version {
+		return 0, ErrInvalidToken
+	}
+	userID, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil || userID == 0 {
+		return 0, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(parts[1]))) {
+		return 0, ErrInvalidToken
+	}
+	return uint(userID), nil
+}
+
+// URL monta o link de cancelamento com o token do usuário na query token
+func (s *Signer) URL(base string, userID uint) string {
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(s.Token(userID))
+}
+
+// sign assina o usuário
+func (s *Signer) sign(id string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("unsubscribe:" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,47 @@
+package unsubscribe
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+	signer, err := NewSigner("segredo")
+	require.NoError(t, err)
+
+	token := signer.Token(42)
+	userID, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), userID)
+
+	other, _ := NewSigner("outro")
+	_, err = other.Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken, "assinado com outro segredo")
+
+	forged := "v1.43." + token[len("v1.42."):]
+	_, err = signer.Verify(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken, "assinatura de outro usuário")
+
+	for _, invalid := range []string{"", "v1.42", "v2.42." + token[len("v1.42."):], "v1.abc.x", "v1.0." + signer.sign("0")} {
+		_, err = signer.Verify(invalid)
+		assert.ErrorIs(t, err, ErrInvalidToken, invalid)
+	}
+
+	_, err = NewSigner("")
+	assert.Error(t, err)
+}
+
+func TestURL(t *testing.T) {
+	signer, _ := NewSigner("segredo")
+
+	link, err := url.Parse(signer.URL("https://loja.example.com/unsubscribe", 7))
+	require.NoError(t, err)
+	userID, err := signer.Verify(link.Query().Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), userID)
+
+	assert.Contains(t, signer.URL("https://loja.example.com/u?src=email", 7), "?src=email&token=v1.7.")
+}
@@ -28,6 +28,7 @@ type NotificationResponse struct {
 	Message   string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	Status    string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
 	CreatedAt string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UserId    uint32 `protobuf:"varint,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
 func (x *NotificationResponse) Reset() {
@@ -86,53 +87,843 @@ func (x *NotificationResponse) GetCreatedAt() string {
 	return ""
 }
 
+func (x *NotificationResponse) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 // ListNotificationsRequest representa uma solicitação para listar notificações
 type ListNotificationsRequest struct {
 }
 
-func (x *ListNotificationsRequest) Reset() {
-	*x = ListNotificationsRequest{}
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+// ListNotificationsResponse representa uma resposta para listar notificações
+type ListNotificationsResponse struct {
+	Notifications []*NotificationResponse `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*NotificationResponse {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+// NotificationsByOrdersRequest seleciona notificações pelos IDs dos pedidos
+type NotificationsByOrdersRequest struct {
+	OrderIds []uint32 `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+}
+
+func (x *NotificationsByOrdersRequest) Reset() {
+	*x = NotificationsByOrdersRequest{}
+}
+
+func (x *NotificationsByOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationsByOrdersRequest) ProtoMessage() {}
+
+func (x *NotificationsByOrdersRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *NotificationsByOrdersRequest) GetOrderIds() []uint32 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+// RedactNotificationsResponse informa quantas notificações foram anonimizadas
+type RedactNotificationsResponse struct {
+	Affected uint32 `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+}
+
+func (x *RedactNotificationsResponse) Reset() {
+	*x = RedactNotificationsResponse{}
+}
+
+func (x *RedactNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedactNotificationsResponse) ProtoMessage() {}
+
+func (x *RedactNotificationsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *RedactNotificationsResponse) GetAffected() uint32 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+// DeliveriesRequest seleciona as tentativas de entrega de uma notificação
+type DeliveriesRequest struct {
+	NotificationId uint32 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+}
+
+func (x *DeliveriesRequest) Reset() {
+	*x = DeliveriesRequest{}
+}
+
+func (x *DeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveriesRequest) ProtoMessage() {}
+
+func (x *DeliveriesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DeliveriesRequest) GetNotificationId() uint32 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+// DeliveryAttempt é uma tentativa de entrega por um canal (log, smtp, sms, webhook ou
+// slack): status sent, failed ou suppressed, resposta do provedor e erro; created_at em
+// RFC 3339. reason é o motivo da supressão (unsubscribed, channel_disabled ou quiet_hours)
+type DeliveryAttempt struct {
+	Id         uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Channel    string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Attempt    int32  `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Status     string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Response   string `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"`
+	Error      string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs int64  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt  string `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Reason     string `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DeliveryAttempt) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// DeliveriesResponse representa as tentativas de entrega de uma notificação
+type DeliveriesResponse struct {
+	Deliveries []*DeliveryAttempt `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+}
+
+func (x *DeliveriesResponse) Reset() {
+	*x = DeliveriesResponse{}
+}
+
+func (x *DeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveriesResponse) ProtoMessage() {}
+
+func (x *DeliveriesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DeliveriesResponse) GetDeliveries() []*DeliveryAttempt {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+// NotificationTemplate é um modelo de mensagem de um evento (payment.approved,
+// dispute.opened...) em uma localidade (pt-BR, en-US ou es). A versão 0 é o modelo
+// embutido ou da pasta configurada; as gravadas começam em 1 e só uma fica ativa.
+type NotificationTemplate struct {
+	Event     string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Locale    string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Version   int32  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Subject   string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Text      string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Html      string `protobuf:"bytes,6,opt,name=html,proto3" json:"html,omitempty"`
+	Active    bool   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedBy string `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *NotificationTemplate) Reset() {
+	*x = NotificationTemplate{}
+}
+
+func (x *NotificationTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationTemplate) ProtoMessage() {}
+
+func (x *NotificationTemplate) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *NotificationTemplate) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *NotificationTemplate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *NotificationTemplate) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// ListTemplatesRequest filtra os modelos por evento e localidade; vazios não filtram
+type ListTemplatesRequest struct {
+	Event  string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Locale string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListTemplatesRequest) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *ListTemplatesRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// ListTemplatesResponse lista os modelos da base e as versões gravadas
+type ListTemplatesResponse struct {
+	Templates []*NotificationTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*NotificationTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// SaveTemplateRequest grava uma nova versão do modelo, que passa a valer. subject, text
+// e html usam text/template (html com escape de html/template), com as funções money e
+// datetime na localidade do modelo
+type SaveTemplateRequest struct {
+	Event   string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Locale  string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Text    string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Html    string `protobuf:"bytes,5,opt,name=html,proto3" json:"html,omitempty"`
+}
+
+func (x *SaveTemplateRequest) Reset() {
+	*x = SaveTemplateRequest{}
+}
+
+func (x *SaveTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTemplateRequest) ProtoMessage() {}
+
+func (x *SaveTemplateRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SaveTemplateRequest) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *SaveTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SaveTemplateRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SaveTemplateRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SaveTemplateRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+// ActivateTemplateRequest volta a usar uma versão gravada
+type ActivateTemplateRequest struct {
+	Event   string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Locale  string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Version int32  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *ActivateTemplateRequest) Reset() {
+	*x = ActivateTemplateRequest{}
+}
+
+func (x *ActivateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateTemplateRequest) ProtoMessage() {}
+
+func (x *ActivateTemplateRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ActivateTemplateRequest) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *ActivateTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ActivateTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// PreviewTemplateRequest monta o modelo com dados de exemplo do evento: o rascunho
+// informado em subject/text/html, a versão gravada em version ou, sem nenhum dos dois,
+// o modelo em uso com as regras de fallback
+type PreviewTemplateRequest struct {
+	Event   string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Locale  string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Version int32  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Subject string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Text    string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Html    string `protobuf:"bytes,6,opt,name=html,proto3" json:"html,omitempty"`
+}
+
+func (x *PreviewTemplateRequest) Reset() {
+	*x = PreviewTemplateRequest{}
+}
+
+func (x *PreviewTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewTemplateRequest) ProtoMessage() {}
+
+func (x *PreviewTemplateRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PreviewTemplateRequest) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *PreviewTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PreviewTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PreviewTemplateRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PreviewTemplateRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PreviewTemplateRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+// RenderedTemplate é a mensagem montada; fallback indica que o modelo usado é de outra
+// localidade ou do evento genérico (payment.status, dispute.status)
+type RenderedTemplate struct {
+	Event    string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Locale   string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Version  int32  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Subject  string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Text     string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Html     string `protobuf:"bytes,6,opt,name=html,proto3" json:"html,omitempty"`
+	Fallback bool   `protobuf:"varint,7,opt,name=fallback,proto3" json:"fallback,omitempty"`
+}
+
+func (x *RenderedTemplate) Reset() {
+	*x = RenderedTemplate{}
+}
+
+func (x *RenderedTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderedTemplate) ProtoMessage() {}
+
+func (x *RenderedTemplate) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *RenderedTemplate) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *RenderedTemplate) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *RenderedTemplate) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RenderedTemplate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RenderedTemplate) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *RenderedTemplate) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *RenderedTemplate) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+// EventChannels são os canais aceitos para um evento, família ou "*"; a lista vazia
+// desliga o evento em todos os canais
+type EventChannels struct {
+	Event    string   `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Channels []string `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *EventChannels) Reset() {
+	*x = EventChannels{}
+}
+
+func (x *EventChannels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventChannels) ProtoMessage() {}
+
+func (x *EventChannels) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *EventChannels) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *EventChannels) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// NotificationPreferences são as preferências de notificação de um usuário. channels
+// indica os canais por evento (payment.approved), família (payment.*, dispute.*) ou "*";
+// eventos sem entrada usam todos os canais. A atualização substitui todas as preferências. quiet_start e quiet_end (HH:MM no fuso
+// timezone) definem o horário de silêncio; unsubscribed suspende todas as notificações.
+type NotificationPreferences struct {
+	UserId         uint32           `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Locale         string           `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Timezone       string           `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	QuietStart     string           `protobuf:"bytes,4,opt,name=quiet_start,json=quietStart,proto3" json:"quiet_start,omitempty"`
+	QuietEnd       string           `protobuf:"bytes,5,opt,name=quiet_end,json=quietEnd,proto3" json:"quiet_end,omitempty"`
+	Channels       []*EventChannels `protobuf:"bytes,6,rep,name=channels,proto3" json:"channels,omitempty"`
+	Unsubscribed   bool             `protobuf:"varint,7,opt,name=unsubscribed,proto3" json:"unsubscribed,omitempty"`
+	UnsubscribedAt string           `protobuf:"bytes,8,opt,name=unsubscribed_at,json=unsubscribedAt,proto3" json:"unsubscribed_at,omitempty"`
+	UpdatedAt      string           `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *NotificationPreferences) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *NotificationPreferences) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetQuietStart() string {
+	if x != nil {
+		return x.QuietStart
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetQuietEnd() string {
+	if x != nil {
+		return x.QuietEnd
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetChannels() []*EventChannels {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetUnsubscribed() bool {
+	if x != nil {
+		return x.Unsubscribed
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetUnsubscribedAt() string {
+	if x != nil {
+		return x.UnsubscribedAt
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// GetPreferencesRequest seleciona as preferências de um usuário
+type GetPreferencesRequest struct {
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *ListNotificationsRequest) String() string {
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+}
+
+func (x *GetPreferencesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsRequest) ProtoMessage() {}
+func (*GetPreferencesRequest) ProtoMessage() {}
 
-func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
 	return nil
 }
 
-// ListNotificationsResponse representa uma resposta para listar notificações
-type ListNotificationsResponse struct {
-	Notifications []*NotificationResponse `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+func (x *GetPreferencesRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-func (x *ListNotificationsResponse) Reset() {
-	*x = ListNotificationsResponse{}
+// UnsubscribeRequest cancela a inscrição com o token do link dos e-mails
+type UnsubscribeRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 }
 
-func (x *ListNotificationsResponse) String() string {
+func (x *UnsubscribeRequest) Reset() {
+	*x = UnsubscribeRequest{}
+}
+
+func (x *UnsubscribeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsResponse) ProtoMessage() {}
+func (*UnsubscribeRequest) ProtoMessage() {}
 
-func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+func (x *UnsubscribeRequest) ProtoReflect() protoreflect.Message {
 	return nil
 }
 
-func (x *ListNotificationsResponse) GetNotifications() []*NotificationResponse {
+func (x *UnsubscribeRequest) GetToken() string {
 	if x != nil {
-		return x.Notifications
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
 // NotificationServiceClient é o cliente do serviço de notificações
 type NotificationServiceClient interface {
 	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	ExportNotifications(ctx context.Context, in *NotificationsByOrdersRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	RedactNotifications(ctx context.Context, in *NotificationsByOrdersRequest, opts ...grpc.CallOption) (*RedactNotificationsResponse, error)
+	ListDeliveries(ctx context.Context, in *DeliveriesRequest, opts ...grpc.CallOption) (*DeliveriesResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	ActivateTemplate(ctx context.Context, in *ActivateTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	PreviewTemplate(ctx context.Context, in *PreviewTemplateRequest, opts ...grpc.CallOption) (*RenderedTemplate, error)
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, in *NotificationPreferences, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
 }
 
 type notificationServiceClient struct {
@@ -152,9 +943,109 @@ func (c *notificationServiceClient) ListNotifications(ctx context.Context, in *L
 	return out, nil
 }
 
+func (c *notificationServiceClient) ExportNotifications(ctx context.Context, in *NotificationsByOrdersRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/ExportNotifications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) RedactNotifications(ctx context.Context, in *NotificationsByOrdersRequest, opts ...grpc.CallOption) (*RedactNotificationsResponse, error) {
+	out := new(RedactNotificationsResponse)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/RedactNotifications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ListDeliveries(ctx context.Context, in *DeliveriesRequest, opts ...grpc.CallOption) (*DeliveriesResponse, error) {
+	out := new(DeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/ListDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/ListTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/SaveTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ActivateTemplate(ctx context.Context, in *ActivateTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/ActivateTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) PreviewTemplate(ctx context.Context, in *PreviewTemplateRequest, opts ...grpc.CallOption) (*RenderedTemplate, error) {
+	out := new(RenderedTemplate)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/PreviewTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/GetPreferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) UpdatePreferences(ctx context.Context, in *NotificationPreferences, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/UpdatePreferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/Unsubscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer é o servidor do serviço de notificações
 type NotificationServiceServer interface {
 	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	ExportNotifications(context.Context, *NotificationsByOrdersRequest) (*ListNotificationsResponse, error)
+	RedactNotifications(context.Context, *NotificationsByOrdersRequest) (*RedactNotificationsResponse, error)
+	ListDeliveries(context.Context, *DeliveriesRequest) (*DeliveriesResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	SaveTemplate(context.Context, *SaveTemplateRequest) (*NotificationTemplate, error)
+	ActivateTemplate(context.Context, *ActivateTemplateRequest) (*NotificationTemplate, error)
+	PreviewTemplate(context.Context, *PreviewTemplateRequest) (*RenderedTemplate, error)
+	GetPreferences(context.Context, *GetPreferencesRequest) (*NotificationPreferences, error)
+	UpdatePreferences(context.Context, *NotificationPreferences) (*NotificationPreferences, error)
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*NotificationPreferences, error)
 }
 
 // UnimplementedNotificationServiceServer deve ser embedded para ter implementações forward compatible
@@ -165,6 +1056,46 @@ func (UnimplementedNotificationServiceServer) ListNotifications(context.Context,
 	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
 }
 
+func (UnimplementedNotificationServiceServer) ExportNotifications(context.Context, *NotificationsByOrdersRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportNotifications not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) RedactNotifications(context.Context, *NotificationsByOrdersRequest) (*RedactNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedactNotifications not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) ListDeliveries(context.Context, *DeliveriesRequest) (*DeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) SaveTemplate(context.Context, *SaveTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTemplate not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) ActivateTemplate(context.Context, *ActivateTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateTemplate not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) PreviewTemplate(context.Context, *PreviewTemplateRequest) (*RenderedTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewTemplate not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) GetPreferences(context.Context, *GetPreferencesRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) UpdatePreferences(context.Context, *NotificationPreferences) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreferences not implemented")
+}
+
+func (UnimplementedNotificationServiceServer) Unsubscribe(context.Context, *UnsubscribeRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+
 // RegisterNotificationServiceServer registra o serviço no servidor gRPC
 func RegisterNotificationServiceServer(s grpc.ServiceRegistrar, srv NotificationServiceServer) {
 	s.RegisterService(&NotificationService_ServiceDesc, srv)
@@ -188,6 +1119,186 @@ func _NotificationService_ListNotifications_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_ExportNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationsByOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ExportNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/ExportNotifications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ExportNotifications(ctx, req.(*NotificationsByOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_RedactNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationsByOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).RedactNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/RedactNotifications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).RedactNotifications(ctx, req.(*NotificationsByOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/ListDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ListDeliveries(ctx, req.(*DeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/ListTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_SaveTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).SaveTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/SaveTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).SaveTemplate(ctx, req.(*SaveTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ActivateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ActivateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/ActivateTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ActivateTemplate(ctx, req.(*ActivateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_PreviewTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).PreviewTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/PreviewTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).PreviewTemplate(ctx, req.(*PreviewTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/GetPreferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_UpdatePreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationPreferences)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).UpdatePreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/UpdatePreferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).UpdatePreferences(ctx, req.(*NotificationPreferences))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.NotificationService/Unsubscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc é o descritor do serviço gRPC
 var NotificationService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "notification.NotificationService",
@@ -197,6 +1308,46 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListNotifications",
 			Handler:    _NotificationService_ListNotifications_Handler,
 		},
+		{
+			MethodName: "ExportNotifications",
+			Handler:    _NotificationService_ExportNotifications_Handler,
+		},
+		{
+			MethodName: "RedactNotifications",
+			Handler:    _NotificationService_RedactNotifications_Handler,
+		},
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _NotificationService_ListDeliveries_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _NotificationService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "SaveTemplate",
+			Handler:    _NotificationService_SaveTemplate_Handler,
+		},
+		{
+			MethodName: "ActivateTemplate",
+			Handler:    _NotificationService_ActivateTemplate_Handler,
+		},
+		{
+			MethodName: "PreviewTemplate",
+			Handler:    _NotificationService_PreviewTemplate_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _NotificationService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreferences",
+			Handler:    _NotificationService_UpdatePreferences_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _NotificationService_Unsubscribe_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification.proto",
@@ -7,10 +7,12 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/clients"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/config"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/messaging"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/metrics"
@@ -19,6 +21,7 @@ import (
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/telemetry"
 	grpcServer "github.com/seu-usuario/go-microservices-architecture/services/order/internal/transport/grpc"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/proto"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
 )
 
 func main() {
@@ -37,6 +40,46 @@ func main() {
 	shutdown := telemetry.InitTracer("order-service")
 	defer shutdown(ctx)
 
+	// 🔐 Validador de tokens para autorização das chamadas gRPC
+	tokens, err := auth.NewTokenManager(config.GetEnv("JWT_SECRET", ""), config.GetEnv("JWT_ISSUER", "user-service"))
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar autenticação: %v", err)
+	}
+
+	// 🤖 Conta de serviço usada nas chamadas ao catalog-service
+	serviceCreds, err := auth.NewServiceCredentials(
+		config.GetEnv("USER_SERVICE_URL", "user:50051"),
+		config.GetEnv("SERVICE_CLIENT_ID", "order-service"),
+		config.GetEnv("SERVICE_CLIENT_SECRET", ""),
+	)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar conta de serviço: %v", err)
+	}
+	defer serviceCreds.Close()
+
+	// 📦 Cliente de reservas de estoque do catalog-service, com o prazo da reserva por forma
+	// de pagamento: o boleto fica reservado até o vencimento, a tolerância e o retorno do banco
+	var reservationTTL service.ReservationTTLs
+	for _, ttl := range []struct {
+		env, fallback string
+		target        *time.Duration
+	}{
+		{"RESERVATION_TTL", "15m", &reservationTTL.Default},
+		{"RESERVATION_TTL_PIX", "30m", &reservationTTL.Pix},
+		{"RESERVATION_TTL_BOLETO", "288h", &reservationTTL.Boleto},
+	} {
+		value, err := time.ParseDuration(config.GetEnv(ttl.env, ttl.fallback))
+		if err != nil {
+			log.Fatalf("❌ %s inválido: %v", ttl.env, err)
+		}
+		*ttl.target = value
+	}
+	inventoryClient, err := clients.NewInventoryClient(config.GetEnv("CATALOG_SERVICE_URL", "catalog:50054"), serviceCreds.DialOption())
+	if err != nil {
+		log.Fatalf("❌ Erro ao conectar ao Catalog Service: %v", err)
+	}
+	defer inventoryClient.Close()
+
 	// 🧩 Conectar ao banco de dados
 	log.Println("🔗 Conectando ao banco de dados...")
 	db := config.ConnectDatabase()
@@ -46,6 +89,11 @@ func main() {
 		log.Fatalf("❌ Erro ao executar migração: %v", err)
 	}
 
+	// 🧾 Carga inicial da tabela de alíquotas
+	if err := config.SeedTaxRates(db); err != nil {
+		log.Fatalf("❌ Erro ao inicializar alíquotas: %v", err)
+	}
+
 	// 🐰 Conectar ao RabbitMQ
 	log.Println("🐰 Conectando ao RabbitMQ...")
 	rabbitmqConn, err := messaging.NewRabbitMQConnection()
@@ -58,14 +106,34 @@ func main() {
 	// 🏗️ Inicializar dependências
 	log.Println("🏗️  Inicializando dependências...")
 	orderRepo := repository.NewOrderRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	taxRateRepo := repository.NewTaxRateRepository(db)
 
 	var orderPublisher *messaging.OrderPublisher
 	if rabbitmqConn != nil {
 		orderPublisher = messaging.NewOrderPublisher(rabbitmqConn)
 	}
 
-	orderService := service.NewOrderService(orderRepo, orderPublisher)
-	orderGRPCServer := grpcServer.NewOrderGRPCServer(orderService)
+	promotionService := service.NewPromotionService(promotionRepo)
+	taxService := service.NewTaxService(taxRateRepo, config.GetEnv("DEFAULT_DESTINATION_STATE", "SP"))
+	orderService := service.NewOrderService(orderRepo, orderPublisher, inventoryClient, promotionService, taxService, reservationTTL)
+	orderGRPCServer := grpcServer.NewOrderGRPCServer(orderService, promotionService, taxService)
+
+	// 💳 Consumir resultados de pagamento para confirmar ou liberar as reservas
+	consumerCtx, stopConsumer := context.WithCancel(ctx)
+	defer stopConsumer()
+	if rabbitmqConn != nil {
+		paymentConsumer, err := messaging.NewPaymentConsumer(rabbitmqConn, orderService.HandlePaymentResult)
+		if err != nil {
+			log.Printf("⚠️ Erro ao criar consumer de pagamentos: %v", err)
+		} else {
+			go func() {
+				if err := paymentConsumer.ConsumePayments(consumerCtx); err != nil && err != context.Canceled {
+					log.Printf("❌ Consumer de pagamentos finalizado com erro: %v", err)
+				}
+			}()
+		}
+	}
 
 	// 🚀 Configurar servidor gRPC
 	port := config.GetEnv("SERVICE_PORT", "50053")
@@ -74,8 +142,11 @@ func main() {
 		log.Fatalf("❌ Erro ao iniciar listener: %v", err)
 	}
 
-	// 📡 Criar e configurar servidor gRPC
-	s := grpc.NewServer()
+	// 📡 Criar e configurar servidor gRPC com autorização por método
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens, grpcServer.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens, grpcServer.MethodPermissions)),
+	)
 	proto.RegisterOrderServiceServer(s, orderGRPCServer)
 
 	// 🎯 Iniciar servidor em goroutine
@@ -92,6 +163,7 @@ func main() {
 	<-quit
 
 	log.Println("🛑 Desligando OrderService...")
+	stopConsumer()
 	s.GracefulStop()
 
 	// Fechar conexão RabbitMQ se existir
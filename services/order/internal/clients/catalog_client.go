@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ReserveStockRequest representa a reserva de estoque de um produto no catalog-service
+type ReserveStockRequest struct {
+	ProductID  string `json:"product_id"`
+	SKU        string `json:"sku"`
+	Quantity   int32  `json:"quantity"`
+	Reference  string `json:"reference"`
+	TTLSeconds int64  `json:"ttl_seconds"`
+}
+
+// ReservationRequest identifica uma reserva de estoque
+type ReservationRequest struct {
+	ID string `json:"id"`
+}
+
+// VariantAttribute representa um atributo da variação reservada
+type VariantAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Money representa um valor em unidades menores (centavos) da moeda, como enviado
+// pelo catalog-service
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Reservation representa uma reserva de estoque retornada pelo catalog-service.
+// Nome, preço, atributos e classe fiscal refletem o produto e a variação no momento da reserva.
+type Reservation struct {
+	ID          string             `json:"id"`
+	ProductID   string             `json:"product_id"`
+	SKU         string             `json:"sku"`
+	Quantity    int32              `json:"quantity"`
+	Reference   string             `json:"reference"`
+	Status      string             `json:"status"`
+	ExpiresAt   string             `json:"expires_at"`
+	VariantID   string             `json:"variant_id"`
+	ProductName string             `json:"product_name"`
+	UnitPrice   *Money             `json:"unit_price"`
+	Attributes  []VariantAttribute `json:"attributes"`
+	TaxClass    string             `json:"tax_class"`
+}
+
+// InventoryClient expõe as operações de reserva de estoque do catalog-service
+type InventoryClient interface {
+	Reserve(ctx context.Context, sku string, quantity int, reference string, ttl time.Duration) (*Reservation, error)
+	Commit(ctx context.Context, reservationID string) (*Reservation, error)
+	Release(ctx context.Context, reservationID string) (*Reservation, error)
+	Close() error
+}
+
+// grpcInventoryClient implementa InventoryClient usando gRPC
+type grpcInventoryClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewInventoryClient conecta ao catalog-service; opts normalmente inclui as credenciais
+// da conta de serviço.
+func NewInventoryClient(addr string, opts ...grpc.DialOption) (InventoryClient, error) {
+	log.Printf("🔌 Configurando conexão com Catalog Service em %s...", addr)
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(addr, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcInventoryClient{conn: conn}, nil
+}
+
+// Reserve reserva estoque do produto ou da variação identificada pelo SKU pelo prazo ttl
+// (zero usa o padrão do catálogo)
+func (c *grpcInventoryClient) Reserve(ctx context.Context, sku string, quantity int, reference string, ttl time.Duration) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.conn.Invoke(ctx, "/catalog.CatalogService/ReserveStock", &ReserveStockRequest{
+		SKU:        sku,
+		Quantity:   int32(quantity),
+		Reference:  reference,
+		TTLSeconds: int64(ttl / time.Second),
+	}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Commit confirma a reserva após o pagamento aprovado
+func (c *grpcInventoryClient) Commit(ctx context.Context, reservationID string) (*Reservation, error) {
+	out := new(Reservation)
+	if err := c.conn.Invoke(ctx, "/catalog.CatalogService/CommitReservation", &ReservationRequest{ID: reservationID}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Release devolve a reserva ao estoque
+func (c *grpcInventoryClient) Release(ctx context.Context, reservationID string) (*Reservation, error) {
+	out := new(Reservation)
+	if err := c.conn.Invoke(ctx, "/catalog.CatalogService/ReleaseReservation", &ReservationRequest{ID: reservationID}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Close fecha a conexão com o catalog-service
+func (c *grpcInventoryClient) Close() error {
+	return c.conn.Close()
+}
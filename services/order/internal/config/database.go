@@ -45,12 +45,17 @@ func ConnectDatabase() *gorm.DB {
 func AutoMigrate(db *gorm.DB) error {
 	log.Println("🔄 Executando migração do banco de dados...")
 
-	err := db.AutoMigrate(&domain.Order{})
+	err := db.AutoMigrate(&domain.Order{}, &domain.OrderDiscount{}, &domain.Promotion{}, &domain.OrderTax{}, &domain.TaxRate{})
 	if err != nil {
 		log.Printf("❌ Erro ao executar migração: %v", err)
 		return err
 	}
 
+	if err := MigrateMoneyColumns(db); err != nil {
+		log.Printf("❌ Erro ao migrar valores monetários: %v", err)
+		return err
+	}
+
 	log.Println("✅ Migração concluída com sucesso")
 	return nil
 }
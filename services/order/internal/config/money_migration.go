@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// legacyMoneyColumn é uma coluna decimal de valores em reais substituída por uma
+// coluna inteira em centavos
+type legacyMoneyColumn struct {
+	table  string
+	legacy string
+	column string
+	// where restringe as linhas convertidas (ex.: apenas promoções de valor fixo)
+	where string
+}
+
+var legacyMoneyColumns = []legacyMoneyColumn{
+	{table: "orders", legacy: "price", column: "price_minor"},
+	{table: "orders", legacy: "subtotal", column: "subtotal_minor"},
+	{table: "orders", legacy: "discount_total", column: "discount_total_minor"},
+	{table: "orders", legacy: "tax_total", column: "tax_total_minor"},
+	{table: "orders", legacy: "total", column: "total_minor"},
+	{table: "order_discounts", legacy: "amount", column: "amount_minor"},
+	{table: "order_taxes", legacy: "base", column: "base_minor"},
+	{table: "order_taxes", legacy: "amount", column: "amount_minor"},
+	{table: "promotions", legacy: "min_subtotal", column: "min_subtotal_minor"},
+	{table: "promotions", legacy: "value", column: "amount_minor", where: "type = 'fixed'"},
+}
+
+// MigrateMoneyColumns copia os valores das antigas colunas decimais para as colunas em
+// centavos e remove as antigas. O arredondamento é feito pelo banco em DECIMAL (meio
+// centavo para cima), nunca em ponto flutuante. Deve rodar depois do AutoMigrate, que
+// cria as novas colunas; colunas já migradas são ignoradas.
+func MigrateMoneyColumns(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, c := range legacyMoneyColumns {
+		if !migrator.HasTable(c.table) || !migrator.HasColumn(c.table, c.legacy) {
+			continue
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET %s = ROUND(CAST(%s AS DECIMAL(18,4)) * 100) WHERE %s = 0",
+			c.table, c.column, c.legacy, c.column)
+		if c.where != "" {
+			query += " AND " + c.where
+		}
+		result := db.Exec(query)
+		if result.Error != nil {
+			return fmt.Errorf("falha ao converter %s.%s: %w", c.table, c.legacy, result.Error)
+		}
+
+		// A coluna value das promoções continua guardando o percentual
+		if c.table == "promotions" && c.legacy == "value" {
+			continue
+		}
+		if err := migrator.DropColumn(c.table, c.legacy); err != nil {
+			return fmt.Errorf("falha ao remover %s.%s: %w", c.table, c.legacy, err)
+		}
+		log.Printf("💱 Coluna %s.%s convertida para %s (%d registros)", c.table, c.legacy, c.column, result.RowsAffected)
+	}
+	return nil
+}
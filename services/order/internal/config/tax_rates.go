@@ -0,0 +1,44 @@
+package config
+
+import (
+	"log"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/tax"
+	"gorm.io/gorm"
+)
+
+// icmsInternalRates são alíquotas internas de ICMS de referência por UF. Servem apenas
+// como carga inicial; a partir daí a tabela é mantida pelo financeiro via SetTaxRate.
+var icmsInternalRates = map[string]float64{
+	"AC": 19, "AL": 19, "AM": 20, "AP": 18, "BA": 20.5, "CE": 20, "DF": 20,
+	"ES": 17, "GO": 19, "MA": 22, "MG": 18, "MS": 17, "MT": 17, "PA": 19,
+	"PB": 20, "PE": 20.5, "PI": 21, "PR": 19.5, "RJ": 22, "RN": 18, "RO": 19.5,
+	"RR": 20, "RS": 17, "SC": 17, "SE": 19, "SP": 18, "TO": 20,
+}
+
+// SeedTaxRates popula a tabela de alíquotas quando ela ainda está vazia
+func SeedTaxRates(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&domain.TaxRate{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	rates := []domain.TaxRate{
+		{TaxClass: "service", State: tax.AnyState, Tax: tax.ISS, Rate: 5},
+		{TaxClass: "exempt", State: tax.AnyState, Tax: tax.ICMS, Rate: 0},
+	}
+	for state, rate := range icmsInternalRates {
+		rates = append(rates, domain.TaxRate{TaxClass: tax.DefaultClass, State: state, Tax: tax.ICMS, Rate: rate, Included: true})
+	}
+
+	if err := db.Create(&rates).Error; err != nil {
+		return err
+	}
+
+	log.Printf("🧾 Tabela de alíquotas inicializada com %d registros", len(rates))
+	return nil
+}
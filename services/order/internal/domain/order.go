@@ -1,18 +1,139 @@
 package domain
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
 
-// Order representa um pedido no sistema
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Estados de um pedido
+const (
+	OrderStatusPending       = "pending"        // estoque reservado, aguardando pagamento
+	OrderStatusPaid          = "paid"           // pagamento aprovado e reserva confirmada
+	OrderStatusPaymentFailed = "payment_failed" // pagamento recusado, reserva liberada
+	OrderStatusCancelled     = "cancelled"      // cancelado antes do pagamento, reserva liberada
+	OrderStatusStockReview   = "stock_review"   // pago, mas a reserva venceu antes da confirmação
+)
+
+// Formas de pagamento aceitas
+const (
+	PaymentMethodCard   = "card"
+	PaymentMethodPix    = "pix"    // cobrança PIX, aprovada quando o PSP confirma o recebimento
+	PaymentMethodBoleto = "boleto" // boleto bancário, aprovado na conciliação do retorno do banco
+)
+
+// Attributes são os atributos da variação comprada (ex.: cor=azul, tamanho=M),
+// gravados como JSON em uma coluna de texto
+type Attributes map[string]string
+
+// Value serializa os atributos para o banco de dados
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan lê os atributos gravados no banco de dados
+func (a *Attributes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = Attributes{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tipo inválido para atributos: %T", value)
+	}
+	return json.Unmarshal(data, a)
+}
+
+// Order representa um pedido no sistema. O item é identificado pelo SKU (de produto ou
+// de variação); nome e atributos são copiados do catálogo no momento da compra, para que
+// alterações posteriores no catálogo não mudem o histórico do pedido.
+// O pedido tem uma única linha (Line 1 em Discounts e Taxes); Total é o valor cobrado no
+// pagamento: subtotal menos descontos mais os tributos que não estão contidos no preço.
 type Order struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Customer  string    `json:"customer" gorm:"not null"`
-	ProductID uint      `json:"product_id" gorm:"not null"`
-	Quantity  int       `json:"quantity" gorm:"not null"`
-	Price     float64   `json:"price" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Customer    string     `json:"customer" gorm:"not null"`
+	ProductID   uint       `json:"product_id" gorm:"not null"`
+	SKU         string     `json:"sku" gorm:"column:sku;size:100"`
+	VariantID   string     `json:"variant_id" gorm:"size:36"`
+	ProductName string     `json:"product_name" gorm:"size:200"`
+	Attributes  Attributes `json:"attributes" gorm:"type:text"`
+	Quantity    int        `json:"quantity" gorm:"not null"`
+	// Valores em unidades menores (centavos) da moeda do pedido
+	Currency      string `json:"currency" gorm:"size:3;not null;default:BRL"`
+	Price         int64  `json:"price" gorm:"column:price_minor;not null;default:0"`
+	Subtotal      int64  `json:"subtotal" gorm:"column:subtotal_minor;not null;default:0"`
+	DiscountTotal int64  `json:"discount_total" gorm:"column:discount_total_minor;not null;default:0"`
+	TaxTotal      int64  `json:"tax_total" gorm:"column:tax_total_minor;not null;default:0"`
+	Total         int64  `json:"total" gorm:"column:total_minor;not null;default:0"`
+	// PaymentCurrency é a moeda em que o cliente paga, convertida na cobrança
+	PaymentCurrency string `json:"payment_currency" gorm:"size:3;not null;default:BRL"`
+	// Installments é o número de parcelas pedido pelo cliente; 1 é à vista
+	Installments int `json:"installments" gorm:"not null;default:1"`
+	// PaymentMethod é a forma de pagamento escolhida: card, pix ou boleto
+	PaymentMethod string `json:"payment_method" gorm:"size:10;not null;default:card"`
+	// DestinationState é a UF de entrega, que define as alíquotas aplicadas
+	DestinationState string `json:"destination_state" gorm:"size:2"`
+	// Risk são os sinais de risco repassados à análise antifraude do payment-service
+	Risk          PaymentRisk `json:"risk" gorm:"embedded;embeddedPrefix:risk_"`
+	Status        string      `json:"status" gorm:"size:20;not null;default:pending"`
+	ReservationID string      `json:"reservation_id" gorm:"size:36"`
+	// ReservationExpiresAt é o vencimento da reserva de estoque, repassado ao payment-service
+	// para que pagamentos retidos em análise não sejam aprovados depois dele
+	ReservationExpiresAt *time.Time `json:"reservation_expires_at"`
+	CreatedAt            time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Discounts []OrderDiscount `json:"discounts,omitempty" gorm:"foreignKey:OrderID"`
+	Taxes     []OrderTax      `json:"taxes,omitempty" gorm:"foreignKey:OrderID"`
 }
 
 // TableName retorna o nome da tabela no banco de dados
 func (Order) TableName() string {
 	return "orders"
 }
+
+// PaymentRisk são os sinais de risco do pedido usados pela análise antifraude do
+// payment-service. Campos vazios apenas não disparam as regras que dependem deles.
+type PaymentRisk struct {
+	// CardFingerprint identifica o cartão sem expor o número
+	CardFingerprint string `json:"card_fingerprint,omitempty" gorm:"size:64"`
+	// BillingState é a UF do endereço de cobrança
+	BillingState string `json:"billing_state,omitempty" gorm:"size:2"`
+	// IPCountry é o país do IP do cliente (ISO 3166-1 alfa-2)
+	IPCountry string `json:"ip_country,omitempty" gorm:"size:2"`
+	// CustomerSince é a data de cadastro do cliente
+	CustomerSince *time.Time `json:"customer_since,omitempty"`
+}
+
+// IsPending indica se o pedido ainda aguarda o resultado do pagamento
+func (o *Order) IsPending() bool {
+	return o.Status == "" || o.Status == OrderStatusPending
+}
+
+// NetTotal retorna o valor a cobrar. Pedidos gravados antes dos descontos não têm
+// Total e são cobrados pelo preço unitário vezes a quantidade.
+func (o *Order) NetTotal() int64 {
+	if o.Total > 0 || o.DiscountTotal > 0 {
+		return o.Total
+	}
+	return o.Price * int64(o.Quantity)
+}
+
+// Money associa um valor do pedido, em centavos, à moeda do pedido
+func (o *Order) Money(amount int64) money.Money {
+	return money.New(amount, o.Currency)
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// StringList é uma lista de textos gravada como JSON em uma coluna de texto
+type StringList []string
+
+// Value serializa a lista para o banco de dados
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan lê a lista gravada no banco de dados
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tipo inválido para lista: %T", value)
+	}
+	return json.Unmarshal(data, l)
+}
+
+// Promotion é uma regra de desconto. Promoções automáticas valem para todos os pedidos
+// elegíveis; as demais só se aplicam quando o cliente informa o cupom (Code).
+// Value é o percentual das promoções percent; Amount, o desconto das promoções fixed,
+// e MinSubtotal estão em centavos de Currency.
+type Promotion struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Code        string     `json:"code" gorm:"size:50;not null;uniqueIndex"`
+	Name        string     `json:"name" gorm:"size:200;not null"`
+	Type        string     `json:"type" gorm:"size:20;not null"`
+	Value       float64    `json:"value" gorm:"type:decimal(10,2);not null;default:0"`
+	Amount      int64      `json:"amount" gorm:"column:amount_minor;not null;default:0"`
+	Currency    string     `json:"currency" gorm:"size:3;not null;default:BRL"`
+	BuyQuantity int        `json:"buy_quantity" gorm:"not null;default:0"`
+	GetQuantity int        `json:"get_quantity" gorm:"not null;default:0"`
+	MinSubtotal int64      `json:"min_subtotal" gorm:"column:min_subtotal_minor;not null;default:0"`
+	SKUs        StringList `json:"skus" gorm:"column:skus;type:text"`
+	Automatic   bool       `json:"automatic" gorm:"not null"`
+	Stacking    string     `json:"stacking" gorm:"size:20;not null"`
+	Priority    int        `json:"priority" gorm:"not null;default:0"`
+	ValidFrom   *time.Time `json:"valid_from"`
+	ValidTo     *time.Time `json:"valid_to"`
+	// Limites de uso por pedidos não cancelados; zero significa ilimitado
+	MaxUses            int       `json:"max_uses" gorm:"not null;default:0"`
+	MaxUsesPerCustomer int       `json:"max_uses_per_customer" gorm:"not null;default:0"`
+	Active             bool      `json:"active" gorm:"not null"`
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// OrderDiscount é o desconto de uma promoção aplicado a uma linha do pedido.
+// Código, nome e tipo são copiados da promoção no momento da compra.
+type OrderDiscount struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	OrderID     uint      `json:"order_id" gorm:"not null;index"`
+	Line        int       `json:"line" gorm:"not null"`
+	SKU         string    `json:"sku" gorm:"column:sku;size:100"`
+	PromotionID uint      `json:"promotion_id" gorm:"not null;index"`
+	Code        string    `json:"code" gorm:"size:50;not null"`
+	Name        string    `json:"name" gorm:"size:200"`
+	Type        string    `json:"type" gorm:"size:20;not null"`
+	Amount      int64     `json:"amount" gorm:"column:amount_minor;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (OrderDiscount) TableName() string {
+	return "order_discounts"
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// TaxRate é uma alíquota da tabela fiscal, por classe fiscal do produto, UF de destino
+// ("*" para qualquer UF) e tributo. Mantida pelo financeiro via RPC, sem deploy.
+type TaxRate struct {
+	ID       uint    `gorm:"primaryKey" json:"id"`
+	TaxClass string  `json:"tax_class" gorm:"size:30;not null;uniqueIndex:idx_tax_rates_key"`
+	State    string  `json:"state" gorm:"size:2;not null;uniqueIndex:idx_tax_rates_key"`
+	Tax      string  `json:"tax" gorm:"size:10;not null;uniqueIndex:idx_tax_rates_key"`
+	Rate     float64 `json:"rate" gorm:"type:decimal(7,4);not null"`
+	// Included indica tributo já contido no preço ("por dentro"), apenas destacado no pedido
+	Included  bool      `json:"included" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (TaxRate) TableName() string {
+	return "tax_rates"
+}
+
+// OrderTax é um tributo calculado para uma linha do pedido, com a alíquota e a
+// base usadas no momento da compra. Base e Amount estão em centavos.
+type OrderTax struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	OrderID   uint      `json:"order_id" gorm:"not null;index"`
+	Line      int       `json:"line" gorm:"not null"`
+	SKU       string    `json:"sku" gorm:"column:sku;size:100"`
+	TaxClass  string    `json:"tax_class" gorm:"size:30;not null"`
+	Tax       string    `json:"tax" gorm:"size:10;not null"`
+	State     string    `json:"state" gorm:"size:2;not null"`
+	Rate      float64   `json:"rate" gorm:"type:decimal(7,4);not null"`
+	Base      int64     `json:"base" gorm:"column:base_minor;not null"`
+	Amount    int64     `json:"amount" gorm:"column:amount_minor;not null"`
+	Included  bool      `json:"included" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (OrderTax) TableName() string {
+	return "order_taxes"
+}
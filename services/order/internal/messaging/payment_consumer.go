@@ -0,0 +1,142 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/config"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Status de pagamento publicados pelo payment-service
+const (
+	PaymentStatusApproved = "approved"
+	PaymentStatusFailed   = "failed"
+)
+
+// PaymentEvent representa o resultado de pagamento publicado pelo payment-service
+type PaymentEvent struct {
+	PaymentID uint        `json:"payment_id"`
+	OrderID   uint        `json:"order_id"`
+	Status    string      `json:"status"`
+	Amount    money.Money `json:"amount"`
+	CreatedAt string      `json:"created_at"`
+}
+
+// PaymentResultHandler aplica ao pedido o resultado do pagamento
+type PaymentResultHandler func(ctx context.Context, orderID uint, approved bool) error
+
+// PaymentConsumer consome os resultados de pagamento destinados ao order-service
+type PaymentConsumer struct {
+	channel   *amqp091.Channel
+	queueName string
+	handler   PaymentResultHandler
+}
+
+// NewPaymentConsumer abre um canal dedicado na conexão existente e declara a fila de resultados
+func NewPaymentConsumer(rabbitmqConn *RabbitMQConnection, handler PaymentResultHandler) (*PaymentConsumer, error) {
+	queueName := config.GetEnv("ORDER_PAYMENTS_QUEUE", "order_payments")
+
+	ch, err := rabbitmqConn.connection.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir canal RabbitMQ Consumer: %w", err)
+	}
+
+	_, err = ch.QueueDeclare(
+		queueName, // nome da fila
+		true,      // durable
+		false,     // delete when unused
+		false,     // exclusive
+		false,     // no-wait
+		nil,       // arguments
+	)
+	if err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("falha ao declarar fila '%s': %w", queueName, err)
+	}
+
+	// Processar uma mensagem por vez
+	if err := ch.Qos(1, 0, false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("falha ao configurar QoS: %w", err)
+	}
+
+	log.Printf("🎯 Fila '%s' declarada com sucesso", queueName)
+
+	return &PaymentConsumer{
+		channel:   ch,
+		queueName: queueName,
+		handler:   handler,
+	}, nil
+}
+
+// ConsumePayments processa os resultados de pagamento até o contexto ser cancelado
+func (c *PaymentConsumer) ConsumePayments(ctx context.Context) error {
+	msgs, err := c.channel.Consume(
+		c.queueName,              // queue
+		"order-service-payments", // consumer tag
+		false,                    // auto-ack
+		false,                    // exclusive
+		false,                    // no-local
+		false,                    // no-wait
+		nil,                      // args
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao registrar consumidor: %w", err)
+	}
+
+	log.Printf("🎧 Consumindo resultados de pagamento da fila '%s'", c.queueName)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Parando consumer de pagamentos por contexto cancelado")
+			return ctx.Err()
+
+		case delivery, ok := <-msgs:
+			if !ok {
+				log.Println("🔚 Canal de mensagens fechado")
+				return nil
+			}
+
+			c.processMessage(ctx, delivery)
+		}
+	}
+}
+
+// processMessage aplica um resultado de pagamento ao pedido correspondente
+func (c *PaymentConsumer) processMessage(ctx context.Context, delivery amqp091.Delivery) {
+	var event PaymentEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		log.Printf("❌ Erro ao fazer parse do resultado de pagamento: %v", err)
+		delivery.Nack(false, false)
+		return
+	}
+
+	if event.Status != PaymentStatusApproved && event.Status != PaymentStatusFailed {
+		log.Printf("⚠️ Status de pagamento ignorado: %s (pedido %d)", event.Status, event.OrderID)
+		delivery.Ack(false)
+		return
+	}
+
+	log.Printf("📨 Resultado de pagamento recebido: Pedido=%d, Status=%s", event.OrderID, event.Status)
+
+	if err := c.handler(ctx, event.OrderID, event.Status == PaymentStatusApproved); err != nil {
+		log.Printf("❌ Erro ao aplicar pagamento ao pedido %d: %v", event.OrderID, err)
+		// Reenfileirar para nova tentativa
+		delivery.Nack(false, true)
+		return
+	}
+
+	if err := delivery.Ack(false); err != nil {
+		log.Printf("⚠️ Erro ao confirmar mensagem: %v", err)
+	}
+}
+
+// Close fecha o canal do consumer
+func (c *PaymentConsumer) Close() error {
+	return c.channel.Close()
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 )
 
 // OrderPublisher gerencia a publicação de eventos de pedidos
@@ -15,15 +16,86 @@ type OrderPublisher struct {
 	rabbitmqConn *RabbitMQConnection
 }
 
-// OrderEvent representa o evento de criação de pedido
+// OrderEvent representa o evento de criação de pedido.
+// Total é o valor líquido a cobrar, já com os descontos de Discounts e os tributos de
+// Taxes que não estão contidos no preço. Os valores seguem no formato de money.Money,
+// em centavos e com a moeda do pedido.
 type OrderEvent struct {
-	ID        uint    `json:"id"`
-	Customer  string  `json:"customer"`
-	ProductID uint    `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
-	CreatedAt string  `json:"created_at"`
-	EventType string  `json:"event_type"`
+	ID            uint              `json:"id"`
+	Customer      string            `json:"customer"`
+	ProductID     uint              `json:"product_id"`
+	SKU           string            `json:"sku,omitempty"`
+	VariantID     string            `json:"variant_id,omitempty"`
+	ProductName   string            `json:"product_name,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	Quantity      int               `json:"quantity"`
+	Price         money.Money       `json:"price"`
+	Subtotal      money.Money       `json:"subtotal"`
+	DiscountTotal money.Money       `json:"discount_total"`
+	TaxTotal      money.Money       `json:"tax_total"`
+	Total         money.Money       `json:"total"`
+	Discounts     []DiscountEvent   `json:"discounts,omitempty"`
+	Taxes         []TaxEvent        `json:"taxes,omitempty"`
+	// DestinationState é a UF de entrega usada no cálculo dos tributos
+	DestinationState string `json:"destination_state,omitempty"`
+	// PaymentCurrency é a moeda em que o cliente paga; o payment-service faz a conversão
+	PaymentCurrency string `json:"payment_currency"`
+	Installments    int    `json:"installments"`
+	PaymentMethod   string `json:"payment_method"`
+	// Risk são os sinais repassados à análise antifraude do payment-service
+	Risk *RiskEvent `json:"risk,omitempty"`
+	// ReservationExpiresAt é o vencimento da reserva de estoque (RFC 3339); pagamentos
+	// retidos em análise não podem ser aprovados depois dele
+	ReservationExpiresAt string `json:"reservation_expires_at,omitempty"`
+	CreatedAt            string `json:"created_at"`
+	EventType            string `json:"event_type"`
+}
+
+// RiskEvent são os sinais de risco do pagamento do pedido
+type RiskEvent struct {
+	CardFingerprint string `json:"card_fingerprint,omitempty"`
+	BillingState    string `json:"billing_state,omitempty"`
+	IPCountry       string `json:"ip_country,omitempty"`
+	CustomerSince   string `json:"customer_since,omitempty"`
+}
+
+// riskEvent converte os sinais de risco do pedido; sem sinais, o evento não os inclui
+func riskEvent(order *domain.Order) *RiskEvent {
+	risk := order.Risk
+	event := &RiskEvent{
+		CardFingerprint: risk.CardFingerprint,
+		BillingState:    risk.BillingState,
+		IPCountry:       risk.IPCountry,
+	}
+	if risk.CustomerSince != nil {
+		event.CustomerSince = risk.CustomerSince.Format(time.RFC3339)
+	}
+	if *event == (RiskEvent{}) {
+		return nil
+	}
+	return event
+}
+
+// DiscountEvent é o desconto de uma promoção em uma linha do pedido
+type DiscountEvent struct {
+	Line   int         `json:"line"`
+	SKU    string      `json:"sku,omitempty"`
+	Code   string      `json:"code"`
+	Name   string      `json:"name,omitempty"`
+	Type   string      `json:"type"`
+	Amount money.Money `json:"amount"`
+}
+
+// TaxEvent é um tributo calculado para uma linha do pedido
+type TaxEvent struct {
+	Line     int         `json:"line"`
+	SKU      string      `json:"sku,omitempty"`
+	TaxClass string      `json:"tax_class"`
+	Tax      string      `json:"tax"`
+	Rate     float64     `json:"rate"`
+	Base     money.Money `json:"base"`
+	Amount   money.Money `json:"amount"`
+	Included bool        `json:"included"`
 }
 
 // NewOrderPublisher cria uma nova instância do publisher de pedidos
@@ -41,13 +113,31 @@ func (p *OrderPublisher) PublishOrderCreated(order *domain.Order) error {
 
 	// Converter pedido para evento
 	orderEvent := OrderEvent{
-		ID:        order.ID,
-		Customer:  order.Customer,
-		ProductID: order.ProductID,
-		Quantity:  order.Quantity,
-		Price:     order.Price,
-		CreatedAt: order.CreatedAt.Format(time.RFC3339),
-		EventType: "order.created",
+		ID:               order.ID,
+		Customer:         order.Customer,
+		ProductID:        order.ProductID,
+		SKU:              order.SKU,
+		VariantID:        order.VariantID,
+		ProductName:      order.ProductName,
+		Attributes:       order.Attributes,
+		Quantity:         order.Quantity,
+		Price:            order.Money(order.Price),
+		Subtotal:         order.Money(order.Subtotal),
+		DiscountTotal:    order.Money(order.DiscountTotal),
+		TaxTotal:         order.Money(order.TaxTotal),
+		Total:            order.Money(order.NetTotal()),
+		PaymentCurrency:  order.PaymentCurrency,
+		Installments:     order.Installments,
+		PaymentMethod:    order.PaymentMethod,
+		Discounts:        discountEvents(order),
+		Taxes:            taxEvents(order),
+		DestinationState: order.DestinationState,
+		Risk:             riskEvent(order),
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
+		EventType:        "order.created",
+	}
+	if order.ReservationExpiresAt != nil {
+		orderEvent.ReservationExpiresAt = order.ReservationExpiresAt.Format(time.RFC3339)
 	}
 
 	// Serializar para JSON
@@ -89,13 +179,28 @@ func (p *OrderPublisher) PublishOrderUpdated(order *domain.Order) error {
 	defer cancel()
 
 	orderEvent := OrderEvent{
-		ID:        order.ID,
-		Customer:  order.Customer,
-		ProductID: order.ProductID,
-		Quantity:  order.Quantity,
-		Price:     order.Price,
-		CreatedAt: order.CreatedAt.Format(time.RFC3339),
-		EventType: "order.updated",
+		ID:               order.ID,
+		Customer:         order.Customer,
+		ProductID:        order.ProductID,
+		SKU:              order.SKU,
+		VariantID:        order.VariantID,
+		ProductName:      order.ProductName,
+		Attributes:       order.Attributes,
+		Quantity:         order.Quantity,
+		Price:            order.Money(order.Price),
+		Subtotal:         order.Money(order.Subtotal),
+		DiscountTotal:    order.Money(order.DiscountTotal),
+		TaxTotal:         order.Money(order.TaxTotal),
+		Total:            order.Money(order.NetTotal()),
+		PaymentCurrency:  order.PaymentCurrency,
+		Installments:     order.Installments,
+		PaymentMethod:    order.PaymentMethod,
+		Discounts:        discountEvents(order),
+		Taxes:            taxEvents(order),
+		DestinationState: order.DestinationState,
+		Risk:             riskEvent(order),
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
+		EventType:        "order.updated",
 	}
 
 	messageBody, err := json.Marshal(orderEvent)
@@ -126,3 +231,37 @@ func (p *OrderPublisher) PublishOrderUpdated(order *domain.Order) error {
 	log.Printf("📨 Mensagem de atualização enviada para fila RabbitMQ com ID: %d", order.ID)
 	return nil
 }
+
+// discountEvents converte os descontos gravados no pedido para o evento
+func discountEvents(order *domain.Order) []DiscountEvent {
+	var events []DiscountEvent
+	for _, discount := range order.Discounts {
+		events = append(events, DiscountEvent{
+			Line:   discount.Line,
+			SKU:    discount.SKU,
+			Code:   discount.Code,
+			Name:   discount.Name,
+			Type:   discount.Type,
+			Amount: order.Money(discount.Amount),
+		})
+	}
+	return events
+}
+
+// taxEvents converte os tributos gravados no pedido para o evento
+func taxEvents(order *domain.Order) []TaxEvent {
+	var events []TaxEvent
+	for _, item := range order.Taxes {
+		events = append(events, TaxEvent{
+			Line:     item.Line,
+			SKU:      item.SKU,
+			TaxClass: item.TaxClass,
+			Tax:      item.Tax,
+			Rate:     item.Rate,
+			Base:     order.Money(item.Base),
+			Amount:   order.Money(item.Amount),
+			Included: item.Included,
+		})
+	}
+	return events
+}
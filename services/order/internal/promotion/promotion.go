@@ -0,0 +1,366 @@
+// Package promotion avalia regras de desconto sobre uma cesta de compras.
+//
+// O pacote não acessa banco de dados: recebe as regras candidatas, a cesta e o uso
+// já registrado de cada regra, e devolve os descontos por linha. Os valores são
+// inteiros em unidades menores da moeda (centavos) e cada desconto é arredondado na
+// própria linha, de modo que a soma das linhas sempre confere com o total do pedido.
+package promotion
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Tipos de regra
+const (
+	TypePercent  = "percent"     // percentual sobre o valor das linhas elegíveis
+	TypeFixed    = "fixed"       // valor fixo sobre a cesta, rateado entre as linhas elegíveis
+	TypeBuyXGetY = "buy_x_get_y" // leve BuyQuantity+GetQuantity unidades do mesmo SKU e pague BuyQuantity
+)
+
+// Políticas de acumulação
+const (
+	StackingStackable = "stackable" // combina com as demais regras acumuláveis
+	StackingExclusive = "exclusive" // aplicada sozinha, apenas se for a melhor opção para o cliente
+)
+
+// Motivos pelos quais uma regra não se aplica à cesta
+var (
+	ErrNotStarted         = errors.New("promoção ainda não começou")
+	ErrExpired            = errors.New("promoção expirada")
+	ErrMinSubtotal        = errors.New("valor mínimo da compra não atingido")
+	ErrUsageLimit         = errors.New("limite de usos da promoção atingido")
+	ErrCustomerUsageLimit = errors.New("limite de usos por cliente atingido")
+	ErrNotApplicable      = errors.New("nenhum item do pedido é elegível para a promoção")
+	ErrCurrency           = errors.New("promoção em outra moeda")
+)
+
+// Rule é uma regra de desconto pronta para avaliação
+type Rule struct {
+	ID   uint
+	Code string
+	Name string
+	Type string
+	// Rate é o percentual (0-100%] em TypePercent
+	Rate money.Rate
+	// Amount é o desconto em centavos em TypeFixed
+	Amount int64
+	// Currency é a moeda de Amount e MinSubtotal
+	Currency    string
+	BuyQuantity int
+	GetQuantity int
+	MinSubtotal int64
+	// SKUs restringe a regra a estes itens; vazio aplica a todos
+	SKUs      []string
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+	// Limites de uso; zero significa ilimitado
+	MaxUses            int
+	MaxUsesPerCustomer int
+	Stacking           string
+	// Priority define a ordem de aplicação das regras acumuláveis (maior primeiro)
+	Priority int
+}
+
+// Exclusive indica se a regra não pode ser combinada com outras
+func (r Rule) Exclusive() bool {
+	return r.Stacking == StackingExclusive
+}
+
+// Line é um item da cesta, com o preço unitário em centavos
+type Line struct {
+	Number    int
+	SKU       string
+	Quantity  int
+	UnitPrice int64
+}
+
+// Basket é a cesta avaliada, com o cliente, a moeda dos preços e o instante da compra
+type Basket struct {
+	Customer string
+	Currency string
+	Lines    []Line
+	At       time.Time
+}
+
+// Usage é a quantidade de pedidos que já usaram uma regra
+type Usage struct {
+	Total    int
+	Customer int
+}
+
+// Discount é o desconto de uma regra em uma linha, em centavos
+type Discount struct {
+	Line   int
+	SKU    string
+	RuleID uint
+	Code   string
+	Name   string
+	Type   string
+	Amount int64
+}
+
+// Skipped é uma regra elegível que ficou de fora pela política de acumulação
+type Skipped struct {
+	Rule   Rule
+	Reason string
+}
+
+// Result é o resultado da avaliação de uma cesta, com os valores em centavos
+type Result struct {
+	Subtotal      int64
+	DiscountTotal int64
+	Total         int64
+	Discounts     []Discount
+	Skipped       []Skipped
+}
+
+// Subtotal soma o valor bruto das linhas
+func (b Basket) Subtotal() int64 {
+	var total int64
+	for _, line := range b.Lines {
+		total += lineAmount(line)
+	}
+	return total
+}
+
+// CheckUsage verifica se ainda há usos disponíveis para mais um pedido; limites
+// zero são ilimitados
+func CheckUsage(maxUses, maxUsesPerCustomer int, usage Usage) error {
+	if maxUses > 0 && usage.Total >= maxUses {
+		return ErrUsageLimit
+	}
+	if maxUsesPerCustomer > 0 && usage.Customer >= maxUsesPerCustomer {
+		return ErrCustomerUsageLimit
+	}
+	return nil
+}
+
+// Check verifica se a regra pode ser usada na cesta: janela de validade, moeda, valor
+// mínimo, limites de uso e itens elegíveis. Não considera a acumulação com outras regras.
+func Check(rule Rule, basket Basket, usage Usage) error {
+	if rule.ValidFrom != nil && basket.At.Before(*rule.ValidFrom) {
+		return ErrNotStarted
+	}
+	if rule.ValidTo != nil && !basket.At.Before(*rule.ValidTo) {
+		return ErrExpired
+	}
+	if err := CheckUsage(rule.MaxUses, rule.MaxUsesPerCustomer, usage); err != nil {
+		return err
+	}
+	if (rule.Type == TypeFixed || rule.MinSubtotal > 0) && money.NormalizeCurrency(rule.Currency) != money.NormalizeCurrency(basket.Currency) {
+		return ErrCurrency
+	}
+	if rule.MinSubtotal > 0 && basket.Subtotal() < rule.MinSubtotal {
+		return ErrMinSubtotal
+	}
+
+	remaining := make([]int64, len(basket.Lines))
+	for i, line := range basket.Lines {
+		remaining[i] = lineAmount(line)
+	}
+	if total(apply(rule, basket.Lines, remaining)) == 0 {
+		return ErrNotApplicable
+	}
+	return nil
+}
+
+// Evaluate aplica as regras elegíveis à cesta. As regras acumuláveis são aplicadas em
+// sequência, por prioridade, cada uma sobre o valor que restou das anteriores; uma regra
+// exclusiva só é usada, sozinha, quando dá mais desconto que a combinação das acumuláveis.
+// Regras que não passam em Check são ignoradas.
+func Evaluate(basket Basket, rules []Rule, usage map[uint]Usage) Result {
+	var stackable, exclusive []Rule
+	for _, rule := range rules {
+		if Check(rule, basket, usage[rule.ID]) != nil {
+			continue
+		}
+		if rule.Exclusive() {
+			exclusive = append(exclusive, rule)
+		} else {
+			stackable = append(stackable, rule)
+		}
+	}
+	sortRules(stackable)
+	sortRules(exclusive)
+
+	best, bestTotal := stackable, total(run(basket, stackable))
+	bestExclusive := false
+	for _, rule := range exclusive {
+		candidate := []Rule{rule}
+		if amount := total(run(basket, candidate)); amount > bestTotal {
+			best, bestTotal, bestExclusive = candidate, amount, true
+		}
+	}
+
+	result := Result{Subtotal: basket.Subtotal()}
+	for _, discount := range run(basket, best) {
+		result.Discounts = append(result.Discounts, discount.toDiscount())
+	}
+	result.DiscountTotal = bestTotal
+	result.Total = result.Subtotal - bestTotal
+
+	applied := make(map[uint]bool, len(best))
+	for _, rule := range best {
+		applied[rule.ID] = true
+	}
+	for _, rule := range append(stackable, exclusive...) {
+		if applied[rule.ID] {
+			continue
+		}
+		reason := "não acumula com a promoção aplicada"
+		if !bestExclusive && rule.Exclusive() {
+			reason = "promoção exclusiva com desconto menor que as acumuláveis"
+		}
+		result.Skipped = append(result.Skipped, Skipped{Rule: rule, Reason: reason})
+	}
+	return result
+}
+
+// lineDiscount é o desconto de uma regra em uma linha durante a avaliação
+type lineDiscount struct {
+	line   Line
+	rule   Rule
+	amount int64
+}
+
+func (d lineDiscount) toDiscount() Discount {
+	return Discount{
+		Line:   d.line.Number,
+		SKU:    d.line.SKU,
+		RuleID: d.rule.ID,
+		Code:   d.rule.Code,
+		Name:   d.rule.Name,
+		Type:   d.rule.Type,
+		Amount: d.amount,
+	}
+}
+
+// run aplica as regras em sequência sobre o valor restante de cada linha
+func run(basket Basket, rules []Rule) []lineDiscount {
+	remaining := make([]int64, len(basket.Lines))
+	for i, line := range basket.Lines {
+		remaining[i] = lineAmount(line)
+	}
+
+	var discounts []lineDiscount
+	for _, rule := range rules {
+		discounts = append(discounts, apply(rule, basket.Lines, remaining)...)
+	}
+	return discounts
+}
+
+// apply calcula o desconto de uma regra em cada linha e o desconta de remaining
+func apply(rule Rule, lines []Line, remaining []int64) []lineDiscount {
+	amounts := make([]int64, len(lines))
+	switch rule.Type {
+	case TypePercent:
+		for i, line := range lines {
+			if eligible(rule, line) {
+				// Com até 100% o desconto não passa do valor da linha; o erro de
+				// estouro não ocorre e, se ocorresse, a linha ficaria sem desconto
+				amounts[i], _ = money.PercentOf(remaining[i], rule.Rate, money.HalfUp)
+			}
+		}
+	case TypeFixed:
+		allocate(rule, lines, remaining, amounts)
+	case TypeBuyXGetY:
+		group := rule.BuyQuantity + rule.GetQuantity
+		if rule.BuyQuantity <= 0 || rule.GetQuantity <= 0 {
+			break
+		}
+		for i, line := range lines {
+			if eligible(rule, line) {
+				free := int64(line.Quantity/group) * int64(rule.GetQuantity)
+				amounts[i] = free * line.UnitPrice
+			}
+		}
+	}
+
+	var discounts []lineDiscount
+	for i, line := range lines {
+		amount := amounts[i]
+		if amount > remaining[i] {
+			amount = remaining[i]
+		}
+		if amount <= 0 {
+			continue
+		}
+		remaining[i] -= amount
+		discounts = append(discounts, lineDiscount{line: line, rule: rule, amount: amount})
+	}
+	return discounts
+}
+
+// allocate rateia um desconto fixo entre as linhas elegíveis na proporção do valor
+// restante de cada uma; os centavos que sobram do arredondamento vão para as linhas
+// de maior valor, para que a soma seja exatamente o desconto
+func allocate(rule Rule, lines []Line, remaining []int64, amounts []int64) {
+	var base int64
+	for i, line := range lines {
+		if eligible(rule, line) {
+			base += remaining[i]
+		}
+	}
+	if base <= 0 {
+		return
+	}
+	discount := rule.Amount
+	if discount > base {
+		discount = base
+	}
+
+	weights := make([]int64, len(lines))
+	for i, line := range lines {
+		if eligible(rule, line) {
+			weights[i] = remaining[i]
+		}
+	}
+	copy(amounts, money.Allocate(discount, weights))
+}
+
+// eligible indica se a linha está entre os SKUs da regra
+func eligible(rule Rule, line Line) bool {
+	if len(rule.SKUs) == 0 {
+		return true
+	}
+	for _, sku := range rule.SKUs {
+		if sku == line.SKU {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOrder define a ordem de aplicação entre regras de mesma prioridade: itens
+// grátis primeiro, depois percentuais e por fim valores fixos
+var typeOrder = map[string]int{TypeBuyXGetY: 0, TypePercent: 1, TypeFixed: 2}
+
+// sortRules ordena por prioridade, tipo e ID, para um resultado estável
+func sortRules(rules []Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if typeOrder[a.Type] != typeOrder[b.Type] {
+			return typeOrder[a.Type] < typeOrder[b.Type]
+		}
+		return a.ID < b.ID
+	})
+}
+
+func total(discounts []lineDiscount) int64 {
+	var sum int64
+	for _, discount := range discounts {
+		sum += discount.amount
+	}
+	return sum
+}
+
+func lineAmount(line Line) int64 {
+	return line.UnitPrice * int64(line.Quantity)
+}
@@ -0,0 +1,141 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+var now = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func basket(lines ...Line) Basket {
+	for i := range lines {
+		lines[i].Number = i + 1
+	}
+	return Basket{Customer: "7", Lines: lines, At: now}
+}
+
+func TestEvaluate_PercentRoundsPerLine(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 3, UnitPrice: 3333}, Line{SKU: "B", Quantity: 1, UnitPrice: 5})
+	rules := []Rule{{ID: 1, Code: "DEZ", Type: TypePercent, Rate: money.PercentRate(10)}}
+
+	result := Evaluate(b, rules, nil)
+
+	require.Len(t, result.Discounts, 2)
+	assert.Equal(t, int64(1000), result.Discounts[0].Amount) // 99,99 * 10% = 9,999
+	assert.Equal(t, int64(1), result.Discounts[1].Amount)    // 0,05 * 10% = 0,005
+	assert.Equal(t, int64(10004), result.Subtotal)
+	assert.Equal(t, int64(1001), result.DiscountTotal)
+	assert.Equal(t, int64(9003), result.Total)
+}
+
+func TestEvaluate_FixedIsSplitAcrossEligibleLines(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 1, UnitPrice: 1000}, Line{SKU: "B", Quantity: 1, UnitPrice: 2000}, Line{SKU: "C", Quantity: 1, UnitPrice: 5000})
+	rules := []Rule{{ID: 1, Type: TypeFixed, Amount: 1000, SKUs: []string{"A", "B"}}}
+
+	result := Evaluate(b, rules, nil)
+
+	require.Len(t, result.Discounts, 2)
+	assert.Equal(t, int64(333), result.Discounts[0].Amount)
+	assert.Equal(t, int64(667), result.Discounts[1].Amount)
+	assert.Equal(t, int64(1000), result.DiscountTotal)
+}
+
+func TestEvaluate_FixedNeverExceedsEligibleAmount(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 1, UnitPrice: 1500})
+	result := Evaluate(b, []Rule{{ID: 1, Type: TypeFixed, Amount: 5000}}, nil)
+
+	assert.Equal(t, int64(1500), result.DiscountTotal)
+	assert.Equal(t, int64(0), result.Total)
+}
+
+func TestEvaluate_BuyXGetY(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 7, UnitPrice: 1250})
+	rules := []Rule{{ID: 1, Type: TypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1}}
+
+	result := Evaluate(b, rules, nil)
+
+	// 7 unidades = 2 grupos de 3, com 2 unidades grátis
+	assert.Equal(t, int64(2500), result.DiscountTotal)
+}
+
+func TestEvaluate_StackableRulesApplyInPriorityOrder(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 1, UnitPrice: 10000})
+	rules := []Rule{
+		{ID: 1, Code: "MENOS10", Type: TypeFixed, Amount: 1000, Stacking: StackingStackable},
+		{ID: 2, Code: "VINTE", Type: TypePercent, Rate: money.PercentRate(20), Stacking: StackingStackable, Priority: 1},
+	}
+
+	result := Evaluate(b, rules, nil)
+
+	require.Len(t, result.Discounts, 2)
+	assert.Equal(t, "VINTE", result.Discounts[0].Code)
+	assert.Equal(t, int64(2000), result.Discounts[0].Amount)
+	assert.Equal(t, int64(1000), result.Discounts[1].Amount)
+	assert.Equal(t, int64(7000), result.Total)
+}
+
+func TestEvaluate_ExclusiveWinsOnlyWhenBetter(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 1, UnitPrice: 10000})
+	stackable := []Rule{
+		{ID: 1, Code: "DEZ", Type: TypePercent, Rate: money.PercentRate(10)},
+		{ID: 2, Code: "CINCO", Type: TypeFixed, Amount: 500},
+	}
+
+	better := Evaluate(b, append(stackable, Rule{ID: 3, Code: "VIP", Type: TypePercent, Rate: money.PercentRate(25), Stacking: StackingExclusive}), nil)
+	assert.Equal(t, int64(2500), better.DiscountTotal)
+	require.Len(t, better.Discounts, 1)
+	assert.Equal(t, "VIP", better.Discounts[0].Code)
+	assert.Len(t, better.Skipped, 2)
+
+	worse := Evaluate(b, append(stackable, Rule{ID: 3, Code: "VIP", Type: TypePercent, Rate: money.PercentRate(12), Stacking: StackingExclusive}), nil)
+	assert.Equal(t, int64(1500), worse.DiscountTotal)
+	require.Len(t, worse.Skipped, 1)
+	assert.Equal(t, "VIP", worse.Skipped[0].Rule.Code)
+}
+
+func TestCheck(t *testing.T) {
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+	b := basket(Line{SKU: "A", Quantity: 2, UnitPrice: 4000})
+	base := Rule{ID: 1, Type: TypePercent, Rate: money.PercentRate(10)}
+
+	tests := []struct {
+		name  string
+		edit  func(r *Rule)
+		usage Usage
+		want  error
+	}{
+		{"válida", func(r *Rule) {}, Usage{}, nil},
+		{"antes do início", func(r *Rule) { r.ValidFrom = &later }, Usage{}, ErrNotStarted},
+		{"expirada", func(r *Rule) { r.ValidTo = &earlier }, Usage{}, ErrExpired},
+		{"valor mínimo", func(r *Rule) { r.MinSubtotal = 8001 }, Usage{}, ErrMinSubtotal},
+		{"valor mínimo exato", func(r *Rule) { r.MinSubtotal = 8000 }, Usage{}, nil},
+		{"limite total", func(r *Rule) { r.MaxUses = 100 }, Usage{Total: 100}, ErrUsageLimit},
+		{"limite por cliente", func(r *Rule) { r.MaxUsesPerCustomer = 1 }, Usage{Total: 5, Customer: 1}, ErrCustomerUsageLimit},
+		{"SKU fora da regra", func(r *Rule) { r.SKUs = []string{"B"} }, Usage{}, ErrNotApplicable},
+		{"quantidade insuficiente", func(r *Rule) { *r = Rule{ID: 1, Type: TypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1} }, Usage{}, ErrNotApplicable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := base
+			tt.edit(&rule)
+			assert.Equal(t, tt.want, Check(rule, b, tt.usage))
+		})
+	}
+}
+
+func TestEvaluate_IgnoresIneligibleRules(t *testing.T) {
+	b := basket(Line{SKU: "A", Quantity: 1, UnitPrice: 10000})
+	rules := []Rule{{ID: 1, Type: TypePercent, Rate: money.PercentRate(10), MaxUsesPerCustomer: 1}}
+
+	result := Evaluate(b, rules, map[uint]Usage{1: {Total: 1, Customer: 1}})
+
+	assert.Empty(t, result.Discounts)
+	assert.Equal(t, int64(10000), result.Total)
+}
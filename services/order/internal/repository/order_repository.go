@@ -10,6 +10,9 @@ type OrderRepository interface {
 	Create(order *domain.Order) error
 	List() ([]domain.Order, error)
 	GetByID(id uint) (*domain.Order, error)
+	UpdateStatus(id uint, status string) error
+	ListByCustomers(customers []string) ([]domain.Order, error)
+	AnonymizeCustomers(customers []string, pseudonym string) ([]uint, error)
 }
 
 // orderRepository implementa OrderRepository
@@ -24,18 +27,22 @@ func NewOrderRepository(db *gorm.DB) OrderRepository {
 	}
 }
 
-// Create cria um novo pedido no banco de dados
+// Create cria um novo pedido no banco de dados, junto com os descontos e tributos.
+// Os limites de uso das promoções aplicadas são conferidos na mesma transação;
+// esgotada alguma delas, nada é gravado e o erro é ErrPromotionExhausted.
 func (r *orderRepository) Create(order *domain.Order) error {
-	if err := r.db.Create(order).Error; err != nil {
-		return err
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkPromotionUsage(tx, order); err != nil {
+			return err
+		}
+		return tx.Create(order).Error
+	})
 }
 
 // List retorna todos os pedidos do banco de dados
 func (r *orderRepository) List() ([]domain.Order, error) {
 	var orders []domain.Order
-	if err := r.db.Order("created_at DESC").Find(&orders).Error; err != nil {
+	if err := r.db.Preload("Discounts").Preload("Taxes").Order("created_at DESC").Find(&orders).Error; err != nil {
 		return nil, err
 	}
 	return orders, nil
@@ -44,8 +51,48 @@ func (r *orderRepository) List() ([]domain.Order, error) {
 // GetByID retorna um pedido específico pelo ID
 func (r *orderRepository) GetByID(id uint) (*domain.Order, error) {
 	var order domain.Order
-	if err := r.db.First(&order, id).Error; err != nil {
+	if err := r.db.Preload("Discounts").Preload("Taxes").First(&order, id).Error; err != nil {
 		return nil, err
 	}
 	return &order, nil
 }
+
+// UpdateStatus altera o estado de um pedido
+func (r *orderRepository) UpdateStatus(id uint, status string) error {
+	return r.db.Model(&domain.Order{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// ListByCustomers retorna os pedidos cujo cliente é um dos identificadores informados
+func (r *orderRepository) ListByCustomers(customers []string) ([]domain.Order, error) {
+	var orders []domain.Order
+	if err := r.db.Preload("Discounts").Preload("Taxes").Where("customer IN ?", customers).Order("created_at").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// AnonymizeCustomers troca o cliente dos pedidos pelo pseudônimo e apaga os sinais de
+// risco, mantendo valores e quantidades
+func (r *orderRepository) AnonymizeCustomers(customers []string, pseudonym string) ([]uint, error) {
+	var ids []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Order{}).Where("customer IN ?", customers).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		// Os sinais de risco (cartão, IP, data de cadastro) também identificam o titular
+		return tx.Model(&domain.Order{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"customer":              pseudonym,
+			"risk_card_fingerprint": "",
+			"risk_billing_state":    "",
+			"risk_ip_country":       "",
+			"risk_customer_since":   nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/promotion"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPromotionExhausted indica que, na gravação do pedido, uma das promoções aplicadas
+// já não tinha usos disponíveis (outro pedido ficou com o último uso)
+var ErrPromotionExhausted = errors.New("promoção sem usos disponíveis")
+
+// releasedStatuses são os estados de pedidos que não consomem o limite de uso das promoções
+var releasedStatuses = []string{domain.OrderStatusCancelled, domain.OrderStatusPaymentFailed}
+
+// PromotionRepository define a interface para operações de persistência de promoções
+type PromotionRepository interface {
+	Create(promo *domain.Promotion) error
+	GetByCode(code string) (*domain.Promotion, error)
+	GetByCodes(codes []string) ([]domain.Promotion, error)
+	List(activeOnly bool) ([]domain.Promotion, error)
+	ListAutomatic(at time.Time) ([]domain.Promotion, error)
+	SetActive(id uint, active bool) error
+	CountUsage(promotionIDs []uint, customer string) (map[uint]promotion.Usage, error)
+}
+
+// promotionRepository implementa PromotionRepository
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository cria uma nova instância do repositório de promoções
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{
+		db: db,
+	}
+}
+
+// Create grava uma nova promoção
+func (r *promotionRepository) Create(promo *domain.Promotion) error {
+	return r.db.Create(promo).Error
+}
+
+// GetByCode retorna uma promoção pelo código
+func (r *promotionRepository) GetByCode(code string) (*domain.Promotion, error) {
+	var promo domain.Promotion
+	if err := r.db.Where("code = ?", code).First(&promo).Error; err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+// GetByCodes retorna as promoções com os códigos informados, ativas ou não
+func (r *promotionRepository) GetByCodes(codes []string) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+	if err := r.db.Where("code IN ?", codes).Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// List retorna as promoções, das mais recentes para as mais antigas
+func (r *promotionRepository) List(activeOnly bool) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+	query := r.db.Order("created_at DESC")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// ListAutomatic retorna as promoções automáticas ativas e vigentes no instante informado
+func (r *promotionRepository) ListAutomatic(at time.Time) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+	err := r.db.
+		Where("automatic = ? AND active = ?", true, true).
+		Where("valid_from IS NULL OR valid_from <= ?", at).
+		Where("valid_to IS NULL OR valid_to > ?", at).
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// SetActive ativa ou desativa uma promoção
+func (r *promotionRepository) SetActive(id uint, active bool) error {
+	return r.db.Model(&domain.Promotion{}).Where("id = ?", id).Update("active", active).Error
+}
+
+// CountUsage conta, por promoção, os pedidos que a usaram no total e pelo cliente.
+// Pedidos cancelados ou com pagamento recusado devolvem o uso. A contagem serve para
+// avaliar o pedido; o limite é garantido por checkPromotionUsage, na gravação.
+func (r *promotionRepository) CountUsage(promotionIDs []uint, customer string) (map[uint]promotion.Usage, error) {
+	return countPromotionUsage(r.db, promotionIDs, customer)
+}
+
+// countPromotionUsage conta o uso das promoções na conexão ou transação informada
+func countPromotionUsage(db *gorm.DB, promotionIDs []uint, customer string) (map[uint]promotion.Usage, error) {
+	usage := make(map[uint]promotion.Usage, len(promotionIDs))
+	if len(promotionIDs) == 0 {
+		return usage, nil
+	}
+
+	var rows []struct {
+		PromotionID uint
+		Total       int
+		Customer    int
+	}
+	err := db.Model(&domain.OrderDiscount{}).
+		Select("order_discounts.promotion_id, "+
+			"COUNT(DISTINCT order_discounts.order_id) AS total, "+
+			"COUNT(DISTINCT CASE WHEN orders.customer = ? THEN order_discounts.order_id END) AS customer", customer).
+		Joins("JOIN orders ON orders.id = order_discounts.order_id").
+		Where("order_discounts.promotion_id IN ?", promotionIDs).
+		Where("orders.status NOT IN ?", releasedStatuses).
+		Group("order_discounts.promotion_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		usage[row.PromotionID] = promotion.Usage{Total: row.Total, Customer: row.Customer}
+	}
+	return usage, nil
+}
+
+// checkPromotionUsage confere, dentro da transação que grava o pedido, os limites de uso
+// das promoções aplicadas. As promoções com limite são travadas (SELECT ... FOR UPDATE),
+// em ordem de ID para evitar deadlocks, antes da contagem: pedidos simultâneos com a
+// mesma promoção esperam um pelo outro e o último uso não é concedido duas vezes.
+// Promoções sem limite não são travadas.
+func checkPromotionUsage(tx *gorm.DB, order *domain.Order) error {
+	ids := discountPromotionIDs(order.Discounts)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var limited []domain.Promotion
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Where("max_uses > 0 OR max_uses_per_customer > 0").
+		Order("id").
+		Find(&limited).Error
+	if err != nil {
+		return err
+	}
+	if len(limited) == 0 {
+		return nil
+	}
+
+	limitedIDs := make([]uint, len(limited))
+	for i, promo := range limited {
+		limitedIDs[i] = promo.ID
+	}
+	usage, err := countPromotionUsage(tx, limitedIDs, order.Customer)
+	if err != nil {
+		return err
+	}
+	for _, promo := range limited {
+		if err := promotion.CheckUsage(promo.MaxUses, promo.MaxUsesPerCustomer, usage[promo.ID]); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrPromotionExhausted, promo.Code, err)
+		}
+	}
+	return nil
+}
+
+// discountPromotionIDs retorna as promoções dos descontos, sem repetições e em ordem
+func discountPromotionIDs(discounts []domain.OrderDiscount) []uint {
+	seen := make(map[uint]bool, len(discounts))
+	var ids []uint
+	for _, discount := range discounts {
+		if !seen[discount.PromotionID] {
+			seen[discount.PromotionID] = true
+			ids = append(ids, discount.PromotionID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaxRateRepository define a interface para operações de persistência da tabela de alíquotas
+type TaxRateRepository interface {
+	List(taxClass string) ([]domain.TaxRate, error)
+	ListByClasses(taxClasses []string) ([]domain.TaxRate, error)
+	Upsert(rate *domain.TaxRate) error
+	Delete(id uint) error
+}
+
+// taxRateRepository implementa TaxRateRepository
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+// NewTaxRateRepository cria uma nova instância do repositório de alíquotas
+func NewTaxRateRepository(db *gorm.DB) TaxRateRepository {
+	return &taxRateRepository{
+		db: db,
+	}
+}
+
+// List retorna as alíquotas cadastradas, opcionalmente de uma única classe fiscal
+func (r *taxRateRepository) List(taxClass string) ([]domain.TaxRate, error) {
+	query := r.db.Order("tax_class, state, tax")
+	if taxClass != "" {
+		query = query.Where("tax_class = ?", taxClass)
+	}
+
+	var rates []domain.TaxRate
+	if err := query.Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// ListByClasses retorna as alíquotas das classes fiscais informadas
+func (r *taxRateRepository) ListByClasses(taxClasses []string) ([]domain.TaxRate, error) {
+	var rates []domain.TaxRate
+	if err := r.db.Where("tax_class IN ?", taxClasses).Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Upsert grava a alíquota, substituindo a existente para a mesma classe, UF e tributo
+func (r *taxRateRepository) Upsert(rate *domain.TaxRate) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tax_class"}, {Name: "state"}, {Name: "tax"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "included", "updated_at"}),
+	}).Create(rate).Error
+	if err != nil {
+		return err
+	}
+
+	// No MySQL o ID não é retornado quando a linha é atualizada
+	return r.db.Where("tax_class = ? AND state = ? AND tax = ?", rate.TaxClass, rate.State, rate.Tax).First(rate).Error
+}
+
+// Delete remove uma alíquota
+func (r *taxRateRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.TaxRate{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
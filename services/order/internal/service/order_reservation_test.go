@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// MockOrderStore is a mock implementation of repository.OrderRepository
+type MockOrderStore struct {
+	mock.Mock
+}
+
+func (m *MockOrderStore) Create(order *domain.Order) error {
+	args := m.Called(order)
+	if args.Error(0) == nil {
+		order.ID = 42
+	}
+	return args.Error(0)
+}
+
+func (m *MockOrderStore) List() ([]domain.Order, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+func (m *MockOrderStore) GetByID(id uint) (*domain.Order, error) {
+	args := m.Called(id)
+	order, _ := args.Get(0).(*domain.Order)
+	return order, args.Error(1)
+}
+
+func (m *MockOrderStore) UpdateStatus(id uint, status string) error {
+	return m.Called(id, status).Error(0)
+}
+
+func (m *MockOrderStore) ListByCustomers(customers []string) ([]domain.Order, error) {
+	args := m.Called(customers)
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+func (m *MockOrderStore) AnonymizeCustomers(customers []string, pseudonym string) ([]uint, error) {
+	args := m.Called(customers, pseudonym)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// MockInventoryClient is a mock implementation of clients.InventoryClient
+type MockInventoryClient struct {
+	mock.Mock
+	reservedTTL time.Duration
+}
+
+func (m *MockInventoryClient) Reserve(ctx context.Context, sku string, quantity int, reference string, ttl time.Duration) (*clients.Reservation, error) {
+	m.reservedTTL = ttl
+	args := m.Called(sku, quantity, reference)
+	reservation, _ := args.Get(0).(*clients.Reservation)
+	return reservation, args.Error(1)
+}
+
+func (m *MockInventoryClient) Commit(ctx context.Context, reservationID string) (*clients.Reservation, error) {
+	args := m.Called(reservationID)
+	reservation, _ := args.Get(0).(*clients.Reservation)
+	return reservation, args.Error(1)
+}
+
+func (m *MockInventoryClient) Release(ctx context.Context, reservationID string) (*clients.Reservation, error) {
+	args := m.Called(reservationID)
+	reservation, _ := args.Get(0).(*clients.Reservation)
+	return reservation, args.Error(1)
+}
+
+func (m *MockInventoryClient) Close() error {
+	return nil
+}
+
+func validOrderInput() CreateOrderInput {
+	return CreateOrderInput{Customer: "7", SKU: "SMART-XYZ-001", Quantity: 2, Price: money.New(199990, "BRL")}
+}
+
+func TestCreateOrder_ReservesStock(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+	require.NoError(t, err)
+	assert.Equal(t, "res-1", order.ReservationID)
+	assert.Equal(t, domain.OrderStatusPending, order.Status)
+}
+
+func TestCreateOrder_KeepsReservationExpiry(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}, ExpiresAt: "2025-01-22T12:00:00Z"}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+	require.NoError(t, err)
+	require.NotNil(t, order.ReservationExpiresAt)
+	assert.Equal(t, time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC), order.ReservationExpiresAt.UTC())
+}
+
+func TestCreateOrder_UsesCatalogPrice(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 179990, Currency: "BRL"}}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	input := validOrderInput()
+	input.Price = money.New(1, "BRL")
+	order, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(179990), order.Price)
+	assert.Equal(t, int64(359980), order.Total)
+}
+
+func TestCreateOrder_RecordsPaymentCurrency(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+	orders := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{})
+
+	order, err := orders.CreateOrder(context.Background(), validOrderInput())
+	require.NoError(t, err)
+	assert.Equal(t, "BRL", order.PaymentCurrency)
+
+	input := validOrderInput()
+	input.PaymentCurrency = " usd "
+	order, err = orders.CreateOrder(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "BRL", order.Currency)
+	assert.Equal(t, "USD", order.PaymentCurrency)
+}
+
+func TestCreateOrder_RejectsUnsupportedPaymentCurrency(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+
+	input := validOrderInput()
+	input.PaymentCurrency = "XYZ"
+	_, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), input)
+
+	assert.ErrorIs(t, err, ErrInvalidCurrency)
+	inventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_RecordsInstallments(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+	orders := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{})
+
+	order, err := orders.CreateOrder(context.Background(), validOrderInput())
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.Installments)
+
+	input := validOrderInput()
+	input.Installments = 6
+	order, err = orders.CreateOrder(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, 6, order.Installments)
+
+	input.Installments = -1
+	_, err = orders.CreateOrder(context.Background(), input)
+	assert.ErrorIs(t, err, ErrInvalidInstallments)
+}
+
+func TestCreateOrder_RecordsPaymentMethod(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+	orders := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{})
+
+	order, err := orders.CreateOrder(context.Background(), validOrderInput())
+	require.NoError(t, err)
+	assert.Equal(t, "card", order.PaymentMethod)
+
+	input := validOrderInput()
+	input.PaymentMethod = " PIX "
+	order, err = orders.CreateOrder(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "pix", order.PaymentMethod)
+
+	input.PaymentMethod = "boleto"
+	order, err = orders.CreateOrder(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "boleto", order.PaymentMethod)
+
+	input.PaymentMethod = "cheque"
+	_, err = orders.CreateOrder(context.Background(), input)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+}
+
+func TestCreateOrder_ReleasesReservationWithoutCatalogPrice(t *testing.T) {
+	tests := []struct {
+		name  string
+		price *clients.Money
+	}{
+		{"no price", nil},
+		{"zero amount", &clients.Money{Currency: "BRL"}},
+		{"missing currency", &clients.Money{Amount: 199990}},
+		{"unknown currency", &clients.Money{Amount: 199990, Currency: "XYZ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(MockOrderStore)
+			inventory := new(MockInventoryClient)
+			inventory.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(&clients.Reservation{ID: "res-1", UnitPrice: tt.price}, nil)
+			inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+
+			_, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+			assert.ErrorIs(t, err, ErrPriceUnavailable)
+			store.AssertNotCalled(t, "Create", mock.Anything)
+			inventory.AssertCalled(t, "Release", "res-1")
+		})
+	}
+}
+
+func TestCreateOrder_UsesCatalogCurrency(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 49999, Currency: "usd"}}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+	require.NoError(t, err)
+	assert.Equal(t, "USD", order.Currency)
+	assert.Equal(t, int64(49999), order.Price)
+	assert.Equal(t, "USD", order.PaymentCurrency)
+}
+
+func TestCreateOrder_RejectedWithoutStock(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.FailedPrecondition, "estoque insuficiente para o produto"))
+
+	_, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+	assert.ErrorIs(t, err, ErrStockUnavailable)
+	store.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateOrder_ReleasesReservationWhenSaveFails(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}}, nil)
+	inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+	store.On("Create", mock.Anything).Return(assert.AnError)
+
+	_, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+	assert.Error(t, err)
+	inventory.AssertCalled(t, "Release", "res-1")
+}
+
+func TestHandlePaymentResult_FailedReleasesReservation(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusPending, ReservationID: "res-1"}, nil)
+	store.On("UpdateStatus", uint(42), domain.OrderStatusPaymentFailed).Return(nil)
+	inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+
+	err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).HandlePaymentResult(context.Background(), 42, false)
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+	inventory.AssertExpectations(t)
+}
+
+func TestHandlePaymentResult_ApprovedCommitsReservation(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusPending, ReservationID: "res-1"}, nil)
+	store.On("UpdateStatus", uint(42), domain.OrderStatusPaid).Return(nil)
+	inventory.On("Commit", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+
+	err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).HandlePaymentResult(context.Background(), 42, true)
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+	inventory.AssertExpectations(t)
+}
+
+func TestHandlePaymentResult_ExpiredReservationNeedsReview(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusPending, ReservationID: "res-1"}, nil)
+	store.On("UpdateStatus", uint(42), domain.OrderStatusStockReview).Return(nil)
+	inventory.On("Commit", "res-1").Return(nil, status.Error(codes.FailedPrecondition, "reserva expirada"))
+
+	err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).HandlePaymentResult(context.Background(), 42, true)
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+	store.AssertNotCalled(t, "UpdateStatus", uint(42), domain.OrderStatusPaid)
+}
+
+func TestHandlePaymentResult_TransientCommitErrorIsRetried(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusPending, ReservationID: "res-1"}, nil)
+	inventory.On("Commit", "res-1").Return(nil, status.Error(codes.Unavailable, "catálogo fora do ar"))
+
+	err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).HandlePaymentResult(context.Background(), 42, true)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	store.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestHandlePaymentResult_IgnoresProcessedOrder(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusCancelled, ReservationID: "res-1"}, nil)
+
+	err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).HandlePaymentResult(context.Background(), 42, true)
+
+	require.NoError(t, err)
+	inventory.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestCancelOrder_PaidOrderIsRejected(t *testing.T) {
+	store := new(MockOrderStore)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusPaid}, nil)
+
+	_, err := NewOrderService(store, nil, new(MockInventoryClient), nil, nil, ReservationTTLs{}).CancelOrder(context.Background(), 42)
+
+	assert.ErrorIs(t, err, ErrOrderNotCancellable)
+}
+
+func TestCancelOrder_ReleasesReservation(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	store.On("GetByID", uint(42)).Return(&domain.Order{ID: 42, Status: domain.OrderStatusPending, ReservationID: "res-1"}, nil)
+	store.On("UpdateStatus", uint(42), domain.OrderStatusCancelled).Return(nil)
+	inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+
+	order, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CancelOrder(context.Background(), 42)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusCancelled, order.Status)
+	inventory.AssertExpectations(t)
+}
+
+func TestCreateOrder_SnapshotsVariant(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	inventory.On("Reserve", "CAM-BAS-011-PT-M", 1, "customer:7").Return(&clients.Reservation{
+		ID:          "res-1",
+		VariantID:   "var-002",
+		ProductName: "Camiseta Básica - Preta",
+		UnitPrice:   &clients.Money{Amount: 4990, Currency: "BRL"},
+		Attributes:  []clients.VariantAttribute{{Name: "cor", Value: "Preta"}, {Name: "tamanho", Value: "M"}},
+	}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := NewOrderService(store, nil, inventory, nil, nil, ReservationTTLs{}).CreateOrder(context.Background(), CreateOrderInput{
+		Customer: "7", SKU: "CAM-BAS-011-PT-M", Quantity: 1, Price: money.New(4990, "BRL"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "var-002", order.VariantID)
+	assert.Equal(t, "Camiseta Básica - Preta", order.ProductName)
+	assert.Equal(t, domain.Attributes{"cor": "Preta", "tamanho": "M"}, order.Attributes)
+}
+
+func TestCreateOrder_ReservationTTLByPaymentMethod(t *testing.T) {
+	ttls := ReservationTTLs{Default: 15 * time.Minute, Pix: 30 * time.Minute, Boleto: 12 * 24 * time.Hour}
+
+	tests := []struct {
+		method string
+		ttl    time.Duration
+	}{
+		{"", 15 * time.Minute},
+		{domain.PaymentMethodCard, 15 * time.Minute},
+		{domain.PaymentMethodPix, 30 * time.Minute},
+		{domain.PaymentMethodBoleto, 12 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run("forma "+tt.method, func(t *testing.T) {
+			store := new(MockOrderStore)
+			inventory := new(MockInventoryClient)
+			inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 199990, Currency: "BRL"}}, nil)
+			store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+			input := validOrderInput()
+			input.PaymentMethod = tt.method
+			_, err := NewOrderService(store, nil, inventory, nil, nil, ttls).CreateOrder(context.Background(), input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.ttl, inventory.reservedTTL)
+		})
+	}
+
+	t.Run("sem prazo próprio usa o padrão", func(t *testing.T) {
+		assert.Equal(t, 15*time.Minute, ReservationTTLs{Default: 15 * time.Minute}.For(domain.PaymentMethodBoleto))
+	})
+}
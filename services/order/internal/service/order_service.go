@@ -1,69 +1,258 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/clients"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/messaging"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/promotion"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/tax"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Tempo máximo das chamadas ao catalog-service
+const inventoryTimeout = 5 * time.Second
+
+var (
+	ErrOrderNotFound       = errors.New("pedido não encontrado")
+	ErrOrderNotCancellable = errors.New("apenas pedidos aguardando pagamento podem ser cancelados")
+	ErrStockUnavailable    = errors.New("estoque indisponível")
+	ErrPriceUnavailable    = errors.New("preço do produto indisponível no catálogo")
+	ErrNothingToCharge     = errors.New("os descontos não podem cobrir o valor total do pedido")
+	ErrInvalidCurrency     = errors.New("moeda de pagamento não suportada")
+	ErrInvalidInstallments = errors.New("número de parcelas inválido")
+	ErrInvalidPayment      = errors.New("forma de pagamento não suportada")
+	ErrInvalidRisk         = errors.New("sinais de risco do pagamento inválidos")
 )
 
+// CreateOrderInput contém os dados de um novo pedido.
+// Price é o preço exibido ao cliente; o pedido usa sempre o preço vigente no catálogo.
+// DestinationState é a UF de entrega; vazia, vale a UF padrão do serviço de tributos.
+// PaymentCurrency é a moeda em que o cliente paga, convertida pelo payment-service na
+// cobrança; vazia, a moeda do pedido. Installments é o número de parcelas (0 ou 1,
+// à vista); as regras de parcelamento do lojista são aplicadas pelo payment-service.
+// PaymentMethod é a forma de pagamento (card, pix ou boleto); vazia, card. Risk são
+// os sinais repassados à análise antifraude do payment-service; todos são opcionais.
+type CreateOrderInput struct {
+	Customer         string
+	ProductID        uint
+	SKU              string
+	Quantity         int
+	Price            money.Money
+	CouponCodes      []string
+	DestinationState string
+	PaymentCurrency  string
+	Installments     int
+	PaymentMethod    string
+	Risk             domain.PaymentRisk
+}
+
+// ReservationTTLs são os prazos das reservas de estoque por forma de pagamento. A reserva
+// precisa durar até o resultado do pagamento: minutos no cartão, a validade da cobrança no
+// PIX e, no boleto, o vencimento, a tolerância após ele e o retorno do banco. Prazos zero
+// usam Default, e Default zero, o padrão do catálogo.
+type ReservationTTLs struct {
+	Default time.Duration
+	Pix     time.Duration
+	Boleto  time.Duration
+}
+
+// For retorna o prazo da reserva para a forma de pagamento
+func (t ReservationTTLs) For(method string) time.Duration {
+	switch {
+	case method == domain.PaymentMethodPix && t.Pix > 0:
+		return t.Pix
+	case method == domain.PaymentMethodBoleto && t.Boleto > 0:
+		return t.Boleto
+	}
+	return t.Default
+}
+
 // OrderService define a interface para regras de negócio de pedidos
 type OrderService interface {
-	CreateOrder(customer string, productID uint, quantity int, price float64) (*domain.Order, error)
+	CreateOrder(ctx context.Context, input CreateOrderInput) (*domain.Order, error)
 	ListOrders() ([]domain.Order, error)
 	GetOrderByID(id uint) (*domain.Order, error)
+	CancelOrder(ctx context.Context, id uint) (*domain.Order, error)
+	HandlePaymentResult(ctx context.Context, orderID uint, approved bool) error
+	ListOrdersByCustomers(customers []string) ([]domain.Order, error)
+	AnonymizeCustomer(customers []string, pseudonym string) ([]uint, error)
 }
 
 // orderService implementa OrderService
 type orderService struct {
 	orderRepo      repository.OrderRepository
 	orderPublisher *messaging.OrderPublisher
+	inventory      clients.InventoryClient
+	promotions     PromotionService
+	taxes          TaxService
+	reservationTTL ReservationTTLs
 }
 
-// NewOrderService cria uma nova instância do serviço de pedidos
-func NewOrderService(orderRepo repository.OrderRepository, orderPublisher *messaging.OrderPublisher) OrderService {
+// NewOrderService cria uma nova instância do serviço de pedidos.
+// Sem promotions, os pedidos são criados sem descontos e cupons são recusados;
+// sem taxes, os pedidos são criados sem tributos.
+func NewOrderService(orderRepo repository.OrderRepository, orderPublisher *messaging.OrderPublisher, inventory clients.InventoryClient, promotions PromotionService, taxes TaxService, reservationTTL ReservationTTLs) OrderService {
 	return &orderService{
 		orderRepo:      orderRepo,
 		orderPublisher: orderPublisher,
+		inventory:      inventory,
+		promotions:     promotions,
+		taxes:          taxes,
+		reservationTTL: reservationTTL,
 	}
 }
 
-// CreateOrder cria um novo pedido com validações de negócio
-func (s *orderService) CreateOrder(customer string, productID uint, quantity int, price float64) (*domain.Order, error) {
+// CreateOrder reserva o estoque no catálogo e só então grava o pedido.
+// Se o pedido não puder ser salvo, a reserva é liberada imediatamente;
+// reservas esquecidas expiram sozinhas no catálogo.
+func (s *orderService) CreateOrder(ctx context.Context, input CreateOrderInput) (*domain.Order, error) {
 	// Validações de negócio
-	if customer == "" {
+	if input.Customer == "" {
 		return nil, errors.New("nome do cliente é obrigatório")
 	}
 
-	if productID == 0 {
-		return nil, errors.New("ID do produto é obrigatório")
+	input.SKU = strings.TrimSpace(input.SKU)
+	if input.SKU == "" {
+		return nil, errors.New("SKU do produto é obrigatório")
 	}
 
-	if quantity <= 0 {
+	if input.Quantity <= 0 {
 		return nil, errors.New("quantidade deve ser maior que zero")
 	}
 
-	if price <= 0 {
-		return nil, errors.New("preço deve ser maior que zero")
+	if strings.TrimSpace(input.PaymentCurrency) != "" && !money.ValidCurrency(input.PaymentCurrency) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCurrency, input.PaymentCurrency)
+	}
+
+	if input.Installments < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidInstallments, input.Installments)
+	}
+
+	method, err := paymentMethod(input.PaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	risk, err := paymentRisk(input.Risk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reservar estoque antes de aceitar o pedido, pelo prazo da forma de pagamento
+	reserveCtx, cancel := context.WithTimeout(ctx, inventoryTimeout)
+	defer cancel()
+	reservation, err := s.inventory.Reserve(reserveCtx, input.SKU, input.Quantity, "customer:"+input.Customer, s.reservationTTL.For(method))
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+
+	// O preço vem do catálogo (vigente no momento da reserva), nunca do cliente,
+	// já em unidades menores e na moeda do produto, que passa a ser a do pedido.
+	unitPrice, ok := reservationPrice(reservation)
+	if !ok {
+		log.Printf("⚠️ Reserva %s sem preço válido para o SKU %s", reservation.ID, input.SKU)
+		s.releaseReservation(ctx, reservation.ID)
+		return nil, ErrPriceUnavailable
+	}
+	if input.Price.IsPositive() && input.Price != unitPrice {
+		log.Printf("ℹ️ Preço informado pelo cliente (%s) difere do catálogo (%s) para o SKU %s; usando o do catálogo",
+			input.Price, unitPrice, input.SKU)
+	}
+
+	// Aplicar promoções automáticas e cupons sobre o preço do catálogo
+	pricing, err := s.applyPromotions(input, unitPrice)
+	if err != nil {
+		s.releaseReservation(ctx, reservation.ID)
+		return nil, err
+	}
+	if pricing.Total <= 0 {
+		s.releaseReservation(ctx, reservation.ID)
+		return nil, ErrNothingToCharge
+	}
+
+	// Calcular os tributos sobre o valor da linha já com os descontos
+	taxes, err := s.calculateTaxes(input, reservation.TaxClass, pricing.Total)
+	if err != nil {
+		s.releaseReservation(ctx, reservation.ID)
+		return nil, err
 	}
 
-	// Criar o pedido
+	// Criar o pedido com a cópia do item reservado (nome, preço e atributos da variação)
 	order := &domain.Order{
-		Customer:  customer,
-		ProductID: productID,
-		Quantity:  quantity,
-		Price:     price,
+		Customer:         input.Customer,
+		ProductID:        input.ProductID,
+		SKU:              input.SKU,
+		VariantID:        reservation.VariantID,
+		ProductName:      reservation.ProductName,
+		Attributes:       snapshotAttributes(reservation.Attributes),
+		Quantity:         input.Quantity,
+		Currency:         unitPrice.Currency,
+		PaymentCurrency:  paymentCurrency(input.PaymentCurrency, unitPrice.Currency),
+		Installments:     max(input.Installments, 1),
+		PaymentMethod:    method,
+		Price:            unitPrice.Amount,
+		Subtotal:         pricing.Subtotal,
+		DiscountTotal:    pricing.DiscountTotal,
+		TaxTotal:         taxes.Total,
+		Total:            pricing.Total + taxes.Added,
+		DestinationState: taxes.State,
+		Risk:             risk,
+		Status:           domain.OrderStatusPending,
+		ReservationID:    reservation.ID,
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, reservation.ExpiresAt); err == nil {
+		order.ReservationExpiresAt = &expiresAt
+	}
+	for _, discount := range pricing.Discounts {
+		order.Discounts = append(order.Discounts, domain.OrderDiscount{
+			Line:        discount.Line,
+			SKU:         discount.SKU,
+			PromotionID: discount.RuleID,
+			Code:        discount.Code,
+			Name:        discount.Name,
+			Type:        discount.Type,
+			Amount:      discount.Amount,
+		})
+	}
+	for _, item := range taxes.Items {
+		order.Taxes = append(order.Taxes, domain.OrderTax{
+			Line:     item.Line,
+			SKU:      item.SKU,
+			TaxClass: item.TaxClass,
+			Tax:      item.Tax,
+			State:    item.State,
+			Rate:     item.Rate.Percent(),
+			Base:     item.Base,
+			Amount:   item.Amount,
+			Included: item.Included,
+		})
 	}
 
 	// Salvar no repositório
 	if err := s.orderRepo.Create(order); err != nil {
+		s.releaseReservation(ctx, reservation.ID)
+		if errors.Is(err, repository.ErrPromotionExhausted) {
+			log.Printf("ℹ️ Pedido do cliente %s recusado na gravação: %v", order.Customer, err)
+			return nil, fmt.Errorf("%w: %w", ErrCouponRejected, err)
+		}
 		log.Printf("Erro ao criar pedido: %v", err)
 		return nil, errors.New("falha ao salvar pedido")
 	}
 
-	log.Printf("✅ Pedido criado com sucesso no banco: ID=%d, Cliente=%s", order.ID, order.Customer)
+	log.Printf("✅ Pedido criado com sucesso no banco: ID=%d, Cliente=%s, Reserva=%s, Total=%s, Desconto=%s, Tributos=%s",
+		order.ID, order.Customer, order.ReservationID, order.Money(order.Total), order.Money(order.DiscountTotal), order.Money(order.TaxTotal))
 
 	// Publicar evento no RabbitMQ
 	if s.orderPublisher != nil {
@@ -78,6 +267,209 @@ func (s *orderService) CreateOrder(customer string, productID uint, quantity int
 	return order, nil
 }
 
+// applyPromotions calcula o valor do pedido, de linha única, com os descontos aplicáveis
+func (s *orderService) applyPromotions(input CreateOrderInput, unitPrice money.Money) (*promotion.Result, error) {
+	basket := promotion.Basket{
+		Customer: input.Customer,
+		Currency: unitPrice.Currency,
+		Lines:    []promotion.Line{{Number: 1, SKU: input.SKU, Quantity: input.Quantity, UnitPrice: unitPrice.Amount}},
+		At:       time.Now(),
+	}
+
+	if s.promotions == nil {
+		if len(nonEmpty(input.CouponCodes)) > 0 {
+			return nil, fmt.Errorf("%w: promoções indisponíveis", ErrCouponRejected)
+		}
+		subtotal := basket.Subtotal()
+		return &promotion.Result{Subtotal: subtotal, Total: subtotal}, nil
+	}
+	return s.promotions.ApplyPromotions(basket, input.CouponCodes)
+}
+
+// calculateTaxes calcula os tributos da linha única do pedido. Sem serviço de tributos,
+// o pedido não tem tributos, mas mantém a UF de destino informada quando válida.
+func (s *orderService) calculateTaxes(input CreateOrderInput, taxClass string, amount int64) (*tax.Result, error) {
+	if s.taxes == nil {
+		state := tax.NormalizeState(input.DestinationState)
+		if !tax.ValidState(state) {
+			state = ""
+		}
+		return &tax.Result{State: state}, nil
+	}
+	return s.taxes.CalculateTaxes(input.DestinationState, []tax.Line{
+		{Number: 1, SKU: input.SKU, TaxClass: taxClass, Amount: amount},
+	})
+}
+
+// CancelOrder cancela um pedido que ainda aguarda pagamento e devolve o estoque reservado
+func (s *orderService) CancelOrder(ctx context.Context, id uint) (*domain.Order, error) {
+	order, err := s.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status == domain.OrderStatusCancelled {
+		return order, nil
+	}
+	if !order.IsPending() {
+		return nil, ErrOrderNotCancellable
+	}
+
+	if err := s.orderRepo.UpdateStatus(order.ID, domain.OrderStatusCancelled); err != nil {
+		log.Printf("Erro ao cancelar pedido %d: %v", order.ID, err)
+		return nil, errors.New("falha ao cancelar pedido")
+	}
+	order.Status = domain.OrderStatusCancelled
+	s.releaseReservation(ctx, order.ReservationID)
+
+	log.Printf("🚫 Pedido %d cancelado", order.ID)
+	return order, nil
+}
+
+// HandlePaymentResult confirma a reserva quando o pagamento é aprovado ou a libera
+// quando é recusado. Aprovações que chegam com a reserva vencida deixam o pedido em
+// conferência manual em vez de pago. Pedidos que já saíram do estado pendente são
+// ignorados, o que torna o processamento seguro para mensagens reentregues.
+func (s *orderService) HandlePaymentResult(ctx context.Context, orderID uint, approved bool) error {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("⚠️ Resultado de pagamento para pedido inexistente: %d", orderID)
+			return nil
+		}
+		return err
+	}
+
+	if !order.IsPending() {
+		log.Printf("ℹ️ Pedido %d já está em %s; resultado de pagamento ignorado", order.ID, order.Status)
+		return nil
+	}
+
+	if !approved {
+		if err := s.orderRepo.UpdateStatus(order.ID, domain.OrderStatusPaymentFailed); err != nil {
+			return err
+		}
+		s.releaseReservation(ctx, order.ReservationID)
+		log.Printf("❌ Pagamento recusado: pedido %d marcado como %s", order.ID, domain.OrderStatusPaymentFailed)
+		return nil
+	}
+
+	if order.ReservationID != "" {
+		commitCtx, cancel := context.WithTimeout(ctx, inventoryTimeout)
+		defer cancel()
+		if _, err := s.inventory.Commit(commitCtx, order.ReservationID); err != nil {
+			if status.Code(err) != codes.FailedPrecondition {
+				// Falha transitória: a mensagem será reprocessada
+				return err
+			}
+			// Reserva vencida ou liberada antes da confirmação: o estoque pode já ter sido
+			// vendido, então o pedido não vira pago e aguarda reposição ou estorno manual
+			if err := s.orderRepo.UpdateStatus(order.ID, domain.OrderStatusStockReview); err != nil {
+				return err
+			}
+			log.Printf("⚠️ Pedido %d pago, mas a reserva %s não pôde ser confirmada (%v); marcado como %s",
+				order.ID, order.ReservationID, err, domain.OrderStatusStockReview)
+			return nil
+		}
+	}
+
+	if err := s.orderRepo.UpdateStatus(order.ID, domain.OrderStatusPaid); err != nil {
+		return err
+	}
+	log.Printf("💰 Pagamento aprovado: pedido %d marcado como %s", order.ID, domain.OrderStatusPaid)
+	return nil
+}
+
+// releaseReservation devolve uma reserva ao estoque. Falhas são apenas registradas:
+// a reserva expira sozinha no catálogo.
+func (s *orderService) releaseReservation(ctx context.Context, reservationID string) {
+	if reservationID == "" {
+		return
+	}
+
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), inventoryTimeout)
+	defer cancel()
+	if _, err := s.inventory.Release(releaseCtx, reservationID); err != nil {
+		log.Printf("⚠️ Erro ao liberar reserva %s (expirará automaticamente): %v", reservationID, err)
+		return
+	}
+	log.Printf("↩️ Reserva %s liberada", reservationID)
+}
+
+// reservationPrice lê o preço unitário da reserva; sem preço positivo em moeda
+// suportada o pedido não pode ser aceito
+func reservationPrice(reservation *clients.Reservation) (money.Money, bool) {
+	price := reservation.UnitPrice
+	if price == nil || price.Amount <= 0 || strings.TrimSpace(price.Currency) == "" || !money.ValidCurrency(price.Currency) {
+		return money.Money{}, false
+	}
+	return money.New(price.Amount, price.Currency), true
+}
+
+// paymentCurrency retorna a moeda de pagamento informada ou, na falta dela, a do pedido
+func paymentCurrency(requested, orderCurrency string) string {
+	if strings.TrimSpace(requested) == "" {
+		return orderCurrency
+	}
+	return money.NormalizeCurrency(requested)
+}
+
+// paymentMethod normaliza e valida a forma de pagamento; vazia, cartão
+func paymentMethod(method string) (string, error) {
+	switch method = strings.ToLower(strings.TrimSpace(method)); method {
+	case "":
+		return domain.PaymentMethodCard, nil
+	case domain.PaymentMethodCard, domain.PaymentMethodPix, domain.PaymentMethodBoleto:
+		return method, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidPayment, method)
+	}
+}
+
+// paymentRisk normaliza os sinais de risco: a UF de cobrança, quando informada, precisa
+// ser válida; códigos de país que não têm duas letras são descartados
+func paymentRisk(risk domain.PaymentRisk) (domain.PaymentRisk, error) {
+	risk.CardFingerprint = strings.TrimSpace(risk.CardFingerprint)
+	if len(risk.CardFingerprint) > 64 {
+		return domain.PaymentRisk{}, fmt.Errorf("%w: impressão digital do cartão com mais de 64 caracteres", ErrInvalidRisk)
+	}
+	if risk.BillingState != "" {
+		risk.BillingState = tax.NormalizeState(risk.BillingState)
+		if !tax.ValidState(risk.BillingState) {
+			return domain.PaymentRisk{}, fmt.Errorf("%w: UF de cobrança %s", ErrInvalidRisk, risk.BillingState)
+		}
+	}
+	risk.IPCountry = strings.ToUpper(strings.TrimSpace(risk.IPCountry))
+	if len(risk.IPCountry) != 2 {
+		risk.IPCountry = ""
+	}
+	return risk, nil
+}
+
+// snapshotAttributes copia os atributos da variação reservada para o pedido
+func snapshotAttributes(attributes []clients.VariantAttribute) domain.Attributes {
+	if len(attributes) == 0 {
+		return nil
+	}
+	snapshot := make(domain.Attributes, len(attributes))
+	for _, attribute := range attributes {
+		snapshot[attribute.Name] = attribute.Value
+	}
+	return snapshot
+}
+
+// inventoryError traduz a recusa do catálogo em erro de negócio do pedido
+func inventoryError(err error) error {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.FailedPrecondition, codes.NotFound, codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrStockUnavailable, st.Message())
+	default:
+		log.Printf("Erro ao reservar estoque: %v", err)
+		return errors.New("falha ao reservar estoque")
+	}
+}
+
 // ListOrders retorna todos os pedidos
 func (s *orderService) ListOrders() ([]domain.Order, error) {
 	orders, err := s.orderRepo.List()
@@ -99,8 +491,54 @@ func (s *orderService) GetOrderByID(id uint) (*domain.Order, error) {
 	order, err := s.orderRepo.GetByID(id)
 	if err != nil {
 		log.Printf("Erro ao buscar pedido %d: %v", id, err)
-		return nil, errors.New("pedido não encontrado")
+		return nil, ErrOrderNotFound
 	}
 
 	return order, nil
 }
+
+// ListOrdersByCustomers retorna os pedidos de um titular para exportação (LGPD)
+func (s *orderService) ListOrdersByCustomers(customers []string) ([]domain.Order, error) {
+	customers = nonEmpty(customers)
+	if len(customers) == 0 {
+		return nil, errors.New("ao menos um identificador do cliente é obrigatório")
+	}
+
+	orders, err := s.orderRepo.ListByCustomers(customers)
+	if err != nil {
+		log.Printf("Erro ao buscar pedidos do titular: %v", err)
+		return nil, errors.New("falha ao buscar pedidos")
+	}
+	return orders, nil
+}
+
+// AnonymizeCustomer substitui os identificadores do titular por um pseudônimo.
+// Valores, quantidades e datas são preservados para fins fiscais.
+func (s *orderService) AnonymizeCustomer(customers []string, pseudonym string) ([]uint, error) {
+	customers = nonEmpty(customers)
+	if len(customers) == 0 {
+		return nil, errors.New("ao menos um identificador do cliente é obrigatório")
+	}
+	if pseudonym == "" {
+		return nil, errors.New("pseudônimo é obrigatório")
+	}
+
+	ids, err := s.orderRepo.AnonymizeCustomers(customers, pseudonym)
+	if err != nil {
+		log.Printf("Erro ao anonimizar pedidos: %v", err)
+		return nil, errors.New("falha ao anonimizar pedidos")
+	}
+
+	log.Printf("🕶️ %d pedidos anonimizados como %s", len(ids), pseudonym)
+	return ids, nil
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
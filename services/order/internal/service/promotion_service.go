@@ -0,0 +1,344 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/promotion"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// MaxCouponCodes limita os cupons informados em um pedido
+const MaxCouponCodes = 5
+
+var (
+	ErrPromotionNotFound  = errors.New("promoção não encontrada")
+	ErrPromotionCodeInUse = errors.New("já existe uma promoção com este código")
+	ErrInvalidPromotion   = errors.New("promoção inválida")
+	ErrCouponRejected     = errors.New("cupom não aplicado")
+
+	errPromotionsUnavailable = errors.New("falha ao consultar promoções")
+)
+
+// couponCodePattern define o formato aceito para códigos de promoção
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+// PromotionInput contém os dados de uma nova promoção. Value é o percentual das
+// promoções percent; Amount é o desconto das promoções fixed.
+type PromotionInput struct {
+	Code               string
+	Name               string
+	Type               string
+	Value              float64
+	Amount             money.Money
+	BuyQuantity        int
+	GetQuantity        int
+	MinSubtotal        money.Money
+	SKUs               []string
+	Automatic          bool
+	Stacking           string
+	Priority           int
+	ValidFrom          *time.Time
+	ValidTo            *time.Time
+	MaxUses            int
+	MaxUsesPerCustomer int
+}
+
+// PromotionService define a interface para cadastro e aplicação de promoções
+type PromotionService interface {
+	CreatePromotion(input PromotionInput) (*domain.Promotion, error)
+	ListPromotions(activeOnly bool) ([]domain.Promotion, error)
+	SetPromotionActive(code string, active bool) (*domain.Promotion, error)
+	ApplyPromotions(basket promotion.Basket, couponCodes []string) (*promotion.Result, error)
+}
+
+// promotionService implementa PromotionService
+type promotionService struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewPromotionService cria uma nova instância do serviço de promoções
+func NewPromotionService(promotionRepo repository.PromotionRepository) PromotionService {
+	return &promotionService{
+		promotionRepo: promotionRepo,
+	}
+}
+
+// CreatePromotion valida e grava uma nova promoção, já ativa
+func (s *promotionService) CreatePromotion(input PromotionInput) (*domain.Promotion, error) {
+	promo, err := newPromotion(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.promotionRepo.GetByCode(promo.Code); err == nil {
+		return nil, ErrPromotionCodeInUse
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Erro ao buscar promoção %s: %v", promo.Code, err)
+		return nil, errPromotionsUnavailable
+	}
+
+	if err := s.promotionRepo.Create(promo); err != nil {
+		log.Printf("Erro ao criar promoção %s: %v", promo.Code, err)
+		return nil, errors.New("falha ao salvar promoção")
+	}
+
+	log.Printf("🏷️ Promoção criada: Código=%s, Tipo=%s, Automática=%t", promo.Code, promo.Type, promo.Automatic)
+	return promo, nil
+}
+
+// newPromotion normaliza e valida os dados da promoção
+func newPromotion(input PromotionInput) (*domain.Promotion, error) {
+	invalid := func(msg string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidPromotion, msg)
+	}
+
+	promo := &domain.Promotion{
+		Code:               normalizeCouponCode(input.Code),
+		Name:               strings.TrimSpace(input.Name),
+		Type:               strings.TrimSpace(input.Type),
+		Value:              input.Value,
+		Amount:             input.Amount.Amount,
+		Currency:           money.NormalizeCurrency(input.Amount.Currency),
+		BuyQuantity:        input.BuyQuantity,
+		GetQuantity:        input.GetQuantity,
+		MinSubtotal:        input.MinSubtotal.Amount,
+		Automatic:          input.Automatic,
+		Stacking:           strings.TrimSpace(input.Stacking),
+		Priority:           input.Priority,
+		ValidFrom:          input.ValidFrom,
+		ValidTo:            input.ValidTo,
+		MaxUses:            input.MaxUses,
+		MaxUsesPerCustomer: input.MaxUsesPerCustomer,
+		Active:             true,
+	}
+	for _, sku := range input.SKUs {
+		if sku = strings.TrimSpace(sku); sku != "" {
+			promo.SKUs = append(promo.SKUs, sku)
+		}
+	}
+	if promo.Stacking == "" {
+		promo.Stacking = promotion.StackingStackable
+	}
+
+	if input.Amount.IsZero() && !input.MinSubtotal.IsZero() {
+		promo.Currency = money.NormalizeCurrency(input.MinSubtotal.Currency)
+	}
+
+	if !couponCodePattern.MatchString(promo.Code) {
+		return nil, invalid("código deve ter de 3 a 50 letras, dígitos, - ou _")
+	}
+	if promo.Name == "" {
+		return nil, invalid("nome é obrigatório")
+	}
+
+	switch promo.Type {
+	case promotion.TypePercent:
+		if _, err := money.RateFromPercent(promo.Value); err != nil || promo.Value <= 0 || promo.Value > 100 {
+			return nil, invalid("percentual deve estar entre 0 e 100")
+		}
+		promo.Amount = 0
+	case promotion.TypeFixed:
+		if promo.Amount <= 0 {
+			return nil, invalid("valor do desconto deve ser maior que zero")
+		}
+		promo.Value = 0
+	case promotion.TypeBuyXGetY:
+		if promo.BuyQuantity <= 0 || promo.GetQuantity <= 0 {
+			return nil, invalid("informe as quantidades compradas e levadas")
+		}
+		promo.Value = 0
+		promo.Amount = 0
+	default:
+		return nil, invalid("tipo deve ser percent, fixed ou buy_x_get_y")
+	}
+
+	if promo.Stacking != promotion.StackingStackable && promo.Stacking != promotion.StackingExclusive {
+		return nil, invalid("acumulação deve ser stackable ou exclusive")
+	}
+	if !money.ValidCurrency(promo.Currency) {
+		return nil, invalid("moeda não suportada")
+	}
+	if !input.Amount.IsZero() && !input.MinSubtotal.IsZero() &&
+		money.NormalizeCurrency(input.Amount.Currency) != money.NormalizeCurrency(input.MinSubtotal.Currency) {
+		return nil, invalid("desconto e valor mínimo devem estar na mesma moeda")
+	}
+	if promo.MinSubtotal < 0 || promo.MaxUses < 0 || promo.MaxUsesPerCustomer < 0 {
+		return nil, invalid("valor mínimo e limites de uso não podem ser negativos")
+	}
+	if promo.ValidFrom != nil && promo.ValidTo != nil && !promo.ValidTo.After(*promo.ValidFrom) {
+		return nil, invalid("fim da vigência deve ser posterior ao início")
+	}
+	return promo, nil
+}
+
+// ListPromotions retorna as promoções cadastradas
+func (s *promotionService) ListPromotions(activeOnly bool) ([]domain.Promotion, error) {
+	promotions, err := s.promotionRepo.List(activeOnly)
+	if err != nil {
+		log.Printf("Erro ao listar promoções: %v", err)
+		return nil, errPromotionsUnavailable
+	}
+	return promotions, nil
+}
+
+// SetPromotionActive ativa ou desativa uma promoção. Pedidos já criados mantêm o desconto.
+func (s *promotionService) SetPromotionActive(code string, active bool) (*domain.Promotion, error) {
+	promo, err := s.promotionRepo.GetByCode(normalizeCouponCode(code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		log.Printf("Erro ao buscar promoção %s: %v", code, err)
+		return nil, errPromotionsUnavailable
+	}
+
+	if promo.Active != active {
+		if err := s.promotionRepo.SetActive(promo.ID, active); err != nil {
+			log.Printf("Erro ao alterar promoção %s: %v", promo.Code, err)
+			return nil, errors.New("falha ao salvar promoção")
+		}
+		promo.Active = active
+		log.Printf("🏷️ Promoção %s ativa=%t", promo.Code, active)
+	}
+	return promo, nil
+}
+
+// ApplyPromotions avalia as promoções automáticas vigentes e os cupons informados.
+// Um cupom inexistente, inativo ou que não atende às condições recusa o pedido;
+// um cupom válido que perde na política de acumulação apenas não é aplicado.
+func (s *promotionService) ApplyPromotions(basket promotion.Basket, couponCodes []string) (*promotion.Result, error) {
+	codes, err := normalizeCouponCodes(couponCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	automatic, err := s.promotionRepo.ListAutomatic(basket.At)
+	if err != nil {
+		log.Printf("Erro ao listar promoções automáticas: %v", err)
+		return nil, errPromotionsUnavailable
+	}
+
+	var coupons []domain.Promotion
+	if len(codes) > 0 {
+		if coupons, err = s.promotionRepo.GetByCodes(codes); err != nil {
+			log.Printf("Erro ao buscar cupons: %v", err)
+			return nil, errPromotionsUnavailable
+		}
+	}
+
+	byCode := make(map[string]domain.Promotion, len(coupons))
+	for _, coupon := range coupons {
+		byCode[coupon.Code] = coupon
+	}
+
+	byID := make(map[uint]promotion.Rule)
+	var rules []promotion.Rule
+	var ids []uint
+	for _, promo := range automatic {
+		rule, err := toRule(promo)
+		if err != nil {
+			return nil, err
+		}
+		byID[promo.ID] = rule
+		rules = append(rules, rule)
+		ids = append(ids, promo.ID)
+	}
+	for _, code := range codes {
+		coupon, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: cupom inexistente", ErrCouponRejected, code)
+		}
+		if !coupon.Active {
+			return nil, fmt.Errorf("%w: %s: cupom inativo", ErrCouponRejected, code)
+		}
+		if _, ok := byID[coupon.ID]; ok {
+			continue
+		}
+		rule, err := toRule(coupon)
+		if err != nil {
+			return nil, err
+		}
+		byID[coupon.ID] = rule
+		rules = append(rules, rule)
+		ids = append(ids, coupon.ID)
+	}
+
+	usage, err := s.promotionRepo.CountUsage(ids, basket.Customer)
+	if err != nil {
+		log.Printf("Erro ao contar uso das promoções: %v", err)
+		return nil, errPromotionsUnavailable
+	}
+
+	for _, code := range codes {
+		coupon := byCode[code]
+		if err := promotion.Check(byID[coupon.ID], basket, usage[coupon.ID]); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrCouponRejected, code, err)
+		}
+	}
+
+	result := promotion.Evaluate(basket, rules, usage)
+	for _, skipped := range result.Skipped {
+		log.Printf("ℹ️ Promoção %s não aplicada para o cliente %s: %s", skipped.Rule.Code, basket.Customer, skipped.Reason)
+	}
+	return &result, nil
+}
+
+// toRule converte a promoção gravada na regra avaliada pelo motor de promoções
+func toRule(promo domain.Promotion) (promotion.Rule, error) {
+	rate, err := money.RateFromPercent(promo.Value)
+	if err != nil {
+		log.Printf("Percentual inválido na promoção %d: %v", promo.ID, err)
+		return promotion.Rule{}, errPromotionsUnavailable
+	}
+	return promotion.Rule{
+		ID:                 promo.ID,
+		Code:               promo.Code,
+		Name:               promo.Name,
+		Type:               promo.Type,
+		Rate:               rate,
+		Amount:             promo.Amount,
+		Currency:           promo.Currency,
+		BuyQuantity:        promo.BuyQuantity,
+		GetQuantity:        promo.GetQuantity,
+		MinSubtotal:        promo.MinSubtotal,
+		SKUs:               promo.SKUs,
+		ValidFrom:          promo.ValidFrom,
+		ValidTo:            promo.ValidTo,
+		MaxUses:            promo.MaxUses,
+		MaxUsesPerCustomer: promo.MaxUsesPerCustomer,
+		Stacking:           promo.Stacking,
+		Priority:           promo.Priority,
+	}, nil
+}
+
+// normalizeCouponCodes remove espaços e repetições dos cupons informados
+func normalizeCouponCodes(codes []string) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		code = normalizeCouponCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		result = append(result, code)
+	}
+	if len(result) > MaxCouponCodes {
+		return nil, fmt.Errorf("%w: informe no máximo %d cupons", ErrCouponRejected, MaxCouponCodes)
+	}
+	return result, nil
+}
+
+// normalizeCouponCode padroniza o código em maiúsculas, sem espaços nas pontas
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
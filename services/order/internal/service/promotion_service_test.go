@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/promotion"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/repository"
+)
+
+// MockPromotionRepository is a mock implementation of repository.PromotionRepository
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) Create(promo *domain.Promotion) error {
+	return m.Called(promo).Error(0)
+}
+
+func (m *MockPromotionRepository) GetByCode(code string) (*domain.Promotion, error) {
+	args := m.Called(code)
+	promo, _ := args.Get(0).(*domain.Promotion)
+	return promo, args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetByCodes(codes []string) ([]domain.Promotion, error) {
+	args := m.Called(codes)
+	return args.Get(0).([]domain.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) List(activeOnly bool) ([]domain.Promotion, error) {
+	args := m.Called(activeOnly)
+	return args.Get(0).([]domain.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) ListAutomatic(at time.Time) ([]domain.Promotion, error) {
+	args := m.Called(at)
+	return args.Get(0).([]domain.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) SetActive(id uint, active bool) error {
+	return m.Called(id, active).Error(0)
+}
+
+func (m *MockPromotionRepository) CountUsage(promotionIDs []uint, customer string) (map[uint]promotion.Usage, error) {
+	args := m.Called(promotionIDs, customer)
+	usage, _ := args.Get(0).(map[uint]promotion.Usage)
+	return usage, args.Error(1)
+}
+
+func testBasket() promotion.Basket {
+	return promotion.Basket{
+		Customer: "7",
+		Lines:    []promotion.Line{{Number: 1, SKU: "SMART-XYZ-001", Quantity: 2, UnitPrice: 10000}},
+		At:       time.Now(),
+	}
+}
+
+func TestApplyPromotions_CombinesAutomaticAndCoupon(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	repo.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{
+		{ID: 1, Code: "SEMANA", Type: promotion.TypePercent, Value: 10, Automatic: true, Active: true, Stacking: promotion.StackingStackable},
+	}, nil)
+	repo.On("GetByCodes", []string{"MENOS20"}).Return([]domain.Promotion{
+		{ID: 2, Code: "MENOS20", Type: promotion.TypeFixed, Amount: 2000, Active: true, Stacking: promotion.StackingStackable},
+	}, nil)
+	repo.On("CountUsage", []uint{1, 2}, "7").Return(map[uint]promotion.Usage{}, nil)
+
+	result, err := NewPromotionService(repo).ApplyPromotions(testBasket(), []string{" menos20 "})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), result.Subtotal)
+	assert.Equal(t, int64(4000), result.DiscountTotal)
+	assert.Equal(t, int64(16000), result.Total)
+	assert.Len(t, result.Discounts, 2)
+}
+
+func TestApplyPromotions_RejectsUnknownCoupon(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	repo.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{}, nil)
+	repo.On("GetByCodes", []string{"NAOEXISTE"}).Return([]domain.Promotion{}, nil)
+
+	_, err := NewPromotionService(repo).ApplyPromotions(testBasket(), []string{"NAOEXISTE"})
+
+	assert.ErrorIs(t, err, ErrCouponRejected)
+}
+
+func TestApplyPromotions_RejectsCouponOverCustomerLimit(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	repo.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{}, nil)
+	repo.On("GetByCodes", []string{"PRIMEIRA"}).Return([]domain.Promotion{
+		{ID: 3, Code: "PRIMEIRA", Type: promotion.TypePercent, Value: 15, Active: true, MaxUsesPerCustomer: 1},
+	}, nil)
+	repo.On("CountUsage", []uint{3}, "7").Return(map[uint]promotion.Usage{3: {Total: 40, Customer: 1}}, nil)
+
+	_, err := NewPromotionService(repo).ApplyPromotions(testBasket(), []string{"PRIMEIRA"})
+
+	assert.ErrorIs(t, err, ErrCouponRejected)
+	assert.ErrorContains(t, err, promotion.ErrCustomerUsageLimit.Error())
+}
+
+func TestApplyPromotions_RejectsInactiveCoupon(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	repo.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{}, nil)
+	repo.On("GetByCodes", []string{"VELHO"}).Return([]domain.Promotion{
+		{ID: 4, Code: "VELHO", Type: promotion.TypePercent, Value: 15, Active: false},
+	}, nil)
+
+	_, err := NewPromotionService(repo).ApplyPromotions(testBasket(), []string{"VELHO"})
+
+	assert.ErrorIs(t, err, ErrCouponRejected)
+}
+
+func TestCreatePromotion_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input PromotionInput
+	}{
+		{"código inválido", PromotionInput{Code: "x", Name: "Promo", Type: promotion.TypePercent, Value: 10}},
+		{"percentual acima de 100", PromotionInput{Code: "CEM", Name: "Promo", Type: promotion.TypePercent, Value: 101}},
+		{"percentual não numérico", PromotionInput{Code: "NAN", Name: "Promo", Type: promotion.TypePercent, Value: math.NaN()}},
+		{"leve e pague sem quantidades", PromotionInput{Code: "LEVE3", Name: "Promo", Type: promotion.TypeBuyXGetY}},
+		{"tipo desconhecido", PromotionInput{Code: "FRETE", Name: "Promo", Type: "shipping", Value: 10}},
+		{"acumulação desconhecida", PromotionInput{Code: "DEZ", Name: "Promo", Type: promotion.TypePercent, Value: 10, Stacking: "sometimes"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPromotionService(new(MockPromotionRepository)).CreatePromotion(tt.input)
+			assert.ErrorIs(t, err, ErrInvalidPromotion)
+		})
+	}
+}
+
+func TestCreatePromotion_NormalizesCode(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	repo.On("GetByCode", "BLACK-10").Return(nil, gorm.ErrRecordNotFound)
+	repo.On("Create", mock.AnythingOfType("*domain.Promotion")).Return(nil)
+
+	promo, err := NewPromotionService(repo).CreatePromotion(PromotionInput{
+		Code: " black-10 ", Name: "Black Friday", Type: promotion.TypePercent, Value: 10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "BLACK-10", promo.Code)
+	assert.Equal(t, promotion.StackingStackable, promo.Stacking)
+	assert.True(t, promo.Active)
+}
+
+func TestCreateOrder_AppliesCouponToTotal(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	promotions := new(MockPromotionRepository)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 10000, Currency: "BRL"}}, nil)
+	promotions.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{}, nil)
+	promotions.On("GetByCodes", []string{"DEZ"}).Return([]domain.Promotion{
+		{ID: 5, Code: "DEZ", Name: "10% off", Type: promotion.TypePercent, Value: 10, Active: true},
+	}, nil)
+	promotions.On("CountUsage", []uint{5}, "7").Return(map[uint]promotion.Usage{}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	input := validOrderInput()
+	input.CouponCodes = []string{"dez"}
+	order, err := NewOrderService(store, nil, inventory, NewPromotionService(promotions), nil, ReservationTTLs{}).CreateOrder(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), order.Subtotal)
+	assert.Equal(t, int64(2000), order.DiscountTotal)
+	assert.Equal(t, int64(18000), order.Total)
+	require.Len(t, order.Discounts, 1)
+	assert.Equal(t, domain.OrderDiscount{Line: 1, SKU: "SMART-XYZ-001", PromotionID: 5, Code: "DEZ", Name: "10% off", Type: promotion.TypePercent, Amount: 2000}, order.Discounts[0])
+}
+
+func TestCreateOrder_RejectedCouponReleasesReservation(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	promotions := new(MockPromotionRepository)
+	inventory.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 10000, Currency: "BRL"}}, nil)
+	inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+	promotions.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{}, nil)
+	promotions.On("GetByCodes", []string{"NAOEXISTE"}).Return([]domain.Promotion{}, nil)
+
+	input := validOrderInput()
+	input.CouponCodes = []string{"NAOEXISTE"}
+	_, err := NewOrderService(store, nil, inventory, NewPromotionService(promotions), nil, ReservationTTLs{}).CreateOrder(context.Background(), input)
+
+	assert.ErrorIs(t, err, ErrCouponRejected)
+	store.AssertNotCalled(t, "Create", mock.Anything)
+	inventory.AssertCalled(t, "Release", "res-1")
+}
+
+func TestCreateOrder_CouponExhaustedOnSaveReleasesReservation(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	promotions := new(MockPromotionRepository)
+	inventory.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 10000, Currency: "BRL"}}, nil)
+	inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+	promotions.On("ListAutomatic", mock.Anything).Return([]domain.Promotion{}, nil)
+	promotions.On("GetByCodes", []string{"ULTIMO"}).Return([]domain.Promotion{
+		{ID: 5, Code: "ULTIMO", Type: promotion.TypePercent, Value: 10, MaxUses: 1, Active: true},
+	}, nil)
+	// A contagem fora da transação ainda vê o último uso livre; outro pedido fica com ele antes da gravação
+	promotions.On("CountUsage", []uint{5}, "7").Return(map[uint]promotion.Usage{}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).
+		Return(fmt.Errorf("%w: ULTIMO: %w", repository.ErrPromotionExhausted, promotion.ErrUsageLimit))
+
+	input := validOrderInput()
+	input.CouponCodes = []string{"ULTIMO"}
+	_, err := NewOrderService(store, nil, inventory, NewPromotionService(promotions), nil, ReservationTTLs{}).CreateOrder(context.Background(), input)
+
+	assert.ErrorIs(t, err, ErrCouponRejected)
+	assert.ErrorIs(t, err, repository.ErrPromotionExhausted)
+	inventory.AssertCalled(t, "Release", "res-1")
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/tax"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+var (
+	ErrInvalidTaxRate     = errors.New("alíquota inválida")
+	ErrTaxRateNotFound    = errors.New("alíquota não encontrada")
+	ErrInvalidDestination = errors.New("UF de destino inválida")
+	ErrTaxNotConfigured   = errors.New("tributação não configurada para o item")
+
+	errTaxesUnavailable = errors.New("falha ao consultar a tabela de alíquotas")
+)
+
+// taxClassPattern define o formato das classes fiscais, o mesmo aceito pelo catálogo
+var taxClassPattern = regexp.MustCompile(`^[a-z0-9_-]{1,30}$`)
+
+// TaxRateInput contém os dados de uma alíquota da tabela fiscal
+type TaxRateInput struct {
+	TaxClass string
+	State    string
+	Tax      string
+	Rate     float64
+	Included bool
+}
+
+// TaxService define a interface para manutenção da tabela de alíquotas e cálculo de tributos
+type TaxService interface {
+	SetTaxRate(input TaxRateInput) (*domain.TaxRate, error)
+	ListTaxRates(taxClass string) ([]domain.TaxRate, error)
+	DeleteTaxRate(id uint) error
+	CalculateTaxes(state string, lines []tax.Line) (*tax.Result, error)
+}
+
+// taxService implementa TaxService
+type taxService struct {
+	taxRepo      repository.TaxRateRepository
+	defaultState string
+}
+
+// NewTaxService cria uma nova instância do serviço de tributos.
+// defaultState é a UF usada quando o pedido não informa o destino.
+func NewTaxService(taxRepo repository.TaxRateRepository, defaultState string) TaxService {
+	return &taxService{
+		taxRepo:      taxRepo,
+		defaultState: tax.NormalizeState(defaultState),
+	}
+}
+
+// SetTaxRate cria ou substitui a alíquota de uma classe fiscal, UF e tributo
+func (s *taxService) SetTaxRate(input TaxRateInput) (*domain.TaxRate, error) {
+	rate, err := newTaxRate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.taxRepo.Upsert(rate); err != nil {
+		log.Printf("Erro ao gravar alíquota %s/%s/%s: %v", rate.TaxClass, rate.State, rate.Tax, err)
+		return nil, errors.New("falha ao salvar alíquota")
+	}
+
+	log.Printf("🧾 Alíquota atualizada: Classe=%s, UF=%s, Tributo=%s, Alíquota=%.4f%%, PorDentro=%t",
+		rate.TaxClass, rate.State, rate.Tax, rate.Rate, rate.Included)
+	return rate, nil
+}
+
+// newTaxRate normaliza e valida os dados da alíquota
+func newTaxRate(input TaxRateInput) (*domain.TaxRate, error) {
+	invalid := func(msg string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidTaxRate, msg)
+	}
+
+	rate := &domain.TaxRate{
+		TaxClass: strings.ToLower(strings.TrimSpace(input.TaxClass)),
+		State:    tax.NormalizeState(input.State),
+		Tax:      strings.ToUpper(strings.TrimSpace(input.Tax)),
+		Rate:     input.Rate,
+		Included: input.Included,
+	}
+	if rate.State == "" {
+		rate.State = tax.AnyState
+	}
+
+	if !taxClassPattern.MatchString(rate.TaxClass) {
+		return nil, invalid("classe fiscal deve ter até 30 letras minúsculas, dígitos, - ou _")
+	}
+	if rate.State != tax.AnyState && !tax.ValidState(rate.State) {
+		return nil, invalid("UF deve ser uma sigla válida ou *")
+	}
+	if !tax.ValidTax(rate.Tax) {
+		return nil, invalid("tributo deve ser ICMS ou ISS")
+	}
+	if _, err := money.RateFromPercent(rate.Rate); err != nil || rate.Rate < 0 || rate.Rate > 100 {
+		return nil, invalid("alíquota deve estar entre 0 e 100")
+	}
+	return rate, nil
+}
+
+// ListTaxRates retorna a tabela de alíquotas, opcionalmente de uma única classe fiscal
+func (s *taxService) ListTaxRates(taxClass string) ([]domain.TaxRate, error) {
+	rates, err := s.taxRepo.List(strings.ToLower(strings.TrimSpace(taxClass)))
+	if err != nil {
+		log.Printf("Erro ao listar alíquotas: %v", err)
+		return nil, errTaxesUnavailable
+	}
+	return rates, nil
+}
+
+// DeleteTaxRate remove uma alíquota da tabela
+func (s *taxService) DeleteTaxRate(id uint) error {
+	if id == 0 {
+		return fmt.Errorf("%w: ID é obrigatório", ErrInvalidTaxRate)
+	}
+
+	if err := s.taxRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTaxRateNotFound
+		}
+		log.Printf("Erro ao remover alíquota %d: %v", id, err)
+		return errors.New("falha ao remover alíquota")
+	}
+
+	log.Printf("🗑️ Alíquota %d removida", id)
+	return nil
+}
+
+// CalculateTaxes calcula os tributos das linhas do pedido para a UF de destino,
+// consultando a tabela vigente a cada pedido
+func (s *taxService) CalculateTaxes(state string, lines []tax.Line) (*tax.Result, error) {
+	state = tax.NormalizeState(state)
+	if state == "" {
+		state = s.defaultState
+	}
+	if !tax.ValidState(state) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDestination, state)
+	}
+
+	classes := make([]string, 0, len(lines))
+	seen := make(map[string]bool)
+	for i := range lines {
+		if lines[i].TaxClass == "" {
+			lines[i].TaxClass = tax.DefaultClass
+		}
+		if !seen[lines[i].TaxClass] {
+			seen[lines[i].TaxClass] = true
+			classes = append(classes, lines[i].TaxClass)
+		}
+	}
+
+	stored, err := s.taxRepo.ListByClasses(classes)
+	if err != nil {
+		log.Printf("Erro ao carregar alíquotas: %v", err)
+		return nil, errTaxesUnavailable
+	}
+
+	rates := make([]tax.Rate, 0, len(stored))
+	for _, rate := range stored {
+		percent, err := money.RateFromPercent(rate.Rate)
+		if err != nil {
+			log.Printf("Alíquota %d inválida na tabela: %v", rate.ID, err)
+			return nil, errTaxesUnavailable
+		}
+		rates = append(rates, tax.Rate{
+			TaxClass: rate.TaxClass,
+			State:    rate.State,
+			Tax:      rate.Tax,
+			Rate:     percent,
+			Included: rate.Included,
+		})
+	}
+
+	result, err := tax.NewTable(rates).Calculate(state, lines)
+	if err != nil {
+		switch {
+		case errors.Is(err, tax.ErrRateNotFound):
+			return nil, fmt.Errorf("%w: %v", ErrTaxNotConfigured, err)
+		case errors.Is(err, money.ErrInvalidAmount):
+			log.Printf("Erro ao calcular tributos: %v", err)
+			return nil, fmt.Errorf("falha ao calcular tributos: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDestination, err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/tax"
+)
+
+// MockTaxRateRepository is a mock implementation of repository.TaxRateRepository
+type MockTaxRateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaxRateRepository) List(taxClass string) ([]domain.TaxRate, error) {
+	args := m.Called(taxClass)
+	return args.Get(0).([]domain.TaxRate), args.Error(1)
+}
+
+func (m *MockTaxRateRepository) ListByClasses(taxClasses []string) ([]domain.TaxRate, error) {
+	args := m.Called(taxClasses)
+	return args.Get(0).([]domain.TaxRate), args.Error(1)
+}
+
+func (m *MockTaxRateRepository) Upsert(rate *domain.TaxRate) error {
+	return m.Called(rate).Error(0)
+}
+
+func (m *MockTaxRateRepository) Delete(id uint) error {
+	return m.Called(id).Error(0)
+}
+
+func TestSetTaxRate_NormalizesInput(t *testing.T) {
+	repo := new(MockTaxRateRepository)
+	repo.On("Upsert", mock.AnythingOfType("*domain.TaxRate")).Return(nil)
+
+	rate, err := NewTaxService(repo, "SP").SetTaxRate(TaxRateInput{TaxClass: " Service ", Tax: "iss", Rate: 2.5})
+
+	require.NoError(t, err)
+	assert.Equal(t, "service", rate.TaxClass)
+	assert.Equal(t, tax.AnyState, rate.State)
+	assert.Equal(t, tax.ISS, rate.Tax)
+}
+
+func TestSetTaxRate_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input TaxRateInput
+	}{
+		{"classe inválida", TaxRateInput{TaxClass: "com espaço", State: "SP", Tax: tax.ICMS, Rate: 18}},
+		{"UF inexistente", TaxRateInput{TaxClass: "standard", State: "XX", Tax: tax.ICMS, Rate: 18}},
+		{"tributo desconhecido", TaxRateInput{TaxClass: "standard", State: "SP", Tax: "IPI", Rate: 10}},
+		{"alíquota negativa", TaxRateInput{TaxClass: "standard", State: "SP", Tax: tax.ICMS, Rate: -1}},
+		{"alíquota não numérica", TaxRateInput{TaxClass: "standard", State: "SP", Tax: tax.ICMS, Rate: math.NaN()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTaxService(new(MockTaxRateRepository), "SP").SetTaxRate(tt.input)
+			assert.ErrorIs(t, err, ErrInvalidTaxRate)
+		})
+	}
+}
+
+func TestDeleteTaxRate_NotFound(t *testing.T) {
+	repo := new(MockTaxRateRepository)
+	repo.On("Delete", uint(9)).Return(gorm.ErrRecordNotFound)
+
+	err := NewTaxService(repo, "SP").DeleteTaxRate(9)
+
+	assert.ErrorIs(t, err, ErrTaxRateNotFound)
+}
+
+func TestCalculateTaxes_UsesDefaultState(t *testing.T) {
+	repo := new(MockTaxRateRepository)
+	repo.On("ListByClasses", []string{tax.DefaultClass}).Return([]domain.TaxRate{
+		{TaxClass: tax.DefaultClass, State: "SP", Tax: tax.ICMS, Rate: 18, Included: true},
+		{TaxClass: tax.DefaultClass, State: "RJ", Tax: tax.ICMS, Rate: 22, Included: true},
+	}, nil)
+
+	result, err := NewTaxService(repo, "sp").CalculateTaxes("", []tax.Line{{Number: 1, Amount: 10000}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "SP", result.State)
+	assert.Equal(t, int64(1800), result.Total)
+	assert.Equal(t, int64(0), result.Added)
+}
+
+func TestCalculateTaxes_Errors(t *testing.T) {
+	repo := new(MockTaxRateRepository)
+	repo.On("ListByClasses", []string{"book"}).Return([]domain.TaxRate{}, nil)
+	repo.On("ListByClasses", []string{"food"}).Return([]domain.TaxRate{}, errors.New("db down"))
+	taxes := NewTaxService(repo, "SP")
+
+	_, err := taxes.CalculateTaxes("ZZ", []tax.Line{{Number: 1, Amount: 10}})
+	assert.ErrorIs(t, err, ErrInvalidDestination)
+
+	_, err = taxes.CalculateTaxes("MG", []tax.Line{{Number: 1, TaxClass: "book", Amount: 10}})
+	assert.ErrorIs(t, err, ErrTaxNotConfigured)
+
+	_, err = taxes.CalculateTaxes("MG", []tax.Line{{Number: 1, TaxClass: "food", Amount: 10}})
+	assert.ErrorIs(t, err, errTaxesUnavailable)
+}
+
+func TestCreateOrder_AddsTaxesNotIncludedInPrice(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	rates := new(MockTaxRateRepository)
+	inventory.On("Reserve", "SMART-XYZ-001", 2, "customer:7").Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 10000, Currency: "BRL"}, TaxClass: "service"}, nil)
+	rates.On("ListByClasses", []string{"service"}).Return([]domain.TaxRate{
+		{TaxClass: "service", State: tax.AnyState, Tax: tax.ISS, Rate: 5},
+	}, nil)
+	store.On("Create", mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	input := validOrderInput()
+	input.DestinationState = "ba"
+	order, err := NewOrderService(store, nil, inventory, nil, NewTaxService(rates, "SP"), ReservationTTLs{}).CreateOrder(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "BA", order.DestinationState)
+	assert.Equal(t, int64(1000), order.TaxTotal)
+	assert.Equal(t, int64(21000), order.Total)
+	require.Len(t, order.Taxes, 1)
+	assert.Equal(t, domain.OrderTax{Line: 1, SKU: "SMART-XYZ-001", TaxClass: "service", Tax: tax.ISS, State: tax.AnyState, Rate: 5, Base: 20000, Amount: 1000}, order.Taxes[0])
+}
+
+func TestCreateOrder_MissingTaxRateReleasesReservation(t *testing.T) {
+	store := new(MockOrderStore)
+	inventory := new(MockInventoryClient)
+	rates := new(MockTaxRateRepository)
+	inventory.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(&clients.Reservation{ID: "res-1", UnitPrice: &clients.Money{Amount: 10000, Currency: "BRL"}, TaxClass: "book"}, nil)
+	inventory.On("Release", "res-1").Return(&clients.Reservation{ID: "res-1"}, nil)
+	rates.On("ListByClasses", []string{"book"}).Return([]domain.TaxRate{}, nil)
+
+	_, err := NewOrderService(store, nil, inventory, nil, NewTaxService(rates, "SP"), ReservationTTLs{}).CreateOrder(context.Background(), validOrderInput())
+
+	assert.ErrorIs(t, err, ErrTaxNotConfigured)
+	store.AssertNotCalled(t, "Create", mock.Anything)
+	inventory.AssertCalled(t, "Release", "res-1")
+}
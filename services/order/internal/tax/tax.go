@@ -0,0 +1,178 @@
+// Package tax calcula os tributos de um pedido a partir de uma tabela de alíquotas
+// por classe fiscal do produto e UF de destino.
+//
+// A tabela vem do banco de dados, para que o financeiro possa alterá-la sem deploy.
+// Cada tributo é calculado e arredondado por linha (meio centavo para cima), sobre o
+// valor da linha já com os descontos incondicionais. Os valores são em centavos.
+package tax
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Tributos suportados
+const (
+	ICMS = "ICMS" // mercadorias, por UF de destino
+	ISS  = "ISS"  // serviços
+)
+
+// AnyState é a UF curinga: vale para todos os destinos sem alíquota específica
+const AnyState = "*"
+
+// DefaultClass é a classe fiscal assumida quando o catálogo não informa nenhuma
+const DefaultClass = "standard"
+
+var (
+	ErrRateNotFound = errors.New("alíquota não cadastrada")
+	ErrInvalidState = errors.New("UF inválida")
+)
+
+// states são as 27 unidades federativas
+var states = map[string]bool{
+	"AC": true, "AL": true, "AM": true, "AP": true, "BA": true, "CE": true, "DF": true,
+	"ES": true, "GO": true, "MA": true, "MG": true, "MS": true, "MT": true, "PA": true,
+	"PB": true, "PE": true, "PI": true, "PR": true, "RJ": true, "RN": true, "RO": true,
+	"RR": true, "RS": true, "SC": true, "SE": true, "SP": true, "TO": true,
+}
+
+// NormalizeState padroniza a UF em maiúsculas e sem espaços
+func NormalizeState(state string) string {
+	return strings.ToUpper(strings.TrimSpace(state))
+}
+
+// ValidState indica se a UF existe
+func ValidState(state string) bool {
+	return states[state]
+}
+
+// ValidTax indica se o tributo é suportado
+func ValidTax(tax string) bool {
+	return tax == ICMS || tax == ISS
+}
+
+// Rate é uma alíquota da tabela. Rate é o percentual; Included indica tributo
+// "por dentro", já contido no preço, que é destacado mas não somado ao total.
+type Rate struct {
+	TaxClass string
+	State    string
+	Tax      string
+	Rate     money.Rate
+	Included bool
+}
+
+// Line é uma linha do pedido. Amount é o valor da linha com os descontos, em centavos.
+type Line struct {
+	Number   int
+	SKU      string
+	TaxClass string
+	Amount   int64
+}
+
+// Item é um tributo calculado para uma linha, com base e valor em centavos
+type Item struct {
+	Line     int
+	SKU      string
+	TaxClass string
+	Tax      string
+	State    string
+	Rate     money.Rate
+	Base     int64
+	Amount   int64
+	Included bool
+}
+
+// Result é o resultado do cálculo. Total soma todos os tributos; Added soma apenas
+// os que não estão contidos no preço e devem ser acrescentados ao total do pedido.
+type Result struct {
+	State string
+	Items []Item
+	Total int64
+	Added int64
+}
+
+// Table é a tabela de alíquotas indexada por classe fiscal
+type Table struct {
+	rates map[string][]Rate
+}
+
+// NewTable monta a tabela a partir das alíquotas cadastradas
+func NewTable(rates []Rate) *Table {
+	table := &Table{rates: make(map[string][]Rate)}
+	for _, rate := range rates {
+		table.rates[rate.TaxClass] = append(table.rates[rate.TaxClass], rate)
+	}
+	return table
+}
+
+// Lookup retorna, para cada tributo da classe, a alíquota da UF de destino ou,
+// na falta dela, a alíquota curinga. O resultado é ordenado pelo nome do tributo.
+func (t *Table) Lookup(taxClass, state string) []Rate {
+	selected := make(map[string]Rate)
+	for _, rate := range t.rates[taxClass] {
+		switch rate.State {
+		case state:
+			selected[rate.Tax] = rate
+		case AnyState:
+			if current, ok := selected[rate.Tax]; !ok || current.State == AnyState {
+				selected[rate.Tax] = rate
+			}
+		}
+	}
+
+	rates := make([]Rate, 0, len(selected))
+	for _, rate := range selected {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Tax < rates[j].Tax })
+	return rates
+}
+
+// Calculate aplica as alíquotas da UF de destino a cada linha. Toda classe fiscal
+// precisa de ao menos uma alíquota (ainda que zero, para itens isentos).
+func (t *Table) Calculate(state string, lines []Line) (Result, error) {
+	state = NormalizeState(state)
+	if !ValidState(state) {
+		return Result{}, fmt.Errorf("%w: %q", ErrInvalidState, state)
+	}
+
+	result := Result{State: state}
+	for _, line := range lines {
+		taxClass := line.TaxClass
+		if taxClass == "" {
+			taxClass = DefaultClass
+		}
+
+		rates := t.Lookup(taxClass, state)
+		if len(rates) == 0 {
+			return Result{}, fmt.Errorf("%w para a classe fiscal %s em %s", ErrRateNotFound, taxClass, state)
+		}
+
+		for _, rate := range rates {
+			amount, err := money.PercentOf(line.Amount, rate.Rate, money.HalfUp)
+			if err != nil {
+				return Result{}, fmt.Errorf("%s sobre a linha %d: %w", rate.Tax, line.Number, err)
+			}
+			result.Total += amount
+			if !rate.Included {
+				result.Added += amount
+			}
+			result.Items = append(result.Items, Item{
+				Line:     line.Number,
+				SKU:      line.SKU,
+				TaxClass: taxClass,
+				Tax:      rate.Tax,
+				State:    rate.State,
+				Rate:     rate.Rate,
+				Base:     line.Amount,
+				Amount:   amount,
+				Included: rate.Included,
+			})
+		}
+	}
+	return result, nil
+}
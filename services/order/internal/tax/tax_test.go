@@ -0,0 +1,80 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+func testTable() *Table {
+	return NewTable([]Rate{
+		{TaxClass: "standard", State: "SP", Tax: ICMS, Rate: money.PercentRate(18)},
+		{TaxClass: "standard", State: "RJ", Tax: ICMS, Rate: money.PercentRate(22)},
+		{TaxClass: "standard", State: AnyState, Tax: ICMS, Rate: money.PercentRate(17)},
+		{TaxClass: "service", State: AnyState, Tax: ISS, Rate: money.PercentRate(5)},
+		{TaxClass: "food", State: AnyState, Tax: ICMS, Rate: money.PercentRate(7), Included: true},
+		{TaxClass: "exempt", State: AnyState, Tax: ICMS, Rate: money.PercentRate(0)},
+	})
+}
+
+func TestLookup_PrefersDestinationState(t *testing.T) {
+	table := testTable()
+
+	assert.Equal(t, money.PercentRate(22), table.Lookup("standard", "RJ")[0].Rate)
+	assert.Equal(t, money.PercentRate(17), table.Lookup("standard", "RS")[0].Rate)
+	assert.Empty(t, table.Lookup("unknown", "SP"))
+}
+
+func TestCalculate_RoundsPerLine(t *testing.T) {
+	result, err := testTable().Calculate("sp", []Line{
+		{Number: 1, SKU: "A", TaxClass: "standard", Amount: 1003}, // 1,8054
+		{Number: 2, SKU: "B", TaxClass: "standard", Amount: 1003},
+		{Number: 3, SKU: "C", TaxClass: "service", Amount: 10}, // 0,005
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "SP", result.State)
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, int64(181), result.Items[0].Amount)
+	assert.Equal(t, int64(181), result.Items[1].Amount)
+	assert.Equal(t, int64(1), result.Items[2].Amount)
+	assert.Equal(t, ISS, result.Items[2].Tax)
+	assert.Equal(t, int64(363), result.Total)
+	assert.Equal(t, int64(363), result.Added)
+}
+
+func TestCalculate_IncludedTaxIsNotAdded(t *testing.T) {
+	result, err := testTable().Calculate("MG", []Line{{Number: 1, TaxClass: "food", Amount: 10000}})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(700), result.Total)
+	assert.Equal(t, int64(0), result.Added)
+	assert.True(t, result.Items[0].Included)
+}
+
+func TestCalculate_EmptyClassUsesDefault(t *testing.T) {
+	result, err := testTable().Calculate("RJ", []Line{{Number: 1, Amount: 5000}})
+
+	require.NoError(t, err)
+	assert.Equal(t, DefaultClass, result.Items[0].TaxClass)
+	assert.Equal(t, int64(1100), result.Total)
+}
+
+func TestCalculate_ExemptClassHasZeroTax(t *testing.T) {
+	result, err := testTable().Calculate("SP", []Line{{Number: 1, TaxClass: "exempt", Amount: 8000}})
+
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, int64(0), result.Total)
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	_, err := testTable().Calculate("XX", []Line{{Number: 1, Amount: 1000}})
+	assert.ErrorIs(t, err, ErrInvalidState)
+
+	_, err = testTable().Calculate("SP", []Line{{Number: 1, TaxClass: "unknown", Amount: 1000}})
+	assert.ErrorIs(t, err, ErrRateNotFound)
+}
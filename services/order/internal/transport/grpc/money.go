@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/order/proto"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// fromProtoMoney converte um valor recebido via gRPC; ausente, vale zero na moeda padrão
+func fromProtoMoney(value *proto.Money) money.Money {
+	if value == nil {
+		return money.Zero(money.DefaultCurrency)
+	}
+	return money.New(value.GetAmount(), value.GetCurrency())
+}
+
+// toProtoMoney converte um valor para a resposta gRPC
+func toProtoMoney(value money.Money) *proto.Money {
+	return &proto.Money{Amount: value.Amount, Currency: value.Currency}
+}
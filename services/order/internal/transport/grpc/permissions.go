@@ -0,0 +1,22 @@
+package grpc
+
+import "github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+
+// MethodPermissions declara a permissão exigida por cada RPC do OrderService. A
+// anonimização chamada por usuários exige login com segundo fator.
+var MethodPermissions = auth.Policy{
+	"/order.OrderService/CreateOrder": {Permission: auth.PermOrdersWrite},
+	"/order.OrderService/ListOrders":  {Permission: auth.PermOrdersRead},
+	"/order.OrderService/CancelOrder": {Permission: auth.PermOrdersWrite},
+
+	"/order.OrderService/ExportCustomerData": {Permission: auth.PermPrivacy},
+	"/order.OrderService/AnonymizeCustomer":  {Permission: auth.PermPrivacy, RequireMFA: true},
+
+	"/order.OrderService/CreatePromotion":    {Permission: auth.PermPromotionsManage},
+	"/order.OrderService/ListPromotions":     {Permission: auth.PermPromotionsManage},
+	"/order.OrderService/SetPromotionActive": {Permission: auth.PermPromotionsManage},
+
+	"/order.OrderService/SetTaxRate":    {Permission: auth.PermTaxesManage},
+	"/order.OrderService/ListTaxRates":  {Permission: auth.PermTaxesManage},
+	"/order.OrderService/DeleteTaxRate": {Permission: auth.PermTaxesManage},
+}
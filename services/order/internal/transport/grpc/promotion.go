@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/proto"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// CreatePromotion cadastra uma promoção ou cupom
+func (s *OrderGRPCServer) CreatePromotion(ctx context.Context, req *proto.PromotionRequest) (*proto.PromotionResponse, error) {
+	log.Printf("🏷️ Recebida requisição CreatePromotion: Código=%s, Tipo=%s", req.GetCode(), req.GetType())
+
+	validFrom, err := parseTime("valid_from", req.GetValidFrom())
+	if err != nil {
+		return nil, err
+	}
+	validTo, err := parseTime("valid_to", req.GetValidTo())
+	if err != nil {
+		return nil, err
+	}
+
+	promo, err := s.promotionService.CreatePromotion(service.PromotionInput{
+		Code:               req.GetCode(),
+		Name:               req.GetName(),
+		Type:               req.GetType(),
+		Value:              req.GetValue(),
+		BuyQuantity:        int(req.GetBuyQuantity()),
+		GetQuantity:        int(req.GetGetQuantity()),
+		Amount:             fromProtoMoney(req.GetAmount()),
+		MinSubtotal:        fromProtoMoney(req.GetMinSubtotal()),
+		SKUs:               req.GetSkus(),
+		Automatic:          req.GetAutomatic(),
+		Stacking:           req.GetStacking(),
+		Priority:           int(req.GetPriority()),
+		ValidFrom:          validFrom,
+		ValidTo:            validTo,
+		MaxUses:            int(req.GetMaxUses()),
+		MaxUsesPerCustomer: int(req.GetMaxUsesPerCustomer()),
+	})
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return toPromotionResponse(promo), nil
+}
+
+// ListPromotions lista as promoções cadastradas
+func (s *OrderGRPCServer) ListPromotions(ctx context.Context, req *proto.ListPromotionsRequest) (*proto.ListPromotionsResponse, error) {
+	promotions, err := s.promotionService.ListPromotions(req.GetActiveOnly())
+	if err != nil {
+		return nil, orderError(err)
+	}
+
+	response := &proto.ListPromotionsResponse{}
+	for i := range promotions {
+		response.Promotions = append(response.Promotions, toPromotionResponse(&promotions[i]))
+	}
+	return response, nil
+}
+
+// SetPromotionActive ativa ou desativa uma promoção pelo código
+func (s *OrderGRPCServer) SetPromotionActive(ctx context.Context, req *proto.SetPromotionActiveRequest) (*proto.PromotionResponse, error) {
+	log.Printf("🏷️ Recebida requisição SetPromotionActive: Código=%s, Ativa=%t", req.GetCode(), req.GetActive())
+
+	promo, err := s.promotionService.SetPromotionActive(req.GetCode(), req.GetActive())
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return toPromotionResponse(promo), nil
+}
+
+func toPromotionResponse(promo *domain.Promotion) *proto.PromotionResponse {
+	return &proto.PromotionResponse{
+		Id:                 uint32(promo.ID),
+		Code:               promo.Code,
+		Name:               promo.Name,
+		Type:               promo.Type,
+		Value:              promo.Value,
+		BuyQuantity:        int32(promo.BuyQuantity),
+		GetQuantity:        int32(promo.GetQuantity),
+		Amount:             toProtoMoney(money.New(promo.Amount, promo.Currency)),
+		MinSubtotal:        toProtoMoney(money.New(promo.MinSubtotal, promo.Currency)),
+		Skus:               promo.SKUs,
+		Automatic:          promo.Automatic,
+		Stacking:           promo.Stacking,
+		Priority:           int32(promo.Priority),
+		ValidFrom:          formatTime(promo.ValidFrom),
+		ValidTo:            formatTime(promo.ValidTo),
+		MaxUses:            int32(promo.MaxUses),
+		MaxUsesPerCustomer: int32(promo.MaxUsesPerCustomer),
+		Active:             promo.Active,
+		CreatedAt:          promo.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseTime lê uma data opcional em RFC 3339
+func parseTime(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s deve estar no formato RFC 3339 (ex.: 2025-11-28T00:00:00-03:00)", field)
+	}
+	return &parsed, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
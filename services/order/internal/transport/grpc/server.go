@@ -2,9 +2,15 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log"
+	"sort"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/service"
 	"github.com/seu-usuario/go-microservices-architecture/services/order/proto"
 )
@@ -12,45 +18,51 @@ import (
 // OrderGRPCServer implementa o servidor gRPC para pedidos
 type OrderGRPCServer struct {
 	proto.UnimplementedOrderServiceServer
-	orderService service.OrderService
+	orderService     service.OrderService
+	promotionService service.PromotionService
+	taxService       service.TaxService
 }
 
 // NewOrderGRPCServer cria uma nova instância do servidor gRPC
-func NewOrderGRPCServer(orderService service.OrderService) *OrderGRPCServer {
+func NewOrderGRPCServer(orderService service.OrderService, promotionService service.PromotionService, taxService service.TaxService) *OrderGRPCServer {
 	return &OrderGRPCServer{
-		orderService: orderService,
+		orderService:     orderService,
+		promotionService: promotionService,
+		taxService:       taxService,
 	}
 }
 
 // CreateOrder cria um novo pedido via gRPC
 func (s *OrderGRPCServer) CreateOrder(ctx context.Context, req *proto.OrderRequest) (*proto.OrderResponse, error) {
-	log.Printf("📝 Recebida requisição CreateOrder: Customer=%s, ProductID=%d, Quantity=%d, Price=%.2f",
-		req.GetCustomer(), req.GetProductId(), req.GetQuantity(), req.GetPrice())
+	log.Printf("📝 Recebida requisição CreateOrder: Customer=%s, ProductID=%d, Quantity=%d, Preço=%s, Cupons=%v, UF=%s",
+		req.GetCustomer(), req.GetProductId(), req.GetQuantity(), fromProtoMoney(req.GetPrice()), req.GetCouponCodes(), req.GetDestinationState())
 
-	// Chamar o serviço de negócio
-	order, err := s.orderService.CreateOrder(
-		req.GetCustomer(),
-		uint(req.GetProductId()),
-		int(req.GetQuantity()),
-		req.GetPrice(),
-	)
+	risk, err := fromProtoRisk(req.GetRisk())
 	if err != nil {
-		log.Printf("❌ Erro ao criar pedido: %v", err)
 		return nil, err
 	}
 
-	// Converter para resposta gRPC
-	response := &proto.OrderResponse{
-		Id:        uint32(order.ID),
-		Customer:  order.Customer,
-		ProductId: uint32(order.ProductID),
-		Quantity:  int32(order.Quantity),
-		Price:     order.Price,
-		CreatedAt: order.CreatedAt.Format(time.RFC3339),
+	// Chamar o serviço de negócio
+	order, err := s.orderService.CreateOrder(ctx, service.CreateOrderInput{
+		Customer:         req.GetCustomer(),
+		ProductID:        uint(req.GetProductId()),
+		SKU:              req.GetSku(),
+		Quantity:         int(req.GetQuantity()),
+		Price:            fromProtoMoney(req.GetPrice()),
+		CouponCodes:      req.GetCouponCodes(),
+		DestinationState: req.GetDestinationState(),
+		PaymentCurrency:  req.GetPaymentCurrency(),
+		Installments:     int(req.GetInstallments()),
+		PaymentMethod:    req.GetPaymentMethod(),
+		Risk:             risk,
+	})
+	if err != nil {
+		log.Printf("❌ Erro ao criar pedido: %v", err)
+		return nil, orderError(err)
 	}
 
 	log.Printf("✅ Pedido criado com sucesso: ID=%d", order.ID)
-	return response, nil
+	return toOrderResponse(order), nil
 }
 
 // ListOrders lista todos os pedidos via gRPC
@@ -66,16 +78,8 @@ func (s *OrderGRPCServer) ListOrders(ctx context.Context, req *proto.ListOrdersR
 
 	// Converter para resposta gRPC
 	var orderResponses []*proto.OrderResponse
-	for _, order := range orders {
-		orderResponse := &proto.OrderResponse{
-			Id:        uint32(order.ID),
-			Customer:  order.Customer,
-			ProductId: uint32(order.ProductID),
-			Quantity:  int32(order.Quantity),
-			Price:     order.Price,
-			CreatedAt: order.CreatedAt.Format(time.RFC3339),
-		}
-		orderResponses = append(orderResponses, orderResponse)
+	for i := range orders {
+		orderResponses = append(orderResponses, toOrderResponse(&orders[i]))
 	}
 
 	response := &proto.ListOrdersResponse{
@@ -85,3 +89,148 @@ func (s *OrderGRPCServer) ListOrders(ctx context.Context, req *proto.ListOrdersR
 	log.Printf("✅ Listados %d pedidos", len(orders))
 	return response, nil
 }
+
+// CancelOrder cancela um pedido aguardando pagamento e devolve o estoque reservado
+func (s *OrderGRPCServer) CancelOrder(ctx context.Context, req *proto.CancelOrderRequest) (*proto.OrderResponse, error) {
+	log.Printf("🚫 Recebida requisição CancelOrder: ID=%d", req.GetId())
+
+	order, err := s.orderService.CancelOrder(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return toOrderResponse(order), nil
+}
+
+// ExportCustomerData retorna os pedidos de um titular para exportação LGPD
+func (s *OrderGRPCServer) ExportCustomerData(ctx context.Context, req *proto.CustomerDataRequest) (*proto.ListOrdersResponse, error) {
+	log.Printf("📦 Recebida requisição ExportCustomerData (%d identificadores)", len(req.GetCustomers()))
+
+	orders, err := s.orderService.ListOrdersByCustomers(req.GetCustomers())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &proto.ListOrdersResponse{}
+	for i := range orders {
+		response.Orders = append(response.Orders, toOrderResponse(&orders[i]))
+	}
+	return response, nil
+}
+
+// AnonymizeCustomer anonimiza o cliente dos pedidos de um titular
+func (s *OrderGRPCServer) AnonymizeCustomer(ctx context.Context, req *proto.AnonymizeCustomerRequest) (*proto.AnonymizeCustomerResponse, error) {
+	ids, err := s.orderService.AnonymizeCustomer(req.GetCustomers(), req.GetPseudonym())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &proto.AnonymizeCustomerResponse{Affected: uint32(len(ids))}
+	for _, id := range ids {
+		response.OrderIds = append(response.OrderIds, uint32(id))
+	}
+	return response, nil
+}
+
+// fromProtoRisk converte os sinais de risco do pagamento; a data de cadastro do cliente
+// vem em RFC 3339
+func fromProtoRisk(risk *proto.PaymentRisk) (domain.PaymentRisk, error) {
+	if risk == nil {
+		return domain.PaymentRisk{}, nil
+	}
+	result := domain.PaymentRisk{
+		CardFingerprint: risk.GetCardFingerprint(),
+		BillingState:    risk.GetBillingState(),
+		IPCountry:       risk.GetIpCountry(),
+	}
+	if since := risk.GetCustomerSince(); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return domain.PaymentRisk{}, status.Errorf(codes.InvalidArgument, "customer_since inválido: %s", since)
+		}
+		result.CustomerSince = &parsed
+	}
+	return result, nil
+}
+
+func toOrderResponse(order *domain.Order) *proto.OrderResponse {
+	response := &proto.OrderResponse{
+		Id:               uint32(order.ID),
+		Customer:         order.Customer,
+		ProductId:        uint32(order.ProductID),
+		Quantity:         int32(order.Quantity),
+		Price:            toProtoMoney(order.Money(order.Price)),
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
+		Sku:              order.SKU,
+		Status:           order.Status,
+		VariantId:        order.VariantID,
+		ProductName:      order.ProductName,
+		Subtotal:         toProtoMoney(order.Money(order.Subtotal)),
+		DiscountTotal:    toProtoMoney(order.Money(order.DiscountTotal)),
+		Total:            toProtoMoney(order.Money(order.NetTotal())),
+		DestinationState: order.DestinationState,
+		PaymentCurrency:  order.PaymentCurrency,
+		Installments:     int32(order.Installments),
+		PaymentMethod:    order.PaymentMethod,
+		TaxTotal:         toProtoMoney(order.Money(order.TaxTotal)),
+	}
+	if order.Subtotal == 0 {
+		// Pedido gravado antes dos descontos
+		response.Subtotal = response.Total
+	}
+	for _, discount := range order.Discounts {
+		response.Discounts = append(response.Discounts, &proto.OrderDiscount{
+			Line:   int32(discount.Line),
+			Sku:    discount.SKU,
+			Code:   discount.Code,
+			Name:   discount.Name,
+			Type:   discount.Type,
+			Amount: toProtoMoney(order.Money(discount.Amount)),
+		})
+	}
+	for _, item := range order.Taxes {
+		response.Taxes = append(response.Taxes, &proto.OrderTax{
+			Line:     int32(item.Line),
+			Sku:      item.SKU,
+			TaxClass: item.TaxClass,
+			Tax:      item.Tax,
+			State:    item.State,
+			Rate:     item.Rate,
+			Base:     toProtoMoney(order.Money(item.Base)),
+			Amount:   toProtoMoney(order.Money(item.Amount)),
+			Included: item.Included,
+		})
+	}
+
+	// Atributos em ordem alfabética, para uma resposta estável
+	names := make([]string, 0, len(order.Attributes))
+	for name := range order.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		response.Attributes = append(response.Attributes, &proto.OrderAttribute{Name: name, Value: order.Attributes[name]})
+	}
+	return response
+}
+
+// orderError traduz erros de negócio do pedido para códigos gRPC
+func orderError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrOrderNotFound), errors.Is(err, service.ErrPromotionNotFound),
+		errors.Is(err, service.ErrTaxRateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrStockUnavailable), errors.Is(err, service.ErrOrderNotCancellable),
+		errors.Is(err, service.ErrPriceUnavailable), errors.Is(err, service.ErrCouponRejected),
+		errors.Is(err, service.ErrNothingToCharge), errors.Is(err, service.ErrTaxNotConfigured):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidPromotion), errors.Is(err, service.ErrInvalidTaxRate),
+		errors.Is(err, service.ErrInvalidDestination), errors.Is(err, service.ErrInvalidCurrency),
+		errors.Is(err, service.ErrInvalidInstallments), errors.Is(err, service.ErrInvalidPayment),
+		errors.Is(err, service.ErrInvalidRisk):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPromotionCodeInUse):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/order/proto"
+)
+
+// SetTaxRate cria ou substitui uma alíquota da tabela fiscal
+func (s *OrderGRPCServer) SetTaxRate(ctx context.Context, req *proto.TaxRateRequest) (*proto.TaxRateResponse, error) {
+	log.Printf("🧾 Recebida requisição SetTaxRate: Classe=%s, UF=%s, Tributo=%s, Alíquota=%.4f",
+		req.GetTaxClass(), req.GetState(), req.GetTax(), req.GetRate())
+
+	rate, err := s.taxService.SetTaxRate(service.TaxRateInput{
+		TaxClass: req.GetTaxClass(),
+		State:    req.GetState(),
+		Tax:      req.GetTax(),
+		Rate:     req.GetRate(),
+		Included: req.GetIncluded(),
+	})
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return toTaxRateResponse(rate), nil
+}
+
+// ListTaxRates lista a tabela de alíquotas
+func (s *OrderGRPCServer) ListTaxRates(ctx context.Context, req *proto.ListTaxRatesRequest) (*proto.ListTaxRatesResponse, error) {
+	rates, err := s.taxService.ListTaxRates(req.GetTaxClass())
+	if err != nil {
+		return nil, orderError(err)
+	}
+
+	response := &proto.ListTaxRatesResponse{}
+	for i := range rates {
+		response.Rates = append(response.Rates, toTaxRateResponse(&rates[i]))
+	}
+	return response, nil
+}
+
+// DeleteTaxRate remove uma alíquota da tabela fiscal
+func (s *OrderGRPCServer) DeleteTaxRate(ctx context.Context, req *proto.DeleteTaxRateRequest) (*proto.DeleteTaxRateResponse, error) {
+	log.Printf("🧾 Recebida requisição DeleteTaxRate: ID=%d", req.GetId())
+
+	if err := s.taxService.DeleteTaxRate(uint(req.GetId())); err != nil {
+		return nil, orderError(err)
+	}
+	return &proto.DeleteTaxRateResponse{Success: true}, nil
+}
+
+func toTaxRateResponse(rate *domain.TaxRate) *proto.TaxRateResponse {
+	return &proto.TaxRateResponse{
+		Id:        uint32(rate.ID),
+		TaxClass:  rate.TaxClass,
+		State:     rate.State,
+		Tax:       rate.Tax,
+		Rate:      rate.Rate,
+		Included:  rate.Included,
+		UpdatedAt: rate.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/runtime/protoimpl"
 )
@@ -18,12 +20,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// OrderRequest representa uma solicitação para criar um pedido
+// Money é um valor monetário exato: amount em unidades menores da moeda (centavos,
+// para BRL) e currency no padrão ISO 4217. Vazia, a moeda é BRL.
+type Money struct {
+	Amount   int64  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// OrderRequest cria um pedido para o SKU informado. price é opcional e apenas
+// informativo: o pedido é gravado com o preço vigente no catálogo. coupon_codes
+// são aplicados junto com as promoções automáticas vigentes. destination_state é a
+// UF de entrega (ex.: "SP"), que define os tributos; vazia, vale a UF padrão.
 type OrderRequest struct {
-	Customer  string  `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
-	ProductId uint32  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity  int32   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price     float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Customer         string   `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	ProductId        uint32   `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity         int32    `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Sku              string   `protobuf:"bytes,5,opt,name=sku,proto3" json:"sku,omitempty"`
+	CouponCodes      []string `protobuf:"bytes,6,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	DestinationState string   `protobuf:"bytes,7,opt,name=destination_state,json=destinationState,proto3" json:"destination_state,omitempty"`
+	Price            *Money   `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	// payment_currency é a moeda em que o cliente paga; vazia, a moeda do pedido
+	PaymentCurrency string `protobuf:"bytes,9,opt,name=payment_currency,json=paymentCurrency,proto3" json:"payment_currency,omitempty"`
+	// installments é o número de parcelas; 0 ou 1, à vista
+	Installments int32 `protobuf:"varint,10,opt,name=installments,proto3" json:"installments,omitempty"`
+	// payment_method é a forma de pagamento: card, pix ou boleto; vazia, card
+	PaymentMethod string `protobuf:"bytes,11,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	// risk são os sinais de risco repassados à análise antifraude do payment-service
+	Risk *PaymentRisk `protobuf:"bytes,12,opt,name=risk,proto3" json:"risk,omitempty"`
 }
 
 func (x *OrderRequest) Reset() {
@@ -61,21 +112,173 @@ func (x *OrderRequest) GetQuantity() int32 {
 	return 0
 }
 
-func (x *OrderRequest) GetPrice() float64 {
+func (x *OrderRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *OrderRequest) GetDestinationState() string {
+	if x != nil {
+		return x.DestinationState
+	}
+	return ""
+}
+
+func (x *OrderRequest) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
+	return nil
+}
+
+func (x *OrderRequest) GetPaymentCurrency() string {
+	if x != nil {
+		return x.PaymentCurrency
+	}
+	return ""
+}
+
+func (x *OrderRequest) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
+	}
 	return 0
 }
 
+func (x *OrderRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *OrderRequest) GetRisk() *PaymentRisk {
+	if x != nil {
+		return x.Risk
+	}
+	return nil
+}
+
+// PaymentRisk são os sinais de risco do pedido usados pela análise antifraude
+type PaymentRisk struct {
+	// card_fingerprint identifica o cartão sem expor o número (gerado pelo tokenizador)
+	CardFingerprint string `protobuf:"bytes,1,opt,name=card_fingerprint,json=cardFingerprint,proto3" json:"card_fingerprint,omitempty"`
+	// billing_state é a UF do endereço de cobrança
+	BillingState string `protobuf:"bytes,2,opt,name=billing_state,json=billingState,proto3" json:"billing_state,omitempty"`
+	// ip_country é o país do IP do cliente (ISO 3166-1 alfa-2)
+	IpCountry string `protobuf:"bytes,3,opt,name=ip_country,json=ipCountry,proto3" json:"ip_country,omitempty"`
+	// customer_since é a data de cadastro do cliente (RFC 3339)
+	CustomerSince string `protobuf:"bytes,4,opt,name=customer_since,json=customerSince,proto3" json:"customer_since,omitempty"`
+}
+
+func (x *PaymentRisk) Reset() {
+	*x = PaymentRisk{}
+}
+
+func (x *PaymentRisk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRisk) ProtoMessage() {}
+
+func (x *PaymentRisk) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PaymentRisk) GetCardFingerprint() string {
+	if x != nil {
+		return x.CardFingerprint
+	}
+	return ""
+}
+
+func (x *PaymentRisk) GetBillingState() string {
+	if x != nil {
+		return x.BillingState
+	}
+	return ""
+}
+
+func (x *PaymentRisk) GetIpCountry() string {
+	if x != nil {
+		return x.IpCountry
+	}
+	return ""
+}
+
+func (x *PaymentRisk) GetCustomerSince() string {
+	if x != nil {
+		return x.CustomerSince
+	}
+	return ""
+}
+
+// OrderAttribute é um atributo da variação comprada (ex.: name "cor", value "azul")
+type OrderAttribute struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *OrderAttribute) Reset() {
+	*x = OrderAttribute{}
+}
+
+func (x *OrderAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAttribute) ProtoMessage() {}
+
+func (x *OrderAttribute) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *OrderAttribute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // OrderResponse representa uma resposta de pedido
 type OrderResponse struct {
-	Id        uint32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Customer  string  `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
-	ProductId uint32  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity  int32   `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price     float64 `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	CreatedAt string  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id               uint32            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Customer         string            `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	ProductId        uint32            `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity         int32             `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt        string            `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Sku              string            `protobuf:"bytes,7,opt,name=sku,proto3" json:"sku,omitempty"`
+	Status           string            `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	VariantId        string            `protobuf:"bytes,9,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	ProductName      string            `protobuf:"bytes,10,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Attributes       []*OrderAttribute `protobuf:"bytes,11,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Discounts        []*OrderDiscount  `protobuf:"bytes,15,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	DestinationState string            `protobuf:"bytes,16,opt,name=destination_state,json=destinationState,proto3" json:"destination_state,omitempty"`
+	Taxes            []*OrderTax       `protobuf:"bytes,18,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	Price            *Money            `protobuf:"bytes,19,opt,name=price,proto3" json:"price,omitempty"`
+	Subtotal         *Money            `protobuf:"bytes,20,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	DiscountTotal    *Money            `protobuf:"bytes,21,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"`
+	TaxTotal         *Money            `protobuf:"bytes,22,opt,name=tax_total,json=taxTotal,proto3" json:"tax_total,omitempty"`
+	Total            *Money            `protobuf:"bytes,23,opt,name=total,proto3" json:"total,omitempty"`
+	PaymentCurrency  string            `protobuf:"bytes,24,opt,name=payment_currency,json=paymentCurrency,proto3" json:"payment_currency,omitempty"`
+	Installments     int32             `protobuf:"varint,25,opt,name=installments,proto3" json:"installments,omitempty"`
+	PaymentMethod    string            `protobuf:"bytes,26,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
 }
 
 func (x *OrderResponse) Reset() {
@@ -120,156 +323,1477 @@ func (x *OrderResponse) GetQuantity() int32 {
 	return 0
 }
 
-func (x *OrderResponse) GetPrice() float64 {
+func (x *OrderResponse) GetCreatedAt() string {
 	if x != nil {
-		return x.Price
+		return x.CreatedAt
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderResponse) GetCreatedAt() string {
+func (x *OrderResponse) GetSku() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Sku
 	}
 	return ""
 }
 
-// ListOrdersRequest representa uma solicitação para listar pedidos
-type ListOrdersRequest struct {
+func (x *OrderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
+func (x *OrderResponse) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
 }
 
-func (x *ListOrdersRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *OrderResponse) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
-
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderResponse) GetAttributes() []*OrderAttribute {
+	if x != nil {
+		return x.Attributes
+	}
 	return nil
 }
 
-// ListOrdersResponse representa uma resposta com lista de pedidos
-type ListOrdersResponse struct {
-	Orders []*OrderResponse `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+func (x *OrderResponse) GetDiscounts() []*OrderDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
+func (x *OrderResponse) GetDestinationState() string {
+	if x != nil {
+		return x.DestinationState
+	}
+	return ""
 }
 
-func (x *ListOrdersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *OrderResponse) GetTaxes() []*OrderTax {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
-
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+func (x *OrderResponse) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
 	return nil
 }
 
-func (x *ListOrdersResponse) GetOrders() []*OrderResponse {
+func (x *OrderResponse) GetSubtotal() *Money {
 	if x != nil {
-		return x.Orders
+		return x.Subtotal
 	}
 	return nil
 }
 
-// OrderServiceClient é o cliente do serviço de pedidos
-type OrderServiceClient interface {
-	CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
-	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+func (x *OrderResponse) GetDiscountTotal() *Money {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return nil
 }
 
-type orderServiceClient struct {
-	cc grpc.ClientConnInterface
+func (x *OrderResponse) GetTaxTotal() *Money {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return nil
 }
 
-func NewOrderServiceClient(cc grpc.ClientConnInterface) OrderServiceClient {
-	return &orderServiceClient{cc}
+func (x *OrderResponse) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
 }
 
-func (c *orderServiceClient) CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
-	out := new(OrderResponse)
-	err := c.cc.Invoke(ctx, "/order.OrderService/CreateOrder", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *OrderResponse) GetPaymentCurrency() string {
+	if x != nil {
+		return x.PaymentCurrency
 	}
-	return out, nil
+	return ""
 }
 
-func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
-	out := new(ListOrdersResponse)
-	err := c.cc.Invoke(ctx, "/order.OrderService/ListOrders", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *OrderResponse) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
 	}
-	return out, nil
+	return 0
 }
 
-// OrderServiceServer é o servidor do serviço de pedidos
-type OrderServiceServer interface {
-	CreateOrder(context.Context, *OrderRequest) (*OrderResponse, error)
-	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+func (x *OrderResponse) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
 }
 
-// UnimplementedOrderServiceServer deve ser embedded para ter implementações forward compatible
-type UnimplementedOrderServiceServer struct {
+// OrderDiscount é o desconto de uma promoção em uma linha do pedido
+type OrderDiscount struct {
+	Line   int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Sku    string `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Code   string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Name   string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Type   string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Amount *Money `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
 }
 
-func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *OrderRequest) (*OrderResponse, error) {
-	return nil, nil
+func (x *OrderDiscount) Reset() {
+	*x = OrderDiscount{}
 }
 
-func (UnimplementedOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
-	return nil, nil
+func (x *OrderDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// RegisterOrderServiceServer registra o serviço no servidor gRPC
-func RegisterOrderServiceServer(s grpc.ServiceRegistrar, srv OrderServiceServer) {
-	s.RegisterService(&OrderService_ServiceDesc, srv)
+func (*OrderDiscount) ProtoMessage() {}
+
+func (x *OrderDiscount) ProtoReflect() protoreflect.Message {
+	return nil
 }
 
-func _OrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(OrderServiceServer).CreateOrder(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/order.OrderService/CreateOrder",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).CreateOrder(ctx, req.(*OrderRequest))
+func (x *OrderDiscount) GetLine() int32 {
+	if x != nil {
+		return x.Line
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _OrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListOrdersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *OrderDiscount) GetSku() string {
+	if x != nil {
+		return x.Sku
 	}
-	if interceptor == nil {
-		return srv.(OrderServiceServer).ListOrders(ctx, in)
+	return ""
+}
+
+func (x *OrderDiscount) GetCode() string {
+	if x != nil {
+		return x.Code
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/order.OrderService/ListOrders",
+	return ""
+}
+
+func (x *OrderDiscount) GetName() string {
+	if x != nil {
+		return x.Name
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	return ""
+}
+
+func (x *OrderDiscount) GetType() string {
+	if x != nil {
+		return x.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-// OrderService_ServiceDesc é o descritor do serviço gRPC
+func (x *OrderDiscount) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// OrderTax é um tributo de uma linha do pedido. included indica tributo contido no
+// preço ("por dentro"), que é destacado mas não somado ao total.
+type OrderTax struct {
+	Line     int32   `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Sku      string  `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	TaxClass string  `protobuf:"bytes,3,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+	Tax      string  `protobuf:"bytes,4,opt,name=tax,proto3" json:"tax,omitempty"`
+	State    string  `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Rate     float64 `protobuf:"fixed64,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	Included bool    `protobuf:"varint,9,opt,name=included,proto3" json:"included,omitempty"`
+	Base     *Money  `protobuf:"bytes,10,opt,name=base,proto3" json:"base,omitempty"`
+	Amount   *Money  `protobuf:"bytes,11,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *OrderTax) Reset() {
+	*x = OrderTax{}
+}
+
+func (x *OrderTax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTax) ProtoMessage() {}
+
+func (x *OrderTax) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *OrderTax) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *OrderTax) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderTax) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *OrderTax) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *OrderTax) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *OrderTax) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *OrderTax) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+func (x *OrderTax) GetBase() *Money {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *OrderTax) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// CancelOrderRequest identifica o pedido a cancelar
+type CancelOrderRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *CancelOrderRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ListOrdersRequest representa uma solicitação para listar pedidos
+type ListOrdersRequest struct {
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+// ListOrdersResponse representa uma resposta com lista de pedidos
+type ListOrdersResponse struct {
+	Orders []*OrderResponse `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListOrdersResponse) GetOrders() []*OrderResponse {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// CustomerDataRequest identifica um titular pelos valores gravados em Customer (ID, e-mail, nome)
+type CustomerDataRequest struct {
+	Customers []string `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+}
+
+func (x *CustomerDataRequest) Reset() {
+	*x = CustomerDataRequest{}
+}
+
+func (x *CustomerDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerDataRequest) ProtoMessage() {}
+
+func (x *CustomerDataRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *CustomerDataRequest) GetCustomers() []string {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+// AnonymizeCustomerRequest substitui o cliente dos pedidos por um pseudônimo
+type AnonymizeCustomerRequest struct {
+	Customers []string `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	Pseudonym string   `protobuf:"bytes,2,opt,name=pseudonym,proto3" json:"pseudonym,omitempty"`
+}
+
+func (x *AnonymizeCustomerRequest) Reset() {
+	*x = AnonymizeCustomerRequest{}
+}
+
+func (x *AnonymizeCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeCustomerRequest) ProtoMessage() {}
+
+func (x *AnonymizeCustomerRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AnonymizeCustomerRequest) GetCustomers() []string {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+func (x *AnonymizeCustomerRequest) GetPseudonym() string {
+	if x != nil {
+		return x.Pseudonym
+	}
+	return ""
+}
+
+// AnonymizeCustomerResponse informa os pedidos anonimizados
+type AnonymizeCustomerResponse struct {
+	Affected uint32   `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+	OrderIds []uint32 `protobuf:"varint,2,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+}
+
+func (x *AnonymizeCustomerResponse) Reset() {
+	*x = AnonymizeCustomerResponse{}
+}
+
+func (x *AnonymizeCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeCustomerResponse) ProtoMessage() {}
+
+func (x *AnonymizeCustomerResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AnonymizeCustomerResponse) GetAffected() uint32 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+func (x *AnonymizeCustomerResponse) GetOrderIds() []uint32 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+// PromotionRequest cadastra uma promoção. type: percent (percentual em value), fixed
+// (desconto em amount) ou buy_x_get_y; stacking: stackable (padrão) ou exclusive;
+// datas em RFC3339, opcionais.
+type PromotionRequest struct {
+	Code               string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name               string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type               string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value              float64  `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	BuyQuantity        int32    `protobuf:"varint,5,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity        int32    `protobuf:"varint,6,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	Skus               []string `protobuf:"bytes,8,rep,name=skus,proto3" json:"skus,omitempty"`
+	Automatic          bool     `protobuf:"varint,9,opt,name=automatic,proto3" json:"automatic,omitempty"`
+	Stacking           string   `protobuf:"bytes,10,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Priority           int32    `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`
+	ValidFrom          string   `protobuf:"bytes,12,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo            string   `protobuf:"bytes,13,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	MaxUses            int32    `protobuf:"varint,14,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	MaxUsesPerCustomer int32    `protobuf:"varint,15,opt,name=max_uses_per_customer,json=maxUsesPerCustomer,proto3" json:"max_uses_per_customer,omitempty"`
+	MinSubtotal        *Money   `protobuf:"bytes,16,opt,name=min_subtotal,json=minSubtotal,proto3" json:"min_subtotal,omitempty"`
+	Amount             *Money   `protobuf:"bytes,17,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *PromotionRequest) Reset() {
+	*x = PromotionRequest{}
+}
+
+func (x *PromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionRequest) ProtoMessage() {}
+
+func (x *PromotionRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PromotionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PromotionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromotionRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PromotionRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PromotionRequest) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *PromotionRequest) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *PromotionRequest) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *PromotionRequest) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+func (x *PromotionRequest) GetStacking() string {
+	if x != nil {
+		return x.Stacking
+	}
+	return ""
+}
+
+func (x *PromotionRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PromotionRequest) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *PromotionRequest) GetValidTo() string {
+	if x != nil {
+		return x.ValidTo
+	}
+	return ""
+}
+
+func (x *PromotionRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *PromotionRequest) GetMaxUsesPerCustomer() int32 {
+	if x != nil {
+		return x.MaxUsesPerCustomer
+	}
+	return 0
+}
+
+func (x *PromotionRequest) GetMinSubtotal() *Money {
+	if x != nil {
+		return x.MinSubtotal
+	}
+	return nil
+}
+
+func (x *PromotionRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// PromotionResponse representa uma promoção cadastrada
+type PromotionResponse struct {
+	Id                 uint32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code               string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name               string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type               string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Value              float64  `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	BuyQuantity        int32    `protobuf:"varint,6,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity        int32    `protobuf:"varint,7,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	Skus               []string `protobuf:"bytes,9,rep,name=skus,proto3" json:"skus,omitempty"`
+	Automatic          bool     `protobuf:"varint,10,opt,name=automatic,proto3" json:"automatic,omitempty"`
+	Stacking           string   `protobuf:"bytes,11,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Priority           int32    `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	ValidFrom          string   `protobuf:"bytes,13,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo            string   `protobuf:"bytes,14,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	MaxUses            int32    `protobuf:"varint,15,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	MaxUsesPerCustomer int32    `protobuf:"varint,16,opt,name=max_uses_per_customer,json=maxUsesPerCustomer,proto3" json:"max_uses_per_customer,omitempty"`
+	Active             bool     `protobuf:"varint,17,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt          string   `protobuf:"bytes,18,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MinSubtotal        *Money   `protobuf:"bytes,19,opt,name=min_subtotal,json=minSubtotal,proto3" json:"min_subtotal,omitempty"`
+	Amount             *Money   `protobuf:"bytes,20,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *PromotionResponse) Reset() {
+	*x = PromotionResponse{}
+}
+
+func (x *PromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionResponse) ProtoMessage() {}
+
+func (x *PromotionResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PromotionResponse) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *PromotionResponse) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+func (x *PromotionResponse) GetStacking() string {
+	if x != nil {
+		return x.Stacking
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetValidTo() string {
+	if x != nil {
+		return x.ValidTo
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetMaxUsesPerCustomer() int32 {
+	if x != nil {
+		return x.MaxUsesPerCustomer
+	}
+	return 0
+}
+
+func (x *PromotionResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PromotionResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PromotionResponse) GetMinSubtotal() *Money {
+	if x != nil {
+		return x.MinSubtotal
+	}
+	return nil
+}
+
+func (x *PromotionResponse) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// ListPromotionsRequest filtra as promoções listadas
+type ListPromotionsRequest struct {
+	ActiveOnly bool `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListPromotionsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+// ListPromotionsResponse contém as promoções cadastradas
+type ListPromotionsResponse struct {
+	Promotions []*PromotionResponse `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*PromotionResponse {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+// SetPromotionActiveRequest ativa ou desativa a promoção pelo código
+type SetPromotionActiveRequest struct {
+	Code   string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Active bool   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+}
+
+func (x *SetPromotionActiveRequest) Reset() {
+	*x = SetPromotionActiveRequest{}
+}
+
+func (x *SetPromotionActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPromotionActiveRequest) ProtoMessage() {}
+
+func (x *SetPromotionActiveRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SetPromotionActiveRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SetPromotionActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// TaxRateRequest cria ou substitui a alíquota de uma classe fiscal, UF e tributo.
+// state "*" (ou vazio) vale para todas as UFs sem alíquota específica; tax: ICMS ou ISS;
+// rate é o percentual.
+type TaxRateRequest struct {
+	TaxClass string  `protobuf:"bytes,1,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+	State    string  `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Tax      string  `protobuf:"bytes,3,opt,name=tax,proto3" json:"tax,omitempty"`
+	Rate     float64 `protobuf:"fixed64,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	Included bool    `protobuf:"varint,5,opt,name=included,proto3" json:"included,omitempty"`
+}
+
+func (x *TaxRateRequest) Reset() {
+	*x = TaxRateRequest{}
+}
+
+func (x *TaxRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxRateRequest) ProtoMessage() {}
+
+func (x *TaxRateRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *TaxRateRequest) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *TaxRateRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaxRateRequest) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *TaxRateRequest) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TaxRateRequest) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+// TaxRateResponse representa uma alíquota da tabela fiscal
+type TaxRateResponse struct {
+	Id        uint32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaxClass  string  `protobuf:"bytes,2,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+	State     string  `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Tax       string  `protobuf:"bytes,4,opt,name=tax,proto3" json:"tax,omitempty"`
+	Rate      float64 `protobuf:"fixed64,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	Included  bool    `protobuf:"varint,6,opt,name=included,proto3" json:"included,omitempty"`
+	UpdatedAt string  `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *TaxRateResponse) Reset() {
+	*x = TaxRateResponse{}
+}
+
+func (x *TaxRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxRateResponse) ProtoMessage() {}
+
+func (x *TaxRateResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *TaxRateResponse) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaxRateResponse) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *TaxRateResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaxRateResponse) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *TaxRateResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TaxRateResponse) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+func (x *TaxRateResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// ListTaxRatesRequest filtra as alíquotas por classe fiscal (vazia lista todas)
+type ListTaxRatesRequest struct {
+	TaxClass string `protobuf:"bytes,1,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+}
+
+func (x *ListTaxRatesRequest) Reset() {
+	*x = ListTaxRatesRequest{}
+}
+
+func (x *ListTaxRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxRatesRequest) ProtoMessage() {}
+
+func (x *ListTaxRatesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListTaxRatesRequest) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+// ListTaxRatesResponse contém a tabela de alíquotas
+type ListTaxRatesResponse struct {
+	Rates []*TaxRateResponse `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+}
+
+func (x *ListTaxRatesResponse) Reset() {
+	*x = ListTaxRatesResponse{}
+}
+
+func (x *ListTaxRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxRatesResponse) ProtoMessage() {}
+
+func (x *ListTaxRatesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListTaxRatesResponse) GetRates() []*TaxRateResponse {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// DeleteTaxRateRequest identifica a alíquota a remover
+type DeleteTaxRateRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteTaxRateRequest) Reset() {
+	*x = DeleteTaxRateRequest{}
+}
+
+func (x *DeleteTaxRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaxRateRequest) ProtoMessage() {}
+
+func (x *DeleteTaxRateRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DeleteTaxRateRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DeleteTaxRateResponse confirma a remoção
+type DeleteTaxRateResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *DeleteTaxRateResponse) Reset() {
+	*x = DeleteTaxRateResponse{}
+}
+
+func (x *DeleteTaxRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaxRateResponse) ProtoMessage() {}
+
+func (x *DeleteTaxRateResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DeleteTaxRateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// OrderServiceClient é o cliente do serviço de pedidos
+type OrderServiceClient interface {
+	CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	ExportCustomerData(ctx context.Context, in *CustomerDataRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	AnonymizeCustomer(ctx context.Context, in *AnonymizeCustomerRequest, opts ...grpc.CallOption) (*AnonymizeCustomerResponse, error)
+	CreatePromotion(ctx context.Context, in *PromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error)
+	ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error)
+	SetPromotionActive(ctx context.Context, in *SetPromotionActiveRequest, opts ...grpc.CallOption) (*PromotionResponse, error)
+	SetTaxRate(ctx context.Context, in *TaxRateRequest, opts ...grpc.CallOption) (*TaxRateResponse, error)
+	ListTaxRates(ctx context.Context, in *ListTaxRatesRequest, opts ...grpc.CallOption) (*ListTaxRatesResponse, error)
+	DeleteTaxRate(ctx context.Context, in *DeleteTaxRateRequest, opts ...grpc.CallOption) (*DeleteTaxRateResponse, error)
+}
+
+type orderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderServiceClient(cc grpc.ClientConnInterface) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/CreateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/ListOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ExportCustomerData(ctx context.Context, in *CustomerDataRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/ExportCustomerData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AnonymizeCustomer(ctx context.Context, in *AnonymizeCustomerRequest, opts ...grpc.CallOption) (*AnonymizeCustomerResponse, error) {
+	out := new(AnonymizeCustomerResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/AnonymizeCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreatePromotion(ctx context.Context, in *PromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error) {
+	out := new(PromotionResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/CreatePromotion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error) {
+	out := new(ListPromotionsResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/ListPromotions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetPromotionActive(ctx context.Context, in *SetPromotionActiveRequest, opts ...grpc.CallOption) (*PromotionResponse, error) {
+	out := new(PromotionResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/SetPromotionActive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetTaxRate(ctx context.Context, in *TaxRateRequest, opts ...grpc.CallOption) (*TaxRateResponse, error) {
+	out := new(TaxRateResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/SetTaxRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListTaxRates(ctx context.Context, in *ListTaxRatesRequest, opts ...grpc.CallOption) (*ListTaxRatesResponse, error) {
+	out := new(ListTaxRatesResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/ListTaxRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DeleteTaxRate(ctx context.Context, in *DeleteTaxRateRequest, opts ...grpc.CallOption) (*DeleteTaxRateResponse, error) {
+	out := new(DeleteTaxRateResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/DeleteTaxRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderServiceServer é o servidor do serviço de pedidos
+type OrderServiceServer interface {
+	CreateOrder(context.Context, *OrderRequest) (*OrderResponse, error)
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*OrderResponse, error)
+	ExportCustomerData(context.Context, *CustomerDataRequest) (*ListOrdersResponse, error)
+	AnonymizeCustomer(context.Context, *AnonymizeCustomerRequest) (*AnonymizeCustomerResponse, error)
+	CreatePromotion(context.Context, *PromotionRequest) (*PromotionResponse, error)
+	ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error)
+	SetPromotionActive(context.Context, *SetPromotionActiveRequest) (*PromotionResponse, error)
+	SetTaxRate(context.Context, *TaxRateRequest) (*TaxRateResponse, error)
+	ListTaxRates(context.Context, *ListTaxRatesRequest) (*ListTaxRatesResponse, error)
+	DeleteTaxRate(context.Context, *DeleteTaxRateRequest) (*DeleteTaxRateResponse, error)
+}
+
+// UnimplementedOrderServiceServer deve ser embedded para ter implementações forward compatible
+type UnimplementedOrderServiceServer struct {
+}
+
+func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *OrderRequest) (*OrderResponse, error) {
+	return nil, nil
+}
+
+func (UnimplementedOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, nil
+}
+
+func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+
+func (UnimplementedOrderServiceServer) ExportCustomerData(context.Context, *CustomerDataRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportCustomerData not implemented")
+}
+
+func (UnimplementedOrderServiceServer) AnonymizeCustomer(context.Context, *AnonymizeCustomerRequest) (*AnonymizeCustomerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnonymizeCustomer not implemented")
+}
+
+func (UnimplementedOrderServiceServer) CreatePromotion(context.Context, *PromotionRequest) (*PromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromotion not implemented")
+}
+
+func (UnimplementedOrderServiceServer) ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromotions not implemented")
+}
+
+func (UnimplementedOrderServiceServer) SetPromotionActive(context.Context, *SetPromotionActiveRequest) (*PromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPromotionActive not implemented")
+}
+
+func (UnimplementedOrderServiceServer) SetTaxRate(context.Context, *TaxRateRequest) (*TaxRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaxRate not implemented")
+}
+
+func (UnimplementedOrderServiceServer) ListTaxRates(context.Context, *ListTaxRatesRequest) (*ListTaxRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaxRates not implemented")
+}
+
+func (UnimplementedOrderServiceServer) DeleteTaxRate(context.Context, *DeleteTaxRateRequest) (*DeleteTaxRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTaxRate not implemented")
+}
+
+// RegisterOrderServiceServer registra o serviço no servidor gRPC
+func RegisterOrderServiceServer(s grpc.ServiceRegistrar, srv OrderServiceServer) {
+	s.RegisterService(&OrderService_ServiceDesc, srv)
+}
+
+func _OrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/CreateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateOrder(ctx, req.(*OrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/ListOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ExportCustomerData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomerDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ExportCustomerData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/ExportCustomerData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ExportCustomerData(ctx, req.(*CustomerDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_AnonymizeCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnonymizeCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AnonymizeCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/AnonymizeCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AnonymizeCustomer(ctx, req.(*AnonymizeCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/CreatePromotion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreatePromotion(ctx, req.(*PromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListPromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromotionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListPromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/ListPromotions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListPromotions(ctx, req.(*ListPromotionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetPromotionActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPromotionActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetPromotionActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/SetPromotionActive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetPromotionActive(ctx, req.(*SetPromotionActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetTaxRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaxRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetTaxRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/SetTaxRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetTaxRate(ctx, req.(*TaxRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListTaxRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaxRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListTaxRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/ListTaxRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListTaxRates(ctx, req.(*ListTaxRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DeleteTaxRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaxRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DeleteTaxRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order.OrderService/DeleteTaxRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DeleteTaxRate(ctx, req.(*DeleteTaxRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderService_ServiceDesc é o descritor do serviço gRPC
 var OrderService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "order.OrderService",
 	HandlerType: (*OrderServiceServer)(nil),
@@ -282,6 +1806,42 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListOrders",
 			Handler:    _OrderService_ListOrders_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "ExportCustomerData",
+			Handler:    _OrderService_ExportCustomerData_Handler,
+		},
+		{
+			MethodName: "AnonymizeCustomer",
+			Handler:    _OrderService_AnonymizeCustomer_Handler,
+		},
+		{
+			MethodName: "CreatePromotion",
+			Handler:    _OrderService_CreatePromotion_Handler,
+		},
+		{
+			MethodName: "ListPromotions",
+			Handler:    _OrderService_ListPromotions_Handler,
+		},
+		{
+			MethodName: "SetPromotionActive",
+			Handler:    _OrderService_SetPromotionActive_Handler,
+		},
+		{
+			MethodName: "SetTaxRate",
+			Handler:    _OrderService_SetTaxRate_Handler,
+		},
+		{
+			MethodName: "ListTaxRates",
+			Handler:    _OrderService_ListTaxRates_Handler,
+		},
+		{
+			MethodName: "DeleteTaxRate",
+			Handler:    _OrderService_DeleteTaxRate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order.proto",
@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"payment-service/internal/config"
 	"payment-service/internal/database"
+	"payment-service/internal/fraud"
+	"payment-service/internal/fx"
+	"payment-service/internal/gateway"
 	"payment-service/internal/messaging"
 	"payment-service/internal/publisher"
 	"payment-service/internal/repository"
@@ -21,6 +28,14 @@ import (
 )
 
 func main() {
+	cfg := config.LoadConfig()
+
+	// Validador de tokens para autorização das chamadas gRPC
+	tokens, err := auth.NewTokenManager(cfg.JWTSecret, cfg.JWTIssuer)
+	if err != nil {
+		log.Fatalf("Falha ao configurar autenticação: %v", err)
+	}
+
 	// Inicializar conexão com o banco de dados
 	db, err := database.Connect()
 	if err != nil {
@@ -43,11 +58,80 @@ func main() {
 	}
 	defer pub.Close()
 
-	paymentService := service.NewPaymentService(paymentRepo, pub)
+	// Cotações para pagamentos em moeda diferente da do pedido
+	fxRepo := repository.NewFXRateRepository(db)
+	rates, err := newFXProvider(cfg, fxRepo)
+	if err != nil {
+		log.Fatalf("Falha ao carregar cotações: %v", err)
+	}
+
+	// Regras de parcelamento do lojista
+	installmentRules, err := cfg.InstallmentRules()
+	if err != nil {
+		log.Fatalf("Falha ao configurar parcelamento: %v", err)
+	}
+
+	// Recebedor das cobranças PIX
+	pixConfig, err := cfg.PixConfig()
+	if err != nil {
+		log.Fatalf("Falha ao configurar PIX: %v", err)
+	}
+
+	// Carteira de cobrança dos boletos
+	boletoConfig, err := cfg.BoletoConfig()
+	if err != nil {
+		log.Fatalf("Falha ao configurar boleto: %v", err)
+	}
+
+	// Provedor dos pagamentos com cartão
+	gatewayConfig, err := cfg.GatewayConfig()
+	if err != nil {
+		log.Fatalf("Falha ao configurar provedor de pagamento: %v", err)
+	}
+
+	// Análise antifraude antes da autorização
+	fraudChecker, err := newFraudChecker(cfg)
+	if err != nil {
+		log.Fatalf("Falha ao configurar antifraude: %v", err)
+	}
+
+	// Regras das contestações (chargebacks)
+	disputeConfig, err := cfg.DisputeConfig()
+	if err != nil {
+		log.Fatalf("Falha ao configurar contestações: %v", err)
+	}
+
+	paymentService := service.NewPaymentService(paymentRepo, pub, rates, fxRepo, installmentRules, pixConfig, boletoConfig, gatewayConfig, fraudChecker)
+	pixService := service.NewPixService(paymentRepo, pub)
+	boletoService := service.NewBoletoService(paymentRepo, pub, boletoConfig)
+	gatewayService := service.NewGatewayService(paymentRepo, repository.NewWebhookRepository(db), pub)
+	disputeService := service.NewDisputeService(paymentRepo, repository.NewDisputeRepository(db), pub, disputeConfig)
+	settlementService := service.NewSettlementService(paymentRepo, repository.NewSettlementRepository(db))
+
+	// Recusar as cobranças PIX não pagas dentro do prazo
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+	go pixService.RunExpiryWorker(workerCtx, cfg.PixExpirySweepInterval)
+
+	// Baixar os boletos não liquidados após a tolerância do vencimento
+	if boletoConfig.Enabled() {
+		go boletoService.RunOverdueWorker(workerCtx, cfg.BoletoOverdueSweepInterval)
+	}
+
+	// Conciliar os arquivos de liquidação deixados pelo provedor na pasta de entrada
+	if cfg.SettlementInboxDir != "" {
+		go settlementService.RunInboxWorker(workerCtx, cfg.SettlementInboxDir, cfg.SettlementSweepInterval)
+	}
 
-	// Inicializar servidor gRPC
-	grpcServer := grpc.NewServer()
-	paymentGRPCServer := transport.NewPaymentGRPCServer(paymentService)
+	// Webhooks de confirmação de PIX do PSP e de eventos do provedor de pagamento
+	webhookServer := newWebhookServer(cfg, gatewayConfig, pixService, gatewayService)
+
+	// Inicializar servidor gRPC com autorização por método
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens, transport.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens, transport.MethodPermissions)),
+	)
+	paymentGRPCServer := transport.NewPaymentGRPCServer(paymentService, pixService, boletoService, disputeService, settlementService)
 	pb.RegisterPaymentServiceServer(grpcServer, paymentGRPCServer)
 
 	// Inicializar consumer RabbitMQ
@@ -88,6 +172,100 @@ func main() {
 	fmt.Println("\nRecebido sinal de parada. Desligando payment service...")
 
 	// Graceful shutdown
+	if webhookServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		webhookServer.Shutdown(shutdownCtx)
+	}
 	grpcServer.GracefulStop()
 	fmt.Println("Payment service desligado com sucesso.")
 }
+
+// newWebhookServer inicia o servidor HTTP dos webhooks. Cada webhook só é registrado
+// com o seu segredo (PIX_WEBHOOK_SECRET, GATEWAY_WEBHOOK_SECRET), sem o qual nenhuma
+// notificação seria aceita; sem nenhum deles o servidor não é iniciado.
+func newWebhookServer(cfg *config.Config, gatewayConfig gateway.Config, pixService service.PixService, gatewayService service.GatewayService) *http.Server {
+	mux := http.NewServeMux()
+	var paths []string
+	if cfg.PixWebhookSecret != "" {
+		mux.Handle(transport.PixWebhookPath, transport.NewPixWebhookHandler(pixService, cfg.PixWebhookSecret))
+		paths = append(paths, transport.PixWebhookPath)
+	} else {
+		log.Println("⚠️ PIX_WEBHOOK_SECRET não definido: webhook de PIX desabilitado")
+	}
+	if cfg.GatewayWebhookSecret != "" {
+		mux.Handle(transport.GatewayWebhookPath, transport.NewGatewayWebhookHandler(
+			gatewayService, gatewayConfig.Provider, cfg.GatewayWebhookSecret, cfg.GatewayWebhookTolerance))
+		paths = append(paths, transport.GatewayWebhookPath)
+	} else {
+		log.Println("⚠️ GATEWAY_WEBHOOK_SECRET não definido: webhook do provedor de pagamento desabilitado")
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	server := &http.Server{
+		Addr:              ":" + cfg.WebhookPort,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Printf("🔔 Webhooks ouvindo em :%s %v", cfg.WebhookPort, paths)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Erro no servidor de webhooks: %v", err)
+		}
+	}()
+	return server
+}
+
+// newFXProvider cria a fonte de cotações configurada. No modo db, as cotações do arquivo
+// (se existir) são importadas para a tabela fx_rates, que passa a ser mantida no banco.
+func newFXProvider(cfg *config.Config, fxRepo repository.FXRateRepository) (fx.Provider, error) {
+	switch cfg.FXProvider {
+	case "file":
+		return fx.NewFileProvider(cfg.FXRatesFile)
+	case "db":
+		file, err := os.Open(cfg.FXRatesFile)
+		if err != nil {
+			log.Printf("ℹ️ Arquivo de cotações %s não importado: %v", cfg.FXRatesFile, err)
+			return fx.NewDBProvider(fxRepo), nil
+		}
+		defer file.Close()
+
+		rates, err := fx.ReadRates(file, fx.SourceFile, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		for _, rate := range rates {
+			row := fx.ToDomain(rate)
+			if err := fxRepo.Upsert(&row); err != nil {
+				return nil, fmt.Errorf("falha ao importar cotação %s/%s: %w", rate.From, rate.To, err)
+			}
+		}
+		log.Printf("💱 %d cotações importadas de %s", len(rates), cfg.FXRatesFile)
+		return fx.NewDBProvider(fxRepo), nil
+	default:
+		return nil, fmt.Errorf("FX_PROVIDER inválido: %q (use file ou db)", cfg.FXProvider)
+	}
+}
+
+// newFraudChecker monta a análise antifraude com as regras e as listas de FRAUD_LISTS_FILE;
+// sem arquivo de listas, apenas as regras são aplicadas
+func newFraudChecker(cfg *config.Config) (fraud.Checker, error) {
+	rules, enabled, err := cfg.FraudRules()
+	if err != nil || !enabled {
+		if err == nil {
+			log.Println("ℹ️ Análise antifraude desligada (FRAUD_ENABLED=false)")
+		}
+		return fraud.Checker{}, err
+	}
+	if cfg.FraudListsFile == "" {
+		return fraud.Checker{Rules: rules, Lists: fraud.StaticLists(fraud.Lists{})}, nil
+	}
+	lists, err := fraud.NewFileLists(cfg.FraudListsFile)
+	if err != nil {
+		return fraud.Checker{}, err
+	}
+	return fraud.Checker{Rules: rules, Lists: lists}, nil
+}
@@ -0,0 +1,543 @@
+// Command paymentctl executa operações administrativas de pagamentos usando os RPCs do
+// PaymentService: conciliação de boletos a partir do arquivo de retorno do banco
+// (ReconcileBoletos) e emissão da segunda via do boleto em PDF (GetBoleto). O
+// subcomando gateway-event faz o papel do provedor de pagamento local (stub) e envia
+// ao webhook um evento assinado para o pagamento de um pedido. O subcomando
+// fraud-review lista os pagamentos retidos pela análise antifraude e registra a
+// decisão do analista (ApprovePayment e RejectPayment). Os subcomandos disputes e
+// dispute-evidence listam as contestações com prazo da defesa próximo
+// (ListDisputesNearDeadline) e anexam evidências à defesa (AddDisputeEvidence). Os
+// subcomandos settlement-import e settlement-report conciliam o arquivo de liquidação
+// do provedor de cartão (ImportSettlement) e listam ou exportam em CSV as conciliações
+// (ListSettlementRuns e ExportSettlementRun).
+//
+// Uso:
+//
+//	paymentctl boleto-reconcile [-dry-run] [-report resultado.csv] retorno.ret
+//	paymentctl boleto-pdf -order 42 [-o boleto.pdf]
+//	paymentctl gateway-event -order 42 [-type payment.failed] [-reason "saldo insuficiente"]
+//	paymentctl fraud-review [-limit 50]
+//	paymentctl fraud-review -approve 42 [-note "cliente confirmou a compra"]
+//	paymentctl fraud-review -reject 42 [-note "cartão contestado"]
+//	paymentctl disputes [-within 72h]
+//	paymentctl dispute-evidence -id 7 [-kind shipping] [-note "entregue ao titular"] [-submit] rastreio.pdf
+//	paymentctl settlement-import [-report resultado.csv] liquidacao-2025-03-10.csv
+//	paymentctl settlement-report [-limit 20]
+//	paymentctl settlement-report -id 3 [-outcome amount_mismatch] [-o conciliacao.csv]
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/boleto"
+	"payment-service/internal/gateway"
+	"payment-service/internal/settlement"
+	"payment-service/proto"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "boleto-reconcile":
+		err = runReconcile(os.Args[2:])
+	case "boleto-pdf":
+		err = runPDF(os.Args[2:])
+	case "gateway-event":
+		err = runGatewayEvent(os.Args[2:])
+	case "fraud-review":
+		err = runFraudReview(os.Args[2:])
+	case "disputes":
+		err = runDisputes(os.Args[2:])
+	case "dispute-evidence":
+		err = runDisputeEvidence(os.Args[2:])
+	case "settlement-import":
+		err = runSettlementImport(os.Args[2:])
+	case "settlement-report":
+		err = runSettlementReport(os.Args[2:])
+	case "-h", "--help", "help":
+		usage()
+		return
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "uso: paymentctl <boleto-reconcile|boleto-pdf|gateway-event|fraud-review|disputes|dispute-evidence|settlement-import|settlement-report> [opções]")
+	fmt.Fprintln(os.Stderr, "  paymentctl boleto-reconcile [-dry-run] [-report resultado.csv] <retorno.ret|->")
+	fmt.Fprintln(os.Stderr, "  paymentctl boleto-pdf -order ID [-o boleto.pdf]")
+	fmt.Fprintln(os.Stderr, "  paymentctl gateway-event -order ID [-type payment.succeeded|payment.failed] [-reason texto] [-url URL]")
+	fmt.Fprintln(os.Stderr, "  paymentctl fraud-review [-limit N] | [-approve ID | -reject ID] [-note texto]")
+	fmt.Fprintln(os.Stderr, "  paymentctl disputes [-within 72h] [-limit N]")
+	fmt.Fprintln(os.Stderr, "  paymentctl dispute-evidence -id ID [-kind receipt|invoice|shipping|communication|other] [-note texto] [-submit] <arquivo>")
+	fmt.Fprintln(os.Stderr, "  paymentctl settlement-import [-report resultado.csv] <liquidacao.csv|->")
+	fmt.Fprintln(os.Stderr, "  paymentctl settlement-report [-limit N] | -id ID [-outcome resultado] [-o arquivo.csv]")
+}
+
+// connection contém as opções de conexão comuns aos subcomandos
+type connection struct {
+	addr  string
+	token string
+}
+
+func (c *connection) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.addr, "addr", getEnv("PAYMENT_SERVICE_URL", "localhost:50053"), "endereço gRPC do payment-service")
+	fs.StringVar(&c.token, "token", os.Getenv("PAYMENT_TOKEN"), "token de acesso (Bearer) com payments:write, payments:read ou fraud:review")
+}
+
+func (c *connection) dial() (proto.PaymentServiceClient, *grpc.ClientConn, context.Context, error) {
+	conn, err := grpc.Dial(c.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("erro ao conectar em %s: %w", c.addr, err)
+	}
+	ctx := context.Background()
+	if c.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	}
+	return proto.NewPaymentServiceClient(conn), conn, ctx, nil
+}
+
+func runReconcile(args []string) error {
+	fs := flag.NewFlagSet("boleto-reconcile", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	dryRun := fs.Bool("dry-run", false, "mostra o resultado da conciliação sem aprovar pagamentos")
+	reportPath := fs.String("report", "", "grava o resultado de cada título neste arquivo CSV")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("informe o arquivo de retorno (ou - para a entrada padrão)")
+	}
+	input := io.Reader(os.Stdin)
+	if path := fs.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	content, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	result, err := client.ReconcileBoletos(ctx, &proto.ReconcileBoletosRequest{Content: content, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	mode := ""
+	if result.GetDryRun() {
+		mode = " (simulação, nada foi gravado)"
+	}
+	fmt.Printf("🏦 Retorno %s conciliado%s: %d títulos, %d liquidados, %d já pagos, %d divergentes, %d não encontrados, %d baixados, %d outras ocorrências\n",
+		result.GetFormat(), mode, len(result.GetItems()), result.GetPaid(), result.GetAlreadyPaid(),
+		result.GetAmountMismatch(), result.GetNotFound(), result.GetExpired(), result.GetIgnored())
+	for _, item := range result.GetItems() {
+		if item.GetDetail() != "" {
+			fmt.Fprintf(os.Stderr, "  linha %d (nosso número %s): %s - %s\n", item.GetLine(), item.GetNossoNumero(), item.GetOutcome(), item.GetDetail())
+		}
+	}
+
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, result.GetItems()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReport grava o resultado de cada título em CSV
+func writeReport(path string, items []*proto.BoletoReconcileItem) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"line", "nosso_numero", "order_id", "occurrence", "outcome", "detail"})
+	for _, item := range items {
+		writer.Write([]string{
+			strconv.Itoa(int(item.GetLine())),
+			item.GetNossoNumero(),
+			strconv.FormatUint(uint64(item.GetOrderId()), 10),
+			item.GetOccurrence(),
+			item.GetOutcome(),
+			item.GetDetail(),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func runPDF(args []string) error {
+	fs := flag.NewFlagSet("boleto-pdf", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	orderID := fs.Uint("order", 0, "ID do pedido")
+	outPath := fs.String("o", "", "arquivo de saída (padrão: boleto-<pedido>.pdf)")
+	fs.Parse(args)
+
+	if *orderID == 0 {
+		return fmt.Errorf("informe o pedido com -order")
+	}
+	if *outPath == "" {
+		*outPath = fmt.Sprintf("boleto-%d.pdf", *orderID)
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	response, err := client.GetBoleto(ctx, &proto.BoletoRequest{OrderId: uint32(*orderID), IncludePdf: true})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*outPath, response.GetPdf(), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("🧾 Boleto do pedido %d (%s, vencimento %s) gravado em %s\n",
+		response.GetOrderId(), response.GetStatus(), response.GetDueDate(), *outPath)
+	fmt.Printf("   %s\n", boleto.FormatDigitableLine(response.GetDigitableLine()))
+	return nil
+}
+
+func runGatewayEvent(args []string) error {
+	fs := flag.NewFlagSet("gateway-event", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	orderID := fs.Uint("order", 0, "ID do pedido cujo pagamento recebe o evento")
+	eventType := fs.String("type", gateway.EventPaymentSucceeded, "tipo do evento: payment.succeeded ou payment.failed")
+	reason := fs.String("reason", "", "motivo informado pelo provedor (ex.: recusa do emissor)")
+	url := fs.String("url", getEnv("PAYMENT_WEBHOOK_URL", "http://localhost:8083/webhooks/gateway"), "URL do webhook do provedor")
+	secret := fs.String("secret", os.Getenv("GATEWAY_WEBHOOK_SECRET"), "segredo compartilhado do webhook")
+	fs.Parse(args)
+
+	if *orderID == 0 {
+		return fmt.Errorf("informe o pedido com -order")
+	}
+	if *secret == "" {
+		return fmt.Errorf("informe o segredo com -secret ou GATEWAY_WEBHOOK_SECRET")
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	payment, err := client.GetPaymentStatus(ctx, &proto.PaymentStatusRequest{OrderId: uint32(*orderID)})
+	if err != nil {
+		return err
+	}
+	if payment.GetGatewayRef() == "" {
+		return fmt.Errorf("pedido %d sem pagamento com cartão no provedor (status %s)", *orderID, payment.GetStatus())
+	}
+
+	stub := gateway.NewStub(*url, *secret)
+	event := stub.Event(*eventType, payment.GetGatewayRef(), money.New(payment.GetAmount().GetAmount(), payment.GetAmount().GetCurrency()))
+	event.Reason = *reason
+	status, err := stub.Send(context.Background(), event)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("webhook respondeu HTTP %d", status)
+	}
+	fmt.Printf("🔔 Evento %s (%s) enviado para %s\n", event.ID, event.Type, event.PaymentRef)
+	return nil
+}
+
+func runFraudReview(args []string) error {
+	fs := flag.NewFlagSet("fraud-review", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	limit := fs.Int("limit", 50, "máximo de pagamentos retidos listados")
+	approve := fs.Uint("approve", 0, "aprova o pagamento retido do pedido informado")
+	reject := fs.Uint("reject", 0, "rejeita o pagamento retido do pedido informado")
+	note := fs.String("note", "", "observação do analista registrada com a decisão")
+	fs.Parse(args)
+
+	if *approve != 0 && *reject != 0 {
+		return fmt.Errorf("use -approve ou -reject, não ambos")
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	if *approve != 0 || *reject != 0 {
+		review := client.ApprovePayment
+		request := &proto.ReviewPaymentRequest{OrderId: uint32(*approve), Note: *note}
+		if *reject != 0 {
+			review = client.RejectPayment
+			request.OrderId = uint32(*reject)
+		}
+		payment, err := review(ctx, request)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔎 Pagamento do pedido %d: %s\n", payment.GetOrderId(), payment.GetStatus())
+		return nil
+	}
+
+	response, err := client.ListPaymentsInReview(ctx, &proto.PaymentsInReviewRequest{Limit: int32(*limit)})
+	if err != nil {
+		return err
+	}
+	if len(response.GetPayments()) == 0 {
+		fmt.Println("✅ Nenhum pagamento aguardando análise")
+		return nil
+	}
+	for _, payment := range response.GetPayments() {
+		fraud := payment.GetFraud()
+		amount := money.New(payment.GetOrderAmount().GetAmount(), payment.GetOrderAmount().GetCurrency())
+		fmt.Printf("🔎 Pedido %d: %s, score %d (retido em %s)\n", payment.GetOrderId(), amount, fraud.GetScore(), payment.GetCreatedAt())
+		if deadline := fraud.GetReviewDeadline(); deadline != "" {
+			fmt.Printf("   ⌛ aprovar até %s (vencimento da reserva de estoque)\n", deadline)
+		}
+		for _, rule := range fraud.GetRules() {
+			fmt.Printf("   +%d %s: %s\n", rule.GetScore(), rule.GetRule(), rule.GetDetail())
+		}
+	}
+	return nil
+}
+
+func runDisputes(args []string) error {
+	fs := flag.NewFlagSet("disputes", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	within := fs.String("within", "", "antecedência do prazo da defesa (ex.: 72h; padrão do servidor)")
+	limit := fs.Int("limit", 50, "máximo de contestações listadas")
+	fs.Parse(args)
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	response, err := client.ListDisputesNearDeadline(ctx, &proto.DisputesNearDeadlineRequest{Within: *within, Limit: int32(*limit)})
+	if err != nil {
+		return err
+	}
+	if len(response.GetDisputes()) == 0 {
+		fmt.Println("✅ Nenhuma contestação com prazo da defesa próximo")
+		return nil
+	}
+	for _, dispute := range response.GetDisputes() {
+		icon := "⚖️"
+		if dispute.GetOverdue() {
+			icon = "🚨"
+		}
+		amount := money.New(dispute.GetAmount().GetAmount(), dispute.GetAmount().GetCurrency())
+		fmt.Printf("%s Contestação %d (%s), pedido %d: %s, %s - defesa até %s, %d evidência(s)\n",
+			icon, dispute.GetId(), dispute.GetReference(), dispute.GetOrderId(), amount, dispute.GetReason(),
+			dispute.GetEvidenceDueAt(), len(dispute.GetEvidence()))
+	}
+	return nil
+}
+
+func runDisputeEvidence(args []string) error {
+	fs := flag.NewFlagSet("dispute-evidence", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	id := fs.Uint("id", 0, "ID da contestação")
+	kind := fs.String("kind", "other", "tipo da evidência: receipt, invoice, shipping, communication ou other")
+	note := fs.String("note", "", "descrição da evidência")
+	submit := fs.Bool("submit", false, "envia a defesa ao adquirente após anexar o arquivo")
+	fs.Parse(args)
+
+	if *id == 0 {
+		return fmt.Errorf("informe a contestação com -id")
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("informe o arquivo da evidência")
+	}
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	evidence, err := client.AddDisputeEvidence(ctx, &proto.AddDisputeEvidenceRequest{
+		DisputeId: uint32(*id),
+		Kind:      *kind,
+		FileName:  fs.Arg(0),
+		Content:   content,
+		Note:      *note,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📎 Evidência %d (%s, %s, %d bytes) anexada à contestação %d\n",
+		evidence.GetId(), evidence.GetFileName(), evidence.GetContentType(), evidence.GetSize(), *id)
+
+	if *submit {
+		dispute, err := client.SubmitDisputeEvidence(ctx, &proto.DisputeRequest{DisputeId: uint32(*id)})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📨 Defesa da contestação %s enviada com %d evidência(s)\n", dispute.GetReference(), len(dispute.GetEvidence()))
+	}
+	return nil
+}
+
+func runSettlementImport(args []string) error {
+	fs := flag.NewFlagSet("settlement-import", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	reportPath := fs.String("report", "", "grava o resultado de cada transação neste arquivo CSV")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("informe o arquivo de liquidação (ou - para a entrada padrão)")
+	}
+	name := fs.Arg(0)
+	input := io.Reader(os.Stdin)
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	} else {
+		name = "stdin.csv"
+	}
+	content, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	run, err := client.ImportSettlement(ctx, &proto.ImportSettlementRequest{FileName: filepath.Base(name), Content: content})
+	if err != nil {
+		return err
+	}
+
+	mode := ""
+	if run.GetAlreadyImported() {
+		mode = " (arquivo já conciliado antes)"
+	}
+	fmt.Printf("📑 Conciliação %d%s: %d transações, %d conferidas, %d sem pagamento, %d ausentes do arquivo, %d com valor divergente, %d com status divergente, %d repetidas\n",
+		run.GetId(), mode, run.GetRecords(), run.GetMatched(), run.GetMissingPayment(), run.GetMissingSettlement(),
+		run.GetAmountMismatch(), run.GetStatusMismatch(), run.GetDuplicate())
+	for _, item := range run.GetItems() {
+		if item.GetOutcome() != settlement.OutcomeMatched {
+			fmt.Fprintf(os.Stderr, "  linha %d (%s): %s - %s\n", item.GetLine(), item.GetGatewayRef(), item.GetOutcome(), item.GetDetail())
+		}
+	}
+
+	if *reportPath != "" {
+		return exportSettlement(ctx, client, run.GetId(), "", *reportPath)
+	}
+	return nil
+}
+
+func runSettlementReport(args []string) error {
+	fs := flag.NewFlagSet("settlement-report", flag.ExitOnError)
+	var conn connection
+	conn.register(fs)
+	id := fs.Uint("id", 0, "exporta em CSV a conciliação informada")
+	outcome := fs.String("outcome", "", "exporta só os itens com este resultado")
+	outPath := fs.String("o", "", "arquivo de saída (padrão: nome sugerido pelo servidor)")
+	limit := fs.Int("limit", 20, "máximo de conciliações listadas")
+	fs.Parse(args)
+
+	client, cc, ctx, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	if *id != 0 {
+		return exportSettlement(ctx, client, uint32(*id), *outcome, *outPath)
+	}
+
+	response, err := client.ListSettlementRuns(ctx, &proto.ListSettlementRunsRequest{Limit: int32(*limit)})
+	if err != nil {
+		return err
+	}
+	if len(response.GetRuns()) == 0 {
+		fmt.Println("📭 Nenhuma conciliação registrada")
+		return nil
+	}
+	for _, run := range response.GetRuns() {
+		divergent := run.GetMissingPayment() + run.GetMissingSettlement() + run.GetAmountMismatch() + run.GetStatusMismatch() + run.GetDuplicate()
+		fmt.Printf("📑 %d %s (%s, %s): %d transações, %d conferidas, %d divergências\n",
+			run.GetId(), run.GetFileName(), run.GetSource(), run.GetCreatedAt(), run.GetRecords(), run.GetMatched(), divergent)
+	}
+	return nil
+}
+
+// exportSettlement grava em CSV o resultado de cada item da conciliação
+func exportSettlement(ctx context.Context, client proto.PaymentServiceClient, id uint32, outcome, path string) error {
+	report, err := client.ExportSettlementRun(ctx, &proto.SettlementRunRequest{RunId: id, Outcome: outcome})
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = report.GetFileName()
+	}
+	if err := os.WriteFile(path, report.GetContent(), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("💾 Conciliação %d gravada em %s\n", id, path)
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,156 @@
+// Package boleto gera boletos bancários no padrão FEBRABAN: o código de barras de 44
+// dígitos, a linha digitável de 47 dígitos com os dígitos verificadores, o PDF com o
+// código de barras ITF e a leitura dos arquivos de retorno CNAB 240 e 400.
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Erros de montagem e validação de códigos
+var (
+	ErrInvalidBarcode       = errors.New("código de barras inválido")
+	ErrInvalidDigitableLine = errors.New("linha digitável inválida")
+	ErrInvalidDueDate       = errors.New("data de vencimento fora do intervalo aceito")
+	ErrInvalidAmount        = errors.New("valor do boleto inválido")
+)
+
+// currencyReal é o código da moeda Real no código de barras
+const currencyReal = "9"
+
+// maxAmount é o maior valor que cabe nos 10 dígitos do código de barras
+const maxAmount = 9_999_999_999
+
+// factorBase é a data-base do fator de vencimento (fator 1000 = 03/07/2000); após o
+// fator 9999 (21/02/2025) a contagem recomeça em 1000 (22/02/2025)
+var factorBase = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+
+// DueFactor calcula o fator de vencimento, os dias desde 07/10/1997 no ciclo de 1000 a 9999
+func DueFactor(due time.Time) (int, error) {
+	day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(day.Sub(factorBase).Hours() / 24)
+	if days < 1000 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidDueDate, due.Format(time.DateOnly))
+	}
+	if days > 9999 {
+		days = (days-10000)%9000 + 1000
+	}
+	return days, nil
+}
+
+// Barcode monta o código de barras de 44 dígitos: banco (3), moeda (1), DV (1), fator
+// de vencimento (4), valor em centavos (10) e campo livre do banco (25)
+func Barcode(bank string, due time.Time, amount int64, freeField string) (string, error) {
+	if len(bank) != 3 || !digits(bank) {
+		return "", fmt.Errorf("%w: banco %q", ErrInvalidBarcode, bank)
+	}
+	if len(freeField) != 25 || !digits(freeField) {
+		return "", fmt.Errorf("%w: campo livre deve ter 25 dígitos", ErrInvalidBarcode)
+	}
+	if amount < 0 || amount > maxAmount {
+		return "", fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	factor, err := DueFactor(due)
+	if err != nil {
+		return "", err
+	}
+
+	body := fmt.Sprintf("%s%s%04d%010d%s", bank, currencyReal, factor, amount, freeField)
+	return body[:4] + mod11(body) + body[4:], nil
+}
+
+// DigitableLine monta a linha digitável de 47 dígitos a partir do código de barras:
+// três campos com o campo livre e DV módulo 10, o DV geral e o fator com o valor
+func DigitableLine(barcode string) (string, error) {
+	if err := VerifyBarcode(barcode); err != nil {
+		return "", err
+	}
+	free := barcode[19:]
+	field1 := barcode[:4] + free[:5]
+	field2 := free[5:15]
+	field3 := free[15:25]
+	return field1 + mod10(field1) + field2 + mod10(field2) + field3 + mod10(field3) + barcode[4:5] + barcode[5:19], nil
+}
+
+// FormatDigitableLine formata a linha digitável para exibição
+// (AAAAA.AAAAA BBBBB.BBBBBB CCCCC.CCCCCC D EEEEEEEEEEEEEE)
+func FormatDigitableLine(line string) string {
+	if len(line) != 47 {
+		return line
+	}
+	return fmt.Sprintf("%s.%s %s.%s %s.%s %s %s",
+		line[0:5], line[5:10], line[10:15], line[15:21], line[21:26], line[26:32], line[32:33], line[33:47])
+}
+
+// ParseDigitableLine valida os dígitos verificadores da linha digitável (com ou sem
+// pontuação) e devolve o código de barras correspondente
+func ParseDigitableLine(line string) (string, error) {
+	line = strings.NewReplacer(".", "", " ", "").Replace(strings.TrimSpace(line))
+	if len(line) != 47 || !digits(line) {
+		return "", fmt.Errorf("%w: deve ter 47 dígitos", ErrInvalidDigitableLine)
+	}
+	for _, field := range [][2]int{{0, 9}, {10, 20}, {21, 31}} {
+		if mod10(line[field[0]:field[1]]) != line[field[1]:field[1]+1] {
+			return "", fmt.Errorf("%w: DV do campo na posição %d", ErrInvalidDigitableLine, field[0]+1)
+		}
+	}
+
+	barcode := line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31]
+	if err := VerifyBarcode(barcode); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidDigitableLine, err)
+	}
+	return barcode, nil
+}
+
+// VerifyBarcode confere o tamanho e o DV geral (módulo 11) do código de barras
+func VerifyBarcode(barcode string) error {
+	if len(barcode) != 44 || !digits(barcode) {
+		return fmt.Errorf("%w: deve ter 44 dígitos", ErrInvalidBarcode)
+	}
+	if mod11(barcode[:4]+barcode[5:]) != barcode[4:5] {
+		return fmt.Errorf("%w: DV geral não confere", ErrInvalidBarcode)
+	}
+	return nil
+}
+
+// mod10 calcula o DV módulo 10 dos campos da linha digitável: pesos 2 e 1 a partir da
+// direita, somando os algarismos de cada produto
+func mod10(value string) string {
+	sum, weight := 0, 2
+	for i := len(value) - 1; i >= 0; i-- {
+		product := int(value[i]-'0') * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+	return fmt.Sprint((10 - sum%10) % 10)
+}
+
+// mod11 calcula o DV geral do código de barras: pesos de 2 a 9 a partir da direita;
+// restos que resultariam em 0, 10 ou 11 viram 1
+func mod11(value string) string {
+	sum, weight := 0, 2
+	for i := len(value) - 1; i >= 0; i-- {
+		sum += int(value[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+	dv := 11 - sum%11
+	if dv == 0 || dv == 10 || dv == 11 {
+		dv = 1
+	}
+	return fmt.Sprint(dv)
+}
+
+// digits informa se o texto tem apenas algarismos
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,134 @@
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Erros de configuração e emissão
+var (
+	ErrInvalidConfig    = errors.New("configuração de boleto inválida")
+	ErrCurrencyNotBRL   = errors.New("boleto aceita apenas cobranças em BRL")
+	ErrSequenceOverflow = errors.New("sequencial do nosso número excede 10 dígitos")
+)
+
+// Beneficiary é o beneficiário (cedente) impresso no boleto
+type Beneficiary struct {
+	Name     string
+	Document string
+	Agency   string
+	Account  string
+}
+
+// Config são os dados da carteira de cobrança. O campo livre segue o leiaute do Banco
+// do Brasil para convênios de 7 posições: seis zeros, convênio, sequencial de 10
+// dígitos do nosso número e carteira. DueDays é o prazo de vencimento a partir da
+// emissão e GraceDays, os dias após o vencimento em que o pagamento ainda é aguardado
+// (compensação e envio do arquivo de retorno).
+type Config struct {
+	Bank        string
+	Convenio    string
+	Carteira    string
+	Beneficiary Beneficiary
+	DueDays     int
+	GraceDays   int
+}
+
+// Boleto é um boleto emitido
+type Boleto struct {
+	NossoNumero   string
+	Barcode       string
+	DigitableLine string
+	DueDate       time.Time
+	Amount        money.Money
+}
+
+// Enabled informa se há um convênio configurado
+func (c Config) Enabled() bool {
+	return strings.TrimSpace(c.Convenio) != ""
+}
+
+// Validate verifica a configuração da carteira
+func (c Config) Validate() error {
+	switch {
+	case len(c.Bank) != 3 || !digits(c.Bank):
+		return fmt.Errorf("%w: banco %q deve ter 3 dígitos", ErrInvalidConfig, c.Bank)
+	case len(c.Convenio) != 7 || !digits(c.Convenio):
+		return fmt.Errorf("%w: convênio %q deve ter 7 dígitos", ErrInvalidConfig, c.Convenio)
+	case len(c.Carteira) != 2 || !digits(c.Carteira):
+		return fmt.Errorf("%w: carteira %q deve ter 2 dígitos", ErrInvalidConfig, c.Carteira)
+	case strings.TrimSpace(c.Beneficiary.Name) == "":
+		return fmt.Errorf("%w: nome do beneficiário é obrigatório", ErrInvalidConfig)
+	case c.DueDays < 1:
+		return fmt.Errorf("%w: prazo de vencimento deve ser positivo", ErrInvalidConfig)
+	case c.GraceDays < 0:
+		return fmt.Errorf("%w: tolerância após o vencimento não pode ser negativa", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// NossoNumero monta o nosso número de 17 dígitos: convênio e sequencial
+func (c Config) NossoNumero(sequence uint64) (string, error) {
+	if sequence > 9_999_999_999 {
+		return "", fmt.Errorf("%w: %d", ErrSequenceOverflow, sequence)
+	}
+	return fmt.Sprintf("%s%010d", c.Convenio, sequence), nil
+}
+
+// Issue emite o boleto do valor com o sequencial informado, vencendo DueDays dias
+// úteis após now
+func (c Config) Issue(sequence uint64, amount money.Money, now time.Time) (Boleto, error) {
+	if err := c.Validate(); err != nil {
+		return Boleto{}, err
+	}
+	if money.NormalizeCurrency(amount.Currency) != money.DefaultCurrency {
+		return Boleto{}, fmt.Errorf("%w: %s", ErrCurrencyNotBRL, amount.Currency)
+	}
+	if !amount.IsPositive() {
+		return Boleto{}, fmt.Errorf("%w: %s", ErrInvalidAmount, amount)
+	}
+
+	nossoNumero, err := c.NossoNumero(sequence)
+	if err != nil {
+		return Boleto{}, err
+	}
+	due := addBusinessDays(now, c.DueDays)
+	barcode, err := Barcode(c.Bank, due, amount.Amount, "000000"+nossoNumero+c.Carteira)
+	if err != nil {
+		return Boleto{}, err
+	}
+	line, err := DigitableLine(barcode)
+	if err != nil {
+		return Boleto{}, err
+	}
+	return Boleto{
+		NossoNumero:   nossoNumero,
+		Barcode:       barcode,
+		DigitableLine: line,
+		DueDate:       due,
+		Amount:        amount,
+	}, nil
+}
+
+// ExpiresAt é o fim da tolerância após o vencimento: o último instante do dia
+// GraceDays dias úteis após due
+func (c Config) ExpiresAt(due time.Time) time.Time {
+	return addBusinessDays(due, c.GraceDays).AddDate(0, 0, 1)
+}
+
+// addBusinessDays soma dias úteis (segunda a sexta), sem considerar feriados, e
+// devolve a data à meia-noite
+func addBusinessDays(t time.Time, days int) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for days > 0 {
+		day = day.AddDate(0, 0, 1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return day
+}
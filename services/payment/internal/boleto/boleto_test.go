@@ -0,0 +1,231 @@
+package boleto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+var config = Config{
+	Bank:        "001",
+	Convenio:    "1234567",
+	Carteira:    "17",
+	Beneficiary: Beneficiary{Name: "Loja Exemplo Ltda", Document: "12.345.678/0001-90", Agency: "1234-5", Account: "67890-1"},
+	DueDays:     3,
+	GraceDays:   2,
+}
+
+func TestBarcode_MatchesBankExample(t *testing.T) {
+	due := time.Date(2007, 12, 31, 0, 0, 0, 0, time.UTC)
+	barcode, err := Barcode("001", due, 100, "0500940144816060680935031")
+
+	require.NoError(t, err)
+	assert.Equal(t, "00193373700000001000500940144816060680935031", barcode)
+
+	line, err := DigitableLine(barcode)
+	require.NoError(t, err)
+	assert.Equal(t, "00190500954014481606906809350314337370000000100", line)
+	assert.Equal(t, "00190.50095 40144.816069 06809.350314 3 37370000000100", FormatDigitableLine(line))
+}
+
+func TestDueFactor(t *testing.T) {
+	cases := []struct {
+		due    time.Time
+		factor int
+	}{
+		{time.Date(2000, 7, 3, 0, 0, 0, 0, time.UTC), 1000},
+		{time.Date(2007, 12, 31, 15, 30, 0, 0, time.UTC), 3737},
+		{time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC), 9999},
+		{time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC), 1000},
+		{time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 1007},
+	}
+	for _, tc := range cases {
+		factor, err := DueFactor(tc.due)
+		require.NoError(t, err)
+		assert.Equal(t, tc.factor, factor, tc.due.Format(time.DateOnly))
+	}
+
+	_, err := DueFactor(time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrInvalidDueDate)
+}
+
+func TestParseDigitableLine(t *testing.T) {
+	barcode, err := ParseDigitableLine("00190.50095 40144.816069 06809.350314 3 37370000000100")
+	require.NoError(t, err)
+	assert.Equal(t, "00193373700000001000500940144816060680935031", barcode)
+
+	// DV do primeiro campo alterado
+	_, err = ParseDigitableLine("00190500944014481606906809350314337370000000100")
+	assert.ErrorIs(t, err, ErrInvalidDigitableLine)
+
+	// DV geral alterado
+	_, err = ParseDigitableLine("00190500954014481606906809350314237370000000100")
+	assert.ErrorIs(t, err, ErrInvalidDigitableLine)
+
+	_, err = ParseDigitableLine("0019050095")
+	assert.ErrorIs(t, err, ErrInvalidDigitableLine)
+}
+
+func TestVerifyBarcode_RejectsWrongCheckDigit(t *testing.T) {
+	assert.ErrorIs(t, VerifyBarcode("00192373700000001000500940144816060680935031"), ErrInvalidBarcode)
+	assert.ErrorIs(t, VerifyBarcode("0019"), ErrInvalidBarcode)
+}
+
+func TestITF(t *testing.T) {
+	widths, err := ITF("00193373700000001000500940144816060680935031")
+	require.NoError(t, err)
+
+	// início (4) + 22 pares com 10 elementos + fim (3)
+	assert.Len(t, widths, 4+22*10+3)
+	modules := 0
+	for _, w := range widths {
+		modules += w
+	}
+	assert.Equal(t, 405, modules)
+
+	_, err = ITF("123")
+	assert.ErrorIs(t, err, ErrInvalidBarcode)
+}
+
+func TestIssue(t *testing.T) {
+	// sexta-feira: vencimento em 3 dias úteis cai na quarta
+	now := time.Date(2025, 6, 6, 14, 0, 0, 0, time.UTC)
+	b, err := config.Issue(42, money.New(19990, "BRL"), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "12345670000000042", b.NossoNumero)
+	assert.Equal(t, time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC), b.DueDate)
+	assert.Equal(t, "0000019990", b.Barcode[9:19])
+	assert.Equal(t, "000000"+"12345670000000042"+"17", b.Barcode[19:])
+	require.NoError(t, VerifyBarcode(b.Barcode))
+
+	parsed, err := ParseDigitableLine(b.DigitableLine)
+	require.NoError(t, err)
+	assert.Equal(t, b.Barcode, parsed)
+
+	// tolerância de 2 dias úteis: aceita até o fim de sexta-feira
+	assert.Equal(t, time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC), config.ExpiresAt(b.DueDate))
+}
+
+func TestIssue_Errors(t *testing.T) {
+	now := time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)
+
+	_, err := config.Issue(1, money.New(100, "USD"), now)
+	assert.ErrorIs(t, err, ErrCurrencyNotBRL)
+
+	_, err = config.Issue(1, money.New(0, "BRL"), now)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = config.Issue(10_000_000_000, money.New(100, "BRL"), now)
+	assert.ErrorIs(t, err, ErrSequenceOverflow)
+
+	invalid := config
+	invalid.Convenio = "123"
+	_, err = invalid.Issue(1, money.New(100, "BRL"), now)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.True(t, invalid.Enabled())
+	assert.False(t, Config{}.Enabled())
+}
+
+// record monta uma linha CNAB com os campos nas posições informadas (base 1)
+func record(size int, fields map[int]string) string {
+	line := []byte(strings.Repeat(" ", size))
+	for start, value := range fields {
+		copy(line[start-1:], value)
+	}
+	return string(line)
+}
+
+func TestReadReturn_CNAB240(t *testing.T) {
+	content := strings.Join([]string{
+		record(240, map[int]string{1: "001", 8: "0"}),
+		record(240, map[int]string{1: "001", 8: "3", 14: "T", 16: "06", 38: "12345670000000042   ", 82: "000000000019990"}),
+		record(240, map[int]string{1: "001", 8: "3", 14: "U", 16: "06", 78: "000000000019990", 138: "12062025"}),
+		record(240, map[int]string{1: "001", 8: "3", 14: "T", 16: "02", 38: "12345670000000043   ", 82: "000000000005000"}),
+		record(240, map[int]string{1: "001", 8: "3", 14: "U", 16: "02", 78: "000000000000000", 138: "00000000"}),
+		record(240, map[int]string{1: "001", 8: "9"}),
+	}, "\r\n")
+
+	file, err := ReadReturn(strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, FormatCNAB240, file.Format)
+	require.Len(t, file.Records, 2)
+
+	paid := file.Records[0]
+	assert.Equal(t, "12345670000000042", paid.NossoNumero)
+	assert.True(t, paid.Paid())
+	assert.Equal(t, int64(19990), paid.Amount)
+	assert.Equal(t, int64(19990), paid.PaidAmount)
+	assert.Equal(t, time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC), paid.PaidAt)
+	assert.Equal(t, 2, paid.Line)
+
+	entry := file.Records[1]
+	assert.Equal(t, "02", entry.Occurrence)
+	assert.False(t, entry.Paid())
+	assert.True(t, entry.PaidAt.IsZero())
+}
+
+func TestReadReturn_CNAB400(t *testing.T) {
+	content := strings.Join([]string{
+		record(400, map[int]string{1: "0", 2: "2RETORNO"}),
+		record(400, map[int]string{1: "7", 64: "12345670000000042", 109: "06", 111: "120625", 153: "0000000019990", 254: "0000000019500"}),
+		record(400, map[int]string{1: "9"}),
+	}, "\n")
+
+	file, err := ReadReturn(strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, FormatCNAB400, file.Format)
+	require.Len(t, file.Records, 1)
+	assert.Equal(t, ReturnRecord{
+		Line:        2,
+		NossoNumero: "12345670000000042",
+		Occurrence:  "06",
+		Amount:      19990,
+		PaidAmount:  19500,
+		PaidAt:      time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC),
+	}, file.Records[0])
+}
+
+func TestReadReturn_Invalid(t *testing.T) {
+	_, err := ReadReturn(strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrInvalidReturnFile)
+
+	_, err = ReadReturn(strings.NewReader("linha curta\n"))
+	assert.ErrorIs(t, err, ErrInvalidReturnFile)
+
+	orphan := record(240, map[int]string{8: "3", 14: "U", 78: "000000000019990", 138: "12062025"})
+	_, err = ReadReturn(strings.NewReader(orphan))
+	assert.ErrorIs(t, err, ErrInvalidReturnFile)
+
+	badAmount := record(400, map[int]string{1: "7", 64: "12345670000000042", 109: "06", 153: "00000000ABCDE", 254: "0000000000000"})
+	_, err = ReadReturn(strings.NewReader(badAmount))
+	assert.ErrorIs(t, err, ErrInvalidReturnFile)
+}
+
+func TestRenderPDF(t *testing.T) {
+	b, err := config.Issue(42, money.New(19990, "BRL"), time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	pdf, err := RenderPDF(b, config, Document{
+		Number:       "42",
+		Payer:        "João da Silva",
+		IssuedAt:     time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC),
+		Instructions: []string{"Não receber após 13/06/2025"},
+	})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+}
+
+func TestFormatting(t *testing.T) {
+	assert.Equal(t, "001-9", bankLabel("001"))
+	assert.Equal(t, "237-2", bankLabel("237"))
+	assert.Equal(t, "1.999,90", formatAmount(199990))
+	assert.Equal(t, "0,05", formatAmount(5))
+	assert.Equal(t, "1.234.567,00", formatAmount(123456700))
+}
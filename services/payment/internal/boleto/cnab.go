@@ -0,0 +1,187 @@
+package boleto
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatos de arquivo de retorno
+const (
+	FormatCNAB240 = "CNAB240"
+	FormatCNAB400 = "CNAB400"
+)
+
+// ErrInvalidReturnFile indica um arquivo de retorno ilegível
+var ErrInvalidReturnFile = errors.New("arquivo de retorno CNAB inválido")
+
+// Ocorrências de liquidação: 06 (liquidação normal) e 17 (liquidação após baixa)
+var paidOccurrences = map[string]bool{"06": true, "17": true}
+
+// ReturnRecord é um título informado no arquivo de retorno. Amount é o valor do título
+// e PaidAmount o valor recebido, ambos em centavos; PaidAt é a data da ocorrência.
+type ReturnRecord struct {
+	Line        int
+	NossoNumero string
+	Occurrence  string
+	Amount      int64
+	PaidAmount  int64
+	PaidAt      time.Time
+}
+
+// Paid informa se a ocorrência é uma liquidação do título
+func (r ReturnRecord) Paid() bool {
+	return paidOccurrences[r.Occurrence]
+}
+
+// ReturnFile é o conteúdo de um arquivo de retorno
+type ReturnFile struct {
+	Format  string
+	Records []ReturnRecord
+}
+
+// ReadReturn lê um arquivo de retorno de cobrança, identificando o formato pelo
+// tamanho das linhas: CNAB 240 (FEBRABAN, segmentos T e U) ou CNAB 400 (leiaute do
+// Banco do Brasil para convênios de 7 posições, registro de detalhe 7)
+func ReadReturn(r io.Reader) (ReturnFile, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ReturnFile{}, fmt.Errorf("%w: %v", ErrInvalidReturnFile, err)
+	}
+	if len(lines) == 0 {
+		return ReturnFile{}, fmt.Errorf("%w: arquivo vazio", ErrInvalidReturnFile)
+	}
+
+	switch len(lines[0]) {
+	case 240:
+		return readCNAB240(lines)
+	case 400:
+		return readCNAB400(lines)
+	default:
+		return ReturnFile{}, fmt.Errorf("%w: linhas com %d posições (esperado 240 ou 400)", ErrInvalidReturnFile, len(lines[0]))
+	}
+}
+
+// readCNAB240 lê os segmentos T (título) e U (valores pagos) dos registros de detalhe
+func readCNAB240(lines []string) (ReturnFile, error) {
+	file := ReturnFile{Format: FormatCNAB240}
+	var pending *ReturnRecord
+	for i, line := range lines {
+		number := i + 1
+		if len(line) != 240 {
+			return ReturnFile{}, fmt.Errorf("%w: linha %d com %d posições", ErrInvalidReturnFile, number, len(line))
+		}
+		if field(line, 8, 8) != "3" {
+			continue
+		}
+
+		switch field(line, 14, 14) {
+		case "T":
+			amount, err := cents(line, 82, 96)
+			if err != nil {
+				return ReturnFile{}, lineError(number, err)
+			}
+			file.Records = append(file.Records, ReturnRecord{
+				Line:        number,
+				NossoNumero: strings.TrimSpace(field(line, 38, 57)),
+				Occurrence:  field(line, 16, 17),
+				Amount:      amount,
+			})
+			pending = &file.Records[len(file.Records)-1]
+		case "U":
+			if pending == nil {
+				return ReturnFile{}, lineError(number, errors.New("segmento U sem segmento T"))
+			}
+			paid, err := cents(line, 78, 92)
+			if err != nil {
+				return ReturnFile{}, lineError(number, err)
+			}
+			paidAt, err := date(field(line, 138, 145), "02012006")
+			if err != nil {
+				return ReturnFile{}, lineError(number, err)
+			}
+			pending.PaidAmount, pending.PaidAt = paid, paidAt
+			pending = nil
+		}
+	}
+	return file, nil
+}
+
+// readCNAB400 lê os registros de detalhe (tipo 7) do retorno
+func readCNAB400(lines []string) (ReturnFile, error) {
+	file := ReturnFile{Format: FormatCNAB400}
+	for i, line := range lines {
+		number := i + 1
+		if len(line) != 400 {
+			return ReturnFile{}, fmt.Errorf("%w: linha %d com %d posições", ErrInvalidReturnFile, number, len(line))
+		}
+		if field(line, 1, 1) != "7" {
+			continue
+		}
+
+		amount, err := cents(line, 153, 165)
+		if err != nil {
+			return ReturnFile{}, lineError(number, err)
+		}
+		paid, err := cents(line, 254, 266)
+		if err != nil {
+			return ReturnFile{}, lineError(number, err)
+		}
+		paidAt, err := date(field(line, 111, 116), "020106")
+		if err != nil {
+			return ReturnFile{}, lineError(number, err)
+		}
+		file.Records = append(file.Records, ReturnRecord{
+			Line:        number,
+			NossoNumero: strings.TrimSpace(field(line, 64, 80)),
+			Occurrence:  field(line, 109, 110),
+			Amount:      amount,
+			PaidAmount:  paid,
+			PaidAt:      paidAt,
+		})
+	}
+	return file, nil
+}
+
+// field retorna as posições de start a end (base 1, inclusivas), como nos leiautes CNAB
+func field(line string, start, end int) string {
+	return line[start-1 : end]
+}
+
+// cents lê um valor numérico com duas casas decimais implícitas
+func cents(line string, start, end int) (int64, error) {
+	raw := field(line, start, end)
+	value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("valor inválido %q nas posições %d-%d", raw, start, end)
+	}
+	return value, nil
+}
+
+// date lê uma data no leiaute informado; zeros indicam data ausente
+func date(raw, layout string) (time.Time, error) {
+	if strings.Trim(raw, "0 ") == "" {
+		return time.Time{}, nil
+	}
+	value, err := time.ParseInLocation(layout, raw, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("data inválida %q", raw)
+	}
+	return value, nil
+}
+
+// lineError identifica a linha com erro
+func lineError(number int, err error) error {
+	return fmt.Errorf("%w: linha %d: %v", ErrInvalidReturnFile, number, err)
+}
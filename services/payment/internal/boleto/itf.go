@@ -0,0 +1,42 @@
+package boleto
+
+import "fmt"
+
+// Larguras das barras do ITF, em módulos: a barra larga tem três vezes a estreita
+const (
+	narrow = 1
+	wide   = 3
+)
+
+// itfPatterns são as larguras das cinco barras de cada algarismo (n = estreita, w = larga)
+var itfPatterns = [10]string{
+	"nnwwn", "wnnnw", "nwnnw", "wwnnn", "nnwnw",
+	"wnwnn", "nwwnn", "nnnww", "wnnwn", "nwnwn",
+}
+
+// ITF codifica os dígitos no Intercalado 2 de 5 usado no código de barras do boleto.
+// Retorna as larguras, em módulos, alternando barra e espaço a partir de uma barra:
+// início (quatro estreitas), os pares de algarismos (o primeiro nas barras, o segundo
+// nos espaços) e fim (larga, estreita, estreita).
+func ITF(value string) ([]int, error) {
+	if len(value)%2 != 0 || !digits(value) {
+		return nil, fmt.Errorf("%w: o ITF exige uma quantidade par de dígitos", ErrInvalidBarcode)
+	}
+
+	widths := []int{narrow, narrow, narrow, narrow}
+	for i := 0; i < len(value); i += 2 {
+		bars, spaces := itfPatterns[value[i]-'0'], itfPatterns[value[i+1]-'0']
+		for j := 0; j < 5; j++ {
+			widths = append(widths, width(bars[j]), width(spaces[j]))
+		}
+	}
+	return append(widths, wide, narrow, narrow), nil
+}
+
+// width converte o símbolo do padrão em largura
+func width(symbol byte) int {
+	if symbol == 'w' {
+		return wide
+	}
+	return narrow
+}
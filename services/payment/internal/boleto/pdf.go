@@ -0,0 +1,166 @@
+package boleto
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Dimensões do código de barras na ficha de compensação (FEBRABAN): 103 mm de
+// comprimento e 13 mm de altura para os 405 módulos do ITF de 44 dígitos
+const (
+	barcodeWidth  = 103.0
+	barcodeHeight = 13.0
+)
+
+// Document são os dados do título impressos no boleto
+type Document struct {
+	Number       string
+	Payer        string
+	IssuedAt     time.Time
+	Instructions []string
+}
+
+// RenderPDF gera o boleto em PDF (A4): recibo do pagador e ficha de compensação com a
+// linha digitável e o código de barras ITF
+func RenderPDF(b Boleto, cfg Config, doc Document) ([]byte, error) {
+	bars, err := ITF(b.Barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Boleto "+b.NossoNumero, true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	bank := bankLabel(cfg.Bank)
+	line := FormatDigitableLine(b.DigitableLine)
+	due := b.DueDate.Format("02/01/2006")
+	amount := formatAmount(b.Amount.Amount)
+	beneficiary := strings.TrimSpace(cfg.Beneficiary.Name + " - " + cfg.Beneficiary.Document)
+	agency := strings.Trim(cfg.Beneficiary.Agency+" / "+cfg.Beneficiary.Account, " /")
+
+	// Recibo do pagador
+	header(pdf, tr, bank, "Recibo do Pagador")
+	row(pdf, tr, []cell{{"Beneficiário", beneficiary, 120}, {"Vencimento", due, 60}})
+	row(pdf, tr, []cell{{"Pagador", doc.Payer, 120}, {"Nosso número", b.NossoNumero, 60}})
+	row(pdf, tr, []cell{{"Número do documento", doc.Number, 60}, {"Agência / Código do beneficiário", agency, 60}, {"Valor do documento", amount, 60}})
+	pdf.Ln(6)
+	pdf.SetDashPattern([]float64{1, 1}, 0)
+	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
+	pdf.SetDashPattern(nil, 0)
+	pdf.Ln(6)
+
+	// Ficha de compensação
+	header(pdf, tr, bank, line)
+	row(pdf, tr, []cell{{"Local de pagamento", "Pagável em qualquer banco até o vencimento", 130}, {"Vencimento", due, 50}})
+	row(pdf, tr, []cell{{"Beneficiário", beneficiary, 130}, {"Agência / Código do beneficiário", agency, 50}})
+	row(pdf, tr, []cell{
+		{"Data do documento", doc.IssuedAt.Format("02/01/2006"), 35},
+		{"Número do documento", doc.Number, 35},
+		{"Espécie", "DM", 20},
+		{"Carteira", cfg.Carteira, 20},
+		{"Moeda", "R$", 20},
+		{"Nosso número", b.NossoNumero, 50},
+	})
+	instructions := strings.Join(doc.Instructions, "\n")
+	pdf.SetFont("Helvetica", "", 6)
+	x, y := pdf.GetXY()
+	pdf.CellFormat(130, 4, tr("Instruções (texto de responsabilidade do beneficiário)"), "LTR", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.MultiCell(130, 4, tr(instructions), "LR", "L", false)
+	pdf.SetXY(x+130, y)
+	pdf.SetFont("Helvetica", "", 6)
+	pdf.CellFormat(50, 4, tr("(=) Valor do documento"), "LTR", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(50, 5, amount, "LR", 2, "R", false, 0, "")
+	pdf.SetXY(x, max(pdf.GetY(), y+4+4*float64(max(len(doc.Instructions), 1))))
+	row(pdf, tr, []cell{{"Pagador", doc.Payer, 180}})
+	pdf.Ln(4)
+
+	// Código de barras ITF
+	modules := 0
+	for _, width := range bars {
+		modules += width
+	}
+	module := barcodeWidth / float64(modules)
+	x, y = 15.0, pdf.GetY()
+	for i, width := range bars {
+		if i%2 == 0 {
+			pdf.Rect(x, y, module*float64(width), barcodeHeight, "F")
+		}
+		x += module * float64(width)
+	}
+	pdf.SetXY(15, y+barcodeHeight+2)
+	pdf.SetFont("Helvetica", "", 6)
+	pdf.CellFormat(180, 4, tr("Autenticação mecânica - Ficha de Compensação"), "", 1, "R", false, 0, "")
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, fmt.Errorf("falha ao gerar PDF do boleto: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// cell é um campo do boleto: rótulo, valor e largura em mm
+type cell struct {
+	label string
+	value string
+	width float64
+}
+
+// header imprime o cabeçalho com o código do banco e o texto à direita
+func header(pdf *gofpdf.Fpdf, tr func(string) string, bank, text string) {
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(30, 8, bank, "B", 0, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(150, 8, tr(text), "B", 1, "R", false, 0, "")
+}
+
+// row imprime uma linha de campos com rótulo pequeno e valor
+func row(pdf *gofpdf.Fpdf, tr func(string) string, cells []cell) {
+	x, y := pdf.GetXY()
+	for _, c := range cells {
+		pdf.SetXY(x, y)
+		pdf.SetFont("Helvetica", "", 6)
+		pdf.CellFormat(c.width, 4, tr(c.label), "LTR", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(c.width, 5, tr(c.value), "LBR", 0, "L", false, 0, "")
+		x += c.width
+	}
+	pdf.SetXY(15, y+9)
+}
+
+// bankLabel formata o código do banco com o dígito verificador (ex.: 001-9)
+func bankLabel(bank string) string {
+	sum, weight := 0, 2
+	for i := len(bank) - 1; i >= 0; i-- {
+		sum += int(bank[i]-'0') * weight
+		weight++
+	}
+	dv := 11 - sum%11
+	switch dv {
+	case 10:
+		return bank + "-X"
+	case 11:
+		return bank + "-0"
+	}
+	return fmt.Sprintf("%s-%d", bank, dv)
+}
+
+// formatAmount formata centavos no padrão brasileiro (ex.: 1.999,90)
+func formatAmount(cents int64) string {
+	whole := fmt.Sprint(cents / 100)
+	var groups []string
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+	return fmt.Sprintf("%s,%02d", strings.Join(groups, "."), cents%100)
+}
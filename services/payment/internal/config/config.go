@@ -1,7 +1,19 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/boleto"
+	"payment-service/internal/dispute"
+	"payment-service/internal/fraud"
+	"payment-service/internal/gateway"
+	"payment-service/internal/installment"
+	"payment-service/internal/pix"
 )
 
 // Config armazena as configurações da aplicação
@@ -19,9 +31,87 @@ type Config struct {
 	RabbitMQUser     string
 	RabbitMQPassword string
 	RabbitMQQueue    string
+	// OrderPaymentsQueue recebe os resultados de pagamento destinados ao order-service
+	OrderPaymentsQueue string
 
 	// Server
 	GRPCPort string
+
+	// Autenticação
+	JWTSecret string
+	JWTIssuer string
+
+	// Câmbio: FXProvider é a fonte das cotações (file ou db) e FXRatesFile o arquivo CSV
+	// lido pelo provider file e importado para a tabela fx_rates pelo provider db
+	FXProvider  string
+	FXRatesFile string
+
+	// Parcelamento: regras do lojista (ver InstallmentRules)
+	InstallmentMax          string
+	InstallmentInterestFree string
+	InstallmentMonthlyRate  string
+	InstallmentMinAmount    string
+	InstallmentCurrency     string
+
+	// WebhookPort é a porta HTTP dos webhooks (PIX e provedor de pagamento)
+	WebhookPort string
+
+	// PIX: recebedor e geração das cobranças (ver PixConfig), prazo de pagamento,
+	// intervalo do expirador e segredo do webhook de confirmação do PSP
+	PixKey                 string
+	PixMerchantName        string
+	PixMerchantCity        string
+	PixMode                string
+	PixLocationURL         string
+	PixExpiry              time.Duration
+	PixExpirySweepInterval time.Duration
+	PixWebhookSecret       string
+
+	// Boleto: carteira de cobrança (ver BoletoConfig), prazos e intervalo da baixa dos
+	// boletos vencidos
+	BoletoBank                 string
+	BoletoConvenio             string
+	BoletoCarteira             string
+	BoletoAgency               string
+	BoletoAccount              string
+	BoletoBeneficiaryName      string
+	BoletoBeneficiaryDocument  string
+	BoletoDueDays              string
+	BoletoGraceDays            string
+	BoletoOverdueSweepInterval time.Duration
+
+	// Provedor de pagamento com cartão (ver GatewayConfig) e verificação dos webhooks
+	GatewayProvider         string
+	GatewayWebhookSecret    string
+	GatewayWebhookTolerance time.Duration
+
+	// Antifraude: regras (ver FraudRules) e arquivo das listas de permissão e bloqueio
+	FraudEnabled              string
+	FraudCurrency             string
+	FraudAmountThresholds     string
+	FraudVelocityWindow       time.Duration
+	FraudMaxCustomerPayments  string
+	FraudMaxCardPayments      string
+	FraudVelocityScore        string
+	FraudNewAccountAge        time.Duration
+	FraudNewAccountScore      string
+	FraudBillingMismatchScore string
+	FraudHomeCountry          string
+	FraudIPCountryScore       string
+	FraudReviewScore          string
+	FraudDenyScore            string
+	FraudListsFile            string
+
+	// Contestações: prazo padrão da defesa, antecedência do alerta de prazo e tamanho
+	// máximo de cada evidência (abaixo do limite de 4 MiB das mensagens gRPC)
+	DisputeEvidenceWindow  time.Duration
+	DisputeWarningWindow   time.Duration
+	DisputeMaxEvidenceSize string
+
+	// Conciliação de liquidações: pasta de entrada dos arquivos do provedor (vazia
+	// desliga a varredura) e intervalo entre as varreduras
+	SettlementInboxDir      string
+	SettlementSweepInterval time.Duration
 }
 
 // LoadConfig carrega as configurações das variáveis de ambiente
@@ -35,15 +125,266 @@ func LoadConfig() *Config {
 		DBName:     getEnv("DB_NAME", "order_service"),
 
 		// RabbitMQ
-		RabbitMQHost:     getEnv("RABBITMQ_HOST", "localhost"),
-		RabbitMQPort:     getEnv("RABBITMQ_PORT", "5672"),
-		RabbitMQUser:     getEnv("RABBITMQ_USER", "guest"),
-		RabbitMQPassword: getEnv("RABBITMQ_PASSWORD", "guest"),
-		RabbitMQQueue:    getEnv("RABBITMQ_QUEUE", "orders"),
+		RabbitMQHost:       getEnv("RABBITMQ_HOST", "localhost"),
+		RabbitMQPort:       getEnv("RABBITMQ_PORT", "5672"),
+		RabbitMQUser:       getEnv("RABBITMQ_USER", "guest"),
+		RabbitMQPassword:   getEnv("RABBITMQ_PASSWORD", "guest"),
+		RabbitMQQueue:      getEnv("RABBITMQ_QUEUE", "orders"),
+		OrderPaymentsQueue: getEnv("ORDER_PAYMENTS_QUEUE", "order_payments"),
 
 		// Server
 		GRPCPort: getEnv("GRPC_PORT", "50053"),
+
+		// Autenticação
+		JWTSecret: getEnv("JWT_SECRET", ""),
+		JWTIssuer: getEnv("JWT_ISSUER", "user-service"),
+
+		// Câmbio
+		FXProvider:  getEnv("FX_PROVIDER", "file"),
+		FXRatesFile: getEnv("FX_RATES_FILE", "config/fx_rates.csv"),
+
+		// Parcelamento
+		InstallmentMax:          getEnv("INSTALLMENT_MAX", "12"),
+		InstallmentInterestFree: getEnv("INSTALLMENT_MAX_INTEREST_FREE", "3"),
+		InstallmentMonthlyRate:  getEnv("INSTALLMENT_MONTHLY_RATE", "1.99"),
+		InstallmentMinAmount:    getEnv("INSTALLMENT_MIN_AMOUNT", "5.00"),
+		InstallmentCurrency:     getEnv("INSTALLMENT_CURRENCY", "BRL"),
+
+		// Webhooks; PIX_WEBHOOK_PORT é o nome anterior da variável
+		WebhookPort: getEnv("WEBHOOK_PORT", getEnv("PIX_WEBHOOK_PORT", "8083")),
+
+		// PIX
+		PixKey:                 getEnv("PIX_KEY", ""),
+		PixMerchantName:        getEnv("PIX_MERCHANT_NAME", "Go Microservices"),
+		PixMerchantCity:        getEnv("PIX_MERCHANT_CITY", "Sao Paulo"),
+		PixMode:                getEnv("PIX_MODE", pix.KindDynamic),
+		PixLocationURL:         getEnv("PIX_LOCATION_URL", ""),
+		PixExpiry:              getEnvAsDuration("PIX_EXPIRY", 15*time.Minute),
+		PixExpirySweepInterval: getEnvAsDuration("PIX_EXPIRY_SWEEP_INTERVAL", time.Minute),
+		PixWebhookSecret:       getEnv("PIX_WEBHOOK_SECRET", ""),
+
+		// Boleto
+		BoletoBank:                 getEnv("BOLETO_BANK", "001"),
+		BoletoConvenio:             getEnv("BOLETO_CONVENIO", ""),
+		BoletoCarteira:             getEnv("BOLETO_CARTEIRA", "17"),
+		BoletoAgency:               getEnv("BOLETO_AGENCY", ""),
+		BoletoAccount:              getEnv("BOLETO_ACCOUNT", ""),
+		BoletoBeneficiaryName:      getEnv("BOLETO_BENEFICIARY_NAME", "Go Microservices"),
+		BoletoBeneficiaryDocument:  getEnv("BOLETO_BENEFICIARY_DOCUMENT", ""),
+		BoletoDueDays:              getEnv("BOLETO_DUE_DAYS", "3"),
+		BoletoGraceDays:            getEnv("BOLETO_GRACE_DAYS", "3"),
+		BoletoOverdueSweepInterval: getEnvAsDuration("BOLETO_OVERDUE_SWEEP_INTERVAL", time.Hour),
+
+		// Provedor de pagamento
+		GatewayProvider:         getEnv("GATEWAY_PROVIDER", gateway.ProviderSimulated),
+		GatewayWebhookSecret:    getEnv("GATEWAY_WEBHOOK_SECRET", ""),
+		GatewayWebhookTolerance: getEnvAsDuration("GATEWAY_WEBHOOK_TOLERANCE", gateway.DefaultTolerance),
+
+		// Antifraude
+		FraudEnabled:              getEnv("FRAUD_ENABLED", "true"),
+		FraudCurrency:             getEnv("FRAUD_CURRENCY", "BRL"),
+		FraudAmountThresholds:     getEnv("FRAUD_AMOUNT_THRESHOLDS", "5000.00:30,20000.00:60"),
+		FraudVelocityWindow:       getEnvAsDuration("FRAUD_VELOCITY_WINDOW", time.Hour),
+		FraudMaxCustomerPayments:  getEnv("FRAUD_MAX_CUSTOMER_PAYMENTS", "5"),
+		FraudMaxCardPayments:      getEnv("FRAUD_MAX_CARD_PAYMENTS", "3"),
+		FraudVelocityScore:        getEnv("FRAUD_VELOCITY_SCORE", "40"),
+		FraudNewAccountAge:        getEnvAsDuration("FRAUD_NEW_ACCOUNT_AGE", 24*time.Hour),
+		FraudNewAccountScore:      getEnv("FRAUD_NEW_ACCOUNT_SCORE", "20"),
+		FraudBillingMismatchScore: getEnv("FRAUD_BILLING_MISMATCH_SCORE", "20"),
+		FraudHomeCountry:          getEnv("FRAUD_HOME_COUNTRY", "BR"),
+		FraudIPCountryScore:       getEnv("FRAUD_IP_COUNTRY_SCORE", "30"),
+		FraudReviewScore:          getEnv("FRAUD_REVIEW_SCORE", "60"),
+		FraudDenyScore:            getEnv("FRAUD_DENY_SCORE", "90"),
+		FraudListsFile:            getEnv("FRAUD_LISTS_FILE", "config/fraud_lists.csv"),
+
+		DisputeEvidenceWindow:  getEnvAsDuration("DISPUTE_EVIDENCE_WINDOW", 7*24*time.Hour),
+		DisputeWarningWindow:   getEnvAsDuration("DISPUTE_WARNING_WINDOW", 72*time.Hour),
+		DisputeMaxEvidenceSize: getEnv("DISPUTE_MAX_EVIDENCE_SIZE", "3145728"),
+
+		SettlementInboxDir:      getEnv("SETTLEMENT_INBOX_DIR", ""),
+		SettlementSweepInterval: getEnvAsDuration("SETTLEMENT_SWEEP_INTERVAL", time.Hour),
+	}
+}
+
+// PixConfig monta os dados do recebedor PIX. Sem PIX_KEY o PIX fica desabilitado e
+// pagamentos PIX são recusados; no modo dynamic, PIX_LOCATION_URL é a base da URL das
+// cobranças no PSP (ex.: pix.example.com/qr/v2)
+func (c *Config) PixConfig() (pix.Config, error) {
+	cfg := pix.Config{
+		Merchant:    pix.Merchant{Key: c.PixKey, Name: c.PixMerchantName, City: c.PixMerchantCity},
+		Mode:        c.PixMode,
+		LocationURL: c.PixLocationURL,
+		Expiry:      c.PixExpiry,
+	}
+	if !cfg.Enabled() {
+		return cfg, nil
 	}
+	if err := cfg.Validate(); err != nil {
+		return pix.Config{}, err
+	}
+	return cfg, nil
+}
+
+// GatewayConfig monta o provedor dos pagamentos com cartão. GATEWAY_PROVIDER=simulated
+// (padrão) aprova ou recusa na hora; com outro provedor (ex.: stub) o pagamento fica
+// pendente até o webhook, que exige GATEWAY_WEBHOOK_SECRET
+func (c *Config) GatewayConfig() (gateway.Config, error) {
+	cfg := gateway.Config{Provider: strings.ToLower(strings.TrimSpace(c.GatewayProvider))}
+	if len(cfg.Provider) > 20 {
+		return gateway.Config{}, fmt.Errorf("GATEWAY_PROVIDER inválido: %q", c.GatewayProvider)
+	}
+	if cfg.Async() && c.GatewayWebhookSecret == "" {
+		return gateway.Config{}, fmt.Errorf("GATEWAY_WEBHOOK_SECRET é obrigatório com GATEWAY_PROVIDER=%s", cfg.Provider)
+	}
+	if c.GatewayWebhookTolerance <= 0 {
+		return gateway.Config{}, fmt.Errorf("GATEWAY_WEBHOOK_TOLERANCE inválido: %s", c.GatewayWebhookTolerance)
+	}
+	return cfg, nil
+}
+
+// BoletoConfig monta a carteira de cobrança dos boletos. Sem BOLETO_CONVENIO o boleto
+// fica desabilitado e pagamentos com boleto são recusados; BOLETO_DUE_DAYS é o prazo de
+// vencimento em dias úteis e BOLETO_GRACE_DAYS, os dias úteis após o vencimento em que
+// a liquidação ainda é aguardada antes da baixa
+func (c *Config) BoletoConfig() (boleto.Config, error) {
+	dueDays, err := strconv.Atoi(c.BoletoDueDays)
+	if err != nil {
+		return boleto.Config{}, fmt.Errorf("BOLETO_DUE_DAYS inválido: %q", c.BoletoDueDays)
+	}
+	graceDays, err := strconv.Atoi(c.BoletoGraceDays)
+	if err != nil {
+		return boleto.Config{}, fmt.Errorf("BOLETO_GRACE_DAYS inválido: %q", c.BoletoGraceDays)
+	}
+	cfg := boleto.Config{
+		Bank:     c.BoletoBank,
+		Convenio: c.BoletoConvenio,
+		Carteira: c.BoletoCarteira,
+		Beneficiary: boleto.Beneficiary{
+			Name:     c.BoletoBeneficiaryName,
+			Document: c.BoletoBeneficiaryDocument,
+			Agency:   c.BoletoAgency,
+			Account:  c.BoletoAccount,
+		},
+		DueDays:   dueDays,
+		GraceDays: graceDays,
+	}
+	if !cfg.Enabled() {
+		return cfg, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return boleto.Config{}, err
+	}
+	return cfg, nil
+}
+
+// InstallmentRules monta as regras de parcelamento: até INSTALLMENT_MAX parcelas, as
+// primeiras INSTALLMENT_MAX_INTEREST_FREE sem juros, INSTALLMENT_MONTHLY_RATE % a.m.
+// acima disso e parcela mínima de INSTALLMENT_MIN_AMOUNT (ex.: 5.00) na moeda
+// INSTALLMENT_CURRENCY
+func (c *Config) InstallmentRules() (installment.Rules, error) {
+	maxInstallments, err := strconv.Atoi(c.InstallmentMax)
+	if err != nil || maxInstallments < 1 {
+		return installment.Rules{}, fmt.Errorf("INSTALLMENT_MAX inválido: %q", c.InstallmentMax)
+	}
+	interestFree, err := strconv.Atoi(c.InstallmentInterestFree)
+	if err != nil || interestFree < 1 || interestFree > maxInstallments {
+		return installment.Rules{}, fmt.Errorf("INSTALLMENT_MAX_INTEREST_FREE inválido: %q", c.InstallmentInterestFree)
+	}
+	rate, err := strconv.ParseFloat(c.InstallmentMonthlyRate, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		return installment.Rules{}, fmt.Errorf("INSTALLMENT_MONTHLY_RATE inválido: %q", c.InstallmentMonthlyRate)
+	}
+	minAmount, err := money.Parse(c.InstallmentMinAmount, c.InstallmentCurrency)
+	if err != nil || minAmount.Amount < 0 {
+		return installment.Rules{}, fmt.Errorf("INSTALLMENT_MIN_AMOUNT inválido: %q", c.InstallmentMinAmount)
+	}
+	return installment.Rules{
+		MaxInstallments: maxInstallments,
+		MaxInterestFree: interestFree,
+		MonthlyRate:     rate,
+		MinInstallment:  minAmount.Amount,
+		Currency:        minAmount.Currency,
+	}, nil
+}
+
+// FraudRules monta as regras antifraude. Com FRAUD_ENABLED=false retorna enabled false
+// e nenhum pagamento é analisado. FRAUD_AMOUNT_THRESHOLDS são faixas valor:score em
+// FRAUD_CURRENCY (ex.: 5000.00:30,20000.00:60); os scores vão de 0 a 100 e zero
+// desliga a regra. Com o score em FRAUD_REVIEW_SCORE o pagamento fica retido para
+// análise e em FRAUD_DENY_SCORE, é recusado
+func (c *Config) FraudRules() (fraud.Rules, bool, error) {
+	enabled, err := strconv.ParseBool(c.FraudEnabled)
+	if err != nil {
+		return fraud.Rules{}, false, fmt.Errorf("FRAUD_ENABLED inválido: %q", c.FraudEnabled)
+	}
+	if !enabled {
+		return fraud.Rules{}, false, nil
+	}
+
+	rules := fraud.Rules{
+		Currency:       money.NormalizeCurrency(c.FraudCurrency),
+		VelocityWindow: c.FraudVelocityWindow,
+		NewAccountAge:  c.FraudNewAccountAge,
+		HomeCountry:    strings.ToUpper(strings.TrimSpace(c.FraudHomeCountry)),
+	}
+	for _, field := range strings.Split(c.FraudAmountThresholds, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		value, score, ok := strings.Cut(field, ":")
+		amount, err := money.Parse(strings.TrimSpace(value), rules.Currency)
+		points, scoreErr := strconv.Atoi(strings.TrimSpace(score))
+		if !ok || err != nil || scoreErr != nil {
+			return fraud.Rules{}, false, fmt.Errorf("FRAUD_AMOUNT_THRESHOLDS inválido: %q", c.FraudAmountThresholds)
+		}
+		rules.AmountThresholds = append(rules.AmountThresholds, fraud.Threshold{Amount: amount.Amount, Score: points})
+	}
+
+	integers := []struct {
+		name  string
+		value string
+		dest  *int
+	}{
+		{"FRAUD_MAX_CUSTOMER_PAYMENTS", c.FraudMaxCustomerPayments, &rules.MaxCustomerPayments},
+		{"FRAUD_MAX_CARD_PAYMENTS", c.FraudMaxCardPayments, &rules.MaxCardPayments},
+		{"FRAUD_VELOCITY_SCORE", c.FraudVelocityScore, &rules.VelocityScore},
+		{"FRAUD_NEW_ACCOUNT_SCORE", c.FraudNewAccountScore, &rules.NewAccountScore},
+		{"FRAUD_BILLING_MISMATCH_SCORE", c.FraudBillingMismatchScore, &rules.BillingMismatchScore},
+		{"FRAUD_IP_COUNTRY_SCORE", c.FraudIPCountryScore, &rules.IPCountryScore},
+		{"FRAUD_REVIEW_SCORE", c.FraudReviewScore, &rules.ReviewScore},
+		{"FRAUD_DENY_SCORE", c.FraudDenyScore, &rules.DenyScore},
+	}
+	for _, field := range integers {
+		value, err := strconv.Atoi(strings.TrimSpace(field.value))
+		if err != nil {
+			return fraud.Rules{}, false, fmt.Errorf("%s inválido: %q", field.name, field.value)
+		}
+		*field.dest = value
+	}
+
+	if err := rules.Validate(); err != nil {
+		return fraud.Rules{}, false, err
+	}
+	return rules, true, nil
+}
+
+// DisputeConfig monta as regras das contestações. DISPUTE_EVIDENCE_WINDOW vale quando o
+// adquirente não informa o prazo da defesa e DISPUTE_WARNING_WINDOW é a antecedência
+// padrão da consulta de contestações próximas do prazo
+func (c *Config) DisputeConfig() (dispute.Config, error) {
+	size, err := strconv.ParseInt(strings.TrimSpace(c.DisputeMaxEvidenceSize), 10, 64)
+	if err != nil {
+		return dispute.Config{}, fmt.Errorf("DISPUTE_MAX_EVIDENCE_SIZE inválido: %q", c.DisputeMaxEvidenceSize)
+	}
+	cfg := dispute.Config{
+		EvidenceWindow:  c.DisputeEvidenceWindow,
+		WarningWindow:   c.DisputeWarningWindow,
+		MaxEvidenceSize: size,
+	}
+	if err := cfg.Validate(); err != nil {
+		return dispute.Config{}, err
+	}
+	return cfg, nil
 }
 
 // getEnv obtém uma variável de ambiente ou retorna um valor padrão
@@ -53,3 +394,13 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvAsDuration obtém uma duração (ex.: 15m) ou retorna o valor padrão
+func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
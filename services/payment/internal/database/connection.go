@@ -55,12 +55,47 @@ func Connect() (*gorm.DB, error) {
 func Migrate(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&domain.Payment{},
+		&domain.PaymentInstallment{},
+		&domain.PaymentFraudRule{},
+		&domain.PixCharge{},
+		&domain.Boleto{},
+		&domain.WebhookEvent{},
+		&domain.Dispute{},
+		&domain.DisputeEvidence{},
+		&domain.LedgerEntry{},
+		&domain.SettlementRun{},
+		&domain.SettlementItem{},
+		&domain.FXRate{},
+		&domain.FXRateSnapshot{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("falha ao executar migrações: %w", err)
 	}
 
+	if err := migrateLegacyAmount(db); err != nil {
+		return fmt.Errorf("falha ao executar migrações: %w", err)
+	}
+
 	fmt.Println("✅ Migrações executadas com sucesso!")
 	return nil
 }
+
+// migrateLegacyAmount copia a antiga coluna amount (decimal, em reais) para amount_minor
+// (centavos) e a remove. O arredondamento é feito em DECIMAL pelo banco, nunca em float.
+func migrateLegacyAmount(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&domain.Payment{}, "amount") {
+		return nil
+	}
+
+	result := db.Exec("UPDATE payments SET amount_minor = ROUND(CAST(amount AS DECIMAL(18,4)) * 100) WHERE amount_minor = 0")
+	if result.Error != nil {
+		return fmt.Errorf("falha ao converter payments.amount: %w", result.Error)
+	}
+	if err := migrator.DropColumn(&domain.Payment{}, "amount"); err != nil {
+		return fmt.Errorf("falha ao remover payments.amount: %w", err)
+	}
+	fmt.Printf("💱 Coluna payments.amount convertida para amount_minor (%d registros)\n", result.RowsAffected)
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package dispute define as regras das contestações (chargebacks) de pagamentos: as
+// transições de status, o prazo da defesa e os anexos aceitos.
+//
+// Uma contestação nasce opened, com o prazo da defesa informado pelo adquirente (ou o
+// prazo padrão do lojista). Enquanto o prazo não vence, o lojista anexa as evidências e
+// envia a defesa (evidence_submitted). O adquirente decide a favor (won) ou contra
+// (lost) o lojista, com ou sem defesa; won e lost são finais.
+package dispute
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"payment-service/internal/domain"
+)
+
+// Erros das regras de contestação
+var (
+	ErrInvalidConfig   = errors.New("configuração de contestações inválida")
+	ErrInvalidEvidence = errors.New("evidência inválida")
+	ErrInvalidDeadline = errors.New("prazo da defesa inválido")
+)
+
+// Tipos de evidência aceitos na defesa
+const (
+	KindReceipt       = "receipt"
+	KindInvoice       = "invoice"
+	KindShipping      = "shipping"
+	KindCommunication = "communication"
+	KindOther         = "other"
+)
+
+var evidenceKinds = map[string]bool{
+	KindReceipt:       true,
+	KindInvoice:       true,
+	KindShipping:      true,
+	KindCommunication: true,
+	KindOther:         true,
+}
+
+// contentTypes são os formatos de anexo aceitos pelos adquirentes
+var contentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"text/plain":      true,
+}
+
+// transitions são as mudanças de status permitidas
+var transitions = map[string][]string{
+	domain.DisputeOpened:            {domain.DisputeEvidenceSubmitted, domain.DisputeWon, domain.DisputeLost},
+	domain.DisputeEvidenceSubmitted: {domain.DisputeWon, domain.DisputeLost},
+}
+
+// CanTransition informa se a contestação pode passar de from para to
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Final informa se o status é uma decisão do adquirente
+func Final(status string) bool {
+	return status == domain.DisputeWon || status == domain.DisputeLost
+}
+
+// Config são as regras de contestação do lojista
+type Config struct {
+	// EvidenceWindow é o prazo da defesa, contado da abertura, quando o adquirente não
+	// informa o prazo
+	EvidenceWindow time.Duration
+	// WarningWindow é a antecedência com que contestações sem defesa aparecem como
+	// próximas do prazo
+	WarningWindow time.Duration
+	// MaxEvidenceSize é o tamanho máximo de cada anexo, em bytes
+	MaxEvidenceSize int64
+}
+
+// Validate verifica a consistência da configuração
+func (c Config) Validate() error {
+	if c.EvidenceWindow <= 0 || c.WarningWindow <= 0 {
+		return fmt.Errorf("%w: prazos devem ser positivos", ErrInvalidConfig)
+	}
+	if c.MaxEvidenceSize <= 0 {
+		return fmt.Errorf("%w: tamanho máximo dos anexos deve ser positivo", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// DueAt retorna o prazo da defesa de uma contestação aberta em opened. Sem prazo
+// informado (requested zero), vale EvidenceWindow; o prazo informado precisa ser
+// posterior à abertura.
+func (c Config) DueAt(opened, requested time.Time) (time.Time, error) {
+	if requested.IsZero() {
+		return opened.Add(c.EvidenceWindow), nil
+	}
+	if !requested.After(opened) {
+		return time.Time{}, fmt.Errorf("%w: %s não é posterior à abertura", ErrInvalidDeadline, requested.Format(time.RFC3339))
+	}
+	return requested, nil
+}
+
+// Evidence é um anexo enviado para a defesa
+type Evidence struct {
+	Kind        string
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// Normalize padroniza o anexo e verifica tipo, formato e tamanho. Sem ContentType, o
+// formato é detectado pelo conteúdo.
+func (c Config) Normalize(e Evidence) (Evidence, error) {
+	e.Kind = strings.ToLower(strings.TrimSpace(e.Kind))
+	if e.Kind == "" {
+		e.Kind = KindOther
+	}
+	if !evidenceKinds[e.Kind] {
+		return Evidence{}, fmt.Errorf("%w: tipo %q (esperado receipt, invoice, shipping, communication ou other)", ErrInvalidEvidence, e.Kind)
+	}
+
+	e.FileName = filepath.Base(strings.TrimSpace(e.FileName))
+	if e.FileName == "" || e.FileName == "." || e.FileName == string(filepath.Separator) {
+		return Evidence{}, fmt.Errorf("%w: nome do arquivo é obrigatório", ErrInvalidEvidence)
+	}
+	if len(e.FileName) > 255 {
+		return Evidence{}, fmt.Errorf("%w: nome do arquivo com mais de 255 caracteres", ErrInvalidEvidence)
+	}
+
+	if len(e.Content) == 0 {
+		return Evidence{}, fmt.Errorf("%w: arquivo vazio", ErrInvalidEvidence)
+	}
+	if int64(len(e.Content)) > c.MaxEvidenceSize {
+		return Evidence{}, fmt.Errorf("%w: %d bytes (máximo %d)", ErrInvalidEvidence, len(e.Content), c.MaxEvidenceSize)
+	}
+
+	e.ContentType = strings.ToLower(strings.TrimSpace(e.ContentType))
+	if e.ContentType == "" {
+		e.ContentType = http.DetectContentType(e.Content)
+	}
+	if base, _, ok := strings.Cut(e.ContentType, ";"); ok {
+		e.ContentType = strings.TrimSpace(base)
+	}
+	if !contentTypes[e.ContentType] {
+		return Evidence{}, fmt.Errorf("%w: formato %s (aceitos PDF, PNG, JPEG e texto)", ErrInvalidEvidence, e.ContentType)
+	}
+	return e, nil
+}
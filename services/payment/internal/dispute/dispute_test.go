@@ -0,0 +1,97 @@
+package dispute
+
+import (
+	"testing"
+	"time"
+
+	"payment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{EvidenceWindow: 7 * 24 * time.Hour, WarningWindow: 72 * time.Hour, MaxEvidenceSize: 1024}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{domain.DisputeOpened, domain.DisputeEvidenceSubmitted, true},
+		{domain.DisputeOpened, domain.DisputeWon, true},
+		{domain.DisputeOpened, domain.DisputeLost, true},
+		{domain.DisputeEvidenceSubmitted, domain.DisputeWon, true},
+		{domain.DisputeEvidenceSubmitted, domain.DisputeLost, true},
+		{domain.DisputeEvidenceSubmitted, domain.DisputeOpened, false},
+		{domain.DisputeEvidenceSubmitted, domain.DisputeEvidenceSubmitted, false},
+		{domain.DisputeWon, domain.DisputeLost, false},
+		{domain.DisputeLost, domain.DisputeWon, false},
+		{"", domain.DisputeOpened, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, CanTransition(tt.from, tt.to), "%s → %s", tt.from, tt.to)
+	}
+	assert.True(t, Final(domain.DisputeWon))
+	assert.True(t, Final(domain.DisputeLost))
+	assert.False(t, Final(domain.DisputeEvidenceSubmitted))
+}
+
+func TestDueAt(t *testing.T) {
+	opened := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	cfg := testConfig()
+
+	due, err := cfg.DueAt(opened, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, opened.AddDate(0, 0, 7), due)
+
+	requested := opened.AddDate(0, 0, 10)
+	due, err = cfg.DueAt(opened, requested)
+	require.NoError(t, err)
+	assert.Equal(t, requested, due)
+
+	_, err = cfg.DueAt(opened, opened.Add(-time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidDeadline)
+}
+
+func TestNormalize(t *testing.T) {
+	cfg := testConfig()
+	pdf := []byte("%PDF-1.4\n1 0 obj\n")
+
+	evidence, err := cfg.Normalize(Evidence{Kind: " Receipt ", FileName: "../../etc/comprovante.pdf", Content: pdf})
+	require.NoError(t, err)
+	assert.Equal(t, KindReceipt, evidence.Kind)
+	assert.Equal(t, "comprovante.pdf", evidence.FileName)
+	assert.Equal(t, "application/pdf", evidence.ContentType)
+
+	evidence, err = cfg.Normalize(Evidence{FileName: "conversa.txt", ContentType: "text/plain; charset=utf-8", Content: []byte("olá")})
+	require.NoError(t, err)
+	assert.Equal(t, KindOther, evidence.Kind)
+	assert.Equal(t, "text/plain", evidence.ContentType)
+
+	invalid := []Evidence{
+		{Kind: "selfie", FileName: "a.pdf", Content: pdf},
+		{FileName: "", Content: pdf},
+		{FileName: "a.pdf"},
+		{FileName: "a.pdf", Content: make([]byte, 1025)},
+		{FileName: "a.zip", ContentType: "application/zip", Content: pdf},
+		{FileName: "a.bin", Content: []byte{0x00, 0x01, 0x02}},
+	}
+	for i, e := range invalid {
+		_, err := cfg.Normalize(e)
+		assert.ErrorIs(t, err, ErrInvalidEvidence, "caso %d", i)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, testConfig().Validate())
+
+	cfg := testConfig()
+	cfg.MaxEvidenceSize = 0
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+
+	cfg = testConfig()
+	cfg.WarningWindow = 0
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+}
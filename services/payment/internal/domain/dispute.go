@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Dispute é uma contestação (chargeback) de um pagamento aprovado, aberta pelo cliente
+// junto ao emissor do cartão. Reference é o identificador da contestação no adquirente
+// e impede que a mesma contestação seja cadastrada duas vezes. A defesa (Evidence) só
+// é aceita até EvidenceDueAt; perdida a contestação, o valor é estornado do pagamento
+// e lançado no razão (LedgerEntry).
+type Dispute struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	PaymentID           uint       `json:"payment_id" gorm:"not null;index"`
+	OrderID             uint       `json:"order_id" gorm:"not null;index"`
+	Reference           string     `json:"reference" gorm:"size:64;not null;uniqueIndex"`
+	Reason              string     `json:"reason" gorm:"size:60;not null"`
+	Amount              int64      `json:"amount" gorm:"column:amount_minor;not null"`
+	Currency            string     `json:"currency" gorm:"size:3;not null"`
+	Status              string     `json:"status" gorm:"size:20;not null;index:idx_disputes_deadline"`
+	EvidenceDueAt       time.Time  `json:"evidence_due_at" gorm:"not null;index:idx_disputes_deadline"`
+	OpenedBy            string     `json:"opened_by" gorm:"size:100"`
+	EvidenceSubmittedAt *time.Time `json:"evidence_submitted_at"`
+	ResolvedAt          *time.Time `json:"resolved_at"`
+	ResolvedBy          string     `json:"resolved_by" gorm:"size:100"`
+	ResolutionNote      string     `json:"resolution_note" gorm:"size:255"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Evidence []DisputeEvidence `json:"evidence,omitempty" gorm:"foreignKey:DisputeID"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Dispute) TableName() string {
+	return "disputes"
+}
+
+// Money retorna o valor contestado
+func (d *Dispute) Money() money.Money {
+	return money.New(d.Amount, d.Currency)
+}
+
+// DisputeEvidence é um anexo da defesa de uma contestação (comprovante de entrega,
+// nota fiscal, conversa com o cliente). O conteúdo só é carregado quando pedido.
+type DisputeEvidence struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	DisputeID   uint      `json:"dispute_id" gorm:"not null;index"`
+	Kind        string    `json:"kind" gorm:"size:30;not null"`
+	FileName    string    `json:"file_name" gorm:"size:255;not null"`
+	ContentType string    `json:"content_type" gorm:"size:100;not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	SHA256      string    `json:"sha256" gorm:"column:sha256;size:64;not null"`
+	Content     []byte    `json:"-" gorm:"type:mediumblob;not null"`
+	Note        string    `json:"note" gorm:"size:255"`
+	UploadedBy  string    `json:"uploaded_by" gorm:"size:100"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (DisputeEvidence) TableName() string {
+	return "dispute_evidence"
+}
+
+// LedgerEntry é um lançamento no razão de um pagamento. Amount é negativo nos débitos
+// (ex.: o estorno de uma contestação perdida).
+type LedgerEntry struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	PaymentID   uint      `json:"payment_id" gorm:"not null;index"`
+	DisputeID   *uint     `json:"dispute_id" gorm:"uniqueIndex:idx_ledger_entries_dispute"`
+	Kind        string    `json:"kind" gorm:"size:20;not null;uniqueIndex:idx_ledger_entries_dispute"`
+	Amount      int64     `json:"amount" gorm:"column:amount_minor;not null"`
+	Currency    string    `json:"currency" gorm:"size:3;not null"`
+	Description string    `json:"description" gorm:"size:255"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
+
+// Status da contestação: aberta, com a defesa enviada e decidida a favor (won) ou
+// contra (lost) o lojista
+const (
+	DisputeOpened            = "opened"
+	DisputeEvidenceSubmitted = "evidence_submitted"
+	DisputeWon               = "won"
+	DisputeLost              = "lost"
+)
+
+// Tipos de lançamento no razão
+const (
+	LedgerChargeback = "chargeback"
+)
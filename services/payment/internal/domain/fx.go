@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// FXRate é a cotação vigente de uma moeda para outra, mantida pela tesouraria.
+// Rate é o valor de uma unidade de FromCurrency em ToCurrency, com 8 casas decimais.
+type FXRate struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	FromCurrency string    `json:"from_currency" gorm:"size:3;not null;uniqueIndex:idx_fx_rates_pair"`
+	ToCurrency   string    `json:"to_currency" gorm:"size:3;not null;uniqueIndex:idx_fx_rates_pair"`
+	Rate         string    `json:"rate" gorm:"type:decimal(18,8);not null"`
+	Source       string    `json:"source" gorm:"size:30;not null"`
+	AsOf         time.Time `json:"as_of" gorm:"not null"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (FXRate) TableName() string {
+	return "fx_rates"
+}
+
+// FXRateSnapshot é a cópia imutável da cotação usada em uma conversão, para que o
+// pagamento continue auditável depois que a cotação vigente mudar
+type FXRateSnapshot struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	FromCurrency string    `json:"from_currency" gorm:"size:3;not null"`
+	ToCurrency   string    `json:"to_currency" gorm:"size:3;not null"`
+	Rate         string    `json:"rate" gorm:"type:decimal(18,8);not null"`
+	Source       string    `json:"source" gorm:"size:30;not null"`
+	AsOf         time.Time `json:"as_of"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (FXRateSnapshot) TableName() string {
+	return "fx_rate_snapshots"
+}
@@ -1,14 +1,62 @@
 package domain
 
-import "time"
+import (
+	"time"
 
-// Payment representa um pagamento no sistema
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Payment representa um pagamento no sistema. Amount é o valor cobrado, em unidades
+// menores (centavos) da moeda do pagamento; OrderAmount é o total do pedido na moeda do
+// pedido. Quando as moedas diferem, FXRate e FXSnapshotID registram a cotação aplicada.
 type Payment struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	OrderID   uint      `json:"order_id" gorm:"not null;uniqueIndex"`
-	Status    string    `json:"status" gorm:"not null"`
-	Amount    float64   `json:"amount" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	OrderID       uint      `json:"order_id" gorm:"not null;uniqueIndex"`
+	Status        string    `json:"status" gorm:"not null"`
+	Amount        int64     `json:"amount" gorm:"column:amount_minor;not null;default:0"`
+	Currency      string    `json:"currency" gorm:"size:3;not null;default:BRL"`
+	OrderAmount   int64     `json:"order_amount" gorm:"column:order_amount_minor;not null;default:0"`
+	OrderCurrency string    `json:"order_currency" gorm:"size:3;not null;default:BRL"`
+	FXRate        string    `json:"fx_rate" gorm:"type:decimal(18,8);not null;default:1"`
+	FXSnapshotID  *uint     `json:"fx_snapshot_id"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	// Method é a forma de pagamento (card, pix ou boleto); PixCharge é a cobrança PIX,
+	// com o BR Code, que fica pendente até a confirmação do PSP ou a expiração; Boleto
+	// é o boleto emitido, pendente até a liquidação no arquivo de retorno do banco
+	Method    string     `json:"method" gorm:"size:10;not null;default:card"`
+	PixCharge *PixCharge `json:"pix_charge,omitempty" gorm:"foreignKey:PaymentID"`
+	Boleto    *Boleto    `json:"boleto,omitempty" gorm:"foreignKey:PaymentID"`
+	// GatewayRef é a referência do pagamento com cartão no provedor (gateway), usada
+	// nos webhooks do provedor
+	GatewayRef string `json:"gateway_ref" gorm:"size:64;index"`
+
+	// Análise antifraude: Customer e CardFingerprint identificam o cliente e o cartão na
+	// contagem de frequência; FraudScore (0 a 100) e FraudDecision são o resultado da
+	// análise e FraudRules, as regras disparadas. Pagamentos retidos (StatusReview)
+	// guardam quem os analisou, quando e a observação do analista. ReservationExpiresAt
+	// é o vencimento da reserva de estoque do pedido, prazo para aprovar a análise.
+	Customer             string             `json:"customer" gorm:"size:100;index"`
+	CardFingerprint      string             `json:"card_fingerprint" gorm:"size:64;index"`
+	FraudScore           int                `json:"fraud_score" gorm:"not null;default:0"`
+	FraudDecision        string             `json:"fraud_decision" gorm:"size:10"`
+	FraudRules           []PaymentFraudRule `json:"fraud_rules,omitempty" gorm:"foreignKey:PaymentID"`
+	ReviewedBy           string             `json:"reviewed_by" gorm:"size:100"`
+	ReviewedAt           *time.Time         `json:"reviewed_at"`
+	ReviewNote           string             `json:"review_note" gorm:"size:255"`
+	ReservationExpiresAt *time.Time         `json:"reservation_expires_at"`
+
+	// ChargedBack é o total estornado por contestações perdidas; estornado o valor
+	// inteiro, o pagamento fica charged_back
+	ChargedBack int64 `json:"charged_back" gorm:"column:charged_back_minor;not null;default:0"`
+
+	// Parcelamento: número de parcelas (1 = à vista), taxa mensal de juros em percentual
+	// e juros incluídos em Amount; Schedule é o cronograma das parcelas
+	Installments   int                  `json:"installments" gorm:"not null;default:1"`
+	InterestFree   bool                 `json:"interest_free" gorm:"not null;default:true"`
+	MonthlyRate    float64              `json:"monthly_rate" gorm:"type:decimal(7,4);not null;default:0"`
+	InterestAmount int64                `json:"interest_amount" gorm:"column:interest_amount_minor;not null;default:0"`
+	Schedule       []PaymentInstallment `json:"schedule,omitempty" gorm:"foreignKey:PaymentID"`
 }
 
 // TableName retorna o nome da tabela no banco de dados
@@ -16,9 +64,121 @@ func (Payment) TableName() string {
 	return "payments"
 }
 
-// PaymentStatus define os possíveis status de pagamento
+// Money retorna o valor cobrado com a moeda do pagamento
+func (p *Payment) Money() money.Money {
+	return money.New(p.Amount, p.Currency)
+}
+
+// OrderMoney retorna o total do pedido na moeda do pedido. Pagamentos anteriores à
+// conversão de moedas não guardam o total do pedido, que é o próprio valor cobrado.
+func (p *Payment) OrderMoney() money.Money {
+	if p.OrderAmount == 0 {
+		return p.Money()
+	}
+	return money.New(p.OrderAmount, p.OrderCurrency)
+}
+
+// PaymentInstallment é uma parcela do cronograma de um pagamento parcelado
+type PaymentInstallment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	PaymentID uint      `json:"payment_id" gorm:"not null;uniqueIndex:idx_payment_installments_number"`
+	Number    int       `json:"number" gorm:"not null;uniqueIndex:idx_payment_installments_number"`
+	Amount    int64     `json:"amount" gorm:"column:amount_minor;not null"`
+	Currency  string    `json:"currency" gorm:"size:3;not null"`
+	DueDate   time.Time `json:"due_date" gorm:"type:date;not null"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (PaymentInstallment) TableName() string {
+	return "payment_installments"
+}
+
+// PaymentFraudRule é uma regra antifraude disparada na análise do pagamento, com os
+// pontos somados ao score e o motivo
+type PaymentFraudRule struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	PaymentID uint   `json:"payment_id" gorm:"not null;index"`
+	Rule      string `json:"rule" gorm:"size:30;not null"`
+	Score     int    `json:"score" gorm:"not null"`
+	Detail    string `json:"detail" gorm:"size:255"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (PaymentFraudRule) TableName() string {
+	return "payment_fraud_rules"
+}
+
+// PixCharge é a cobrança PIX de um pagamento. TxID identifica a cobrança no webhook
+// do PSP; EndToEndID é o identificador da transação PIX recebida.
+type PixCharge struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	PaymentID  uint       `json:"payment_id" gorm:"not null;uniqueIndex"`
+	TxID       string     `json:"txid" gorm:"column:txid;size:35;not null;uniqueIndex"`
+	Kind       string     `json:"kind" gorm:"size:10;not null"`
+	Payload    string     `json:"payload" gorm:"type:text;not null"`
+	Status     string     `json:"status" gorm:"size:10;not null;default:active;index:idx_pix_charges_expiry"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null;index:idx_pix_charges_expiry"`
+	PaidAt     *time.Time `json:"paid_at"`
+	EndToEndID string     `json:"end_to_end_id" gorm:"size:32"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (PixCharge) TableName() string {
+	return "pix_charges"
+}
+
+// Boleto é o boleto de um pagamento. NossoNumero identifica o título nos arquivos de
+// retorno; ExpiresAt é o fim da tolerância após o vencimento, quando o pagamento é
+// recusado. PaidAmount e PaidAt vêm da liquidação informada pelo banco.
+type Boleto struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	PaymentID     uint       `json:"payment_id" gorm:"not null;uniqueIndex"`
+	NossoNumero   string     `json:"nosso_numero" gorm:"size:17;not null;uniqueIndex"`
+	Barcode       string     `json:"barcode" gorm:"size:44;not null"`
+	DigitableLine string     `json:"digitable_line" gorm:"size:47;not null"`
+	DueDate       time.Time  `json:"due_date" gorm:"type:date;not null"`
+	Status        string     `json:"status" gorm:"size:10;not null;default:open;index:idx_boletos_expiry"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null;index:idx_boletos_expiry"`
+	PaidAt        *time.Time `json:"paid_at"`
+	PaidAmount    int64      `json:"paid_amount" gorm:"column:paid_amount_minor;not null;default:0"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Boleto) TableName() string {
+	return "boletos"
+}
+
+// PaymentStatus define os possíveis status de pagamento. Pagamentos PIX e boleto ficam
+// pendentes até a confirmação; cobranças expiradas viram failed. Pagamentos retidos
+// pela análise antifraude ficam em review até a decisão do analista. Pagamentos
+// aprovados estornados por inteiro em contestações perdidas ficam charged_back.
+const (
+	StatusApproved    = "approved"
+	StatusFailed      = "failed"
+	StatusPending     = "pending"
+	StatusReview      = "review"
+	StatusChargedBack = "charged_back"
+)
+
+// Formas de pagamento
+const (
+	MethodCard   = "card"
+	MethodPix    = "pix"
+	MethodBoleto = "boleto"
+)
+
+// Status da cobrança PIX
+const (
+	PixStatusActive  = "active"
+	PixStatusPaid    = "paid"
+	PixStatusExpired = "expired"
+)
+
+// Status do boleto
 const (
-	StatusApproved = "approved"
-	StatusFailed   = "failed"
-	StatusPending  = "pending"
+	BoletoStatusOpen    = "open"
+	BoletoStatusPaid    = "paid"
+	BoletoStatusExpired = "expired"
 )
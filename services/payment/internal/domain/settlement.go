@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// SettlementRun é a conciliação de um arquivo de liquidação do provedor de cartão com os
+// pagamentos. FileSHA256 identifica o arquivo: reimportar o mesmo arquivo devolve a
+// conciliação já gravada. PeriodStart e PeriodEnd delimitam os dias das transações do
+// arquivo, quando informados; os totais contam os itens por resultado.
+type SettlementRun struct {
+	ID                uint             `gorm:"primaryKey" json:"id"`
+	FileName          string           `json:"file_name" gorm:"size:255;not null"`
+	FileSHA256        string           `json:"file_sha256" gorm:"column:file_sha256;size:64;not null;uniqueIndex"`
+	Source            string           `json:"source" gorm:"size:10;not null"`
+	ImportedBy        string           `json:"imported_by" gorm:"size:100"`
+	PeriodStart       *time.Time       `json:"period_start" gorm:"type:date"`
+	PeriodEnd         *time.Time       `json:"period_end" gorm:"type:date"`
+	Records           int              `json:"records" gorm:"not null;default:0"`
+	Matched           int              `json:"matched" gorm:"not null;default:0"`
+	MissingPayment    int              `json:"missing_payment" gorm:"not null;default:0"`
+	MissingSettlement int              `json:"missing_settlement" gorm:"not null;default:0"`
+	AmountMismatch    int              `json:"amount_mismatch" gorm:"not null;default:0"`
+	StatusMismatch    int              `json:"status_mismatch" gorm:"not null;default:0"`
+	Duplicate         int              `json:"duplicate" gorm:"not null;default:0"`
+	CreatedAt         time.Time        `json:"created_at" gorm:"autoCreateTime;index"`
+	Items             []SettlementItem `json:"items,omitempty" gorm:"foreignKey:RunID"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (SettlementRun) TableName() string {
+	return "settlement_runs"
+}
+
+// SettlementItem é o resultado de uma transação do arquivo de liquidação, ou de um
+// pagamento aprovado no período que não aparece no arquivo (Line zero). Os valores
+// estão em unidades menores da moeda de cada lado.
+type SettlementItem struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	RunID           uint   `json:"run_id" gorm:"not null;index:idx_settlement_items_outcome"`
+	Line            int    `json:"line" gorm:"not null;default:0"`
+	GatewayRef      string `json:"gateway_ref" gorm:"size:64;not null;index"`
+	PaymentID       *uint  `json:"payment_id"`
+	OrderID         uint   `json:"order_id" gorm:"not null;default:0"`
+	Outcome         string `json:"outcome" gorm:"size:20;not null;index:idx_settlement_items_outcome"`
+	SettledStatus   string `json:"settled_status" gorm:"size:20"`
+	SettledAmount   int64  `json:"settled_amount" gorm:"column:settled_amount_minor;not null;default:0"`
+	SettledCurrency string `json:"settled_currency" gorm:"size:3"`
+	PaymentStatus   string `json:"payment_status" gorm:"size:20"`
+	PaymentAmount   int64  `json:"payment_amount" gorm:"column:payment_amount_minor;not null;default:0"`
+	PaymentCurrency string `json:"payment_currency" gorm:"size:3"`
+	Detail          string `json:"detail" gorm:"size:255"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (SettlementItem) TableName() string {
+	return "settlement_items"
+}
+
+// Origens da conciliação: enviada pelo RPC (paymentctl) ou lida da pasta de entrada
+const (
+	SettlementSourceRPC   = "rpc"
+	SettlementSourceInbox = "inbox"
+)
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// WebhookEvent é um webhook recebido de um provedor de pagamento, guardado com o corpo
+// original para auditoria. EventID é único por provedor e garante que reenvios do
+// mesmo evento não sejam aplicados duas vezes; ProcessedAt fica vazio até o evento
+// ser aplicado, e Outcome registra o que ele causou no pagamento.
+type WebhookEvent struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Provider    string     `json:"provider" gorm:"size:20;not null;uniqueIndex:idx_webhook_events_event"`
+	EventID     string     `json:"event_id" gorm:"size:64;not null;uniqueIndex:idx_webhook_events_event"`
+	EventType   string     `json:"event_type" gorm:"size:40;not null"`
+	PaymentRef  string     `json:"payment_ref" gorm:"size:64;not null;index"`
+	PaymentID   *uint      `json:"payment_id"`
+	Signature   string     `json:"signature" gorm:"size:255;not null"`
+	SignedAt    time.Time  `json:"signed_at" gorm:"not null"`
+	Payload     string     `json:"payload" gorm:"type:text;not null"`
+	Outcome     string     `json:"outcome" gorm:"size:20"`
+	Detail      string     `json:"detail" gorm:"size:255"`
+	ReceivedAt  time.Time  `json:"received_at" gorm:"autoCreateTime"`
+	ProcessedAt *time.Time `json:"processed_at"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (WebhookEvent) TableName() string {
+	return "webhook_events"
+}
+
+// Resultados da aplicação de um webhook ao pagamento
+const (
+	WebhookApplied        = "applied"
+	WebhookAlreadyApplied = "already_applied"
+	WebhookIgnored        = "ignored"
+	WebhookNotFound       = "not_found"
+	WebhookAmountMismatch = "amount_mismatch"
+)
@@ -0,0 +1,234 @@
+// Package fraud pontua o risco de fraude de cada pagamento antes da autorização.
+//
+// Cada regra disparada soma pontos ao score (de 0 a 100): valor acima dos limites,
+// muitos pagamentos do mesmo cliente ou cartão em pouco tempo, conta recém-criada e
+// divergências de localização (UF de cobrança diferente da de entrega, IP fora do
+// país da loja). Com o score em ReviewScore ou mais o pagamento fica retido para
+// análise manual; em DenyScore ou mais, é recusado. As listas de permissão e de
+// bloqueio (Lists) decidem antes das regras.
+package fraud
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Decisões da análise
+const (
+	DecisionAllow  = "allow"
+	DecisionReview = "review"
+	DecisionDeny   = "deny"
+)
+
+// Regras que podem ser disparadas
+const (
+	RuleAllowList         = "allow_list"
+	RuleDenyList          = "deny_list"
+	RuleAmount            = "amount"
+	RuleCustomerVelocity  = "velocity_customer"
+	RuleCardVelocity      = "velocity_card"
+	RuleNewAccount        = "new_account"
+	RuleBillingMismatch   = "billing_mismatch"
+	RuleIPCountryMismatch = "ip_country_mismatch"
+)
+
+// MaxScore é o maior score possível
+const MaxScore = 100
+
+// ErrInvalidRules indica uma configuração de regras inconsistente
+var ErrInvalidRules = errors.New("regras antifraude inválidas")
+
+// Threshold é uma faixa de valor: pagamentos a partir de Amount (em unidades menores de
+// Rules.Currency) somam Score
+type Threshold struct {
+	Amount int64
+	Score  int
+}
+
+// Rules são as regras antifraude do lojista. Regras com score zero ficam desligadas.
+type Rules struct {
+	// Currency é a moeda dos limites de valor
+	Currency string
+	// AmountThresholds são as faixas de valor em ordem crescente; vale a maior atingida
+	AmountThresholds []Threshold
+
+	// VelocityWindow é a janela de contagem dos pagamentos anteriores do mesmo cliente e
+	// do mesmo cartão; a partir de MaxCustomerPayments ou MaxCardPayments pagamentos na
+	// janela, o próximo soma VelocityScore
+	VelocityWindow      time.Duration
+	MaxCustomerPayments int
+	MaxCardPayments     int
+	VelocityScore       int
+
+	// NewAccountAge é a idade da conta abaixo da qual o cliente soma NewAccountScore
+	NewAccountAge   time.Duration
+	NewAccountScore int
+
+	// BillingMismatchScore é somado quando a UF de cobrança difere da UF de entrega
+	BillingMismatchScore int
+	// HomeCountry é o país da loja (ISO 3166-1 alfa-2); IP de outro país soma IPCountryScore
+	HomeCountry    string
+	IPCountryScore int
+
+	// ReviewScore e DenyScore são os scores a partir dos quais o pagamento fica retido
+	// para análise ou é recusado
+	ReviewScore int
+	DenyScore   int
+}
+
+// Validate verifica a consistência das regras
+func (r Rules) Validate() error {
+	if !money.ValidCurrency(r.Currency) {
+		return fmt.Errorf("%w: moeda %q", ErrInvalidRules, r.Currency)
+	}
+	var last int64
+	for _, threshold := range r.AmountThresholds {
+		if threshold.Amount <= last || threshold.Score < 0 {
+			return fmt.Errorf("%w: faixas de valor devem ser positivas e crescentes", ErrInvalidRules)
+		}
+		last = threshold.Amount
+	}
+	for _, score := range []int{r.VelocityScore, r.NewAccountScore, r.BillingMismatchScore, r.IPCountryScore} {
+		if score < 0 || score > MaxScore {
+			return fmt.Errorf("%w: scores devem ficar entre 0 e %d", ErrInvalidRules, MaxScore)
+		}
+	}
+	if r.VelocityScore > 0 && (r.VelocityWindow <= 0 || r.MaxCustomerPayments < 0 || r.MaxCardPayments < 0) {
+		return fmt.Errorf("%w: janela e limites de frequência", ErrInvalidRules)
+	}
+	if r.ReviewScore <= 0 || r.DenyScore < r.ReviewScore || r.DenyScore > MaxScore {
+		return fmt.Errorf("%w: esperado 0 < revisão (%d) <= recusa (%d) <= %d", ErrInvalidRules, r.ReviewScore, r.DenyScore, MaxScore)
+	}
+	return nil
+}
+
+// Input são os dados do pagamento analisado. Amount deve estar em Rules.Currency;
+// valores em outra moeda (sem cotação para conversão) contam como acima da maior faixa.
+// CustomerPayments e CardPayments são os pagamentos anteriores na janela de
+// frequência. Campos vazios não disparam as regras que dependem deles.
+type Input struct {
+	Customer         string
+	CardFingerprint  string
+	Amount           money.Money
+	BillingState     string
+	ShippingState    string
+	IPCountry        string
+	CustomerSince    *time.Time
+	CustomerPayments int
+	CardPayments     int
+	Now              time.Time
+}
+
+// Hit é uma regra disparada, com os pontos somados e o motivo
+type Hit struct {
+	Rule   string
+	Score  int
+	Detail string
+}
+
+// Assessment é o resultado da análise: o score, a decisão e as regras disparadas
+type Assessment struct {
+	Score    int
+	Decision string
+	Hits     []Hit
+}
+
+// Evaluate analisa o pagamento. A lista de bloqueio recusa e a de permissão libera sem
+// avaliar as regras; as demais regras somam pontos até MaxScore.
+func (r Rules) Evaluate(lists Lists, in Input) Assessment {
+	if entry, ok := lists.Match(DecisionDeny, in); ok {
+		return Assessment{Score: MaxScore, Decision: DecisionDeny, Hits: []Hit{{Rule: RuleDenyList, Score: MaxScore, Detail: entry.describe()}}}
+	}
+	if entry, ok := lists.Match(DecisionAllow, in); ok {
+		return Assessment{Decision: DecisionAllow, Hits: []Hit{{Rule: RuleAllowList, Detail: entry.describe()}}}
+	}
+
+	var hits []Hit
+	add := func(rule string, score int, detail string, args ...any) {
+		if score > 0 {
+			hits = append(hits, Hit{Rule: rule, Score: score, Detail: fmt.Sprintf(detail, args...)})
+		}
+	}
+
+	if hit, ok := r.amountHit(in.Amount); ok {
+		hits = append(hits, hit)
+	}
+	if r.MaxCustomerPayments > 0 && in.Customer != "" && in.CustomerPayments >= r.MaxCustomerPayments {
+		add(RuleCustomerVelocity, r.VelocityScore, "%d pagamentos do cliente em %s", in.CustomerPayments+1, r.VelocityWindow)
+	}
+	if r.MaxCardPayments > 0 && in.CardFingerprint != "" && in.CardPayments >= r.MaxCardPayments {
+		add(RuleCardVelocity, r.VelocityScore, "%d pagamentos do cartão em %s", in.CardPayments+1, r.VelocityWindow)
+	}
+	if in.CustomerSince != nil && in.Now.Sub(*in.CustomerSince) < r.NewAccountAge {
+		add(RuleNewAccount, r.NewAccountScore, "conta criada há %s", in.Now.Sub(*in.CustomerSince).Truncate(time.Minute))
+	}
+	billing, shipping := normalize(in.BillingState), normalize(in.ShippingState)
+	if billing != "" && shipping != "" && billing != shipping {
+		add(RuleBillingMismatch, r.BillingMismatchScore, "cobrança em %s, entrega em %s", billing, shipping)
+	}
+	country, home := normalize(in.IPCountry), normalize(r.HomeCountry)
+	if country != "" && home != "" && country != home {
+		add(RuleIPCountryMismatch, r.IPCountryScore, "IP em %s", country)
+	}
+
+	assessment := Assessment{Decision: DecisionAllow, Hits: hits}
+	for _, hit := range hits {
+		assessment.Score += hit.Score
+	}
+	assessment.Score = min(assessment.Score, MaxScore)
+	switch {
+	case assessment.Score >= r.DenyScore:
+		assessment.Decision = DecisionDeny
+	case assessment.Score >= r.ReviewScore:
+		assessment.Decision = DecisionReview
+	}
+	return assessment
+}
+
+// Checker aplica as regras com as listas vigentes. O valor zero fica desligado.
+type Checker struct {
+	Rules Rules
+	Lists ListSource
+}
+
+// Enabled informa se a análise antifraude está ligada
+func (c Checker) Enabled() bool {
+	return c.Rules.ReviewScore > 0
+}
+
+// Evaluate analisa o pagamento com as listas vigentes
+func (c Checker) Evaluate(in Input) Assessment {
+	var lists Lists
+	if c.Lists != nil {
+		lists = c.Lists.Lists()
+	}
+	return c.Rules.Evaluate(lists, in)
+}
+
+// amountHit retorna a maior faixa de valor atingida pelo pagamento
+func (r Rules) amountHit(amount money.Money) (Hit, bool) {
+	if len(r.AmountThresholds) == 0 {
+		return Hit{}, false
+	}
+	if money.NormalizeCurrency(amount.Currency) != money.NormalizeCurrency(r.Currency) {
+		top := r.AmountThresholds[len(r.AmountThresholds)-1]
+		return Hit{Rule: RuleAmount, Score: top.Score, Detail: fmt.Sprintf("valor %s sem conversão para %s", amount, r.Currency)}, top.Score > 0
+	}
+	for i := len(r.AmountThresholds) - 1; i >= 0; i-- {
+		threshold := r.AmountThresholds[i]
+		if amount.Amount >= threshold.Amount {
+			limit := money.New(threshold.Amount, r.Currency)
+			return Hit{Rule: RuleAmount, Score: threshold.Score, Detail: fmt.Sprintf("valor %s a partir de %s", amount, limit)}, threshold.Score > 0
+		}
+	}
+	return Hit{}, false
+}
+
+// normalize padroniza UFs e códigos de país para comparação
+func normalize(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
@@ -0,0 +1,224 @@
+package fraud
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+func testRules() Rules {
+	return Rules{
+		Currency:             "BRL",
+		AmountThresholds:     []Threshold{{Amount: 500000, Score: 30}, {Amount: 2000000, Score: 60}},
+		VelocityWindow:       time.Hour,
+		MaxCustomerPayments:  3,
+		MaxCardPayments:      3,
+		VelocityScore:        40,
+		NewAccountAge:        24 * time.Hour,
+		NewAccountScore:      20,
+		BillingMismatchScore: 20,
+		HomeCountry:          "BR",
+		IPCountryScore:       30,
+		ReviewScore:          60,
+		DenyScore:            90,
+	}
+}
+
+func input(amount int64) Input {
+	return Input{
+		Customer:        "42",
+		CardFingerprint: "fp_abc",
+		Amount:          money.New(amount, "BRL"),
+		BillingState:    "SP",
+		ShippingState:   "SP",
+		IPCountry:       "BR",
+		Now:             testNow,
+	}
+}
+
+func rules(assessment Assessment) []string {
+	var fired []string
+	for _, hit := range assessment.Hits {
+		fired = append(fired, hit.Rule)
+	}
+	return fired
+}
+
+func TestEvaluate(t *testing.T) {
+	newAccount := testNow.Add(-2 * time.Hour)
+	oldAccount := testNow.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		name     string
+		input    func() Input
+		score    int
+		decision string
+		rules    []string
+	}{
+		{"sem sinais", func() Input { return input(19990) }, 0, DecisionAllow, nil},
+		{"primeira faixa de valor", func() Input { return input(500000) }, 30, DecisionAllow, []string{RuleAmount}},
+		{"maior faixa de valor", func() Input { return input(2500000) }, 60, DecisionReview, []string{RuleAmount}},
+		{"moeda sem conversão", func() Input {
+			in := input(100)
+			in.Amount = money.New(100, "USD")
+			return in
+		}, 60, DecisionReview, []string{RuleAmount}},
+		{"frequência do cliente", func() Input {
+			in := input(19990)
+			in.CustomerPayments = 3
+			return in
+		}, 40, DecisionAllow, []string{RuleCustomerVelocity}},
+		{"abaixo do limite de frequência", func() Input {
+			in := input(19990)
+			in.CustomerPayments, in.CardPayments = 2, 2
+			return in
+		}, 0, DecisionAllow, nil},
+		{"frequência do cliente e do cartão", func() Input {
+			in := input(19990)
+			in.CustomerPayments, in.CardPayments = 5, 3
+			return in
+		}, 80, DecisionReview, []string{RuleCustomerVelocity, RuleCardVelocity}},
+		{"conta nova", func() Input {
+			in := input(19990)
+			in.CustomerSince = &newAccount
+			return in
+		}, 20, DecisionAllow, []string{RuleNewAccount}},
+		{"conta antiga", func() Input {
+			in := input(19990)
+			in.CustomerSince = &oldAccount
+			return in
+		}, 0, DecisionAllow, nil},
+		{"localização divergente", func() Input {
+			in := input(19990)
+			in.BillingState, in.IPCountry = "rj", "us"
+			in.CustomerSince = &newAccount
+			return in
+		}, 70, DecisionReview, []string{RuleNewAccount, RuleBillingMismatch, RuleIPCountryMismatch}},
+		{"sem dados de localização", func() Input {
+			in := input(19990)
+			in.BillingState, in.IPCountry = "", ""
+			return in
+		}, 0, DecisionAllow, nil},
+		{"score limitado", func() Input {
+			in := input(2500000)
+			in.CustomerPayments, in.IPCountry = 3, "US"
+			return in
+		}, 100, DecisionDeny, []string{RuleAmount, RuleCustomerVelocity, RuleIPCountryMismatch}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := testRules().Evaluate(Lists{}, tt.input())
+			assert.Equal(t, tt.score, assessment.Score)
+			assert.Equal(t, tt.decision, assessment.Decision)
+			assert.Equal(t, tt.rules, rules(assessment))
+		})
+	}
+}
+
+func TestEvaluateDetails(t *testing.T) {
+	in := input(2500000)
+	in.CustomerPayments = 3
+	assessment := testRules().Evaluate(Lists{}, in)
+	require.Len(t, assessment.Hits, 2)
+	assert.Equal(t, "valor BRL 25000.00 a partir de BRL 20000.00", assessment.Hits[0].Detail)
+	assert.Equal(t, "4 pagamentos do cliente em 1h0m0s", assessment.Hits[1].Detail)
+}
+
+func TestEvaluateLists(t *testing.T) {
+	lists, err := NewLists([]Entry{
+		{List: "deny", Kind: "card", Value: "fp_stolen", Reason: "chargeback"},
+		{List: "allow", Kind: "customer", Value: "42"},
+		{List: "deny", Kind: "country", Value: "kp"},
+	})
+	require.NoError(t, err)
+
+	risky := input(2500000)
+	risky.CustomerPayments = 10
+	allowed := testRules().Evaluate(lists, risky)
+	assert.Equal(t, DecisionAllow, allowed.Decision)
+	assert.Zero(t, allowed.Score)
+	assert.Equal(t, []string{RuleAllowList}, rules(allowed))
+
+	// O bloqueio vale mesmo para clientes da lista de permissão
+	stolen := input(19990)
+	stolen.CardFingerprint = "fp_stolen"
+	denied := testRules().Evaluate(lists, stolen)
+	assert.Equal(t, DecisionDeny, denied.Decision)
+	assert.Equal(t, MaxScore, denied.Score)
+	assert.Equal(t, "card fp_stolen: chargeback", denied.Hits[0].Detail)
+
+	country := input(19990)
+	country.Customer, country.IPCountry = "7", "KP"
+	assert.Equal(t, DecisionDeny, testRules().Evaluate(lists, country).Decision)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, testRules().Validate())
+
+	invalid := []func(*Rules){
+		func(r *Rules) { r.Currency = "XXX" },
+		func(r *Rules) {
+			r.AmountThresholds = []Threshold{{Amount: 2000000, Score: 60}, {Amount: 500000, Score: 30}}
+		},
+		func(r *Rules) { r.AmountThresholds = []Threshold{{Amount: 0, Score: 10}} },
+		func(r *Rules) { r.VelocityScore = 101 },
+		func(r *Rules) { r.VelocityWindow = 0 },
+		func(r *Rules) { r.ReviewScore = 0 },
+		func(r *Rules) { r.DenyScore = 50 },
+	}
+	for i, change := range invalid {
+		r := testRules()
+		change(&r)
+		assert.ErrorIs(t, r.Validate(), ErrInvalidRules, "caso %d", i)
+	}
+}
+
+func TestReadLists(t *testing.T) {
+	lists, err := ReadLists(strings.NewReader("# comentário\nlist,kind,value,reason\ndeny,card,fp_1,chargeback\nallow,customer,42\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, lists.Len())
+
+	invalid := []string{
+		"block,card,fp_1\n",
+		"deny,email,a@b.com\n",
+		"deny,card,\n",
+		"deny,card\n",
+	}
+	for _, content := range invalid {
+		_, err := ReadLists(strings.NewReader(content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestFileLists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fraud_lists.csv")
+	require.NoError(t, os.WriteFile(path, []byte("deny,customer,13\n"), 0o644))
+
+	source, err := NewFileLists(path)
+	require.NoError(t, err)
+	_, denied := source.Lists().Match(DecisionDeny, Input{Customer: "13"})
+	assert.True(t, denied)
+
+	// Alterações no arquivo valem sem reiniciar; um arquivo inválido mantém as listas
+	require.NoError(t, os.WriteFile(path, []byte("deny,customer,14\n"), 0o644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	_, denied = source.Lists().Match(DecisionDeny, Input{Customer: "14"})
+	assert.True(t, denied)
+
+	require.NoError(t, os.WriteFile(path, []byte("deny,email,x\n"), 0o644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+	_, denied = source.Lists().Match(DecisionDeny, Input{Customer: "14"})
+	assert.True(t, denied)
+
+	_, err = NewFileLists(filepath.Join(t.TempDir(), "ausente.csv"))
+	assert.Error(t, err)
+}
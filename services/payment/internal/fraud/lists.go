@@ -0,0 +1,209 @@
+package fraud
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tipos de entrada das listas
+const (
+	KindCustomer = "customer"
+	KindCard     = "card"
+	KindCountry  = "country"
+)
+
+// Entry é uma entrada da lista de permissão (allow) ou de bloqueio (deny): um cliente,
+// a impressão digital de um cartão ou o país do IP
+type Entry struct {
+	List   string
+	Kind   string
+	Value  string
+	Reason string
+}
+
+// describe resume a entrada para o registro da regra disparada
+func (e Entry) describe() string {
+	text := e.Kind + " " + e.Value
+	if e.Reason != "" {
+		text += ": " + e.Reason
+	}
+	return text
+}
+
+// Lists são as listas de permissão e de bloqueio. O valor zero é válido e vazio.
+type Lists struct {
+	entries map[string]Entry
+}
+
+// NewLists monta as listas a partir das entradas
+func NewLists(entries []Entry) (Lists, error) {
+	lists := Lists{entries: make(map[string]Entry, len(entries))}
+	for _, entry := range entries {
+		entry.List = strings.ToLower(strings.TrimSpace(entry.List))
+		entry.Kind = strings.ToLower(strings.TrimSpace(entry.Kind))
+		entry.Value = strings.TrimSpace(entry.Value)
+		entry.Reason = strings.TrimSpace(entry.Reason)
+		if entry.List != DecisionAllow && entry.List != DecisionDeny {
+			return Lists{}, fmt.Errorf("lista inválida: %q (esperado allow ou deny)", entry.List)
+		}
+		if entry.Kind != KindCustomer && entry.Kind != KindCard && entry.Kind != KindCountry {
+			return Lists{}, fmt.Errorf("tipo inválido: %q (esperado customer, card ou country)", entry.Kind)
+		}
+		if entry.Value == "" {
+			return Lists{}, fmt.Errorf("valor vazio na lista %s", entry.List)
+		}
+		lists.entries[listKey(entry.List, entry.Kind, entry.Value)] = entry
+	}
+	return lists, nil
+}
+
+// Len retorna o número de entradas
+func (l Lists) Len() int {
+	return len(l.entries)
+}
+
+// Match procura o cliente, o cartão ou o país do IP do pagamento na lista informada
+func (l Lists) Match(list string, in Input) (Entry, bool) {
+	candidates := []struct{ kind, value string }{
+		{KindCustomer, in.Customer},
+		{KindCard, in.CardFingerprint},
+		{KindCountry, in.IPCountry},
+	}
+	for _, candidate := range candidates {
+		if strings.TrimSpace(candidate.value) == "" {
+			continue
+		}
+		if entry, ok := l.entries[listKey(list, candidate.kind, candidate.value)]; ok {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// listKey é a chave de busca; países são comparados sem diferenciar maiúsculas
+func listKey(list, kind, value string) string {
+	value = strings.TrimSpace(value)
+	if kind == KindCountry {
+		value = strings.ToUpper(value)
+	}
+	return list + "|" + kind + "|" + value
+}
+
+// ReadLists lê as listas em CSV (list,kind,value[,reason]; ex.: deny,card,fp_123,chargeback).
+// Linhas iniciadas por # são ignoradas, assim como um cabeçalho com os nomes das colunas.
+func ReadLists(r io.Reader) (Lists, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []Entry
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Lists{}, fmt.Errorf("listas antifraude: %w", err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "list") {
+			continue
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return Lists{}, fmt.Errorf("listas antifraude, linha %d: esperado list,kind,value[,reason]", line)
+		}
+		entry := Entry{List: record[0], Kind: record[1], Value: record[2]}
+		if len(record) == 4 {
+			entry.Reason = record[3]
+		}
+		if _, err := NewLists([]Entry{entry}); err != nil {
+			return Lists{}, fmt.Errorf("listas antifraude, linha %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return NewLists(entries)
+}
+
+// ListSource fornece as listas vigentes
+type ListSource interface {
+	Lists() Lists
+}
+
+// staticLists são listas fixas
+type staticLists struct {
+	lists Lists
+}
+
+// StaticLists cria um ListSource com listas fixas
+func StaticLists(lists Lists) ListSource {
+	return staticLists{lists: lists}
+}
+
+func (s staticLists) Lists() Lists {
+	return s.lists
+}
+
+// fileLists lê as listas de um arquivo CSV e o relê quando ele é alterado, para que a
+// equipe de risco bloqueie ou libere clientes sem reiniciar o serviço
+type fileLists struct {
+	path     string
+	mu       sync.Mutex
+	lists    Lists
+	modified time.Time
+}
+
+// NewFileLists cria um ListSource a partir de um arquivo CSV (ver ReadLists)
+func NewFileLists(path string) (ListSource, error) {
+	source := &fileLists{path: path}
+	if err := source.reload(); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// Lists retorna as listas, relendo o arquivo se necessário
+func (s *fileLists) Lists() Lists {
+	if err := s.reload(); err != nil {
+		// Mantém as últimas listas válidas se o arquivo ficar ilegível
+		log.Printf("⚠️ Erro ao reler listas antifraude de %s: %v", s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lists
+}
+
+// reload relê o arquivo se ele foi alterado desde a última leitura
+func (s *fileLists) reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("falha ao ler listas antifraude: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if info.ModTime().Equal(s.modified) {
+		return nil
+	}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("falha ao ler listas antifraude: %w", err)
+	}
+	defer file.Close()
+
+	lists, err := ReadLists(file)
+	if err != nil {
+		return err
+	}
+	s.lists = lists
+	s.modified = info.ModTime()
+	log.Printf("🛡️ %d entradas antifraude carregadas de %s", lists.Len(), s.path)
+	return nil
+}
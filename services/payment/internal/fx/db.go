@@ -0,0 +1,61 @@
+package fx
+
+import (
+	"payment-service/internal/domain"
+	"payment-service/internal/repository"
+)
+
+// dbProvider lê as cotações da tabela fx_rates
+type dbProvider struct {
+	repo repository.FXRateRepository
+}
+
+// NewDBProvider cria um Provider a partir da tabela fx_rates
+func NewDBProvider(repo repository.FXRateRepository) Provider {
+	return &dbProvider{repo: repo}
+}
+
+// Rate retorna a cotação de from para to
+func (p *dbProvider) Rate(from, to string) (Rate, error) {
+	rows, err := p.repo.FindPair(from, to)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	rates := make([]Rate, 0, len(rows))
+	for _, row := range rows {
+		rate, err := FromDomain(row)
+		if err != nil {
+			return Rate{}, err
+		}
+		rates = append(rates, rate)
+	}
+	return lookup(rates, from, to)
+}
+
+// FromDomain converte uma cotação gravada no banco
+func FromDomain(row domain.FXRate) (Rate, error) {
+	return newRate(row.FromCurrency, row.ToCurrency, row.Rate, row.Source, row.AsOf)
+}
+
+// ToDomain converte uma cotação para gravação no banco
+func ToDomain(rate Rate) domain.FXRate {
+	return domain.FXRate{
+		FromCurrency: rate.From,
+		ToCurrency:   rate.To,
+		Rate:         rate.Decimal(),
+		Source:       rate.Source,
+		AsOf:         rate.AsOf,
+	}
+}
+
+// Snapshot monta a cópia imutável da cotação usada em uma conversão
+func Snapshot(rate Rate) domain.FXRateSnapshot {
+	return domain.FXRateSnapshot{
+		FromCurrency: rate.From,
+		ToCurrency:   rate.To,
+		Rate:         rate.Decimal(),
+		Source:       rate.Source,
+		AsOf:         rate.AsOf,
+	}
+}
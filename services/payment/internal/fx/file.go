@@ -0,0 +1,128 @@
+package fx
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SourceFile identifica as cotações lidas de arquivo
+const SourceFile = "file"
+
+// fileProvider lê as cotações de um arquivo CSV e o relê quando ele é alterado,
+// para que a tesouraria atualize as cotações sem reiniciar o serviço
+type fileProvider struct {
+	path     string
+	mu       sync.Mutex
+	rates    []Rate
+	modified time.Time
+}
+
+// NewFileProvider cria um Provider a partir de um arquivo CSV no formato
+// from,to,rate[,as_of] (ex.: USD,BRL,5.4321,2025-11-28). Linhas iniciadas por # são
+// ignoradas, assim como um cabeçalho com os nomes das colunas.
+func NewFileProvider(path string) (Provider, error) {
+	p := &fileProvider{path: path}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Rate retorna a cotação de from para to
+func (p *fileProvider) Rate(from, to string) (Rate, error) {
+	if err := p.reload(); err != nil {
+		// Mantém as últimas cotações válidas se o arquivo ficar ilegível
+		log.Printf("⚠️ Erro ao reler cotações de %s: %v", p.path, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return lookup(p.rates, from, to)
+}
+
+// reload relê o arquivo se ele foi alterado desde a última leitura
+func (p *fileProvider) reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("falha ao ler arquivo de cotações: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if info.ModTime().Equal(p.modified) {
+		return nil
+	}
+
+	file, err := os.Open(p.path)
+	if err != nil {
+		return fmt.Errorf("falha ao ler arquivo de cotações: %w", err)
+	}
+	defer file.Close()
+
+	rates, err := ReadRates(file, SourceFile, info.ModTime())
+	if err != nil {
+		return err
+	}
+	p.rates = rates
+	p.modified = info.ModTime()
+	log.Printf("💱 %d cotações carregadas de %s", len(rates), p.path)
+	return nil
+}
+
+// ReadRates lê cotações em CSV (from,to,rate[,as_of]). Sem as_of, a cotação vale a
+// partir de defaultAsOf.
+func ReadRates(r io.Reader, source string, defaultAsOf time.Time) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rates []Rate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cotações: %w", err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "from") {
+			continue
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return nil, fmt.Errorf("cotações, linha %d: esperado from,to,rate[,as_of]", line)
+		}
+
+		asOf := defaultAsOf
+		if len(record) == 4 && strings.TrimSpace(record[3]) != "" {
+			if asOf, err = parseAsOf(record[3]); err != nil {
+				return nil, fmt.Errorf("cotações, linha %d: %w", line, err)
+			}
+		}
+		rate, err := newRate(record[0], record[1], record[2], source, asOf)
+		if err != nil {
+			return nil, fmt.Errorf("cotações, linha %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// parseAsOf lê a data da cotação em RFC 3339 ou apenas a data (AAAA-MM-DD)
+func parseAsOf(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("data da cotação inválida: %q", value)
+	}
+	return t, nil
+}
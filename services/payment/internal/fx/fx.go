@@ -0,0 +1,180 @@
+// Package fx converte valores entre moedas a partir de cotações com 8 casas decimais.
+//
+// As cotações são inteiras (escaladas por RateScale) e a conversão é feita em
+// aritmética inteira, com arredondamento explícito na casa da moeda de destino.
+// As fontes de cotação implementam Provider: arquivo CSV (NewFileProvider) ou a
+// tabela fx_rates (NewDBProvider).
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// RateScale é a precisão das cotações: 8 casas decimais
+const RateScale = 100000000
+
+// rateDecimals é o número de casas decimais de RateScale
+const rateDecimals = 8
+
+// Erros de cotação
+var (
+	ErrRateNotFound = errors.New("cotação não encontrada")
+	ErrInvalidRate  = errors.New("cotação inválida")
+)
+
+// SourceIdentity identifica a "cotação" 1:1 usada quando as moedas são iguais
+const SourceIdentity = "identity"
+
+// Rate é a cotação de From para To: uma unidade de From vale Value/RateScale de To
+type Rate struct {
+	From   string
+	To     string
+	Value  int64
+	Source string
+	AsOf   time.Time
+}
+
+// Provider fornece a cotação vigente entre duas moedas
+type Provider interface {
+	Rate(from, to string) (Rate, error)
+}
+
+// Identity retorna a cotação 1:1 de uma moeda para ela mesma
+func Identity(currency string) Rate {
+	currency = money.NormalizeCurrency(currency)
+	return Rate{From: currency, To: currency, Value: RateScale, Source: SourceIdentity}
+}
+
+// IsIdentity indica se a cotação não altera o valor
+func (r Rate) IsIdentity() bool {
+	return r.From == r.To
+}
+
+// Decimal formata a cotação com 8 casas decimais (ex.: "5.12345678")
+func (r Rate) Decimal() string {
+	return FormatRate(r.Value)
+}
+
+// Inverse retorna a cotação no sentido oposto, arredondada na oitava casa
+func (r Rate) Inverse() (Rate, error) {
+	if r.Value <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+	value, err := divideHalfUp(new(big.Int).Mul(big.NewInt(RateScale), big.NewInt(RateScale)), big.NewInt(r.Value))
+	if err != nil || value <= 0 {
+		return Rate{}, fmt.Errorf("%w: inversa de %s/%s fora do limite", ErrInvalidRate, r.From, r.To)
+	}
+	return Rate{From: r.To, To: r.From, Value: value, Source: r.Source, AsOf: r.AsOf}, nil
+}
+
+// Convert converte um valor de From para To, arredondando meio centavo para cima
+func (r Rate) Convert(value money.Money) (money.Money, error) {
+	if money.NormalizeCurrency(value.Currency) != r.From {
+		return money.Money{}, fmt.Errorf("%w: valor em %s, cotação de %s", money.ErrCurrencyMismatch, value.Currency, r.From)
+	}
+	if r.IsIdentity() {
+		return money.New(value.Amount, r.To), nil
+	}
+	if r.Value <= 0 {
+		return money.Money{}, ErrInvalidRate
+	}
+
+	// amount * rate * 10^expTo / (RateScale * 10^expFrom)
+	n := new(big.Int).Mul(big.NewInt(value.Amount), big.NewInt(r.Value))
+	n.Mul(n, pow10(money.Exponent(r.To)))
+	d := new(big.Int).Mul(big.NewInt(RateScale), pow10(money.Exponent(r.From)))
+	amount, err := divideHalfUp(n, d)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return money.New(amount, r.To), nil
+}
+
+// ParseRate lê uma cotação decimal com até 8 casas (ex.: "5.1234" ou "0,19")
+func ParseRate(value string) (int64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" || !digits(whole) || !digits(fraction) || len(fraction) > rateDecimals {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+
+	scaled, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", rateDecimals-len(fraction)), 10)
+	if !ok || !scaled.IsInt64() || scaled.Sign() <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+	return scaled.Int64(), nil
+}
+
+// FormatRate formata uma cotação escalada com 8 casas decimais
+func FormatRate(value int64) string {
+	s := fmt.Sprintf("%0*d", rateDecimals+1, value)
+	return s[:len(s)-rateDecimals] + "." + s[len(s)-rateDecimals:]
+}
+
+// lookup procura a cotação direta entre as informadas e, na falta dela, inverte a
+// cotação no sentido oposto
+func lookup(rates []Rate, from, to string) (Rate, error) {
+	from, to = money.NormalizeCurrency(from), money.NormalizeCurrency(to)
+	if from == to {
+		return Identity(from), nil
+	}
+	for _, rate := range rates {
+		if rate.From == from && rate.To == to {
+			return rate, nil
+		}
+	}
+	for _, rate := range rates {
+		if rate.From == to && rate.To == from {
+			return rate.Inverse()
+		}
+	}
+	return Rate{}, fmt.Errorf("%w: %s/%s", ErrRateNotFound, from, to)
+}
+
+// newRate valida e monta uma cotação
+func newRate(from, to, value, source string, asOf time.Time) (Rate, error) {
+	from, to = money.NormalizeCurrency(from), money.NormalizeCurrency(to)
+	if !money.ValidCurrency(from) || !money.ValidCurrency(to) || from == to {
+		return Rate{}, fmt.Errorf("%w: par %s/%s", ErrInvalidRate, from, to)
+	}
+	scaled, err := ParseRate(value)
+	if err != nil {
+		return Rate{}, err
+	}
+	return Rate{From: from, To: to, Value: scaled, Source: source, AsOf: asOf}, nil
+}
+
+// divideHalfUp calcula n / d arredondando a metade para longe do zero
+func divideHalfUp(n, d *big.Int) (int64, error) {
+	negative := n.Sign()*d.Sign() < 0
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(n), new(big.Int).Abs(d), new(big.Int))
+	if new(big.Int).Lsh(r, 1).Cmp(new(big.Int).Abs(d)) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if negative {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("%w: valor fora do limite", money.ErrInvalidAmount)
+	}
+	return q.Int64(), nil
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,117 @@
+package fx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+func TestParseRate(t *testing.T) {
+	value, err := ParseRate("5.4321")
+	require.NoError(t, err)
+	assert.Equal(t, int64(543210000), value)
+	assert.Equal(t, "5.43210000", FormatRate(value))
+
+	value, err = ParseRate("0,00000001")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+	assert.Equal(t, "0.00000001", FormatRate(value))
+
+	for _, invalid := range []string{"", ".", "0", "-1", "1.123456789", "abc", "1e3"} {
+		_, err := ParseRate(invalid)
+		assert.ErrorIs(t, err, ErrInvalidRate, invalid)
+	}
+}
+
+func TestConvert_RoundsHalfUpInTargetCurrency(t *testing.T) {
+	rate, err := newRate("BRL", "USD", "0.18518519", SourceFile, time.Time{})
+	require.NoError(t, err)
+
+	usd, err := rate.Convert(money.New(18000, "BRL")) // 33,3333342 USD
+	require.NoError(t, err)
+	assert.Equal(t, money.New(3333, "USD"), usd)
+
+	usd, err = rate.Convert(money.New(27, "BRL")) // 0,04999 USD
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), usd.Amount)
+}
+
+func TestConvert_AdjustsCurrencyExponent(t *testing.T) {
+	rate, err := newRate("BRL", "CLP", "172.41379310", SourceFile, time.Time{})
+	require.NoError(t, err)
+
+	clp, err := rate.Convert(money.New(10050, "BRL")) // R$ 100,50 = 17.327,586 CLP
+	require.NoError(t, err)
+	assert.Equal(t, money.New(17328, "CLP"), clp)
+
+	_, err = rate.Convert(money.New(100, "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+}
+
+func TestLookup_UsesInverseAndIdentity(t *testing.T) {
+	usdBrl, err := newRate("usd", "brl", "5.4", SourceFile, time.Time{})
+	require.NoError(t, err)
+	rates := []Rate{usdBrl}
+
+	direct, err := lookup(rates, "USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, int64(540000000), direct.Value)
+
+	inverse, err := lookup(rates, "BRL", "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "BRL", inverse.From)
+	assert.Equal(t, "0.18518519", inverse.Decimal())
+
+	identity, err := lookup(rates, "eur", "EUR")
+	require.NoError(t, err)
+	assert.True(t, identity.IsIdentity())
+
+	_, err = lookup(rates, "BRL", "EUR")
+	assert.ErrorIs(t, err, ErrRateNotFound)
+}
+
+func TestReadRates(t *testing.T) {
+	defaultAsOf := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC)
+	rates, err := ReadRates(strings.NewReader(`# comentário
+from,to,rate,as_of
+USD,BRL,5.40
+EUR,BRL,6.25,2025-11-27
+`), SourceFile, defaultAsOf)
+
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, defaultAsOf, rates[0].AsOf)
+	assert.Equal(t, time.Date(2025, 11, 27, 0, 0, 0, 0, time.UTC), rates[1].AsOf)
+	assert.Equal(t, SourceFile, rates[1].Source)
+
+	_, err = ReadRates(strings.NewReader("USD,USD,1\n"), SourceFile, defaultAsOf)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+	_, err = ReadRates(strings.NewReader("USD,BRL\n"), SourceFile, defaultAsOf)
+	assert.Error(t, err)
+}
+
+func TestFileProvider_ReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fx_rates.csv")
+	require.NoError(t, os.WriteFile(path, []byte("USD,BRL,5.40\n"), 0o644))
+
+	provider, err := NewFileProvider(path)
+	require.NoError(t, err)
+	rate, err := provider.Rate("USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, "5.40000000", rate.Decimal())
+
+	require.NoError(t, os.WriteFile(path, []byte("USD,BRL,5.50\n"), 0o644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	rate, err = provider.Rate("USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, "5.50000000", rate.Decimal())
+}
@@ -0,0 +1,142 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSecret = []byte("whsec_teste")
+	testNow    = time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","payment_ref":"stub_1"}`)
+	header := Sign(testSecret, body, testNow)
+	assert.True(t, strings.HasPrefix(header, "t=1710504000,v1="))
+
+	signedAt, err := Verify(testSecret, body, header, testNow.Add(time.Minute), DefaultTolerance)
+	require.NoError(t, err)
+	assert.True(t, signedAt.Equal(testNow))
+
+	// Durante a troca de segredo o provedor envia uma assinatura para cada segredo
+	rotated := header + ",v1=" + signature([]byte("segredo_antigo"), "1710504000", body)
+	_, err = Verify(testSecret, body, rotated, testNow, DefaultTolerance)
+	assert.NoError(t, err)
+	_, err = Verify([]byte("segredo_antigo"), body, rotated, testNow, DefaultTolerance)
+	assert.NoError(t, err)
+}
+
+func TestVerifyRejects(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","payment_ref":"stub_1"}`)
+	header := Sign(testSecret, body, testNow)
+
+	tests := []struct {
+		name   string
+		secret []byte
+		body   []byte
+		header string
+		now    time.Time
+		err    error
+	}{
+		{"corpo alterado", testSecret, []byte(`{"id":"evt_1","type":"payment.failed","payment_ref":"stub_1"}`), header, testNow, ErrInvalidSignature},
+		{"segredo errado", []byte("outro"), body, header, testNow, ErrInvalidSignature},
+		{"sem segredo", nil, body, header, testNow, ErrInvalidSignature},
+		{"carimbo trocado", testSecret, body, strings.Replace(header, "t=1710504000", "t=1710504060", 1), testNow, ErrInvalidSignature},
+		{"cabeçalho vazio", testSecret, body, "", testNow, ErrInvalidSignature},
+		{"sem v1", testSecret, body, "t=1710504000", testNow, ErrInvalidSignature},
+		{"carimbo inválido", testSecret, body, "t=ontem,v1=abc", testNow, ErrInvalidSignature},
+		{"reutilizado", testSecret, body, header, testNow.Add(DefaultTolerance + time.Second), ErrStaleTimestamp},
+		{"do futuro", testSecret, body, header, testNow.Add(-DefaultTolerance - time.Second), ErrStaleTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Verify(tt.secret, tt.body, tt.header, tt.now, DefaultTolerance)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"payment.failed","payment_ref":"stub_1","amount":{"amount":1999,"currency":"BRL"},"reason":"saldo insuficiente"}`))
+	require.NoError(t, err)
+	assert.Equal(t, EventPaymentFailed, event.Type)
+	assert.Equal(t, money.New(1999, "BRL"), *event.Amount)
+	assert.Equal(t, "saldo insuficiente", event.Reason)
+
+	invalid := []string{
+		`não é json`,
+		`{"type":"payment.failed","payment_ref":"stub_1"}`,
+		`{"id":"evt_1","payment_ref":"stub_1"}`,
+		`{"id":"evt_1","type":"payment.failed","payment_ref":" "}`,
+		`{"id":"` + strings.Repeat("x", 65) + `","type":"payment.failed","payment_ref":"stub_1"}`,
+	}
+	for _, body := range invalid {
+		_, err := ParseEvent([]byte(body))
+		assert.ErrorIs(t, err, ErrInvalidEvent, body)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	assert.False(t, Config{}.Async())
+	assert.False(t, Config{Provider: ProviderSimulated}.Async())
+	assert.True(t, Config{Provider: "stub"}.Async())
+
+	ref := Config{Provider: "stub"}.NewRef()
+	assert.Regexp(t, `^stub_[0-9a-f]{24}$`, ref)
+	assert.NotEqual(t, ref, Config{Provider: "stub"}.NewRef())
+	assert.True(t, strings.HasPrefix(Config{}.NewRef(), ProviderSimulated+"_"))
+}
+
+func TestStubSend(t *testing.T) {
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if _, err := Verify(testSecret, body, r.Header.Get(SignatureHeader), time.Now(), DefaultTolerance); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		event, err := ParseEvent(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	stub := NewStub(server.URL, string(testSecret))
+	event := stub.Event(EventPaymentSucceeded, "stub_1", money.New(1999, "BRL"))
+	assert.Regexp(t, `^evt_[0-9a-f]{24}$`, event.ID)
+
+	status, err := stub.Send(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, received, 1)
+	assert.Equal(t, event.ID, received[0].ID)
+	assert.Equal(t, "stub_1", received[0].PaymentRef)
+
+	// Relógio do provedor atrasado além da tolerância
+	stub.Now = func() time.Time { return time.Now().Add(-time.Hour) }
+	status, err = stub.Send(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Segredo diferente do configurado no webhook
+	body, _ := json.Marshal(event)
+	status, err = stub.SendRaw(context.Background(), body, Sign([]byte("outro"), body, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Len(t, received, 1)
+}
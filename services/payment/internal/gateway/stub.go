@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Stub é um provedor de pagamentos local: monta eventos e os envia assinados ao webhook
+// do payment-service, como um gateway real faria. Now permite simular relógios
+// adiantados ou atrasados.
+type Stub struct {
+	URL    string
+	Secret []byte
+	Client *http.Client
+	Now    func() time.Time
+}
+
+// NewStub cria o provedor local que envia webhooks para url assinados com secret
+func NewStub(url, secret string) *Stub {
+	return &Stub{
+		URL:    url,
+		Secret: []byte(secret),
+		Client: &http.Client{Timeout: 10 * time.Second},
+		Now:    time.Now,
+	}
+}
+
+// Event monta um evento do tipo informado para o pagamento ref, com um ID novo
+func (s *Stub) Event(eventType, ref string, amount money.Money) Event {
+	return Event{
+		ID:         "evt_" + randomHex(12),
+		Type:       eventType,
+		PaymentRef: ref,
+		Amount:     &amount,
+		CreatedAt:  s.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// Send envia o evento assinado com o horário atual e devolve o status HTTP da resposta
+func (s *Stub) Send(ctx context.Context, event Event) (int, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	return s.SendRaw(ctx, body, Sign(s.Secret, body, s.Now()))
+}
+
+// SendRaw envia o corpo com o cabeçalho de assinatura informado, para reenviar um
+// webhook já assinado ou testar assinaturas inválidas
+func (s *Stub) SendRaw(ctx context.Context, body []byte, signature string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao enviar webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,161 @@
+// Package gateway trata os eventos assíncronos dos provedores de pagamento (gateways):
+// o formato dos eventos, a assinatura HMAC com carimbo de tempo dos webhooks e um
+// provedor local (Stub) que dispara webhooks assinados em desenvolvimento e testes.
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// SignatureHeader é o cabeçalho com o carimbo de tempo e a assinatura do webhook, no
+// formato "t=<unix>,v1=<hex>"; mais de um v1 é aceito durante a troca de segredo
+const SignatureHeader = "X-Gateway-Signature"
+
+// DefaultTolerance é a diferença máxima entre o carimbo de tempo e o relógio local
+const DefaultTolerance = 5 * time.Minute
+
+// ProviderSimulated é o provedor padrão: cartões aprovados ou recusados na hora, sem
+// webhook
+const ProviderSimulated = "simulated"
+
+// Tipos de evento tratados
+const (
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+)
+
+// Erros de verificação e leitura dos webhooks
+var (
+	ErrInvalidSignature = errors.New("assinatura do webhook inválida")
+	ErrStaleTimestamp   = errors.New("carimbo de tempo do webhook fora da tolerância")
+	ErrInvalidEvent     = errors.New("evento do webhook inválido")
+)
+
+// Event é um evento enviado pelo provedor. ID identifica o evento (reenvios mantêm o
+// mesmo ID); PaymentRef é a referência do pagamento no provedor; Amount, quando
+// presente, é conferido com o valor cobrado.
+type Event struct {
+	ID         string       `json:"id"`
+	Type       string       `json:"type"`
+	PaymentRef string       `json:"payment_ref"`
+	Amount     *money.Money `json:"amount,omitempty"`
+	Reason     string       `json:"reason,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// Config é o provedor de pagamentos com cartão. No provedor simulated o resultado é
+// imediato; nos demais o pagamento fica pendente até o webhook do provedor.
+type Config struct {
+	Provider string
+}
+
+// Async informa se o resultado dos pagamentos chega pelo webhook
+func (c Config) Async() bool {
+	return c.Provider != "" && c.Provider != ProviderSimulated
+}
+
+// NewRef gera a referência de um pagamento no provedor (ex.: stub_4f1c...)
+func (c Config) NewRef() string {
+	provider := c.Provider
+	if provider == "" {
+		provider = ProviderSimulated
+	}
+	return provider + "_" + randomHex(12)
+}
+
+// ParseEvent lê e valida o corpo de um webhook
+func ParseEvent(body []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	switch {
+	case strings.TrimSpace(event.ID) == "":
+		return Event{}, fmt.Errorf("%w: id é obrigatório", ErrInvalidEvent)
+	case strings.TrimSpace(event.Type) == "":
+		return Event{}, fmt.Errorf("%w: type é obrigatório", ErrInvalidEvent)
+	case strings.TrimSpace(event.PaymentRef) == "":
+		return Event{}, fmt.Errorf("%w: payment_ref é obrigatório", ErrInvalidEvent)
+	case len(event.ID) > 64 || len(event.PaymentRef) > 64:
+		return Event{}, fmt.Errorf("%w: id e payment_ref têm até 64 caracteres", ErrInvalidEvent)
+	}
+	return event, nil
+}
+
+// Sign assina o corpo do webhook com HMAC-SHA256 sobre "<unix>.<corpo>" e devolve o
+// valor do cabeçalho SignatureHeader
+func Sign(secret, body []byte, timestamp time.Time) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + unix + ",v1=" + signature(secret, unix, body)
+}
+
+// Verify confere a assinatura do webhook em tempo constante e se o carimbo de tempo
+// está a até tolerance de now, o que impede a reutilização de webhooks capturados.
+// Retorna o instante da assinatura. Sem segredo nenhuma assinatura é aceita.
+func Verify(secret, body []byte, header string, now time.Time, tolerance time.Duration) (time.Time, error) {
+	if len(secret) == 0 {
+		return time.Time{}, ErrInvalidSignature
+	}
+
+	var unix string
+	var candidates []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			candidates = append(candidates, value)
+		}
+	}
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(candidates) == 0 {
+		return time.Time{}, fmt.Errorf("%w: cabeçalho %s malformado", ErrInvalidSignature, SignatureHeader)
+	}
+
+	expected := []byte(signature(secret, unix, body))
+	valid := false
+	for _, candidate := range candidates {
+		if hmac.Equal(expected, []byte(candidate)) {
+			valid = true
+		}
+	}
+	if !valid {
+		return time.Time{}, ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(seconds, 0)
+	if diff := now.Sub(signedAt); diff > tolerance || diff < -tolerance {
+		return signedAt, fmt.Errorf("%w: assinado em %s", ErrStaleTimestamp, signedAt.UTC().Format(time.RFC3339))
+	}
+	return signedAt, nil
+}
+
+// signature calcula o HMAC-SHA256 em hexadecimal de "<unix>.<corpo>"
+func signature(secret []byte, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex gera size bytes aleatórios em hexadecimal
+func randomHex(size int) string {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("falha ao gerar identificador aleatório: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
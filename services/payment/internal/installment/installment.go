@@ -0,0 +1,170 @@
+// Package installment monta planos de parcelamento de pagamentos com cartão.
+//
+// Até Rules.MaxInterestFree parcelas o valor é dividido sem juros; acima disso as
+// parcelas seguem a Tabela Price com a taxa mensal do lojista. Todo o cálculo é
+// feito em frações exatas (math/big) e arredondado para o centavo apenas no valor
+// da parcela.
+package installment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Erros de validação do plano
+var (
+	ErrInvalidInstallments = errors.New("número de parcelas inválido")
+	ErrInstallmentTooSmall = errors.New("valor da parcela abaixo do mínimo")
+	ErrCurrencyNotAllowed  = errors.New("parcelamento indisponível nesta moeda")
+)
+
+// rateScale é a precisão da taxa de juros: 4 casas decimais do percentual
+const rateScale = 10000
+
+// Rules são as regras de parcelamento do lojista
+type Rules struct {
+	// MaxInstallments é o máximo de parcelas aceito
+	MaxInstallments int
+	// MaxInterestFree é o máximo de parcelas sem juros
+	MaxInterestFree int
+	// MonthlyRate é a taxa de juros ao mês, em percentual (ex.: 1.99), acima de MaxInterestFree
+	MonthlyRate float64
+	// MinInstallment é o valor mínimo de cada parcela, em unidades menores da moeda
+	MinInstallment int64
+	// Currency é a única moeda em que o parcelamento é oferecido
+	Currency string
+}
+
+// Installment é uma parcela do plano
+type Installment struct {
+	Number  int
+	Amount  int64
+	DueDate time.Time
+}
+
+// Plan é um plano de parcelamento. Total é a soma das parcelas e Interest, os juros
+// cobrados sobre Principal.
+type Plan struct {
+	Installments int
+	InterestFree bool
+	MonthlyRate  float64
+	Currency     string
+	Principal    int64
+	Interest     int64
+	Total        int64
+	Schedule     []Installment
+}
+
+// Validate verifica se o número de parcelas é aceito para o valor
+func (r Rules) Validate(amount money.Money, installments int) error {
+	if installments < 1 || installments > r.MaxInstallments {
+		return fmt.Errorf("%w: %d (máximo %d)", ErrInvalidInstallments, installments, r.MaxInstallments)
+	}
+	if installments == 1 {
+		return nil
+	}
+	if money.NormalizeCurrency(amount.Currency) != money.NormalizeCurrency(r.Currency) {
+		return fmt.Errorf("%w: %s", ErrCurrencyNotAllowed, amount.Currency)
+	}
+	return nil
+}
+
+// Build valida e monta o plano, com a primeira parcela vencendo um mês após start.
+// Uma única parcela é o pagamento à vista.
+func (r Rules) Build(amount money.Money, installments int, start time.Time) (Plan, error) {
+	if err := r.Validate(amount, installments); err != nil {
+		return Plan{}, err
+	}
+	if !amount.IsPositive() {
+		return Plan{}, fmt.Errorf("%w: valor %s", ErrInvalidInstallments, amount)
+	}
+
+	plan := Plan{
+		Installments: installments,
+		InterestFree: installments <= r.MaxInterestFree || installments == 1 || r.MonthlyRate <= 0,
+		Currency:     money.NormalizeCurrency(amount.Currency),
+		Principal:    amount.Amount,
+	}
+
+	var amounts []int64
+	if plan.InterestFree {
+		amounts = splitEvenly(amount.Amount, installments)
+	} else {
+		plan.MonthlyRate = r.MonthlyRate
+		payment, err := pricePayment(amount.Amount, r.MonthlyRate, installments)
+		if err != nil {
+			return Plan{}, err
+		}
+		amounts = make([]int64, installments)
+		for i := range amounts {
+			amounts[i] = payment
+		}
+	}
+
+	for i, value := range amounts {
+		if installments > 1 && value < r.MinInstallment {
+			return Plan{}, fmt.Errorf("%w: %s em %dx (mínimo %s)", ErrInstallmentTooSmall,
+				money.New(value, plan.Currency), installments, money.New(r.MinInstallment, plan.Currency))
+		}
+		plan.Schedule = append(plan.Schedule, Installment{Number: i + 1, Amount: value, DueDate: addMonths(start, i+1)})
+		plan.Total += value
+	}
+	plan.Interest = plan.Total - plan.Principal
+	return plan, nil
+}
+
+// splitEvenly divide o valor em parcelas iguais; os centavos que sobram vão para a
+// primeira parcela, como é costume nas operadoras
+func splitEvenly(amount int64, installments int) []int64 {
+	parts := make([]int64, installments)
+	base := amount / int64(installments)
+	for i := range parts {
+		parts[i] = base
+	}
+	parts[0] += amount - base*int64(installments)
+	return parts
+}
+
+// pricePayment calcula a parcela da Tabela Price, P·i·(1+i)^n / ((1+i)^n − 1),
+// arredondada meio centavo para cima
+func pricePayment(principal int64, monthlyRate float64, installments int) (int64, error) {
+	rate := big.NewRat(int64(math.Round(monthlyRate*rateScale)), 100*rateScale)
+	if rate.Sign() <= 0 {
+		return 0, fmt.Errorf("%w: taxa de juros %.4f%%", ErrInvalidInstallments, monthlyRate)
+	}
+
+	factor := new(big.Rat).SetInt64(1)
+	growth := new(big.Rat).Add(big.NewRat(1, 1), rate)
+	for i := 0; i < installments; i++ {
+		factor.Mul(factor, growth)
+	}
+
+	payment := new(big.Rat).Mul(new(big.Rat).SetInt64(principal), rate)
+	payment.Mul(payment, factor)
+	payment.Quo(payment, new(big.Rat).Sub(factor, big.NewRat(1, 1)))
+
+	// Arredondamento: floor(x + 1/2)
+	payment.Add(payment, big.NewRat(1, 2))
+	rounded := new(big.Int).Quo(payment.Num(), payment.Denom())
+	if !rounded.IsInt64() {
+		return 0, fmt.Errorf("%w: valor fora do limite", money.ErrInvalidAmount)
+	}
+	return rounded.Int64(), nil
+}
+
+// addMonths soma meses mantendo o dia, limitado ao último dia do mês
+// (31/01 + 1 mês = 28/02 ou 29/02)
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,79 @@
+package installment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+func testRules() Rules {
+	return Rules{MaxInstallments: 12, MaxInterestFree: 3, MonthlyRate: 1.99, MinInstallment: 500, Currency: "BRL"}
+}
+
+var start = time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+
+func TestBuild_InterestFreeSplitsRemainderIntoFirstInstallment(t *testing.T) {
+	plan, err := testRules().Build(money.New(10000, "BRL"), 3, start)
+
+	require.NoError(t, err)
+	assert.True(t, plan.InterestFree)
+	require.Len(t, plan.Schedule, 3)
+	assert.Equal(t, int64(3334), plan.Schedule[0].Amount)
+	assert.Equal(t, int64(3333), plan.Schedule[1].Amount)
+	assert.Equal(t, int64(3333), plan.Schedule[2].Amount)
+	assert.Equal(t, int64(10000), plan.Total)
+	assert.Equal(t, int64(0), plan.Interest)
+}
+
+func TestBuild_PriceTableAboveInterestFreeLimit(t *testing.T) {
+	// R$ 1.000,00 em 10x a 1,99% a.m.: parcela de R$ 111,27 (111,2683)
+	plan, err := testRules().Build(money.New(100000, "BRL"), 10, start)
+
+	require.NoError(t, err)
+	assert.False(t, plan.InterestFree)
+	assert.Equal(t, 1.99, plan.MonthlyRate)
+	require.Len(t, plan.Schedule, 10)
+	for _, item := range plan.Schedule {
+		assert.Equal(t, int64(11127), item.Amount)
+	}
+	assert.Equal(t, int64(111270), plan.Total)
+	assert.Equal(t, int64(11270), plan.Interest)
+}
+
+func TestBuild_ScheduleDueDatesClampToMonthEnd(t *testing.T) {
+	plan, err := testRules().Build(money.New(30000, "BRL"), 3, start)
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC), plan.Schedule[0].DueDate)
+	assert.Equal(t, time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC), plan.Schedule[1].DueDate)
+	assert.Equal(t, time.Date(2025, 4, 30, 10, 0, 0, 0, time.UTC), plan.Schedule[2].DueDate)
+}
+
+func TestBuild_SingleInstallmentIsUpfront(t *testing.T) {
+	plan, err := testRules().Build(money.New(300, "USD"), 1, start)
+
+	require.NoError(t, err)
+	assert.True(t, plan.InterestFree)
+	assert.Equal(t, int64(300), plan.Total)
+	assert.Equal(t, "USD", plan.Currency)
+}
+
+func TestBuild_RejectsPlansOutsideMerchantRules(t *testing.T) {
+	rules := testRules()
+
+	_, err := rules.Build(money.New(100000, "BRL"), 13, start)
+	assert.ErrorIs(t, err, ErrInvalidInstallments)
+
+	_, err = rules.Build(money.New(100000, "BRL"), 0, start)
+	assert.ErrorIs(t, err, ErrInvalidInstallments)
+
+	_, err = rules.Build(money.New(2000, "BRL"), 5, start)
+	assert.ErrorIs(t, err, ErrInstallmentTooSmall)
+
+	_, err = rules.Build(money.New(100000, "USD"), 2, start)
+	assert.ErrorIs(t, err, ErrCurrencyNotAllowed)
+}
@@ -7,21 +7,92 @@ import (
 	"log"
 	"time"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 	"payment-service/internal/config"
 	"payment-service/internal/service"
 
 	"github.com/rabbitmq/amqp091-go"
 )
 
-// OrderEvent representa o evento de pedido recebido do RabbitMQ
+// OrderEvent representa o evento de pedido recebido do RabbitMQ. Os valores vêm em
+// centavos com a moeda; eventos antigos, com números decimais, são lidos em reais.
+// PaymentCurrency é a moeda em que o cliente paga; vazia, a moeda do pedido.
+// Installments é o número de parcelas escolhido pelo cliente (0 ou 1, à vista) e
+// PaymentMethod a forma de pagamento (card, pix ou boleto; vazia, card).
+// DestinationState e Risk alimentam a análise antifraude e ReservationExpiresAt (RFC 3339)
+// é o vencimento da reserva de estoque, prazo para aprovar pagamentos retidos.
 type OrderEvent struct {
-	ID        uint    `json:"id"`
-	Customer  string  `json:"customer"`
-	ProductID uint    `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
-	CreatedAt string  `json:"created_at"`
-	EventType string  `json:"event_type"`
+	ID                   uint            `json:"id"`
+	Customer             string          `json:"customer"`
+	ProductID            uint            `json:"product_id"`
+	Quantity             int             `json:"quantity"`
+	Price                money.Money     `json:"price"`
+	Subtotal             money.Money     `json:"subtotal"`
+	DiscountTotal        money.Money     `json:"discount_total"`
+	TaxTotal             money.Money     `json:"tax_total"`
+	Total                money.Money     `json:"total"`
+	PaymentCurrency      string          `json:"payment_currency"`
+	Installments         int             `json:"installments"`
+	PaymentMethod        string          `json:"payment_method"`
+	Discounts            []OrderDiscount `json:"discounts,omitempty"`
+	DestinationState     string          `json:"destination_state"`
+	Risk                 *OrderRisk      `json:"risk,omitempty"`
+	ReservationExpiresAt string          `json:"reservation_expires_at,omitempty"`
+	CreatedAt            string          `json:"created_at"`
+	EventType            string          `json:"event_type"`
+}
+
+// OrderRisk são os sinais de risco informados na criação do pedido: a impressão
+// digital do cartão, a UF de cobrança, o país do IP do cliente e a data de criação da
+// conta (RFC 3339)
+type OrderRisk struct {
+	CardFingerprint string `json:"card_fingerprint,omitempty"`
+	BillingState    string `json:"billing_state,omitempty"`
+	IPCountry       string `json:"ip_country,omitempty"`
+	CustomerSince   string `json:"customer_since,omitempty"`
+}
+
+// paymentInput monta a cobrança do pedido com os sinais de risco do evento
+func (e OrderEvent) paymentInput() service.ProcessPaymentInput {
+	input := service.ProcessPaymentInput{
+		OrderID:       e.ID,
+		Amount:        e.Amount(),
+		Currency:      e.PaymentCurrency,
+		Installments:  e.Installments,
+		Method:        e.PaymentMethod,
+		Customer:      e.Customer,
+		ShippingState: e.DestinationState,
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, e.ReservationExpiresAt); err == nil {
+		input.ReservationExpiresAt = &expiresAt
+	}
+	if e.Risk != nil {
+		input.CardFingerprint = e.Risk.CardFingerprint
+		input.BillingState = e.Risk.BillingState
+		input.IPCountry = e.Risk.IPCountry
+		if since, err := time.Parse(time.RFC3339, e.Risk.CustomerSince); err == nil {
+			input.CustomerSince = &since
+		}
+	}
+	return input
+}
+
+// OrderDiscount é o desconto de uma promoção aplicado ao pedido
+type OrderDiscount struct {
+	Line   int         `json:"line"`
+	SKU    string      `json:"sku"`
+	Code   string      `json:"code"`
+	Type   string      `json:"type"`
+	Amount money.Money `json:"amount"`
+}
+
+// Amount retorna o valor líquido a cobrar. Eventos publicados antes dos descontos
+// não trazem o total e são cobrados pelo preço unitário vezes a quantidade.
+func (e OrderEvent) Amount() money.Money {
+	if e.Total.IsPositive() || e.DiscountTotal.IsPositive() {
+		return e.Total
+	}
+	return e.Price.Mul(int64(e.Quantity))
 }
 
 // OrderConsumer gerencia o consumo de mensagens da fila de pedidos
@@ -146,14 +217,14 @@ func (c *OrderConsumer) processMessage(delivery amqp091.Delivery) {
 		return
 	}
 
-	// Calcular total (preço * quantidade)
-	totalAmount := orderEvent.Price * float64(orderEvent.Quantity)
+	// Cobrar o total líquido, já com os descontos do pedido
+	totalAmount := orderEvent.Amount()
 
-	log.Printf("💳 Processando pagamento - OrderID: %d, Amount: %.2f",
-		orderEvent.ID, totalAmount)
+	log.Printf("💳 Processando pagamento - OrderID: %d, Amount: %s, Desconto: %s, Tributos: %s, Moeda de pagamento: %s, Parcelas: %d, Forma: %s",
+		orderEvent.ID, totalAmount, orderEvent.DiscountTotal, orderEvent.TaxTotal, orderEvent.PaymentCurrency, orderEvent.Installments, orderEvent.PaymentMethod)
 
 	// Processar pagamento
-	payment, err := c.paymentService.ProcessPayment(orderEvent.ID, totalAmount)
+	payment, err := c.paymentService.ProcessPayment(orderEvent.paymentInput())
 	if err != nil {
 		log.Printf("❌ Erro ao processar pagamento para OrderID %d: %v", orderEvent.ID, err)
 		// Rejeitar com requeue=true para tentar novamente
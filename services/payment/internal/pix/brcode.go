@@ -0,0 +1,307 @@
+// Package pix gera cobranças PIX no padrão BR Code do Banco Central: o payload EMV
+// (copia e cola) estático ou dinâmico, com txid e CRC16, e o QR Code em PNG. Também
+// assina e valida os webhooks de confirmação enviados pelo PSP.
+package pix
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+// Tipos de BR Code: o estático leva a chave PIX no payload; o dinâmico, a URL do
+// payload da cobrança no PSP (location), de uso único
+const (
+	KindStatic  = "static"
+	KindDynamic = "dynamic"
+)
+
+// Erros de geração e validação do BR Code
+var (
+	ErrInvalidPayload  = errors.New("payload BR Code inválido")
+	ErrInvalidConfig   = errors.New("configuração PIX inválida")
+	ErrInvalidCharge   = errors.New("cobrança PIX inválida")
+	ErrCurrencyNotBRL  = errors.New("PIX aceita apenas cobranças em BRL")
+	ErrChecksumInvalid = errors.New("CRC16 do BR Code não confere")
+)
+
+// IDs dos campos EMV usados no BR Code (Manual do BR Code, BACEN)
+const (
+	idPayloadFormat     = "00"
+	idPointOfInitiation = "01"
+	idMerchantAccount   = "26"
+	idCategoryCode      = "52"
+	idCurrency          = "53"
+	idAmount            = "54"
+	idCountryCode       = "58"
+	idMerchantName      = "59"
+	idMerchantCity      = "60"
+	idAdditionalData    = "62"
+	idCRC               = "63"
+
+	// Subcampos da conta do recebedor (26) e dos dados adicionais (62)
+	idGUI         = "00"
+	idKey         = "01"
+	idDescription = "02"
+	idLocation    = "25"
+	idTxID        = "05"
+
+	pixGUI        = "br.gov.bcb.pix"
+	currencyBRL   = "986"
+	oneTimeUse    = "12"
+	noTxID        = "***"
+	maxNameLen    = 25
+	maxCityLen    = 15
+	maxKeyLen     = 77
+	maxFieldLen   = 99
+	maxStaticTxID = 25
+	dynamicTxID   = 32
+)
+
+// Merchant identifica o recebedor: chave PIX, nome e cidade
+type Merchant struct {
+	Key  string
+	Name string
+	City string
+}
+
+// Config são os dados do recebedor e da geração de cobranças. Mode é static ou
+// dynamic; LocationURL é a base da URL do payload das cobranças dinâmicas no PSP
+// (sem https://), à qual o txid é acrescentado; Expiry é o prazo para pagamento.
+type Config struct {
+	Merchant    Merchant
+	Mode        string
+	LocationURL string
+	Expiry      time.Duration
+}
+
+// Charge é uma cobrança PIX gerada
+type Charge struct {
+	TxID      string
+	Kind      string
+	Payload   string
+	ExpiresAt time.Time
+}
+
+// Enabled informa se há uma chave PIX configurada
+func (c Config) Enabled() bool {
+	return strings.TrimSpace(c.Merchant.Key) != ""
+}
+
+// Validate verifica a configuração do recebedor
+func (c Config) Validate() error {
+	key := strings.TrimSpace(c.Merchant.Key)
+	if key == "" || len(key) > maxKeyLen {
+		return fmt.Errorf("%w: chave PIX deve ter de 1 a %d caracteres", ErrInvalidConfig, maxKeyLen)
+	}
+	if sanitize(c.Merchant.Name, maxNameLen) == "" || sanitize(c.Merchant.City, maxCityLen) == "" {
+		return fmt.Errorf("%w: nome e cidade do recebedor são obrigatórios", ErrInvalidConfig)
+	}
+	if c.Expiry <= 0 {
+		return fmt.Errorf("%w: prazo de expiração deve ser positivo", ErrInvalidConfig)
+	}
+	switch c.Mode {
+	case KindStatic:
+		return nil
+	case KindDynamic:
+		if location(c.LocationURL, strings.Repeat("0", dynamicTxID)) == "" {
+			return fmt.Errorf("%w: URL do payload obrigatória no modo dinâmico", ErrInvalidConfig)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: modo %q (use static ou dynamic)", ErrInvalidConfig, c.Mode)
+	}
+}
+
+// Issue gera uma cobrança do valor, com txid novo, vencendo em Expiry a partir de now.
+// description aparece no aplicativo do pagador apenas no BR Code estático.
+func (c Config) Issue(amount money.Money, description string, now time.Time) (Charge, error) {
+	if err := c.Validate(); err != nil {
+		return Charge{}, err
+	}
+	if money.NormalizeCurrency(amount.Currency) != money.DefaultCurrency {
+		return Charge{}, fmt.Errorf("%w: %s", ErrCurrencyNotBRL, amount.Currency)
+	}
+	if !amount.IsPositive() {
+		return Charge{}, fmt.Errorf("%w: valor %s", ErrInvalidCharge, amount)
+	}
+
+	charge := Charge{Kind: c.Mode, ExpiresAt: now.Add(c.Expiry)}
+	var err error
+	if c.Mode == KindStatic {
+		charge.TxID = NewTxID(maxStaticTxID)
+		charge.Payload, err = StaticPayload(c.Merchant, amount, charge.TxID, description)
+	} else {
+		charge.TxID = NewTxID(dynamicTxID)
+		charge.Payload, err = DynamicPayload(c.Merchant, location(c.LocationURL, charge.TxID), amount)
+	}
+	if err != nil {
+		return Charge{}, err
+	}
+	return charge, nil
+}
+
+// StaticPayload monta o BR Code estático: chave PIX, valor (opcional, zero para o
+// pagador informar), txid de até 25 caracteres alfanuméricos e descrição opcional
+func StaticPayload(m Merchant, amount money.Money, txID, description string) (string, error) {
+	if txID == "" {
+		txID = noTxID
+	} else if len(txID) > maxStaticTxID || !alphanumeric(txID) {
+		return "", fmt.Errorf("%w: txid %q (até %d caracteres alfanuméricos)", ErrInvalidCharge, txID, maxStaticTxID)
+	}
+
+	account := field(idGUI, pixGUI) + field(idKey, strings.TrimSpace(m.Key))
+	if description = sanitize(description, maxFieldLen); description != "" {
+		// A descrição ocupa o espaço que sobra no campo 26
+		room := maxFieldLen - len(account) - 4
+		if room > 0 {
+			account += field(idDescription, truncate(description, room))
+		}
+	}
+	return build(m, "", account, amount, txID)
+}
+
+// DynamicPayload monta o BR Code dinâmico, de uso único, que aponta para o payload da
+// cobrança no PSP; o txid é informado pelo PSP a partir da URL
+func DynamicPayload(m Merchant, locationURL string, amount money.Money) (string, error) {
+	if locationURL == "" || len(locationURL) > maxKeyLen {
+		return "", fmt.Errorf("%w: URL do payload %q", ErrInvalidCharge, locationURL)
+	}
+	account := field(idGUI, pixGUI) + field(idLocation, locationURL)
+	return build(m, oneTimeUse, account, amount, noTxID)
+}
+
+// build monta o payload completo e acrescenta o CRC16
+func build(m Merchant, initiation, account string, amount money.Money, txID string) (string, error) {
+	if len(account) > maxFieldLen {
+		return "", fmt.Errorf("%w: dados da conta excedem %d caracteres", ErrInvalidCharge, maxFieldLen)
+	}
+	if !amount.IsZero() && money.NormalizeCurrency(amount.Currency) != money.DefaultCurrency {
+		return "", fmt.Errorf("%w: %s", ErrCurrencyNotBRL, amount.Currency)
+	}
+	name, city := sanitize(m.Name, maxNameLen), sanitize(m.City, maxCityLen)
+	if name == "" || city == "" {
+		return "", fmt.Errorf("%w: nome e cidade do recebedor são obrigatórios", ErrInvalidConfig)
+	}
+
+	var b strings.Builder
+	b.WriteString(field(idPayloadFormat, "01"))
+	if initiation != "" {
+		b.WriteString(field(idPointOfInitiation, initiation))
+	}
+	b.WriteString(field(idMerchantAccount, account))
+	b.WriteString(field(idCategoryCode, "0000"))
+	b.WriteString(field(idCurrency, currencyBRL))
+	if amount.IsPositive() {
+		b.WriteString(field(idAmount, amount.Decimal()))
+	}
+	b.WriteString(field(idCountryCode, "BR"))
+	b.WriteString(field(idMerchantName, name))
+	b.WriteString(field(idMerchantCity, city))
+	b.WriteString(field(idAdditionalData, field(idTxID, txID)))
+	b.WriteString(idCRC + "04")
+	b.WriteString(checksum(b.String()))
+	return b.String(), nil
+}
+
+// Verify valida a estrutura TLV do payload e o CRC16 final
+func Verify(payload string) error {
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != idCRC+"04" {
+		return fmt.Errorf("%w: CRC ausente", ErrInvalidPayload)
+	}
+	for rest := payload; rest != ""; {
+		if len(rest) < 4 {
+			return fmt.Errorf("%w: campo truncado", ErrInvalidPayload)
+		}
+		size, err := strconv.Atoi(rest[2:4])
+		if err != nil || len(rest) < 4+size {
+			return fmt.Errorf("%w: tamanho do campo %s", ErrInvalidPayload, rest[:2])
+		}
+		rest = rest[4+size:]
+	}
+	if want := checksum(payload[:len(payload)-4]); payload[len(payload)-4:] != want {
+		return fmt.Errorf("%w: esperado %s", ErrChecksumInvalid, want)
+	}
+	return nil
+}
+
+// NewTxID gera um txid alfanumérico aleatório com o tamanho informado
+func NewTxID(size int) string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	// Bytes acima do último múltiplo do alfabeto são descartados para não enviesar
+	limit := byte(256 - 256%len(alphabet))
+	txID := make([]byte, 0, size)
+	buf := make([]byte, size)
+	for len(txID) < size {
+		if _, err := rand.Read(buf); err != nil {
+			panic(fmt.Sprintf("pix: falha ao gerar txid: %v", err))
+		}
+		for _, b := range buf {
+			if b < limit && len(txID) < size {
+				txID = append(txID, alphabet[int(b)%len(alphabet)])
+			}
+		}
+	}
+	return string(txID)
+}
+
+// field codifica um campo EMV: ID, tamanho com dois dígitos e valor
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// location monta a URL do payload da cobrança dinâmica, sem o esquema
+func location(base, txID string) string {
+	base = strings.TrimSpace(base)
+	base = strings.TrimPrefix(strings.TrimPrefix(base, "https://"), "http://")
+	base = strings.TrimSuffix(base, "/")
+	if base == "" {
+		return ""
+	}
+	return base + "/" + txID
+}
+
+// alphanumeric informa se o texto tem apenas letras e dígitos ASCII
+func alphanumeric(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// accents mapeia letras acentuadas para ASCII; o BR Code aceita apenas ASCII
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a", "é", "e", "ê", "e", "è", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i", "ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u", "ç", "c", "ñ", "n",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A", "É", "E", "Ê", "E", "È", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I", "Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U", "Ç", "C", "Ñ", "N",
+)
+
+// sanitize remove acentos e caracteres fora do ASCII imprimível e limita o tamanho
+func sanitize(s string, limit int) string {
+	s = accents.Replace(strings.TrimSpace(s))
+	var b strings.Builder
+	for _, r := range s {
+		if r >= ' ' && r <= '~' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(truncate(b.String(), limit))
+}
+
+// truncate limita o texto ASCII a limit caracteres
+func truncate(s string, limit int) string {
+	if len(s) > limit {
+		return s[:limit]
+	}
+	return s
+}
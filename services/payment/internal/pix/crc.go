@@ -0,0 +1,20 @@
+package pix
+
+import "fmt"
+
+// checksum calcula o CRC16-CCITT (polinômio 0x1021, valor inicial 0xFFFF) do payload,
+// incluindo o ID e o tamanho do campo 63, em quatro dígitos hexadecimais maiúsculos
+func checksum(payload string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(payload); i++ {
+		crc ^= uint16(payload[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
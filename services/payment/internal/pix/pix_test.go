@@ -0,0 +1,116 @@
+package pix
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+)
+
+var merchant = Merchant{Key: "123e4567-e12b-12d1-a456-426655440000", Name: "Fulano de Tal", City: "BRASILIA"}
+
+func TestChecksum(t *testing.T) {
+	assert.Equal(t, "29B1", checksum("123456789"))
+}
+
+func TestStaticPayload_MatchesBACENExample(t *testing.T) {
+	// Exemplo do Manual do BR Code: chave aleatória, sem valor e sem txid
+	payload, err := StaticPayload(merchant, money.Zero("BRL"), "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D", payload)
+	assert.NoError(t, Verify(payload))
+}
+
+func TestStaticPayload_WithAmountTxIDAndDescription(t *testing.T) {
+	m := Merchant{Key: "loja@example.com", Name: "Loja São João Comércio de Eletrônicos", City: "São Paulo"}
+	payload, err := StaticPayload(m, money.New(199990, "BRL"), "PEDIDO42", "Pedido 42")
+
+	require.NoError(t, err)
+	require.NoError(t, Verify(payload))
+	assert.Contains(t, payload, "0116loja@example.com0209Pedido 42")
+	assert.Contains(t, payload, "54071999.90")
+	assert.Contains(t, payload, "5925Loja Sao Joao Comercio de")
+	assert.Contains(t, payload, "6009Sao Paulo")
+	assert.Contains(t, payload, "62120508PEDIDO42")
+
+	_, err = StaticPayload(m, money.New(100, "BRL"), "pedido-42", "")
+	assert.ErrorIs(t, err, ErrInvalidCharge)
+	_, err = StaticPayload(m, money.New(100, "USD"), "", "")
+	assert.ErrorIs(t, err, ErrCurrencyNotBRL)
+}
+
+func TestDynamicPayload_PointsToLocation(t *testing.T) {
+	payload, err := DynamicPayload(merchant, "pix.example.com/qr/v2/abc123", money.New(5000, "BRL"))
+
+	require.NoError(t, err)
+	require.NoError(t, Verify(payload))
+	assert.True(t, strings.HasPrefix(payload, "000201010212"))
+	assert.Contains(t, payload, "0014br.gov.bcb.pix2528pix.example.com/qr/v2/abc123")
+	assert.Contains(t, payload, "62070503***")
+}
+
+func TestVerify_RejectsTamperedPayload(t *testing.T) {
+	payload, err := StaticPayload(merchant, money.New(1000, "BRL"), "ABC", "")
+	require.NoError(t, err)
+
+	tampered := strings.Replace(payload, "540510.00", "540599.00", 1)
+	assert.ErrorIs(t, Verify(tampered), ErrChecksumInvalid)
+	assert.ErrorIs(t, Verify(payload[:len(payload)-10]), ErrInvalidPayload)
+}
+
+func TestIssue_GeneratesChargePerMode(t *testing.T) {
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	cfg := Config{Merchant: merchant, Mode: KindDynamic, LocationURL: "https://pix.example.com/qr/v2/", Expiry: 15 * time.Minute}
+
+	charge, err := cfg.Issue(money.New(18000, "BRL"), "Pedido 7", now)
+	require.NoError(t, err)
+	assert.Equal(t, KindDynamic, charge.Kind)
+	assert.Len(t, charge.TxID, 32)
+	assert.Equal(t, now.Add(15*time.Minute), charge.ExpiresAt)
+	assert.Contains(t, charge.Payload, "pix.example.com/qr/v2/"+charge.TxID)
+	assert.NoError(t, Verify(charge.Payload))
+
+	cfg.Mode = KindStatic
+	charge, err = cfg.Issue(money.New(18000, "BRL"), "Pedido 7", now)
+	require.NoError(t, err)
+	assert.Len(t, charge.TxID, 25)
+	assert.Contains(t, charge.Payload, "0525"+charge.TxID)
+
+	_, err = cfg.Issue(money.New(18000, "USD"), "", now)
+	assert.ErrorIs(t, err, ErrCurrencyNotBRL)
+
+	cfg.Mode = KindDynamic
+	cfg.LocationURL = ""
+	_, err = cfg.Issue(money.New(18000, "BRL"), "", now)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestQRCodePNG(t *testing.T) {
+	payload, err := StaticPayload(merchant, money.New(1000, "BRL"), "ABC", "")
+	require.NoError(t, err)
+
+	png, err := QRCodePNG(payload, 0)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG\r\n\x1a\n")))
+
+	_, err = QRCodePNG("texto qualquer", DefaultQRCodeSize)
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("segredo")
+	body := []byte(`{"pix":[{"txid":"ABC"}]}`)
+	signature := Sign(secret, body)
+
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.True(t, VerifySignature(secret, body, signature))
+	assert.False(t, VerifySignature(secret, []byte(`{"pix":[]}`), signature))
+	assert.False(t, VerifySignature([]byte("outro"), body, signature))
+	assert.False(t, VerifySignature(nil, body, Sign(nil, body)))
+}
@@ -0,0 +1,30 @@
+package pix
+
+import (
+	"fmt"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Tamanhos aceitos para a imagem do QR Code, em pixels
+const (
+	DefaultQRCodeSize = 256
+	MaxQRCodeSize     = 1024
+	minQRCodeSize     = 128
+)
+
+// QRCodePNG gera o QR Code do payload em PNG, com correção de erros média (15%),
+// como recomenda o Manual do BR Code. Tamanhos fora do intervalo aceito usam o padrão.
+func QRCodePNG(payload string, size int) ([]byte, error) {
+	if err := Verify(payload); err != nil {
+		return nil, err
+	}
+	if size < minQRCodeSize || size > MaxQRCodeSize {
+		size = DefaultQRCodeSize
+	}
+	png, err := qrcode.Encode(payload, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao gerar QR Code: %w", err)
+	}
+	return png, nil
+}
@@ -0,0 +1,30 @@
+package pix
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader é o cabeçalho com a assinatura do corpo do webhook
+const SignatureHeader = "X-Pix-Signature"
+
+// signaturePrefix identifica o algoritmo da assinatura
+const signaturePrefix = "sha256="
+
+// Sign assina o corpo do webhook com HMAC-SHA256 ("sha256=<hex>")
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature confere, em tempo constante, a assinatura do corpo do webhook.
+// Sem segredo configurado nenhuma assinatura é aceita.
+func VerifySignature(secret, body []byte, signature string) bool {
+	if len(secret) == 0 || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(strings.TrimSpace(signature)))
+}
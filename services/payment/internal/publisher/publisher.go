@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
 	"payment-service/internal/config"
 	"payment-service/internal/domain"
 
@@ -17,17 +18,41 @@ type PaymentPublisher struct {
 	conn      *amqp091.Connection
 	channel   *amqp091.Channel
 	queueName string
+	// orderQueueName recebe uma cópia de cada evento para o order-service
+	// confirmar ou liberar a reserva de estoque do pedido
+	orderQueueName string
 }
 
-// PaymentEvent representa o evento de pagamento a ser enviado
+// PaymentEvent representa o evento de pagamento a ser enviado. Amount é o valor
+// cobrado e OrderAmount o total na moeda do pedido, convertido pela cotação FXRate.
+// Customer é o ID do usuário dono do pedido, destinatário das notificações.
+// Eventos de contestação têm EventType payment.disputed e trazem a contestação em Dispute.
 type PaymentEvent struct {
-	PaymentID uint    `json:"payment_id"`
-	OrderID   uint    `json:"order_id"`
-	Status    string  `json:"status"`
-	Amount    float64 `json:"amount"`
-	CreatedAt string  `json:"created_at"`
+	PaymentID   uint          `json:"payment_id"`
+	OrderID     uint          `json:"order_id"`
+	Customer    string        `json:"customer,omitempty"`
+	Status      string        `json:"status"`
+	Amount      money.Money   `json:"amount"`
+	OrderAmount money.Money   `json:"order_amount"`
+	FXRate      string        `json:"fx_rate"`
+	CreatedAt   string        `json:"created_at"`
+	EventType   string        `json:"event_type,omitempty"`
+	Dispute     *DisputeEvent `json:"dispute,omitempty"`
 }
 
+// DisputeEvent é a contestação de um evento payment.disputed
+type DisputeEvent struct {
+	ID            uint        `json:"id"`
+	Reference     string      `json:"reference"`
+	Reason        string      `json:"reason"`
+	Status        string      `json:"status"`
+	Amount        money.Money `json:"amount"`
+	EvidenceDueAt string      `json:"evidence_due_at"`
+}
+
+// EventPaymentDisputed é o tipo dos eventos de contestação de pagamento
+const EventPaymentDisputed = "payment.disputed"
+
 // NewPaymentPublisher cria uma nova instância do publisher
 func NewPaymentPublisher() (*PaymentPublisher, error) {
 	cfg := config.LoadConfig()
@@ -67,28 +92,32 @@ func NewPaymentPublisher() (*PaymentPublisher, error) {
 
 	log.Println("📡 Canal RabbitMQ Publisher criado com sucesso")
 
-	// Declarar a fila payments
+	// Declarar a fila payments (notificações) e a fila de resultados para o order-service
 	paymentQueueName := "payments"
-	_, err = ch.QueueDeclare(
-		paymentQueueName, // name
-		true,             // durable
-		false,            // delete when unused
-		false,            // exclusive
-		false,            // no-wait
-		nil,              // arguments
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("falha ao declarar fila %s: %w", paymentQueueName, err)
-	}
+	orderQueueName := cfg.OrderPaymentsQueue
+	for _, name := range []string{paymentQueueName, orderQueueName} {
+		_, err = ch.QueueDeclare(
+			name,  // name
+			true,  // durable
+			false, // delete when unused
+			false, // exclusive
+			false, // no-wait
+			nil,   // arguments
+		)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, fmt.Errorf("falha ao declarar fila %s: %w", name, err)
+		}
 
-	log.Printf("🎯 Fila '%s' declarada com sucesso", paymentQueueName)
+		log.Printf("🎯 Fila '%s' declarada com sucesso", name)
+	}
 
 	return &PaymentPublisher{
-		conn:      conn,
-		channel:   ch,
-		queueName: paymentQueueName,
+		conn:           conn,
+		channel:        ch,
+		queueName:      paymentQueueName,
+		orderQueueName: orderQueueName,
 	}, nil
 }
 
@@ -96,11 +125,14 @@ func NewPaymentPublisher() (*PaymentPublisher, error) {
 func (p *PaymentPublisher) PublishPaymentEvent(payment *domain.Payment) error {
 	// Criar evento
 	event := PaymentEvent{
-		PaymentID: payment.ID,
-		OrderID:   payment.OrderID,
-		Status:    payment.Status,
-		Amount:    payment.Amount,
-		CreatedAt: payment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		PaymentID:   payment.ID,
+		OrderID:     payment.OrderID,
+		Customer:    payment.Customer,
+		Status:      payment.Status,
+		Amount:      payment.Money(),
+		OrderAmount: payment.OrderMoney(),
+		FXRate:      payment.FXRate,
+		CreatedAt:   payment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	// Serializar para JSON
@@ -109,7 +141,62 @@ func (p *PaymentPublisher) PublishPaymentEvent(payment *domain.Payment) error {
 		return fmt.Errorf("falha ao serializar evento de pagamento: %w", err)
 	}
 
-	// Publicar mensagem
+	// Publicar mensagem nas filas de notificação e de resultados do pedido
+	for _, queue := range []string{p.queueName, p.orderQueueName} {
+		err = p.channel.Publish(
+			"",    // exchange
+			queue, // routing key (queue name)
+			false, // mandatory
+			false, // immediate
+			amqp091.Publishing{
+				ContentType: "application/json",
+				Body:        body,
+			})
+
+		if err != nil {
+			return fmt.Errorf("falha ao publicar evento de pagamento na fila %s: %w", queue, err)
+		}
+	}
+
+	log.Printf("📤 Evento de pagamento publicado nas filas '%s' e '%s':", p.queueName, p.orderQueueName)
+	log.Printf("   🆔 Payment ID: %d", event.PaymentID)
+	log.Printf("   📝 Order ID: %d", event.OrderID)
+	log.Printf("   📊 Status: %s", event.Status)
+	log.Printf("   💰 Valor: %s (pedido: %s, cotação: %s)", event.Amount, event.OrderAmount, event.FXRate)
+	log.Printf("   ⏰ Criado em: %s", event.CreatedAt)
+
+	return nil
+}
+
+// PublishDisputeEvent publica na fila de notificações um evento payment.disputed a cada
+// mudança de status da contestação. O order-service não recebe o evento: a contestação
+// não altera o pedido.
+func (p *PaymentPublisher) PublishDisputeEvent(payment *domain.Payment, dispute *domain.Dispute) error {
+	event := PaymentEvent{
+		PaymentID:   payment.ID,
+		OrderID:     payment.OrderID,
+		Customer:    payment.Customer,
+		Status:      payment.Status,
+		Amount:      payment.Money(),
+		OrderAmount: payment.OrderMoney(),
+		FXRate:      payment.FXRate,
+		CreatedAt:   payment.CreatedAt.Format(time.RFC3339),
+		EventType:   EventPaymentDisputed,
+		Dispute: &DisputeEvent{
+			ID:            dispute.ID,
+			Reference:     dispute.Reference,
+			Reason:        dispute.Reason,
+			Status:        dispute.Status,
+			Amount:        dispute.Money(),
+			EvidenceDueAt: dispute.EvidenceDueAt.Format(time.RFC3339),
+		},
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("falha ao serializar evento de contestação: %w", err)
+	}
+
 	err = p.channel.Publish(
 		"",          // exchange
 		p.queueName, // routing key (queue name)
@@ -119,18 +206,12 @@ func (p *PaymentPublisher) PublishPaymentEvent(payment *domain.Payment) error {
 			ContentType: "application/json",
 			Body:        body,
 		})
-
 	if err != nil {
-		return fmt.Errorf("falha ao publicar evento de pagamento: %w", err)
+		return fmt.Errorf("falha ao publicar evento de contestação na fila %s: %w", p.queueName, err)
 	}
 
-	log.Printf("📤 Evento de pagamento publicado na fila '%s':", p.queueName)
-	log.Printf("   🆔 Payment ID: %d", event.PaymentID)
-	log.Printf("   📝 Order ID: %d", event.OrderID)
-	log.Printf("   📊 Status: %s", event.Status)
-	log.Printf("   💰 Valor: R$ %.2f", event.Amount)
-	log.Printf("   ⏰ Criado em: %s", event.CreatedAt)
-
+	log.Printf("📤 Evento %s publicado na fila '%s': pedido %d, contestação %s %s (%s)",
+		EventPaymentDisputed, p.queueName, payment.OrderID, dispute.Reference, dispute.Status, dispute.Money())
 	return nil
 }
 
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"time"
+
+	"payment-service/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DisputeRepository define a interface para operações de persistência das contestações,
+// das evidências da defesa e dos lançamentos no razão
+type DisputeRepository interface {
+	Create(dispute *domain.Dispute) (bool, error)
+	GetByID(id uint) (*domain.Dispute, error)
+	GetByReference(reference string) (*domain.Dispute, error)
+	List(orderID uint, status string, limit int) ([]domain.Dispute, error)
+	ListByPayment(paymentID uint) ([]domain.Dispute, error)
+	ListDueBefore(status string, before time.Time, limit int) ([]domain.Dispute, error)
+	AddEvidence(evidence *domain.DisputeEvidence) error
+	GetEvidence(id uint) (*domain.DisputeEvidence, error)
+	SubmitEvidence(dispute *domain.Dispute) (bool, error)
+	Resolve(dispute *domain.Dispute, entry *domain.LedgerEntry) (bool, error)
+}
+
+// disputeRepository implementa DisputeRepository
+type disputeRepository struct {
+	db *gorm.DB
+}
+
+// NewDisputeRepository cria uma nova instância do repositório de contestações
+func NewDisputeRepository(db *gorm.DB) DisputeRepository {
+	return &disputeRepository{
+		db: db,
+	}
+}
+
+// withEvidence carrega os dados das evidências da contestação, sem o conteúdo dos anexos
+func withEvidence(db *gorm.DB) *gorm.DB {
+	return db.Preload("Evidence", func(db *gorm.DB) *gorm.DB {
+		return db.Omit("content").Order("created_at")
+	})
+}
+
+// Create grava a contestação. Retorna false, sem gravar, quando já existe uma
+// contestação com a mesma referência do adquirente.
+func (r *disputeRepository) Create(dispute *domain.Dispute) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dispute)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID busca uma contestação pelo ID
+func (r *disputeRepository) GetByID(id uint) (*domain.Dispute, error) {
+	var dispute domain.Dispute
+	if err := r.db.Scopes(withEvidence).First(&dispute, id).Error; err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// GetByReference busca uma contestação pela referência do adquirente
+func (r *disputeRepository) GetByReference(reference string) (*domain.Dispute, error) {
+	var dispute domain.Dispute
+	if err := r.db.Scopes(withEvidence).Where("reference = ?", reference).First(&dispute).Error; err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// List retorna as contestações, as mais recentes primeiro, filtradas pelo pedido e pelo
+// status quando informados
+func (r *disputeRepository) List(orderID uint, status string, limit int) ([]domain.Dispute, error) {
+	query := r.db.Scopes(withEvidence).Order("created_at DESC").Limit(limit)
+	if orderID != 0 {
+		query = query.Where("order_id = ?", orderID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var disputes []domain.Dispute
+	if err := query.Find(&disputes).Error; err != nil {
+		return nil, err
+	}
+	return disputes, nil
+}
+
+// ListByPayment retorna as contestações de um pagamento
+func (r *disputeRepository) ListByPayment(paymentID uint) ([]domain.Dispute, error) {
+	var disputes []domain.Dispute
+	if err := r.db.Where("payment_id = ?", paymentID).Order("created_at").Find(&disputes).Error; err != nil {
+		return nil, err
+	}
+	return disputes, nil
+}
+
+// ListDueBefore retorna as contestações no status informado com prazo da defesa até
+// before, as de prazo mais próximo primeiro
+func (r *disputeRepository) ListDueBefore(status string, before time.Time, limit int) ([]domain.Dispute, error) {
+	var disputes []domain.Dispute
+	err := r.db.Scopes(withEvidence).
+		Where("status = ? AND evidence_due_at <= ?", status, before).
+		Order("evidence_due_at").
+		Limit(limit).
+		Find(&disputes).Error
+	if err != nil {
+		return nil, err
+	}
+	return disputes, nil
+}
+
+// AddEvidence grava um anexo da defesa
+func (r *disputeRepository) AddEvidence(evidence *domain.DisputeEvidence) error {
+	return r.db.Create(evidence).Error
+}
+
+// GetEvidence busca um anexo da defesa, com o conteúdo
+func (r *disputeRepository) GetEvidence(id uint) (*domain.DisputeEvidence, error) {
+	var evidence domain.DisputeEvidence
+	if err := r.db.First(&evidence, id).Error; err != nil {
+		return nil, err
+	}
+	return &evidence, nil
+}
+
+// SubmitEvidence marca a defesa da contestação como enviada. Só uma contestação ainda
+// aberta muda; retorna false quando ela já saiu de opened.
+func (r *disputeRepository) SubmitEvidence(dispute *domain.Dispute) (bool, error) {
+	result := r.db.Model(&domain.Dispute{}).
+		Where("id = ? AND status = ?", dispute.ID, domain.DisputeOpened).
+		Updates(map[string]interface{}{
+			"status":                domain.DisputeEvidenceSubmitted,
+			"evidence_submitted_at": dispute.EvidenceSubmittedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Resolve grava a decisão do adquirente. Na contestação perdida, o lançamento do
+// estorno entra no razão e o valor soma ao total estornado do pagamento, que fica
+// charged_back quando estornado por inteiro. Só uma contestação ainda não decidida
+// muda; retorna false quando ela já foi decidida.
+func (r *disputeRepository) Resolve(dispute *domain.Dispute, entry *domain.LedgerEntry) (bool, error) {
+	resolved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Dispute{}).
+			Where("id = ? AND status IN ?", dispute.ID, []string{domain.DisputeOpened, domain.DisputeEvidenceSubmitted}).
+			Updates(map[string]interface{}{
+				"status":          dispute.Status,
+				"resolved_at":     dispute.ResolvedAt,
+				"resolved_by":     dispute.ResolvedBy,
+				"resolution_note": dispute.ResolutionNote,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if entry != nil {
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+			err := tx.Model(&domain.Payment{}).Where("id = ?", dispute.PaymentID).
+				Update("charged_back_minor", gorm.Expr("charged_back_minor + ?", -entry.Amount)).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(&domain.Payment{}).
+				Where("id = ? AND status = ? AND charged_back_minor >= amount_minor", dispute.PaymentID, domain.StatusApproved).
+				Update("status", domain.StatusChargedBack).Error
+			if err != nil {
+				return err
+			}
+		}
+		resolved = true
+		return nil
+	})
+	return resolved, err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"payment-service/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FXRateRepository define a interface para operações de persistência das cotações
+type FXRateRepository interface {
+	FindPair(from, to string) ([]domain.FXRate, error)
+	Upsert(rate *domain.FXRate) error
+	CreateSnapshot(snapshot *domain.FXRateSnapshot) error
+}
+
+// fxRateRepository implementa FXRateRepository
+type fxRateRepository struct {
+	db *gorm.DB
+}
+
+// NewFXRateRepository cria uma nova instância do repositório de cotações
+func NewFXRateRepository(db *gorm.DB) FXRateRepository {
+	return &fxRateRepository{
+		db: db,
+	}
+}
+
+// FindPair retorna as cotações do par nos dois sentidos (from→to e to→from)
+func (r *fxRateRepository) FindPair(from, to string) ([]domain.FXRate, error) {
+	var rates []domain.FXRate
+	err := r.db.Where("(from_currency = ? AND to_currency = ?) OR (from_currency = ? AND to_currency = ?)",
+		from, to, to, from).Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Upsert grava a cotação, substituindo a existente para o mesmo par
+func (r *fxRateRepository) Upsert(rate *domain.FXRate) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "from_currency"}, {Name: "to_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "as_of", "updated_at"}),
+	}).Create(rate).Error
+}
+
+// CreateSnapshot grava a cópia da cotação usada em uma conversão
+func (r *fxRateRepository) CreateSnapshot(snapshot *domain.FXRateSnapshot) error {
+	return r.db.Create(snapshot).Error
+}
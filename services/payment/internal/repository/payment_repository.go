@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"payment-service/internal/domain"
 
 	"gorm.io/gorm"
@@ -12,6 +14,22 @@ type PaymentRepository interface {
 	GetByOrderID(orderID uint) (*domain.Payment, error)
 	List() ([]domain.Payment, error)
 	GetByID(id uint) (*domain.Payment, error)
+	ListByOrderIDs(orderIDs []uint) ([]domain.Payment, error)
+	GetByPixTxID(txID string) (*domain.Payment, error)
+	ListExpiredPix(now time.Time, limit int) ([]domain.Payment, error)
+	SettlePix(payment *domain.Payment) (bool, error)
+	GetByNossoNumero(nossoNumero string) (*domain.Payment, error)
+	ListOverdueBoletos(now time.Time, limit int) ([]domain.Payment, error)
+	SettleBoleto(payment *domain.Payment) (bool, error)
+	GetByGatewayRef(ref string) (*domain.Payment, error)
+	UpdateStatus(paymentID uint, from, to string) (bool, error)
+	CountRecent(customer, cardFingerprint string, since time.Time) (int, int, error)
+	ListInReview(limit int) ([]domain.Payment, error)
+	CompleteReview(payment *domain.Payment) (bool, error)
+	ListByGatewayRefs(refs []string) ([]domain.Payment, error)
+	ListSettleable(from, to time.Time) ([]domain.Payment, error)
+	Search(filter PaymentFilter) ([]domain.Payment, error)
+	AnonymizeByOrderIDs(orderIDs []uint, pseudonym string) ([]uint, error)
 }
 
 // paymentRepository implementa PaymentRepository
@@ -26,7 +44,19 @@ func NewPaymentRepository(db *gorm.DB) PaymentRepository {
 	}
 }
 
-// Create cria um novo pagamento no banco de dados
+// orderByNumber ordena o cronograma pelo número da parcela
+func orderByNumber(db *gorm.DB) *gorm.DB {
+	return db.Order("number")
+}
+
+// withDetails carrega o cronograma de parcelas, a cobrança PIX, o boleto e as regras
+// antifraude disparadas do pagamento
+func withDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Schedule", orderByNumber).Preload("PixCharge").Preload("Boleto").Preload("FraudRules")
+}
+
+// Create cria um novo pagamento no banco de dados, com o cronograma de parcelas, a
+// cobrança PIX, o boleto e as regras antifraude disparadas
 func (r *paymentRepository) Create(payment *domain.Payment) error {
 	if err := r.db.Create(payment).Error; err != nil {
 		return err
@@ -37,7 +67,7 @@ func (r *paymentRepository) Create(payment *domain.Payment) error {
 // GetByOrderID retorna um pagamento específico pelo OrderID
 func (r *paymentRepository) GetByOrderID(orderID uint) (*domain.Payment, error) {
 	var payment domain.Payment
-	if err := r.db.Where("order_id = ?", orderID).First(&payment).Error; err != nil {
+	if err := r.db.Scopes(withDetails).Where("order_id = ?", orderID).First(&payment).Error; err != nil {
 		return nil, err
 	}
 	return &payment, nil
@@ -46,7 +76,7 @@ func (r *paymentRepository) GetByOrderID(orderID uint) (*domain.Payment, error)
 // List retorna todos os pagamentos do banco de dados
 func (r *paymentRepository) List() ([]domain.Payment, error) {
 	var payments []domain.Payment
-	if err := r.db.Order("created_at DESC").Find(&payments).Error; err != nil {
+	if err := r.db.Scopes(withDetails).Order("created_at DESC").Find(&payments).Error; err != nil {
 		return nil, err
 	}
 	return payments, nil
@@ -55,8 +85,322 @@ func (r *paymentRepository) List() ([]domain.Payment, error) {
 // GetByID retorna um pagamento específico pelo ID
 func (r *paymentRepository) GetByID(id uint) (*domain.Payment, error) {
 	var payment domain.Payment
-	if err := r.db.First(&payment, id).Error; err != nil {
+	if err := r.db.Scopes(withDetails).First(&payment, id).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ListByOrderIDs retorna os pagamentos dos pedidos informados
+func (r *paymentRepository) ListByOrderIDs(orderIDs []uint) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	if err := r.db.Scopes(withDetails).Where("order_id IN ?", orderIDs).Order("created_at").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// GetByPixTxID retorna o pagamento da cobrança PIX com o txid informado
+func (r *paymentRepository) GetByPixTxID(txID string) (*domain.Payment, error) {
+	var charge domain.PixCharge
+	if err := r.db.Where("txid = ?", txID).First(&charge).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(charge.PaymentID)
+}
+
+// ListExpiredPix retorna os pagamentos pendentes cuja cobrança PIX venceu até now
+func (r *paymentRepository) ListExpiredPix(now time.Time, limit int) ([]domain.Payment, error) {
+	var paymentIDs []uint
+	err := r.db.Model(&domain.PixCharge{}).
+		Where("status = ? AND expires_at <= ?", domain.PixStatusActive, now).
+		Order("expires_at").Limit(limit).
+		Pluck("payment_id", &paymentIDs).Error
+	if err != nil || len(paymentIDs) == 0 {
+		return nil, err
+	}
+
+	var payments []domain.Payment
+	if err := r.db.Scopes(withDetails).Where("id IN ? AND status = ?", paymentIDs, domain.StatusPending).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// SettlePix grava o resultado da cobrança PIX (paga ou expirada) e o novo status do
+// pagamento. A transição só acontece a partir de uma cobrança ativa; retorna false
+// quando a cobrança já tinha sido paga ou expirada, sem alterar nada.
+func (r *paymentRepository) SettlePix(payment *domain.Payment) (bool, error) {
+	charge := payment.PixCharge
+	settled := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.PixCharge{}).
+			Where("id = ? AND status = ?", charge.ID, domain.PixStatusActive).
+			Updates(map[string]interface{}{
+				"status":        charge.Status,
+				"paid_at":       charge.PaidAt,
+				"end_to_end_id": charge.EndToEndID,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Model(&domain.Payment{}).Where("id = ?", payment.ID).Update("status", payment.Status).Error; err != nil {
+			return err
+		}
+		settled = true
+		return nil
+	})
+	return settled, err
+}
+
+// GetByNossoNumero retorna o pagamento do boleto com o nosso número informado
+func (r *paymentRepository) GetByNossoNumero(nossoNumero string) (*domain.Payment, error) {
+	var boleto domain.Boleto
+	if err := r.db.Where("nosso_numero = ?", nossoNumero).First(&boleto).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(boleto.PaymentID)
+}
+
+// ListOverdueBoletos retorna os pagamentos pendentes cujo boleto passou da tolerância
+// após o vencimento até now
+func (r *paymentRepository) ListOverdueBoletos(now time.Time, limit int) ([]domain.Payment, error) {
+	var paymentIDs []uint
+	err := r.db.Model(&domain.Boleto{}).
+		Where("status = ? AND expires_at <= ?", domain.BoletoStatusOpen, now).
+		Order("expires_at").Limit(limit).
+		Pluck("payment_id", &paymentIDs).Error
+	if err != nil || len(paymentIDs) == 0 {
+		return nil, err
+	}
+
+	var payments []domain.Payment
+	if err := r.db.Scopes(withDetails).Where("id IN ? AND status = ?", paymentIDs, domain.StatusPending).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// SettleBoleto grava a liquidação ou a baixa por vencimento do boleto e o novo status
+// do pagamento. Como em SettlePix, só um boleto em aberto muda de estado; retorna false
+// quando ele já tinha sido pago ou baixado.
+func (r *paymentRepository) SettleBoleto(payment *domain.Payment) (bool, error) {
+	boleto := payment.Boleto
+	settled := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Boleto{}).
+			Where("id = ? AND status = ?", boleto.ID, domain.BoletoStatusOpen).
+			Updates(map[string]interface{}{
+				"status":            boleto.Status,
+				"paid_at":           boleto.PaidAt,
+				"paid_amount_minor": boleto.PaidAmount,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Model(&domain.Payment{}).Where("id = ?", payment.ID).Update("status", payment.Status).Error; err != nil {
+			return err
+		}
+		settled = true
+		return nil
+	})
+	return settled, err
+}
+
+// GetByGatewayRef retorna o pagamento com a referência do provedor informada
+func (r *paymentRepository) GetByGatewayRef(ref string) (*domain.Payment, error) {
+	var payment domain.Payment
+	if err := r.db.Scopes(withDetails).Where("gateway_ref = ?", ref).First(&payment).Error; err != nil {
 		return nil, err
 	}
 	return &payment, nil
 }
+
+// UpdateStatus muda o status do pagamento de from para to. Retorna false, sem alterar
+// nada, quando o pagamento já não estava em from.
+func (r *paymentRepository) UpdateStatus(paymentID uint, from, to string) (bool, error) {
+	result := r.db.Model(&domain.Payment{}).
+		Where("id = ? AND status = ?", paymentID, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountRecent conta os pagamentos criados desde since para o cliente e para o cartão
+// informados; identificadores vazios contam zero
+func (r *paymentRepository) CountRecent(customer, cardFingerprint string, since time.Time) (int, int, error) {
+	count := func(column, value string) (int, error) {
+		if value == "" {
+			return 0, nil
+		}
+		var total int64
+		err := r.db.Model(&domain.Payment{}).Where(column+" = ? AND created_at >= ?", value, since).Count(&total).Error
+		return int(total), err
+	}
+	byCustomer, err := count("customer", customer)
+	if err != nil {
+		return 0, 0, err
+	}
+	byCard, err := count("card_fingerprint", cardFingerprint)
+	if err != nil {
+		return 0, 0, err
+	}
+	return byCustomer, byCard, nil
+}
+
+// ListInReview retorna os pagamentos retidos pela análise antifraude, os mais antigos
+// primeiro
+func (r *paymentRepository) ListInReview(limit int) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	if err := r.db.Scopes(withDetails).Where("status = ?", domain.StatusReview).Order("created_at").Limit(limit).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// CompleteReview grava a decisão do analista sobre um pagamento retido: o novo status,
+// a análise e, na aprovação, a cobrança PIX, o boleto ou a referência no provedor. Só
+// um pagamento ainda em review muda; retorna false quando outro analista já decidiu.
+func (r *paymentRepository) CompleteReview(payment *domain.Payment) (bool, error) {
+	completed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Payment{}).
+			Where("id = ? AND status = ?", payment.ID, domain.StatusReview).
+			Updates(map[string]interface{}{
+				"status":      payment.Status,
+				"gateway_ref": payment.GatewayRef,
+				"reviewed_by": payment.ReviewedBy,
+				"reviewed_at": payment.ReviewedAt,
+				"review_note": payment.ReviewNote,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if payment.PixCharge != nil {
+			payment.PixCharge.PaymentID = payment.ID
+			if err := tx.Create(payment.PixCharge).Error; err != nil {
+				return err
+			}
+		}
+		if payment.Boleto != nil {
+			payment.Boleto.PaymentID = payment.ID
+			if err := tx.Create(payment.Boleto).Error; err != nil {
+				return err
+			}
+		}
+		completed = true
+		return nil
+	})
+	return completed, err
+}
+
+// gatewayRefBatch é o máximo de referências por consulta em ListByGatewayRefs
+const gatewayRefBatch = 500
+
+// ListByGatewayRefs retorna os pagamentos com as referências do provedor informadas,
+// consultadas em lotes
+func (r *paymentRepository) ListByGatewayRefs(refs []string) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	for start := 0; start < len(refs); start += gatewayRefBatch {
+		end := min(start+gatewayRefBatch, len(refs))
+		var batch []domain.Payment
+		if err := r.db.Where("gateway_ref IN ?", refs[start:end]).Find(&batch).Error; err != nil {
+			return nil, err
+		}
+		payments = append(payments, batch...)
+	}
+	return payments, nil
+}
+
+// ListSettleable retorna os pagamentos com cartão no provedor criados em [from, to) que
+// devem aparecer no arquivo de liquidação: os aprovados e os estornados depois
+func (r *paymentRepository) ListSettleable(from, to time.Time) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	err := r.db.Where("gateway_ref <> '' AND status IN ? AND created_at >= ? AND created_at < ?",
+		[]string{domain.StatusApproved, domain.StatusChargedBack}, from, to).
+		Order("created_at").
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// PaymentFilter seleciona os pagamentos de Search; campos vazios não filtram. A página
+// começa depois de AfterID, na ordem do ID decrescente (os mais novos primeiro).
+type PaymentFilter struct {
+	Statuses    []string
+	OrderIDs    []uint
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Currency    string
+	MinAmount   *int64
+	MaxAmount   *int64
+	AfterID     uint
+	Limit       int
+}
+
+// Search retorna uma página dos pagamentos que atendem ao filtro, os mais novos primeiro
+func (r *paymentRepository) Search(filter PaymentFilter) ([]domain.Payment, error) {
+	query := r.db.Scopes(withDetails)
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.OrderIDs) > 0 {
+		query = query.Where("order_id IN ?", filter.OrderIDs)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.AfterID > 0 {
+		query = query.Where("id < ?", filter.AfterID)
+	}
+
+	var payments []domain.Payment
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// AnonymizeByOrderIDs troca o cliente dos pagamentos dos pedidos pelo pseudônimo e apaga
+// a impressão digital do cartão, os detalhes das regras antifraude e a observação do
+// analista, mantendo valores, datas e status. Retorna os IDs dos pagamentos alterados.
+func (r *paymentRepository) AnonymizeByOrderIDs(orderIDs []uint, pseudonym string) ([]uint, error) {
+	var ids []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Payment{}).Where("order_id IN ?", orderIDs).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Model(&domain.Payment{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"customer":         pseudonym,
+			"card_fingerprint": "",
+			"review_note":      "",
+		}).Error; err != nil {
+			return err
+		}
+		// Os detalhes das regras citam o cartão, o país do IP e as UFs do titular
+		return tx.Model(&domain.PaymentFraudRule{}).Where("payment_id IN ?", ids).Update("detail", "").Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"payment-service/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettlementRepository define a interface para operações de persistência das
+// conciliações dos arquivos de liquidação
+type SettlementRepository interface {
+	Create(run *domain.SettlementRun) (bool, error)
+	GetByID(id uint, outcome string) (*domain.SettlementRun, error)
+	GetBySHA256(sum string) (*domain.SettlementRun, error)
+	List(limit int) ([]domain.SettlementRun, error)
+}
+
+// settlementRepository implementa SettlementRepository
+type settlementRepository struct {
+	db *gorm.DB
+}
+
+// NewSettlementRepository cria uma nova instância do repositório de conciliações
+func NewSettlementRepository(db *gorm.DB) SettlementRepository {
+	return &settlementRepository{
+		db: db,
+	}
+}
+
+// Create grava a conciliação com os itens. Retorna false, sem gravar, quando o mesmo
+// arquivo já foi conciliado.
+func (r *settlementRepository) Create(run *domain.SettlementRun) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		items := run.Items
+		run.Items = nil
+		defer func() { run.Items = items }()
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(run)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		for i := range items {
+			items[i].RunID = run.ID
+		}
+		if len(items) > 0 {
+			if err := tx.CreateInBatches(items, 500).Error; err != nil {
+				return err
+			}
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// withItems carrega os itens da conciliação na ordem do arquivo, com os pagamentos
+// ausentes do arquivo no fim, filtrados pelo resultado quando informado
+func withItems(outcome string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+			if outcome != "" {
+				db = db.Where("outcome = ?", outcome)
+			}
+			return db.Order("line = 0, line, id")
+		})
+	}
+}
+
+// GetByID busca uma conciliação com os itens, filtrados pelo resultado quando informado
+func (r *settlementRepository) GetByID(id uint, outcome string) (*domain.SettlementRun, error) {
+	var run domain.SettlementRun
+	if err := r.db.Scopes(withItems(outcome)).First(&run, id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetBySHA256 busca a conciliação de um arquivo pelo hash do conteúdo, com os itens
+func (r *settlementRepository) GetBySHA256(sum string) (*domain.SettlementRun, error) {
+	var run domain.SettlementRun
+	if err := r.db.Scopes(withItems("")).Where("file_sha256 = ?", sum).First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// List retorna as conciliações mais recentes, sem os itens
+func (r *settlementRepository) List(limit int) ([]domain.SettlementRun, error) {
+	var runs []domain.SettlementRun
+	if err := r.db.Order("created_at DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"payment-service/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository define a interface para operações de persistência dos webhooks
+// recebidos dos provedores de pagamento
+type WebhookRepository interface {
+	Create(event *domain.WebhookEvent) (bool, error)
+	GetByEventID(provider, eventID string) (*domain.WebhookEvent, error)
+	SaveOutcome(event *domain.WebhookEvent) error
+}
+
+// webhookRepository implementa WebhookRepository
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository cria uma nova instância do repositório de webhooks
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// Create grava o webhook recebido. Retorna false, sem gravar, quando o provedor já
+// enviou um evento com o mesmo ID.
+func (r *webhookRepository) Create(event *domain.WebhookEvent) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByEventID retorna o webhook do provedor com o ID de evento informado
+func (r *webhookRepository) GetByEventID(provider, eventID string) (*domain.WebhookEvent, error) {
+	var event domain.WebhookEvent
+	if err := r.db.Where("provider = ? AND event_id = ?", provider, eventID).First(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// SaveOutcome grava o resultado da aplicação do webhook ao pagamento
+func (r *webhookRepository) SaveOutcome(event *domain.WebhookEvent) error {
+	return r.db.Model(&domain.WebhookEvent{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"payment_id":   event.PaymentID,
+		"outcome":      event.Outcome,
+		"detail":       event.Detail,
+		"processed_at": event.ProcessedAt,
+	}).Error
+}
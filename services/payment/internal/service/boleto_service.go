@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"payment-service/internal/boleto"
+	"payment-service/internal/domain"
+	"payment-service/internal/publisher"
+	"payment-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// boletoOverdueBatchSize é o número de boletos vencidos por consulta
+const boletoOverdueBatchSize = 100
+
+// ErrBoletoNotFound indica um pedido sem boleto
+var ErrBoletoNotFound = errors.New("boleto não encontrado")
+
+// Resultados da conciliação de cada título do arquivo de retorno
+const (
+	ReconcilePaid           = "paid"
+	ReconcileAlreadyPaid    = "already_paid"
+	ReconcileNotFound       = "not_found"
+	ReconcileAmountMismatch = "amount_mismatch"
+	ReconcileExpired        = "expired"
+	ReconcileIgnored        = "ignored"
+)
+
+// ReconcileItem é o resultado da conciliação de um título do arquivo de retorno
+type ReconcileItem struct {
+	Line        int
+	NossoNumero string
+	OrderID     uint
+	Occurrence  string
+	Outcome     string
+	Detail      string
+}
+
+// ReconcileResult resume a conciliação de um arquivo de retorno. Em DryRun nada é
+// gravado: Items mostra o que aconteceria com cada título.
+type ReconcileResult struct {
+	Format string
+	DryRun bool
+	Counts map[string]int
+	Items  []ReconcileItem
+}
+
+// BoletoService define as operações sobre boletos já emitidos
+type BoletoService interface {
+	GetBoleto(orderID uint) (*domain.Payment, error)
+	RenderPDF(payment *domain.Payment) ([]byte, error)
+	Reconcile(file boleto.ReturnFile, dryRun bool) (ReconcileResult, error)
+	ExpireOverdue(now time.Time) (int, error)
+	RunOverdueWorker(ctx context.Context, interval time.Duration)
+}
+
+// boletoService implementa BoletoService
+type boletoService struct {
+	paymentRepo repository.PaymentRepository
+	publisher   *publisher.PaymentPublisher
+	config      boleto.Config
+}
+
+// NewBoletoService cria uma nova instância do serviço de boletos; config é a carteira
+// de cobrança impressa no PDF
+func NewBoletoService(paymentRepo repository.PaymentRepository, pub *publisher.PaymentPublisher, config boleto.Config) BoletoService {
+	return &boletoService{
+		paymentRepo: paymentRepo,
+		publisher:   pub,
+		config:      config,
+	}
+}
+
+// GetBoleto retorna o pagamento com boleto de um pedido
+func (s *boletoService) GetBoleto(orderID uint) (*domain.Payment, error) {
+	if orderID == 0 {
+		return nil, fmt.Errorf("%w: ID do pedido é obrigatório", ErrBoletoNotFound)
+	}
+
+	payment, err := s.paymentRepo.GetByOrderID(orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && payment.Boleto == nil {
+		return nil, fmt.Errorf("%w: pedido %d", ErrBoletoNotFound, orderID)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar boleto do pedido %d: %v", orderID, err)
+		return nil, errors.New("falha ao buscar boleto")
+	}
+	return payment, nil
+}
+
+// RenderPDF gera o PDF do boleto do pagamento
+func (s *boletoService) RenderPDF(payment *domain.Payment) ([]byte, error) {
+	b := payment.Boleto
+	if b == nil {
+		return nil, fmt.Errorf("%w: pedido %d", ErrBoletoNotFound, payment.OrderID)
+	}
+
+	pdf, err := boleto.RenderPDF(boleto.Boleto{
+		NossoNumero:   b.NossoNumero,
+		Barcode:       b.Barcode,
+		DigitableLine: b.DigitableLine,
+		DueDate:       b.DueDate,
+		Amount:        payment.Money(),
+	}, s.config, boleto.Document{
+		Number:   fmt.Sprint(payment.OrderID),
+		Payer:    fmt.Sprintf("Pedido %d", payment.OrderID),
+		IssuedAt: payment.CreatedAt,
+		Instructions: []string{
+			fmt.Sprintf("Não receber após %s", s.config.ExpiresAt(b.DueDate).AddDate(0, 0, -1).Format("02/01/2006")),
+			"Após o vencimento, o pedido pode ser cancelado",
+		},
+	})
+	if err != nil {
+		log.Printf("Erro ao gerar PDF do boleto %s: %v", b.NossoNumero, err)
+		return nil, errors.New("falha ao gerar PDF do boleto")
+	}
+	return pdf, nil
+}
+
+// Reconcile concilia os títulos do arquivo de retorno com os boletos emitidos pelo
+// nosso número. Liquidações com valor pago igual ou maior que o do boleto aprovam o
+// pagamento; valores menores ficam pendentes para análise. Reprocessar o mesmo arquivo
+// não altera pagamentos já aprovados.
+func (s *boletoService) Reconcile(file boleto.ReturnFile, dryRun bool) (ReconcileResult, error) {
+	result := ReconcileResult{Format: file.Format, DryRun: dryRun, Counts: map[string]int{}}
+	for _, record := range file.Records {
+		item, err := s.reconcile(record, dryRun)
+		if err != nil {
+			return result, err
+		}
+		result.Counts[item.Outcome]++
+		result.Items = append(result.Items, item)
+	}
+
+	log.Printf("🏦 Retorno %s conciliado (simulação: %t): %d títulos, %d liquidados, %d divergentes, %d não encontrados",
+		file.Format, dryRun, len(file.Records), result.Counts[ReconcilePaid],
+		result.Counts[ReconcileAmountMismatch], result.Counts[ReconcileNotFound])
+	return result, nil
+}
+
+// reconcile concilia um título do arquivo de retorno
+func (s *boletoService) reconcile(record boleto.ReturnRecord, dryRun bool) (ReconcileItem, error) {
+	item := ReconcileItem{Line: record.Line, NossoNumero: record.NossoNumero, Occurrence: record.Occurrence}
+
+	payment, err := s.paymentRepo.GetByNossoNumero(record.NossoNumero)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		item.Outcome = ReconcileNotFound
+		item.Detail = "nosso número sem boleto emitido"
+		return item, nil
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar boleto %s: %v", record.NossoNumero, err)
+		return item, errors.New("falha ao buscar boleto")
+	}
+	item.OrderID = payment.OrderID
+	b := payment.Boleto
+
+	switch {
+	case !record.Paid():
+		item.Outcome = ReconcileIgnored
+		item.Detail = fmt.Sprintf("ocorrência %s não é liquidação", record.Occurrence)
+		return item, nil
+	case b.Status == domain.BoletoStatusPaid:
+		item.Outcome = ReconcileAlreadyPaid
+		return item, nil
+	case b.Status == domain.BoletoStatusExpired:
+		item.Outcome = ReconcileExpired
+		item.Detail = "boleto pago após a baixa por vencimento: devolução necessária"
+		log.Printf("⚠️ Boleto %s liquidado após a baixa (OrderID %d): devolução necessária", b.NossoNumero, payment.OrderID)
+		return item, nil
+	case record.PaidAmount < payment.Amount:
+		item.Outcome = ReconcileAmountMismatch
+		item.Detail = fmt.Sprintf("pago %d, cobrado %d (centavos)", record.PaidAmount, payment.Amount)
+		log.Printf("⚠️ Boleto %s pago com valor menor (OrderID %d): %s", b.NossoNumero, payment.OrderID, item.Detail)
+		return item, nil
+	}
+
+	item.Outcome = ReconcilePaid
+	if dryRun {
+		return item, nil
+	}
+
+	paidAt := record.PaidAt
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+	b.Status = domain.BoletoStatusPaid
+	b.PaidAt = &paidAt
+	b.PaidAmount = record.PaidAmount
+	payment.Status = domain.StatusApproved
+	if err := s.settle(payment); err != nil {
+		return item, err
+	}
+	if payment.Boleto.Status == domain.BoletoStatusExpired {
+		// Baixado enquanto o retorno era processado
+		item.Outcome = ReconcileExpired
+		item.Detail = "boleto pago após a baixa por vencimento: devolução necessária"
+		return item, nil
+	}
+	log.Printf("✅ Boleto liquidado para OrderID %d - nosso número %s", payment.OrderID, b.NossoNumero)
+	return item, nil
+}
+
+// ExpireOverdue recusa os pagamentos cujos boletos passaram da tolerância após o
+// vencimento sem liquidação, liberando a reserva do pedido
+func (s *boletoService) ExpireOverdue(now time.Time) (int, error) {
+	expired := 0
+	for {
+		payments, err := s.paymentRepo.ListOverdueBoletos(now, boletoOverdueBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for i := range payments {
+			payment := &payments[i]
+			payment.Boleto.Status = domain.BoletoStatusExpired
+			payment.Status = domain.StatusFailed
+			if err := s.settle(payment); err != nil {
+				return expired, err
+			}
+			if payment.Status != domain.StatusFailed {
+				// Liquidado enquanto a baixa era processada
+				continue
+			}
+			log.Printf("⌛ Boleto vencido para OrderID %d - nosso número %s", payment.OrderID, payment.Boleto.NossoNumero)
+			expired++
+		}
+
+		if len(payments) < boletoOverdueBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// RunOverdueWorker baixa os boletos vencidos periodicamente até o contexto ser cancelado
+func (s *boletoService) RunOverdueWorker(ctx context.Context, interval time.Duration) {
+	log.Printf("⏰ Baixa de boletos vencidos iniciada (intervalo %s)", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Baixa de boletos vencidos finalizada")
+			return
+		case <-ticker.C:
+			count, err := s.ExpireOverdue(time.Now())
+			if err != nil {
+				log.Printf("⚠️ Erro ao baixar boletos vencidos: %v", err)
+			}
+			if count > 0 {
+				log.Printf("⌛ %d boletos vencidos baixados", count)
+			}
+		}
+	}
+}
+
+// settle grava o resultado do boleto e publica o evento do pagamento. Se a liquidação
+// ou a baixa chegou antes, o estado gravado prevalece e nada é publicado.
+func (s *boletoService) settle(payment *domain.Payment) error {
+	settled, err := s.paymentRepo.SettleBoleto(payment)
+	if err != nil {
+		log.Printf("Erro ao atualizar boleto %s: %v", payment.Boleto.NossoNumero, err)
+		return errors.New("falha ao atualizar boleto")
+	}
+	if !settled {
+		current, err := s.paymentRepo.GetByID(payment.ID)
+		if err == nil {
+			*payment = *current
+		}
+		return nil
+	}
+
+	if s.publisher != nil {
+		if err := s.publisher.PublishPaymentEvent(payment); err != nil {
+			log.Printf("⚠️ Erro ao publicar evento de pagamento: %v", err)
+		}
+	}
+	return nil
+}
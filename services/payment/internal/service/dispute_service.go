@@ -0,0 +1,412 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/dispute"
+	"payment-service/internal/domain"
+	"payment-service/internal/publisher"
+	"payment-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// Erros das contestações
+var (
+	ErrDisputeNotFound       = errors.New("contestação não encontrada")
+	ErrEvidenceNotFound      = errors.New("evidência não encontrada")
+	ErrInvalidDispute        = errors.New("contestação inválida")
+	ErrPaymentNotDisputable  = errors.New("pagamento não pode ser contestado")
+	ErrDisputeClosed         = errors.New("contestação não aceita mais alterações")
+	ErrDisputeDeadlinePassed = errors.New("prazo da defesa encerrado")
+)
+
+// maxDisputeList é o máximo de contestações devolvidas por consulta
+const maxDisputeList = 200
+
+// OpenDisputeInput é uma contestação recebida do adquirente. Sem Amount, contesta-se o
+// valor ainda não contestado do pagamento; sem EvidenceDueAt, vale o prazo padrão.
+type OpenDisputeInput struct {
+	OrderID       uint
+	Reference     string
+	Reason        string
+	Amount        money.Money
+	EvidenceDueAt time.Time
+	OpenedBy      string
+}
+
+// DisputeEvidenceInput é um anexo da defesa de uma contestação
+type DisputeEvidenceInput struct {
+	DisputeID   uint
+	Kind        string
+	FileName    string
+	ContentType string
+	Content     []byte
+	Note        string
+	UploadedBy  string
+}
+
+// ResolveDisputeInput é a decisão do adquirente sobre a contestação
+type ResolveDisputeInput struct {
+	DisputeID  uint
+	Won        bool
+	ResolvedBy string
+	Note       string
+}
+
+// DisputeService define as operações das contestações (chargebacks) de pagamentos
+type DisputeService interface {
+	OpenDispute(input OpenDisputeInput) (*domain.Dispute, error)
+	AddEvidence(input DisputeEvidenceInput) (*domain.DisputeEvidence, error)
+	SubmitEvidence(disputeID uint) (*domain.Dispute, error)
+	ResolveDispute(input ResolveDisputeInput) (*domain.Dispute, error)
+	GetDispute(id uint) (*domain.Dispute, error)
+	GetEvidence(id uint) (*domain.DisputeEvidence, error)
+	ListDisputes(orderID uint, status string, limit int) ([]domain.Dispute, error)
+	ListNearDeadline(within time.Duration, limit int) ([]domain.Dispute, error)
+}
+
+// disputeService implementa DisputeService
+type disputeService struct {
+	paymentRepo repository.PaymentRepository
+	disputeRepo repository.DisputeRepository
+	publisher   *publisher.PaymentPublisher
+	config      dispute.Config
+	now         func() time.Time
+}
+
+// NewDisputeService cria uma nova instância do serviço de contestações
+func NewDisputeService(paymentRepo repository.PaymentRepository, disputeRepo repository.DisputeRepository, pub *publisher.PaymentPublisher, cfg dispute.Config) DisputeService {
+	return &disputeService{
+		paymentRepo: paymentRepo,
+		disputeRepo: disputeRepo,
+		publisher:   pub,
+		config:      cfg,
+		now:         time.Now,
+	}
+}
+
+// OpenDispute registra a contestação de um pagamento aprovado. A mesma referência do
+// adquirente devolve a contestação já registrada, sem duplicá-la. O valor contestado
+// não pode passar do que ainda não foi contestado no pagamento (contestações ganhas
+// liberam o valor).
+func (s *disputeService) OpenDispute(input OpenDisputeInput) (*domain.Dispute, error) {
+	reference := strings.TrimSpace(input.Reference)
+	reason := strings.TrimSpace(input.Reason)
+	if input.OrderID == 0 || reference == "" || reason == "" {
+		return nil, fmt.Errorf("%w: pedido, referência e motivo são obrigatórios", ErrInvalidDispute)
+	}
+	if len(reference) > 64 || len(reason) > 60 {
+		return nil, fmt.Errorf("%w: referência até 64 e motivo até 60 caracteres", ErrInvalidDispute)
+	}
+
+	payment, err := s.paymentRepo.GetByOrderID(input.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: pedido %d", ErrPaymentNotFound, input.OrderID)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar pagamento do pedido %d: %v", input.OrderID, err)
+		return nil, errors.New("falha ao buscar pagamento")
+	}
+
+	if existing, err := s.disputeRepo.GetByReference(reference); err == nil {
+		if existing.PaymentID != payment.ID {
+			return nil, fmt.Errorf("%w: referência %s já usada em outro pedido", ErrInvalidDispute, reference)
+		}
+		return existing, nil
+	}
+
+	if payment.Status != domain.StatusApproved {
+		return nil, fmt.Errorf("%w: pagamento do pedido %d está %s", ErrPaymentNotDisputable, input.OrderID, payment.Status)
+	}
+
+	available, err := s.undisputed(payment)
+	if err != nil {
+		return nil, err
+	}
+	amount := available
+	if !input.Amount.IsZero() {
+		amount = money.New(input.Amount.Amount, input.Amount.Currency)
+		if amount.Currency != payment.Currency {
+			return nil, fmt.Errorf("%w: valor em %s, pagamento em %s", ErrInvalidDispute, amount.Currency, payment.Currency)
+		}
+	}
+	if !amount.IsPositive() || amount.Amount > available.Amount {
+		return nil, fmt.Errorf("%w: valor %s, disponível para contestação %s", ErrInvalidDispute, amount, available)
+	}
+
+	now := s.now()
+	dueAt, err := s.config.DueAt(now, input.EvidenceDueAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDispute, err)
+	}
+
+	record := &domain.Dispute{
+		PaymentID:     payment.ID,
+		OrderID:       payment.OrderID,
+		Reference:     reference,
+		Reason:        reason,
+		Amount:        amount.Amount,
+		Currency:      amount.Currency,
+		Status:        domain.DisputeOpened,
+		EvidenceDueAt: dueAt,
+		OpenedBy:      truncate(input.OpenedBy, 100),
+	}
+	created, err := s.disputeRepo.Create(record)
+	if err != nil {
+		log.Printf("Erro ao gravar contestação %s: %v", reference, err)
+		return nil, errors.New("falha ao gravar contestação")
+	}
+	if !created {
+		// Gravada em paralelo com a mesma referência
+		return s.disputeRepo.GetByReference(reference)
+	}
+
+	log.Printf("⚖️ Contestação %s aberta para o pedido %d: %s (%s), defesa até %s",
+		reference, payment.OrderID, amount, reason, dueAt.Format(time.RFC3339))
+	s.publish(payment, record)
+	return record, nil
+}
+
+// undisputed retorna o valor do pagamento ainda não contestado
+func (s *disputeService) undisputed(payment *domain.Payment) (money.Money, error) {
+	disputes, err := s.disputeRepo.ListByPayment(payment.ID)
+	if err != nil {
+		log.Printf("Erro ao listar contestações do pagamento %d: %v", payment.ID, err)
+		return money.Money{}, errors.New("falha ao buscar contestações")
+	}
+	available := payment.Amount
+	for _, existing := range disputes {
+		if existing.Status != domain.DisputeWon {
+			available -= existing.Amount
+		}
+	}
+	return money.New(max(available, 0), payment.Currency), nil
+}
+
+// AddEvidence anexa um arquivo à defesa de uma contestação aberta, dentro do prazo
+func (s *disputeService) AddEvidence(input DisputeEvidenceInput) (*domain.DisputeEvidence, error) {
+	record, err := s.GetDispute(input.DisputeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDefense(record); err != nil {
+		return nil, err
+	}
+
+	evidence, err := s.config.Normalize(dispute.Evidence{
+		Kind:        input.Kind,
+		FileName:    input.FileName,
+		ContentType: input.ContentType,
+		Content:     input.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDispute, err)
+	}
+
+	sum := sha256.Sum256(evidence.Content)
+	attachment := &domain.DisputeEvidence{
+		DisputeID:   record.ID,
+		Kind:        evidence.Kind,
+		FileName:    evidence.FileName,
+		ContentType: evidence.ContentType,
+		Size:        int64(len(evidence.Content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Content:     evidence.Content,
+		Note:        truncate(strings.TrimSpace(input.Note), 255),
+		UploadedBy:  truncate(input.UploadedBy, 100),
+	}
+	if err := s.disputeRepo.AddEvidence(attachment); err != nil {
+		log.Printf("Erro ao gravar evidência da contestação %d: %v", record.ID, err)
+		return nil, errors.New("falha ao gravar evidência")
+	}
+
+	log.Printf("📎 Evidência %s (%s, %d bytes) anexada à contestação %s",
+		attachment.FileName, attachment.Kind, attachment.Size, record.Reference)
+	return attachment, nil
+}
+
+// SubmitEvidence envia a defesa da contestação. Exige ao menos uma evidência e o prazo
+// ainda aberto.
+func (s *disputeService) SubmitEvidence(disputeID uint) (*domain.Dispute, error) {
+	record, err := s.GetDispute(disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDefense(record); err != nil {
+		return nil, err
+	}
+	if len(record.Evidence) == 0 {
+		return nil, fmt.Errorf("%w: anexe ao menos uma evidência antes de enviar a defesa", ErrInvalidDispute)
+	}
+
+	submittedAt := s.now()
+	record.EvidenceSubmittedAt = &submittedAt
+	submitted, err := s.disputeRepo.SubmitEvidence(record)
+	if err != nil {
+		log.Printf("Erro ao enviar defesa da contestação %d: %v", record.ID, err)
+		return nil, errors.New("falha ao enviar defesa")
+	}
+	if !submitted {
+		return nil, fmt.Errorf("%w: contestação %s já saiu de %s", ErrDisputeClosed, record.Reference, domain.DisputeOpened)
+	}
+	record.Status = domain.DisputeEvidenceSubmitted
+
+	log.Printf("📨 Defesa da contestação %s enviada com %d evidência(s)", record.Reference, len(record.Evidence))
+	s.publishFor(record)
+	return record, nil
+}
+
+// checkDefense verifica se a contestação ainda aceita evidências
+func (s *disputeService) checkDefense(record *domain.Dispute) error {
+	if record.Status != domain.DisputeOpened {
+		return fmt.Errorf("%w: contestação %s está %s", ErrDisputeClosed, record.Reference, record.Status)
+	}
+	if s.now().After(record.EvidenceDueAt) {
+		return fmt.Errorf("%w: contestação %s venceu em %s", ErrDisputeDeadlinePassed, record.Reference, record.EvidenceDueAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// ResolveDispute registra a decisão do adquirente. Perdida a contestação, o valor é
+// estornado: o débito entra no razão do pagamento e o pagamento estornado por inteiro
+// fica charged_back.
+func (s *disputeService) ResolveDispute(input ResolveDisputeInput) (*domain.Dispute, error) {
+	record, err := s.GetDispute(input.DisputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.DisputeLost
+	if input.Won {
+		status = domain.DisputeWon
+	}
+	if !dispute.CanTransition(record.Status, status) {
+		return nil, fmt.Errorf("%w: contestação %s já está %s", ErrDisputeClosed, record.Reference, record.Status)
+	}
+
+	resolvedAt := s.now()
+	record.Status = status
+	record.ResolvedAt = &resolvedAt
+	record.ResolvedBy = truncate(input.ResolvedBy, 100)
+	record.ResolutionNote = truncate(strings.TrimSpace(input.Note), 255)
+
+	var entry *domain.LedgerEntry
+	if status == domain.DisputeLost {
+		entry = &domain.LedgerEntry{
+			PaymentID:   record.PaymentID,
+			DisputeID:   &record.ID,
+			Kind:        domain.LedgerChargeback,
+			Amount:      -record.Amount,
+			Currency:    record.Currency,
+			Description: truncate(fmt.Sprintf("Contestação %s perdida: %s", record.Reference, record.Reason), 255),
+		}
+	}
+
+	resolved, err := s.disputeRepo.Resolve(record, entry)
+	if err != nil {
+		log.Printf("Erro ao gravar decisão da contestação %d: %v", record.ID, err)
+		return nil, errors.New("falha ao gravar decisão da contestação")
+	}
+	if !resolved {
+		return nil, fmt.Errorf("%w: contestação %s já foi decidida", ErrDisputeClosed, record.Reference)
+	}
+
+	if status == domain.DisputeLost {
+		log.Printf("💸 Contestação %s perdida: %s estornado do pedido %d", record.Reference, record.Money(), record.OrderID)
+	} else {
+		log.Printf("🏆 Contestação %s ganha: pedido %d mantém o pagamento", record.Reference, record.OrderID)
+	}
+	s.publishFor(record)
+	return record, nil
+}
+
+// GetDispute busca uma contestação, com os dados das evidências
+func (s *disputeService) GetDispute(id uint) (*domain.Dispute, error) {
+	record, err := s.disputeRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrDisputeNotFound, id)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar contestação %d: %v", id, err)
+		return nil, errors.New("falha ao buscar contestação")
+	}
+	return record, nil
+}
+
+// GetEvidence busca um anexo da defesa, com o conteúdo
+func (s *disputeService) GetEvidence(id uint) (*domain.DisputeEvidence, error) {
+	evidence, err := s.disputeRepo.GetEvidence(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrEvidenceNotFound, id)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar evidência %d: %v", id, err)
+		return nil, errors.New("falha ao buscar evidência")
+	}
+	return evidence, nil
+}
+
+// ListDisputes lista as contestações, as mais recentes primeiro, filtradas pelo pedido
+// e pelo status quando informados
+func (s *disputeService) ListDisputes(orderID uint, status string, limit int) ([]domain.Dispute, error) {
+	disputes, err := s.disputeRepo.List(orderID, strings.TrimSpace(status), listLimit(limit))
+	if err != nil {
+		log.Printf("Erro ao listar contestações: %v", err)
+		return nil, errors.New("falha ao listar contestações")
+	}
+	return disputes, nil
+}
+
+// ListNearDeadline lista as contestações ainda sem defesa cujo prazo vence dentro de
+// within (sem janela, a do lojista), incluindo as já vencidas, as mais urgentes
+// primeiro
+func (s *disputeService) ListNearDeadline(within time.Duration, limit int) ([]domain.Dispute, error) {
+	if within <= 0 {
+		within = s.config.WarningWindow
+	}
+	disputes, err := s.disputeRepo.ListDueBefore(domain.DisputeOpened, s.now().Add(within), listLimit(limit))
+	if err != nil {
+		log.Printf("Erro ao listar contestações próximas do prazo: %v", err)
+		return nil, errors.New("falha ao listar contestações")
+	}
+	return disputes, nil
+}
+
+// listLimit aplica o limite padrão e o máximo das consultas de contestações
+func listLimit(limit int) int {
+	if limit <= 0 || limit > maxDisputeList {
+		return maxDisputeList
+	}
+	return limit
+}
+
+// publishFor publica o evento da contestação com o pagamento atualizado
+func (s *disputeService) publishFor(record *domain.Dispute) {
+	if s.publisher == nil {
+		return
+	}
+	payment, err := s.paymentRepo.GetByID(record.PaymentID)
+	if err != nil {
+		log.Printf("⚠️ Erro ao buscar pagamento %d para o evento da contestação: %v", record.PaymentID, err)
+		return
+	}
+	s.publish(payment, record)
+}
+
+// publish publica o evento payment.disputed; falhas são apenas registradas
+func (s *disputeService) publish(payment *domain.Payment, record *domain.Dispute) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.PublishDisputeEvent(payment, record); err != nil {
+		log.Printf("⚠️ Erro ao publicar evento de contestação: %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/dispute"
+	"payment-service/internal/domain"
+	"payment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryDisputeRepository guarda contestações, evidências e lançamentos em memória e
+// aplica o estorno aos pagamentos de paymentRepo, como a transação de Resolve
+type memoryDisputeRepository struct {
+	paymentRepo *reviewPaymentRepository
+	disputes    map[uint]*domain.Dispute
+	evidence    map[uint]*domain.DisputeEvidence
+	ledger      []domain.LedgerEntry
+}
+
+func (r *memoryDisputeRepository) Create(d *domain.Dispute) (bool, error) {
+	for _, existing := range r.disputes {
+		if existing.Reference == d.Reference {
+			return false, nil
+		}
+	}
+	d.ID = uint(len(r.disputes) + 1)
+	d.CreatedAt = time.Now()
+	copy := *d
+	r.disputes[d.ID] = &copy
+	return true, nil
+}
+
+func (r *memoryDisputeRepository) load(d *domain.Dispute) *domain.Dispute {
+	copy := *d
+	copy.Evidence = nil
+	for _, evidence := range r.evidence {
+		if evidence.DisputeID == d.ID {
+			copy.Evidence = append(copy.Evidence, *evidence)
+		}
+	}
+	return &copy
+}
+
+func (r *memoryDisputeRepository) GetByID(id uint) (*domain.Dispute, error) {
+	d, ok := r.disputes[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.load(d), nil
+}
+
+func (r *memoryDisputeRepository) GetByReference(reference string) (*domain.Dispute, error) {
+	for _, d := range r.disputes {
+		if d.Reference == reference {
+			return r.load(d), nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryDisputeRepository) List(orderID uint, status string, limit int) ([]domain.Dispute, error) {
+	var disputes []domain.Dispute
+	for _, d := range r.disputes {
+		if (orderID == 0 || d.OrderID == orderID) && (status == "" || d.Status == status) {
+			disputes = append(disputes, *r.load(d))
+		}
+	}
+	return disputes, nil
+}
+
+func (r *memoryDisputeRepository) ListByPayment(paymentID uint) ([]domain.Dispute, error) {
+	var disputes []domain.Dispute
+	for _, d := range r.disputes {
+		if d.PaymentID == paymentID {
+			disputes = append(disputes, *d)
+		}
+	}
+	return disputes, nil
+}
+
+func (r *memoryDisputeRepository) ListDueBefore(status string, before time.Time, limit int) ([]domain.Dispute, error) {
+	var disputes []domain.Dispute
+	for _, d := range r.disputes {
+		if d.Status == status && !d.EvidenceDueAt.After(before) {
+			disputes = append(disputes, *r.load(d))
+		}
+	}
+	sort.Slice(disputes, func(i, j int) bool { return disputes[i].EvidenceDueAt.Before(disputes[j].EvidenceDueAt) })
+	return disputes, nil
+}
+
+func (r *memoryDisputeRepository) AddEvidence(evidence *domain.DisputeEvidence) error {
+	evidence.ID = uint(len(r.evidence) + 1)
+	copy := *evidence
+	r.evidence[evidence.ID] = &copy
+	return nil
+}
+
+func (r *memoryDisputeRepository) GetEvidence(id uint) (*domain.DisputeEvidence, error) {
+	evidence, ok := r.evidence[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copy := *evidence
+	return &copy, nil
+}
+
+func (r *memoryDisputeRepository) SubmitEvidence(d *domain.Dispute) (bool, error) {
+	stored := r.disputes[d.ID]
+	if stored.Status != domain.DisputeOpened {
+		return false, nil
+	}
+	stored.Status = domain.DisputeEvidenceSubmitted
+	stored.EvidenceSubmittedAt = d.EvidenceSubmittedAt
+	return true, nil
+}
+
+func (r *memoryDisputeRepository) Resolve(d *domain.Dispute, entry *domain.LedgerEntry) (bool, error) {
+	stored := r.disputes[d.ID]
+	if dispute.Final(stored.Status) {
+		return false, nil
+	}
+	stored.Status = d.Status
+	stored.ResolvedAt = d.ResolvedAt
+	stored.ResolvedBy = d.ResolvedBy
+	if entry != nil {
+		r.ledger = append(r.ledger, *entry)
+		payment := r.paymentRepo.payments[stored.OrderID]
+		payment.ChargedBack -= entry.Amount
+		if payment.Status == domain.StatusApproved && payment.ChargedBack >= payment.Amount {
+			payment.Status = domain.StatusChargedBack
+		}
+	}
+	return true, nil
+}
+
+var _ repository.DisputeRepository = (*memoryDisputeRepository)(nil)
+
+// getByIDPaymentRepository completa o repositório da análise antifraude com GetByID
+type getByIDPaymentRepository struct {
+	*reviewPaymentRepository
+}
+
+func (r getByIDPaymentRepository) GetByID(id uint) (*domain.Payment, error) {
+	for _, payment := range r.payments {
+		if payment.ID == id {
+			copy := *payment
+			return &copy, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+var disputeNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newDisputeFixture(t *testing.T) (*disputeService, *reviewPaymentRepository, *memoryDisputeRepository) {
+	payments := &reviewPaymentRepository{payments: map[uint]*domain.Payment{
+		42: {ID: 1, OrderID: 42, Amount: 10000, Currency: "BRL", Status: domain.StatusApproved},
+		43: {ID: 2, OrderID: 43, Amount: 5000, Currency: "BRL", Status: domain.StatusReview},
+	}}
+	disputes := &memoryDisputeRepository{
+		paymentRepo: payments,
+		disputes:    map[uint]*domain.Dispute{},
+		evidence:    map[uint]*domain.DisputeEvidence{},
+	}
+	cfg := dispute.Config{EvidenceWindow: 7 * 24 * time.Hour, WarningWindow: 72 * time.Hour, MaxEvidenceSize: 1024}
+	require.NoError(t, cfg.Validate())
+
+	svc := NewDisputeService(getByIDPaymentRepository{payments}, disputes, nil, cfg).(*disputeService)
+	svc.now = func() time.Time { return disputeNow }
+	return svc, payments, disputes
+}
+
+func TestOpenDispute(t *testing.T) {
+	svc, _, _ := newDisputeFixture(t)
+
+	opened, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-1", Reason: "fraude", Amount: money.New(4000, "brl")})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DisputeOpened, opened.Status)
+	assert.Equal(t, "BRL", opened.Currency)
+	assert.Equal(t, disputeNow.AddDate(0, 0, 7), opened.EvidenceDueAt)
+
+	again, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-1", Reason: "fraude"})
+	require.NoError(t, err)
+	assert.Equal(t, opened.ID, again.ID, "a mesma referência devolve a contestação registrada")
+
+	// Sem valor, contesta o restante ainda não contestado
+	rest, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-2", Reason: "não reconhecida"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6000), rest.Amount)
+
+	_, err = svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-3", Reason: "fraude", Amount: money.New(1, "BRL")})
+	assert.ErrorIs(t, err, ErrInvalidDispute)
+
+	_, err = svc.OpenDispute(OpenDisputeInput{OrderID: 43, Reference: "CB-1", Reason: "fraude"})
+	assert.ErrorIs(t, err, ErrInvalidDispute, "referência usada em outro pedido")
+
+	_, err = svc.OpenDispute(OpenDisputeInput{OrderID: 43, Reference: "CB-4", Reason: "fraude"})
+	assert.ErrorIs(t, err, ErrPaymentNotDisputable)
+
+	_, err = svc.OpenDispute(OpenDisputeInput{OrderID: 99, Reference: "CB-5", Reason: "fraude"})
+	assert.ErrorIs(t, err, ErrPaymentNotFound)
+
+	_, err = svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-6", Reason: "fraude", EvidenceDueAt: disputeNow.Add(-time.Hour)})
+	assert.ErrorIs(t, err, ErrInvalidDispute)
+}
+
+func TestDisputeEvidence(t *testing.T) {
+	svc, _, _ := newDisputeFixture(t)
+	opened, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-1", Reason: "produto não recebido"})
+	require.NoError(t, err)
+
+	_, err = svc.SubmitEvidence(opened.ID)
+	assert.ErrorIs(t, err, ErrInvalidDispute, "defesa sem evidências")
+
+	evidence, err := svc.AddEvidence(DisputeEvidenceInput{DisputeID: opened.ID, Kind: "shipping", FileName: "rastreio.txt", Content: []byte("entregue em 01/03")})
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", evidence.ContentType)
+	assert.Equal(t, int64(17), evidence.Size)
+	assert.Len(t, evidence.SHA256, 64)
+
+	_, err = svc.AddEvidence(DisputeEvidenceInput{DisputeID: opened.ID, FileName: "grande.txt", Content: make([]byte, 2048)})
+	assert.ErrorIs(t, err, ErrInvalidDispute)
+
+	submitted, err := svc.SubmitEvidence(opened.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DisputeEvidenceSubmitted, submitted.Status)
+	require.NotNil(t, submitted.EvidenceSubmittedAt)
+
+	_, err = svc.AddEvidence(DisputeEvidenceInput{DisputeID: opened.ID, FileName: "tarde.txt", Content: []byte("x")})
+	assert.ErrorIs(t, err, ErrDisputeClosed)
+
+	_, err = svc.GetEvidence(99)
+	assert.ErrorIs(t, err, ErrEvidenceNotFound)
+}
+
+func TestDisputeDeadlinePassed(t *testing.T) {
+	svc, _, _ := newDisputeFixture(t)
+	opened, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-1", Reason: "fraude", EvidenceDueAt: disputeNow.Add(time.Hour)})
+	require.NoError(t, err)
+
+	svc.now = func() time.Time { return disputeNow.Add(2 * time.Hour) }
+	_, err = svc.AddEvidence(DisputeEvidenceInput{DisputeID: opened.ID, FileName: "recibo.txt", Content: []byte("pago")})
+	assert.ErrorIs(t, err, ErrDisputeDeadlinePassed)
+}
+
+func TestResolveDispute(t *testing.T) {
+	svc, payments, disputes := newDisputeFixture(t)
+	won, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-1", Reason: "fraude", Amount: money.New(4000, "BRL")})
+	require.NoError(t, err)
+	lost, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-2", Reason: "fraude", Amount: money.New(6000, "BRL")})
+	require.NoError(t, err)
+
+	resolved, err := svc.ResolveDispute(ResolveDisputeInput{DisputeID: won.ID, Won: true, ResolvedBy: "ana@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DisputeWon, resolved.Status)
+	assert.Empty(t, disputes.ledger, "contestação ganha não estorna")
+	assert.Equal(t, domain.StatusApproved, payments.payments[42].Status)
+
+	resolved, err = svc.ResolveDispute(ResolveDisputeInput{DisputeID: lost.ID, ResolvedBy: "ana@example.com", Note: "sem defesa"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DisputeLost, resolved.Status)
+	require.Len(t, disputes.ledger, 1)
+	assert.Equal(t, domain.LedgerChargeback, disputes.ledger[0].Kind)
+	assert.Equal(t, int64(-6000), disputes.ledger[0].Amount)
+	assert.Equal(t, int64(6000), payments.payments[42].ChargedBack)
+	assert.Equal(t, domain.StatusApproved, payments.payments[42].Status, "estorno parcial mantém o pagamento aprovado")
+
+	_, err = svc.ResolveDispute(ResolveDisputeInput{DisputeID: lost.ID, Won: true})
+	assert.ErrorIs(t, err, ErrDisputeClosed)
+
+	// O valor da contestação ganha volta a poder ser contestado
+	last, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-3", Reason: "duplicidade"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), last.Amount)
+	_, err = svc.ResolveDispute(ResolveDisputeInput{DisputeID: last.ID})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusChargedBack, payments.payments[42].Status)
+}
+
+func TestListNearDeadline(t *testing.T) {
+	svc, _, _ := newDisputeFixture(t)
+	far, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-1", Reason: "fraude", Amount: money.New(1000, "BRL")})
+	require.NoError(t, err)
+	near, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-2", Reason: "fraude", Amount: money.New(1000, "BRL"), EvidenceDueAt: disputeNow.Add(48 * time.Hour)})
+	require.NoError(t, err)
+	overdue, err := svc.OpenDispute(OpenDisputeInput{OrderID: 42, Reference: "CB-3", Reason: "fraude", Amount: money.New(1000, "BRL"), EvidenceDueAt: disputeNow.Add(time.Hour)})
+	require.NoError(t, err)
+
+	svc.now = func() time.Time { return disputeNow.Add(2 * time.Hour) }
+	disputes, err := svc.ListNearDeadline(0, 0)
+	require.NoError(t, err)
+	require.Len(t, disputes, 2)
+	assert.Equal(t, overdue.ID, disputes[0].ID)
+	assert.Equal(t, near.ID, disputes[1].ID)
+
+	disputes, err = svc.ListNearDeadline(8*24*time.Hour, 0)
+	require.NoError(t, err)
+	assert.Len(t, disputes, 3)
+	assert.Equal(t, far.ID, disputes[2].ID)
+}
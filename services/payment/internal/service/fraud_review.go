@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/fraud"
+
+	"gorm.io/gorm"
+)
+
+// Erros da análise manual de pagamentos retidos
+var (
+	ErrPaymentNotFound = errors.New("pagamento não encontrado")
+	ErrNotInReview     = errors.New("pagamento não está em análise")
+	ErrReviewExpired   = errors.New("a reserva de estoque do pedido venceu; o pagamento só pode ser rejeitado")
+)
+
+// maxReviewList é o máximo de pagamentos retidos devolvidos por consulta
+const maxReviewList = 200
+
+// ReviewPaymentInput é a decisão do analista sobre um pagamento retido pela análise
+// antifraude: Approve libera a cobrança, senão o pagamento é recusado
+type ReviewPaymentInput struct {
+	OrderID  uint
+	Approve  bool
+	Reviewer string
+	Note     string
+}
+
+// screen analisa o risco de fraude do pagamento e registra no pagamento o score, a
+// decisão e as regras disparadas. Só devolve erro em falhas internas.
+func (s *paymentService) screen(payment *domain.Payment, input ProcessPaymentInput) error {
+	if !s.fraud.Enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	rules := s.fraud.Rules
+	in := fraud.Input{
+		Customer:        payment.Customer,
+		CardFingerprint: payment.CardFingerprint,
+		Amount:          s.fraudAmount(payment.OrderMoney()),
+		BillingState:    input.BillingState,
+		ShippingState:   input.ShippingState,
+		IPCountry:       input.IPCountry,
+		CustomerSince:   input.CustomerSince,
+		Now:             now,
+	}
+	if rules.VelocityScore > 0 {
+		byCustomer, byCard, err := s.paymentRepo.CountRecent(payment.Customer, payment.CardFingerprint, now.Add(-rules.VelocityWindow))
+		if err != nil {
+			log.Printf("Erro ao contar pagamentos recentes: %v", err)
+			return errors.New("falha na análise antifraude")
+		}
+		in.CustomerPayments, in.CardPayments = byCustomer, byCard
+	}
+
+	assessment := s.fraud.Evaluate(in)
+	payment.FraudScore = assessment.Score
+	payment.FraudDecision = assessment.Decision
+	for _, hit := range assessment.Hits {
+		payment.FraudRules = append(payment.FraudRules, domain.PaymentFraudRule{
+			Rule:   hit.Rule,
+			Score:  hit.Score,
+			Detail: truncate(hit.Detail, 255),
+		})
+	}
+	if len(assessment.Hits) > 0 {
+		log.Printf("🛡️ Análise antifraude do OrderID %d: score %d, decisão %s, regras %s",
+			payment.OrderID, assessment.Score, assessment.Decision, firedRules(assessment.Hits))
+	}
+	return nil
+}
+
+// fraudAmount converte o total do pedido para a moeda dos limites de valor. Sem
+// cotação, o valor segue na moeda original e a regra de valor o trata como acima da
+// maior faixa.
+func (s *paymentService) fraudAmount(amount money.Money) money.Money {
+	currency := money.NormalizeCurrency(s.fraud.Rules.Currency)
+	if amount.Currency == currency || s.rates == nil {
+		return amount
+	}
+	rate, err := s.rates.Rate(amount.Currency, currency)
+	if err != nil {
+		return amount
+	}
+	converted, err := rate.Convert(amount)
+	if err != nil {
+		return amount
+	}
+	return converted
+}
+
+// firedRules lista os códigos das regras disparadas para o log
+func firedRules(hits []fraud.Hit) string {
+	codes := make([]string, len(hits))
+	for i, hit := range hits {
+		codes[i] = hit.Rule
+	}
+	return strings.Join(codes, ", ")
+}
+
+// ListPaymentsInReview retorna os pagamentos retidos aguardando análise, os mais
+// antigos primeiro
+func (s *paymentService) ListPaymentsInReview(limit int) ([]domain.Payment, error) {
+	if limit <= 0 || limit > maxReviewList {
+		limit = maxReviewList
+	}
+	payments, err := s.paymentRepo.ListInReview(limit)
+	if err != nil {
+		log.Printf("Erro ao listar pagamentos em análise: %v", err)
+		return nil, errors.New("falha ao buscar pagamentos em análise")
+	}
+	return payments, nil
+}
+
+// ReviewPayment aplica a decisão do analista a um pagamento retido. Na aprovação o
+// pagamento segue para a cobrança como se não tivesse sido retido (cartão no provedor,
+// cobrança PIX ou boleto); na rejeição, é recusado. O evento do pagamento é publicado
+// com o novo status. Depois do vencimento da reserva de estoque a aprovação é recusada:
+// o estoque já voltou ao catálogo e pode ter sido vendido a outro cliente.
+func (s *paymentService) ReviewPayment(input ReviewPaymentInput) (*domain.Payment, error) {
+	if input.OrderID == 0 {
+		return nil, fmt.Errorf("%w: ID do pedido é obrigatório", ErrPaymentNotFound)
+	}
+
+	payment, err := s.paymentRepo.GetByOrderID(input.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: pedido %d", ErrPaymentNotFound, input.OrderID)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar pagamento do pedido %d: %v", input.OrderID, err)
+		return nil, errors.New("falha ao buscar pagamento")
+	}
+	if payment.Status != domain.StatusReview {
+		return nil, fmt.Errorf("%w: pedido %d está %s", ErrNotInReview, input.OrderID, payment.Status)
+	}
+
+	reviewedAt := time.Now()
+	if input.Approve && payment.ReservationExpiresAt != nil && reviewedAt.After(*payment.ReservationExpiresAt) {
+		log.Printf("⌛ Aprovação do OrderID %d recusada: reserva venceu em %s", payment.OrderID, payment.ReservationExpiresAt.Format(time.RFC3339))
+		return nil, fmt.Errorf("%w: pedido %d, reserva vencida em %s", ErrReviewExpired, input.OrderID, payment.ReservationExpiresAt.Format(time.RFC3339))
+	}
+	payment.ReviewedBy = truncate(input.Reviewer, 100)
+	payment.ReviewedAt = &reviewedAt
+	payment.ReviewNote = truncate(strings.TrimSpace(input.Note), 255)
+	if input.Approve {
+		log.Printf("✅ Pagamento do OrderID %d aprovado na análise por %s", payment.OrderID, payment.ReviewedBy)
+		s.authorize(payment)
+	} else {
+		log.Printf("⛔ Pagamento do OrderID %d rejeitado na análise por %s", payment.OrderID, payment.ReviewedBy)
+		payment.Status = domain.StatusFailed
+	}
+
+	completed, err := s.paymentRepo.CompleteReview(payment)
+	if err != nil {
+		log.Printf("Erro ao gravar análise do pagamento %d: %v", payment.ID, err)
+		return nil, errors.New("falha ao gravar análise do pagamento")
+	}
+	if !completed {
+		return nil, fmt.Errorf("%w: pedido %d já foi analisado", ErrNotInReview, input.OrderID)
+	}
+
+	if s.publisher != nil {
+		if err := s.publisher.PublishPaymentEvent(payment); err != nil {
+			log.Printf("⚠️ Erro ao publicar evento de pagamento: %v", err)
+		}
+	}
+	return payment, nil
+}
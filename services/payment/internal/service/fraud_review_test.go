@@ -0,0 +1,283 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/boleto"
+	"payment-service/internal/domain"
+	"payment-service/internal/fraud"
+	"payment-service/internal/gateway"
+	"payment-service/internal/installment"
+	"payment-service/internal/pix"
+	"payment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// reviewPaymentRepository guarda os pagamentos por pedido em memória; só os métodos
+// usados na análise antifraude são implementados
+type reviewPaymentRepository struct {
+	repository.PaymentRepository
+	payments     map[uint]*domain.Payment
+	byCustomer   int
+	byCard       int
+	countedSince time.Time
+}
+
+func (r *reviewPaymentRepository) Create(payment *domain.Payment) error {
+	payment.ID = uint(len(r.payments) + 1)
+	copy := *payment
+	r.payments[payment.OrderID] = &copy
+	return nil
+}
+
+func (r *reviewPaymentRepository) GetByOrderID(orderID uint) (*domain.Payment, error) {
+	payment, ok := r.payments[orderID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copy := *payment
+	return &copy, nil
+}
+
+func (r *reviewPaymentRepository) CountRecent(customer, cardFingerprint string, since time.Time) (int, int, error) {
+	r.countedSince = since
+	return r.byCustomer, r.byCard, nil
+}
+
+func (r *reviewPaymentRepository) CompleteReview(payment *domain.Payment) (bool, error) {
+	stored, ok := r.payments[payment.OrderID]
+	if !ok || stored.Status != domain.StatusReview {
+		return false, nil
+	}
+	copy := *payment
+	r.payments[payment.OrderID] = &copy
+	return true, nil
+}
+
+func (r *reviewPaymentRepository) AnonymizeByOrderIDs(orderIDs []uint, pseudonym string) ([]uint, error) {
+	var ids []uint
+	for _, orderID := range orderIDs {
+		payment, ok := r.payments[orderID]
+		if !ok {
+			continue
+		}
+		payment.Customer = pseudonym
+		payment.CardFingerprint = ""
+		payment.ReviewNote = ""
+		for i := range payment.FraudRules {
+			payment.FraudRules[i].Detail = ""
+		}
+		ids = append(ids, payment.ID)
+	}
+	return ids, nil
+}
+
+func newFraudFixture(t *testing.T, entries ...fraud.Entry) (PaymentService, *reviewPaymentRepository) {
+	lists, err := fraud.NewLists(entries)
+	require.NoError(t, err)
+	checker := fraud.Checker{
+		Rules: fraud.Rules{
+			Currency:             "BRL",
+			AmountThresholds:     []fraud.Threshold{{Amount: 500000, Score: 30}},
+			VelocityWindow:       time.Hour,
+			MaxCustomerPayments:  3,
+			MaxCardPayments:      3,
+			VelocityScore:        40,
+			BillingMismatchScore: 20,
+			HomeCountry:          "BR",
+			IPCountryScore:       30,
+			ReviewScore:          60,
+			DenyScore:            90,
+		},
+		Lists: fraud.StaticLists(lists),
+	}
+	repo := &reviewPaymentRepository{payments: map[uint]*domain.Payment{}}
+	// Provedor assíncrono: o pagamento liberado fica pendente, sem o resultado aleatório
+	svc := NewPaymentService(repo, nil, nil, nil, installment.Rules{}, pix.Config{}, boleto.Config{}, gateway.Config{Provider: "stub"}, checker)
+	return svc, repo
+}
+
+func fraudInput(orderID uint, amount int64) ProcessPaymentInput {
+	return ProcessPaymentInput{
+		OrderID:         orderID,
+		Amount:          money.New(amount, "BRL"),
+		Customer:        "42",
+		CardFingerprint: "fp_abc",
+		BillingState:    "SP",
+		ShippingState:   "SP",
+		IPCountry:       "BR",
+	}
+}
+
+func TestProcessPayment_FraudDecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    func() ProcessPaymentInput
+		byCard   int
+		status   string
+		decision string
+		score    int
+	}{
+		{"sem risco", func() ProcessPaymentInput { return fraudInput(1, 19990) }, 0, domain.StatusPending, fraud.DecisionAllow, 0},
+		{"retido para análise", func() ProcessPaymentInput {
+			in := fraudInput(1, 600000)
+			in.IPCountry = "US"
+			return in
+		}, 0, domain.StatusReview, fraud.DecisionReview, 60},
+		{"recusado", func() ProcessPaymentInput {
+			in := fraudInput(1, 600000)
+			in.BillingState = "RJ"
+			return in
+		}, 3, domain.StatusFailed, fraud.DecisionDeny, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newFraudFixture(t)
+			repo.byCard = tt.byCard
+
+			payment, err := svc.ProcessPayment(tt.input())
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, payment.Status)
+			assert.Equal(t, tt.decision, payment.FraudDecision)
+			assert.Equal(t, tt.score, payment.FraudScore)
+			assert.Len(t, payment.FraudRules, len(repo.payments[1].FraudRules))
+			if tt.status == domain.StatusPending {
+				assert.NotEmpty(t, payment.GatewayRef)
+			} else {
+				assert.Empty(t, payment.GatewayRef, "pagamento retido ou recusado não vai ao provedor")
+			}
+		})
+	}
+}
+
+func TestProcessPayment_FraudLists(t *testing.T) {
+	svc, _ := newFraudFixture(t, fraud.Entry{List: "deny", Kind: "card", Value: "fp_abc", Reason: "chargeback"})
+
+	payment, err := svc.ProcessPayment(fraudInput(1, 19990))
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, payment.Status)
+	require.Len(t, payment.FraudRules, 1)
+	assert.Equal(t, fraud.RuleDenyList, payment.FraudRules[0].Rule)
+	assert.Equal(t, "card fp_abc: chargeback", payment.FraudRules[0].Detail)
+}
+
+func TestReviewPayment(t *testing.T) {
+	held := func(t *testing.T) (PaymentService, *reviewPaymentRepository) {
+		svc, repo := newFraudFixture(t)
+		in := fraudInput(7, 600000)
+		in.IPCountry = "US"
+		payment, err := svc.ProcessPayment(in)
+		require.NoError(t, err)
+		require.Equal(t, domain.StatusReview, payment.Status)
+		return svc, repo
+	}
+
+	t.Run("aprovado", func(t *testing.T) {
+		svc, repo := held(t)
+		payment, err := svc.ReviewPayment(ReviewPaymentInput{OrderID: 7, Approve: true, Reviewer: "risco@loja.com", Note: " cliente confirmou "})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, payment.Status)
+		assert.NotEmpty(t, payment.GatewayRef)
+		assert.Equal(t, "risco@loja.com", repo.payments[7].ReviewedBy)
+		assert.Equal(t, "cliente confirmou", repo.payments[7].ReviewNote)
+		assert.NotNil(t, repo.payments[7].ReviewedAt)
+		assert.Equal(t, fraud.DecisionReview, repo.payments[7].FraudDecision, "a análise original é mantida")
+
+		_, err = svc.ReviewPayment(ReviewPaymentInput{OrderID: 7, Approve: false})
+		assert.ErrorIs(t, err, ErrNotInReview)
+	})
+
+	t.Run("rejeitado", func(t *testing.T) {
+		svc, repo := held(t)
+		payment, err := svc.ReviewPayment(ReviewPaymentInput{OrderID: 7, Reviewer: "risco@loja.com"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusFailed, payment.Status)
+		assert.Empty(t, payment.GatewayRef)
+		assert.Equal(t, domain.StatusFailed, repo.payments[7].Status)
+	})
+
+	t.Run("reserva vencida bloqueia a aprovação", func(t *testing.T) {
+		svc, repo := newFraudFixture(t)
+		in := fraudInput(8, 600000)
+		in.IPCountry = "US"
+		expiresAt := time.Now().Add(-time.Minute)
+		in.ReservationExpiresAt = &expiresAt
+		_, err := svc.ProcessPayment(in)
+		require.NoError(t, err)
+
+		_, err = svc.ReviewPayment(ReviewPaymentInput{OrderID: 8, Approve: true, Reviewer: "risco@loja.com"})
+		assert.ErrorIs(t, err, ErrReviewExpired)
+		assert.Equal(t, domain.StatusReview, repo.payments[8].Status)
+		assert.Empty(t, repo.payments[8].GatewayRef, "a cobrança não vai ao provedor")
+
+		payment, err := svc.ReviewPayment(ReviewPaymentInput{OrderID: 8, Reviewer: "risco@loja.com"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusFailed, payment.Status)
+	})
+
+	t.Run("aprovado dentro do prazo da reserva", func(t *testing.T) {
+		svc, _ := newFraudFixture(t)
+		in := fraudInput(9, 600000)
+		in.IPCountry = "US"
+		expiresAt := time.Now().Add(time.Hour)
+		in.ReservationExpiresAt = &expiresAt
+		_, err := svc.ProcessPayment(in)
+		require.NoError(t, err)
+
+		payment, err := svc.ReviewPayment(ReviewPaymentInput{OrderID: 9, Approve: true})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, payment.Status)
+	})
+
+	t.Run("pedido desconhecido", func(t *testing.T) {
+		svc, _ := newFraudFixture(t)
+		_, err := svc.ReviewPayment(ReviewPaymentInput{OrderID: 99, Approve: true})
+		assert.ErrorIs(t, err, ErrPaymentNotFound)
+	})
+}
+
+func TestProcessPayment_FraudDisabled(t *testing.T) {
+	repo := &reviewPaymentRepository{payments: map[uint]*domain.Payment{}}
+	svc := NewPaymentService(repo, nil, nil, nil, installment.Rules{}, pix.Config{}, boleto.Config{}, gateway.Config{Provider: "stub"}, fraud.Checker{})
+
+	in := fraudInput(1, 10000000)
+	in.IPCountry = "US"
+	payment, err := svc.ProcessPayment(in)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, payment.Status)
+	assert.Empty(t, payment.FraudDecision)
+	assert.True(t, repo.countedSince.IsZero(), "sem análise não há contagem de frequência")
+}
+
+func TestAnonymizePayments(t *testing.T) {
+	svc, repo := newFraudFixture(t)
+	in := fraudInput(7, 600000)
+	in.IPCountry = "US"
+	_, err := svc.ProcessPayment(in)
+	require.NoError(t, err)
+	_, err = svc.ProcessPayment(fraudInput(8, 19990))
+	require.NoError(t, err)
+	require.NotEmpty(t, repo.payments[7].FraudRules)
+
+	ids, err := svc.AnonymizePayments([]uint{7, 99}, "anon-1")
+	require.NoError(t, err)
+	assert.Equal(t, []uint{repo.payments[7].ID}, ids)
+
+	anonymized := repo.payments[7]
+	assert.Equal(t, "anon-1", anonymized.Customer)
+	assert.Empty(t, anonymized.CardFingerprint)
+	for _, rule := range anonymized.FraudRules {
+		assert.Empty(t, rule.Detail)
+	}
+	assert.Equal(t, int64(600000), anonymized.OrderAmount, "valores são mantidos")
+	assert.Equal(t, domain.StatusReview, anonymized.Status)
+	assert.Equal(t, "42", repo.payments[8].Customer, "outros pedidos não mudam")
+
+	_, err = svc.AnonymizePayments([]uint{7}, "")
+	assert.Error(t, err)
+}
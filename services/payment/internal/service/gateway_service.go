@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/gateway"
+	"payment-service/internal/publisher"
+	"payment-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// GatewayWebhook é um webhook do provedor com a assinatura já verificada: o corpo
+// original, o cabeçalho de assinatura e o evento lido do corpo
+type GatewayWebhook struct {
+	Provider  string
+	Payload   []byte
+	Signature string
+	SignedAt  time.Time
+	Event     gateway.Event
+}
+
+// GatewayService define o processamento dos eventos enviados pelo provedor de pagamento
+type GatewayService interface {
+	ProcessWebhook(input GatewayWebhook) (*domain.WebhookEvent, error)
+}
+
+// gatewayService implementa GatewayService
+type gatewayService struct {
+	paymentRepo repository.PaymentRepository
+	webhookRepo repository.WebhookRepository
+	publisher   *publisher.PaymentPublisher
+}
+
+// NewGatewayService cria uma nova instância do serviço de webhooks do provedor
+func NewGatewayService(paymentRepo repository.PaymentRepository, webhookRepo repository.WebhookRepository, pub *publisher.PaymentPublisher) GatewayService {
+	return &gatewayService{
+		paymentRepo: paymentRepo,
+		webhookRepo: webhookRepo,
+		publisher:   pub,
+	}
+}
+
+// eventStatus é o status do pagamento que cada tipo de evento produz
+var eventStatus = map[string]string{
+	gateway.EventPaymentSucceeded: domain.StatusApproved,
+	gateway.EventPaymentFailed:    domain.StatusFailed,
+}
+
+// ProcessWebhook guarda o webhook e aplica o evento ao pagamento. Reenvios de um evento
+// já aplicado devolvem o registro original sem alterar o pagamento; um evento gravado
+// cuja aplicação falhou é aplicado de novo no reenvio. Só pagamentos pendentes mudam de
+// status, e cada mudança publica o evento do pagamento uma única vez.
+func (s *gatewayService) ProcessWebhook(input GatewayWebhook) (*domain.WebhookEvent, error) {
+	event := input.Event
+	record := &domain.WebhookEvent{
+		Provider:   input.Provider,
+		EventID:    event.ID,
+		EventType:  event.Type,
+		PaymentRef: event.PaymentRef,
+		Signature:  truncate(input.Signature, 255),
+		SignedAt:   input.SignedAt,
+		Payload:    string(input.Payload),
+	}
+
+	created, err := s.webhookRepo.Create(record)
+	if err != nil {
+		log.Printf("Erro ao gravar webhook %s/%s: %v", input.Provider, event.ID, err)
+		return nil, errors.New("falha ao gravar webhook")
+	}
+	if !created {
+		stored, err := s.webhookRepo.GetByEventID(input.Provider, event.ID)
+		if err != nil {
+			log.Printf("Erro ao buscar webhook %s/%s: %v", input.Provider, event.ID, err)
+			return nil, errors.New("falha ao buscar webhook")
+		}
+		if stored.ProcessedAt != nil {
+			log.Printf("ℹ️ Webhook %s/%s reenviado; já processado (%s)", input.Provider, event.ID, stored.Outcome)
+			return stored, nil
+		}
+		record = stored
+	}
+
+	if err := s.apply(record, event); err != nil {
+		return nil, err
+	}
+
+	processedAt := time.Now()
+	record.ProcessedAt = &processedAt
+	if err := s.webhookRepo.SaveOutcome(record); err != nil {
+		log.Printf("Erro ao gravar resultado do webhook %s/%s: %v", input.Provider, event.ID, err)
+		return nil, errors.New("falha ao gravar resultado do webhook")
+	}
+	log.Printf("🔔 Webhook %s/%s (%s) para %s: %s %s", input.Provider, event.ID, event.Type, event.PaymentRef, record.Outcome, record.Detail)
+	return record, nil
+}
+
+// apply aplica o evento ao pagamento e registra o resultado em record. Só devolve erro
+// em falhas internas, para que o provedor reenvie o webhook.
+func (s *gatewayService) apply(record *domain.WebhookEvent, event gateway.Event) error {
+	payment, err := s.paymentRepo.GetByGatewayRef(event.PaymentRef)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		record.Outcome = domain.WebhookNotFound
+		record.Detail = "referência sem pagamento"
+		return nil
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar pagamento %s: %v", event.PaymentRef, err)
+		return errors.New("falha ao buscar pagamento")
+	}
+	record.PaymentID = &payment.ID
+
+	target, known := eventStatus[event.Type]
+	switch {
+	case !known:
+		record.Outcome = domain.WebhookIgnored
+		record.Detail = fmt.Sprintf("tipo de evento %q não tratado", event.Type)
+		return nil
+	case event.Amount != nil && money.New(event.Amount.Amount, event.Amount.Currency) != payment.Money():
+		record.Outcome = domain.WebhookAmountMismatch
+		record.Detail = fmt.Sprintf("evento %s, cobrado %s", *event.Amount, payment.Money())
+		return nil
+	case payment.Status == target:
+		record.Outcome = domain.WebhookAlreadyApplied
+		return nil
+	case payment.Status != domain.StatusPending:
+		record.Outcome = domain.WebhookIgnored
+		record.Detail = fmt.Sprintf("transição %s → %s não permitida", payment.Status, target)
+		return nil
+	}
+
+	changed, err := s.paymentRepo.UpdateStatus(payment.ID, domain.StatusPending, target)
+	if err != nil {
+		log.Printf("Erro ao atualizar pagamento %d: %v", payment.ID, err)
+		return errors.New("falha ao atualizar pagamento")
+	}
+	if !changed {
+		// Outro evento mudou o pagamento enquanto este era processado
+		record.Outcome = domain.WebhookIgnored
+		record.Detail = "pagamento alterado por outro evento"
+		return nil
+	}
+
+	payment.Status = target
+	record.Outcome = domain.WebhookApplied
+	if event.Reason != "" {
+		record.Detail = truncate(event.Reason, 255)
+	}
+	if s.publisher != nil {
+		if err := s.publisher.PublishPaymentEvent(payment); err != nil {
+			log.Printf("⚠️ Erro ao publicar evento de pagamento: %v", err)
+		}
+	}
+	return nil
+}
+
+// truncate limita o texto a size bytes, o tamanho da coluna, sem cortar um caractere
+func truncate(text string, size int) string {
+	if len(text) <= size {
+		return text
+	}
+	for size > 0 && !utf8.RuneStart(text[size]) {
+		size--
+	}
+	return text[:size]
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/gateway"
+	"payment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryPaymentRepository guarda os pagamentos em memória; só os métodos usados pelo
+// GatewayService são implementados
+type memoryPaymentRepository struct {
+	repository.PaymentRepository
+	payments map[string]*domain.Payment
+	updates  int
+}
+
+func (r *memoryPaymentRepository) GetByGatewayRef(ref string) (*domain.Payment, error) {
+	payment, ok := r.payments[ref]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copy := *payment
+	return &copy, nil
+}
+
+func (r *memoryPaymentRepository) UpdateStatus(paymentID uint, from, to string) (bool, error) {
+	for _, payment := range r.payments {
+		if payment.ID == paymentID && payment.Status == from {
+			payment.Status = to
+			r.updates++
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memoryWebhookRepository guarda os webhooks em memória, únicos por provedor e evento
+type memoryWebhookRepository struct {
+	events map[string]*domain.WebhookEvent
+}
+
+func (r *memoryWebhookRepository) Create(event *domain.WebhookEvent) (bool, error) {
+	key := event.Provider + "/" + event.EventID
+	if _, ok := r.events[key]; ok {
+		return false, nil
+	}
+	copy := *event
+	r.events[key] = &copy
+	return true, nil
+}
+
+func (r *memoryWebhookRepository) GetByEventID(provider, eventID string) (*domain.WebhookEvent, error) {
+	event, ok := r.events[provider+"/"+eventID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copy := *event
+	return &copy, nil
+}
+
+func (r *memoryWebhookRepository) SaveOutcome(event *domain.WebhookEvent) error {
+	copy := *event
+	r.events[event.Provider+"/"+event.EventID] = &copy
+	return nil
+}
+
+func newGatewayFixture(status string) (GatewayService, *memoryPaymentRepository, *memoryWebhookRepository) {
+	payments := &memoryPaymentRepository{payments: map[string]*domain.Payment{
+		"stub_1": {ID: 1, OrderID: 10, Amount: 1999, Currency: "BRL", Method: "card", Status: status, GatewayRef: "stub_1"},
+	}}
+	webhooks := &memoryWebhookRepository{events: map[string]*domain.WebhookEvent{}}
+	return NewGatewayService(payments, webhooks, nil), payments, webhooks
+}
+
+func webhookFor(eventType, ref string, amount int64) GatewayWebhook {
+	stub := gateway.NewStub("", "whsec_teste")
+	return GatewayWebhook{
+		Provider: "stub",
+		Payload:  []byte(`{}`),
+		SignedAt: time.Now(),
+		Event:    stub.Event(eventType, ref, money.New(amount, "BRL")),
+	}
+}
+
+func TestProcessWebhook_Applied(t *testing.T) {
+	svc, payments, webhooks := newGatewayFixture(domain.StatusPending)
+	input := webhookFor(gateway.EventPaymentSucceeded, "stub_1", 1999)
+
+	record, err := svc.ProcessWebhook(input)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookApplied, record.Outcome)
+	assert.Equal(t, uint(1), *record.PaymentID)
+	assert.NotNil(t, record.ProcessedAt)
+	assert.Equal(t, domain.StatusApproved, payments.payments["stub_1"].Status)
+	assert.Len(t, webhooks.events, 1)
+
+	// O reenvio do mesmo evento devolve o registro original sem tocar no pagamento
+	again, err := svc.ProcessWebhook(input)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookApplied, again.Outcome)
+	assert.Equal(t, 1, payments.updates)
+	assert.Len(t, webhooks.events, 1)
+}
+
+func TestProcessWebhook_Failed(t *testing.T) {
+	svc, payments, _ := newGatewayFixture(domain.StatusPending)
+	input := webhookFor(gateway.EventPaymentFailed, "stub_1", 1999)
+	input.Event.Reason = "saldo insuficiente"
+
+	record, err := svc.ProcessWebhook(input)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookApplied, record.Outcome)
+	assert.Equal(t, "saldo insuficiente", record.Detail)
+	assert.Equal(t, domain.StatusFailed, payments.payments["stub_1"].Status)
+}
+
+func TestProcessWebhook_ReprocessesUnfinished(t *testing.T) {
+	svc, payments, webhooks := newGatewayFixture(domain.StatusPending)
+	input := webhookFor(gateway.EventPaymentSucceeded, "stub_1", 1999)
+
+	// Webhook gravado numa entrega anterior que falhou antes de ser aplicado
+	webhooks.Create(&domain.WebhookEvent{Provider: "stub", EventID: input.Event.ID})
+
+	record, err := svc.ProcessWebhook(input)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookApplied, record.Outcome)
+	assert.Equal(t, domain.StatusApproved, payments.payments["stub_1"].Status)
+}
+
+func TestProcessWebhook_Outcomes(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		eventType string
+		ref       string
+		amount    int64
+		outcome   string
+	}{
+		{"referência desconhecida", domain.StatusPending, gateway.EventPaymentSucceeded, "stub_2", 1999, domain.WebhookNotFound},
+		{"valor divergente", domain.StatusPending, gateway.EventPaymentSucceeded, "stub_1", 999, domain.WebhookAmountMismatch},
+		{"tipo não tratado", domain.StatusPending, "payment.refunded", "stub_1", 1999, domain.WebhookIgnored},
+		{"já aprovado", domain.StatusApproved, gateway.EventPaymentSucceeded, "stub_1", 1999, domain.WebhookAlreadyApplied},
+		{"aprovado depois recusado", domain.StatusApproved, gateway.EventPaymentFailed, "stub_1", 1999, domain.WebhookIgnored},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, payments, _ := newGatewayFixture(tt.status)
+
+			record, err := svc.ProcessWebhook(webhookFor(tt.eventType, tt.ref, tt.amount))
+			require.NoError(t, err)
+			assert.Equal(t, tt.outcome, record.Outcome)
+			assert.NotNil(t, record.ProcessedAt)
+			assert.Equal(t, tt.status, payments.payments["stub_1"].Status)
+			assert.Zero(t, payments.updates)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPaymentQuery indica filtros inválidos na listagem de pagamentos
+var ErrInvalidPaymentQuery = errors.New("consulta de pagamentos inválida")
+
+// Limites das consultas de pagamentos
+const (
+	defaultPaymentPage = 50
+	maxPaymentPage     = 200
+	// MaxPaymentsByOrders é o máximo de pedidos por consulta em lote
+	MaxPaymentsByOrders = 500
+)
+
+// paymentStatuses são os status aceitos no filtro da listagem
+var paymentStatuses = []string{
+	domain.StatusPending,
+	domain.StatusReview,
+	domain.StatusApproved,
+	domain.StatusFailed,
+	domain.StatusChargedBack,
+}
+
+// PaymentQuery são os filtros da listagem paginada de pagamentos; campos vazios não
+// filtram. CreatedTo é exclusivo; a faixa de valores filtra também pela moeda.
+type PaymentQuery struct {
+	Status      string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	MinAmount   *money.Money
+	MaxAmount   *money.Money
+	OrderIDs    []uint
+	PageSize    int
+	PageToken   string
+}
+
+// PaymentPage é uma página da listagem; NextPageToken vazio indica a última página
+type PaymentPage struct {
+	Payments      []domain.Payment
+	NextPageToken string
+}
+
+// SearchPayments lista os pagamentos que atendem aos filtros, os mais novos primeiro
+func (s *paymentService) SearchPayments(query PaymentQuery) (PaymentPage, error) {
+	filter, err := paymentFilter(query)
+	if err != nil {
+		return PaymentPage{}, err
+	}
+
+	// Um a mais indica se há próxima página
+	filter.Limit++
+	payments, err := s.paymentRepo.Search(filter)
+	if err != nil {
+		log.Printf("Erro ao listar pagamentos: %v", err)
+		return PaymentPage{}, errors.New("falha ao buscar pagamentos")
+	}
+
+	page := PaymentPage{Payments: payments}
+	if len(payments) == filter.Limit {
+		page.Payments = payments[:filter.Limit-1]
+		page.NextPageToken = strconv.FormatUint(uint64(page.Payments[len(page.Payments)-1].ID), 10)
+	}
+	return page, nil
+}
+
+// paymentFilter valida os filtros da listagem e os converte para o repositório
+func paymentFilter(query PaymentQuery) (repository.PaymentFilter, error) {
+	filter := repository.PaymentFilter{
+		OrderIDs:    query.OrderIDs,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		Limit:       query.PageSize,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPaymentPage
+	}
+	filter.Limit = min(filter.Limit, maxPaymentPage)
+
+	if status := strings.TrimSpace(query.Status); status != "" {
+		if !slices.Contains(paymentStatuses, status) {
+			return filter, fmt.Errorf("%w: status %q (esperado %s)", ErrInvalidPaymentQuery, status, strings.Join(paymentStatuses, ", "))
+		}
+		filter.Statuses = []string{status}
+	}
+	if len(query.OrderIDs) > MaxPaymentsByOrders {
+		return filter, fmt.Errorf("%w: no máximo %d pedidos", ErrInvalidPaymentQuery, MaxPaymentsByOrders)
+	}
+	if !query.CreatedFrom.IsZero() && !query.CreatedTo.IsZero() && !query.CreatedFrom.Before(query.CreatedTo) {
+		return filter, fmt.Errorf("%w: created_from deve ser anterior a created_to", ErrInvalidPaymentQuery)
+	}
+
+	for _, bound := range []*money.Money{query.MinAmount, query.MaxAmount} {
+		if bound == nil {
+			continue
+		}
+		if filter.Currency != "" && bound.Currency != filter.Currency {
+			return filter, fmt.Errorf("%w: valores mínimo e máximo em moedas diferentes", ErrInvalidPaymentQuery)
+		}
+		filter.Currency = bound.Currency
+	}
+	if query.MinAmount != nil {
+		filter.MinAmount = &query.MinAmount.Amount
+	}
+	if query.MaxAmount != nil {
+		filter.MaxAmount = &query.MaxAmount.Amount
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, fmt.Errorf("%w: valor mínimo maior que o máximo", ErrInvalidPaymentQuery)
+	}
+
+	if query.PageToken != "" {
+		after, err := strconv.ParseUint(query.PageToken, 10, 32)
+		if err != nil || after == 0 {
+			return filter, fmt.Errorf("%w: page_token %q", ErrInvalidPaymentQuery, query.PageToken)
+		}
+		filter.AfterID = uint(after)
+	}
+	return filter, nil
+}
+
+// GetPayment busca um pagamento pelo ID
+func (s *paymentService) GetPayment(id uint) (*domain.Payment, error) {
+	payment, err := s.paymentRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrPaymentNotFound, id)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar pagamento %d: %v", id, err)
+		return nil, errors.New("falha ao buscar pagamento")
+	}
+	return payment, nil
+}
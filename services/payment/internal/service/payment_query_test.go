@@ -0,0 +1,138 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/boleto"
+	"payment-service/internal/domain"
+	"payment-service/internal/fraud"
+	"payment-service/internal/gateway"
+	"payment-service/internal/installment"
+	"payment-service/internal/pix"
+	"payment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// searchPaymentRepository guarda os pagamentos em memória, do mais novo ao mais antigo;
+// só as consultas da listagem são implementadas
+type searchPaymentRepository struct {
+	repository.PaymentRepository
+	payments []domain.Payment
+	filters  []repository.PaymentFilter
+}
+
+func (r *searchPaymentRepository) Search(filter repository.PaymentFilter) ([]domain.Payment, error) {
+	r.filters = append(r.filters, filter)
+	var payments []domain.Payment
+	for _, payment := range r.payments {
+		if filter.AfterID > 0 && payment.ID >= filter.AfterID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && payment.Status != filter.Statuses[0] {
+			continue
+		}
+		if len(payments) < filter.Limit {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
+
+func (r *searchPaymentRepository) GetByID(id uint) (*domain.Payment, error) {
+	for _, payment := range r.payments {
+		if payment.ID == id {
+			return &payment, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func newSearchFixture() (PaymentService, *searchPaymentRepository) {
+	repo := &searchPaymentRepository{}
+	for id := uint(5); id >= 1; id-- {
+		status := domain.StatusApproved
+		if id%2 == 0 {
+			status = domain.StatusFailed
+		}
+		repo.payments = append(repo.payments, domain.Payment{ID: id, OrderID: 40 + id, Amount: int64(id) * 1000, Currency: "BRL", Status: status})
+	}
+	svc := NewPaymentService(repo, nil, nil, nil, installment.Rules{}, pix.Config{}, boleto.Config{}, gateway.Config{}, fraud.Checker{})
+	return svc, repo
+}
+
+func TestSearchPayments_Pagination(t *testing.T) {
+	svc, _ := newSearchFixture()
+
+	first, err := svc.SearchPayments(PaymentQuery{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Payments, 2)
+	assert.Equal(t, uint(5), first.Payments[0].ID)
+	assert.Equal(t, "4", first.NextPageToken)
+
+	second, err := svc.SearchPayments(PaymentQuery{PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), second.Payments[0].ID)
+	assert.Equal(t, "2", second.NextPageToken)
+
+	last, err := svc.SearchPayments(PaymentQuery{PageSize: 2, PageToken: second.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, last.Payments, 1)
+	assert.Empty(t, last.NextPageToken, "última página")
+
+	approved, err := svc.SearchPayments(PaymentQuery{Status: domain.StatusApproved})
+	require.NoError(t, err)
+	assert.Len(t, approved.Payments, 3)
+	assert.Empty(t, approved.NextPageToken)
+}
+
+func TestSearchPayments_Filters(t *testing.T) {
+	svc, repo := newSearchFixture()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	minimum, maximum := money.New(1000, "BRL"), money.New(5000, "BRL")
+
+	_, err := svc.SearchPayments(PaymentQuery{
+		CreatedFrom: from,
+		CreatedTo:   from.AddDate(0, 1, 0),
+		MinAmount:   &minimum,
+		MaxAmount:   &maximum,
+		OrderIDs:    []uint{41, 42},
+		PageSize:    1000,
+	})
+	require.NoError(t, err)
+	filter := repo.filters[0]
+	assert.Equal(t, "BRL", filter.Currency)
+	assert.Equal(t, int64(1000), *filter.MinAmount)
+	assert.Equal(t, int64(5000), *filter.MaxAmount)
+	assert.Equal(t, []uint{41, 42}, filter.OrderIDs)
+	assert.Equal(t, maxPaymentPage+1, filter.Limit, "page_size limitado, mais um para a próxima página")
+
+	dollars := money.New(100, "USD")
+	invalid := []PaymentQuery{
+		{Status: "unknown"},
+		{CreatedFrom: from, CreatedTo: from},
+		{MinAmount: &maximum, MaxAmount: &minimum},
+		{MinAmount: &minimum, MaxAmount: &dollars},
+		{PageToken: "abc"},
+		{OrderIDs: make([]uint, MaxPaymentsByOrders+1)},
+	}
+	for i, query := range invalid {
+		_, err := svc.SearchPayments(query)
+		assert.ErrorIs(t, err, ErrInvalidPaymentQuery, "caso %d", i)
+	}
+}
+
+func TestGetPayment(t *testing.T) {
+	svc, _ := newSearchFixture()
+
+	payment, err := svc.GetPayment(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint(43), payment.OrderID)
+
+	_, err = svc.GetPayment(99)
+	assert.ErrorIs(t, err, ErrPaymentNotFound)
+}
@@ -2,46 +2,130 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"math/rand"
+	"strings"
+	"time"
 
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/boleto"
 	"payment-service/internal/domain"
+	"payment-service/internal/fraud"
+	"payment-service/internal/fx"
+	"payment-service/internal/gateway"
+	"payment-service/internal/installment"
+	"payment-service/internal/pix"
 	"payment-service/internal/publisher"
 	"payment-service/internal/repository"
 )
 
+// Erros de forma de pagamento
+var (
+	ErrInvalidPaymentMethod = errors.New("forma de pagamento não suportada")
+	ErrPixUnavailable       = errors.New("PIX não configurado")
+	ErrPixInstallments      = errors.New("PIX não aceita parcelamento")
+	ErrBoletoUnavailable    = errors.New("boleto não configurado")
+	ErrBoletoInstallments   = errors.New("boleto não aceita parcelamento")
+	ErrFraudDenied          = errors.New("pagamento recusado pela análise antifraude")
+)
+
+// ProcessPaymentInput contém os dados de cobrança de um pedido. Amount é o total do
+// pedido na moeda do pedido; Currency é a moeda em que o cliente paga (vazia, a do
+// pedido), Installments o número de parcelas (0 ou 1, à vista) e Method a forma de
+// pagamento (card, pix ou boleto; vazia, card). Os demais campos alimentam a análise
+// antifraude: o cliente e a impressão digital do cartão (nunca o número), as UFs de
+// cobrança e de entrega, o país do IP e a data de criação da conta do cliente.
+// ReservationExpiresAt é o vencimento da reserva de estoque do pedido: um pagamento retido
+// para análise não pode ser aprovado depois dele.
+type ProcessPaymentInput struct {
+	OrderID         uint
+	Amount          money.Money
+	Currency        string
+	Installments    int
+	Method          string
+	Customer        string
+	CardFingerprint string
+	BillingState    string
+	ShippingState   string
+	IPCountry       string
+	CustomerSince   *time.Time
+
+	ReservationExpiresAt *time.Time
+}
+
 // PaymentService define a interface para regras de negócio de pagamentos
 type PaymentService interface {
-	ProcessPayment(orderID uint, amount float64) (*domain.Payment, error)
+	ProcessPayment(input ProcessPaymentInput) (*domain.Payment, error)
 	GetPaymentByOrderID(orderID uint) (*domain.Payment, error)
 	ListPayments() ([]domain.Payment, error)
+	SearchPayments(query PaymentQuery) (PaymentPage, error)
+	GetPayment(id uint) (*domain.Payment, error)
+	ListPaymentsByOrderIDs(orderIDs []uint) ([]domain.Payment, error)
+	AnonymizePayments(orderIDs []uint, pseudonym string) ([]uint, error)
+	ListPaymentsInReview(limit int) ([]domain.Payment, error)
+	ReviewPayment(input ReviewPaymentInput) (*domain.Payment, error)
 }
 
 // paymentService implementa PaymentService
 type paymentService struct {
 	paymentRepo repository.PaymentRepository
 	publisher   *publisher.PaymentPublisher
+	rates       fx.Provider
+	fxRepo      repository.FXRateRepository
+	rules       installment.Rules
+	pix         pix.Config
+	boleto      boleto.Config
+	gateway     gateway.Config
+	fraud       fraud.Checker
 }
 
-// NewPaymentService cria uma nova instância do serviço de pagamentos
-func NewPaymentService(paymentRepo repository.PaymentRepository, pub *publisher.PaymentPublisher) PaymentService {
+// NewPaymentService cria uma nova instância do serviço de pagamentos. rates fornece as
+// cotações para cobrar em moeda diferente da do pedido e fxRepo guarda a cópia de cada
+// cotação aplicada; sem eles, apenas pagamentos na moeda do pedido são aceitos.
+// rules são as regras de parcelamento do lojista, pixConfig o recebedor das cobranças PIX,
+// boletoConfig a carteira de cobrança dos boletos, gatewayConfig o provedor dos
+// pagamentos com cartão e fraudChecker, a análise antifraude (desligada no valor zero).
+func NewPaymentService(paymentRepo repository.PaymentRepository, pub *publisher.PaymentPublisher, rates fx.Provider, fxRepo repository.FXRateRepository, rules installment.Rules, pixConfig pix.Config, boletoConfig boleto.Config, gatewayConfig gateway.Config, fraudChecker fraud.Checker) PaymentService {
 	return &paymentService{
 		paymentRepo: paymentRepo,
 		publisher:   pub,
+		rates:       rates,
+		fxRepo:      fxRepo,
+		rules:       rules,
+		pix:         pixConfig,
+		boleto:      boletoConfig,
+		gateway:     gatewayConfig,
+		fraud:       fraudChecker,
 	}
 }
 
-// ProcessPayment processa um pagamento para um pedido
-func (s *paymentService) ProcessPayment(orderID uint, amount float64) (*domain.Payment, error) {
+// ProcessPayment processa um pagamento para um pedido. A conversão de moeda usa a
+// cotação vigente, registrada no pagamento, e o parcelamento é feito sobre o valor
+// convertido, conforme as regras do lojista. Pagamentos sem cotação ou com um
+// parcelamento fora das regras são recusados. Pagamentos PIX ficam pendentes com a
+// cobrança gerada até a confirmação do PSP (ver PixService) e pagamentos com boleto,
+// até a liquidação no arquivo de retorno do banco (ver BoletoService). Com um provedor
+// de cartão assíncrono, o pagamento com cartão fica pendente até o webhook do provedor
+// (ver GatewayService); no provedor simulated, o resultado é imediato. Antes de tudo
+// isso a análise antifraude pode recusar o pagamento ou retê-lo em review, sem cobrança,
+// até a decisão do analista (ver ReviewPayment).
+func (s *paymentService) ProcessPayment(input ProcessPaymentInput) (*domain.Payment, error) {
+	orderID, amount := input.OrderID, input.Amount
+
 	// Validações de negócio
 	if orderID == 0 {
 		return nil, errors.New("ID do pedido é obrigatório")
 	}
 
-	if amount <= 0 {
+	if !amount.IsPositive() {
 		return nil, errors.New("valor do pagamento deve ser maior que zero")
 	}
 
+	if !money.ValidCurrency(amount.Currency) {
+		return nil, errors.New("moeda do pagamento não suportada")
+	}
+
 	// Verificar se já existe um pagamento para este pedido
 	existingPayment, err := s.paymentRepo.GetByOrderID(orderID)
 	if err == nil && existingPayment != nil {
@@ -49,21 +133,75 @@ func (s *paymentService) ProcessPayment(orderID uint, amount float64) (*domain.P
 		return existingPayment, nil
 	}
 
-	// Simular processamento do pagamento (90% de aprovação)
-	var status string
-	if rand.Float64() < 0.9 {
-		status = domain.StatusApproved
-		log.Printf("✅ Pagamento simulado - APROVADO para OrderID %d", orderID)
-	} else {
-		status = domain.StatusFailed
-		log.Printf("❌ Pagamento simulado - FALHOU para OrderID %d", orderID)
+	// Converter o total do pedido para a moeda de pagamento e montar o parcelamento
+	charge, rate, chargeErr := s.convert(amount, input.Currency)
+	plan := upfront(charge)
+	method := paymentMethod(input.Method)
+	if chargeErr == nil {
+		chargeErr = s.validateMethod(method, input.Installments)
+	}
+	if chargeErr == nil {
+		plan, chargeErr = s.buildPlan(charge, input.Installments)
 	}
 
 	// Criar o pagamento
 	payment := &domain.Payment{
-		OrderID: orderID,
-		Status:  status,
-		Amount:  amount,
+		OrderID:         orderID,
+		Amount:          plan.Total,
+		Currency:        charge.Currency,
+		OrderAmount:     amount.Amount,
+		OrderCurrency:   money.NormalizeCurrency(amount.Currency),
+		FXRate:          rate.Decimal(),
+		Installments:    plan.Installments,
+		InterestFree:    plan.InterestFree,
+		MonthlyRate:     plan.MonthlyRate,
+		InterestAmount:  plan.Interest,
+		Method:          method,
+		Customer:        truncate(strings.TrimSpace(input.Customer), 100),
+		CardFingerprint: truncate(strings.TrimSpace(input.CardFingerprint), 64),
+
+		ReservationExpiresAt: input.ReservationExpiresAt,
+	}
+
+	// Analisar o risco de fraude antes de autorizar
+	if chargeErr == nil {
+		if err := s.screen(payment, input); err != nil {
+			return nil, err
+		}
+		if payment.FraudDecision == fraud.DecisionDeny {
+			chargeErr = fmt.Errorf("%w: score %d", ErrFraudDenied, payment.FraudScore)
+		}
+	}
+
+	switch {
+	case chargeErr != nil:
+		payment.Status = domain.StatusFailed
+		log.Printf("❌ Pagamento recusado para OrderID %d: %v", orderID, chargeErr)
+	case payment.FraudDecision == fraud.DecisionReview:
+		payment.Status = domain.StatusReview
+		log.Printf("🔎 Pagamento retido para análise antifraude - OrderID %d, score %d", orderID, payment.FraudScore)
+	default:
+		s.authorize(payment)
+	}
+
+	// Cronograma das parcelas
+	for _, item := range plan.Schedule {
+		payment.Schedule = append(payment.Schedule, domain.PaymentInstallment{
+			Number:   item.Number,
+			Amount:   item.Amount,
+			Currency: plan.Currency,
+			DueDate:  item.DueDate,
+		})
+	}
+
+	// Guardar a cópia da cotação aplicada
+	if !rate.IsIdentity() {
+		snapshot := fx.Snapshot(rate)
+		if err := s.fxRepo.CreateSnapshot(&snapshot); err != nil {
+			log.Printf("Erro ao registrar cotação: %v", err)
+			return nil, errors.New("falha ao registrar cotação do pagamento")
+		}
+		payment.FXSnapshotID = &snapshot.ID
 	}
 
 	// Salvar no repositório
@@ -81,12 +219,166 @@ func (s *paymentService) ProcessPayment(orderID uint, amount float64) (*domain.P
 		}
 	}
 
-	log.Printf("💰 Pagamento processado para OrderID: %d - Status: %s - Amount: %.2f",
-		orderID, status, amount)
+	log.Printf("💰 Pagamento processado para OrderID: %d - Status: %s - Forma: %s - Amount: %s em %dx - Pedido: %s - Cotação: %s",
+		orderID, payment.Status, method, payment.Money(), payment.Installments, amount, rate.Decimal())
 
 	return payment, nil
 }
 
+// authorize gera a cobrança PIX ou o boleto, pagos depois pelo cliente, ou envia o
+// pagamento com cartão ao provedor, e define o status do pagamento
+func (s *paymentService) authorize(payment *domain.Payment) {
+	orderID, charge := payment.OrderID, payment.Money()
+
+	var err error
+	switch payment.Method {
+	case domain.MethodPix:
+		payment.PixCharge, err = s.issuePix(orderID, charge)
+	case domain.MethodBoleto:
+		payment.Boleto, err = s.issueBoleto(orderID, charge)
+	default:
+		// Referência do pagamento com cartão no provedor
+		payment.GatewayRef = s.gateway.NewRef()
+	}
+
+	// Simular processamento do pagamento com cartão (90% de aprovação)
+	switch {
+	case err != nil:
+		payment.Status = domain.StatusFailed
+		log.Printf("❌ Pagamento recusado para OrderID %d: %v", orderID, err)
+	case payment.PixCharge != nil:
+		payment.Status = domain.StatusPending
+		log.Printf("🔳 Cobrança PIX gerada para OrderID %d - txid %s, expira em %s",
+			orderID, payment.PixCharge.TxID, payment.PixCharge.ExpiresAt.Format(time.RFC3339))
+	case payment.Boleto != nil:
+		payment.Status = domain.StatusPending
+		log.Printf("🧾 Boleto emitido para OrderID %d - nosso número %s, vencimento %s",
+			orderID, payment.Boleto.NossoNumero, payment.Boleto.DueDate.Format(time.DateOnly))
+	case s.gateway.Async():
+		payment.Status = domain.StatusPending
+		log.Printf("⏳ Pagamento enviado ao provedor %s para OrderID %d - referência %s",
+			s.gateway.Provider, orderID, payment.GatewayRef)
+	case rand.Float64() < 0.9:
+		payment.Status = domain.StatusApproved
+		log.Printf("✅ Pagamento simulado - APROVADO para OrderID %d", orderID)
+	default:
+		payment.Status = domain.StatusFailed
+		log.Printf("❌ Pagamento simulado - FALHOU para OrderID %d", orderID)
+	}
+}
+
+// paymentMethod normaliza a forma de pagamento; vazia, cartão
+func paymentMethod(method string) string {
+	method = strings.ToLower(strings.TrimSpace(method))
+	if method == "" {
+		return domain.MethodCard
+	}
+	return method
+}
+
+// validateMethod verifica se a forma de pagamento é aceita com o parcelamento pedido
+func (s *paymentService) validateMethod(method string, installments int) error {
+	switch method {
+	case domain.MethodCard:
+		return nil
+	case domain.MethodPix:
+		if !s.pix.Enabled() {
+			return ErrPixUnavailable
+		}
+		if installments > 1 {
+			return fmt.Errorf("%w: %d parcelas", ErrPixInstallments, installments)
+		}
+		return nil
+	case domain.MethodBoleto:
+		if !s.boleto.Enabled() {
+			return ErrBoletoUnavailable
+		}
+		if installments > 1 {
+			return fmt.Errorf("%w: %d parcelas", ErrBoletoInstallments, installments)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidPaymentMethod, method)
+	}
+}
+
+// issuePix gera a cobrança PIX do valor cobrado
+func (s *paymentService) issuePix(orderID uint, charge money.Money) (*domain.PixCharge, error) {
+	issued, err := s.pix.Issue(charge, fmt.Sprintf("Pedido %d", orderID), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PixCharge{
+		TxID:      issued.TxID,
+		Kind:      issued.Kind,
+		Payload:   issued.Payload,
+		Status:    domain.PixStatusActive,
+		ExpiresAt: issued.ExpiresAt,
+	}, nil
+}
+
+// issueBoleto emite o boleto do valor cobrado; o sequencial do nosso número é o ID do
+// pedido, único por pagamento
+func (s *paymentService) issueBoleto(orderID uint, charge money.Money) (*domain.Boleto, error) {
+	issued, err := s.boleto.Issue(uint64(orderID), charge, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Boleto{
+		NossoNumero:   issued.NossoNumero,
+		Barcode:       issued.Barcode,
+		DigitableLine: issued.DigitableLine,
+		DueDate:       issued.DueDate,
+		Status:        domain.BoletoStatusOpen,
+		ExpiresAt:     s.boleto.ExpiresAt(issued.DueDate),
+	}, nil
+}
+
+// buildPlan monta o parcelamento do valor cobrado; 0 ou 1 parcela é à vista
+func (s *paymentService) buildPlan(charge money.Money, installments int) (installment.Plan, error) {
+	if installments <= 1 {
+		return upfront(charge), nil
+	}
+	return s.rules.Build(charge, installments, time.Now())
+}
+
+// upfront é o pagamento à vista, sem cronograma de parcelas
+func upfront(charge money.Money) installment.Plan {
+	return installment.Plan{
+		Installments: 1,
+		InterestFree: true,
+		Currency:     charge.Currency,
+		Principal:    charge.Amount,
+		Total:        charge.Amount,
+	}
+}
+
+// convert converte o total do pedido para a moeda de pagamento. Sem cotação para o
+// par, o valor é mantido na moeda do pedido e o erro é devolvido.
+func (s *paymentService) convert(amount money.Money, currency string) (money.Money, fx.Rate, error) {
+	amount = money.New(amount.Amount, amount.Currency)
+	if currency == "" {
+		currency = amount.Currency
+	}
+	currency = money.NormalizeCurrency(currency)
+	if currency == amount.Currency {
+		return amount, fx.Identity(currency), nil
+	}
+	if s.rates == nil || s.fxRepo == nil {
+		return amount, fx.Identity(amount.Currency), fmt.Errorf("%w: %s/%s", fx.ErrRateNotFound, amount.Currency, currency)
+	}
+
+	rate, err := s.rates.Rate(amount.Currency, currency)
+	if err != nil {
+		return amount, fx.Identity(amount.Currency), err
+	}
+	charge, err := rate.Convert(amount)
+	if err != nil || !charge.IsPositive() {
+		return amount, fx.Identity(amount.Currency), fmt.Errorf("%w: %s/%s = %s", fx.ErrInvalidRate, rate.From, rate.To, rate.Decimal())
+	}
+	return charge, rate, nil
+}
+
 // GetPaymentByOrderID retorna o pagamento de um pedido específico
 func (s *paymentService) GetPaymentByOrderID(orderID uint) (*domain.Payment, error) {
 	if orderID == 0 {
@@ -113,3 +405,38 @@ func (s *paymentService) ListPayments() ([]domain.Payment, error) {
 	log.Printf("📋 Listados %d pagamentos", len(payments))
 	return payments, nil
 }
+
+// ListPaymentsByOrderIDs retorna os pagamentos de um conjunto de pedidos
+func (s *paymentService) ListPaymentsByOrderIDs(orderIDs []uint) ([]domain.Payment, error) {
+	if len(orderIDs) == 0 {
+		return []domain.Payment{}, nil
+	}
+
+	payments, err := s.paymentRepo.ListByOrderIDs(orderIDs)
+	if err != nil {
+		log.Printf("Erro ao buscar pagamentos por pedidos: %v", err)
+		return nil, errors.New("falha ao buscar pagamentos")
+	}
+	return payments, nil
+}
+
+// AnonymizePayments substitui o cliente dos pagamentos dos pedidos de um titular por um
+// pseudônimo e remove os sinais antifraude que o identificam (LGPD). Valores, datas e
+// status são preservados como registros financeiros.
+func (s *paymentService) AnonymizePayments(orderIDs []uint, pseudonym string) ([]uint, error) {
+	if pseudonym == "" {
+		return nil, errors.New("pseudônimo é obrigatório")
+	}
+	if len(orderIDs) == 0 {
+		return []uint{}, nil
+	}
+
+	ids, err := s.paymentRepo.AnonymizeByOrderIDs(orderIDs, pseudonym)
+	if err != nil {
+		log.Printf("Erro ao anonimizar pagamentos: %v", err)
+		return nil, errors.New("falha ao anonimizar pagamentos")
+	}
+
+	log.Printf("🕶️ %d pagamentos anonimizados como %s", len(ids), pseudonym)
+	return ids, nil
+}
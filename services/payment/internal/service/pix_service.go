@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/publisher"
+	"payment-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// pixExpiryBatchSize é o número de cobranças expiradas por consulta
+const pixExpiryBatchSize = 100
+
+// Erros da confirmação de cobranças PIX
+var (
+	ErrPixChargeNotFound = errors.New("cobrança PIX não encontrada")
+	ErrPixAmountMismatch = errors.New("valor recebido difere do valor da cobrança PIX")
+	ErrPixChargeClosed   = errors.New("cobrança PIX expirada")
+)
+
+// PixConfirmation é a confirmação de um PIX recebido, enviada pelo PSP no webhook
+type PixConfirmation struct {
+	TxID       string
+	EndToEndID string
+	Amount     money.Money
+	PaidAt     time.Time
+}
+
+// PixService define as operações sobre cobranças PIX já geradas
+type PixService interface {
+	GetCharge(orderID uint) (*domain.Payment, error)
+	Confirm(input PixConfirmation) (*domain.Payment, error)
+	ExpireCharges(now time.Time) (int, error)
+	RunExpiryWorker(ctx context.Context, interval time.Duration)
+}
+
+// pixService implementa PixService
+type pixService struct {
+	paymentRepo repository.PaymentRepository
+	publisher   *publisher.PaymentPublisher
+}
+
+// NewPixService cria uma nova instância do serviço de cobranças PIX
+func NewPixService(paymentRepo repository.PaymentRepository, pub *publisher.PaymentPublisher) PixService {
+	return &pixService{
+		paymentRepo: paymentRepo,
+		publisher:   pub,
+	}
+}
+
+// GetCharge retorna o pagamento PIX de um pedido, com a cobrança
+func (s *pixService) GetCharge(orderID uint) (*domain.Payment, error) {
+	if orderID == 0 {
+		return nil, fmt.Errorf("%w: ID do pedido é obrigatório", ErrPixChargeNotFound)
+	}
+
+	payment, err := s.paymentRepo.GetByOrderID(orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && payment.PixCharge == nil {
+		return nil, fmt.Errorf("%w: pedido %d", ErrPixChargeNotFound, orderID)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar cobrança PIX do pedido %d: %v", orderID, err)
+		return nil, errors.New("falha ao buscar cobrança PIX")
+	}
+	return payment, nil
+}
+
+// Confirm aprova o pagamento da cobrança PIX recebida. Confirmações repetidas da mesma
+// cobrança são ignoradas; valores diferentes do cobrado e cobranças já expiradas são
+// recusados.
+func (s *pixService) Confirm(input PixConfirmation) (*domain.Payment, error) {
+	payment, err := s.paymentRepo.GetByPixTxID(input.TxID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: txid %s", ErrPixChargeNotFound, input.TxID)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar cobrança PIX %s: %v", input.TxID, err)
+		return nil, errors.New("falha ao buscar cobrança PIX")
+	}
+
+	charge := payment.PixCharge
+	switch charge.Status {
+	case domain.PixStatusPaid:
+		log.Printf("ℹ️ PIX %s já confirmado para OrderID %d", input.TxID, payment.OrderID)
+		return payment, nil
+	case domain.PixStatusExpired:
+		log.Printf("⚠️ PIX recebido para cobrança expirada %s (OrderID %d, E2E %s): devolução necessária",
+			input.TxID, payment.OrderID, input.EndToEndID)
+		return payment, ErrPixChargeClosed
+	}
+
+	if input.Amount != payment.Money() {
+		return payment, fmt.Errorf("%w: recebido %s, cobrado %s", ErrPixAmountMismatch, input.Amount, payment.Money())
+	}
+
+	paidAt := input.PaidAt
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+	charge.Status = domain.PixStatusPaid
+	charge.PaidAt = &paidAt
+	charge.EndToEndID = input.EndToEndID
+	payment.Status = domain.StatusApproved
+
+	if err := s.settle(payment); err != nil {
+		return nil, err
+	}
+	if payment.PixCharge == nil || payment.PixCharge.Status != domain.PixStatusPaid {
+		// A cobrança expirou enquanto a confirmação era processada
+		return payment, ErrPixChargeClosed
+	}
+	log.Printf("✅ PIX confirmado para OrderID %d - txid %s, E2E %s", payment.OrderID, charge.TxID, charge.EndToEndID)
+	return payment, nil
+}
+
+// ExpireCharges recusa os pagamentos PIX cujas cobranças venceram sem pagamento,
+// liberando a reserva do pedido
+func (s *pixService) ExpireCharges(now time.Time) (int, error) {
+	expired := 0
+	for {
+		payments, err := s.paymentRepo.ListExpiredPix(now, pixExpiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for i := range payments {
+			payment := &payments[i]
+			payment.PixCharge.Status = domain.PixStatusExpired
+			payment.Status = domain.StatusFailed
+			if err := s.settle(payment); err != nil {
+				return expired, err
+			}
+			if payment.Status != domain.StatusFailed {
+				// Confirmado enquanto a expiração era processada
+				continue
+			}
+			log.Printf("⌛ Cobrança PIX expirada para OrderID %d - txid %s", payment.OrderID, payment.PixCharge.TxID)
+			expired++
+		}
+
+		if len(payments) < pixExpiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// RunExpiryWorker expira as cobranças vencidas periodicamente até o contexto ser cancelado
+func (s *pixService) RunExpiryWorker(ctx context.Context, interval time.Duration) {
+	log.Printf("⏰ Expirador de cobranças PIX iniciado (intervalo %s)", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Expirador de cobranças PIX finalizado")
+			return
+		case <-ticker.C:
+			count, err := s.ExpireCharges(time.Now())
+			if err != nil {
+				log.Printf("⚠️ Erro ao expirar cobranças PIX: %v", err)
+			}
+			if count > 0 {
+				log.Printf("⌛ %d cobranças PIX expiradas", count)
+			}
+		}
+	}
+}
+
+// settle grava o resultado da cobrança e publica o evento do pagamento. Se outra
+// confirmação ou a expiração chegou antes, o estado gravado prevalece e nada é publicado.
+func (s *pixService) settle(payment *domain.Payment) error {
+	settled, err := s.paymentRepo.SettlePix(payment)
+	if err != nil {
+		log.Printf("Erro ao atualizar cobrança PIX %s: %v", payment.PixCharge.TxID, err)
+		return errors.New("falha ao atualizar cobrança PIX")
+	}
+	if !settled {
+		current, err := s.paymentRepo.GetByID(payment.ID)
+		if err == nil {
+			*payment = *current
+		}
+		return nil
+	}
+
+	if s.publisher != nil {
+		if err := s.publisher.PublishPaymentEvent(payment); err != nil {
+			log.Printf("⚠️ Erro ao publicar evento de pagamento: %v", err)
+		}
+	}
+	return nil
+}
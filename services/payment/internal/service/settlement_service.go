@@ -0,0 +1,318 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"payment-service/internal/domain"
+	"payment-service/internal/repository"
+	"payment-service/internal/settlement"
+
+	"gorm.io/gorm"
+)
+
+// Erros da conciliação de liquidações
+var (
+	ErrSettlementRunNotFound = errors.New("conciliação não encontrada")
+	ErrInvalidSettlement     = errors.New("conciliação inválida")
+)
+
+// maxSettlementRuns é o máximo de conciliações devolvidas por consulta
+const maxSettlementRuns = 100
+
+// SettlementImport é um arquivo de liquidação a conciliar
+type SettlementImport struct {
+	FileName   string
+	Content    []byte
+	Source     string
+	ImportedBy string
+}
+
+// SettlementService define as operações da conciliação dos pagamentos com os arquivos
+// de liquidação do provedor de cartão
+type SettlementService interface {
+	Import(input SettlementImport) (*domain.SettlementRun, bool, error)
+	GetRun(id uint, outcome string) (*domain.SettlementRun, error)
+	ListRuns(limit int) ([]domain.SettlementRun, error)
+	ImportInbox(dir string) (int, error)
+	RunInboxWorker(ctx context.Context, dir string, interval time.Duration)
+}
+
+// settlementService implementa SettlementService
+type settlementService struct {
+	paymentRepo    repository.PaymentRepository
+	settlementRepo repository.SettlementRepository
+}
+
+// NewSettlementService cria uma nova instância do serviço de conciliação
+func NewSettlementService(paymentRepo repository.PaymentRepository, settlementRepo repository.SettlementRepository) SettlementService {
+	return &settlementService{
+		paymentRepo:    paymentRepo,
+		settlementRepo: settlementRepo,
+	}
+}
+
+// Import concilia um arquivo de liquidação e grava o resultado. O mesmo arquivo
+// (mesmo conteúdo) devolve a conciliação já gravada, com created false.
+func (s *settlementService) Import(input SettlementImport) (*domain.SettlementRun, bool, error) {
+	sum := sha256.Sum256(input.Content)
+	digest := hex.EncodeToString(sum[:])
+	if existing, err := s.settlementRepo.GetBySHA256(digest); err == nil {
+		log.Printf("📑 Arquivo de liquidação %s já conciliado (conciliação %d)", input.FileName, existing.ID)
+		return existing, false, nil
+	}
+
+	file, err := settlement.ReadFile(bytes.NewReader(input.Content))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidSettlement, err)
+	}
+
+	run := &domain.SettlementRun{
+		FileName:   truncate(filepath.Base(strings.TrimSpace(input.FileName)), 255),
+		FileSHA256: digest,
+		Source:     input.Source,
+		ImportedBy: truncate(input.ImportedBy, 100),
+		Records:    len(file.Records),
+	}
+	if run.Items, err = s.classify(file, run); err != nil {
+		return nil, false, err
+	}
+	for _, item := range run.Items {
+		countOutcome(run, item.Outcome)
+	}
+
+	created, err := s.settlementRepo.Create(run)
+	if err != nil {
+		log.Printf("Erro ao gravar conciliação do arquivo %s: %v", run.FileName, err)
+		return nil, false, errors.New("falha ao gravar conciliação")
+	}
+	if !created {
+		// Conciliado em paralelo
+		existing, err := s.settlementRepo.GetBySHA256(digest)
+		return existing, false, err
+	}
+
+	log.Printf("📑 Liquidação %s conciliada: %d transações, %d conferidas, %d sem pagamento, %d ausentes do arquivo, %d com valor divergente, %d com status divergente, %d repetidas",
+		run.FileName, run.Records, run.Matched, run.MissingPayment, run.MissingSettlement,
+		run.AmountMismatch, run.StatusMismatch, run.Duplicate)
+	return run, true, nil
+}
+
+// classify compara cada transação com o pagamento da mesma referência e, quando o
+// arquivo traz as datas, aponta os pagamentos do período que não aparecem nele
+func (s *settlementService) classify(file settlement.File, run *domain.SettlementRun) ([]domain.SettlementItem, error) {
+	refs := make([]string, 0, len(file.Records))
+	inFile := map[string]bool{}
+	for _, record := range file.Records {
+		if !inFile[record.GatewayRef] {
+			inFile[record.GatewayRef] = true
+			refs = append(refs, record.GatewayRef)
+		}
+	}
+	payments, err := s.paymentRepo.ListByGatewayRefs(refs)
+	if err != nil {
+		log.Printf("Erro ao buscar pagamentos da liquidação: %v", err)
+		return nil, errors.New("falha ao buscar pagamentos")
+	}
+	byRef := map[string]*domain.Payment{}
+	for i := range payments {
+		byRef[payments[i].GatewayRef] = &payments[i]
+	}
+
+	var items []domain.SettlementItem
+	seen := map[string]bool{}
+	for _, record := range file.Records {
+		payment := byRef[record.GatewayRef]
+		outcome, detail := settlement.Classify(record, payment)
+		key := record.GatewayRef + "|" + record.Status
+		if seen[key] {
+			outcome, detail = settlement.OutcomeDuplicate, fmt.Sprintf("transação %s repetida no arquivo", record.Status)
+		}
+		seen[key] = true
+
+		item := domain.SettlementItem{
+			Line:            record.Line,
+			GatewayRef:      record.GatewayRef,
+			Outcome:         outcome,
+			SettledStatus:   record.Status,
+			SettledAmount:   record.Amount.Amount,
+			SettledCurrency: record.Amount.Currency,
+			Detail:          truncate(detail, 255),
+		}
+		if payment != nil {
+			setPayment(&item, payment)
+		}
+		items = append(items, item)
+	}
+
+	from, to, ok := file.Period()
+	if !ok {
+		return items, nil
+	}
+	run.PeriodStart, run.PeriodEnd = &from, &to
+	settleable, err := s.paymentRepo.ListSettleable(from, to)
+	if err != nil {
+		log.Printf("Erro ao buscar pagamentos do período da liquidação: %v", err)
+		return nil, errors.New("falha ao buscar pagamentos")
+	}
+	for i := range settleable {
+		payment := &settleable[i]
+		if inFile[payment.GatewayRef] {
+			continue
+		}
+		item := domain.SettlementItem{
+			GatewayRef: payment.GatewayRef,
+			Outcome:    settlement.OutcomeMissingSettlement,
+			Detail:     fmt.Sprintf("pagamento de %s ausente do arquivo", payment.CreatedAt.UTC().Format(time.DateOnly)),
+		}
+		setPayment(&item, payment)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// setPayment copia os dados do pagamento para o item da conciliação
+func setPayment(item *domain.SettlementItem, payment *domain.Payment) {
+	id := payment.ID
+	item.PaymentID = &id
+	item.OrderID = payment.OrderID
+	item.PaymentStatus = payment.Status
+	item.PaymentAmount = payment.Amount
+	item.PaymentCurrency = payment.Currency
+}
+
+// countOutcome soma o item ao total do resultado na conciliação
+func countOutcome(run *domain.SettlementRun, outcome string) {
+	switch outcome {
+	case settlement.OutcomeMatched:
+		run.Matched++
+	case settlement.OutcomeMissingPayment:
+		run.MissingPayment++
+	case settlement.OutcomeMissingSettlement:
+		run.MissingSettlement++
+	case settlement.OutcomeAmountMismatch:
+		run.AmountMismatch++
+	case settlement.OutcomeStatusMismatch:
+		run.StatusMismatch++
+	case settlement.OutcomeDuplicate:
+		run.Duplicate++
+	}
+}
+
+// GetRun busca uma conciliação com os itens, filtrados pelo resultado quando informado
+func (s *settlementService) GetRun(id uint, outcome string) (*domain.SettlementRun, error) {
+	outcome = strings.TrimSpace(outcome)
+	if outcome != "" && !slices.Contains(settlement.Outcomes, outcome) {
+		return nil, fmt.Errorf("%w: resultado %q (esperado %s)", ErrInvalidSettlement, outcome, strings.Join(settlement.Outcomes, ", "))
+	}
+
+	run, err := s.settlementRepo.GetByID(id, outcome)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrSettlementRunNotFound, id)
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar conciliação %d: %v", id, err)
+		return nil, errors.New("falha ao buscar conciliação")
+	}
+	return run, nil
+}
+
+// ListRuns lista as conciliações mais recentes, sem os itens
+func (s *settlementService) ListRuns(limit int) ([]domain.SettlementRun, error) {
+	if limit <= 0 || limit > maxSettlementRuns {
+		limit = maxSettlementRuns
+	}
+	runs, err := s.settlementRepo.List(limit)
+	if err != nil {
+		log.Printf("Erro ao listar conciliações: %v", err)
+		return nil, errors.New("falha ao listar conciliações")
+	}
+	return runs, nil
+}
+
+// ImportInbox concilia os arquivos .csv da pasta de entrada. Os conciliados vão para
+// processed/ e os ilegíveis para failed/; em falhas de banco o arquivo fica na pasta
+// para a próxima varredura. Retorna quantos arquivos foram conciliados.
+func (s *settlementService) ImportInbox(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao ler a pasta de liquidações %s: %w", dir, err)
+	}
+
+	imported := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".csv") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("⚠️ Erro ao ler arquivo de liquidação %s: %v", path, err)
+			continue
+		}
+
+		_, _, err = s.Import(SettlementImport{
+			FileName:   entry.Name(),
+			Content:    content,
+			Source:     domain.SettlementSourceInbox,
+			ImportedBy: domain.SettlementSourceInbox,
+		})
+		target := "processed"
+		if errors.Is(err, ErrInvalidSettlement) {
+			log.Printf("⚠️ Arquivo de liquidação %s recusado: %v", entry.Name(), err)
+			target = "failed"
+		} else if err != nil {
+			log.Printf("⚠️ Erro ao conciliar arquivo de liquidação %s: %v", entry.Name(), err)
+			continue
+		} else {
+			imported++
+		}
+
+		if err := moveTo(path, filepath.Join(dir, target)); err != nil {
+			log.Printf("⚠️ Erro ao mover arquivo de liquidação %s: %v", path, err)
+		}
+	}
+	return imported, nil
+}
+
+// moveTo move o arquivo para a pasta informada, criando-a se preciso
+func moveTo(path, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.Rename(path, filepath.Join(dir, filepath.Base(path)))
+}
+
+// RunInboxWorker concilia periodicamente os arquivos deixados na pasta de entrada até o
+// contexto ser cancelado
+func (s *settlementService) RunInboxWorker(ctx context.Context, dir string, interval time.Duration) {
+	log.Printf("⏰ Conciliação de liquidações iniciada (pasta %s, intervalo %s)", dir, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Conciliação de liquidações finalizada")
+			return
+		case <-ticker.C:
+			count, err := s.ImportInbox(dir)
+			if err != nil {
+				log.Printf("⚠️ Erro ao conciliar liquidações: %v", err)
+			}
+			if count > 0 {
+				log.Printf("📑 %d arquivos de liquidação conciliados", count)
+			}
+		}
+	}
+}
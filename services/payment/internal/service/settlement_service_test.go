@@ -0,0 +1,182 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"payment-service/internal/domain"
+	"payment-service/internal/repository"
+	"payment-service/internal/settlement"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// settlementPaymentRepository guarda os pagamentos com cartão em memória; só as
+// consultas da conciliação são implementadas
+type settlementPaymentRepository struct {
+	repository.PaymentRepository
+	payments []domain.Payment
+}
+
+func (r *settlementPaymentRepository) ListByGatewayRefs(refs []string) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	for _, payment := range r.payments {
+		for _, ref := range refs {
+			if payment.GatewayRef == ref {
+				payments = append(payments, payment)
+			}
+		}
+	}
+	return payments, nil
+}
+
+func (r *settlementPaymentRepository) ListSettleable(from, to time.Time) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	for _, payment := range r.payments {
+		settleable := payment.Status == domain.StatusApproved || payment.Status == domain.StatusChargedBack
+		if settleable && !payment.CreatedAt.Before(from) && payment.CreatedAt.Before(to) {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
+
+// memorySettlementRepository guarda as conciliações em memória
+type memorySettlementRepository struct {
+	runs []domain.SettlementRun
+}
+
+func (r *memorySettlementRepository) Create(run *domain.SettlementRun) (bool, error) {
+	for _, existing := range r.runs {
+		if existing.FileSHA256 == run.FileSHA256 {
+			return false, nil
+		}
+	}
+	run.ID = uint(len(r.runs) + 1)
+	r.runs = append(r.runs, *run)
+	return true, nil
+}
+
+func (r *memorySettlementRepository) GetByID(id uint, outcome string) (*domain.SettlementRun, error) {
+	for _, run := range r.runs {
+		if run.ID != id {
+			continue
+		}
+		items := run.Items
+		run.Items = nil
+		for _, item := range items {
+			if outcome == "" || item.Outcome == outcome {
+				run.Items = append(run.Items, item)
+			}
+		}
+		return &run, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memorySettlementRepository) GetBySHA256(sum string) (*domain.SettlementRun, error) {
+	for _, run := range r.runs {
+		if run.FileSHA256 == sum {
+			return &run, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memorySettlementRepository) List(limit int) ([]domain.SettlementRun, error) {
+	return r.runs, nil
+}
+
+func newSettlementFixture() (SettlementService, *memorySettlementRepository) {
+	day := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	payments := &settlementPaymentRepository{payments: []domain.Payment{
+		{ID: 1, OrderID: 41, GatewayRef: "stub_1", Amount: 19990, Currency: "BRL", Status: domain.StatusApproved, CreatedAt: day},
+		{ID: 2, OrderID: 42, GatewayRef: "stub_2", Amount: 5000, Currency: "BRL", Status: domain.StatusApproved, CreatedAt: day},
+		{ID: 3, OrderID: 43, GatewayRef: "stub_3", Amount: 7000, Currency: "BRL", Status: domain.StatusFailed, CreatedAt: day},
+		{ID: 4, OrderID: 44, GatewayRef: "stub_4", Amount: 1000, Currency: "BRL", Status: domain.StatusApproved, CreatedAt: day},
+		{ID: 5, OrderID: 45, GatewayRef: "stub_5", Amount: 1000, Currency: "BRL", Status: domain.StatusApproved, CreatedAt: day.AddDate(0, 0, 1)},
+	}}
+	runs := &memorySettlementRepository{}
+	return NewSettlementService(payments, runs), runs
+}
+
+const settlementFile = `gateway_ref,amount,currency,status,transaction_date
+stub_1,199.90,BRL,settled,2025-03-10
+stub_2,49.00,BRL,settled,2025-03-10
+stub_3,70.00,BRL,settled,2025-03-10
+stub_9,10.00,BRL,settled,2025-03-10
+stub_1,199.90,BRL,settled,2025-03-10
+`
+
+func TestImportSettlement(t *testing.T) {
+	svc, _ := newSettlementFixture()
+
+	run, created, err := svc.Import(SettlementImport{FileName: "/tmp/liquidacao.csv", Content: []byte(settlementFile), Source: domain.SettlementSourceRPC, ImportedBy: "financeiro@example.com"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "liquidacao.csv", run.FileName)
+	assert.Len(t, run.FileSHA256, 64)
+	assert.Equal(t, 5, run.Records)
+	require.NotNil(t, run.PeriodStart)
+	assert.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), *run.PeriodEnd)
+
+	outcomes := map[string]string{}
+	for _, item := range run.Items {
+		outcomes[item.GatewayRef+"/"+item.Outcome] = item.Detail
+	}
+	assert.Contains(t, outcomes, "stub_1/"+settlement.OutcomeMatched)
+	assert.Contains(t, outcomes, "stub_1/"+settlement.OutcomeDuplicate)
+	assert.Contains(t, outcomes, "stub_2/"+settlement.OutcomeAmountMismatch)
+	assert.Contains(t, outcomes, "stub_3/"+settlement.OutcomeStatusMismatch)
+	assert.Contains(t, outcomes, "stub_9/"+settlement.OutcomeMissingPayment)
+	assert.Contains(t, outcomes, "stub_4/"+settlement.OutcomeMissingSettlement)
+	assert.NotContains(t, outcomes, "stub_5/"+settlement.OutcomeMissingSettlement, "pagamento fora do período do arquivo")
+
+	assert.Equal(t, 1, run.Matched)
+	assert.Equal(t, 1, run.Duplicate)
+	assert.Equal(t, 1, run.AmountMismatch)
+	assert.Equal(t, 1, run.StatusMismatch)
+	assert.Equal(t, 1, run.MissingPayment)
+	assert.Equal(t, 1, run.MissingSettlement)
+
+	again, created, err := svc.Import(SettlementImport{FileName: "copia.csv", Content: []byte(settlementFile)})
+	require.NoError(t, err)
+	assert.False(t, created, "o mesmo arquivo não é conciliado de novo")
+	assert.Equal(t, run.ID, again.ID)
+
+	filtered, err := svc.GetRun(run.ID, settlement.OutcomeAmountMismatch)
+	require.NoError(t, err)
+	require.Len(t, filtered.Items, 1)
+	assert.Equal(t, uint(42), filtered.Items[0].OrderID)
+
+	_, err = svc.GetRun(run.ID, "unknown")
+	assert.ErrorIs(t, err, ErrInvalidSettlement)
+	_, err = svc.GetRun(99, "")
+	assert.ErrorIs(t, err, ErrSettlementRunNotFound)
+
+	_, _, err = svc.Import(SettlementImport{FileName: "ruim.csv", Content: []byte("ref;valor\n")})
+	assert.ErrorIs(t, err, ErrInvalidSettlement)
+}
+
+func TestImportInbox(t *testing.T) {
+	svc, runs := newSettlementFixture()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "liquidacao.csv"), []byte(settlementFile), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ruim.CSV"), []byte("ref;valor\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "leia-me.txt"), []byte("ignorado"), 0o644))
+
+	count, err := svc.ImportInbox(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, runs.runs, 1)
+	assert.Equal(t, domain.SettlementSourceInbox, runs.runs[0].Source)
+
+	assert.FileExists(t, filepath.Join(dir, "processed", "liquidacao.csv"))
+	assert.FileExists(t, filepath.Join(dir, "failed", "ruim.CSV"))
+	assert.FileExists(t, filepath.Join(dir, "leia-me.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "liquidacao.csv"))
+}
@@ -0,0 +1,273 @@
+// Package settlement lê os arquivos de liquidação (settlement) do provedor de cartão e
+// classifica cada transação liquidada contra o pagamento registrado com a mesma
+// referência do provedor (gateway_ref).
+//
+// O arquivo é um CSV com cabeçalho, separado por vírgula ou ponto e vírgula, com as
+// colunas gateway_ref, amount, currency e status e, opcional, transaction_date
+// (AAAA-MM-DD, em UTC). Com transaction_date, os pagamentos com cartão aprovados nos
+// dias do arquivo que não aparecem nele também são apontados.
+package settlement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+)
+
+// ErrInvalidFile indica um arquivo de liquidação ilegível
+var ErrInvalidFile = errors.New("arquivo de liquidação inválido")
+
+// Status das transações no arquivo de liquidação
+const (
+	StatusSettled    = "settled"
+	StatusRefunded   = "refunded"
+	StatusChargeback = "chargeback"
+	StatusFailed     = "failed"
+)
+
+// statusAliases normaliza os nomes usados pelos provedores
+var statusAliases = map[string]string{
+	"settled":      StatusSettled,
+	"captured":     StatusSettled,
+	"paid":         StatusSettled,
+	"succeeded":    StatusSettled,
+	"refunded":     StatusRefunded,
+	"chargeback":   StatusChargeback,
+	"charged_back": StatusChargeback,
+	"failed":       StatusFailed,
+	"declined":     StatusFailed,
+}
+
+// Resultados da conciliação de cada transação
+const (
+	OutcomeMatched           = "matched"
+	OutcomeMissingPayment    = "missing_payment"
+	OutcomeMissingSettlement = "missing_settlement"
+	OutcomeAmountMismatch    = "amount_mismatch"
+	OutcomeStatusMismatch    = "status_mismatch"
+	OutcomeDuplicate         = "duplicate"
+)
+
+// Outcomes são os resultados possíveis, na ordem do relatório
+var Outcomes = []string{
+	OutcomeMatched,
+	OutcomeMissingPayment,
+	OutcomeMissingSettlement,
+	OutcomeAmountMismatch,
+	OutcomeStatusMismatch,
+	OutcomeDuplicate,
+}
+
+// Record é uma transação do arquivo de liquidação. Amount é sempre positivo;
+// TransactionDate é zero quando o arquivo não traz a coluna.
+type Record struct {
+	Line            int
+	GatewayRef      string
+	Amount          money.Money
+	Status          string
+	TransactionDate time.Time
+}
+
+// File é o conteúdo de um arquivo de liquidação
+type File struct {
+	Records []Record
+}
+
+// Period retorna o intervalo [from, to) dos dias das transações do arquivo; ok é false
+// quando o arquivo não traz as datas
+func (f File) Period() (from, to time.Time, ok bool) {
+	for _, record := range f.Records {
+		day := record.TransactionDate
+		if day.IsZero() {
+			continue
+		}
+		if from.IsZero() || day.Before(from) {
+			from = day
+		}
+		if day.After(to) {
+			to = day
+		}
+	}
+	if from.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
+
+// required são as colunas obrigatórias do arquivo
+var required = []string{"gateway_ref", "amount", "currency", "status"}
+
+// ReadFile lê um arquivo de liquidação em CSV
+func ReadFile(r io.Reader) (File, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return File{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	first, _, _ := bytes.Cut(content, []byte("\n"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+	if bytes.Count(first, []byte(";")) > bytes.Count(first, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return File{}, fmt.Errorf("%w: arquivo vazio", ErrInvalidFile)
+	}
+	if err != nil {
+		return File{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return File{}, fmt.Errorf("%w: coluna %s ausente no cabeçalho", ErrInvalidFile, name)
+		}
+	}
+
+	var file File
+	reader.FieldsPerRecord = -1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return File{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(name string) string {
+			index, ok := columns[name]
+			if !ok || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		record, err := parseRecord(line, value)
+		if err != nil {
+			return File{}, fmt.Errorf("%w: linha %d: %v", ErrInvalidFile, line, err)
+		}
+		file.Records = append(file.Records, record)
+	}
+	if len(file.Records) == 0 {
+		return File{}, fmt.Errorf("%w: nenhuma transação", ErrInvalidFile)
+	}
+	return file, nil
+}
+
+// parseRecord converte as colunas de uma linha em uma transação
+func parseRecord(line int, value func(string) string) (Record, error) {
+	record := Record{Line: line, GatewayRef: value("gateway_ref")}
+	if record.GatewayRef == "" || len(record.GatewayRef) > 64 {
+		return Record{}, fmt.Errorf("gateway_ref %q inválido", record.GatewayRef)
+	}
+
+	status, ok := statusAliases[strings.ToLower(value("status"))]
+	if !ok {
+		return Record{}, fmt.Errorf("status %q desconhecido", value("status"))
+	}
+	record.Status = status
+
+	raw := value("amount")
+	if !strings.Contains(raw, ".") {
+		raw = strings.Replace(raw, ",", ".", 1)
+	}
+	amount, err := money.Parse(raw, value("currency"))
+	if err != nil {
+		return Record{}, err
+	}
+	if amount.Amount < 0 {
+		amount.Amount = -amount.Amount
+	}
+	record.Amount = amount
+
+	if date := value("transaction_date"); date != "" {
+		day, err := time.ParseInLocation(time.DateOnly, date, time.UTC)
+		if err != nil {
+			return Record{}, fmt.Errorf("transaction_date %q inválida (esperado AAAA-MM-DD)", date)
+		}
+		record.TransactionDate = day
+	}
+	return record, nil
+}
+
+// Classify compara a transação liquidada com o pagamento da mesma referência.
+// Liquidações esperam o pagamento aprovado (ou estornado depois, em contestação),
+// estornos e chargebacks esperam o pagamento charged_back com o total estornado e
+// falhas, o pagamento recusado. A divergência de status prevalece sobre a de valor.
+func Classify(record Record, payment *domain.Payment) (outcome, detail string) {
+	if payment == nil {
+		return OutcomeMissingPayment, "nenhum pagamento com esta referência"
+	}
+
+	expected := map[string][]string{
+		StatusSettled:    {domain.StatusApproved, domain.StatusChargedBack},
+		StatusRefunded:   {domain.StatusChargedBack},
+		StatusChargeback: {domain.StatusChargedBack},
+		StatusFailed:     {domain.StatusFailed},
+	}[record.Status]
+	consistent := false
+	for _, status := range expected {
+		consistent = consistent || payment.Status == status
+	}
+	if !consistent {
+		return OutcomeStatusMismatch, fmt.Sprintf("liquidação %s, pagamento %s", record.Status, payment.Status)
+	}
+
+	want := payment.Money()
+	if record.Status == StatusRefunded || record.Status == StatusChargeback {
+		want = money.New(payment.ChargedBack, payment.Currency)
+	}
+	if record.Status != StatusFailed && (record.Amount.Currency != want.Currency || record.Amount.Amount != want.Amount) {
+		return OutcomeAmountMismatch, fmt.Sprintf("liquidado %s, registrado %s", record.Amount, want)
+	}
+	return OutcomeMatched, ""
+}
+
+// WriteReport grava o resultado de cada transação em CSV
+func WriteReport(w io.Writer, items []domain.SettlementItem) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"line", "gateway_ref", "order_id", "outcome", "settled_status", "payment_status",
+		"settled_amount", "settled_currency", "payment_amount", "payment_currency", "detail"})
+	for _, item := range items {
+		writer.Write([]string{
+			optional(item.Line != 0, fmt.Sprint(item.Line)),
+			item.GatewayRef,
+			optional(item.OrderID != 0, fmt.Sprint(item.OrderID)),
+			item.Outcome,
+			item.SettledStatus,
+			item.PaymentStatus,
+			optional(item.SettledCurrency != "", money.New(item.SettledAmount, item.SettledCurrency).Decimal()),
+			item.SettledCurrency,
+			optional(item.PaymentCurrency != "", money.New(item.PaymentAmount, item.PaymentCurrency).Decimal()),
+			item.PaymentCurrency,
+			item.Detail,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// optional devolve value quando present, senão vazio
+func optional(present bool, value string) string {
+	if !present {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+package settlement
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile(t *testing.T) {
+	content := "\xef\xbb\xbfGateway_Ref,amount,currency,status,transaction_date\n" +
+		"stub_1,199.90,BRL,captured,2025-03-10\n" +
+		"\n" +
+		"stub_2,-50.00,brl,CHARGEBACK,2025-03-11\n"
+
+	file, err := ReadFile(strings.NewReader(content))
+	require.NoError(t, err)
+	require.Len(t, file.Records, 2)
+	assert.Equal(t, Record{
+		Line:            2,
+		GatewayRef:      "stub_1",
+		Amount:          money.New(19990, "BRL"),
+		Status:          StatusSettled,
+		TransactionDate: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+	}, file.Records[0])
+	assert.Equal(t, 4, file.Records[1].Line)
+	assert.Equal(t, int64(5000), file.Records[1].Amount.Amount, "estornos vêm com valor positivo")
+	assert.Equal(t, StatusChargeback, file.Records[1].Status)
+
+	from, to, ok := file.Period()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC), to)
+}
+
+func TestReadFile_Semicolon(t *testing.T) {
+	file, err := ReadFile(strings.NewReader("status;gateway_ref;amount;currency\nsettled;stub_9;10,50;BRL\n"))
+	require.NoError(t, err)
+	require.Len(t, file.Records, 1)
+	assert.Equal(t, int64(1050), file.Records[0].Amount.Amount)
+
+	_, _, ok := file.Period()
+	assert.False(t, ok, "sem transaction_date não há período")
+}
+
+func TestReadFile_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"gateway_ref,amount,currency\nstub_1,1.00,BRL\n",
+		"gateway_ref,amount,currency,status\n",
+		"gateway_ref,amount,currency,status\nstub_1,1.00,BRL,pending\n",
+		"gateway_ref,amount,currency,status\nstub_1,abc,BRL,settled\n",
+		"gateway_ref,amount,currency,status\n,1.00,BRL,settled\n",
+		"gateway_ref,amount,currency,status,transaction_date\nstub_1,1.00,BRL,settled,10/03/2025\n",
+	}
+	for i, content := range invalid {
+		_, err := ReadFile(strings.NewReader(content))
+		assert.ErrorIs(t, err, ErrInvalidFile, "caso %d", i)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	approved := &domain.Payment{Amount: 19990, Currency: "BRL", Status: domain.StatusApproved}
+	chargedBack := &domain.Payment{Amount: 19990, Currency: "BRL", Status: domain.StatusChargedBack, ChargedBack: 19990}
+	failed := &domain.Payment{Amount: 19990, Currency: "BRL", Status: domain.StatusFailed}
+	settled := Record{Amount: money.New(19990, "BRL"), Status: StatusSettled}
+
+	tests := []struct {
+		name    string
+		record  Record
+		payment *domain.Payment
+		outcome string
+	}{
+		{"liquidado", settled, approved, OutcomeMatched},
+		{"liquidado e contestado depois", settled, chargedBack, OutcomeMatched},
+		{"sem pagamento", settled, nil, OutcomeMissingPayment},
+		{"valor divergente", Record{Amount: money.New(19900, "BRL"), Status: StatusSettled}, approved, OutcomeAmountMismatch},
+		{"moeda divergente", Record{Amount: money.New(19990, "USD"), Status: StatusSettled}, approved, OutcomeAmountMismatch},
+		{"liquidado mas recusado", settled, failed, OutcomeStatusMismatch},
+		{"chargeback registrado", Record{Amount: money.New(19990, "BRL"), Status: StatusChargeback}, chargedBack, OutcomeMatched},
+		{"chargeback não registrado", Record{Amount: money.New(19990, "BRL"), Status: StatusChargeback}, approved, OutcomeStatusMismatch},
+		{"falha", Record{Amount: money.New(1, "BRL"), Status: StatusFailed}, failed, OutcomeMatched},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, _ := Classify(tt.record, tt.payment)
+			assert.Equal(t, tt.outcome, outcome)
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	paymentID := uint(7)
+	items := []domain.SettlementItem{
+		{Line: 2, GatewayRef: "stub_1", PaymentID: &paymentID, OrderID: 42, Outcome: OutcomeAmountMismatch,
+			SettledStatus: StatusSettled, SettledAmount: 19900, SettledCurrency: "BRL",
+			PaymentStatus: domain.StatusApproved, PaymentAmount: 19990, PaymentCurrency: "BRL",
+			Detail: "liquidado R$ 199,00, registrado R$ 199,90"},
+		{Line: 3, GatewayRef: "stub_x", Outcome: OutcomeMissingPayment, SettledStatus: StatusSettled, SettledAmount: 100, SettledCurrency: "BRL"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, items))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "line,gateway_ref,order_id,outcome,settled_status,payment_status,settled_amount,settled_currency,payment_amount,payment_currency,detail", lines[0])
+	assert.Equal(t, `2,stub_1,42,amount_mismatch,settled,approved,199.00,BRL,199.90,BRL,"liquidado R$ 199,00, registrado R$ 199,90"`, lines[1])
+	assert.Equal(t, "3,stub_x,,missing_payment,settled,,1.00,BRL,,,", lines[2])
+}
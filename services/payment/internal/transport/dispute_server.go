@@ -0,0 +1,192 @@
+package transport
+
+import (
+	"context"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/service"
+	pb "payment-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OpenDispute registra a contestação (chargeback) recebida do adquirente
+func (s *PaymentGRPCServer) OpenDispute(ctx context.Context, req *pb.OpenDisputeRequest) (*pb.Dispute, error) {
+	input := service.OpenDisputeInput{
+		OrderID:   uint(req.GetOrderId()),
+		Reference: req.GetReference(),
+		Reason:    req.GetReason(),
+		OpenedBy:  reviewer(ctx),
+	}
+	if amount := req.GetAmount(); amount != nil {
+		input.Amount = money.New(amount.GetAmount(), amount.GetCurrency())
+	}
+	if due := req.GetEvidenceDueAt(); due != "" {
+		dueAt, err := time.Parse(time.RFC3339, due)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "evidence_due_at inválido: %q (esperado RFC 3339)", due)
+		}
+		input.EvidenceDueAt = dueAt
+	}
+
+	dispute, err := s.disputeService.OpenDispute(input)
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDispute(dispute), nil
+}
+
+// AddDisputeEvidence anexa um arquivo à defesa da contestação
+func (s *PaymentGRPCServer) AddDisputeEvidence(ctx context.Context, req *pb.AddDisputeEvidenceRequest) (*pb.DisputeEvidence, error) {
+	evidence, err := s.disputeService.AddEvidence(service.DisputeEvidenceInput{
+		DisputeID:   uint(req.GetDisputeId()),
+		Kind:        req.GetKind(),
+		FileName:    req.GetFileName(),
+		ContentType: req.GetContentType(),
+		Content:     req.GetContent(),
+		Note:        req.GetNote(),
+		UploadedBy:  reviewer(ctx),
+	})
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDisputeEvidence(evidence, false), nil
+}
+
+// SubmitDisputeEvidence envia a defesa da contestação ao adquirente
+func (s *PaymentGRPCServer) SubmitDisputeEvidence(ctx context.Context, req *pb.DisputeRequest) (*pb.Dispute, error) {
+	dispute, err := s.disputeService.SubmitEvidence(uint(req.GetDisputeId()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDispute(dispute), nil
+}
+
+// ResolveDispute registra a decisão do adquirente; a contestação perdida estorna o valor
+func (s *PaymentGRPCServer) ResolveDispute(ctx context.Context, req *pb.ResolveDisputeRequest) (*pb.Dispute, error) {
+	var won bool
+	switch req.GetOutcome() {
+	case domain.DisputeWon:
+		won = true
+	case domain.DisputeLost:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "outcome inválido: %q (esperado won ou lost)", req.GetOutcome())
+	}
+
+	dispute, err := s.disputeService.ResolveDispute(service.ResolveDisputeInput{
+		DisputeID:  uint(req.GetDisputeId()),
+		Won:        won,
+		ResolvedBy: reviewer(ctx),
+		Note:       req.GetNote(),
+	})
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDispute(dispute), nil
+}
+
+// GetDispute retorna uma contestação com os dados das evidências
+func (s *PaymentGRPCServer) GetDispute(ctx context.Context, req *pb.DisputeRequest) (*pb.Dispute, error) {
+	dispute, err := s.disputeService.GetDispute(uint(req.GetDisputeId()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDispute(dispute), nil
+}
+
+// GetDisputeEvidence retorna um anexo da defesa com o conteúdo
+func (s *PaymentGRPCServer) GetDisputeEvidence(ctx context.Context, req *pb.DisputeEvidenceRequest) (*pb.DisputeEvidence, error) {
+	evidence, err := s.disputeService.GetEvidence(uint(req.GetEvidenceId()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDisputeEvidence(evidence, true), nil
+}
+
+// ListDisputes lista as contestações, as mais recentes primeiro
+func (s *PaymentGRPCServer) ListDisputes(ctx context.Context, req *pb.ListDisputesRequest) (*pb.DisputesResponse, error) {
+	disputes, err := s.disputeService.ListDisputes(uint(req.GetOrderId()), req.GetStatus(), int(req.GetLimit()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDisputesResponse(disputes), nil
+}
+
+// ListDisputesNearDeadline lista as contestações sem defesa com prazo próximo ou vencido,
+// as mais urgentes primeiro
+func (s *PaymentGRPCServer) ListDisputesNearDeadline(ctx context.Context, req *pb.DisputesNearDeadlineRequest) (*pb.DisputesResponse, error) {
+	var within time.Duration
+	if value := req.GetWithin(); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "within inválido: %q (ex.: 72h)", value)
+		}
+		within = parsed
+	}
+
+	disputes, err := s.disputeService.ListNearDeadline(within, int(req.GetLimit()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toDisputesResponse(disputes), nil
+}
+
+// toDisputesResponse converte uma lista de contestações
+func toDisputesResponse(disputes []domain.Dispute) *pb.DisputesResponse {
+	response := &pb.DisputesResponse{}
+	for i := range disputes {
+		response.Disputes = append(response.Disputes, toDispute(&disputes[i]))
+	}
+	return response
+}
+
+// toDispute converte a contestação para a resposta gRPC
+func toDispute(dispute *domain.Dispute) *pb.Dispute {
+	response := &pb.Dispute{
+		Id:             uint32(dispute.ID),
+		OrderId:        uint32(dispute.OrderID),
+		PaymentId:      uint32(dispute.PaymentID),
+		Reference:      dispute.Reference,
+		Reason:         dispute.Reason,
+		Amount:         toProtoMoney(dispute.Money()),
+		Status:         dispute.Status,
+		EvidenceDueAt:  dispute.EvidenceDueAt.Format(time.RFC3339),
+		Overdue:        dispute.Status == domain.DisputeOpened && time.Now().After(dispute.EvidenceDueAt),
+		OpenedBy:       dispute.OpenedBy,
+		CreatedAt:      dispute.CreatedAt.Format(time.RFC3339),
+		ResolvedBy:     dispute.ResolvedBy,
+		ResolutionNote: dispute.ResolutionNote,
+	}
+	if dispute.EvidenceSubmittedAt != nil {
+		response.EvidenceSubmittedAt = dispute.EvidenceSubmittedAt.Format(time.RFC3339)
+	}
+	if dispute.ResolvedAt != nil {
+		response.ResolvedAt = dispute.ResolvedAt.Format(time.RFC3339)
+	}
+	for i := range dispute.Evidence {
+		response.Evidence = append(response.Evidence, toDisputeEvidence(&dispute.Evidence[i], false))
+	}
+	return response
+}
+
+// toDisputeEvidence converte um anexo da defesa; o conteúdo só vai com withContent
+func toDisputeEvidence(evidence *domain.DisputeEvidence, withContent bool) *pb.DisputeEvidence {
+	response := &pb.DisputeEvidence{
+		Id:          uint32(evidence.ID),
+		Kind:        evidence.Kind,
+		FileName:    evidence.FileName,
+		ContentType: evidence.ContentType,
+		Size:        evidence.Size,
+		Sha256:      evidence.SHA256,
+		Note:        evidence.Note,
+		UploadedBy:  evidence.UploadedBy,
+		CreatedAt:   evidence.CreatedAt.Format(time.RFC3339),
+	}
+	if withContent {
+		response.Content = evidence.Content
+	}
+	return response
+}
@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"payment-service/internal/gateway"
+	"payment-service/internal/service"
+)
+
+// GatewayWebhookPath é o caminho do webhook de eventos do provedor de pagamento
+const GatewayWebhookPath = "/webhooks/gateway"
+
+// gatewayWebhookHandler recebe os eventos do provedor de pagamento
+type gatewayWebhookHandler struct {
+	gatewayService service.GatewayService
+	provider       string
+	secret         []byte
+	tolerance      time.Duration
+}
+
+// NewGatewayWebhookHandler cria o handler do webhook do provedor. O corpo deve vir
+// assinado com HMAC-SHA256 no cabeçalho X-Gateway-Signature (t=<unix>,v1=<hex>) usando
+// o segredo compartilhado; assinaturas com carimbo de tempo a mais de tolerance do
+// relógio local são recusadas como reutilização.
+func NewGatewayWebhookHandler(gatewayService service.GatewayService, provider, secret string, tolerance time.Duration) http.Handler {
+	return &gatewayWebhookHandler{
+		gatewayService: gatewayService,
+		provider:       provider,
+		secret:         []byte(secret),
+		tolerance:      tolerance,
+	}
+}
+
+// ServeHTTP valida a assinatura, guarda o webhook e aplica o evento. Eventos
+// desconhecidos ou que não mudam o pagamento respondem 200 com o resultado, para que o
+// provedor não os reenvie; falhas internas respondem 500 para um novo envio.
+func (h *gatewayWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "método não permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil || len(body) > maxWebhookBody {
+		http.Error(w, "corpo inválido", http.StatusBadRequest)
+		return
+	}
+	header := r.Header.Get(gateway.SignatureHeader)
+	signedAt, err := gateway.Verify(h.secret, body, header, time.Now(), h.tolerance)
+	if errors.Is(err, gateway.ErrStaleTimestamp) {
+		log.Printf("🚫 Webhook do provedor recusado como reutilização (%s): %v", r.RemoteAddr, err)
+		http.Error(w, "assinatura expirada", http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		log.Printf("🚫 Webhook do provedor com assinatura inválida de %s", r.RemoteAddr)
+		http.Error(w, "assinatura inválida", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := gateway.ParseEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	record, err := h.gatewayService.ProcessWebhook(service.GatewayWebhook{
+		Provider:  h.provider,
+		Payload:   body,
+		Signature: header,
+		SignedAt:  signedAt,
+		Event:     event,
+	})
+	if err != nil {
+		log.Printf("❌ Erro ao processar webhook %s: %v", event.ID, err)
+		http.Error(w, "falha ao processar webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok", "outcome": record.Outcome})
+}
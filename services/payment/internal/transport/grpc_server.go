@@ -1,8 +1,20 @@
 package transport
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/boleto"
+	"payment-service/internal/domain"
+	"payment-service/internal/pix"
 	"payment-service/internal/service"
 	pb "payment-service/proto"
 )
@@ -10,13 +22,21 @@ import (
 // PaymentGRPCServer implementa o servidor gRPC do payment service
 type PaymentGRPCServer struct {
 	pb.UnimplementedPaymentServiceServer
-	paymentService service.PaymentService
+	paymentService    service.PaymentService
+	pixService        service.PixService
+	boletoService     service.BoletoService
+	disputeService    service.DisputeService
+	settlementService service.SettlementService
 }
 
 // NewPaymentGRPCServer cria uma nova instância do servidor gRPC
-func NewPaymentGRPCServer(paymentService service.PaymentService) *PaymentGRPCServer {
+func NewPaymentGRPCServer(paymentService service.PaymentService, pixService service.PixService, boletoService service.BoletoService, disputeService service.DisputeService, settlementService service.SettlementService) *PaymentGRPCServer {
 	return &PaymentGRPCServer{
-		paymentService: paymentService,
+		paymentService:    paymentService,
+		pixService:        pixService,
+		boletoService:     boletoService,
+		disputeService:    disputeService,
+		settlementService: settlementService,
 	}
 }
 
@@ -27,13 +47,388 @@ func (s *PaymentGRPCServer) GetPaymentStatus(ctx context.Context, req *pb.Paymen
 		return &pb.PaymentStatusResponse{
 			OrderId: req.GetOrderId(),
 			Status:  "NOT_FOUND",
-			Amount:  0,
+			Amount:  toProtoMoney(money.Zero(money.DefaultCurrency)),
 		}, nil
 	}
 
-	return &pb.PaymentStatusResponse{
-		OrderId: uint32(payment.OrderID),
-		Status:  payment.Status,
-		Amount:  payment.Amount,
+	return toStatusResponse(payment), nil
+}
+
+// ExportPaymentData retorna os pagamentos dos pedidos de um titular (LGPD), incluindo o
+// cliente e a impressão digital do cartão usados na análise antifraude
+func (s *PaymentGRPCServer) ExportPaymentData(ctx context.Context, req *pb.PaymentsByOrdersRequest) (*pb.PaymentsResponse, error) {
+	payments, err := s.paymentService.ListPaymentsByOrderIDs(toOrderIDs(req.GetOrderIds()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPaymentsResponse(payments)
+	for i, record := range response.Payments {
+		record.Customer = payments[i].Customer
+		record.CardFingerprint = payments[i].CardFingerprint
+	}
+	return response, nil
+}
+
+// AnonymizePayments pseudonimiza o cliente dos pagamentos dos pedidos de um titular
+// (LGPD); os pagamentos são mantidos por serem registros financeiros
+func (s *PaymentGRPCServer) AnonymizePayments(ctx context.Context, req *pb.AnonymizePaymentsRequest) (*pb.AnonymizePaymentsResponse, error) {
+	ids, err := s.paymentService.AnonymizePayments(toOrderIDs(req.GetOrderIds()), req.GetPseudonym())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.AnonymizePaymentsResponse{Affected: uint32(len(ids))}
+	for _, id := range ids {
+		response.PaymentIds = append(response.PaymentIds, uint32(id))
+	}
+	return response, nil
+}
+
+// GetPayment retorna um pagamento pelo ID
+func (s *PaymentGRPCServer) GetPayment(ctx context.Context, req *pb.PaymentRequest) (*pb.PaymentRecord, error) {
+	payment, err := s.paymentService.GetPayment(uint(req.GetPaymentId()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toPaymentRecord(payment), nil
+}
+
+// ListPayments retorna uma página dos pagamentos que atendem aos filtros
+func (s *PaymentGRPCServer) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
+	query := service.PaymentQuery{
+		Status:    req.GetStatus(),
+		OrderIDs:  toOrderIDs(req.GetOrderIds()),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	var err error
+	if query.CreatedFrom, err = parseTimestamp("created_from", req.GetCreatedFrom()); err != nil {
+		return nil, paymentError(err)
+	}
+	if query.CreatedTo, err = parseTimestamp("created_to", req.GetCreatedTo()); err != nil {
+		return nil, paymentError(err)
+	}
+	if amount := req.GetMinAmount(); amount != nil {
+		value := money.New(amount.GetAmount(), amount.GetCurrency())
+		query.MinAmount = &value
+	}
+	if amount := req.GetMaxAmount(); amount != nil {
+		value := money.New(amount.GetAmount(), amount.GetCurrency())
+		query.MaxAmount = &value
+	}
+
+	page, err := s.paymentService.SearchPayments(query)
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return &pb.ListPaymentsResponse{
+		Payments:      toPaymentsResponse(page.Payments).Payments,
+		NextPageToken: page.NextPageToken,
 	}, nil
 }
+
+// GetPaymentsByOrderIDs retorna em uma consulta os pagamentos de até 500 pedidos
+func (s *PaymentGRPCServer) GetPaymentsByOrderIDs(ctx context.Context, req *pb.PaymentsByOrdersRequest) (*pb.PaymentsResponse, error) {
+	if len(req.GetOrderIds()) > service.MaxPaymentsByOrders {
+		return nil, status.Errorf(codes.InvalidArgument, "no máximo %d pedidos por consulta", service.MaxPaymentsByOrders)
+	}
+
+	payments, err := s.paymentService.ListPaymentsByOrderIDs(toOrderIDs(req.GetOrderIds()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toPaymentsResponse(payments), nil
+}
+
+// toOrderIDs converte os IDs de pedidos da requisição
+func toOrderIDs(ids []uint32) []uint {
+	orderIDs := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		orderIDs = append(orderIDs, uint(id))
+	}
+	return orderIDs
+}
+
+// parseTimestamp lê um filtro de data em RFC 3339; vazio não filtra
+func parseTimestamp(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s %q (esperado RFC 3339)", service.ErrInvalidPaymentQuery, field, value)
+	}
+	return parsed, nil
+}
+
+// ListPaymentsInReview retorna a fila de pagamentos retidos pela análise antifraude
+func (s *PaymentGRPCServer) ListPaymentsInReview(ctx context.Context, req *pb.PaymentsInReviewRequest) (*pb.PaymentsResponse, error) {
+	payments, err := s.paymentService.ListPaymentsInReview(int(req.GetLimit()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toPaymentsResponse(payments), nil
+}
+
+// ApprovePayment libera a cobrança de um pagamento retido para análise
+func (s *PaymentGRPCServer) ApprovePayment(ctx context.Context, req *pb.ReviewPaymentRequest) (*pb.PaymentStatusResponse, error) {
+	return s.review(ctx, req, true)
+}
+
+// RejectPayment recusa um pagamento retido para análise
+func (s *PaymentGRPCServer) RejectPayment(ctx context.Context, req *pb.ReviewPaymentRequest) (*pb.PaymentStatusResponse, error) {
+	return s.review(ctx, req, false)
+}
+
+// review registra a decisão do analista autenticado sobre um pagamento retido
+func (s *PaymentGRPCServer) review(ctx context.Context, req *pb.ReviewPaymentRequest, approve bool) (*pb.PaymentStatusResponse, error) {
+	payment, err := s.paymentService.ReviewPayment(service.ReviewPaymentInput{
+		OrderID:  uint(req.GetOrderId()),
+		Approve:  approve,
+		Reviewer: reviewer(ctx),
+		Note:     req.GetNote(),
+	})
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toStatusResponse(payment), nil
+}
+
+// reviewer identifica o analista pelo token da chamada
+func reviewer(ctx context.Context) string {
+	caller := auth.CallerFromContext(ctx)
+	if caller == nil {
+		return "desconhecido"
+	}
+	if caller.Email != "" {
+		return caller.Email
+	}
+	return caller.Subject
+}
+
+// GetPixCharge retorna o BR Code e o QR Code da cobrança PIX de um pedido
+func (s *PaymentGRPCServer) GetPixCharge(ctx context.Context, req *pb.PixChargeRequest) (*pb.PixChargeResponse, error) {
+	payment, err := s.pixService.GetCharge(uint(req.GetOrderId()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+
+	charge := payment.PixCharge
+	png, err := pix.QRCodePNG(charge.Payload, int(req.GetQrCodeSize()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "falha ao gerar QR Code: %v", err)
+	}
+
+	response := &pb.PixChargeResponse{
+		OrderId:    uint32(payment.OrderID),
+		Txid:       charge.TxID,
+		Kind:       charge.Kind,
+		Payload:    charge.Payload,
+		QrCodePng:  png,
+		Amount:     toProtoMoney(payment.Money()),
+		Status:     charge.Status,
+		ExpiresAt:  charge.ExpiresAt.Format(time.RFC3339),
+		EndToEndId: charge.EndToEndID,
+	}
+	if charge.PaidAt != nil {
+		response.PaidAt = charge.PaidAt.Format(time.RFC3339)
+	}
+	return response, nil
+}
+
+// GetBoleto retorna o código de barras, a linha digitável e, se pedido, o PDF do boleto
+// de um pedido
+func (s *PaymentGRPCServer) GetBoleto(ctx context.Context, req *pb.BoletoRequest) (*pb.BoletoResponse, error) {
+	payment, err := s.boletoService.GetBoleto(uint(req.GetOrderId()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+
+	b := payment.Boleto
+	response := &pb.BoletoResponse{
+		OrderId:       uint32(payment.OrderID),
+		NossoNumero:   b.NossoNumero,
+		Barcode:       b.Barcode,
+		DigitableLine: b.DigitableLine,
+		Amount:        toProtoMoney(payment.Money()),
+		DueDate:       b.DueDate.Format(time.DateOnly),
+		Status:        b.Status,
+		ExpiresAt:     b.ExpiresAt.Format(time.RFC3339),
+		PaidAmount:    toProtoMoney(money.New(b.PaidAmount, payment.Currency)),
+	}
+	if b.PaidAt != nil {
+		response.PaidAt = b.PaidAt.Format(time.RFC3339)
+	}
+	if req.GetIncludePdf() {
+		pdf, err := s.boletoService.RenderPDF(payment)
+		if err != nil {
+			return nil, paymentError(err)
+		}
+		response.Pdf = pdf
+	}
+	return response, nil
+}
+
+// ReconcileBoletos concilia o arquivo de retorno CNAB enviado com os boletos emitidos
+func (s *PaymentGRPCServer) ReconcileBoletos(ctx context.Context, req *pb.ReconcileBoletosRequest) (*pb.ReconcileBoletosResponse, error) {
+	file, err := boleto.ReadReturn(bytes.NewReader(req.GetContent()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := s.boletoService.Reconcile(file, req.GetDryRun())
+	if err != nil {
+		return nil, paymentError(err)
+	}
+
+	response := &pb.ReconcileBoletosResponse{
+		Format:         result.Format,
+		DryRun:         result.DryRun,
+		Paid:           int32(result.Counts[service.ReconcilePaid]),
+		AlreadyPaid:    int32(result.Counts[service.ReconcileAlreadyPaid]),
+		NotFound:       int32(result.Counts[service.ReconcileNotFound]),
+		AmountMismatch: int32(result.Counts[service.ReconcileAmountMismatch]),
+		Expired:        int32(result.Counts[service.ReconcileExpired]),
+		Ignored:        int32(result.Counts[service.ReconcileIgnored]),
+	}
+	for _, item := range result.Items {
+		response.Items = append(response.Items, &pb.BoletoReconcileItem{
+			Line:        int32(item.Line),
+			NossoNumero: item.NossoNumero,
+			OrderId:     uint32(item.OrderID),
+			Occurrence:  item.Occurrence,
+			Outcome:     item.Outcome,
+			Detail:      item.Detail,
+		})
+	}
+	return response, nil
+}
+
+// paymentError converte os erros do serviço em status gRPC
+func paymentError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrPixChargeNotFound), errors.Is(err, service.ErrBoletoNotFound),
+		errors.Is(err, service.ErrPaymentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrDisputeNotFound), errors.Is(err, service.ErrEvidenceNotFound),
+		errors.Is(err, service.ErrSettlementRunNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidDispute), errors.Is(err, service.ErrInvalidSettlement),
+		errors.Is(err, service.ErrInvalidPaymentQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrNotInReview), errors.Is(err, service.ErrReviewExpired), errors.Is(err, service.ErrPaymentNotDisputable),
+		errors.Is(err, service.ErrDisputeClosed), errors.Is(err, service.ErrDisputeDeadlinePassed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// paymentMethod retorna a forma de pagamento; pagamentos anteriores ao PIX são com cartão
+func paymentMethod(payment *domain.Payment) string {
+	if payment.Method == "" {
+		return domain.MethodCard
+	}
+	return payment.Method
+}
+
+// toStatusResponse converte o pagamento para a resposta de status
+func toStatusResponse(payment *domain.Payment) *pb.PaymentStatusResponse {
+	return &pb.PaymentStatusResponse{
+		OrderId:         uint32(payment.OrderID),
+		Status:          payment.Status,
+		Amount:          toProtoMoney(payment.Money()),
+		OrderAmount:     toProtoMoney(payment.OrderMoney()),
+		FxRate:          payment.FXRate,
+		InstallmentPlan: toInstallmentPlan(payment),
+		Method:          paymentMethod(payment),
+		GatewayRef:      payment.GatewayRef,
+		Fraud:           toFraudAssessment(payment),
+		ChargedBack:     toProtoMoney(money.New(payment.ChargedBack, payment.Currency)),
+	}
+}
+
+// toPaymentsResponse converte uma lista de pagamentos
+func toPaymentsResponse(payments []domain.Payment) *pb.PaymentsResponse {
+	response := &pb.PaymentsResponse{}
+	for i := range payments {
+		response.Payments = append(response.Payments, toPaymentRecord(&payments[i]))
+	}
+	return response
+}
+
+// toPaymentRecord converte o pagamento para o registro completo
+func toPaymentRecord(payment *domain.Payment) *pb.PaymentRecord {
+	return &pb.PaymentRecord{
+		Id:              uint32(payment.ID),
+		OrderId:         uint32(payment.OrderID),
+		Status:          payment.Status,
+		Amount:          toProtoMoney(payment.Money()),
+		OrderAmount:     toProtoMoney(payment.OrderMoney()),
+		FxRate:          payment.FXRate,
+		InstallmentPlan: toInstallmentPlan(payment),
+		Method:          paymentMethod(payment),
+		GatewayRef:      payment.GatewayRef,
+		CreatedAt:       payment.CreatedAt.Format(time.RFC3339),
+		Fraud:           toFraudAssessment(payment),
+		ChargedBack:     toProtoMoney(money.New(payment.ChargedBack, payment.Currency)),
+	}
+}
+
+// toFraudAssessment converte a análise antifraude do pagamento; pagamentos não
+// analisados não têm análise
+func toFraudAssessment(payment *domain.Payment) *pb.FraudAssessment {
+	if payment.FraudDecision == "" {
+		return nil
+	}
+	assessment := &pb.FraudAssessment{
+		Score:      int32(payment.FraudScore),
+		Decision:   payment.FraudDecision,
+		ReviewedBy: payment.ReviewedBy,
+		ReviewNote: payment.ReviewNote,
+	}
+	for _, rule := range payment.FraudRules {
+		assessment.Rules = append(assessment.Rules, &pb.FraudRule{
+			Rule:   rule.Rule,
+			Score:  int32(rule.Score),
+			Detail: rule.Detail,
+		})
+	}
+	if payment.ReviewedAt != nil {
+		assessment.ReviewedAt = payment.ReviewedAt.Format(time.RFC3339)
+	}
+	if payment.ReservationExpiresAt != nil {
+		assessment.ReviewDeadline = payment.ReservationExpiresAt.Format(time.RFC3339)
+	}
+	return assessment
+}
+
+// toProtoMoney converte um valor para a resposta gRPC
+func toProtoMoney(value money.Money) *pb.Money {
+	return &pb.Money{Amount: value.Amount, Currency: value.Currency}
+}
+
+// toInstallmentPlan converte o parcelamento e o cronograma do pagamento
+func toInstallmentPlan(payment *domain.Payment) *pb.InstallmentPlan {
+	plan := &pb.InstallmentPlan{
+		Installments: int32(payment.Installments),
+		InterestFree: payment.InterestFree,
+		MonthlyRate:  payment.MonthlyRate,
+		Interest:     toProtoMoney(money.New(payment.InterestAmount, payment.Currency)),
+		Total:        toProtoMoney(payment.Money()),
+	}
+	if plan.Installments == 0 {
+		// Pagamento anterior ao parcelamento
+		plan.Installments = 1
+		plan.InterestFree = true
+	}
+	for _, item := range payment.Schedule {
+		plan.Schedule = append(plan.Schedule, &pb.Installment{
+			Number:  int32(item.Number),
+			Amount:  toProtoMoney(money.New(item.Amount, item.Currency)),
+			DueDate: item.DueDate.Format(time.DateOnly),
+		})
+	}
+	return plan
+}
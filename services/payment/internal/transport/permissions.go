@@ -0,0 +1,36 @@
+package transport
+
+import "github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+
+// MethodPermissions declara a permissão exigida por cada RPC do PaymentService. A
+// anonimização e as decisões da análise antifraude e das contestações exigem login com
+// segundo fator.
+var MethodPermissions = auth.Policy{
+	"/payment.PaymentService/GetPaymentStatus":      {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ExportPaymentData":     {Permission: auth.PermPrivacy},
+	"/payment.PaymentService/AnonymizePayments":     {Permission: auth.PermPrivacy, RequireMFA: true},
+	"/payment.PaymentService/GetPayment":            {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ListPayments":          {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/GetPaymentsByOrderIDs": {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/GetPixCharge":          {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/GetBoleto":             {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ReconcileBoletos":      {Permission: auth.PermPaymentsWrite},
+
+	"/payment.PaymentService/ListPaymentsInReview": {Permission: auth.PermFraudReview},
+	"/payment.PaymentService/ApprovePayment":       {Permission: auth.PermFraudReview, RequireMFA: true},
+	"/payment.PaymentService/RejectPayment":        {Permission: auth.PermFraudReview, RequireMFA: true},
+
+	"/payment.PaymentService/OpenDispute":              {Permission: auth.PermPaymentsWrite},
+	"/payment.PaymentService/AddDisputeEvidence":       {Permission: auth.PermPaymentsWrite},
+	"/payment.PaymentService/SubmitDisputeEvidence":    {Permission: auth.PermPaymentsWrite},
+	"/payment.PaymentService/ResolveDispute":           {Permission: auth.PermPaymentsWrite, RequireMFA: true},
+	"/payment.PaymentService/GetDispute":               {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/GetDisputeEvidence":       {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ListDisputes":             {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ListDisputesNearDeadline": {Permission: auth.PermPaymentsRead},
+
+	"/payment.PaymentService/ImportSettlement":    {Permission: auth.PermPaymentsWrite},
+	"/payment.PaymentService/GetSettlementRun":    {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ListSettlementRuns":  {Permission: auth.PermPaymentsRead},
+	"/payment.PaymentService/ExportSettlementRun": {Permission: auth.PermPaymentsRead},
+}
@@ -0,0 +1,116 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/pix"
+	"payment-service/internal/service"
+)
+
+// maxWebhookBody limita o tamanho do corpo aceito no webhook
+const maxWebhookBody = 1 << 20
+
+// PixWebhookPath é o caminho do webhook de confirmação de PIX
+const PixWebhookPath = "/webhooks/pix"
+
+// pixWebhookRequest é o corpo enviado pelo PSP, no formato da API PIX do BACEN
+type pixWebhookRequest struct {
+	Pix []pixReceived `json:"pix"`
+}
+
+// pixReceived é um PIX recebido: valor em reais com ponto decimal e horário em RFC 3339
+type pixReceived struct {
+	EndToEndID string `json:"endToEndId"`
+	TxID       string `json:"txid"`
+	Valor      string `json:"valor"`
+	Horario    string `json:"horario"`
+}
+
+// pixWebhookHandler recebe as confirmações de PIX do PSP
+type pixWebhookHandler struct {
+	pixService service.PixService
+	secret     []byte
+}
+
+// NewPixWebhookHandler cria o handler do webhook de PIX. O corpo deve vir assinado com
+// HMAC-SHA256 no cabeçalho X-Pix-Signature usando o segredo compartilhado com o PSP.
+func NewPixWebhookHandler(pixService service.PixService, secret string) http.Handler {
+	return &pixWebhookHandler{
+		pixService: pixService,
+		secret:     []byte(secret),
+	}
+}
+
+// ServeHTTP valida a assinatura e confirma cada PIX recebido. Cobranças desconhecidas,
+// expiradas ou com valor divergente são registradas e não provocam novas tentativas do
+// PSP; falhas internas respondem 500 para que o PSP reenvie a notificação.
+func (h *pixWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "método não permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil || len(body) > maxWebhookBody {
+		http.Error(w, "corpo inválido", http.StatusBadRequest)
+		return
+	}
+	if !pix.VerifySignature(h.secret, body, r.Header.Get(pix.SignatureHeader)) {
+		log.Printf("🚫 Webhook PIX com assinatura inválida de %s", r.RemoteAddr)
+		http.Error(w, "assinatura inválida", http.StatusUnauthorized)
+		return
+	}
+
+	var request pixWebhookRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	for _, received := range request.Pix {
+		if err := h.confirm(received); err != nil {
+			log.Printf("❌ Erro ao confirmar PIX %s: %v", received.TxID, err)
+			http.Error(w, "falha ao confirmar PIX", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+// confirm confirma um PIX recebido; só devolve os erros que justificam novo envio
+func (h *pixWebhookHandler) confirm(received pixReceived) error {
+	amount, err := money.Parse(received.Valor, money.DefaultCurrency)
+	if err != nil {
+		log.Printf("⚠️ PIX %s ignorado: valor inválido %q", received.TxID, received.Valor)
+		return nil
+	}
+	paidAt, err := time.Parse(time.RFC3339, received.Horario)
+	if err != nil {
+		paidAt = time.Now()
+	}
+
+	_, err = h.pixService.Confirm(service.PixConfirmation{
+		TxID:       received.TxID,
+		EndToEndID: received.EndToEndID,
+		Amount:     amount,
+		PaidAt:     paidAt,
+	})
+	switch {
+	case errors.Is(err, service.ErrPixChargeNotFound), errors.Is(err, service.ErrPixAmountMismatch),
+		errors.Is(err, service.ErrPixChargeClosed):
+		log.Printf("⚠️ PIX %s não confirmado: %v", received.TxID, err)
+		return nil
+	default:
+		return err
+	}
+}
@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/money"
+	"payment-service/internal/domain"
+	"payment-service/internal/service"
+	"payment-service/internal/settlement"
+	pb "payment-service/proto"
+)
+
+// ImportSettlement concilia o arquivo de liquidação enviado com os pagamentos
+func (s *PaymentGRPCServer) ImportSettlement(ctx context.Context, req *pb.ImportSettlementRequest) (*pb.SettlementRun, error) {
+	run, created, err := s.settlementService.Import(service.SettlementImport{
+		FileName:   req.GetFileName(),
+		Content:    req.GetContent(),
+		Source:     domain.SettlementSourceRPC,
+		ImportedBy: reviewer(ctx),
+	})
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	response := toSettlementRun(run)
+	response.AlreadyImported = !created
+	return response, nil
+}
+
+// GetSettlementRun retorna uma conciliação com os itens
+func (s *PaymentGRPCServer) GetSettlementRun(ctx context.Context, req *pb.SettlementRunRequest) (*pb.SettlementRun, error) {
+	run, err := s.settlementService.GetRun(uint(req.GetRunId()), req.GetOutcome())
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	return toSettlementRun(run), nil
+}
+
+// ListSettlementRuns lista as conciliações mais recentes, sem os itens
+func (s *PaymentGRPCServer) ListSettlementRuns(ctx context.Context, req *pb.ListSettlementRunsRequest) (*pb.SettlementRunsResponse, error) {
+	runs, err := s.settlementService.ListRuns(int(req.GetLimit()))
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	response := &pb.SettlementRunsResponse{}
+	for i := range runs {
+		response.Runs = append(response.Runs, toSettlementRun(&runs[i]))
+	}
+	return response, nil
+}
+
+// ExportSettlementRun retorna o resultado de uma conciliação em CSV
+func (s *PaymentGRPCServer) ExportSettlementRun(ctx context.Context, req *pb.SettlementRunRequest) (*pb.SettlementReport, error) {
+	run, err := s.settlementService.GetRun(uint(req.GetRunId()), req.GetOutcome())
+	if err != nil {
+		return nil, paymentError(err)
+	}
+
+	var report bytes.Buffer
+	if err := settlement.WriteReport(&report, run.Items); err != nil {
+		return nil, paymentError(err)
+	}
+	name := strings.TrimSuffix(run.FileName, ".csv")
+	if outcome := req.GetOutcome(); outcome != "" {
+		name += "-" + outcome
+	}
+	return &pb.SettlementReport{
+		FileName: fmt.Sprintf("conciliacao-%d-%s.csv", run.ID, name),
+		Content:  report.Bytes(),
+	}, nil
+}
+
+// toSettlementRun converte a conciliação para a resposta gRPC
+func toSettlementRun(run *domain.SettlementRun) *pb.SettlementRun {
+	response := &pb.SettlementRun{
+		Id:                uint32(run.ID),
+		FileName:          run.FileName,
+		FileSha256:        run.FileSHA256,
+		Source:            run.Source,
+		ImportedBy:        run.ImportedBy,
+		Records:           int32(run.Records),
+		Matched:           int32(run.Matched),
+		MissingPayment:    int32(run.MissingPayment),
+		MissingSettlement: int32(run.MissingSettlement),
+		AmountMismatch:    int32(run.AmountMismatch),
+		StatusMismatch:    int32(run.StatusMismatch),
+		Duplicate:         int32(run.Duplicate),
+		CreatedAt:         run.CreatedAt.Format(time.RFC3339),
+	}
+	if run.PeriodStart != nil && run.PeriodEnd != nil {
+		response.PeriodStart = run.PeriodStart.Format(time.DateOnly)
+		response.PeriodEnd = run.PeriodEnd.Format(time.DateOnly)
+	}
+	for _, item := range run.Items {
+		converted := &pb.SettlementItem{
+			Line:          int32(item.Line),
+			GatewayRef:    item.GatewayRef,
+			OrderId:       uint32(item.OrderID),
+			Outcome:       item.Outcome,
+			SettledStatus: item.SettledStatus,
+			PaymentStatus: item.PaymentStatus,
+			Detail:        item.Detail,
+		}
+		if item.PaymentID != nil {
+			converted.PaymentId = uint32(*item.PaymentID)
+		}
+		if item.SettledCurrency != "" {
+			converted.SettledAmount = toProtoMoney(money.New(item.SettledAmount, item.SettledCurrency))
+		}
+		if item.PaymentCurrency != "" {
+			converted.PaymentAmount = toProtoMoney(money.New(item.PaymentAmount, item.PaymentCurrency))
+		}
+		response.Items = append(response.Items, converted)
+	}
+	return response
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/runtime/protoimpl"
 )
@@ -18,6 +20,150 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Money é um valor monetário exato: amount em unidades menores da moeda (centavos,
+// para BRL) e currency no padrão ISO 4217
+type Money struct {
+	Amount   int64  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Installment é uma parcela do cronograma; due_date no formato AAAA-MM-DD
+type Installment struct {
+	Number  int32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Amount  *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	DueDate string `protobuf:"bytes,3,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+}
+
+func (x *Installment) Reset() {
+	*x = Installment{}
+}
+
+func (x *Installment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Installment) ProtoMessage() {}
+
+func (x *Installment) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *Installment) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Installment) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Installment) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+// InstallmentPlan é o parcelamento do pagamento: installments = 1 é à vista;
+// monthly_rate é a taxa de juros ao mês em percentual, zero nos planos sem juros
+type InstallmentPlan struct {
+	Installments int32          `protobuf:"varint,1,opt,name=installments,proto3" json:"installments,omitempty"`
+	InterestFree bool           `protobuf:"varint,2,opt,name=interest_free,json=interestFree,proto3" json:"interest_free,omitempty"`
+	MonthlyRate  float64        `protobuf:"fixed64,3,opt,name=monthly_rate,json=monthlyRate,proto3" json:"monthly_rate,omitempty"`
+	Interest     *Money         `protobuf:"bytes,4,opt,name=interest,proto3" json:"interest,omitempty"`
+	Total        *Money         `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	Schedule     []*Installment `protobuf:"bytes,6,rep,name=schedule,proto3" json:"schedule,omitempty"`
+}
+
+func (x *InstallmentPlan) Reset() {
+	*x = InstallmentPlan{}
+}
+
+func (x *InstallmentPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallmentPlan) ProtoMessage() {}
+
+func (x *InstallmentPlan) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *InstallmentPlan) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetInterestFree() bool {
+	if x != nil {
+		return x.InterestFree
+	}
+	return false
+}
+
+func (x *InstallmentPlan) GetMonthlyRate() float64 {
+	if x != nil {
+		return x.MonthlyRate
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetInterest() *Money {
+	if x != nil {
+		return x.Interest
+	}
+	return nil
+}
+
+func (x *InstallmentPlan) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *InstallmentPlan) GetSchedule() []*Installment {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
 // PaymentStatusRequest representa uma solicitação para obter status de pagamento
 type PaymentStatusRequest struct {
 	OrderId uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -46,9 +192,19 @@ func (x *PaymentStatusRequest) GetOrderId() uint32 {
 
 // PaymentStatusResponse representa uma resposta de status de pagamento
 type PaymentStatusResponse struct {
-	OrderId uint32  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status  string  `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Amount  float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	OrderId         uint32           `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status          string           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Amount          *Money           `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	OrderAmount     *Money           `protobuf:"bytes,5,opt,name=order_amount,json=orderAmount,proto3" json:"order_amount,omitempty"`
+	FxRate          string           `protobuf:"bytes,6,opt,name=fx_rate,json=fxRate,proto3" json:"fx_rate,omitempty"`
+	InstallmentPlan *InstallmentPlan `protobuf:"bytes,7,opt,name=installment_plan,json=installmentPlan,proto3" json:"installment_plan,omitempty"`
+	// method é a forma de pagamento: card, pix ou boleto
+	Method string `protobuf:"bytes,8,opt,name=method,proto3" json:"method,omitempty"`
+	// gateway_ref é a referência do pagamento com cartão no provedor
+	GatewayRef string           `protobuf:"bytes,9,opt,name=gateway_ref,json=gatewayRef,proto3" json:"gateway_ref,omitempty"`
+	Fraud      *FraudAssessment `protobuf:"bytes,10,opt,name=fraud,proto3" json:"fraud,omitempty"`
+	// charged_back é o total estornado por contestações perdidas
+	ChargedBack *Money `protobuf:"bytes,11,opt,name=charged_back,json=chargedBack,proto3" json:"charged_back,omitempty"`
 }
 
 func (x *PaymentStatusResponse) Reset() {
@@ -79,79 +235,3010 @@ func (x *PaymentStatusResponse) GetStatus() string {
 	return ""
 }
 
-func (x *PaymentStatusResponse) GetAmount() float64 {
+func (x *PaymentStatusResponse) GetAmount() *Money {
 	if x != nil {
 		return x.Amount
 	}
+	return nil
+}
+
+func (x *PaymentStatusResponse) GetOrderAmount() *Money {
+	if x != nil {
+		return x.OrderAmount
+	}
+	return nil
+}
+
+func (x *PaymentStatusResponse) GetFxRate() string {
+	if x != nil {
+		return x.FxRate
+	}
+	return ""
+}
+
+func (x *PaymentStatusResponse) GetInstallmentPlan() *InstallmentPlan {
+	if x != nil {
+		return x.InstallmentPlan
+	}
+	return nil
+}
+
+func (x *PaymentStatusResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PaymentStatusResponse) GetGatewayRef() string {
+	if x != nil {
+		return x.GatewayRef
+	}
+	return ""
+}
+
+func (x *PaymentStatusResponse) GetFraud() *FraudAssessment {
+	if x != nil {
+		return x.Fraud
+	}
+	return nil
+}
+
+func (x *PaymentStatusResponse) GetChargedBack() *Money {
+	if x != nil {
+		return x.ChargedBack
+	}
+	return nil
+}
+
+// PaymentsByOrdersRequest seleciona pagamentos pelos IDs dos pedidos
+type PaymentsByOrdersRequest struct {
+	OrderIds []uint32 `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+}
+
+func (x *PaymentsByOrdersRequest) Reset() {
+	*x = PaymentsByOrdersRequest{}
+}
+
+func (x *PaymentsByOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsByOrdersRequest) ProtoMessage() {}
+
+func (x *PaymentsByOrdersRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PaymentsByOrdersRequest) GetOrderIds() []uint32 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+// PaymentRecord representa um pagamento completo
+type PaymentRecord struct {
+	Id              uint32           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId         uint32           `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status          string           `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt       string           `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Amount          *Money           `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	OrderAmount     *Money           `protobuf:"bytes,7,opt,name=order_amount,json=orderAmount,proto3" json:"order_amount,omitempty"`
+	FxRate          string           `protobuf:"bytes,8,opt,name=fx_rate,json=fxRate,proto3" json:"fx_rate,omitempty"`
+	InstallmentPlan *InstallmentPlan `protobuf:"bytes,9,opt,name=installment_plan,json=installmentPlan,proto3" json:"installment_plan,omitempty"`
+	Method          string           `protobuf:"bytes,10,opt,name=method,proto3" json:"method,omitempty"`
+	GatewayRef      string           `protobuf:"bytes,11,opt,name=gateway_ref,json=gatewayRef,proto3" json:"gateway_ref,omitempty"`
+	Fraud           *FraudAssessment `protobuf:"bytes,12,opt,name=fraud,proto3" json:"fraud,omitempty"`
+	ChargedBack     *Money           `protobuf:"bytes,13,opt,name=charged_back,json=chargedBack,proto3" json:"charged_back,omitempty"`
+	// Preenchidos apenas na exportação LGPD (ExportPaymentData)
+	Customer        string `protobuf:"bytes,14,opt,name=customer,proto3" json:"customer,omitempty"`
+	CardFingerprint string `protobuf:"bytes,15,opt,name=card_fingerprint,json=cardFingerprint,proto3" json:"card_fingerprint,omitempty"`
+}
+
+func (x *PaymentRecord) Reset() {
+	*x = PaymentRecord{}
+}
+
+func (x *PaymentRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRecord) ProtoMessage() {}
+
+func (x *PaymentRecord) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PaymentRecord) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
 	return 0
 }
 
-// PaymentServiceClient é o cliente do serviço de pagamentos
-type PaymentServiceClient interface {
-	GetPaymentStatus(ctx context.Context, in *PaymentStatusRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error)
+func (x *PaymentRecord) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
 }
 
-type paymentServiceClient struct {
-	cc grpc.ClientConnInterface
+func (x *PaymentRecord) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func NewPaymentServiceClient(cc grpc.ClientConnInterface) PaymentServiceClient {
-	return &paymentServiceClient{cc}
+func (x *PaymentRecord) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
 }
 
-func (c *paymentServiceClient) GetPaymentStatus(ctx context.Context, in *PaymentStatusRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error) {
-	out := new(PaymentStatusResponse)
-	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPaymentStatus", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *PaymentRecord) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
 	}
-	return out, nil
+	return nil
 }
 
-// PaymentServiceServer é o servidor do serviço de pagamentos
-type PaymentServiceServer interface {
-	GetPaymentStatus(context.Context, *PaymentStatusRequest) (*PaymentStatusResponse, error)
+func (x *PaymentRecord) GetOrderAmount() *Money {
+	if x != nil {
+		return x.OrderAmount
+	}
+	return nil
 }
 
-// UnimplementedPaymentServiceServer deve ser embedded para ter implementações forward compatible
-type UnimplementedPaymentServiceServer struct {
+func (x *PaymentRecord) GetFxRate() string {
+	if x != nil {
+		return x.FxRate
+	}
+	return ""
 }
 
-func (UnimplementedPaymentServiceServer) GetPaymentStatus(context.Context, *PaymentStatusRequest) (*PaymentStatusResponse, error) {
-	return nil, nil
+func (x *PaymentRecord) GetInstallmentPlan() *InstallmentPlan {
+	if x != nil {
+		return x.InstallmentPlan
+	}
+	return nil
 }
 
-// RegisterPaymentServiceServer registra o serviço no servidor gRPC
-func RegisterPaymentServiceServer(s grpc.ServiceRegistrar, srv PaymentServiceServer) {
-	s.RegisterService(&PaymentService_ServiceDesc, srv)
+func (x *PaymentRecord) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
 }
 
-func _PaymentService_GetPaymentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PaymentStatusRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *PaymentRecord) GetGatewayRef() string {
+	if x != nil {
+		return x.GatewayRef
 	}
-	if interceptor == nil {
-		return srv.(PaymentServiceServer).GetPaymentStatus(ctx, in)
+	return ""
+}
+
+func (x *PaymentRecord) GetFraud() *FraudAssessment {
+	if x != nil {
+		return x.Fraud
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/payment.PaymentService/GetPaymentStatus",
+	return nil
+}
+
+func (x *PaymentRecord) GetChargedBack() *Money {
+	if x != nil {
+		return x.ChargedBack
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaymentServiceServer).GetPaymentStatus(ctx, req.(*PaymentStatusRequest))
+	return nil
+}
+
+func (x *PaymentRecord) GetCustomer() string {
+	if x != nil {
+		return x.Customer
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-// PaymentService_ServiceDesc é o descritor do serviço gRPC
-var PaymentService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "payment.PaymentService",
-	HandlerType: (*PaymentServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetPaymentStatus",
-			Handler:    _PaymentService_GetPaymentStatus_Handler,
+func (x *PaymentRecord) GetCardFingerprint() string {
+	if x != nil {
+		return x.CardFingerprint
+	}
+	return ""
+}
+
+// AnonymizePaymentsRequest pseudonimiza os pagamentos dos pedidos de um titular (LGPD)
+type AnonymizePaymentsRequest struct {
+	OrderIds  []uint32 `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	Pseudonym string   `protobuf:"bytes,2,opt,name=pseudonym,proto3" json:"pseudonym,omitempty"`
+}
+
+func (x *AnonymizePaymentsRequest) Reset() {
+	*x = AnonymizePaymentsRequest{}
+}
+
+func (x *AnonymizePaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizePaymentsRequest) ProtoMessage() {}
+
+func (x *AnonymizePaymentsRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AnonymizePaymentsRequest) GetOrderIds() []uint32 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *AnonymizePaymentsRequest) GetPseudonym() string {
+	if x != nil {
+		return x.Pseudonym
+	}
+	return ""
+}
+
+// AnonymizePaymentsResponse informa os pagamentos anonimizados
+type AnonymizePaymentsResponse struct {
+	Affected   uint32   `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+	PaymentIds []uint32 `protobuf:"varint,2,rep,packed,name=payment_ids,json=paymentIds,proto3" json:"payment_ids,omitempty"`
+}
+
+func (x *AnonymizePaymentsResponse) Reset() {
+	*x = AnonymizePaymentsResponse{}
+}
+
+func (x *AnonymizePaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizePaymentsResponse) ProtoMessage() {}
+
+func (x *AnonymizePaymentsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AnonymizePaymentsResponse) GetAffected() uint32 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+func (x *AnonymizePaymentsResponse) GetPaymentIds() []uint32 {
+	if x != nil {
+		return x.PaymentIds
+	}
+	return nil
+}
+
+// PaymentRequest seleciona um pagamento pelo ID
+type PaymentRequest struct {
+	PaymentId uint32 `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+}
+
+func (x *PaymentRequest) Reset() {
+	*x = PaymentRequest{}
+}
+
+func (x *PaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRequest) ProtoMessage() {}
+
+func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PaymentRequest) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+// ListPaymentsRequest lista pagamentos, os mais novos primeiro; campos vazios não
+// filtram. created_from e created_to (exclusivo) em RFC 3339; min_amount e max_amount
+// filtram também pela moeda. page_size zero devolve até 50 (máximo 200); page_token é o
+// next_page_token da página anterior.
+type ListPaymentsRequest struct {
+	Status      string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedFrom string   `protobuf:"bytes,2,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   string   `protobuf:"bytes,3,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	MinAmount   *Money   `protobuf:"bytes,4,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount   *Money   `protobuf:"bytes,5,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	OrderIds    []uint32 `protobuf:"varint,6,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	PageSize    int32    `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken   string   `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetMinAmount() *Money {
+	if x != nil {
+		return x.MinAmount
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetMaxAmount() *Money {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetOrderIds() []uint32 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListPaymentsResponse é uma página de pagamentos; next_page_token vazio indica a
+// última página
+type ListPaymentsResponse struct {
+	Payments      []*PaymentRecord `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	NextPageToken string           `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*PaymentRecord {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// FraudRule é uma regra antifraude disparada, com os pontos somados ao score
+type FraudRule struct {
+	Rule   string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Score  int32  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *FraudRule) Reset() {
+	*x = FraudRule{}
+}
+
+func (x *FraudRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudRule) ProtoMessage() {}
+
+func (x *FraudRule) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *FraudRule) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *FraudRule) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *FraudRule) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// FraudAssessment é a análise antifraude do pagamento: score de 0 a 100, decisão
+// (allow, review ou deny), regras disparadas e, nos pagamentos retidos, a análise
+// manual (reviewed_at em RFC 3339). review_deadline é o vencimento da reserva de
+// estoque do pedido, depois do qual o pagamento retido só pode ser rejeitado
+type FraudAssessment struct {
+	Score          int32        `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	Decision       string       `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Rules          []*FraudRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	ReviewedBy     string       `protobuf:"bytes,4,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	ReviewedAt     string       `protobuf:"bytes,5,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	ReviewNote     string       `protobuf:"bytes,6,opt,name=review_note,json=reviewNote,proto3" json:"review_note,omitempty"`
+	ReviewDeadline string       `protobuf:"bytes,7,opt,name=review_deadline,json=reviewDeadline,proto3" json:"review_deadline,omitempty"`
+}
+
+func (x *FraudAssessment) Reset() {
+	*x = FraudAssessment{}
+}
+
+func (x *FraudAssessment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudAssessment) ProtoMessage() {}
+
+func (x *FraudAssessment) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *FraudAssessment) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *FraudAssessment) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *FraudAssessment) GetRules() []*FraudRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *FraudAssessment) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *FraudAssessment) GetReviewedAt() string {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return ""
+}
+
+func (x *FraudAssessment) GetReviewNote() string {
+	if x != nil {
+		return x.ReviewNote
+	}
+	return ""
+}
+
+func (x *FraudAssessment) GetReviewDeadline() string {
+	if x != nil {
+		return x.ReviewDeadline
+	}
+	return ""
+}
+
+// PaymentsInReviewRequest lista os pagamentos retidos pela análise antifraude;
+// limit zero devolve até 200
+type PaymentsInReviewRequest struct {
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *PaymentsInReviewRequest) Reset() {
+	*x = PaymentsInReviewRequest{}
+}
+
+func (x *PaymentsInReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsInReviewRequest) ProtoMessage() {}
+
+func (x *PaymentsInReviewRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PaymentsInReviewRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ReviewPaymentRequest é a decisão sobre o pagamento retido de um pedido, com a
+// observação do analista
+type ReviewPaymentRequest struct {
+	OrderId uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Note    string `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *ReviewPaymentRequest) Reset() {
+	*x = ReviewPaymentRequest{}
+}
+
+func (x *ReviewPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentRequest) ProtoMessage() {}
+
+func (x *ReviewPaymentRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ReviewPaymentRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReviewPaymentRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// PaymentsResponse representa uma lista de pagamentos
+type PaymentsResponse struct {
+	Payments []*PaymentRecord `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+}
+
+func (x *PaymentsResponse) Reset() {
+	*x = PaymentsResponse{}
+}
+
+func (x *PaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsResponse) ProtoMessage() {}
+
+func (x *PaymentsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PaymentsResponse) GetPayments() []*PaymentRecord {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+// PixChargeRequest pede a cobrança PIX de um pedido; qr_code_size é o lado da imagem
+// em pixels (zero, 256)
+type PixChargeRequest struct {
+	OrderId    uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	QrCodeSize int32  `protobuf:"varint,2,opt,name=qr_code_size,json=qrCodeSize,proto3" json:"qr_code_size,omitempty"`
+}
+
+func (x *PixChargeRequest) Reset() {
+	*x = PixChargeRequest{}
+}
+
+func (x *PixChargeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixChargeRequest) ProtoMessage() {}
+
+func (x *PixChargeRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PixChargeRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *PixChargeRequest) GetQrCodeSize() int32 {
+	if x != nil {
+		return x.QrCodeSize
+	}
+	return 0
+}
+
+// PixChargeResponse traz o BR Code (copia e cola) e o QR Code em PNG. kind é static ou
+// dynamic; status é active, paid ou expired; datas em RFC 3339
+type PixChargeResponse struct {
+	OrderId    uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Txid       string `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	Kind       string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Payload    string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	QrCodePng  []byte `protobuf:"bytes,5,opt,name=qr_code_png,json=qrCodePng,proto3" json:"qr_code_png,omitempty"`
+	Amount     *Money `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status     string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt  string `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	PaidAt     string `protobuf:"bytes,9,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	EndToEndId string `protobuf:"bytes,10,opt,name=end_to_end_id,json=endToEndId,proto3" json:"end_to_end_id,omitempty"`
+}
+
+func (x *PixChargeResponse) Reset() {
+	*x = PixChargeResponse{}
+}
+
+func (x *PixChargeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixChargeResponse) ProtoMessage() {}
+
+func (x *PixChargeResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *PixChargeResponse) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *PixChargeResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *PixChargeResponse) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PixChargeResponse) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *PixChargeResponse) GetQrCodePng() []byte {
+	if x != nil {
+		return x.QrCodePng
+	}
+	return nil
+}
+
+func (x *PixChargeResponse) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *PixChargeResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PixChargeResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *PixChargeResponse) GetPaidAt() string {
+	if x != nil {
+		return x.PaidAt
+	}
+	return ""
+}
+
+func (x *PixChargeResponse) GetEndToEndId() string {
+	if x != nil {
+		return x.EndToEndId
+	}
+	return ""
+}
+
+// BoletoRequest pede o boleto de um pedido; include_pdf inclui o PDF na resposta
+type BoletoRequest struct {
+	OrderId    uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	IncludePdf bool   `protobuf:"varint,2,opt,name=include_pdf,json=includePdf,proto3" json:"include_pdf,omitempty"`
+}
+
+func (x *BoletoRequest) Reset() {
+	*x = BoletoRequest{}
+}
+
+func (x *BoletoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoletoRequest) ProtoMessage() {}
+
+func (x *BoletoRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *BoletoRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *BoletoRequest) GetIncludePdf() bool {
+	if x != nil {
+		return x.IncludePdf
+	}
+	return false
+}
+
+// BoletoResponse traz o código de barras (44 dígitos) e a linha digitável (47 dígitos)
+// do boleto. status é open, paid ou expired; due_date em AAAA-MM-DD e demais datas em
+// RFC 3339
+type BoletoResponse struct {
+	OrderId       uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	NossoNumero   string `protobuf:"bytes,2,opt,name=nosso_numero,json=nossoNumero,proto3" json:"nosso_numero,omitempty"`
+	Barcode       string `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	DigitableLine string `protobuf:"bytes,4,opt,name=digitable_line,json=digitableLine,proto3" json:"digitable_line,omitempty"`
+	Amount        *Money `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	DueDate       string `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Status        string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt     string `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	PaidAt        string `protobuf:"bytes,9,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	PaidAmount    *Money `protobuf:"bytes,10,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	Pdf           []byte `protobuf:"bytes,11,opt,name=pdf,proto3" json:"pdf,omitempty"`
+}
+
+func (x *BoletoResponse) Reset() {
+	*x = BoletoResponse{}
+}
+
+func (x *BoletoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoletoResponse) ProtoMessage() {}
+
+func (x *BoletoResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *BoletoResponse) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *BoletoResponse) GetNossoNumero() string {
+	if x != nil {
+		return x.NossoNumero
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetDigitableLine() string {
+	if x != nil {
+		return x.DigitableLine
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *BoletoResponse) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetPaidAt() string {
+	if x != nil {
+		return x.PaidAt
+	}
+	return ""
+}
+
+func (x *BoletoResponse) GetPaidAmount() *Money {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return nil
+}
+
+func (x *BoletoResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+// ReconcileBoletosRequest envia o arquivo de retorno CNAB 240 ou 400 do banco; em
+// dry_run nada é gravado
+type ReconcileBoletosRequest struct {
+	Content []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	DryRun  bool   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *ReconcileBoletosRequest) Reset() {
+	*x = ReconcileBoletosRequest{}
+}
+
+func (x *ReconcileBoletosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBoletosRequest) ProtoMessage() {}
+
+func (x *ReconcileBoletosRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ReconcileBoletosRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ReconcileBoletosRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// BoletoReconcileItem é o resultado de um título do arquivo de retorno. outcome é paid,
+// already_paid, not_found, amount_mismatch, expired ou ignored
+type BoletoReconcileItem struct {
+	Line        int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	NossoNumero string `protobuf:"bytes,2,opt,name=nosso_numero,json=nossoNumero,proto3" json:"nosso_numero,omitempty"`
+	OrderId     uint32 `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Occurrence  string `protobuf:"bytes,4,opt,name=occurrence,proto3" json:"occurrence,omitempty"`
+	Outcome     string `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Detail      string `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *BoletoReconcileItem) Reset() {
+	*x = BoletoReconcileItem{}
+}
+
+func (x *BoletoReconcileItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoletoReconcileItem) ProtoMessage() {}
+
+func (x *BoletoReconcileItem) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *BoletoReconcileItem) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *BoletoReconcileItem) GetNossoNumero() string {
+	if x != nil {
+		return x.NossoNumero
+	}
+	return ""
+}
+
+func (x *BoletoReconcileItem) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *BoletoReconcileItem) GetOccurrence() string {
+	if x != nil {
+		return x.Occurrence
+	}
+	return ""
+}
+
+func (x *BoletoReconcileItem) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *BoletoReconcileItem) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// ReconcileBoletosResponse resume a conciliação do arquivo de retorno
+type ReconcileBoletosResponse struct {
+	Format         string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	DryRun         bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Paid           int32                  `protobuf:"varint,3,opt,name=paid,proto3" json:"paid,omitempty"`
+	AlreadyPaid    int32                  `protobuf:"varint,4,opt,name=already_paid,json=alreadyPaid,proto3" json:"already_paid,omitempty"`
+	NotFound       int32                  `protobuf:"varint,5,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	AmountMismatch int32                  `protobuf:"varint,6,opt,name=amount_mismatch,json=amountMismatch,proto3" json:"amount_mismatch,omitempty"`
+	Expired        int32                  `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
+	Ignored        int32                  `protobuf:"varint,8,opt,name=ignored,proto3" json:"ignored,omitempty"`
+	Items          []*BoletoReconcileItem `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ReconcileBoletosResponse) Reset() {
+	*x = ReconcileBoletosResponse{}
+}
+
+func (x *ReconcileBoletosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBoletosResponse) ProtoMessage() {}
+
+func (x *ReconcileBoletosResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ReconcileBoletosResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ReconcileBoletosResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ReconcileBoletosResponse) GetPaid() int32 {
+	if x != nil {
+		return x.Paid
+	}
+	return 0
+}
+
+func (x *ReconcileBoletosResponse) GetAlreadyPaid() int32 {
+	if x != nil {
+		return x.AlreadyPaid
+	}
+	return 0
+}
+
+func (x *ReconcileBoletosResponse) GetNotFound() int32 {
+	if x != nil {
+		return x.NotFound
+	}
+	return 0
+}
+
+func (x *ReconcileBoletosResponse) GetAmountMismatch() int32 {
+	if x != nil {
+		return x.AmountMismatch
+	}
+	return 0
+}
+
+func (x *ReconcileBoletosResponse) GetExpired() int32 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *ReconcileBoletosResponse) GetIgnored() int32 {
+	if x != nil {
+		return x.Ignored
+	}
+	return 0
+}
+
+func (x *ReconcileBoletosResponse) GetItems() []*BoletoReconcileItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// DisputeEvidence é um anexo da defesa; o conteúdo vem em GetDisputeEvidence. kind é
+// receipt, invoice, shipping, communication ou other; sha256 em hexadecimal
+type DisputeEvidence struct {
+	Id          uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind        string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	FileName    string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256      string `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Note        string `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	UploadedBy  string `protobuf:"bytes,8,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CreatedAt   string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Content     []byte `protobuf:"bytes,10,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *DisputeEvidence) Reset() {
+	*x = DisputeEvidence{}
+}
+
+func (x *DisputeEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidence) ProtoMessage() {}
+
+func (x *DisputeEvidence) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DisputeEvidence) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DisputeEvidence) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DisputeEvidence) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Dispute é a contestação (chargeback) de um pagamento. status é opened,
+// evidence_submitted, won ou lost; overdue indica defesa não enviada no prazo; datas
+// em RFC 3339
+type Dispute struct {
+	Id                  uint32             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId             uint32             `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PaymentId           uint32             `protobuf:"varint,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Reference           string             `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	Reason              string             `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount              *Money             `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status              string             `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	EvidenceDueAt       string             `protobuf:"bytes,8,opt,name=evidence_due_at,json=evidenceDueAt,proto3" json:"evidence_due_at,omitempty"`
+	Overdue             bool               `protobuf:"varint,9,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	OpenedBy            string             `protobuf:"bytes,10,opt,name=opened_by,json=openedBy,proto3" json:"opened_by,omitempty"`
+	CreatedAt           string             `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EvidenceSubmittedAt string             `protobuf:"bytes,12,opt,name=evidence_submitted_at,json=evidenceSubmittedAt,proto3" json:"evidence_submitted_at,omitempty"`
+	ResolvedAt          string             `protobuf:"bytes,13,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	ResolvedBy          string             `protobuf:"bytes,14,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	ResolutionNote      string             `protobuf:"bytes,15,opt,name=resolution_note,json=resolutionNote,proto3" json:"resolution_note,omitempty"`
+	Evidence            []*DisputeEvidence `protobuf:"bytes,16,rep,name=evidence,proto3" json:"evidence,omitempty"`
+}
+
+func (x *Dispute) Reset() {
+	*x = Dispute{}
+}
+
+func (x *Dispute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dispute) ProtoMessage() {}
+
+func (x *Dispute) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *Dispute) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Dispute) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Dispute) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *Dispute) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Dispute) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Dispute) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Dispute) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Dispute) GetEvidenceDueAt() string {
+	if x != nil {
+		return x.EvidenceDueAt
+	}
+	return ""
+}
+
+func (x *Dispute) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *Dispute) GetOpenedBy() string {
+	if x != nil {
+		return x.OpenedBy
+	}
+	return ""
+}
+
+func (x *Dispute) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Dispute) GetEvidenceSubmittedAt() string {
+	if x != nil {
+		return x.EvidenceSubmittedAt
+	}
+	return ""
+}
+
+func (x *Dispute) GetResolvedAt() string {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return ""
+}
+
+func (x *Dispute) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Dispute) GetResolutionNote() string {
+	if x != nil {
+		return x.ResolutionNote
+	}
+	return ""
+}
+
+func (x *Dispute) GetEvidence() []*DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+// OpenDisputeRequest registra a contestação recebida do adquirente. reference é o
+// identificador do adquirente (repetido, devolve a mesma contestação); sem amount é
+// contestado o valor ainda não contestado; sem evidence_due_at (RFC 3339) vale o prazo
+// padrão
+type OpenDisputeRequest struct {
+	OrderId       uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reference     string `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount        *Money `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	EvidenceDueAt string `protobuf:"bytes,5,opt,name=evidence_due_at,json=evidenceDueAt,proto3" json:"evidence_due_at,omitempty"`
+}
+
+func (x *OpenDisputeRequest) Reset() {
+	*x = OpenDisputeRequest{}
+}
+
+func (x *OpenDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenDisputeRequest) ProtoMessage() {}
+
+func (x *OpenDisputeRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *OpenDisputeRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OpenDisputeRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *OpenDisputeRequest) GetEvidenceDueAt() string {
+	if x != nil {
+		return x.EvidenceDueAt
+	}
+	return ""
+}
+
+// AddDisputeEvidenceRequest anexa um arquivo à defesa; sem content_type o formato é
+// detectado pelo conteúdo
+type AddDisputeEvidenceRequest struct {
+	DisputeId   uint32 `protobuf:"varint,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Kind        string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	FileName    string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content     []byte `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Note        string `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *AddDisputeEvidenceRequest) Reset() {
+	*x = AddDisputeEvidenceRequest{}
+}
+
+func (x *AddDisputeEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDisputeEvidenceRequest) ProtoMessage() {}
+
+func (x *AddDisputeEvidenceRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AddDisputeEvidenceRequest) GetDisputeId() uint32 {
+	if x != nil {
+		return x.DisputeId
+	}
+	return 0
+}
+
+func (x *AddDisputeEvidenceRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *AddDisputeEvidenceRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// DisputeRequest seleciona uma contestação
+type DisputeRequest struct {
+	DisputeId uint32 `protobuf:"varint,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+}
+
+func (x *DisputeRequest) Reset() {
+	*x = DisputeRequest{}
+}
+
+func (x *DisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeRequest) ProtoMessage() {}
+
+func (x *DisputeRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DisputeRequest) GetDisputeId() uint32 {
+	if x != nil {
+		return x.DisputeId
+	}
+	return 0
+}
+
+// DisputeEvidenceRequest seleciona um anexo da defesa
+type DisputeEvidenceRequest struct {
+	EvidenceId uint32 `protobuf:"varint,1,opt,name=evidence_id,json=evidenceId,proto3" json:"evidence_id,omitempty"`
+}
+
+func (x *DisputeEvidenceRequest) Reset() {
+	*x = DisputeEvidenceRequest{}
+}
+
+func (x *DisputeEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidenceRequest) ProtoMessage() {}
+
+func (x *DisputeEvidenceRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DisputeEvidenceRequest) GetEvidenceId() uint32 {
+	if x != nil {
+		return x.EvidenceId
+	}
+	return 0
+}
+
+// ResolveDisputeRequest registra a decisão do adquirente: outcome won ou lost
+type ResolveDisputeRequest struct {
+	DisputeId uint32 `protobuf:"varint,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Outcome   string `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Note      string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *ResolveDisputeRequest) Reset() {
+	*x = ResolveDisputeRequest{}
+}
+
+func (x *ResolveDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDisputeRequest) ProtoMessage() {}
+
+func (x *ResolveDisputeRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ResolveDisputeRequest) GetDisputeId() uint32 {
+	if x != nil {
+		return x.DisputeId
+	}
+	return 0
+}
+
+func (x *ResolveDisputeRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// ListDisputesRequest lista as contestações, filtradas por pedido e status quando
+// informados; limit zero devolve até 200
+type ListDisputesRequest struct {
+	OrderId uint32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Limit   int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListDisputesRequest) Reset() {
+	*x = ListDisputesRequest{}
+}
+
+func (x *ListDisputesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisputesRequest) ProtoMessage() {}
+
+func (x *ListDisputesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListDisputesRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ListDisputesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListDisputesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// DisputesNearDeadlineRequest lista as contestações sem defesa com prazo vencendo em
+// within (duração, ex.: 72h; vazio usa a janela padrão), incluindo as vencidas
+type DisputesNearDeadlineRequest struct {
+	Within string `protobuf:"bytes,1,opt,name=within,proto3" json:"within,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *DisputesNearDeadlineRequest) Reset() {
+	*x = DisputesNearDeadlineRequest{}
+}
+
+func (x *DisputesNearDeadlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputesNearDeadlineRequest) ProtoMessage() {}
+
+func (x *DisputesNearDeadlineRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DisputesNearDeadlineRequest) GetWithin() string {
+	if x != nil {
+		return x.Within
+	}
+	return ""
+}
+
+func (x *DisputesNearDeadlineRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// DisputesResponse representa uma lista de contestações
+type DisputesResponse struct {
+	Disputes []*Dispute `protobuf:"bytes,1,rep,name=disputes,proto3" json:"disputes,omitempty"`
+}
+
+func (x *DisputesResponse) Reset() {
+	*x = DisputesResponse{}
+}
+
+func (x *DisputesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputesResponse) ProtoMessage() {}
+
+func (x *DisputesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *DisputesResponse) GetDisputes() []*Dispute {
+	if x != nil {
+		return x.Disputes
+	}
+	return nil
+}
+
+// ImportSettlementRequest envia o arquivo de liquidação do provedor de cartão (CSV com
+// gateway_ref, amount, currency, status e, opcional, transaction_date)
+type ImportSettlementRequest struct {
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Content  []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *ImportSettlementRequest) Reset() {
+	*x = ImportSettlementRequest{}
+}
+
+func (x *ImportSettlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSettlementRequest) ProtoMessage() {}
+
+func (x *ImportSettlementRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ImportSettlementRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ImportSettlementRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// SettlementItem é o resultado de uma transação do arquivo, ou de um pagamento do
+// período ausente do arquivo (line zero). outcome é matched, missing_payment,
+// missing_settlement, amount_mismatch, status_mismatch ou duplicate
+type SettlementItem struct {
+	Line          int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	GatewayRef    string `protobuf:"bytes,2,opt,name=gateway_ref,json=gatewayRef,proto3" json:"gateway_ref,omitempty"`
+	PaymentId     uint32 `protobuf:"varint,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       uint32 `protobuf:"varint,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Outcome       string `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	SettledStatus string `protobuf:"bytes,6,opt,name=settled_status,json=settledStatus,proto3" json:"settled_status,omitempty"`
+	SettledAmount *Money `protobuf:"bytes,7,opt,name=settled_amount,json=settledAmount,proto3" json:"settled_amount,omitempty"`
+	PaymentStatus string `protobuf:"bytes,8,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
+	PaymentAmount *Money `protobuf:"bytes,9,opt,name=payment_amount,json=paymentAmount,proto3" json:"payment_amount,omitempty"`
+	Detail        string `protobuf:"bytes,10,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *SettlementItem) Reset() {
+	*x = SettlementItem{}
+}
+
+func (x *SettlementItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementItem) ProtoMessage() {}
+
+func (x *SettlementItem) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SettlementItem) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SettlementItem) GetGatewayRef() string {
+	if x != nil {
+		return x.GatewayRef
+	}
+	return ""
+}
+
+func (x *SettlementItem) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *SettlementItem) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *SettlementItem) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *SettlementItem) GetSettledStatus() string {
+	if x != nil {
+		return x.SettledStatus
+	}
+	return ""
+}
+
+func (x *SettlementItem) GetSettledAmount() *Money {
+	if x != nil {
+		return x.SettledAmount
+	}
+	return nil
+}
+
+func (x *SettlementItem) GetPaymentStatus() string {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return ""
+}
+
+func (x *SettlementItem) GetPaymentAmount() *Money {
+	if x != nil {
+		return x.PaymentAmount
+	}
+	return nil
+}
+
+func (x *SettlementItem) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// SettlementRun é a conciliação de um arquivo de liquidação, com os totais por
+// resultado. already_imported indica um arquivo já conciliado antes; source é rpc ou
+// inbox; period_start e period_end (exclusivo) em AAAA-MM-DD e created_at em RFC 3339
+type SettlementRun struct {
+	Id                uint32            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileName          string            `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSha256        string            `protobuf:"bytes,3,opt,name=file_sha256,json=fileSha256,proto3" json:"file_sha256,omitempty"`
+	Source            string            `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	ImportedBy        string            `protobuf:"bytes,5,opt,name=imported_by,json=importedBy,proto3" json:"imported_by,omitempty"`
+	PeriodStart       string            `protobuf:"bytes,6,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd         string            `protobuf:"bytes,7,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	Records           int32             `protobuf:"varint,8,opt,name=records,proto3" json:"records,omitempty"`
+	Matched           int32             `protobuf:"varint,9,opt,name=matched,proto3" json:"matched,omitempty"`
+	MissingPayment    int32             `protobuf:"varint,10,opt,name=missing_payment,json=missingPayment,proto3" json:"missing_payment,omitempty"`
+	MissingSettlement int32             `protobuf:"varint,11,opt,name=missing_settlement,json=missingSettlement,proto3" json:"missing_settlement,omitempty"`
+	AmountMismatch    int32             `protobuf:"varint,12,opt,name=amount_mismatch,json=amountMismatch,proto3" json:"amount_mismatch,omitempty"`
+	StatusMismatch    int32             `protobuf:"varint,13,opt,name=status_mismatch,json=statusMismatch,proto3" json:"status_mismatch,omitempty"`
+	Duplicate         int32             `protobuf:"varint,14,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	CreatedAt         string            `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AlreadyImported   bool              `protobuf:"varint,16,opt,name=already_imported,json=alreadyImported,proto3" json:"already_imported,omitempty"`
+	Items             []*SettlementItem `protobuf:"bytes,17,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *SettlementRun) Reset() {
+	*x = SettlementRun{}
+}
+
+func (x *SettlementRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementRun) ProtoMessage() {}
+
+func (x *SettlementRun) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SettlementRun) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetFileSha256() string {
+	if x != nil {
+		return x.FileSha256
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetImportedBy() string {
+	if x != nil {
+		return x.ImportedBy
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetPeriodStart() string {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetPeriodEnd() string {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetRecords() int32 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetMissingPayment() int32 {
+	if x != nil {
+		return x.MissingPayment
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetMissingSettlement() int32 {
+	if x != nil {
+		return x.MissingSettlement
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetAmountMismatch() int32 {
+	if x != nil {
+		return x.AmountMismatch
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetStatusMismatch() int32 {
+	if x != nil {
+		return x.StatusMismatch
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetDuplicate() int32 {
+	if x != nil {
+		return x.Duplicate
+	}
+	return 0
+}
+
+func (x *SettlementRun) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SettlementRun) GetAlreadyImported() bool {
+	if x != nil {
+		return x.AlreadyImported
+	}
+	return false
+}
+
+func (x *SettlementRun) GetItems() []*SettlementItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// SettlementRunRequest seleciona uma conciliação; outcome filtra os itens
+type SettlementRunRequest struct {
+	RunId   uint32 `protobuf:"varint,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Outcome string `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+}
+
+func (x *SettlementRunRequest) Reset() {
+	*x = SettlementRunRequest{}
+}
+
+func (x *SettlementRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementRunRequest) ProtoMessage() {}
+
+func (x *SettlementRunRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SettlementRunRequest) GetRunId() uint32 {
+	if x != nil {
+		return x.RunId
+	}
+	return 0
+}
+
+func (x *SettlementRunRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+// ListSettlementRunsRequest lista as conciliações mais recentes; limit zero devolve até
+// 100
+type ListSettlementRunsRequest struct {
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListSettlementRunsRequest) Reset() {
+	*x = ListSettlementRunsRequest{}
+}
+
+func (x *ListSettlementRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSettlementRunsRequest) ProtoMessage() {}
+
+func (x *ListSettlementRunsRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListSettlementRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SettlementRunsResponse representa uma lista de conciliações, sem os itens
+type SettlementRunsResponse struct {
+	Runs []*SettlementRun `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+}
+
+func (x *SettlementRunsResponse) Reset() {
+	*x = SettlementRunsResponse{}
+}
+
+func (x *SettlementRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementRunsResponse) ProtoMessage() {}
+
+func (x *SettlementRunsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SettlementRunsResponse) GetRuns() []*SettlementRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+// SettlementReport é o resultado de uma conciliação em CSV
+type SettlementReport struct {
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Content  []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *SettlementReport) Reset() {
+	*x = SettlementReport{}
+}
+
+func (x *SettlementReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementReport) ProtoMessage() {}
+
+func (x *SettlementReport) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SettlementReport) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *SettlementReport) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// PaymentServiceClient é o cliente do serviço de pagamentos
+type PaymentServiceClient interface {
+	GetPaymentStatus(ctx context.Context, in *PaymentStatusRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error)
+	ExportPaymentData(ctx context.Context, in *PaymentsByOrdersRequest, opts ...grpc.CallOption) (*PaymentsResponse, error)
+	AnonymizePayments(ctx context.Context, in *AnonymizePaymentsRequest, opts ...grpc.CallOption) (*AnonymizePaymentsResponse, error)
+	GetPayment(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*PaymentRecord, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	GetPaymentsByOrderIDs(ctx context.Context, in *PaymentsByOrdersRequest, opts ...grpc.CallOption) (*PaymentsResponse, error)
+	GetPixCharge(ctx context.Context, in *PixChargeRequest, opts ...grpc.CallOption) (*PixChargeResponse, error)
+	GetBoleto(ctx context.Context, in *BoletoRequest, opts ...grpc.CallOption) (*BoletoResponse, error)
+	ReconcileBoletos(ctx context.Context, in *ReconcileBoletosRequest, opts ...grpc.CallOption) (*ReconcileBoletosResponse, error)
+	ListPaymentsInReview(ctx context.Context, in *PaymentsInReviewRequest, opts ...grpc.CallOption) (*PaymentsResponse, error)
+	ApprovePayment(ctx context.Context, in *ReviewPaymentRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error)
+	RejectPayment(ctx context.Context, in *ReviewPaymentRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error)
+	OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	AddDisputeEvidence(ctx context.Context, in *AddDisputeEvidenceRequest, opts ...grpc.CallOption) (*DisputeEvidence, error)
+	SubmitDisputeEvidence(ctx context.Context, in *DisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	ResolveDispute(ctx context.Context, in *ResolveDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	GetDispute(ctx context.Context, in *DisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	GetDisputeEvidence(ctx context.Context, in *DisputeEvidenceRequest, opts ...grpc.CallOption) (*DisputeEvidence, error)
+	ListDisputes(ctx context.Context, in *ListDisputesRequest, opts ...grpc.CallOption) (*DisputesResponse, error)
+	ListDisputesNearDeadline(ctx context.Context, in *DisputesNearDeadlineRequest, opts ...grpc.CallOption) (*DisputesResponse, error)
+	ImportSettlement(ctx context.Context, in *ImportSettlementRequest, opts ...grpc.CallOption) (*SettlementRun, error)
+	GetSettlementRun(ctx context.Context, in *SettlementRunRequest, opts ...grpc.CallOption) (*SettlementRun, error)
+	ListSettlementRuns(ctx context.Context, in *ListSettlementRunsRequest, opts ...grpc.CallOption) (*SettlementRunsResponse, error)
+	ExportSettlementRun(ctx context.Context, in *SettlementRunRequest, opts ...grpc.CallOption) (*SettlementReport, error)
+}
+
+type paymentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPaymentServiceClient(cc grpc.ClientConnInterface) PaymentServiceClient {
+	return &paymentServiceClient{cc}
+}
+
+func (c *paymentServiceClient) GetPaymentStatus(ctx context.Context, in *PaymentStatusRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error) {
+	out := new(PaymentStatusResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPaymentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ExportPaymentData(ctx context.Context, in *PaymentsByOrdersRequest, opts ...grpc.CallOption) (*PaymentsResponse, error) {
+	out := new(PaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ExportPaymentData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) AnonymizePayments(ctx context.Context, in *AnonymizePaymentsRequest, opts ...grpc.CallOption) (*AnonymizePaymentsResponse, error) {
+	out := new(AnonymizePaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/AnonymizePayments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetPayment(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*PaymentRecord, error) {
+	out := new(PaymentRecord)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ListPayments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetPaymentsByOrderIDs(ctx context.Context, in *PaymentsByOrdersRequest, opts ...grpc.CallOption) (*PaymentsResponse, error) {
+	out := new(PaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPaymentsByOrderIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetPixCharge(ctx context.Context, in *PixChargeRequest, opts ...grpc.CallOption) (*PixChargeResponse, error) {
+	out := new(PixChargeResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetPixCharge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetBoleto(ctx context.Context, in *BoletoRequest, opts ...grpc.CallOption) (*BoletoResponse, error) {
+	out := new(BoletoResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetBoleto", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReconcileBoletos(ctx context.Context, in *ReconcileBoletosRequest, opts ...grpc.CallOption) (*ReconcileBoletosResponse, error) {
+	out := new(ReconcileBoletosResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ReconcileBoletos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPaymentsInReview(ctx context.Context, in *PaymentsInReviewRequest, opts ...grpc.CallOption) (*PaymentsResponse, error) {
+	out := new(PaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ListPaymentsInReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ApprovePayment(ctx context.Context, in *ReviewPaymentRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error) {
+	out := new(PaymentStatusResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ApprovePayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RejectPayment(ctx context.Context, in *ReviewPaymentRequest, opts ...grpc.CallOption) (*PaymentStatusResponse, error) {
+	out := new(PaymentStatusResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/RejectPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/OpenDispute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) AddDisputeEvidence(ctx context.Context, in *AddDisputeEvidenceRequest, opts ...grpc.CallOption) (*DisputeEvidence, error) {
+	out := new(DisputeEvidence)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/AddDisputeEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) SubmitDisputeEvidence(ctx context.Context, in *DisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/SubmitDisputeEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ResolveDispute(ctx context.Context, in *ResolveDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ResolveDispute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetDispute(ctx context.Context, in *DisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetDispute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetDisputeEvidence(ctx context.Context, in *DisputeEvidenceRequest, opts ...grpc.CallOption) (*DisputeEvidence, error) {
+	out := new(DisputeEvidence)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetDisputeEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListDisputes(ctx context.Context, in *ListDisputesRequest, opts ...grpc.CallOption) (*DisputesResponse, error) {
+	out := new(DisputesResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ListDisputes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListDisputesNearDeadline(ctx context.Context, in *DisputesNearDeadlineRequest, opts ...grpc.CallOption) (*DisputesResponse, error) {
+	out := new(DisputesResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ListDisputesNearDeadline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ImportSettlement(ctx context.Context, in *ImportSettlementRequest, opts ...grpc.CallOption) (*SettlementRun, error) {
+	out := new(SettlementRun)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ImportSettlement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetSettlementRun(ctx context.Context, in *SettlementRunRequest, opts ...grpc.CallOption) (*SettlementRun, error) {
+	out := new(SettlementRun)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/GetSettlementRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListSettlementRuns(ctx context.Context, in *ListSettlementRunsRequest, opts ...grpc.CallOption) (*SettlementRunsResponse, error) {
+	out := new(SettlementRunsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ListSettlementRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ExportSettlementRun(ctx context.Context, in *SettlementRunRequest, opts ...grpc.CallOption) (*SettlementReport, error) {
+	out := new(SettlementReport)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ExportSettlementRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentServiceServer é o servidor do serviço de pagamentos
+type PaymentServiceServer interface {
+	GetPaymentStatus(context.Context, *PaymentStatusRequest) (*PaymentStatusResponse, error)
+	ExportPaymentData(context.Context, *PaymentsByOrdersRequest) (*PaymentsResponse, error)
+	AnonymizePayments(context.Context, *AnonymizePaymentsRequest) (*AnonymizePaymentsResponse, error)
+	GetPayment(context.Context, *PaymentRequest) (*PaymentRecord, error)
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	GetPaymentsByOrderIDs(context.Context, *PaymentsByOrdersRequest) (*PaymentsResponse, error)
+	GetPixCharge(context.Context, *PixChargeRequest) (*PixChargeResponse, error)
+	GetBoleto(context.Context, *BoletoRequest) (*BoletoResponse, error)
+	ReconcileBoletos(context.Context, *ReconcileBoletosRequest) (*ReconcileBoletosResponse, error)
+	ListPaymentsInReview(context.Context, *PaymentsInReviewRequest) (*PaymentsResponse, error)
+	ApprovePayment(context.Context, *ReviewPaymentRequest) (*PaymentStatusResponse, error)
+	RejectPayment(context.Context, *ReviewPaymentRequest) (*PaymentStatusResponse, error)
+	OpenDispute(context.Context, *OpenDisputeRequest) (*Dispute, error)
+	AddDisputeEvidence(context.Context, *AddDisputeEvidenceRequest) (*DisputeEvidence, error)
+	SubmitDisputeEvidence(context.Context, *DisputeRequest) (*Dispute, error)
+	ResolveDispute(context.Context, *ResolveDisputeRequest) (*Dispute, error)
+	GetDispute(context.Context, *DisputeRequest) (*Dispute, error)
+	GetDisputeEvidence(context.Context, *DisputeEvidenceRequest) (*DisputeEvidence, error)
+	ListDisputes(context.Context, *ListDisputesRequest) (*DisputesResponse, error)
+	ListDisputesNearDeadline(context.Context, *DisputesNearDeadlineRequest) (*DisputesResponse, error)
+	ImportSettlement(context.Context, *ImportSettlementRequest) (*SettlementRun, error)
+	GetSettlementRun(context.Context, *SettlementRunRequest) (*SettlementRun, error)
+	ListSettlementRuns(context.Context, *ListSettlementRunsRequest) (*SettlementRunsResponse, error)
+	ExportSettlementRun(context.Context, *SettlementRunRequest) (*SettlementReport, error)
+}
+
+// UnimplementedPaymentServiceServer deve ser embedded para ter implementações forward compatible
+type UnimplementedPaymentServiceServer struct {
+}
+
+func (UnimplementedPaymentServiceServer) GetPaymentStatus(context.Context, *PaymentStatusRequest) (*PaymentStatusResponse, error) {
+	return nil, nil
+}
+
+func (UnimplementedPaymentServiceServer) ExportPaymentData(context.Context, *PaymentsByOrdersRequest) (*PaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPaymentData not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) AnonymizePayments(context.Context, *AnonymizePaymentsRequest) (*AnonymizePaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnonymizePayments not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetPayment(context.Context, *PaymentRequest) (*PaymentRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayment not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayments not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetPaymentsByOrderIDs(context.Context, *PaymentsByOrdersRequest) (*PaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentsByOrderIDs not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetPixCharge(context.Context, *PixChargeRequest) (*PixChargeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPixCharge not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetBoleto(context.Context, *BoletoRequest) (*BoletoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoleto not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ReconcileBoletos(context.Context, *ReconcileBoletosRequest) (*ReconcileBoletosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileBoletos not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ListPaymentsInReview(context.Context, *PaymentsInReviewRequest) (*PaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPaymentsInReview not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ApprovePayment(context.Context, *ReviewPaymentRequest) (*PaymentStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApprovePayment not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) RejectPayment(context.Context, *ReviewPaymentRequest) (*PaymentStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectPayment not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) OpenDispute(context.Context, *OpenDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenDispute not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) AddDisputeEvidence(context.Context, *AddDisputeEvidenceRequest) (*DisputeEvidence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDisputeEvidence not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) SubmitDisputeEvidence(context.Context, *DisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitDisputeEvidence not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ResolveDispute(context.Context, *ResolveDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDispute not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetDispute(context.Context, *DisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispute not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetDisputeEvidence(context.Context, *DisputeEvidenceRequest) (*DisputeEvidence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDisputeEvidence not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ListDisputes(context.Context, *ListDisputesRequest) (*DisputesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisputes not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ListDisputesNearDeadline(context.Context, *DisputesNearDeadlineRequest) (*DisputesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisputesNearDeadline not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ImportSettlement(context.Context, *ImportSettlementRequest) (*SettlementRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSettlement not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) GetSettlementRun(context.Context, *SettlementRunRequest) (*SettlementRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettlementRun not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ListSettlementRuns(context.Context, *ListSettlementRunsRequest) (*SettlementRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSettlementRuns not implemented")
+}
+
+func (UnimplementedPaymentServiceServer) ExportSettlementRun(context.Context, *SettlementRunRequest) (*SettlementReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSettlementRun not implemented")
+}
+
+// RegisterPaymentServiceServer registra o serviço no servidor gRPC
+func RegisterPaymentServiceServer(s grpc.ServiceRegistrar, srv PaymentServiceServer) {
+	s.RegisterService(&PaymentService_ServiceDesc, srv)
+}
+
+func _PaymentService_GetPaymentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPaymentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetPaymentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPaymentStatus(ctx, req.(*PaymentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ExportPaymentData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsByOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ExportPaymentData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ExportPaymentData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ExportPaymentData(ctx, req.(*PaymentsByOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_AnonymizePayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnonymizePaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).AnonymizePayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/AnonymizePayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).AnonymizePayments(ctx, req.(*AnonymizePaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPayment(ctx, req.(*PaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ListPayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetPaymentsByOrderIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsByOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPaymentsByOrderIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetPaymentsByOrderIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPaymentsByOrderIDs(ctx, req.(*PaymentsByOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetPixCharge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PixChargeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPixCharge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetPixCharge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPixCharge(ctx, req.(*PixChargeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetBoleto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoletoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetBoleto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetBoleto",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetBoleto(ctx, req.(*BoletoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ReconcileBoletos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileBoletosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReconcileBoletos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ReconcileBoletos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReconcileBoletos(ctx, req.(*ReconcileBoletosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListPaymentsInReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsInReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPaymentsInReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ListPaymentsInReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPaymentsInReview(ctx, req.(*PaymentsInReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ApprovePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ApprovePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ApprovePayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ApprovePayment(ctx, req.(*ReviewPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RejectPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RejectPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/RejectPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RejectPayment(ctx, req.(*ReviewPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_OpenDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).OpenDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/OpenDispute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).OpenDispute(ctx, req.(*OpenDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_AddDisputeEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDisputeEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).AddDisputeEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/AddDisputeEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).AddDisputeEvidence(ctx, req.(*AddDisputeEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_SubmitDisputeEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).SubmitDisputeEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/SubmitDisputeEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).SubmitDisputeEvidence(ctx, req.(*DisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ResolveDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ResolveDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ResolveDispute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ResolveDispute(ctx, req.(*ResolveDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetDispute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetDispute(ctx, req.(*DisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetDisputeEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetDisputeEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetDisputeEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetDisputeEvidence(ctx, req.(*DisputeEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListDisputes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisputesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListDisputes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ListDisputes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListDisputes(ctx, req.(*ListDisputesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListDisputesNearDeadline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputesNearDeadlineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListDisputesNearDeadline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ListDisputesNearDeadline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListDisputesNearDeadline(ctx, req.(*DisputesNearDeadlineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ImportSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ImportSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ImportSettlement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ImportSettlement(ctx, req.(*ImportSettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetSettlementRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettlementRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetSettlementRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/GetSettlementRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetSettlementRun(ctx, req.(*SettlementRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListSettlementRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSettlementRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListSettlementRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ListSettlementRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListSettlementRuns(ctx, req.(*ListSettlementRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ExportSettlementRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettlementRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ExportSettlementRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.PaymentService/ExportSettlementRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ExportSettlementRun(ctx, req.(*SettlementRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentService_ServiceDesc é o descritor do serviço gRPC
+var PaymentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "payment.PaymentService",
+	HandlerType: (*PaymentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPaymentStatus",
+			Handler:    _PaymentService_GetPaymentStatus_Handler,
+		},
+		{
+			MethodName: "ExportPaymentData",
+			Handler:    _PaymentService_ExportPaymentData_Handler,
+		},
+		{
+			MethodName: "AnonymizePayments",
+			Handler:    _PaymentService_AnonymizePayments_Handler,
+		},
+		{
+			MethodName: "GetPayment",
+			Handler:    _PaymentService_GetPayment_Handler,
+		},
+		{
+			MethodName: "ListPayments",
+			Handler:    _PaymentService_ListPayments_Handler,
+		},
+		{
+			MethodName: "GetPaymentsByOrderIDs",
+			Handler:    _PaymentService_GetPaymentsByOrderIDs_Handler,
+		},
+		{
+			MethodName: "GetPixCharge",
+			Handler:    _PaymentService_GetPixCharge_Handler,
+		},
+		{
+			MethodName: "GetBoleto",
+			Handler:    _PaymentService_GetBoleto_Handler,
+		},
+		{
+			MethodName: "ReconcileBoletos",
+			Handler:    _PaymentService_ReconcileBoletos_Handler,
+		},
+		{
+			MethodName: "ListPaymentsInReview",
+			Handler:    _PaymentService_ListPaymentsInReview_Handler,
+		},
+		{
+			MethodName: "ApprovePayment",
+			Handler:    _PaymentService_ApprovePayment_Handler,
+		},
+		{
+			MethodName: "RejectPayment",
+			Handler:    _PaymentService_RejectPayment_Handler,
+		},
+		{
+			MethodName: "OpenDispute",
+			Handler:    _PaymentService_OpenDispute_Handler,
+		},
+		{
+			MethodName: "AddDisputeEvidence",
+			Handler:    _PaymentService_AddDisputeEvidence_Handler,
+		},
+		{
+			MethodName: "SubmitDisputeEvidence",
+			Handler:    _PaymentService_SubmitDisputeEvidence_Handler,
+		},
+		{
+			MethodName: "ResolveDispute",
+			Handler:    _PaymentService_ResolveDispute_Handler,
+		},
+		{
+			MethodName: "GetDispute",
+			Handler:    _PaymentService_GetDispute_Handler,
+		},
+		{
+			MethodName: "GetDisputeEvidence",
+			Handler:    _PaymentService_GetDisputeEvidence_Handler,
+		},
+		{
+			MethodName: "ListDisputes",
+			Handler:    _PaymentService_ListDisputes_Handler,
+		},
+		{
+			MethodName: "ListDisputesNearDeadline",
+			Handler:    _PaymentService_ListDisputesNearDeadline_Handler,
+		},
+		{
+			MethodName: "ImportSettlement",
+			Handler:    _PaymentService_ImportSettlement_Handler,
+		},
+		{
+			MethodName: "GetSettlementRun",
+			Handler:    _PaymentService_GetSettlementRun_Handler,
+		},
+		{
+			MethodName: "ListSettlementRuns",
+			Handler:    _PaymentService_ListSettlementRuns_Handler,
+		},
+		{
+			MethodName: "ExportSettlementRun",
+			Handler:    _PaymentService_ExportSettlementRun_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type callerKey struct{}
+
+// WithCaller retorna um contexto contendo as claims do chamador autenticado
+func WithCaller(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, callerKey{}, claims)
+}
+
+// CallerFromContext retorna as claims do chamador, ou nil para métodos públicos
+func CallerFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(callerKey{}).(*Claims)
+	return claims
+}
+
+// UnaryServerInterceptor autoriza chamadas unárias conforme a política do serviço
+func UnaryServerInterceptor(tokens *TokenManager, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, tokens, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor autoriza chamadas de streaming conforme a política do serviço
+func StreamServerInterceptor(tokens *TokenManager, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), tokens, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize valida o token do metadata "authorization" e checa a permissão e o segundo
+// fator exigidos pelo método
+func authorize(ctx context.Context, tokens *TokenManager, policy Policy, method string) (context.Context, error) {
+	rule, declared := policy[method]
+	permission := rule.Permission
+	if !declared {
+		log.Printf("🚫 Método sem permissão declarada: %s", method)
+		return nil, status.Error(codes.PermissionDenied, "método sem política de acesso")
+	}
+	if permission == Public {
+		return ctx, nil
+	}
+
+	token := tokenFromMetadata(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "token de acesso ausente")
+	}
+
+	claims, err := tokens.Verify(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if claims.Kind == KindMFAChallenge {
+		return nil, status.Error(codes.Unauthenticated, "token de desafio MFA não é um token de acesso")
+	}
+
+	if permission != Authenticated && !claims.HasPermission(permission) {
+		log.Printf("🚫 %s (%s) sem permissão %s para %s", claims.Subject, claims.Kind, permission, method)
+		return nil, status.Errorf(codes.PermissionDenied, "permissão %s necessária", permission)
+	}
+
+	if rule.RequireMFA && !claims.IsService() && !claims.HasMFA() {
+		log.Printf("🚫 %s (%s) sem segundo fator para %s", claims.Subject, claims.Kind, method)
+		return nil, status.Error(codes.PermissionDenied, "operação exige login com segundo fator (MFA)")
+	}
+
+	return WithCaller(ctx, claims), nil
+}
+
+// tokenFromMetadata extrai o token Bearer do metadata gRPC
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	const prefix = "bearer "
+	value := values[0]
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(prefix):])
+}
+
+// authorizedStream substitui o contexto do stream pelo contexto autenticado
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testPolicy = Policy{
+	"/user.UserService/Login":      {Permission: Public},
+	"/user.UserService/ListUsers":  {Permission: PermUsersRead},
+	"/user.UserService/AssignRole": {Permission: PermRolesAdmin, RequireMFA: true},
+}
+
+func callWithToken(t *testing.T, tokens *TokenManager, method, token string) (*Claims, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	var caller *Claims
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller = CallerFromContext(ctx)
+		return nil, nil
+	}
+
+	interceptor := UnaryServerInterceptor(tokens, testPolicy)
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return caller, err
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tokens, err := NewTokenManager("test-secret", "user-service")
+	require.NoError(t, err)
+
+	serviceToken, _, err := tokens.Issue(Claims{
+		Permissions:      []string{PermUsersRead},
+		Kind:             KindService,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "bff-graphql"},
+	}, time.Minute)
+	require.NoError(t, err)
+
+	userToken, _, err := tokens.Issue(Claims{
+		Permissions:      []string{PermOrdersRead},
+		Kind:             KindUser,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "42"},
+	}, time.Minute)
+	require.NoError(t, err)
+
+	other, err := NewTokenManager("other-secret", "user-service")
+	require.NoError(t, err)
+	forgedToken, _, err := other.Issue(Claims{
+		Permissions:      []string{PermUsersRead},
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "intruso"},
+	}, time.Minute)
+	require.NoError(t, err)
+
+	challengeToken, _, err := tokens.Issue(Claims{
+		Kind:             KindMFAChallenge,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "42"},
+	}, time.Minute)
+	require.NoError(t, err)
+
+	t.Run("método público dispensa token", func(t *testing.T) {
+		caller, err := callWithToken(t, tokens, "/user.UserService/Login", "")
+		assert.NoError(t, err)
+		assert.Nil(t, caller)
+	})
+
+	t.Run("conta de serviço com permissão", func(t *testing.T) {
+		caller, err := callWithToken(t, tokens, "/user.UserService/ListUsers", serviceToken)
+		require.NoError(t, err)
+		assert.Equal(t, "bff-graphql", caller.Subject)
+		assert.True(t, caller.IsService())
+	})
+
+	t.Run("token ausente", func(t *testing.T) {
+		_, err := callWithToken(t, tokens, "/user.UserService/ListUsers", "")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("assinatura inválida", func(t *testing.T) {
+		_, err := callWithToken(t, tokens, "/user.UserService/ListUsers", forgedToken)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("permissão insuficiente", func(t *testing.T) {
+		_, err := callWithToken(t, tokens, "/user.UserService/ListUsers", userToken)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("token de desafio MFA não dá acesso", func(t *testing.T) {
+		_, err := callWithToken(t, tokens, "/user.UserService/ListUsers", challengeToken)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("método sem política é negado", func(t *testing.T) {
+		_, err := callWithToken(t, tokens, "/user.UserService/DeleteUser", serviceToken)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestUnaryServerInterceptor_RequireMFA(t *testing.T) {
+	tokens, err := NewTokenManager("test-secret", "user-service")
+	require.NoError(t, err)
+
+	issue := func(kind string, amr ...string) string {
+		token, _, err := tokens.Issue(Claims{
+			Permissions:      []string{PermRolesAdmin, PermUsersRead},
+			Kind:             kind,
+			AMR:              amr,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "1"},
+		}, time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		code  codes.Code
+	}{
+		{"administrador só com senha", issue(KindUser, AMRPassword), codes.PermissionDenied},
+		{"administrador com segundo fator", issue(KindUser, AMRPassword, AMROTP), codes.OK},
+		{"conta de serviço", issue(KindService), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := callWithToken(t, tokens, "/user.UserService/AssignRole", tt.token)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	// Métodos sem RequireMFA seguem aceitando o login só com senha
+	_, err = callWithToken(t, tokens, "/user.UserService/ListUsers", issue(KindUser, AMRPassword))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,73 @@
+// Package auth valida os tokens emitidos pelo user-service e autoriza as chamadas gRPC
+// de todos os serviços, que importam este módulo pelo replace do go.mod.
+package auth
+
+// Permissões conhecidas pelos serviços.
+const (
+	PermUsersRead  = "users:read"
+	PermUsersWrite = "users:write"
+	PermRolesAdmin = "roles:manage"
+
+	PermOrdersRead  = "orders:read"
+	PermOrdersWrite = "orders:write"
+
+	PermPaymentsRead  = "payments:read"
+	PermPaymentsWrite = "payments:write"
+
+	PermNotificationsRead = "notifications:read"
+	// PermNotificationsManage autoriza manter e pré-visualizar os modelos das notificações
+	PermNotificationsManage = "notifications:manage"
+
+	PermCatalogRead  = "catalog:read"
+	PermCatalogWrite = "catalog:write"
+
+	// PermInventoryWrite autoriza reservar, confirmar e liberar estoque
+	PermInventoryWrite = "inventory:write"
+
+	// PermPromotionsManage autoriza cadastrar, ativar e desativar promoções e cupons
+	PermPromotionsManage = "promotions:manage"
+
+	// PermTaxesManage autoriza manter a tabela de alíquotas de tributos
+	PermTaxesManage = "taxes:manage"
+
+	// PermPrivacy autoriza exportação e anonimização de dados pessoais (LGPD)
+	PermPrivacy = "privacy:manage"
+
+	// PermFraudReview autoriza consultar a fila de análise antifraude e aprovar ou
+	// rejeitar pagamentos retidos
+	PermFraudReview = "fraud:review"
+)
+
+// Public marca um método gRPC que não exige autenticação
+const Public = "public"
+
+// Authenticated marca um método que aceita qualquer token de acesso válido;
+// o próprio handler decide o escopo a partir do chamador
+const Authenticated = "authenticated"
+
+// Tipos de identidade presentes nos tokens
+const (
+	KindUser    = "user"
+	KindService = "service"
+	// KindMFAChallenge identifica o token de desafio emitido entre a senha e o segundo fator;
+	// ele não dá acesso a nenhum RPC protegido
+	KindMFAChallenge = "mfa_challenge"
+)
+
+// Métodos de autenticação registrados na claim "amr" (RFC 8176)
+const (
+	AMRPassword = "pwd"
+	AMROTP      = "otp"
+)
+
+// Rule é o que um método exige do chamador: a permissão (ou Public/Authenticated) e, nas
+// operações privilegiadas, RequireMFA, que só aceita usuários cujo login teve segundo
+// fator. Contas de serviço não têm segundo fator e são aceitas apenas pela permissão.
+type Rule struct {
+	Permission string
+	RequireMFA bool
+}
+
+// Policy associa o nome completo do método gRPC (ex: "/order.OrderService/CreateOrder")
+// à regra de acesso do chamador. Métodos ausentes da política são negados.
+type Policy map[string]Rule
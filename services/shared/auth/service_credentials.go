@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Margem de renovação do token antes da expiração
+const serviceTokenRefreshMargin = time.Minute
+
+// ServiceTokenRequest representa as credenciais da conta de serviço que faz a chamada
+type ServiceTokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// ServiceTokenResponse representa o token emitido pelo user-service
+type ServiceTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// ServiceCredentials implementa credentials.PerRPCCredentials anexando um token de
+// conta de serviço a cada chamada gRPC. O token é obtido no user-service
+// (IssueServiceToken) e renovado automaticamente antes de expirar.
+type ServiceCredentials struct {
+	conn         *grpc.ClientConn
+	clientID     string
+	clientSecret string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+var _ credentials.PerRPCCredentials = (*ServiceCredentials)(nil)
+
+// NewServiceCredentials cria as credenciais da conta de serviço usando uma conexão
+// dedicada (sem credenciais) com o user-service
+func NewServiceCredentials(userAddr, clientID, clientSecret string) (*ServiceCredentials, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, errors.New("SERVICE_CLIENT_ID e SERVICE_CLIENT_SECRET são obrigatórios")
+	}
+
+	conn, err := grpc.Dial(userAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceCredentials{
+		conn:         conn,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}, nil
+}
+
+// DialOption retorna a opção de dial que aplica as credenciais às chamadas
+func (c *ServiceCredentials) DialOption() grpc.DialOption {
+	return grpc.WithPerRPCCredentials(c)
+}
+
+// GetRequestMetadata retorna o header authorization com o token de serviço vigente
+func (c *ServiceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.currentToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity permite o uso em conexões internas sem TLS
+func (c *ServiceCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// Close fecha a conexão usada para emitir tokens
+func (c *ServiceCredentials) Close() error {
+	return c.conn.Close()
+}
+
+// currentToken retorna o token em cache ou solicita um novo quando próximo da expiração
+func (c *ServiceCredentials) currentToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expiresAt) > serviceTokenRefreshMargin {
+		return c.token, nil
+	}
+
+	out := new(ServiceTokenResponse)
+	err := c.conn.Invoke(ctx, "/user.UserService/IssueServiceToken", &ServiceTokenRequest{
+		ClientID:     c.clientID,
+		ClientSecret: c.clientSecret,
+	}, out)
+	if err != nil {
+		log.Printf("❌ Erro ao obter token de serviço: %v", err)
+		return "", err
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, out.ExpiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = out.AccessToken
+	c.expiresAt = expiresAt
+	log.Printf("🤖 Token de serviço renovado para %s (expira em %s)", c.clientID, out.ExpiresAt)
+	return c.token, nil
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims representa as claims dos tokens emitidos pelo user-service
+type Claims struct {
+	Email       string   `json:"email,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"perms,omitempty"`
+	Kind        string   `json:"kind"`
+	AMR         []string `json:"amr,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasPermission verifica se o token concede a permissão informada
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMFA indica se o login foi concluído com segundo fator
+func (c *Claims) HasMFA() bool {
+	for _, method := range c.AMR {
+		if method == AMROTP {
+			return true
+		}
+	}
+	return false
+}
+
+// IsService indica se o token pertence a uma conta de serviço
+func (c *Claims) IsService() bool {
+	return c.Kind == KindService
+}
+
+// TokenManager emite e valida tokens JWT assinados com HMAC-SHA256
+type TokenManager struct {
+	secret []byte
+	issuer string
+}
+
+// NewTokenManager cria um novo gerenciador de tokens
+func NewTokenManager(secret, issuer string) (*TokenManager, error) {
+	if secret == "" {
+		return nil, errors.New("JWT_SECRET não configurado")
+	}
+	return &TokenManager{
+		secret: []byte(secret),
+		issuer: issuer,
+	}, nil
+}
+
+// Issue assina um novo token com as claims informadas e validade ttl
+func (m *TokenManager) Issue(claims Claims, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims.Issuer = m.issuer
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("falha ao assinar token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// Verify valida assinatura, emissor e expiração do token
+func (m *TokenManager) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("token inválido: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token inválido: claim 'sub' ausente")
+	}
+	return claims, nil
+}
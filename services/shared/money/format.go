@@ -0,0 +1,101 @@
+package money
+
+import (
+	"strings"
+)
+
+// Localidades suportadas na formatação
+const (
+	LocalePtBR    = "pt-BR"
+	LocaleEnUS    = "en-US"
+	LocaleES      = "es"
+	DefaultLocale = LocalePtBR
+)
+
+// localeFormat descreve como uma localidade escreve valores monetários
+type localeFormat struct {
+	decimal     string
+	group       string
+	symbolAfter bool // símbolo depois do número (ex.: "1.999,90 €")
+	space       bool // espaço entre símbolo e número
+	symbols     map[string]string
+}
+
+var localeFormats = map[string]localeFormat{
+	LocalePtBR: {decimal: ",", group: ".", space: true, symbols: map[string]string{"USD": "US$", "CAD": "CA$"}},
+	LocaleEnUS: {decimal: ".", group: ",", symbols: map[string]string{"USD": "$", "CAD": "CA$"}},
+	LocaleES:   {decimal: ",", group: ".", symbolAfter: true, space: true, symbols: map[string]string{"USD": "US$", "CAD": "CA$"}},
+}
+
+// symbols são os símbolos comuns às localidades; moedas sem símbolo usam o código ISO
+var symbols = map[string]string{
+	"BRL": "R$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "CN¥", "KRW": "₩",
+}
+
+// NormalizeLocale padroniza a localidade (pt_BR, pt-br e pt viram pt-BR); localidades
+// desconhecidas usam DefaultLocale
+func NormalizeLocale(locale string) string {
+	language, _, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	switch strings.ToLower(language) {
+	case "en":
+		return LocaleEnUS
+	case "es":
+		return LocaleES
+	default:
+		return DefaultLocale
+	}
+}
+
+// Format formata o valor na moeda dele conforme a localidade
+// (ex.: "R$ 1.999,90" em pt-BR, "R$1,999.90" em en-US, "1.999,90 R$" em es)
+func Format(m Money, locale string) string {
+	format := localeFormats[NormalizeLocale(locale)]
+	currency := m.currency()
+
+	symbol, ok := format.symbols[currency]
+	if !ok {
+		if symbol, ok = symbols[currency]; !ok {
+			symbol = currency
+		}
+	}
+
+	// Decimal usa ponto decimal e nenhum separador de milhar
+	number := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(number, ".")
+	number = groupThousands(whole, format.group)
+	if hasFraction {
+		number += format.decimal + fraction
+	}
+
+	separator := ""
+	if format.space || len(symbol) == 3 && symbol == currency {
+		separator = " "
+	}
+	if format.symbolAfter {
+		return sign + number + separator + symbol
+	}
+	return sign + symbol + separator + number
+}
+
+// groupThousands insere o separador de milhar a cada três dígitos
+func groupThousands(digits, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,392 @@
+// Package money representa valores monetários como inteiros em unidades menores da
+// moeda (centavos, no caso do real) junto com o código ISO 4217.
+//
+// Nenhuma conta é feita em float64: somas e multiplicações são inteiras, percentuais
+// são Rate inteiros e toda divisão (percentuais, rateios, conversão de valores
+// decimais) recebe um modo de arredondamento explícito. float64 só aparece nas bordas:
+// em Float e Rate.Percent, para exibição e métricas, e em FromFloat e RateFromPercent,
+// para valores que ainda chegam como double.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency é a moeda assumida quando nenhuma é informada
+const DefaultCurrency = "BRL"
+
+var (
+	ErrInvalidAmount    = errors.New("valor monetário inválido")
+	ErrUnknownCurrency  = errors.New("moeda não suportada")
+	ErrCurrencyMismatch = errors.New("operação entre moedas diferentes")
+	ErrInvalidRate      = errors.New("percentual inválido")
+	ErrDivisionByZero   = errors.New("divisão por zero")
+)
+
+// RoundingMode define como descartar as frações menores que a unidade da moeda
+type RoundingMode int
+
+const (
+	// HalfUp arredonda o meio para longe do zero (0,005 → 0,01), o padrão comercial
+	HalfUp RoundingMode = iota
+	// HalfEven arredonda o meio para o par mais próximo (arredondamento bancário)
+	HalfEven
+	// Down descarta a fração (trunca em direção ao zero)
+	Down
+	// Up arredonda qualquer fração para longe do zero
+	Up
+)
+
+// exponents são as casas decimais de cada moeda suportada (ISO 4217)
+var exponents = map[string]int{
+	"BRL": 2, "USD": 2, "EUR": 2, "GBP": 2, "CAD": 2, "CHF": 2, "CNY": 2,
+	"ARS": 2, "MXN": 2, "COP": 2, "PEN": 2, "UYU": 2, "BOB": 2,
+	"CLP": 0, "PYG": 0, "JPY": 0, "KRW": 0,
+	"KWD": 3, "BHD": 3,
+}
+
+// Money é um valor em unidades menores da moeda. O valor zero (sem moeda) é
+// tratado como zero na moeda padrão.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// New cria um valor a partir das unidades menores (ex.: 199990 BRL = R$ 1.999,90)
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: NormalizeCurrency(currency)}
+}
+
+// Zero retorna o valor zero na moeda informada
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// NormalizeCurrency padroniza o código da moeda; vazio vira a moeda padrão
+func NormalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+// ValidCurrency indica se a moeda é suportada
+func ValidCurrency(currency string) bool {
+	_, ok := exponents[NormalizeCurrency(currency)]
+	return ok
+}
+
+// Exponent retorna as casas decimais da moeda; moedas desconhecidas usam 2
+func Exponent(currency string) int {
+	if exponent, ok := exponents[NormalizeCurrency(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Parse converte um decimal exato ("1999.90", "-0.5") para a moeda. Valores com
+// mais casas do que a moeda admite são recusados; use FromDecimal para arredondar.
+func Parse(value, currency string) (Money, error) {
+	return parse(value, currency, nil)
+}
+
+// FromDecimal converte um decimal, arredondando as casas excedentes pelo modo informado
+func FromDecimal(value, currency string, mode RoundingMode) (Money, error) {
+	return parse(value, currency, &mode)
+}
+
+// FromFloat converte um float64 recebido de fora (ex.: um campo double) usando a sua
+// menor representação decimal, e não a binária, arredondada pelo modo informado
+func FromFloat(value float64, currency string, mode RoundingMode) (Money, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Money{}, ErrInvalidAmount
+	}
+	return FromDecimal(strconv.FormatFloat(value, 'f', -1, 64), currency, mode)
+}
+
+func parse(value, currency string, mode *RoundingMode) (Money, error) {
+	currency = NormalizeCurrency(currency)
+	if !ValidCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	unsigned := strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+	whole, fraction, _ := strings.Cut(unsigned, ".")
+	if whole == "" && fraction == "" || !digits(whole) || !digits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+
+	exponent := Exponent(currency)
+	if len(fraction) > exponent && mode == nil {
+		return Money{}, fmt.Errorf("%w: %q tem mais de %d casas decimais", ErrInvalidAmount, value, exponent)
+	}
+	for len(fraction) < exponent {
+		fraction += "0"
+	}
+
+	n, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if negative {
+		n.Neg(n)
+	}
+	rounding := HalfUp
+	if mode != nil {
+		rounding = *mode
+	}
+	amount, err := divide(n, pow10(len(fraction)-exponent), rounding)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Add soma dois valores da mesma moeda
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.currency()}, nil
+}
+
+// Sub subtrai dois valores da mesma moeda
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.currency()}, nil
+}
+
+// Mul multiplica o valor por uma quantidade inteira
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount * quantity, Currency: m.currency()}
+}
+
+// Percent calcula o percentual rate do valor
+func (m Money) Percent(rate Rate, mode RoundingMode) (Money, error) {
+	amount, err := PercentOf(m.Amount, rate, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.currency()}, nil
+}
+
+// Cmp compara dois valores da mesma moeda: -1, 0 ou 1
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// IsZero indica valor zero
+func (m Money) IsZero() bool { return m.Amount == 0 }
+
+// IsPositive indica valor maior que zero
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+
+// Decimal formata o valor com as casas da moeda e ponto decimal (ex.: "1999.90")
+func (m Money) Decimal() string {
+	exponent := Exponent(m.currency())
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	s := new(big.Int).Abs(big.NewInt(amount)).String()
+	if exponent == 0 {
+		return sign + s
+	}
+	for len(s) <= exponent {
+		s = "0" + s
+	}
+	return sign + s[:len(s)-exponent] + "." + s[len(s)-exponent:]
+}
+
+// String formata o valor com a moeda (ex.: "BRL 1999.90")
+func (m Money) String() string {
+	return m.currency() + " " + m.Decimal()
+}
+
+// Float retorna o valor em unidades da moeda. Use apenas para exibição e métricas.
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(Exponent(m.currency()))
+}
+
+// UnmarshalJSON aceita o objeto {"amount": 199990, "currency": "BRL"} e, por
+// compatibilidade com eventos antigos, um número decimal em unidades da moeda padrão
+// (199.99), lido a partir do texto, sem passar por float64
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' {
+		if string(data) == "null" {
+			return nil
+		}
+		value, err := FromDecimal(string(data), DefaultCurrency, HalfUp)
+		if err != nil {
+			return err
+		}
+		*m = value
+		return nil
+	}
+
+	type plain Money
+	var value plain
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*m = New(value.Amount, value.Currency)
+	return nil
+}
+
+func (m Money) currency() string {
+	return NormalizeCurrency(m.Currency)
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency() != other.currency() {
+		return fmt.Errorf("%w: %s e %s", ErrCurrencyMismatch, m.currency(), other.currency())
+	}
+	return nil
+}
+
+// rateScale é a precisão dos percentuais: 4 casas decimais, como nas tabelas de alíquotas
+const rateScale = 10000
+
+// Rate é um percentual inteiro com 4 casas decimais, em centésimos de ponto-base:
+// 1 = 0,0001% e 180000 = 18%
+type Rate int64
+
+// PercentRate cria o percentual a partir de um valor inteiro (ex.: 18 para 18%)
+func PercentRate(percent int64) Rate {
+	return Rate(percent * rateScale)
+}
+
+// RateFromPercent converte um percentual que ainda chega como double (ex.: 20.5 para
+// 20,5%), arredondado para 4 casas decimais
+func RateFromPercent(percent float64) (Rate, error) {
+	scaled := math.Round(percent * rateScale)
+	if math.IsNaN(scaled) || math.IsInf(scaled, 0) || math.Abs(scaled) >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidRate, percent)
+	}
+	return Rate(scaled), nil
+}
+
+// Percent retorna o percentual como double, para exibição e respostas da API
+func (r Rate) Percent() float64 {
+	return float64(r) / rateScale
+}
+
+// PercentOf calcula o percentual rate de um valor em unidades menores, arredondado
+// pelo modo informado
+func PercentOf(amount int64, rate Rate, mode RoundingMode) (int64, error) {
+	return MulDiv(amount, int64(rate), 100*rateScale, mode)
+}
+
+// MulDiv calcula amount * numerator / denominator sem perda de precisão,
+// arredondando o resultado pelo modo informado
+func MulDiv(amount, numerator, denominator int64, mode RoundingMode) (int64, error) {
+	if denominator == 0 {
+		return 0, ErrDivisionByZero
+	}
+	n := new(big.Int).Mul(big.NewInt(amount), big.NewInt(numerator))
+	return divide(n, big.NewInt(denominator), mode)
+}
+
+// Allocate reparte amount na proporção dos pesos. As sobras do arredondamento vão
+// para o maior peso, para que a soma das partes seja exatamente amount.
+func Allocate(amount int64, weights []int64) []int64 {
+	parts := make([]int64, len(weights))
+	var total int64
+	largest := -1
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		total += weight
+		if largest < 0 || weight > weights[largest] {
+			largest = i
+		}
+	}
+	if total == 0 {
+		return parts
+	}
+
+	var allocated int64
+	for i, weight := range weights {
+		if weight > 0 {
+			// weight <= total: a parte nunca passa de amount nem estoura o int64
+			parts[i], _ = MulDiv(amount, weight, total, Down)
+			allocated += parts[i]
+		}
+	}
+	parts[largest] += amount - allocated
+	return parts
+}
+
+// divide calcula n / d arredondando pelo modo informado
+func divide(n, d *big.Int, mode RoundingMode) (int64, error) {
+	negative := n.Sign()*d.Sign() < 0
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(n), new(big.Int).Abs(d), new(big.Int))
+
+	if r.Sign() != 0 {
+		twice := new(big.Int).Lsh(r, 1)
+		half := twice.Cmp(new(big.Int).Abs(d))
+		var up bool
+		switch mode {
+		case HalfUp:
+			up = half >= 0
+		case HalfEven:
+			up = half > 0 || half == 0 && q.Bit(0) == 1
+		case Up:
+			up = true
+		case Down:
+			up = false
+		}
+		if up {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	if negative {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("%w: valor fora do limite", ErrInvalidAmount)
+	}
+	return q.Int64(), nil
+}
+
+func pow10(n int) *big.Int {
+	if n <= 0 {
+		return big.NewInt(1)
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,170 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     int64
+	}{
+		{"1999.90", "BRL", 199990},
+		{"1999.9", "brl", 199990},
+		{"-0.05", "", -5},
+		{"12", "JPY", 12},
+		{"1.234", "KWD", 1234},
+		{".5", "USD", 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			m, err := Parse(tt.value, tt.currency)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.Amount)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, value := range []string{"", ".", "1,50", "abc", "1.999", "--1"} {
+		_, err := Parse(value, "BRL")
+		assert.ErrorIs(t, err, ErrInvalidAmount, value)
+	}
+
+	_, err := Parse("10", "XYZ")
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestFromDecimal_RoundingModes(t *testing.T) {
+	tests := []struct {
+		value string
+		mode  RoundingMode
+		want  int64
+	}{
+		{"0.125", HalfUp, 13},
+		{"0.125", HalfEven, 12},
+		{"0.135", HalfEven, 14},
+		{"0.129", Down, 12},
+		{"0.121", Up, 13},
+		{"-0.125", HalfUp, -13},
+		{"-0.125", HalfEven, -12},
+	}
+
+	for _, tt := range tests {
+		m, err := FromDecimal(tt.value, "BRL", tt.mode)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, m.Amount, tt.value)
+	}
+}
+
+func TestFromFloat_UsesShortestDecimal(t *testing.T) {
+	// 1.005 em binário é 1.00499999999999989..., mas o valor informado era 1.005
+	m, err := FromFloat(1.005, "BRL", HalfUp)
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), m.Amount)
+
+	m, err = FromFloat(0.1+0.2, "BRL", HalfUp)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), m.Amount)
+}
+
+func TestPercentOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rate   Rate
+		mode   RoundingMode
+		want   int64
+	}{
+		{"meio centavo para cima", 1003, PercentRate(18), HalfUp, 181}, // 180,54
+		{"meio exato para cima", 10, PercentRate(5), HalfUp, 1},        // 0,5
+		{"meio exato para o par", 10, PercentRate(5), HalfEven, 0},     // 0,5
+		{"alíquota fracionária", 10000, 205000, Down, 2050},            // 20,5%
+		{"quatro casas decimais", 1000000, 36525, HalfUp, 36525},       // 3,6525%
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PercentOf(tt.amount, tt.rate, tt.mode)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPercentOf_Overflow(t *testing.T) {
+	_, err := PercentOf(math.MaxInt64, PercentRate(200), HalfUp)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestMulDiv_DivisionByZero(t *testing.T) {
+	_, err := MulDiv(100, 1, 0, HalfUp)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+}
+
+func TestRateFromPercent(t *testing.T) {
+	rate, err := RateFromPercent(20.5)
+	require.NoError(t, err)
+	assert.Equal(t, Rate(205000), rate)
+	assert.Equal(t, 20.5, rate.Percent())
+
+	rate, err = RateFromPercent(0.1 + 0.2) // 0,30000000000000004
+	require.NoError(t, err)
+	assert.Equal(t, Rate(3000), rate)
+
+	for _, invalid := range []float64{math.NaN(), math.Inf(1), 1e300} {
+		_, err := RateFromPercent(invalid)
+		assert.ErrorIs(t, err, ErrInvalidRate)
+	}
+}
+
+func TestAllocate_SumsExactly(t *testing.T) {
+	parts := Allocate(1000, []int64{1, 1, 1})
+	assert.Equal(t, []int64{334, 333, 333}, parts)
+
+	parts = Allocate(500, []int64{0, 300, 700})
+	assert.Equal(t, []int64{0, 150, 350}, parts)
+
+	assert.Equal(t, []int64{0, 0}, Allocate(100, []int64{0, 0}))
+}
+
+func TestArithmetic(t *testing.T) {
+	a := New(1050, "BRL")
+	sum, err := a.Add(New(250, ""))
+	require.NoError(t, err)
+	assert.Equal(t, New(1300, "BRL"), sum)
+
+	_, err = a.Sub(New(100, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	assert.Equal(t, int64(3150), a.Mul(3).Amount)
+	percent, err := a.Percent(PercentRate(18), HalfUp)
+	require.NoError(t, err)
+	assert.Equal(t, New(189, "BRL"), percent)
+}
+
+func TestDecimalAndString(t *testing.T) {
+	assert.Equal(t, "1999.90", New(199990, "BRL").Decimal())
+	assert.Equal(t, "-0.05", New(-5, "BRL").Decimal())
+	assert.Equal(t, "1500", New(1500, "JPY").Decimal())
+	assert.Equal(t, "USD 0.07", New(7, "usd").String())
+	assert.Equal(t, 1999.9, New(199990, "BRL").Float())
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var event struct {
+		Total  Money `json:"total"`
+		Legacy Money `json:"legacy"`
+	}
+	err := json.Unmarshal([]byte(`{"total": {"amount": 199990, "currency": "usd"}, "legacy": 179.91}`), &event)
+
+	require.NoError(t, err)
+	assert.Equal(t, New(199990, "USD"), event.Total)
+	assert.Equal(t, New(17991, "BRL"), event.Legacy)
+}
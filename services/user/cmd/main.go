@@ -1,22 +1,129 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"google.golang.org/grpc"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/config"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/metrics"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/telemetry"
+	grpcServer "github.com/seu-usuario/go-microservices-architecture/services/user/internal/transport/grpc"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/proto"
 )
 
 func main() {
-	fmt.Println("🧩 User Service rodando na porta 50051...")
+	cfg := config.Load()
+
+	// 📊 Inicializar métricas Prometheus
+	log.Println("📊 Inicializando métricas Prometheus...")
+	metrics.Init()
 
-	lis, err := net.Listen("tcp", ":50051")
+	// 🔍 Inicializar OpenTelemetry Tracing
+	log.Println("🔍 Inicializando OpenTelemetry Tracing...")
+	ctx := context.Background()
+	shutdown := telemetry.InitTracer("user-service")
+	defer shutdown(ctx)
+
+	// 🔐 Gerenciador de tokens JWT
+	tokens, err := auth.NewTokenManager(cfg.JWTSecret, cfg.JWTIssuer)
 	if err != nil {
-		panic(err)
+		log.Fatalf("❌ Erro ao configurar autenticação: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	if err := grpcServer.Serve(lis); err != nil {
-		panic(err)
+	// 🧩 Conectar ao banco de dados
+	log.Println("🔗 Conectando ao banco de dados...")
+	db := config.ConnectDatabase(cfg)
+
+	// ⚙️ Executar migração automática
+	if err := config.AutoMigrate(db); err != nil {
+		log.Fatalf("❌ Erro ao executar migração: %v", err)
 	}
+
+	// 🏗️ Inicializar dependências
+	log.Println("🏗️  Inicializando dependências...")
+	userRepo := repository.NewUserRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	accountRepo := repository.NewServiceAccountRepository(db)
+	recoveryRepo := repository.NewRecoveryCodeRepository(db)
+
+	userService := service.NewUserService(userRepo, roleRepo, accountRepo, recoveryRepo, tokens, cfg.JWTExpiration, cfg.MFAIssuer)
+
+	// 🛡️ Sincronizar papéis padrão, contas de serviço e administrador inicial
+	if err := userService.SeedAccessControl(cfg.ServiceAccounts); err != nil {
+		log.Fatalf("❌ Erro ao sincronizar controle de acesso: %v", err)
+	}
+	if cfg.AdminEmail != "" {
+		if err := userService.EnsureAdmin("Administrador", cfg.AdminEmail, cfg.AdminPassword); err != nil {
+			log.Printf("⚠️ Erro ao garantir administrador inicial: %v", err)
+		}
+	}
+
+	// 🔗 Conexões com os serviços envolvidos nas solicitações LGPD
+	selfCreds := clients.NewSelfCredentials(tokens, "user-service", auth.PermPrivacy)
+	orderConn, err := clients.Dial(cfg.OrderServiceURL, selfCreds)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar conexão com Order Service: %v", err)
+	}
+	defer orderConn.Close()
+	paymentConn, err := clients.Dial(cfg.PaymentServiceURL, selfCreds)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar conexão com Payment Service: %v", err)
+	}
+	defer paymentConn.Close()
+	notificationConn, err := clients.Dial(cfg.NotificationServiceURL, selfCreds)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar conexão com Notification Service: %v", err)
+	}
+	defer notificationConn.Close()
+
+	privacyService := service.NewPrivacyService(
+		userRepo,
+		recoveryRepo,
+		repository.NewPrivacyRequestRepository(db),
+		clients.NewOrderClient(orderConn),
+		clients.NewPaymentClient(paymentConn),
+		clients.NewNotificationClient(notificationConn),
+	)
+
+	userGRPCServer := grpcServer.NewUserGRPCServer(userService, privacyService)
+
+	// 🚀 Configurar servidor gRPC
+	lis, err := net.Listen("tcp", ":"+cfg.ServicePort)
+	if err != nil {
+		log.Fatalf("❌ Erro ao iniciar listener: %v", err)
+	}
+
+	// 📡 Criar servidor gRPC com autorização por método
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens, grpcServer.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens, grpcServer.MethodPermissions)),
+	)
+	proto.RegisterUserServiceServer(s, userGRPCServer)
+
+	// 🎯 Iniciar servidor em goroutine
+	go func() {
+		log.Printf("🧩 User Service rodando em gRPC :%s", cfg.ServicePort)
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("❌ Erro ao servir gRPC: %v", err)
+		}
+	}()
+
+	// 🛡️ Graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("🛑 Desligando UserService...")
+	s.GracefulStop()
+	log.Println("✅ UserService desligado com sucesso")
 }
@@ -0,0 +1,18 @@
+package clients
+
+import (
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Dial abre uma conexão (não bloqueante) com outro serviço anexando as credenciais informadas
+func Dial(addr string, creds credentials.PerRPCCredentials) (*grpc.ClientConn, error) {
+	log.Printf("🔌 Configurando conexão com %s...", addr)
+	return grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(creds),
+	)
+}
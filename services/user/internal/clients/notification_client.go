@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// NotificationRecord representa uma notificação retornada pelo notification-service
+type NotificationRecord struct {
+	ID        uint32 `json:"id"`
+	PaymentID uint32 `json:"payment_id"`
+	OrderID   uint32 `json:"order_id"`
+	Message   string `json:"message"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+}
+
+// NotificationsByOrdersRequest seleciona notificações pelos IDs dos pedidos
+type NotificationsByOrdersRequest struct {
+	OrderIDs []uint32 `json:"order_ids"`
+}
+
+// NotificationsResponse representa uma lista de notificações
+type NotificationsResponse struct {
+	Notifications []*NotificationRecord `json:"notifications"`
+}
+
+// RedactNotificationsResponse informa quantas notificações foram anonimizadas
+type RedactNotificationsResponse struct {
+	Affected uint32 `json:"affected"`
+}
+
+// NotificationClient expõe as operações LGPD do notification-service
+type NotificationClient interface {
+	ExportNotifications(ctx context.Context, orderIDs []uint32) ([]*NotificationRecord, error)
+	RedactNotifications(ctx context.Context, orderIDs []uint32) (uint32, error)
+}
+
+// grpcNotificationClient implementa NotificationClient usando gRPC
+type grpcNotificationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewNotificationClient cria um cliente para o notification-service a partir de uma conexão existente
+func NewNotificationClient(cc grpc.ClientConnInterface) NotificationClient {
+	return &grpcNotificationClient{cc: cc}
+}
+
+// ExportNotifications busca as notificações dos pedidos do titular
+func (c *grpcNotificationClient) ExportNotifications(ctx context.Context, orderIDs []uint32) ([]*NotificationRecord, error) {
+	out := new(NotificationsResponse)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/ExportNotifications", &NotificationsByOrdersRequest{OrderIDs: orderIDs}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Notifications, nil
+}
+
+// RedactNotifications anonimiza as mensagens dos pedidos do titular
+func (c *grpcNotificationClient) RedactNotifications(ctx context.Context, orderIDs []uint32) (uint32, error) {
+	out := new(RedactNotificationsResponse)
+	err := c.cc.Invoke(ctx, "/notification.NotificationService/RedactNotifications", &NotificationsByOrdersRequest{OrderIDs: orderIDs}, out)
+	if err != nil {
+		return 0, err
+	}
+	return out.Affected, nil
+}
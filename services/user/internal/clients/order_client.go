@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// OrderRecord representa um pedido retornado pelo order-service
+type OrderRecord struct {
+	ID        uint32  `json:"id"`
+	Customer  string  `json:"customer"`
+	ProductID uint32  `json:"product_id"`
+	Quantity  int32   `json:"quantity"`
+	Price     float64 `json:"price"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// CustomerDataRequest identifica o titular pelos valores gravados em Customer
+type CustomerDataRequest struct {
+	Customers []string `json:"customers"`
+}
+
+// CustomerOrdersResponse representa os pedidos do titular
+type CustomerOrdersResponse struct {
+	Orders []*OrderRecord `json:"orders"`
+}
+
+// AnonymizeCustomerRequest representa a anonimização dos pedidos do titular
+type AnonymizeCustomerRequest struct {
+	Customers []string `json:"customers"`
+	Pseudonym string   `json:"pseudonym"`
+}
+
+// AnonymizeCustomerResponse informa os pedidos anonimizados
+type AnonymizeCustomerResponse struct {
+	Affected uint32   `json:"affected"`
+	OrderIDs []uint32 `json:"order_ids"`
+}
+
+// OrderClient expõe as operações LGPD do order-service
+type OrderClient interface {
+	ExportCustomerData(ctx context.Context, customers []string) ([]*OrderRecord, error)
+	AnonymizeCustomer(ctx context.Context, customers []string, pseudonym string) (*AnonymizeCustomerResponse, error)
+}
+
+// grpcOrderClient implementa OrderClient usando gRPC
+type grpcOrderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewOrderClient cria um cliente para o order-service a partir de uma conexão existente
+func NewOrderClient(cc grpc.ClientConnInterface) OrderClient {
+	return &grpcOrderClient{cc: cc}
+}
+
+// ExportCustomerData busca os pedidos do titular
+func (c *grpcOrderClient) ExportCustomerData(ctx context.Context, customers []string) ([]*OrderRecord, error) {
+	out := new(CustomerOrdersResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/ExportCustomerData", &CustomerDataRequest{Customers: customers}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Orders, nil
+}
+
+// AnonymizeCustomer substitui o cliente dos pedidos do titular por um pseudônimo
+func (c *grpcOrderClient) AnonymizeCustomer(ctx context.Context, customers []string, pseudonym string) (*AnonymizeCustomerResponse, error) {
+	out := new(AnonymizeCustomerResponse)
+	err := c.cc.Invoke(ctx, "/order.OrderService/AnonymizeCustomer", &AnonymizeCustomerRequest{
+		Customers: customers,
+		Pseudonym: pseudonym,
+	}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
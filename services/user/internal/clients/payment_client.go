@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// PaymentRecord representa um pagamento retornado pelo payment-service. Customer e
+// CardFingerprint são os dados do titular usados na análise antifraude.
+type PaymentRecord struct {
+	ID              uint32  `json:"id"`
+	OrderID         uint32  `json:"order_id"`
+	Status          string  `json:"status"`
+	Amount          float64 `json:"amount"`
+	CreatedAt       string  `json:"created_at"`
+	Customer        string  `json:"customer,omitempty"`
+	CardFingerprint string  `json:"card_fingerprint,omitempty"`
+}
+
+// PaymentsByOrdersRequest seleciona pagamentos pelos IDs dos pedidos
+type PaymentsByOrdersRequest struct {
+	OrderIDs []uint32 `json:"order_ids"`
+}
+
+// PaymentsResponse representa uma lista de pagamentos
+type PaymentsResponse struct {
+	Payments []*PaymentRecord `json:"payments"`
+}
+
+// AnonymizePaymentsRequest representa a anonimização dos pagamentos dos pedidos do titular
+type AnonymizePaymentsRequest struct {
+	OrderIDs  []uint32 `json:"order_ids"`
+	Pseudonym string   `json:"pseudonym"`
+}
+
+// AnonymizePaymentsResponse informa os pagamentos anonimizados
+type AnonymizePaymentsResponse struct {
+	Affected   uint32   `json:"affected"`
+	PaymentIDs []uint32 `json:"payment_ids"`
+}
+
+// PaymentClient expõe as operações LGPD do payment-service
+type PaymentClient interface {
+	ExportPaymentData(ctx context.Context, orderIDs []uint32) ([]*PaymentRecord, error)
+	AnonymizePayments(ctx context.Context, orderIDs []uint32, pseudonym string) (*AnonymizePaymentsResponse, error)
+}
+
+// grpcPaymentClient implementa PaymentClient usando gRPC
+type grpcPaymentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewPaymentClient cria um cliente para o payment-service a partir de uma conexão existente
+func NewPaymentClient(cc grpc.ClientConnInterface) PaymentClient {
+	return &grpcPaymentClient{cc: cc}
+}
+
+// ExportPaymentData busca os pagamentos dos pedidos do titular
+func (c *grpcPaymentClient) ExportPaymentData(ctx context.Context, orderIDs []uint32) ([]*PaymentRecord, error) {
+	out := new(PaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/ExportPaymentData", &PaymentsByOrdersRequest{OrderIDs: orderIDs}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Payments, nil
+}
+
+// AnonymizePayments substitui o cliente dos pagamentos dos pedidos do titular por um pseudônimo
+func (c *grpcPaymentClient) AnonymizePayments(ctx context.Context, orderIDs []uint32, pseudonym string) (*AnonymizePaymentsResponse, error) {
+	out := new(AnonymizePaymentsResponse)
+	err := c.cc.Invoke(ctx, "/payment.PaymentService/AnonymizePayments", &AnonymizePaymentsRequest{
+		OrderIDs:  orderIDs,
+		Pseudonym: pseudonym,
+	}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	ServicePort     string
+	DBHost          string
+	DBPort          string
+	DBUser          string
+	DBPass          string
+	DBName          string
+	JWTSecret       string
+	JWTIssuer       string
+	JWTExpiration   time.Duration
+	ServiceAccounts map[string]string
+	AdminEmail      string
+	AdminPassword   string
+	MFAIssuer       string
+
+	// Serviços consultados nas solicitações LGPD
+	OrderServiceURL        string
+	PaymentServiceURL      string
+	NotificationServiceURL string
+}
+
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg := &Config{
+		ServicePort:     getEnv("SERVICE_PORT", "50051"),
+		DBHost:          getEnv("DB_HOST", "mysql"),
+		DBPort:          getEnv("DB_PORT", "3306"),
+		DBUser:          getEnv("DB_USER", "root"),
+		DBPass:          getEnv("DB_PASSWORD", "secret"),
+		DBName:          getEnv("DB_NAME", "user_service"),
+		JWTSecret:       getEnv("JWT_SECRET", ""),
+		JWTIssuer:       getEnv("JWT_ISSUER", "user-service"),
+		JWTExpiration:   getEnvAsDuration("JWT_EXPIRATION", 24*time.Hour),
+		ServiceAccounts: parseServiceAccounts(getEnv("SERVICE_ACCOUNTS", "")),
+		AdminEmail:      getEnv("ADMIN_EMAIL", ""),
+		AdminPassword:   getEnv("ADMIN_PASSWORD", ""),
+		MFAIssuer:       getEnv("MFA_ISSUER", "Go Microservices"),
+
+		OrderServiceURL:        getEnv("ORDER_SERVICE_URL", "localhost:50052"),
+		PaymentServiceURL:      getEnv("PAYMENT_SERVICE_URL", "localhost:50053"),
+		NotificationServiceURL: getEnv("NOTIFICATION_SERVICE_URL", "localhost:50055"),
+	}
+
+	log.Printf("Config carregada: port=%s db=%s@%s:%s/%s contas de serviço=%d",
+		cfg.ServicePort, cfg.DBUser, cfg.DBHost, cfg.DBPort, cfg.DBName, len(cfg.ServiceAccounts))
+	return cfg
+}
+
+func getEnv(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return fallback
+}
+
+// GetEnv retorna uma variável de ambiente ou um valor padrão (exportada)
+func GetEnv(key, fallback string) string {
+	return getEnv(key, fallback)
+}
+
+// getEnvAsDuration lê durações no formato do Go (ex: "24h", "15m")
+func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+		log.Printf("⚠️ Valor inválido para %s: %q, usando %s", key, value, fallback)
+	}
+	return fallback
+}
+
+// parseServiceAccounts lê contas de serviço no formato "bff-graphql=segredo,outro=segredo2"
+func parseServiceAccounts(value string) map[string]string {
+	accounts := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		name, secret, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" || secret == "" {
+			continue
+		}
+		accounts[name] = secret
+	}
+	return accounts
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Tipos de solicitação do titular (LGPD art. 18)
+const (
+	PrivacyRequestExport = "EXPORT"
+	PrivacyRequestErase  = "ERASE"
+)
+
+// Status de processamento de uma solicitação
+const (
+	PrivacyStatusProcessing = "PROCESSING"
+	PrivacyStatusCompleted  = "COMPLETED"
+	PrivacyStatusFailed     = "FAILED"
+)
+
+// PrivacyRequest registra cada solicitação de exportação ou eliminação de dados
+// para fins de auditoria, com o resultado de cada serviço envolvido
+type PrivacyRequest struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `json:"user_id" gorm:"not null;index"`
+	Type        string     `json:"type" gorm:"size:16;not null"`
+	Status      string     `json:"status" gorm:"size:16;not null"`
+	RequestedBy string     `json:"requested_by" gorm:"size:128;not null"`
+	Reason      string     `json:"reason"`
+	Details     string     `json:"details" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (PrivacyRequest) TableName() string {
+	return "privacy_requests"
+}
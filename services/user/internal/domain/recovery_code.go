@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// RecoveryCode é um código de recuperação de uso único para quando o usuário
+// não tem acesso ao aplicativo autenticador. Apenas o hash SHA-256 é armazenado.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null;index"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,54 @@
+package domain
+
+// Papéis padrão criados na inicialização do serviço
+const (
+	RoleAdmin   = "ADMIN"
+	RoleUser    = "USER"
+	RoleService = "SERVICE"
+)
+
+// Role agrupa permissões atribuídas a usuários e contas de serviço
+type Role struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Name        string       `json:"name" gorm:"size:64;uniqueIndex;not null"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions;"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Role) TableName() string {
+	return "roles"
+}
+
+// Permission representa uma permissão no formato "recurso:ação"
+type Permission struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name" gorm:"size:64;uniqueIndex;not null"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+func roleNames(roles []Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+func permissionNames(roles []Role) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			if !seen[permission.Name] {
+				seen[permission.Name] = true
+				names = append(names, permission.Name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// ServiceAccount representa a identidade de outro serviço (ex: bff-graphql)
+// usada nas chamadas serviço-a-serviço
+type ServiceAccount struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Name       string    `json:"name" gorm:"size:128;uniqueIndex;not null"`
+	SecretHash string    `json:"-" gorm:"not null"`
+	Active     bool      `json:"active" gorm:"not null;default:true"`
+	Roles      []Role    `json:"roles" gorm:"many2many:service_account_roles;"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (ServiceAccount) TableName() string {
+	return "service_accounts"
+}
+
+// RoleNames retorna os nomes dos papéis da conta de serviço
+func (a *ServiceAccount) RoleNames() []string {
+	return roleNames(a.Roles)
+}
+
+// PermissionNames retorna as permissões concedidas pelos papéis da conta, sem repetição
+func (a *ServiceAccount) PermissionNames() []string {
+	return permissionNames(a.Roles)
+}
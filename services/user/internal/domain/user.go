@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// User representa um usuário da plataforma
+type User struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Name         string    `json:"name" gorm:"not null"`
+	Email        string    `json:"email" gorm:"size:255;uniqueIndex;not null"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	Roles        []Role    `json:"roles" gorm:"many2many:user_roles;"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Segundo fator (TOTP)
+	MFAEnabled       bool       `json:"mfa_enabled" gorm:"not null;default:false"`
+	MFASecret        string     `json:"-"`
+	MFAPendingSecret string     `json:"-"`
+	MFALastStep      int64      `json:"-"`
+	MFAEnabledAt     *time.Time `json:"mfa_enabled_at"`
+	MFAFailures      int        `json:"-" gorm:"not null;default:0"`
+	MFALockedUntil   *time.Time `json:"-"`
+
+	// ErasedAt indica que os dados pessoais foram anonimizados (LGPD)
+	ErasedAt *time.Time `json:"erased_at"`
+}
+
+// TableName retorna o nome da tabela no banco de dados
+func (User) TableName() string {
+	return "users"
+}
+
+// RoleNames retorna os nomes dos papéis do usuário
+func (u *User) RoleNames() []string {
+	return roleNames(u.Roles)
+}
+
+// PermissionNames retorna as permissões concedidas pelos papéis do usuário, sem repetição
+func (u *User) PermissionNames() []string {
+	return permissionNames(u.Roles)
+}
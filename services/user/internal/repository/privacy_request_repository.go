@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"gorm.io/gorm"
+)
+
+// PrivacyRequestRepository define a interface para a trilha de auditoria de solicitações LGPD
+type PrivacyRequestRepository interface {
+	Create(request *domain.PrivacyRequest) error
+	Update(request *domain.PrivacyRequest) error
+	ListByUser(userID uint) ([]domain.PrivacyRequest, error)
+}
+
+// privacyRequestRepository implementa PrivacyRequestRepository
+type privacyRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewPrivacyRequestRepository cria uma nova instância do repositório de solicitações LGPD
+func NewPrivacyRequestRepository(db *gorm.DB) PrivacyRequestRepository {
+	return &privacyRequestRepository{
+		db: db,
+	}
+}
+
+// Create registra uma nova solicitação
+func (r *privacyRequestRepository) Create(request *domain.PrivacyRequest) error {
+	return r.db.Create(request).Error
+}
+
+// Update salva o andamento da solicitação
+func (r *privacyRequestRepository) Update(request *domain.PrivacyRequest) error {
+	return r.db.Save(request).Error
+}
+
+// ListByUser retorna as solicitações de um titular, da mais recente para a mais antiga
+func (r *privacyRequestRepository) ListByUser(userID uint) ([]domain.PrivacyRequest, error) {
+	var requests []domain.PrivacyRequest
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
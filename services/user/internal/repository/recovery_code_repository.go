@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepository define a interface para persistência dos códigos de recuperação de MFA
+type RecoveryCodeRepository interface {
+	Replace(userID uint, hashes []string) error
+	Consume(userID uint, hash string) (bool, error)
+	CountUnused(userID uint) (int64, error)
+	DeleteByUser(userID uint) error
+}
+
+// recoveryCodeRepository implementa RecoveryCodeRepository
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository cria uma nova instância do repositório de códigos de recuperação
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db: db,
+	}
+}
+
+// Replace invalida os códigos anteriores do usuário e grava o novo conjunto
+func (r *recoveryCodeRepository) Replace(userID uint, hashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]domain.RecoveryCode, 0, len(hashes))
+		for _, hash := range hashes {
+			codes = append(codes, domain.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Consume marca o código como usado de forma atômica; retorna false se não existir ou já tiver sido usado
+func (r *recoveryCodeRepository) Consume(userID uint, hash string) (bool, error) {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountUnused retorna quantos códigos ainda podem ser usados
+func (r *recoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByUser remove todos os códigos do usuário
+func (r *recoveryCodeRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+}
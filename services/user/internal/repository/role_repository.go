@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"gorm.io/gorm"
+)
+
+// RoleRepository define a interface para operações de persistência de papéis e permissões
+type RoleRepository interface {
+	List() ([]domain.Role, error)
+	GetByName(name string) (*domain.Role, error)
+	Ensure(name, description string, permissions []string) (*domain.Role, error)
+}
+
+// roleRepository implementa RoleRepository
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository cria uma nova instância do repositório de papéis
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+// List retorna todos os papéis com suas permissões
+func (r *roleRepository) List() ([]domain.Role, error) {
+	var roles []domain.Role
+	if err := r.db.Preload("Permissions").Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// GetByName retorna um papel pelo nome
+func (r *roleRepository) GetByName(name string) (*domain.Role, error) {
+	var role domain.Role
+	if err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// Ensure cria o papel caso não exista e garante que possua exatamente as permissões informadas
+func (r *roleRepository) Ensure(name, description string, permissions []string) (*domain.Role, error) {
+	var role domain.Role
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("name = ?", name).First(&role).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			role = domain.Role{Name: name, Description: description}
+			err = tx.Create(&role).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		perms := make([]domain.Permission, 0, len(permissions))
+		for _, permName := range permissions {
+			perm := domain.Permission{Name: permName}
+			if err := tx.Where(domain.Permission{Name: permName}).FirstOrCreate(&perm).Error; err != nil {
+				return err
+			}
+			perms = append(perms, perm)
+		}
+
+		if err := tx.Model(&role).Association("Permissions").Replace(perms); err != nil {
+			return err
+		}
+		role.Permissions = perms
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
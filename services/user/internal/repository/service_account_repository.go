@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ServiceAccountRepository define a interface para operações de persistência de contas de serviço
+type ServiceAccountRepository interface {
+	GetByName(name string) (*domain.ServiceAccount, error)
+	Upsert(account *domain.ServiceAccount) error
+}
+
+// serviceAccountRepository implementa ServiceAccountRepository
+type serviceAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceAccountRepository cria uma nova instância do repositório de contas de serviço
+func NewServiceAccountRepository(db *gorm.DB) ServiceAccountRepository {
+	return &serviceAccountRepository{
+		db: db,
+	}
+}
+
+// GetByName retorna uma conta de serviço pelo nome com papéis e permissões carregados
+func (r *serviceAccountRepository) GetByName(name string) (*domain.ServiceAccount, error) {
+	var account domain.ServiceAccount
+	if err := r.db.Preload("Roles.Permissions").Where("name = ?", name).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Upsert cria ou atualiza a conta de serviço (segredo, status e papéis) pelo nome
+func (r *serviceAccountRepository) Upsert(account *domain.ServiceAccount) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.ServiceAccount
+		err := tx.Where("name = ?", account.Name).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(account).Error
+		case err != nil:
+			return err
+		}
+
+		account.ID = existing.ID
+		if err := tx.Model(&existing).Updates(map[string]interface{}{
+			"secret_hash": account.SecretHash,
+			"active":      account.Active,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&existing).Association("Roles").Replace(account.Roles)
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"gorm.io/gorm"
+)
+
+// UserRepository define a interface para operações de persistência de usuários
+type UserRepository interface {
+	Create(user *domain.User) error
+	List() ([]domain.User, error)
+	GetByID(id uint) (*domain.User, error)
+	GetByEmail(email string) (*domain.User, error)
+	Update(user *domain.User) error
+	AddRole(user *domain.User, role *domain.Role) error
+	RemoveRole(user *domain.User, role *domain.Role) error
+}
+
+// userRepository implementa UserRepository
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository cria uma nova instância do repositório de usuários
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+// Create cria um novo usuário junto com a associação de papéis
+func (r *userRepository) Create(user *domain.User) error {
+	return r.db.Create(user).Error
+}
+
+// List retorna todos os usuários com seus papéis
+func (r *userRepository) List() ([]domain.User, error) {
+	var users []domain.User
+	if err := r.db.Preload("Roles").Order("created_at DESC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetByID retorna um usuário com papéis e permissões carregados
+func (r *userRepository) GetByID(id uint) (*domain.User, error) {
+	var user domain.User
+	if err := r.db.Preload("Roles.Permissions").First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByEmail retorna um usuário pelo e-mail com papéis e permissões carregados
+func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
+	var user domain.User
+	if err := r.db.Preload("Roles.Permissions").Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Update salva os campos do usuário sem alterar as associações de papéis
+func (r *userRepository) Update(user *domain.User) error {
+	return r.db.Omit("Roles").Save(user).Error
+}
+
+// AddRole associa um papel ao usuário
+func (r *userRepository) AddRole(user *domain.User, role *domain.Role) error {
+	return r.db.Model(user).Association("Roles").Append(role)
+}
+
+// RemoveRole remove a associação de um papel do usuário
+func (r *userRepository) RemoveRole(user *domain.User, role *domain.Role) error {
+	return r.db.Model(user).Association("Roles").Delete(role)
+}
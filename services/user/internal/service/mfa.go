@@ -0,0 +1,322 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/totp"
+)
+
+const (
+	// Validade do token de desafio entre a senha e o segundo fator
+	mfaChallengeTTL = 5 * time.Minute
+	// Falhas consecutivas de segundo fator antes do bloqueio temporário
+	mfaMaxFailures = 5
+	mfaLockout     = 15 * time.Minute
+	// Quantidade de códigos de recuperação gerados por vez
+	recoveryCodeCount = 10
+)
+
+var (
+	ErrMFANotEnabled     = errors.New("MFA não está habilitado")
+	ErrMFAAlreadyEnabled = errors.New("MFA já está habilitado")
+	ErrMFANotEnrolled    = errors.New("nenhum cadastro de MFA pendente")
+	ErrInvalidMFACode    = errors.New("código de verificação inválido")
+	ErrMFALocked         = errors.New("muitas tentativas inválidas, tente novamente mais tarde")
+	ErrInvalidChallenge  = errors.New("desafio de MFA inválido ou expirado")
+)
+
+// MFAEnrollment contém os dados exibidos ao usuário para cadastrar o autenticador
+type MFAEnrollment struct {
+	Secret     string
+	OTPAuthURI string
+}
+
+var totpOptions = totp.DefaultOptions()
+
+// EnrollMFA gera um novo segredo TOTP pendente; o MFA só é ativado após ConfirmMFA
+func (s *userService) EnrollMFA(userID uint) (*MFAEnrollment, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.New("falha ao gerar segredo")
+	}
+
+	user.MFAPendingSecret = secret
+	if err := s.userRepo.Update(user); err != nil {
+		log.Printf("Erro ao salvar cadastro de MFA do usuário %d: %v", user.ID, err)
+		return nil, errors.New("falha ao iniciar cadastro de MFA")
+	}
+
+	log.Printf("🔐 Cadastro de MFA iniciado para o usuário %d", user.ID)
+	return &MFAEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totpOptions.URI(s.mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA valida o primeiro código do autenticador, ativa o MFA e retorna os códigos de recuperação
+func (s *userService) ConfirmMFA(userID uint, code string) ([]string, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFAPendingSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	key, err := totp.DecodeSecret(user.MFAPendingSecret)
+	if err != nil {
+		return nil, err
+	}
+	step, err := totpOptions.Validate(key, code, time.Now(), 0)
+	if err != nil {
+		return nil, ErrInvalidMFACode
+	}
+
+	now := time.Now()
+	user.MFAEnabled = true
+	user.MFASecret = user.MFAPendingSecret
+	user.MFAPendingSecret = ""
+	user.MFALastStep = step
+	user.MFAEnabledAt = &now
+	user.MFAFailures = 0
+	user.MFALockedUntil = nil
+	if err := s.userRepo.Update(user); err != nil {
+		log.Printf("Erro ao ativar MFA do usuário %d: %v", user.ID, err)
+		return nil, errors.New("falha ao ativar MFA")
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ MFA ativado para o usuário %d", user.ID)
+	return codes, nil
+}
+
+// VerifyMFA troca o token de desafio e um código TOTP (ou de recuperação) pelo token de acesso
+func (s *userService) VerifyMFA(challengeToken, code, recoveryCode string) (*LoginResult, error) {
+	claims, err := s.tokens.Verify(challengeToken)
+	if err != nil || claims.Kind != auth.KindMFAChallenge {
+		return nil, ErrInvalidChallenge
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	user, err := s.GetUserByID(uint(userID))
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+
+	if err := s.checkSecondFactor(user, code, recoveryCode); err != nil {
+		return nil, err
+	}
+
+	token, err := s.issueAccessToken(user, auth.AMRPassword, auth.AMROTP)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔑 Login com MFA realizado: %s", user.Email)
+	return &LoginResult{User: user, AccessToken: token}, nil
+}
+
+// DisableMFA desativa o segundo fator exigindo novamente senha e um código válido
+func (s *userService) DisableMFA(userID uint, password, code string) error {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+	if err := s.checkPassword(user, password); err != nil {
+		return err
+	}
+	if err := s.checkSecondFactor(user, code, code); err != nil {
+		return err
+	}
+
+	user.MFAEnabled = false
+	user.MFASecret = ""
+	user.MFAPendingSecret = ""
+	user.MFALastStep = 0
+	user.MFAEnabledAt = nil
+	if err := s.userRepo.Update(user); err != nil {
+		log.Printf("Erro ao desativar MFA do usuário %d: %v", user.ID, err)
+		return errors.New("falha ao desativar MFA")
+	}
+	if err := s.recoveryRepo.DeleteByUser(user.ID); err != nil {
+		log.Printf("⚠️ Erro ao remover códigos de recuperação do usuário %d: %v", user.ID, err)
+	}
+
+	log.Printf("🔓 MFA desativado para o usuário %d", user.ID)
+	return nil
+}
+
+// RegenerateRecoveryCodes invalida os códigos de recuperação atuais e gera um novo conjunto
+func (s *userService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+	if err := s.checkSecondFactor(user, code, ""); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(user.ID)
+}
+
+// issueChallenge emite o token de desafio que representa "senha validada, falta o segundo fator"
+func (s *userService) issueChallenge(user *domain.User) (*IssuedToken, error) {
+	token, expiresAt, err := s.tokens.Issue(auth.Claims{
+		Kind:             auth.KindMFAChallenge,
+		AMR:              []string{auth.AMRPassword},
+		RegisteredClaims: jwt.RegisteredClaims{Subject: strconv.FormatUint(uint64(user.ID), 10)},
+	}, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedToken{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// checkSecondFactor valida um código TOTP ou, na falta dele, um código de recuperação.
+// Falhas consecutivas bloqueiam temporariamente novas tentativas.
+func (s *userService) checkSecondFactor(user *domain.User, code, recoveryCode string) error {
+	if user.MFALockedUntil != nil && time.Now().Before(*user.MFALockedUntil) {
+		return ErrMFALocked
+	}
+
+	ok, err := s.matchSecondFactor(user, code, recoveryCode)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		user.MFAFailures++
+		if user.MFAFailures >= mfaMaxFailures {
+			lockedUntil := time.Now().Add(mfaLockout)
+			user.MFALockedUntil = &lockedUntil
+			user.MFAFailures = 0
+			log.Printf("🚫 MFA bloqueado até %s para o usuário %d", lockedUntil.Format(time.RFC3339), user.ID)
+		}
+		if err := s.userRepo.Update(user); err != nil {
+			log.Printf("⚠️ Erro ao registrar falha de MFA do usuário %d: %v", user.ID, err)
+		}
+		return ErrInvalidMFACode
+	}
+
+	user.MFAFailures = 0
+	user.MFALockedUntil = nil
+	return s.userRepo.Update(user)
+}
+
+// matchSecondFactor verifica o código TOTP (avançando o último passo usado) ou consome um código de recuperação
+func (s *userService) matchSecondFactor(user *domain.User, code, recoveryCode string) (bool, error) {
+	if code = strings.TrimSpace(code); len(code) == totpOptions.Digits {
+		key, err := totp.DecodeSecret(user.MFASecret)
+		if err != nil {
+			return false, err
+		}
+		if step, err := totpOptions.Validate(key, code, time.Now(), user.MFALastStep); err == nil {
+			user.MFALastStep = step
+			return true, nil
+		}
+	}
+
+	if recoveryCode = normalizeRecoveryCode(recoveryCode); recoveryCode != "" {
+		used, err := s.recoveryRepo.Consume(user.ID, hashRecoveryCode(recoveryCode))
+		if err != nil {
+			return false, err
+		}
+		if used {
+			remaining, _ := s.recoveryRepo.CountUnused(user.ID)
+			log.Printf("🧾 Código de recuperação usado pelo usuário %d (%d restantes)", user.ID, remaining)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// replaceRecoveryCodes gera novos códigos, persiste apenas os hashes e retorna os códigos em claro
+func (s *userService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, errors.New("falha ao gerar códigos de recuperação")
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(normalizeRecoveryCode(code)))
+	}
+
+	if err := s.recoveryRepo.Replace(userID, hashes); err != nil {
+		log.Printf("Erro ao salvar códigos de recuperação do usuário %d: %v", userID, err)
+		return nil, errors.New("falha ao salvar códigos de recuperação")
+	}
+	return codes, nil
+}
+
+// Alfabeto sem caracteres ambíguos (0/o, 1/l/i)
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// generateRecoveryCode gera um código no formato "xxxxx-xxxxx".
+// Bytes acima do maior múltiplo do alfabeto são descartados para evitar viés.
+func generateRecoveryCode() (string, error) {
+	const limit = 256 - 256%len(recoveryAlphabet)
+
+	code := make([]byte, 0, 10)
+	buf := make([]byte, 16)
+	for len(code) < cap(code) {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) < limit && len(code) < cap(code) {
+				code = append(code, recoveryAlphabet[int(b)%len(recoveryAlphabet)])
+			}
+		}
+	}
+	return string(code[:5]) + "-" + string(code[5:]), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
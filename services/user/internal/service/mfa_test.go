@@ -0,0 +1,247 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/repository"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/totp"
+)
+
+// memoryUserRepository guarda os usuários em memória; métodos não usados nos testes ficam na interface embutida
+type memoryUserRepository struct {
+	repository.UserRepository
+	users map[uint]*domain.User
+}
+
+func (r *memoryUserRepository) GetByID(id uint) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(email string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return r.GetByID(user.ID)
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *memoryUserRepository) Update(user *domain.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+// memoryRecoveryCodeRepository guarda os hashes dos códigos de recuperação e quais já foram usados
+type memoryRecoveryCodeRepository struct {
+	codes map[string]bool
+}
+
+func (r *memoryRecoveryCodeRepository) Replace(userID uint, hashes []string) error {
+	r.codes = make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		r.codes[hash] = false
+	}
+	return nil
+}
+
+func (r *memoryRecoveryCodeRepository) Consume(userID uint, hash string) (bool, error) {
+	used, ok := r.codes[hash]
+	if !ok || used {
+		return false, nil
+	}
+	r.codes[hash] = true
+	return true, nil
+}
+
+func (r *memoryRecoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	for _, used := range r.codes {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRecoveryCodeRepository) DeleteByUser(userID uint) error {
+	r.codes = nil
+	return nil
+}
+
+const mfaTestPassword = "senha-forte-123"
+
+// newMFAService cria o serviço com um usuário de MFA ativo e retorna a chave TOTP e os códigos de recuperação
+func newMFAService(t *testing.T) (*userService, *memoryUserRepository, []byte, []string) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(mfaTestPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	users := &memoryUserRepository{users: map[uint]*domain.User{
+		1: {ID: 1, Name: "Maria", Email: "maria@example.com", PasswordHash: string(hash)},
+	}}
+	tokens, err := auth.NewTokenManager("test-secret", "user-service")
+	require.NoError(t, err)
+
+	svc := NewUserService(users, nil, nil, &memoryRecoveryCodeRepository{}, tokens, time.Hour, "Loja").(*userService)
+
+	enrollment, err := svc.EnrollMFA(1)
+	require.NoError(t, err)
+	key, err := totp.DecodeSecret(enrollment.Secret)
+	require.NoError(t, err)
+
+	// O código de ativação consome o passo atual; os testes usam códigos de recuperação para logar
+	codes, err := svc.ConfirmMFA(1, totpOptions.CodeAt(key, time.Now()))
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+
+	return svc, users, key, codes
+}
+
+func challengeFor(t *testing.T, svc *userService) string {
+	t.Helper()
+
+	result, err := svc.Login("maria@example.com", mfaTestPassword)
+	require.NoError(t, err)
+	require.True(t, result.MFARequired())
+	return result.Challenge.AccessToken
+}
+
+func TestVerifyMFA_LockoutAfterFailures(t *testing.T) {
+	svc, users, key, codes := newMFAService(t)
+	challenge := challengeFor(t, svc)
+
+	for i := 1; i < mfaMaxFailures; i++ {
+		_, err := svc.VerifyMFA(challenge, wrongCode(key), "")
+		require.ErrorIs(t, err, ErrInvalidMFACode, "tentativa %d", i)
+	}
+	assert.Nil(t, users.users[1].MFALockedUntil)
+
+	_, err := svc.VerifyMFA(challenge, wrongCode(key), "")
+	require.ErrorIs(t, err, ErrInvalidMFACode)
+	require.NotNil(t, users.users[1].MFALockedUntil)
+	assert.WithinDuration(t, time.Now().Add(mfaLockout), *users.users[1].MFALockedUntil, time.Minute)
+
+	t.Run("bloqueio recusa até código de recuperação válido", func(t *testing.T) {
+		_, err := svc.VerifyMFA(challenge, "", codes[0])
+		assert.ErrorIs(t, err, ErrMFALocked)
+	})
+
+	t.Run("após o bloqueio o código volta a ser aceito", func(t *testing.T) {
+		expired := time.Now().Add(-time.Second)
+		users.users[1].MFALockedUntil = &expired
+
+		result, err := svc.VerifyMFA(challenge, "", codes[0])
+		require.NoError(t, err)
+		require.NotNil(t, result.AccessToken)
+		assert.Zero(t, users.users[1].MFAFailures)
+		assert.Nil(t, users.users[1].MFALockedUntil)
+	})
+}
+
+func TestVerifyMFA_RecoveryCodeSingleUse(t *testing.T) {
+	svc, _, _, codes := newMFAService(t)
+	challenge := challengeFor(t, svc)
+
+	result, err := svc.VerifyMFA(challenge, "", codes[1])
+	require.NoError(t, err)
+
+	claims, err := svc.tokens.Verify(result.AccessToken.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.HasMFA())
+
+	_, err = svc.VerifyMFA(challenge, "", codes[1])
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	// Outro código do mesmo conjunto continua válido, inclusive digitado com hífen e maiúsculas
+	_, err = svc.VerifyMFA(challenge, "", " "+strings.ToUpper(codes[2])+" ")
+	assert.NoError(t, err)
+}
+
+func TestDisableMFA_RequiresReauthentication(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		code     func(key []byte, codes []string) string
+		err      error
+	}{
+		{
+			name:     "senha incorreta",
+			password: "senha-errada",
+			code:     func(key []byte, codes []string) string { return codes[0] },
+			err:      ErrInvalidCredentials,
+		},
+		{
+			name:     "código inválido",
+			password: mfaTestPassword,
+			code:     func(key []byte, codes []string) string { return wrongCode(key) },
+			err:      ErrInvalidMFACode,
+		},
+		{
+			name:     "sem código",
+			password: mfaTestPassword,
+			code:     func(key []byte, codes []string) string { return "" },
+			err:      ErrInvalidMFACode,
+		},
+		{
+			name:     "senha e código de recuperação",
+			password: mfaTestPassword,
+			code:     func(key []byte, codes []string) string { return codes[0] },
+		},
+		{
+			name:     "senha e código TOTP do próximo passo",
+			password: mfaTestPassword,
+			code: func(key []byte, codes []string) string {
+				return totpOptions.CodeAt(key, time.Now().Add(totpOptions.Period))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, users, key, codes := newMFAService(t)
+
+			err := svc.DisableMFA(1, tt.password, tt.code(key, codes))
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.True(t, users.users[1].MFAEnabled)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.False(t, users.users[1].MFAEnabled)
+			assert.Empty(t, users.users[1].MFASecret)
+			assert.Nil(t, svc.recoveryRepo.(*memoryRecoveryCodeRepository).codes)
+		})
+	}
+}
+
+// wrongCode retorna um código de 6 dígitos que não vale em nenhuma janela tolerada
+func wrongCode(key []byte) string {
+	now := time.Now()
+	valid := map[string]bool{}
+	for delta := -totpOptions.Skew; delta <= totpOptions.Skew; delta++ {
+		valid[totpOptions.CodeAt(key, now.Add(time.Duration(delta)*totpOptions.Period))] = true
+	}
+	for _, code := range []string{"000000", "111111", "222222", "333333"} {
+		if !valid[code] {
+			return code
+		}
+	}
+	return "999999"
+}
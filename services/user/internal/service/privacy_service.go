@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/repository"
+)
+
+// Tempo máximo para a coleta ou anonimização em todos os serviços
+const privacyTimeout = 30 * time.Second
+
+// Nomes dos serviços registrados na trilha de auditoria
+const (
+	sourceUser         = "user-service"
+	sourceOrder        = "order-service"
+	sourcePayment      = "payment-service"
+	sourceNotification = "notification-service"
+)
+
+var ErrUserErased = errors.New("os dados deste usuário já foram eliminados")
+
+// PrivacyExport representa o arquivo JSON gerado para o titular
+type PrivacyExport struct {
+	RequestID   uint
+	FileName    string
+	ContentType string
+	Archive     []byte
+}
+
+// SourceResult registra o resultado de cada serviço em uma solicitação
+type SourceResult struct {
+	Status   string `json:"status"`
+	Records  int    `json:"records"`
+	Error    string `json:"error,omitempty"`
+	Retained string `json:"retained,omitempty"`
+}
+
+// exportArchive é o conteúdo do arquivo de exportação
+type exportArchive struct {
+	RequestID     uint                          `json:"request_id"`
+	GeneratedAt   time.Time                     `json:"generated_at"`
+	User          exportedUser                  `json:"user"`
+	Orders        []*clients.OrderRecord        `json:"orders"`
+	Payments      []*clients.PaymentRecord      `json:"payments"`
+	Notifications []*clients.NotificationRecord `json:"notifications"`
+	Sources       map[string]SourceResult       `json:"sources"`
+}
+
+type exportedUser struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Roles      []string   `json:"roles"`
+	MFAEnabled bool       `json:"mfa_enabled"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ErasedAt   *time.Time `json:"erased_at,omitempty"`
+}
+
+// PrivacyService define as solicitações do titular previstas na LGPD
+type PrivacyService interface {
+	ExportUserData(userID uint, requestedBy string) (*PrivacyExport, error)
+	EraseUser(userID uint, requestedBy, reason string) (*domain.PrivacyRequest, error)
+	ListRequests(userID uint) ([]domain.PrivacyRequest, error)
+}
+
+// privacyService implementa PrivacyService
+type privacyService struct {
+	userRepo      repository.UserRepository
+	recoveryRepo  repository.RecoveryCodeRepository
+	requestRepo   repository.PrivacyRequestRepository
+	orders        clients.OrderClient
+	payments      clients.PaymentClient
+	notifications clients.NotificationClient
+}
+
+// NewPrivacyService cria uma nova instância do serviço de privacidade
+func NewPrivacyService(
+	userRepo repository.UserRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	requestRepo repository.PrivacyRequestRepository,
+	orders clients.OrderClient,
+	payments clients.PaymentClient,
+	notifications clients.NotificationClient,
+) PrivacyService {
+	return &privacyService{
+		userRepo:      userRepo,
+		recoveryRepo:  recoveryRepo,
+		requestRepo:   requestRepo,
+		orders:        orders,
+		payments:      payments,
+		notifications: notifications,
+	}
+}
+
+// ExportUserData reúne os dados do titular em todos os serviços e monta o arquivo JSON.
+// Falhas de um serviço não impedem a exportação; ficam registradas em "sources".
+func (s *privacyService) ExportUserData(userID uint, requestedBy string) (*PrivacyExport, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	request, err := s.openRequest(user.ID, domain.PrivacyRequestExport, requestedBy, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), privacyTimeout)
+	defer cancel()
+
+	archive := exportArchive{
+		RequestID:   request.ID,
+		GeneratedAt: time.Now().UTC(),
+		User: exportedUser{
+			ID:         user.ID,
+			Name:       user.Name,
+			Email:      user.Email,
+			Roles:      user.RoleNames(),
+			MFAEnabled: user.MFAEnabled,
+			CreatedAt:  user.CreatedAt,
+			UpdatedAt:  user.UpdatedAt,
+			ErasedAt:   user.ErasedAt,
+		},
+		Sources: map[string]SourceResult{
+			sourceUser: {Status: "ok", Records: 1},
+		},
+	}
+
+	orders, err := s.orders.ExportCustomerData(ctx, customerIdentifiers(user))
+	archive.Orders = orders
+	archive.Sources[sourceOrder] = sourceResult(len(orders), err)
+
+	orderIDs := orderIDsOf(orders)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		payments, err := s.payments.ExportPaymentData(ctx, orderIDs)
+		mu.Lock()
+		defer mu.Unlock()
+		archive.Payments = payments
+		archive.Sources[sourcePayment] = sourceResult(len(payments), err)
+	}()
+	go func() {
+		defer wg.Done()
+		notifications, err := s.notifications.ExportNotifications(ctx, orderIDs)
+		mu.Lock()
+		defer mu.Unlock()
+		archive.Notifications = notifications
+		archive.Sources[sourceNotification] = sourceResult(len(notifications), err)
+	}()
+	wg.Wait()
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("falha ao gerar arquivo de exportação: %w", err)
+	}
+
+	s.closeRequest(request, archive.Sources)
+	log.Printf("📦 Exportação LGPD #%d concluída para o usuário %d (%s)", request.ID, user.ID, request.Status)
+
+	return &PrivacyExport{
+		RequestID:   request.ID,
+		FileName:    fmt.Sprintf("dados-usuario-%d-%s.json", user.ID, archive.GeneratedAt.Format("20060102T150405Z")),
+		ContentType: "application/json",
+		Archive:     data,
+	}, nil
+}
+
+// EraseUser anonimiza os dados pessoais do titular em todos os serviços.
+// Pedidos e pagamentos são mantidos (obrigação legal/fiscal) com o cliente pseudonimizado,
+// o mesmo pseudônimo nos dois serviços.
+// Os serviços remotos são processados antes do user-service para que uma falha
+// possa ser repetida: enquanto o usuário não é anonimizado seus identificadores continuam válidos.
+func (s *privacyService) EraseUser(userID uint, requestedBy, reason string) (*domain.PrivacyRequest, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.ErasedAt != nil {
+		return nil, ErrUserErased
+	}
+
+	request, err := s.openRequest(user.ID, domain.PrivacyRequestErase, requestedBy, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), privacyTimeout)
+	defer cancel()
+
+	sources := map[string]SourceResult{}
+	identifiers := customerIdentifiers(user)
+
+	// 1. Descobrir os pedidos do titular (as notificações são indexadas por pedido)
+	orders, err := s.orders.ExportCustomerData(ctx, identifiers)
+	if err != nil {
+		sources[sourceOrder] = sourceResult(0, err)
+		return s.failRequest(request, sources)
+	}
+	orderIDs := orderIDsOf(orders)
+
+	// 2. Remover o conteúdo das notificações
+	redacted, err := s.notifications.RedactNotifications(ctx, orderIDs)
+	sources[sourceNotification] = sourceResult(int(redacted), err)
+	if err != nil {
+		return s.failRequest(request, sources)
+	}
+
+	// 3. Pseudonimizar o cliente e apagar os sinais antifraude dos pagamentos
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.payments.AnonymizePayments(ctx, orderIDs, pseudonym)
+	if err != nil {
+		sources[sourcePayment] = sourceResult(0, err)
+		return s.failRequest(request, sources)
+	}
+	sources[sourcePayment] = SourceResult{
+		Status:   "ok",
+		Records:  int(payments.Affected),
+		Retained: "valores e status mantidos como registros financeiros",
+	}
+
+	// 4. Pseudonimizar o cliente dos pedidos
+	anonymized, err := s.orders.AnonymizeCustomer(ctx, identifiers, pseudonym)
+	if err != nil {
+		sources[sourceOrder] = sourceResult(0, err)
+		return s.failRequest(request, sources)
+	}
+	sources[sourceOrder] = SourceResult{
+		Status:   "ok",
+		Records:  int(anonymized.Affected),
+		Retained: "valores e quantidades mantidos para fins fiscais",
+	}
+
+	// 5. Anonimizar o próprio cadastro
+	if err := s.anonymizeUser(user); err != nil {
+		sources[sourceUser] = sourceResult(0, err)
+		return s.failRequest(request, sources)
+	}
+	sources[sourceUser] = SourceResult{Status: "ok", Records: 1}
+
+	s.closeRequest(request, sources)
+	log.Printf("🕶️ Eliminação LGPD #%d concluída para o usuário %d", request.ID, user.ID)
+	return request, nil
+}
+
+// ListRequests retorna a trilha de auditoria das solicitações do titular
+func (s *privacyService) ListRequests(userID uint) ([]domain.PrivacyRequest, error) {
+	requests, err := s.requestRepo.ListByUser(userID)
+	if err != nil {
+		log.Printf("Erro ao listar solicitações LGPD do usuário %d: %v", userID, err)
+		return nil, errors.New("falha ao buscar solicitações")
+	}
+	return requests, nil
+}
+
+// anonymizeUser remove os dados pessoais e credenciais do cadastro, mantendo o ID
+func (s *privacyService) anonymizeUser(user *domain.User) error {
+	now := time.Now()
+	user.Name = "Titular removido"
+	user.Email = fmt.Sprintf("removido-%d@anonimizado.invalid", user.ID)
+	user.PasswordHash = ""
+	user.MFAEnabled = false
+	user.MFASecret = ""
+	user.MFAPendingSecret = ""
+	user.MFAEnabledAt = nil
+	user.ErasedAt = &now
+
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	return s.recoveryRepo.DeleteByUser(user.ID)
+}
+
+func (s *privacyService) openRequest(userID uint, requestType, requestedBy, reason string) (*domain.PrivacyRequest, error) {
+	request := &domain.PrivacyRequest{
+		UserID:      userID,
+		Type:        requestType,
+		Status:      domain.PrivacyStatusProcessing,
+		RequestedBy: requestedBy,
+		Reason:      reason,
+	}
+	if err := s.requestRepo.Create(request); err != nil {
+		log.Printf("Erro ao registrar solicitação LGPD: %v", err)
+		return nil, errors.New("falha ao registrar solicitação")
+	}
+	return request, nil
+}
+
+func (s *privacyService) closeRequest(request *domain.PrivacyRequest, sources map[string]SourceResult) {
+	now := time.Now()
+	request.Status = domain.PrivacyStatusCompleted
+	for _, result := range sources {
+		if result.Error != "" {
+			request.Status = domain.PrivacyStatusFailed
+		}
+	}
+	request.CompletedAt = &now
+	if details, err := json.Marshal(sources); err == nil {
+		request.Details = string(details)
+	}
+	if err := s.requestRepo.Update(request); err != nil {
+		log.Printf("⚠️ Erro ao atualizar solicitação LGPD #%d: %v", request.ID, err)
+	}
+}
+
+// failRequest encerra a solicitação como falha para que possa ser repetida
+func (s *privacyService) failRequest(request *domain.PrivacyRequest, sources map[string]SourceResult) (*domain.PrivacyRequest, error) {
+	s.closeRequest(request, sources)
+	log.Printf("❌ Eliminação LGPD #%d interrompida: %s", request.ID, request.Details)
+	return request, fmt.Errorf("eliminação interrompida, solicitação #%d pode ser repetida", request.ID)
+}
+
+// customerIdentifiers retorna os valores que identificam o titular no campo Customer dos pedidos.
+// O nome não é usado por não ser único.
+func customerIdentifiers(user *domain.User) []string {
+	return []string{strconv.FormatUint(uint64(user.ID), 10), user.Email}
+}
+
+func orderIDsOf(orders []*clients.OrderRecord) []uint32 {
+	ids := make([]uint32, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
+
+func sourceResult(records int, err error) SourceResult {
+	if err != nil {
+		return SourceResult{Status: "error", Error: err.Error()}
+	}
+	return SourceResult{Status: "ok", Records: records}
+}
+
+// newPseudonym gera um identificador aleatório, sem relação com o titular e não registrado
+func newPseudonym() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "anon-" + hex.EncodeToString(buf), nil
+}
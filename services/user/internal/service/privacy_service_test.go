@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/clients"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+)
+
+// memoryPrivacyRequestRepository guarda a trilha de auditoria em memória
+type memoryPrivacyRequestRepository struct {
+	requests []*domain.PrivacyRequest
+}
+
+func (r *memoryPrivacyRequestRepository) Create(request *domain.PrivacyRequest) error {
+	request.ID = uint(len(r.requests) + 1)
+	r.requests = append(r.requests, request)
+	return nil
+}
+
+func (r *memoryPrivacyRequestRepository) Update(request *domain.PrivacyRequest) error {
+	return nil
+}
+
+func (r *memoryPrivacyRequestRepository) ListByUser(userID uint) ([]domain.PrivacyRequest, error) {
+	var requests []domain.PrivacyRequest
+	for _, request := range r.requests {
+		if request.UserID == userID {
+			requests = append(requests, *request)
+		}
+	}
+	return requests, nil
+}
+
+// stubOrderClient devolve os pedidos configurados e registra a anonimização
+type stubOrderClient struct {
+	orders       []*clients.OrderRecord
+	exportErr    error
+	anonymizeErr error
+	pseudonym    string
+}
+
+func (c *stubOrderClient) ExportCustomerData(ctx context.Context, customers []string) ([]*clients.OrderRecord, error) {
+	if c.exportErr != nil {
+		return nil, c.exportErr
+	}
+	return c.orders, nil
+}
+
+func (c *stubOrderClient) AnonymizeCustomer(ctx context.Context, customers []string, pseudonym string) (*clients.AnonymizeCustomerResponse, error) {
+	if c.anonymizeErr != nil {
+		return nil, c.anonymizeErr
+	}
+	c.pseudonym = pseudonym
+	return &clients.AnonymizeCustomerResponse{Affected: uint32(len(c.orders)), OrderIDs: orderIDsOf(c.orders)}, nil
+}
+
+// stubPaymentClient devolve os pagamentos configurados e registra a anonimização
+type stubPaymentClient struct {
+	payments     []*clients.PaymentRecord
+	exportErr    error
+	anonymizeErr error
+	orderIDs     []uint32
+	pseudonym    string
+}
+
+func (c *stubPaymentClient) ExportPaymentData(ctx context.Context, orderIDs []uint32) ([]*clients.PaymentRecord, error) {
+	if c.exportErr != nil {
+		return nil, c.exportErr
+	}
+	return c.payments, nil
+}
+
+func (c *stubPaymentClient) AnonymizePayments(ctx context.Context, orderIDs []uint32, pseudonym string) (*clients.AnonymizePaymentsResponse, error) {
+	if c.anonymizeErr != nil {
+		return nil, c.anonymizeErr
+	}
+	c.orderIDs, c.pseudonym = orderIDs, pseudonym
+	return &clients.AnonymizePaymentsResponse{Affected: uint32(len(c.payments))}, nil
+}
+
+// stubNotificationClient devolve as notificações configuradas e registra a remoção do conteúdo
+type stubNotificationClient struct {
+	notifications []*clients.NotificationRecord
+	exportErr     error
+	redactErr     error
+	redacted      []uint32
+}
+
+func (c *stubNotificationClient) ExportNotifications(ctx context.Context, orderIDs []uint32) ([]*clients.NotificationRecord, error) {
+	if c.exportErr != nil {
+		return nil, c.exportErr
+	}
+	return c.notifications, nil
+}
+
+func (c *stubNotificationClient) RedactNotifications(ctx context.Context, orderIDs []uint32) (uint32, error) {
+	if c.redactErr != nil {
+		return 0, c.redactErr
+	}
+	c.redacted = orderIDs
+	return uint32(len(c.notifications)), nil
+}
+
+type privacyFixture struct {
+	svc           PrivacyService
+	users         *memoryUserRepository
+	recovery      *memoryRecoveryCodeRepository
+	requests      *memoryPrivacyRequestRepository
+	orders        *stubOrderClient
+	payments      *stubPaymentClient
+	notifications *stubNotificationClient
+}
+
+func newPrivacyFixture() *privacyFixture {
+	f := &privacyFixture{
+		users: &memoryUserRepository{users: map[uint]*domain.User{
+			7: {ID: 7, Name: "Maria", Email: "maria@example.com", PasswordHash: "hash", MFAEnabled: true, MFASecret: "segredo"},
+		}},
+		recovery: &memoryRecoveryCodeRepository{codes: map[string]bool{"hash-1": false}},
+		requests: &memoryPrivacyRequestRepository{},
+		orders: &stubOrderClient{orders: []*clients.OrderRecord{
+			{ID: 10, Customer: "7", Quantity: 1, Price: 99.9},
+			{ID: 11, Customer: "maria@example.com", Quantity: 2, Price: 10},
+		}},
+		payments: &stubPaymentClient{payments: []*clients.PaymentRecord{
+			{ID: 20, OrderID: 10, Status: "approved", Amount: 99.9, Customer: "7", CardFingerprint: "fp_abc"},
+		}},
+		notifications: &stubNotificationClient{notifications: []*clients.NotificationRecord{{ID: 30}}},
+	}
+	f.svc = NewPrivacyService(f.users, f.recovery, f.requests, f.orders, f.payments, f.notifications)
+	return f
+}
+
+// sources lê o resultado por serviço gravado na trilha de auditoria
+func sources(t *testing.T, request *domain.PrivacyRequest) map[string]SourceResult {
+	t.Helper()
+	var result map[string]SourceResult
+	require.NoError(t, json.Unmarshal([]byte(request.Details), &result))
+	return result
+}
+
+func TestExportUserData(t *testing.T) {
+	t.Run("reúne os dados de todos os serviços", func(t *testing.T) {
+		f := newPrivacyFixture()
+
+		export, err := f.svc.ExportUserData(7, "maria@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", export.ContentType)
+		assert.Contains(t, export.FileName, "dados-usuario-7-")
+
+		var archive exportArchive
+		require.NoError(t, json.Unmarshal(export.Archive, &archive))
+		assert.Equal(t, "maria@example.com", archive.User.Email)
+		assert.True(t, archive.User.MFAEnabled)
+		assert.Len(t, archive.Orders, 2)
+		require.Len(t, archive.Payments, 1)
+		assert.Equal(t, "fp_abc", archive.Payments[0].CardFingerprint)
+		assert.Len(t, archive.Notifications, 1)
+		assert.NotContains(t, string(export.Archive), "hash", "credenciais não são exportadas")
+		assert.NotContains(t, string(export.Archive), "segredo")
+
+		assert.Equal(t, SourceResult{Status: "ok", Records: 2}, archive.Sources[sourceOrder])
+		assert.Equal(t, SourceResult{Status: "ok", Records: 1}, archive.Sources[sourcePayment])
+		assert.Equal(t, domain.PrivacyStatusCompleted, f.requests.requests[0].Status)
+	})
+
+	t.Run("falha de um serviço não impede a exportação", func(t *testing.T) {
+		f := newPrivacyFixture()
+		f.payments.exportErr = errors.New("payment-service indisponível")
+
+		export, err := f.svc.ExportUserData(7, "maria@example.com")
+		require.NoError(t, err)
+
+		var archive exportArchive
+		require.NoError(t, json.Unmarshal(export.Archive, &archive))
+		assert.Len(t, archive.Orders, 2)
+		assert.Empty(t, archive.Payments)
+		assert.Equal(t, "error", archive.Sources[sourcePayment].Status)
+		assert.Equal(t, "payment-service indisponível", archive.Sources[sourcePayment].Error)
+		assert.Equal(t, domain.PrivacyStatusFailed, f.requests.requests[0].Status)
+	})
+
+	t.Run("usuário inexistente", func(t *testing.T) {
+		f := newPrivacyFixture()
+		_, err := f.svc.ExportUserData(99, "admin")
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Empty(t, f.requests.requests)
+	})
+}
+
+func TestEraseUser_Cascade(t *testing.T) {
+	f := newPrivacyFixture()
+
+	request, err := f.svc.EraseUser(7, "maria@example.com", "pedido do titular")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrivacyStatusCompleted, request.Status)
+
+	assert.Equal(t, []uint32{10, 11}, f.notifications.redacted)
+	assert.Equal(t, []uint32{10, 11}, f.payments.orderIDs)
+	assert.NotEmpty(t, f.orders.pseudonym)
+	assert.Equal(t, f.orders.pseudonym, f.payments.pseudonym, "pedidos e pagamentos recebem o mesmo pseudônimo")
+
+	user := f.users.users[7]
+	assert.NotNil(t, user.ErasedAt)
+	assert.Equal(t, "removido-7@anonimizado.invalid", user.Email)
+	assert.Empty(t, user.PasswordHash)
+	assert.False(t, user.MFAEnabled)
+	assert.Empty(t, user.MFASecret)
+	assert.Nil(t, f.recovery.codes)
+
+	result := sources(t, request)
+	assert.Equal(t, 2, result[sourceOrder].Records)
+	assert.Equal(t, 1, result[sourcePayment].Records)
+	assert.Equal(t, 1, result[sourceNotification].Records)
+	assert.Equal(t, 1, result[sourceUser].Records)
+
+	_, err = f.svc.EraseUser(7, "maria@example.com", "")
+	assert.ErrorIs(t, err, ErrUserErased)
+}
+
+func TestEraseUser_PartialFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(f *privacyFixture)
+		source string
+		// Etapas seguintes à falha não podem ter sido executadas
+		check func(t *testing.T, f *privacyFixture)
+	}{
+		{
+			name:   "order-service fora na busca dos pedidos",
+			setup:  func(f *privacyFixture) { f.orders.exportErr = errors.New("order-service indisponível") },
+			source: sourceOrder,
+			check: func(t *testing.T, f *privacyFixture) {
+				assert.Nil(t, f.notifications.redacted)
+				assert.Empty(t, f.payments.pseudonym)
+			},
+		},
+		{
+			name:   "notification-service fora",
+			setup:  func(f *privacyFixture) { f.notifications.redactErr = errors.New("notification-service indisponível") },
+			source: sourceNotification,
+			check: func(t *testing.T, f *privacyFixture) {
+				assert.Empty(t, f.payments.pseudonym)
+				assert.Empty(t, f.orders.pseudonym)
+			},
+		},
+		{
+			name:   "payment-service fora",
+			setup:  func(f *privacyFixture) { f.payments.anonymizeErr = errors.New("payment-service indisponível") },
+			source: sourcePayment,
+			check: func(t *testing.T, f *privacyFixture) {
+				assert.Empty(t, f.orders.pseudonym)
+			},
+		},
+		{
+			name:   "order-service fora na anonimização",
+			setup:  func(f *privacyFixture) { f.orders.anonymizeErr = errors.New("order-service indisponível") },
+			source: sourceOrder,
+			check: func(t *testing.T, f *privacyFixture) {
+				assert.NotEmpty(t, f.payments.pseudonym)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPrivacyFixture()
+			tt.setup(f)
+
+			request, err := f.svc.EraseUser(7, "admin@loja.com", "pedido do titular")
+			require.Error(t, err)
+			require.NotNil(t, request)
+			assert.Equal(t, domain.PrivacyStatusFailed, request.Status)
+			assert.Equal(t, "error", sources(t, request)[tt.source].Status)
+			tt.check(t, f)
+
+			// O cadastro continua intacto para que a solicitação possa ser repetida
+			assert.Nil(t, f.users.users[7].ErasedAt)
+			assert.Equal(t, "maria@example.com", f.users.users[7].Email)
+		})
+	}
+}
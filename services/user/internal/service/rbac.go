@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+)
+
+// roleDefinition descreve um papel padrão e suas permissões
+type roleDefinition struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// defaultRoles são criados (ou sincronizados) a cada inicialização do serviço
+var defaultRoles = []roleDefinition{
+	{
+		Name:        domain.RoleAdmin,
+		Description: "Administrador com acesso total",
+		Permissions: []string{
+			auth.PermUsersRead, auth.PermUsersWrite, auth.PermRolesAdmin,
+			auth.PermOrdersRead, auth.PermOrdersWrite,
+			auth.PermPaymentsRead, auth.PermPaymentsWrite,
+			auth.PermNotificationsRead, auth.PermNotificationsManage,
+			auth.PermCatalogRead, auth.PermCatalogWrite,
+			auth.PermInventoryWrite,
+			auth.PermPromotionsManage,
+			auth.PermTaxesManage,
+			auth.PermPrivacy,
+			auth.PermFraudReview,
+		},
+	},
+	// O cliente final não chama pedidos, pagamentos e notificações diretamente: esses RPCs
+	// não filtram pelo chamador, e o BFF os acessa com a conta de serviço aplicando o
+	// escopo do usuário
+	{
+		Name:        domain.RoleUser,
+		Description: "Cliente final",
+		Permissions: []string{
+			auth.PermCatalogRead,
+		},
+	},
+	{
+		Name:        domain.RoleService,
+		Description: "Conta de serviço usada em chamadas internas (ex: BFF)",
+		Permissions: []string{
+			auth.PermUsersRead, auth.PermUsersWrite,
+			auth.PermOrdersRead, auth.PermOrdersWrite,
+			auth.PermPaymentsRead,
+			auth.PermNotificationsRead,
+			auth.PermCatalogRead, auth.PermInventoryWrite,
+		},
+	},
+}
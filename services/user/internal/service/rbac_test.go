@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultRole(t *testing.T, name string) *auth.Claims {
+	t.Helper()
+	for _, def := range defaultRoles {
+		if def.Name == name {
+			return &auth.Claims{Roles: []string{def.Name}, Permissions: def.Permissions}
+		}
+	}
+	require.FailNow(t, "papel padrão ausente", name)
+	return nil
+}
+
+// Os RPCs abaixo não filtram pelo chamador: um token de cliente que os alcançasse veria
+// ou alteraria os dados de todos os clientes
+func TestDefaultRoles_UserHasNoDirectAccess(t *testing.T) {
+	customer := defaultRole(t, domain.RoleUser)
+	service := defaultRole(t, domain.RoleService)
+
+	tests := []struct {
+		method     string
+		permission string
+	}{
+		{"/order.OrderService/CreateOrder", auth.PermOrdersWrite},
+		{"/order.OrderService/ListOrders", auth.PermOrdersRead},
+		{"/order.OrderService/CancelOrder", auth.PermOrdersWrite},
+		{"/payment.PaymentService/GetPayment", auth.PermPaymentsRead},
+		{"/payment.PaymentService/ListPayments", auth.PermPaymentsRead},
+		{"/payment.PaymentService/GetPaymentsByOrderIDs", auth.PermPaymentsRead},
+		{"/notification.NotificationService/ListNotifications", auth.PermNotificationsRead},
+		{"/notification.NotificationService/ListDeliveries", auth.PermNotificationsRead},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			assert.False(t, customer.HasPermission(tt.permission), "o cliente não chama %s diretamente", tt.method)
+			assert.True(t, service.HasPermission(tt.permission), "o BFF chama %s com a conta de serviço", tt.method)
+		})
+	}
+
+	assert.True(t, customer.HasPermission(auth.PermCatalogRead))
+}
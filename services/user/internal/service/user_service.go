@@ -0,0 +1,363 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/repository"
+)
+
+// Tempo de vida dos tokens de contas de serviço; os clientes renovam antes de expirar
+const serviceTokenTTL = 15 * time.Minute
+
+var (
+	ErrInvalidCredentials = errors.New("credenciais inválidas")
+	ErrUserNotFound       = errors.New("usuário não encontrado")
+	ErrRoleNotFound       = errors.New("papel não encontrado")
+)
+
+// IssuedToken representa um token de acesso emitido
+type IssuedToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// LoginResult representa o resultado de uma etapa de login.
+// Quando o usuário tem MFA habilitado, a senha gera apenas um Challenge que deve
+// ser trocado pelo token de acesso em VerifyMFA.
+type LoginResult struct {
+	User        *domain.User
+	AccessToken *IssuedToken
+	Challenge   *IssuedToken
+}
+
+// MFARequired indica que o login aguarda o segundo fator
+func (r *LoginResult) MFARequired() bool {
+	return r.Challenge != nil
+}
+
+// UserService define a interface para regras de negócio de usuários, papéis e contas de serviço
+type UserService interface {
+	CreateUser(name, email, password string) (*domain.User, error)
+	ListUsers() ([]domain.User, error)
+	GetUserByID(id uint) (*domain.User, error)
+	Login(email, password string) (*LoginResult, error)
+	VerifyMFA(challengeToken, code, recoveryCode string) (*LoginResult, error)
+	EnrollMFA(userID uint) (*MFAEnrollment, error)
+	ConfirmMFA(userID uint, code string) ([]string, error)
+	DisableMFA(userID uint, password, code string) error
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	AssignRole(userID uint, roleName string) (*domain.User, error)
+	RevokeRole(userID uint, roleName string) (*domain.User, error)
+	ListRoles() ([]domain.Role, error)
+	IssueServiceToken(clientID, clientSecret string) (*IssuedToken, error)
+	SeedAccessControl(serviceAccounts map[string]string) error
+	EnsureAdmin(name, email, password string) error
+}
+
+// userService implementa UserService
+type userService struct {
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	accountRepo  repository.ServiceAccountRepository
+	recoveryRepo repository.RecoveryCodeRepository
+	tokens       *auth.TokenManager
+	tokenTTL     time.Duration
+	mfaIssuer    string
+}
+
+// NewUserService cria uma nova instância do serviço de usuários
+func NewUserService(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	accountRepo repository.ServiceAccountRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	tokens *auth.TokenManager,
+	tokenTTL time.Duration,
+	mfaIssuer string,
+) UserService {
+	return &userService{
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		accountRepo:  accountRepo,
+		recoveryRepo: recoveryRepo,
+		tokens:       tokens,
+		tokenTTL:     tokenTTL,
+		mfaIssuer:    mfaIssuer,
+	}
+}
+
+// CreateUser cria um novo usuário com o papel USER.
+// A senha é opcional: usuários sem senha não conseguem fazer login até que uma seja definida.
+func (s *userService) CreateUser(name, email, password string) (*domain.User, error) {
+	name = strings.TrimSpace(name)
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if name == "" {
+		return nil, errors.New("nome é obrigatório")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, errors.New("e-mail inválido")
+	}
+	if password != "" && len(password) < 8 {
+		return nil, errors.New("senha deve ter pelo menos 8 caracteres")
+	}
+
+	if _, err := s.userRepo.GetByEmail(email); err == nil {
+		return nil, errors.New("e-mail já cadastrado")
+	}
+
+	role, err := s.roleRepo.GetByName(domain.RoleUser)
+	if err != nil {
+		log.Printf("Erro ao buscar papel %s: %v", domain.RoleUser, err)
+		return nil, ErrRoleNotFound
+	}
+
+	user := &domain.User{
+		Name:  name,
+		Email: email,
+		Roles: []domain.Role{*role},
+	}
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, errors.New("falha ao processar senha")
+		}
+		user.PasswordHash = string(hash)
+	}
+
+	if err := s.userRepo.Create(user); err != nil {
+		log.Printf("Erro ao criar usuário: %v", err)
+		return nil, errors.New("falha ao salvar usuário")
+	}
+
+	log.Printf("✅ Usuário criado com sucesso: ID=%d, Email=%s", user.ID, user.Email)
+	return user, nil
+}
+
+// ListUsers retorna todos os usuários
+func (s *userService) ListUsers() ([]domain.User, error) {
+	users, err := s.userRepo.List()
+	if err != nil {
+		log.Printf("Erro ao listar usuários: %v", err)
+		return nil, errors.New("falha ao buscar usuários")
+	}
+
+	log.Printf("📋 Listados %d usuários", len(users))
+	return users, nil
+}
+
+// GetUserByID retorna um usuário específico pelo ID
+func (s *userService) GetUserByID(id uint) (*domain.User, error) {
+	if id == 0 {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		log.Printf("Erro ao buscar usuário %d: %v", id, err)
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// Login valida e-mail e senha. Sem MFA o token de acesso é emitido direto;
+// com MFA habilitado é emitido um token de desafio para VerifyMFA.
+func (s *userService) Login(email, password string) (*LoginResult, error) {
+	user, err := s.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if err := s.checkPassword(user, password); err != nil {
+		log.Printf("🔒 Falha de login para %s", user.Email)
+		return nil, err
+	}
+
+	if user.MFAEnabled {
+		challenge, err := s.issueChallenge(user)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("🔐 Segundo fator exigido para %s", user.Email)
+		return &LoginResult{User: user, Challenge: challenge}, nil
+	}
+
+	token, err := s.issueAccessToken(user, auth.AMRPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔑 Login realizado: %s", user.Email)
+	return &LoginResult{User: user, AccessToken: token}, nil
+}
+
+// checkPassword compara a senha informada com o hash armazenado
+func (s *userService) checkPassword(user *domain.User, password string) error {
+	if user.PasswordHash == "" {
+		return ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// issueAccessToken emite o token de acesso com papéis, permissões e métodos de autenticação usados
+func (s *userService) issueAccessToken(user *domain.User, amr ...string) (*IssuedToken, error) {
+	token, expiresAt, err := s.tokens.Issue(auth.Claims{
+		Email:            user.Email,
+		Roles:            user.RoleNames(),
+		Permissions:      user.PermissionNames(),
+		Kind:             auth.KindUser,
+		AMR:              amr,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: strconv.FormatUint(uint64(user.ID), 10)},
+	}, s.tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedToken{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// AssignRole atribui um papel existente ao usuário
+func (s *userService) AssignRole(userID uint, roleName string) (*domain.User, error) {
+	user, role, err := s.userAndRole(userID, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.AddRole(user, role); err != nil {
+		log.Printf("Erro ao atribuir papel %s ao usuário %d: %v", role.Name, user.ID, err)
+		return nil, errors.New("falha ao atribuir papel")
+	}
+
+	log.Printf("🛡️ Papel %s atribuído ao usuário %d", role.Name, user.ID)
+	return s.GetUserByID(userID)
+}
+
+// RevokeRole remove um papel do usuário
+func (s *userService) RevokeRole(userID uint, roleName string) (*domain.User, error) {
+	user, role, err := s.userAndRole(userID, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.RemoveRole(user, role); err != nil {
+		log.Printf("Erro ao remover papel %s do usuário %d: %v", role.Name, user.ID, err)
+		return nil, errors.New("falha ao remover papel")
+	}
+
+	log.Printf("🛡️ Papel %s removido do usuário %d", role.Name, user.ID)
+	return s.GetUserByID(userID)
+}
+
+// ListRoles retorna os papéis cadastrados e suas permissões
+func (s *userService) ListRoles() ([]domain.Role, error) {
+	roles, err := s.roleRepo.List()
+	if err != nil {
+		log.Printf("Erro ao listar papéis: %v", err)
+		return nil, errors.New("falha ao buscar papéis")
+	}
+	return roles, nil
+}
+
+// IssueServiceToken autentica uma conta de serviço (client credentials) e emite um token de curta duração
+func (s *userService) IssueServiceToken(clientID, clientSecret string) (*IssuedToken, error) {
+	account, err := s.accountRepo.GetByName(clientID)
+	if err != nil || !account.Active {
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(account.SecretHash), []byte(clientSecret)); err != nil {
+		log.Printf("🔒 Segredo inválido para conta de serviço %s", clientID)
+		return nil, ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.tokens.Issue(auth.Claims{
+		Roles:            account.RoleNames(),
+		Permissions:      account.PermissionNames(),
+		Kind:             auth.KindService,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: account.Name},
+	}, serviceTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🤖 Token de serviço emitido para %s", account.Name)
+	return &IssuedToken{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// SeedAccessControl sincroniza os papéis padrão e as contas de serviço configuradas
+func (s *userService) SeedAccessControl(serviceAccounts map[string]string) error {
+	roles := make(map[string]*domain.Role, len(defaultRoles))
+	for _, def := range defaultRoles {
+		role, err := s.roleRepo.Ensure(def.Name, def.Description, def.Permissions)
+		if err != nil {
+			return err
+		}
+		roles[def.Name] = role
+	}
+
+	for name, secret := range serviceAccounts {
+		hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		account := &domain.ServiceAccount{
+			Name:       name,
+			SecretHash: string(hash),
+			Active:     true,
+			Roles:      []domain.Role{*roles[domain.RoleService]},
+		}
+		if err := s.accountRepo.Upsert(account); err != nil {
+			return err
+		}
+		log.Printf("🤖 Conta de serviço sincronizada: %s", name)
+	}
+
+	log.Printf("🛡️ %d papéis padrão sincronizados", len(roles))
+	return nil
+}
+
+// EnsureAdmin garante a existência de um administrador inicial para bootstrap do RBAC
+func (s *userService) EnsureAdmin(name, email, password string) error {
+	user, err := s.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if user, err = s.CreateUser(name, email, password); err != nil {
+			return err
+		}
+	}
+
+	for _, role := range user.Roles {
+		if role.Name == domain.RoleAdmin {
+			return nil
+		}
+	}
+
+	_, err = s.AssignRole(user.ID, domain.RoleAdmin)
+	return err
+}
+
+func (s *userService) userAndRole(userID uint, roleName string) (*domain.User, *domain.Role, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role, err := s.roleRepo.GetByName(strings.ToUpper(strings.TrimSpace(roleName)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, role, nil
+}
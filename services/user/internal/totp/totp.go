@@ -0,0 +1,156 @@
+// Package totp implementa senhas de uso único baseadas em tempo (RFC 6238 / RFC 4226)
+// compatíveis com Google Authenticator, Authy e similares.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Algorithm identifica a função HMAC usada no cálculo do código
+type Algorithm string
+
+const (
+	SHA1   Algorithm = "SHA1"
+	SHA256 Algorithm = "SHA256"
+	SHA512 Algorithm = "SHA512"
+)
+
+// Parâmetros padrão suportados pelos aplicativos autenticadores
+const (
+	DefaultPeriod     = 30 * time.Second
+	DefaultDigits     = 6
+	DefaultSecretSize = 20
+)
+
+var (
+	ErrInvalidSecret = errors.New("segredo TOTP inválido")
+	ErrInvalidCode   = errors.New("código TOTP inválido")
+)
+
+// Options define os parâmetros do gerador
+type Options struct {
+	Period    time.Duration
+	Digits    int
+	Algorithm Algorithm
+	// Skew é o número de janelas aceitas antes e depois da atual (tolerância de relógio)
+	Skew int
+}
+
+// DefaultOptions retorna SHA1, 6 dígitos, janelas de 30s e tolerância de uma janela
+func DefaultOptions() Options {
+	return Options{
+		Period:    DefaultPeriod,
+		Digits:    DefaultDigits,
+		Algorithm: SHA1,
+		Skew:      1,
+	}
+}
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret gera um novo segredo aleatório codificado em base32 (sem padding)
+func GenerateSecret() (string, error) {
+	secret := make([]byte, DefaultSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// DecodeSecret decodifica um segredo base32, ignorando espaços e caixa
+func DecodeSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := encoding.DecodeString(strings.TrimRight(normalized, "="))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// Step retorna o contador de tempo (T) para o instante informado
+func (o Options) Step(t time.Time) int64 {
+	return t.Unix() / int64(o.Period/time.Second)
+}
+
+// Code calcula o código para um contador (HOTP, RFC 4226)
+func (o Options) Code(key []byte, step int64) string {
+	mac := hmac.New(o.hash(), key)
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Truncamento dinâmico
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < o.Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", o.Digits, value%mod)
+}
+
+// CodeAt calcula o código TOTP para o instante informado
+func (o Options) CodeAt(key []byte, t time.Time) string {
+	return o.Code(key, o.Step(t))
+}
+
+// Validate verifica o código considerando a tolerância de relógio e retorna o contador
+// correspondente. Códigos de contadores <= lastStep são rejeitados para impedir reuso.
+func (o Options) Validate(key []byte, code string, t time.Time, lastStep int64) (int64, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != o.Digits {
+		return 0, ErrInvalidCode
+	}
+
+	current := o.Step(t)
+	for delta := -o.Skew; delta <= o.Skew; delta++ {
+		step := current + int64(delta)
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(o.Code(key, step)), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+	return 0, ErrInvalidCode
+}
+
+// URI monta a URI otpauth:// usada para gerar o QR code de cadastro
+func (o Options) URI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", string(o.Algorithm))
+	params.Set("digits", fmt.Sprint(o.Digits))
+	params.Set("period", fmt.Sprint(int64(o.Period/time.Second)))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func (o Options) hash() func() hash.Hash {
+	switch o.Algorithm {
+	case SHA256:
+		return sha256.New
+	case SHA512:
+		return sha512.New
+	default:
+		return sha1.New
+	}
+}
@@ -0,0 +1,94 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Sementes do Apêndice B da RFC 6238
+var rfcSeeds = map[Algorithm][]byte{
+	SHA1:   []byte("12345678901234567890"),
+	SHA256: []byte("12345678901234567890123456789012"),
+	SHA512: []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+}
+
+func TestCodeAt_RFC6238Vectors(t *testing.T) {
+	testCases := []struct {
+		unix      int64
+		algorithm Algorithm
+		code      string
+	}{
+		{59, SHA1, "94287082"},
+		{59, SHA256, "46119246"},
+		{59, SHA512, "90693936"},
+		{1111111109, SHA1, "07081804"},
+		{1111111109, SHA256, "68084774"},
+		{1111111109, SHA512, "25091201"},
+		{1111111111, SHA1, "14050471"},
+		{1111111111, SHA256, "67062674"},
+		{1111111111, SHA512, "99943326"},
+		{1234567890, SHA1, "89005924"},
+		{1234567890, SHA256, "91819424"},
+		{1234567890, SHA512, "93441116"},
+		{2000000000, SHA1, "69279037"},
+		{2000000000, SHA256, "90698825"},
+		{2000000000, SHA512, "38618901"},
+		{20000000000, SHA1, "65353130"},
+		{20000000000, SHA256, "77737706"},
+		{20000000000, SHA512, "47863826"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.algorithm)+"/"+time.Unix(tc.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			opts := Options{Period: 30 * time.Second, Digits: 8, Algorithm: tc.algorithm}
+			assert.Equal(t, tc.code, opts.CodeAt(rfcSeeds[tc.algorithm], time.Unix(tc.unix, 0)))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	opts := DefaultOptions()
+	key := rfcSeeds[SHA1]
+	now := time.Unix(1111111111, 0)
+
+	code := opts.CodeAt(key, now)
+	step, err := opts.Validate(key, code, now, 0)
+	require.NoError(t, err)
+	assert.Equal(t, opts.Step(now), step)
+
+	// Tolerância de uma janela para relógios dessincronizados
+	_, err = opts.Validate(key, opts.CodeAt(key, now.Add(-30*time.Second)), now, 0)
+	assert.NoError(t, err)
+	_, err = opts.Validate(key, opts.CodeAt(key, now.Add(-90*time.Second)), now, 0)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	// Código já utilizado não pode ser reaproveitado
+	_, err = opts.Validate(key, code, now, step)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = opts.Validate(key, "12345", now, 0)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	key, err := DecodeSecret(strings.ToLower(secret))
+	require.NoError(t, err)
+	assert.Len(t, key, DefaultSecretSize)
+
+	uri := DefaultOptions().URI("Go Microservices", "admin@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Go%20Microservices:admin@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=Go+Microservices")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+
+	_, err = DecodeSecret("não-base32!")
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import "github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+
+// MethodPermissions declara a permissão exigida por cada RPC do UserService.
+// Login, VerifyMFA e IssueServiceToken são públicos pois são eles que emitem os tokens;
+// os RPCs de MFA atuam sobre o próprio usuário autenticado. Mudanças de papéis e a
+// anonimização exigem login com segundo fator.
+var MethodPermissions = auth.Policy{
+	"/user.UserService/CreateUser":        {Permission: auth.PermUsersWrite},
+	"/user.UserService/ListUsers":         {Permission: auth.PermUsersRead},
+	"/user.UserService/GetUser":           {Permission: auth.PermUsersRead},
+	"/user.UserService/Login":             {Permission: auth.Public},
+	"/user.UserService/AssignRole":        {Permission: auth.PermRolesAdmin, RequireMFA: true},
+	"/user.UserService/RevokeRole":        {Permission: auth.PermRolesAdmin, RequireMFA: true},
+	"/user.UserService/ListRoles":         {Permission: auth.PermRolesAdmin},
+	"/user.UserService/IssueServiceToken": {Permission: auth.Public},
+
+	"/user.UserService/EnrollMFA":               {Permission: auth.Authenticated},
+	"/user.UserService/ConfirmMFA":              {Permission: auth.Authenticated},
+	"/user.UserService/VerifyMFA":               {Permission: auth.Public},
+	"/user.UserService/DisableMFA":              {Permission: auth.Authenticated},
+	"/user.UserService/RegenerateRecoveryCodes": {Permission: auth.Authenticated},
+
+	// O próprio titular pode exportar seus dados; terceiros precisam de privacy:manage
+	"/user.UserService/ExportUserData":      {Permission: auth.Authenticated},
+	"/user.UserService/EraseUser":           {Permission: auth.PermPrivacy, RequireMFA: true},
+	"/user.UserService/ListPrivacyRequests": {Permission: auth.PermPrivacy},
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/proto"
+)
+
+// ExportUserData gera o arquivo JSON com os dados do titular em todos os serviços
+func (s *UserGRPCServer) ExportUserData(ctx context.Context, req *proto.ExportUserDataRequest) (*proto.ExportUserDataResponse, error) {
+	caller := auth.CallerFromContext(ctx)
+	userID := uint(req.GetUserId())
+	if !caller.HasPermission(auth.PermPrivacy) && !isSelf(caller, userID) {
+		return nil, status.Error(codes.PermissionDenied, "apenas o titular ou o encarregado de dados podem exportar")
+	}
+
+	log.Printf("📦 Recebida requisição ExportUserData: UserID=%d, Solicitante=%s", userID, caller.Subject)
+
+	export, err := s.privacyService.ExportUserData(userID, requester(caller))
+	if err != nil {
+		return nil, privacyError(err)
+	}
+
+	return &proto.ExportUserDataResponse{
+		RequestId:   uint32(export.RequestID),
+		FileName:    export.FileName,
+		ContentType: export.ContentType,
+		Archive:     export.Archive,
+	}, nil
+}
+
+// EraseUser anonimiza os dados pessoais do titular em todos os serviços
+func (s *UserGRPCServer) EraseUser(ctx context.Context, req *proto.EraseUserRequest) (*proto.PrivacyRequestResponse, error) {
+	caller := auth.CallerFromContext(ctx)
+	log.Printf("🕶️ Recebida requisição EraseUser: UserID=%d, Solicitante=%s", req.GetUserId(), caller.Subject)
+
+	request, err := s.privacyService.EraseUser(uint(req.GetUserId()), requester(caller), req.GetReason())
+	if err != nil {
+		return nil, privacyError(err)
+	}
+	return toPrivacyRequestResponse(request), nil
+}
+
+// ListPrivacyRequests retorna a trilha de auditoria LGPD de um titular
+func (s *UserGRPCServer) ListPrivacyRequests(ctx context.Context, req *proto.ListPrivacyRequestsRequest) (*proto.ListPrivacyRequestsResponse, error) {
+	requests, err := s.privacyService.ListRequests(uint(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &proto.ListPrivacyRequestsResponse{}
+	for i := range requests {
+		response.Requests = append(response.Requests, toPrivacyRequestResponse(&requests[i]))
+	}
+	return response, nil
+}
+
+func toPrivacyRequestResponse(request *domain.PrivacyRequest) *proto.PrivacyRequestResponse {
+	response := &proto.PrivacyRequestResponse{
+		Id:          uint32(request.ID),
+		UserId:      uint32(request.UserID),
+		Type:        request.Type,
+		Status:      request.Status,
+		RequestedBy: request.RequestedBy,
+		Reason:      request.Reason,
+		Details:     request.Details,
+		CreatedAt:   request.CreatedAt.Format(time.RFC3339),
+	}
+	if request.CompletedAt != nil {
+		response.CompletedAt = request.CompletedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// isSelf indica se o chamador é o próprio usuário titular
+func isSelf(caller *auth.Claims, userID uint) bool {
+	return caller.Kind == auth.KindUser && caller.Subject == strconv.FormatUint(uint64(userID), 10)
+}
+
+// requester identifica o solicitante na trilha de auditoria (ex: "user:1", "service:bff-graphql")
+func requester(caller *auth.Claims) string {
+	return caller.Kind + ":" + caller.Subject
+}
+
+// privacyError traduz erros das solicitações LGPD para códigos gRPC
+func privacyError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrUserErased):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
@@ -0,0 +1,278 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seu-usuario/go-microservices-architecture/services/shared/auth"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/domain"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/internal/service"
+	"github.com/seu-usuario/go-microservices-architecture/services/user/proto"
+)
+
+// UserGRPCServer implementa o servidor gRPC para usuários
+type UserGRPCServer struct {
+	proto.UnimplementedUserServiceServer
+	userService    service.UserService
+	privacyService service.PrivacyService
+}
+
+// NewUserGRPCServer cria uma nova instância do servidor gRPC
+func NewUserGRPCServer(userService service.UserService, privacyService service.PrivacyService) *UserGRPCServer {
+	return &UserGRPCServer{
+		userService:    userService,
+		privacyService: privacyService,
+	}
+}
+
+// CreateUser cria um novo usuário via gRPC
+func (s *UserGRPCServer) CreateUser(ctx context.Context, req *proto.UserRequest) (*proto.UserResponse, error) {
+	log.Printf("📝 Recebida requisição CreateUser: Name=%s, Email=%s", req.GetName(), req.GetEmail())
+
+	user, err := s.userService.CreateUser(req.GetName(), req.GetEmail(), req.GetPassword())
+	if err != nil {
+		log.Printf("❌ Erro ao criar usuário: %v", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toUserResponse(user), nil
+}
+
+// ListUsers lista todos os usuários via gRPC
+func (s *UserGRPCServer) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	log.Println("📋 Recebida requisição ListUsers")
+
+	users, err := s.userService.ListUsers()
+	if err != nil {
+		log.Printf("❌ Erro ao listar usuários: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var userResponses []*proto.UserResponse
+	for i := range users {
+		userResponses = append(userResponses, toUserResponse(&users[i]))
+	}
+
+	return &proto.ListUsersResponse{Users: userResponses}, nil
+}
+
+// GetUser busca um usuário pelo ID via gRPC
+func (s *UserGRPCServer) GetUser(ctx context.Context, req *proto.GetUserRequest) (*proto.UserResponse, error) {
+	user, err := s.userService.GetUserByID(uint(req.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toUserResponse(user), nil
+}
+
+// Login autentica um usuário final e retorna um token de acesso ou, com MFA, um token de desafio
+func (s *UserGRPCServer) Login(ctx context.Context, req *proto.LoginRequest) (*proto.LoginResponse, error) {
+	result, err := s.userService.Login(req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, authError(err)
+	}
+	return toLoginResponse(result), nil
+}
+
+// VerifyMFA conclui o login com o segundo fator
+func (s *UserGRPCServer) VerifyMFA(ctx context.Context, req *proto.VerifyMFARequest) (*proto.LoginResponse, error) {
+	result, err := s.userService.VerifyMFA(req.GetChallengeToken(), req.GetCode(), req.GetRecoveryCode())
+	if err != nil {
+		return nil, authError(err)
+	}
+	return toLoginResponse(result), nil
+}
+
+// EnrollMFA inicia o cadastro de TOTP para o usuário autenticado
+func (s *UserGRPCServer) EnrollMFA(ctx context.Context, req *proto.EnrollMFARequest) (*proto.EnrollMFAResponse, error) {
+	userID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.userService.EnrollMFA(userID)
+	if err != nil {
+		return nil, authError(err)
+	}
+	return &proto.EnrollMFAResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.OTPAuthURI,
+	}, nil
+}
+
+// ConfirmMFA ativa o MFA e retorna os códigos de recuperação
+func (s *UserGRPCServer) ConfirmMFA(ctx context.Context, req *proto.ConfirmMFARequest) (*proto.RecoveryCodesResponse, error) {
+	userID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.userService.ConfirmMFA(userID, req.GetCode())
+	if err != nil {
+		return nil, authError(err)
+	}
+	return &proto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA desativa o MFA do usuário autenticado após reautenticação
+func (s *UserGRPCServer) DisableMFA(ctx context.Context, req *proto.DisableMFARequest) (*proto.DisableMFAResponse, error) {
+	userID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.DisableMFA(userID, req.GetPassword(), req.GetCode()); err != nil {
+		return nil, authError(err)
+	}
+	return &proto.DisableMFAResponse{}, nil
+}
+
+// RegenerateRecoveryCodes substitui os códigos de recuperação do usuário autenticado
+func (s *UserGRPCServer) RegenerateRecoveryCodes(ctx context.Context, req *proto.RegenerateRecoveryCodesRequest) (*proto.RecoveryCodesResponse, error) {
+	userID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.userService.RegenerateRecoveryCodes(userID, req.GetCode())
+	if err != nil {
+		return nil, authError(err)
+	}
+	return &proto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// AssignRole atribui um papel a um usuário via gRPC
+func (s *UserGRPCServer) AssignRole(ctx context.Context, req *proto.RoleAssignmentRequest) (*proto.UserResponse, error) {
+	user, err := s.userService.AssignRole(uint(req.GetUserId()), req.GetRole())
+	if err != nil {
+		return nil, roleError(err)
+	}
+	return toUserResponse(user), nil
+}
+
+// RevokeRole remove um papel de um usuário via gRPC
+func (s *UserGRPCServer) RevokeRole(ctx context.Context, req *proto.RoleAssignmentRequest) (*proto.UserResponse, error) {
+	user, err := s.userService.RevokeRole(uint(req.GetUserId()), req.GetRole())
+	if err != nil {
+		return nil, roleError(err)
+	}
+	return toUserResponse(user), nil
+}
+
+// ListRoles lista os papéis e permissões via gRPC
+func (s *UserGRPCServer) ListRoles(ctx context.Context, req *proto.ListRolesRequest) (*proto.ListRolesResponse, error) {
+	roles, err := s.userService.ListRoles()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &proto.ListRolesResponse{}
+	for _, role := range roles {
+		permissions := make([]string, 0, len(role.Permissions))
+		for _, permission := range role.Permissions {
+			permissions = append(permissions, permission.Name)
+		}
+		response.Roles = append(response.Roles, &proto.RoleResponse{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: permissions,
+		})
+	}
+	return response, nil
+}
+
+// IssueServiceToken emite um token para uma conta de serviço (client credentials)
+func (s *UserGRPCServer) IssueServiceToken(ctx context.Context, req *proto.ServiceTokenRequest) (*proto.ServiceTokenResponse, error) {
+	token, err := s.userService.IssueServiceToken(req.GetClientId(), req.GetClientSecret())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		log.Printf("❌ Erro ao emitir token de serviço: %v", err)
+		return nil, status.Error(codes.Internal, "falha ao emitir token")
+	}
+
+	return &proto.ServiceTokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// toUserResponse converte o domínio para a mensagem gRPC
+func toUserResponse(user *domain.User) *proto.UserResponse {
+	return &proto.UserResponse{
+		Id:        uint32(user.ID),
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt.Format(time.RFC3339),
+		Roles:     user.RoleNames(),
+	}
+}
+
+// toLoginResponse converte o resultado do login para a mensagem gRPC
+func toLoginResponse(result *service.LoginResult) *proto.LoginResponse {
+	response := &proto.LoginResponse{
+		TokenType: "Bearer",
+		User:      toUserResponse(result.User),
+	}
+	if result.MFARequired() {
+		response.MfaRequired = true
+		response.ChallengeToken = result.Challenge.AccessToken
+		response.ExpiresAt = result.Challenge.ExpiresAt.Format(time.RFC3339)
+		return response
+	}
+	response.AccessToken = result.AccessToken.AccessToken
+	response.ExpiresAt = result.AccessToken.ExpiresAt.Format(time.RFC3339)
+	return response
+}
+
+// callerUserID retorna o ID do usuário final autenticado; contas de serviço não gerenciam MFA
+func callerUserID(ctx context.Context) (uint, error) {
+	caller := auth.CallerFromContext(ctx)
+	if caller == nil || caller.Kind != auth.KindUser {
+		return 0, status.Error(codes.PermissionDenied, "operação disponível apenas para usuários")
+	}
+	id, err := strconv.ParseUint(caller.Subject, 10, 64)
+	if err != nil {
+		return 0, status.Error(codes.Unauthenticated, "identificador de usuário inválido no token")
+	}
+	return uint(id), nil
+}
+
+// authError traduz erros de login e MFA para códigos gRPC
+func authError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials),
+		errors.Is(err, service.ErrInvalidMFACode),
+		errors.Is(err, service.ErrInvalidChallenge):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrMFALocked):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrMFANotEnabled),
+		errors.Is(err, service.ErrMFAAlreadyEnabled),
+		errors.Is(err, service.ErrMFANotEnrolled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		log.Printf("❌ Erro de autenticação: %v", err)
+		return status.Error(codes.Internal, "falha ao processar autenticação")
+	}
+}
+
+// roleError traduz erros de atribuição de papéis para códigos gRPC
+func roleError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrRoleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}